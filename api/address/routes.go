@@ -1,19 +1,34 @@
 package address
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/numbatx/gn-numbat/api/errors"
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/proof"
+	"github.com/numbatx/gn-numbat/node/external"
 )
 
+// defaultStoragePageSize is the number of storage entries returned when no limit query parameter is provided
+const defaultStoragePageSize = 100
+
+// maxStoragePageSize is the highest number of storage entries that can be requested in a page
+const maxStoragePageSize = 1000
+
 // FacadeHandler interface defines methods that can be used from `numbatFacade` context variable
 type FacadeHandler interface {
 	GetBalance(address string) (*big.Int, error)
 	GetAccount(address string) (*state.Account, error)
+	GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error)
+	GetAccountAtBlockHash(address string, blockHash string) (*state.Account, error)
+	GetAccountProof(address string) (*proof.AccountProof, error)
+	GetAccountStorageValue(address string, key string) (*external.StorageValue, error)
+	GetAccountStorage(address string, startKey string, maxEntries int) (*external.StoragePage, error)
 }
 
 type accountResponse struct {
@@ -24,15 +39,55 @@ type accountResponse struct {
 	RootHash []byte `json:"rootHash"`
 }
 
+type accountProofResponse struct {
+	Address    string   `json:"address"`
+	Value      string   `json:"value"`
+	Proof      []string `json:"proof"`
+	RootHash   string   `json:"rootHash"`
+	BlockNonce uint64   `json:"blockNonce"`
+	BlockHash  string   `json:"blockHash"`
+}
+
+type storageValueResponse struct {
+	Address  string `json:"address"`
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	RootHash string `json:"rootHash"`
+}
+
+type storageEntryResponse struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type storagePageResponse struct {
+	Address  string                 `json:"address"`
+	Entries  []storageEntryResponse `json:"entries"`
+	RootHash string                 `json:"rootHash"`
+	NextKey  string                 `json:"nextKey"`
+}
+
+// blockQuery selects, through the blockNonce or blockHash query parameters, the committed block whose state
+// an account is read from. When none of them is provided, the account is read from the current state
+type blockQuery struct {
+	hasNonce bool
+	nonce    uint64
+	hash     string
+}
+
 // Routes defines address related routes
 func Routes(router *gin.RouterGroup) {
 	router.GET("/:address", GetAccount)
 	router.GET("/:address/balance", GetBalance)
+	router.GET("/:address/proof", GetAccountProof)
+	router.GET("/:address/storage", GetAccountStorage)
+	router.GET("/:address/storage/:key", GetAccountStorageValue)
 }
 
 // GetAccount returns an accountResponse containing information
 //
-//	about the account correlated with provided address
+//	about the account correlated with provided address. The optional blockNonce or blockHash
+//	query parameters select the committed block the account is read at
 func GetAccount(c *gin.Context) {
 	ef, ok := c.MustGet("numbatFacade").(FacadeHandler)
 	if !ok {
@@ -40,8 +95,19 @@ func GetAccount(c *gin.Context) {
 		return
 	}
 
+	query, err := parseBlockQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrCouldNotGetAccount.Error(), err.Error())})
+		return
+	}
+
 	addr := c.Param("address")
-	acc, err := ef.GetAccount(addr)
+	var acc *state.Account
+	if query == nil {
+		acc, err = ef.GetAccount(addr)
+	} else {
+		acc, err = getAccountAtBlock(ef, addr, query)
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrCouldNotGetAccount.Error(), err.Error())})
 		return
@@ -49,7 +115,8 @@ func GetAccount(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"account": accountResponseFromBaseAccount(addr, acc)})
 }
 
-// GetBalance returns the balance for the address parameter
+// GetBalance returns the balance for the address parameter. The optional blockNonce or blockHash query parameters
+// select the committed block the balance is read at
 func GetBalance(c *gin.Context) {
 	ef, ok := c.MustGet("numbatFacade").(FacadeHandler)
 	if !ok {
@@ -63,7 +130,22 @@ func GetBalance(c *gin.Context) {
 		return
 	}
 
-	balance, err := ef.GetBalance(addr)
+	query, err := parseBlockQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetBalance.Error(), err.Error())})
+		return
+	}
+
+	var balance *big.Int
+	if query == nil {
+		balance, err = ef.GetBalance(addr)
+	} else {
+		var acc *state.Account
+		acc, err = getAccountAtBlock(ef, addr, query)
+		if err == nil {
+			balance = acc.Balance
+		}
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetBalance.Error(), err.Error())})
 		return
@@ -72,6 +154,175 @@ func GetBalance(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"balance": balance})
 }
 
+// GetAccountProof returns the Merkle proof of the account correlated with the address parameter against the
+// state root hash of the latest committed block. All byte fields of the response are hex encoded
+func GetAccountProof(c *gin.Context) {
+	ef, ok := c.MustGet("numbatFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+	addr := c.Param("address")
+
+	if addr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetAccountProof.Error(), errors.ErrEmptyAddress.Error())})
+		return
+	}
+
+	accProof, err := ef.GetAccountProof(addr)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetAccountProof.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"proof": accountProofResponseFromAccountProof(accProof)})
+}
+
+// GetAccountStorageValue returns the value stored for the hex encoded key parameter in the storage of the account
+// correlated with the address parameter, along with the root hash of the account data trie. All byte fields of the
+// response are hex encoded
+func GetAccountStorageValue(c *gin.Context) {
+	ef, ok := c.MustGet("numbatFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+	addr := c.Param("address")
+	key := c.Param("key")
+
+	if addr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetAccountStorage.Error(), errors.ErrEmptyAddress.Error())})
+		return
+	}
+	if key == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetAccountStorage.Error(), errors.ErrEmptyStorageKey.Error())})
+		return
+	}
+
+	storageValue, err := ef.GetAccountStorageValue(addr, key)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetAccountStorage.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"storage": storageValueResponse{
+		Address:  addr,
+		Key:      hex.EncodeToString(storageValue.Key),
+		Value:    hex.EncodeToString(storageValue.Value),
+		RootHash: hex.EncodeToString(storageValue.RootHash),
+	}})
+}
+
+// GetAccountStorage returns a page of the storage of the account correlated with the address parameter. The page
+// starts from the hex encoded startKey query parameter, or from the first key if it is missing, and holds at most
+// limit entries. The nextKey field of the response is the startKey of the next page, empty for the last page
+func GetAccountStorage(c *gin.Context) {
+	ef, ok := c.MustGet("numbatFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+	addr := c.Param("address")
+
+	if addr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetAccountStorage.Error(), errors.ErrEmptyAddress.Error())})
+		return
+	}
+
+	limit := defaultStoragePageSize
+	if limitParam, hasLimit := c.GetQuery("limit"); hasLimit {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil || limit <= 0 || limit > maxStoragePageSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetAccountStorage.Error(), errors.ErrInvalidStoragePageSize.Error())})
+			return
+		}
+	}
+
+	page, err := ef.GetAccountStorage(addr, c.Query("startKey"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetAccountStorage.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"storage": storagePageResponseFromStoragePage(addr, page)})
+}
+
+// parseBlockQuery reads the blockNonce and blockHash query parameters. A nil blockQuery is returned when none of
+// them is provided
+func parseBlockQuery(c *gin.Context) (*blockQuery, error) {
+	blockNonce, hasNonce := c.GetQuery("blockNonce")
+	blockHash, hasHash := c.GetQuery("blockHash")
+
+	switch {
+	case hasNonce && hasHash:
+		return nil, errors.ErrBlockNonceAndHash
+	case hasNonce:
+		nonce, err := strconv.ParseUint(blockNonce, 10, 64)
+		if err != nil {
+			return nil, errors.ErrInvalidBlockNonce
+		}
+		return &blockQuery{hasNonce: true, nonce: nonce}, nil
+	case hasHash:
+		if blockHash == "" {
+			return nil, errors.ErrEmptyBlockHash
+		}
+		return &blockQuery{hash: blockHash}, nil
+	}
+
+	return nil, nil
+}
+
+// getAccountAtBlock returns the account as it was at the queried block. An account that did not exist yet at that
+// block is returned as a zero-value account, the same way the current state reports a zero balance for it
+func getAccountAtBlock(ef FacadeHandler, address string, query *blockQuery) (*state.Account, error) {
+	var acc *state.Account
+	var err error
+	if query.hasNonce {
+		acc, err = ef.GetAccountAtBlockNonce(address, query.nonce)
+	} else {
+		acc, err = ef.GetAccountAtBlockHash(address, query.hash)
+	}
+	if err == state.ErrAccNotFound {
+		return &state.Account{Balance: big.NewInt(0)}, nil
+	}
+
+	return acc, err
+}
+
+func accountProofResponseFromAccountProof(accProof *proof.AccountProof) accountProofResponse {
+	proofNodes := make([]string, len(accProof.Proof))
+	for i, node := range accProof.Proof {
+		proofNodes[i] = hex.EncodeToString(node)
+	}
+
+	return accountProofResponse{
+		Address:    hex.EncodeToString(accProof.Address),
+		Value:      hex.EncodeToString(accProof.Value),
+		Proof:      proofNodes,
+		RootHash:   hex.EncodeToString(accProof.RootHash),
+		BlockNonce: accProof.BlockNonce,
+		BlockHash:  hex.EncodeToString(accProof.BlockHash),
+	}
+}
+
+func storagePageResponseFromStoragePage(address string, page *external.StoragePage) storagePageResponse {
+	entries := make([]storageEntryResponse, len(page.Entries))
+	for i, entry := range page.Entries {
+		entries[i] = storageEntryResponse{
+			Key:   hex.EncodeToString(entry.Key),
+			Value: hex.EncodeToString(entry.Value),
+		}
+	}
+
+	return storagePageResponse{
+		Address:  address,
+		Entries:  entries,
+		RootHash: hex.EncodeToString(page.RootHash),
+		NextKey:  hex.EncodeToString(page.NextKey),
+	}
+}
+
 func accountResponseFromBaseAccount(address string, account *state.Account) accountResponse {
 	return accountResponse{
 		Address:  address,
@@ -1,6 +1,7 @@
 package address_test
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,6 +19,8 @@ import (
 	"github.com/numbatx/gn-numbat/api/middleware"
 	"github.com/numbatx/gn-numbat/api/mock"
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/proof"
+	"github.com/numbatx/gn-numbat/node/external"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -53,6 +56,18 @@ type AccountResponse struct {
 	} `json:"account"`
 }
 
+type AccountProofResponse struct {
+	GeneralResponse
+	Proof struct {
+		Address    string   `json:"address"`
+		Value      string   `json:"value"`
+		Proof      []string `json:"proof"`
+		RootHash   string   `json:"rootHash"`
+		BlockNonce uint64   `json:"blockNonce"`
+		BlockHash  string   `json:"blockHash"`
+	} `json:"proof"`
+}
+
 func TestAddressRoute_EmptyTrailReturns404(t *testing.T) {
 	t.Parallel()
 	facade := mock.Facade{}
@@ -232,6 +247,406 @@ func TestGetAccount_ReturnsSuccessfully(t *testing.T) {
 	assert.Empty(t, accountResponse.Error)
 }
 
+func TestGetAccountProof_FailsWithWrongFacadeTypeConversion(t *testing.T) {
+	t.Parallel()
+
+	ws := startNodeServerWrongFacade()
+	req, _ := http.NewRequest("GET", "/address/empty/proof", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	proofResponse := AccountProofResponse{}
+	loadResponse(resp.Body, &proofResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, errors2.ErrInvalidAppContext.Error(), proofResponse.Error)
+}
+
+func TestGetAccountProof_FailWhenFacadeGetAccountProofFails(t *testing.T) {
+	t.Parallel()
+	returnedError := "i am an error"
+	facade := mock.Facade{
+		GetAccountProofHandler: func(address string) (*proof.AccountProof, error) {
+			return nil, errors.New(returnedError)
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/proof", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	proofResponse := AccountProofResponse{}
+	loadResponse(resp.Body, &proofResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Empty(t, proofResponse.Proof)
+	assert.True(t, strings.Contains(proofResponse.Error, fmt.Sprintf("%s: %s", errors2.ErrGetAccountProof.Error(), returnedError)))
+}
+
+func TestGetAccountProof_ReturnsSuccessfully(t *testing.T) {
+	t.Parallel()
+	accProof := &proof.AccountProof{
+		Address:    []byte("address"),
+		Value:      []byte("value"),
+		Proof:      [][]byte{[]byte("node1"), []byte("node2")},
+		RootHash:   []byte("root hash"),
+		BlockNonce: 4,
+		BlockHash:  []byte("block hash"),
+	}
+	facade := mock.Facade{
+		GetAccountProofHandler: func(address string) (*proof.AccountProof, error) {
+			return accProof, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/proof", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	proofResponse := AccountProofResponse{}
+	loadResponse(resp.Body, &proofResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, proofResponse.Error)
+	assert.Equal(t, hex.EncodeToString(accProof.Address), proofResponse.Proof.Address)
+	assert.Equal(t, hex.EncodeToString(accProof.Value), proofResponse.Proof.Value)
+	assert.Equal(t, []string{hex.EncodeToString(accProof.Proof[0]), hex.EncodeToString(accProof.Proof[1])}, proofResponse.Proof.Proof)
+	assert.Equal(t, hex.EncodeToString(accProof.RootHash), proofResponse.Proof.RootHash)
+	assert.Equal(t, accProof.BlockNonce, proofResponse.Proof.BlockNonce)
+	assert.Equal(t, hex.EncodeToString(accProof.BlockHash), proofResponse.Proof.BlockHash)
+}
+
+func TestGetBalance_WithBlockNonceShouldReturnBalanceAtBlock(t *testing.T) {
+	t.Parallel()
+	amount := big.NewInt(45)
+	addr := "testAddress"
+	facade := mock.Facade{
+		GetAccountAtBlockNonceHandler: func(address string, blockNonce uint64) (*state.Account, error) {
+			if address == addr && blockNonce == 7 {
+				return &state.Account{Balance: amount}, nil
+			}
+			return nil, errors.New("unexpected call")
+		},
+	}
+
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/address/%s/balance?blockNonce=7", addr), nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	addressResponse := NewAddressResponse()
+	loadResponse(resp.Body, &addressResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, amount, addressResponse.Balance)
+	assert.Equal(t, "", addressResponse.Error)
+}
+
+func TestGetBalance_WithInvalidBlockNonceShouldErr(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{}
+
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/addr/balance?blockNonce=-1", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	addressResponse := NewAddressResponse()
+	loadResponse(resp.Body, &addressResponse)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, fmt.Sprintf("%s: %s", errors2.ErrGetBalance.Error(), errors2.ErrInvalidBlockNonce.Error()), addressResponse.Error)
+}
+
+func TestGetBalance_AtBlockFailsShouldErr(t *testing.T) {
+	t.Parallel()
+	accountError := errors.New("block not found")
+	facade := mock.Facade{
+		GetAccountAtBlockHashHandler: func(address string, blockHash string) (*state.Account, error) {
+			return nil, accountError
+		},
+	}
+
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/addr/balance?blockHash=aabb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	addressResponse := NewAddressResponse()
+	loadResponse(resp.Body, &addressResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, fmt.Sprintf("%s: %s", errors2.ErrGetBalance.Error(), accountError.Error()), addressResponse.Error)
+}
+
+func TestGetAccount_WithBlockHashShouldReturnAccountAtBlock(t *testing.T) {
+	t.Parallel()
+	blockHash := "aabbcc"
+	facade := mock.Facade{
+		GetAccountAtBlockHashHandler: func(address string, hash string) (*state.Account, error) {
+			if hash == blockHash {
+				return &state.Account{Nonce: 3, Balance: big.NewInt(100)}, nil
+			}
+			return nil, errors.New("unexpected call")
+		},
+	}
+
+	ws := startNodeServer(&facade)
+
+	reqAddress := "test"
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/address/%s?blockHash=%s", reqAddress, blockHash), nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	accountResponse := AccountResponse{}
+	loadResponse(resp.Body, &accountResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, reqAddress, accountResponse.Account.Address)
+	assert.Equal(t, uint64(3), accountResponse.Account.Nonce)
+	assert.Equal(t, "100", accountResponse.Account.Balance)
+	assert.Empty(t, accountResponse.Error)
+}
+
+func TestGetBalance_AccountMissingAtBlockShouldReturnZero(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		GetAccountAtBlockNonceHandler: func(address string, blockNonce uint64) (*state.Account, error) {
+			return nil, state.ErrAccNotFound
+		},
+	}
+
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/addr/balance?blockNonce=7", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	addressResponse := NewAddressResponse()
+	loadResponse(resp.Body, &addressResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, big.NewInt(0), addressResponse.Balance)
+	assert.Empty(t, addressResponse.Error)
+}
+
+func TestGetAccount_AccountMissingAtBlockShouldReturnZeroValueAccount(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{
+		GetAccountAtBlockHashHandler: func(address string, hash string) (*state.Account, error) {
+			return nil, state.ErrAccNotFound
+		},
+	}
+
+	ws := startNodeServer(&facade)
+
+	reqAddress := "test"
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/address/%s?blockHash=aabbcc", reqAddress), nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	accountResponse := AccountResponse{}
+	loadResponse(resp.Body, &accountResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, reqAddress, accountResponse.Account.Address)
+	assert.Equal(t, uint64(0), accountResponse.Account.Nonce)
+	assert.Equal(t, "0", accountResponse.Account.Balance)
+	assert.Empty(t, accountResponse.Error)
+}
+
+func TestGetAccount_WithBlockNonceAndHashShouldErr(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{}
+
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test?blockNonce=2&blockHash=aabb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	accountResponse := AccountResponse{}
+	loadResponse(resp.Body, &accountResponse)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Equal(t, fmt.Sprintf("%s: %s", errors2.ErrCouldNotGetAccount.Error(), errors2.ErrBlockNonceAndHash.Error()), accountResponse.Error)
+}
+
+type StorageValueResponse struct {
+	GeneralResponse
+	Storage struct {
+		Address  string `json:"address"`
+		Key      string `json:"key"`
+		Value    string `json:"value"`
+		RootHash string `json:"rootHash"`
+	} `json:"storage"`
+}
+
+type StoragePageResponse struct {
+	GeneralResponse
+	Storage struct {
+		Address string `json:"address"`
+		Entries []struct {
+			Key   string `json:"key"`
+			Value string `json:"value"`
+		} `json:"entries"`
+		RootHash string `json:"rootHash"`
+		NextKey  string `json:"nextKey"`
+	} `json:"storage"`
+}
+
+func TestGetAccountStorageValue_FailsWithWrongFacadeTypeConversion(t *testing.T) {
+	t.Parallel()
+
+	ws := startNodeServerWrongFacade()
+	req, _ := http.NewRequest("GET", "/address/empty/storage/aabb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StorageValueResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, errors2.ErrInvalidAppContext.Error(), storageResponse.Error)
+}
+
+func TestGetAccountStorageValue_FailWhenFacadeFails(t *testing.T) {
+	t.Parallel()
+	returnedError := "i am an error"
+	facade := mock.Facade{
+		GetAccountStorageValueHandler: func(address string, key string) (*external.StorageValue, error) {
+			return nil, errors.New(returnedError)
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage/aabb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StorageValueResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, fmt.Sprintf("%s: %s", errors2.ErrGetAccountStorage.Error(), returnedError), storageResponse.Error)
+}
+
+func TestGetAccountStorageValue_ReturnsSuccessfully(t *testing.T) {
+	t.Parallel()
+	storageValue := &external.StorageValue{
+		Key:      []byte{0xaa, 0xbb},
+		Value:    []byte("value"),
+		RootHash: []byte("root hash"),
+	}
+	facade := mock.Facade{
+		GetAccountStorageValueHandler: func(address string, key string) (*external.StorageValue, error) {
+			if address == "test" && key == "aabb" {
+				return storageValue, nil
+			}
+			return nil, errors.New("unexpected call")
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage/aabb", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StorageValueResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, storageResponse.Error)
+	assert.Equal(t, "test", storageResponse.Storage.Address)
+	assert.Equal(t, "aabb", storageResponse.Storage.Key)
+	assert.Equal(t, hex.EncodeToString(storageValue.Value), storageResponse.Storage.Value)
+	assert.Equal(t, hex.EncodeToString(storageValue.RootHash), storageResponse.Storage.RootHash)
+}
+
+func TestGetAccountStorage_FailWhenFacadeFails(t *testing.T) {
+	t.Parallel()
+	returnedError := "i am an error"
+	facade := mock.Facade{
+		GetAccountStorageHandler: func(address string, startKey string, maxEntries int) (*external.StoragePage, error) {
+			return nil, errors.New(returnedError)
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StoragePageResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, fmt.Sprintf("%s: %s", errors2.ErrGetAccountStorage.Error(), returnedError), storageResponse.Error)
+}
+
+func TestGetAccountStorage_InvalidLimitShouldErr(t *testing.T) {
+	t.Parallel()
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	for _, limit := range []string{"a", "0", "1001"} {
+		req, _ := http.NewRequest("GET", "/address/test/storage?limit="+limit, nil)
+		resp := httptest.NewRecorder()
+		ws.ServeHTTP(resp, req)
+
+		storageResponse := StoragePageResponse{}
+		loadResponse(resp.Body, &storageResponse)
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+		assert.Equal(t, fmt.Sprintf("%s: %s", errors2.ErrGetAccountStorage.Error(), errors2.ErrInvalidStoragePageSize.Error()), storageResponse.Error)
+	}
+}
+
+func TestGetAccountStorage_ReturnsSuccessfully(t *testing.T) {
+	t.Parallel()
+	page := &external.StoragePage{
+		Entries: []external.StorageEntry{
+			{Key: []byte("key1"), Value: []byte("value1")},
+			{Key: []byte("key2"), Value: []byte("value2")},
+		},
+		RootHash: []byte("root hash"),
+		NextKey:  []byte("key3"),
+	}
+	facade := mock.Facade{
+		GetAccountStorageHandler: func(address string, startKey string, maxEntries int) (*external.StoragePage, error) {
+			if address == "test" && startKey == "aabb" && maxEntries == 2 {
+				return page, nil
+			}
+			return nil, errors.New("unexpected call")
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage?startKey=aabb&limit=2", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	storageResponse := StoragePageResponse{}
+	loadResponse(resp.Body, &storageResponse)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, storageResponse.Error)
+	assert.Equal(t, "test", storageResponse.Storage.Address)
+	assert.Equal(t, 2, len(storageResponse.Storage.Entries))
+	assert.Equal(t, hex.EncodeToString([]byte("key2")), storageResponse.Storage.Entries[1].Key)
+	assert.Equal(t, hex.EncodeToString([]byte("value2")), storageResponse.Storage.Entries[1].Value)
+	assert.Equal(t, hex.EncodeToString(page.RootHash), storageResponse.Storage.RootHash)
+	assert.Equal(t, hex.EncodeToString(page.NextKey), storageResponse.Storage.NextKey)
+}
+
+func TestGetAccountStorage_WithoutLimitShouldUseTheDefaultPageSize(t *testing.T) {
+	t.Parallel()
+	requestedEntries := 0
+	facade := mock.Facade{
+		GetAccountStorageHandler: func(address string, startKey string, maxEntries int) (*external.StoragePage, error) {
+			requestedEntries = maxEntries
+			return &external.StoragePage{}, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	req, _ := http.NewRequest("GET", "/address/test/storage", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, 100, requestedEntries)
+}
+
 func loadResponse(rsp io.Reader, destination interface{}) {
 	jsonParser := json.NewDecoder(rsp)
 	err := jsonParser.Decode(destination)
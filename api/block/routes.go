@@ -2,11 +2,14 @@ package block
 
 import (
 	"encoding/hex"
+	"fmt"
+	"math/big"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/numbatx/gn-numbat/api/errors"
+	"github.com/numbatx/gn-numbat/data/state"
 	"github.com/numbatx/gn-numbat/node/external"
 )
 
@@ -14,6 +17,7 @@ import (
 type FacadeHandler interface {
 	RecentNotarizedBlocks(maxShardHeadersNum int) ([]*external.BlockHeader, error)
 	RetrieveShardBlock(blockHash []byte) (*external.ShardBlockInfo, error)
+	GetBlockStateDiff(blockHash string) (*state.StateDiff, error)
 }
 
 type blockResponse struct {
@@ -30,6 +34,33 @@ type blockResponse struct {
 	PrevHash      string   `json:"prevHash"`
 }
 
+type accountDiffResponse struct {
+	Address     string `json:"address"`
+	Created     bool   `json:"created"`
+	Removed     bool   `json:"removed"`
+	OldNonce    uint64 `json:"oldNonce"`
+	NewNonce    uint64 `json:"newNonce"`
+	OldBalance  string `json:"oldBalance"`
+	NewBalance  string `json:"newBalance"`
+	OldCodeHash string `json:"oldCodeHash"`
+	NewCodeHash string `json:"newCodeHash"`
+	OldRootHash string `json:"oldRootHash"`
+	NewRootHash string `json:"newRootHash"`
+}
+
+type codeDiffResponse struct {
+	CodeHash string `json:"codeHash"`
+	OldCode  string `json:"oldCode"`
+}
+
+type stateDiffResponse struct {
+	BlockHash    string                `json:"blockHash"`
+	PrevRootHash string                `json:"prevRootHash"`
+	RootHash     string                `json:"rootHash"`
+	Accounts     []accountDiffResponse `json:"accounts"`
+	Codes        []codeDiffResponse    `json:"codes"`
+}
+
 const recentBlocksCount = 20
 
 func convertRecentBlocks(headers []*external.BlockHeader) []blockResponse {
@@ -59,9 +90,53 @@ func convertShardHeader(header *external.BlockHeader) blockResponse {
 	}
 }
 
+func convertStateDiff(blockHash string, diff *state.StateDiff) stateDiffResponse {
+	accounts := make([]accountDiffResponse, len(diff.Accounts))
+	for i, accountDiff := range diff.Accounts {
+		accounts[i] = accountDiffResponse{
+			Address:     hex.EncodeToString(accountDiff.Address),
+			Created:     accountDiff.Created,
+			Removed:     accountDiff.Removed,
+			OldNonce:    accountDiff.OldNonce,
+			NewNonce:    accountDiff.NewNonce,
+			OldBalance:  balanceToString(accountDiff.OldBalance),
+			NewBalance:  balanceToString(accountDiff.NewBalance),
+			OldCodeHash: hex.EncodeToString(accountDiff.OldCodeHash),
+			NewCodeHash: hex.EncodeToString(accountDiff.NewCodeHash),
+			OldRootHash: hex.EncodeToString(accountDiff.OldRootHash),
+			NewRootHash: hex.EncodeToString(accountDiff.NewRootHash),
+		}
+	}
+
+	codes := make([]codeDiffResponse, len(diff.Codes))
+	for i, codeDiff := range diff.Codes {
+		codes[i] = codeDiffResponse{
+			CodeHash: hex.EncodeToString(codeDiff.CodeHash),
+			OldCode:  hex.EncodeToString(codeDiff.OldCode),
+		}
+	}
+
+	return stateDiffResponse{
+		BlockHash:    blockHash,
+		PrevRootHash: hex.EncodeToString(diff.PrevRootHash),
+		RootHash:     hex.EncodeToString(diff.RootHash),
+		Accounts:     accounts,
+		Codes:        codes,
+	}
+}
+
+func balanceToString(balance *big.Int) string {
+	if balance == nil {
+		return "0"
+	}
+
+	return balance.String()
+}
+
 // Routes defines block related routes
 func Routes(router *gin.RouterGroup) {
 	router.GET("/:block", Block)
+	router.GET("/:block/statediff", StateDiff)
 }
 
 // RoutesForBlocksLists defines routes related to the lists of blocks. Used separately so
@@ -119,3 +194,26 @@ func RecentBlocks(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"blocks": convertRecentBlocks(recentBlocks)})
 }
+
+// StateDiff returns the changes made to the accounts by the committed block associated with the provided hash
+func StateDiff(c *gin.Context) {
+	ef, ok := c.MustGet("numbatFacade").(FacadeHandler)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	blockHash := c.Param("block")
+	diff, err := ef.GetBlockStateDiff(blockHash)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetStateDiff.Error(), err.Error())})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetStateDiff.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"stateDiff": convertStateDiff(blockHash, diff)})
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -16,6 +17,7 @@ import (
 	apiErrors "github.com/numbatx/gn-numbat/api/errors"
 	"github.com/numbatx/gn-numbat/api/mock"
 	"github.com/numbatx/gn-numbat/api/node"
+	"github.com/numbatx/gn-numbat/data/state"
 	"github.com/numbatx/gn-numbat/node/external"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,6 +26,27 @@ type errorResponse struct {
 	Error string `json:"error"`
 }
 
+type stateDiffResponse struct {
+	errorResponse
+	StateDiff struct {
+		BlockHash    string `json:"blockHash"`
+		PrevRootHash string `json:"prevRootHash"`
+		RootHash     string `json:"rootHash"`
+		Accounts     []struct {
+			Address    string `json:"address"`
+			Created    bool   `json:"created"`
+			OldNonce   uint64 `json:"oldNonce"`
+			NewNonce   uint64 `json:"newNonce"`
+			OldBalance string `json:"oldBalance"`
+			NewBalance string `json:"newBalance"`
+		} `json:"accounts"`
+		Codes []struct {
+			CodeHash string `json:"codeHash"`
+			OldCode  string `json:"oldCode"`
+		} `json:"codes"`
+	} `json:"stateDiff"`
+}
+
 type recentBlocksResponse struct {
 	errorResponse
 	Blocks      []*external.BlockHeader `json:"blocks"`
@@ -240,3 +263,105 @@ func TestBlock_KeyIsNotHexShouldReturnServerError(t *testing.T) {
 	loadResponse(resp.Body, &rb)
 	assert.Equal(t, resp.Code, http.StatusInternalServerError)
 }
+
+//------- StateDiff
+
+func TestStateDiff_FailsWithWrongFacadeTypeConversion(t *testing.T) {
+	t.Parallel()
+	ws := startNodeServerWrongFacade()
+	req, _ := http.NewRequest("GET", "/block/aaee/statediff", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	statusRsp := errorResponse{}
+	loadResponse(resp.Body, &statusRsp)
+	assert.Equal(t, resp.Code, http.StatusInternalServerError)
+	assert.Equal(t, apiErrors.ErrInvalidAppContext.Error(), statusRsp.Error)
+}
+
+func TestStateDiff_ReturnsCorrectly(t *testing.T) {
+	t.Parallel()
+
+	testBlockHashHex := "aaee"
+	facade := mock.Facade{
+		GetBlockStateDiffHandler: func(blockHash string) (*state.StateDiff, error) {
+			assert.Equal(t, testBlockHashHex, blockHash)
+			return &state.StateDiff{
+				PrevRootHash: []byte("prev root"),
+				RootHash:     []byte("root"),
+				Accounts: []state.AccountDiff{
+					{
+						Address:    []byte("address"),
+						Created:    true,
+						NewNonce:   1,
+						NewBalance: big.NewInt(100),
+					},
+				},
+				Codes: []state.CodeDiff{
+					{CodeHash: []byte("code hash")},
+				},
+			}, nil
+		},
+	}
+
+	ws := startNodeServer(&facade)
+	req, _ := http.NewRequest("GET", fmt.Sprintf("/block/%s/statediff", testBlockHashHex), nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	rsp := stateDiffResponse{}
+	loadResponse(resp.Body, &rsp)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, testBlockHashHex, rsp.StateDiff.BlockHash)
+	assert.Equal(t, hex.EncodeToString([]byte("prev root")), rsp.StateDiff.PrevRootHash)
+	assert.Equal(t, hex.EncodeToString([]byte("root")), rsp.StateDiff.RootHash)
+	assert.Equal(t, 1, len(rsp.StateDiff.Accounts))
+	assert.Equal(t, hex.EncodeToString([]byte("address")), rsp.StateDiff.Accounts[0].Address)
+	assert.True(t, rsp.StateDiff.Accounts[0].Created)
+	assert.Equal(t, uint64(1), rsp.StateDiff.Accounts[0].NewNonce)
+	assert.Equal(t, "0", rsp.StateDiff.Accounts[0].OldBalance)
+	assert.Equal(t, "100", rsp.StateDiff.Accounts[0].NewBalance)
+	assert.Equal(t, 1, len(rsp.StateDiff.Codes))
+	assert.Equal(t, hex.EncodeToString([]byte("code hash")), rsp.StateDiff.Codes[0].CodeHash)
+	assert.Equal(t, "", rsp.StateDiff.Codes[0].OldCode)
+}
+
+func TestStateDiff_NotFoundShouldReturnPageNotFound(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{
+		GetBlockStateDiffHandler: func(blockHash string) (*state.StateDiff, error) {
+			return nil, errors.New("state diff not found")
+		},
+	}
+
+	ws := startNodeServer(&facade)
+	req, _ := http.NewRequest("GET", "/block/aaee/statediff", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	rsp := stateDiffResponse{}
+	loadResponse(resp.Body, &rsp)
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Contains(t, rsp.Error, apiErrors.ErrGetStateDiff.Error())
+}
+
+func TestStateDiff_FacadeErrorShouldReturnServerError(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{
+		GetBlockStateDiffHandler: func(blockHash string) (*state.StateDiff, error) {
+			return nil, errors.New("invalid block hash")
+		},
+	}
+
+	ws := startNodeServer(&facade)
+	req, _ := http.NewRequest("GET", "/block/aae_/statediff", nil)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	rsp := stateDiffResponse{}
+	loadResponse(resp.Body, &rsp)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Contains(t, rsp.Error, "invalid block hash")
+}
@@ -13,6 +13,27 @@ var ErrCouldNotGetAccount = errors.New("could not get requested account")
 // ErrGetBalance signals an error in getting the balance for an account
 var ErrGetBalance = errors.New("get balance error")
 
+// ErrGetAccountProof signals an error in getting the Merkle proof for an account
+var ErrGetAccountProof = errors.New("get account proof error")
+
+// ErrGetAccountStorage signals an error in reading the storage of an account
+var ErrGetAccountStorage = errors.New("get account storage error")
+
+// ErrEmptyStorageKey signals an empty storage key was provided
+var ErrEmptyStorageKey = errors.New("storage key was empty")
+
+// ErrInvalidStoragePageSize signals that the limit query parameter is not a number between 1 and the maximum page size
+var ErrInvalidStoragePageSize = errors.New("invalid storage page size")
+
+// ErrInvalidBlockNonce signals that the blockNonce query parameter is not a valid unsigned 64 bits value
+var ErrInvalidBlockNonce = errors.New("invalid block nonce")
+
+// ErrEmptyBlockHash signals that an empty blockHash query parameter was provided
+var ErrEmptyBlockHash = errors.New("block hash was empty")
+
+// ErrBlockNonceAndHash signals that both the blockNonce and the blockHash query parameters were provided
+var ErrBlockNonceAndHash = errors.New("only one of block nonce and block hash can be provided")
+
 // ErrEmptyAddress signals an empty address was provided
 var ErrEmptyAddress = errors.New("address was empty")
 
@@ -46,8 +67,20 @@ var ErrInvalidSignatureHex = errors.New("invalid signature, could not decode hex
 // ErrValidationEmptyTxHash signals an empty tx hash was provided
 var ErrValidationEmptyTxHash = errors.New("TxHash is empty")
 
+// ErrValidationEmptyTransfers signals that a batch transaction without transfers was provided
+var ErrValidationEmptyTransfers = errors.New("transfers list is empty")
+
 // ErrGetTransaction signals an error happend trying to fetch a transaction
 var ErrGetTransaction = errors.New("transaction getting failed")
 
+// ErrGetTransactionStatus signals an error happened trying to fetch the status of a transaction
+var ErrGetTransactionStatus = errors.New("transaction status getting failed")
+
 // ErrTxNotFound signals an error happend trying to fetch a transaction
 var ErrTxNotFound = errors.New("transaction was not found")
+
+// ErrInvalidGas signals that the provided gas price or gas limit is not a valid unsigned 64 bits value
+var ErrInvalidGas = errors.New("invalid gas price or gas limit")
+
+// ErrGetStateDiff signals an error happened trying to fetch the state diff of a block
+var ErrGetStateDiff = errors.New("state diff getting failed")
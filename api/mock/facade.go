@@ -6,6 +6,7 @@ import (
 
 	"github.com/numbatx/gn-numbat/core/statistics"
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/proof"
 	"github.com/numbatx/gn-numbat/data/transaction"
 	"github.com/numbatx/gn-numbat/node/external"
 	"github.com/numbatx/gn-numbat/node/heartbeat"
@@ -21,13 +22,21 @@ type Facade struct {
 	GetHeartbeatsHandler                           func() ([]heartbeat.PubKeyHeartbeat, error)
 	BalanceHandler                                 func(string) (*big.Int, error)
 	GetAccountHandler                              func(address string) (*state.Account, error)
+	GetAccountProofHandler                         func(address string) (*proof.AccountProof, error)
+	GetAccountStorageValueHandler                  func(address string, key string) (*external.StorageValue, error)
+	GetAccountStorageHandler                       func(address string, startKey string, maxEntries int) (*external.StoragePage, error)
+	GetAccountAtBlockNonceHandler                  func(address string, blockNonce uint64) (*state.Account, error)
+	GetAccountAtBlockHashHandler                   func(address string, blockHash string) (*state.Account, error)
 	GenerateTransactionHandler                     func(sender string, receiver string, value *big.Int, code string) (*transaction.Transaction, error)
 	GetTransactionHandler                          func(hash string) (*transaction.Transaction, error)
-	SendTransactionHandler                         func(nonce uint64, sender string, receiver string, value *big.Int, code string, signature []byte) (*transaction.Transaction, error)
+	GetTransactionStatusHandler                    func(hash string) (*transaction.ExecutionResult, error)
+	SendBatchTransactionHandler                    func(nonce uint64, sender string, receivers []string, values []*big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, signature []byte) (*transaction.Transaction, error)
+	SendTransactionHandler                         func(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, code string, signature []byte) (*transaction.Transaction, error)
 	GenerateAndSendBulkTransactionsHandler         func(destination string, value *big.Int, nrTransactions uint64) error
 	GenerateAndSendBulkTransactionsOneByOneHandler func(destination string, value *big.Int, nrTransactions uint64) error
 	RecentNotarizedBlocksHandler                   func(maxShardHeadersNum int) ([]*external.BlockHeader, error)
 	RetrieveShardBlockHandler                      func(blockHash []byte) (*external.ShardBlockInfo, error)
+	GetBlockStateDiffHandler                       func(blockHash string) (*state.StateDiff, error)
 }
 
 func (f *Facade) RecentNotarizedBlocks(maxShardHeadersNum int) ([]*external.BlockHeader, error) {
@@ -38,6 +47,11 @@ func (f *Facade) RetrieveShardBlock(blockHash []byte) (*external.ShardBlockInfo,
 	return f.RetrieveShardBlockHandler(blockHash)
 }
 
+// GetBlockStateDiff is the mock implementation of a handler's GetBlockStateDiff method
+func (f *Facade) GetBlockStateDiff(blockHash string) (*state.StateDiff, error) {
+	return f.GetBlockStateDiffHandler(blockHash)
+}
+
 // IsNodeRunning is the mock implementation of a handler's IsNodeRunning method
 func (f *Facade) IsNodeRunning() bool {
 	return f.Running
@@ -87,6 +101,31 @@ func (f *Facade) GetAccount(address string) (*state.Account, error) {
 	return f.GetAccountHandler(address)
 }
 
+// GetAccountAtBlockNonce is the mock implementation of a handler's GetAccountAtBlockNonce method
+func (f *Facade) GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error) {
+	return f.GetAccountAtBlockNonceHandler(address, blockNonce)
+}
+
+// GetAccountAtBlockHash is the mock implementation of a handler's GetAccountAtBlockHash method
+func (f *Facade) GetAccountAtBlockHash(address string, blockHash string) (*state.Account, error) {
+	return f.GetAccountAtBlockHashHandler(address, blockHash)
+}
+
+// GetAccountProof is the mock implementation of a handler's GetAccountProof method
+func (f *Facade) GetAccountProof(address string) (*proof.AccountProof, error) {
+	return f.GetAccountProofHandler(address)
+}
+
+// GetAccountStorageValue is the mock implementation of a handler's GetAccountStorageValue method
+func (f *Facade) GetAccountStorageValue(address string, key string) (*external.StorageValue, error) {
+	return f.GetAccountStorageValueHandler(address, key)
+}
+
+// GetAccountStorage is the mock implementation of a handler's GetAccountStorage method
+func (f *Facade) GetAccountStorage(address string, startKey string, maxEntries int) (*external.StoragePage, error) {
+	return f.GetAccountStorageHandler(address, startKey, maxEntries)
+}
+
 // GenerateTransaction is the mock implementation of a handler's GenerateTransaction method
 func (f *Facade) GenerateTransaction(sender string, receiver string, value *big.Int,
 	code string) (*transaction.Transaction, error) {
@@ -98,9 +137,19 @@ func (f *Facade) GetTransaction(hash string) (*transaction.Transaction, error) {
 	return f.GetTransactionHandler(hash)
 }
 
+// GetTransactionStatus is the mock implementation of a handler's GetTransactionStatus method
+func (f *Facade) GetTransactionStatus(hash string) (*transaction.ExecutionResult, error) {
+	return f.GetTransactionStatusHandler(hash)
+}
+
 // SendTransaction is the mock implementation of a handler's SendTransaction method
-func (f *Facade) SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, code string, signature []byte) (*transaction.Transaction, error) {
-	return f.SendTransactionHandler(nonce, sender, receiver, value, code, signature)
+func (f *Facade) SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, code string, signature []byte) (*transaction.Transaction, error) {
+	return f.SendTransactionHandler(nonce, sender, receiver, value, gasPrice, gasLimit, validFromRound, validUntilRound, code, signature)
+}
+
+// SendBatchTransaction is the mock implementation of a handler's SendBatchTransaction method
+func (f *Facade) SendBatchTransaction(nonce uint64, sender string, receivers []string, values []*big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, signature []byte) (*transaction.Transaction, error) {
+	return f.SendBatchTransactionHandler(nonce, sender, receivers, values, gasPrice, gasLimit, validFromRound, validUntilRound, signature)
 }
 
 // GenerateAndSendBulkTransactions is the mock implementation of a handler's GenerateAndSendBulkTransactions method
@@ -14,8 +14,10 @@ import (
 // TxService interface defines methods that can be used from `numbatFacade` context variable
 type TxService interface {
 	GenerateTransaction(sender string, receiver string, value *big.Int, code string) (*transaction.Transaction, error)
-	SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, code string, signature []byte) (*transaction.Transaction, error)
+	SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, code string, signature []byte) (*transaction.Transaction, error)
+	SendBatchTransaction(nonce uint64, sender string, receivers []string, values []*big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, signature []byte) (*transaction.Transaction, error)
 	GetTransaction(hash string) (*transaction.Transaction, error)
+	GetTransactionStatus(hash string) (*transaction.ExecutionResult, error)
 	GenerateAndSendBulkTransactions(string, *big.Int, uint64) error
 	GenerateAndSendBulkTransactionsOneByOne(string, *big.Int, uint64) error
 }
@@ -38,25 +40,61 @@ type MultipleTxRequest struct {
 
 // SendTxRequest represents the structure that maps and validates user input for publishing a new transaction
 type SendTxRequest struct {
-	Sender    string   `form:"sender" json:"sender"`
-	Receiver  string   `form:"receiver" json:"receiver"`
-	Value     *big.Int `form:"value" json:"value"`
-	Data      string   `form:"data" json:"data"`
-	Nonce     uint64   `form:"nonce" json:"nonce"`
-	GasPrice  *big.Int `form:"gasPrice" json:"gasPrice"`
-	GasLimit  *big.Int `form:"gasLimit" json:"gasLimit"`
-	Signature string   `form:"signature" json:"signature"`
-	Challenge string   `form:"challenge" json:"challenge"`
+	Sender          string   `form:"sender" json:"sender"`
+	Receiver        string   `form:"receiver" json:"receiver"`
+	Value           *big.Int `form:"value" json:"value"`
+	Data            string   `form:"data" json:"data"`
+	Nonce           uint64   `form:"nonce" json:"nonce"`
+	GasPrice        *big.Int `form:"gasPrice" json:"gasPrice"`
+	GasLimit        *big.Int `form:"gasLimit" json:"gasLimit"`
+	ValidFromRound  uint32   `form:"validFromRound" json:"validFromRound"`
+	ValidUntilRound uint32   `form:"validUntilRound" json:"validUntilRound"`
+	Signature       string   `form:"signature" json:"signature"`
+	Challenge       string   `form:"challenge" json:"challenge"`
+}
+
+// TransferRequest represents a receiver and the value it gets through a batch transfer transaction
+type TransferRequest struct {
+	Receiver string   `form:"receiver" json:"receiver"`
+	Value    *big.Int `form:"value" json:"value"`
+}
+
+// SendBatchTxRequest represents the structure that maps and validates user input for publishing a new batch
+// transfer transaction
+type SendBatchTxRequest struct {
+	Sender          string            `form:"sender" json:"sender"`
+	Transfers       []TransferRequest `form:"transfers" json:"transfers"`
+	Nonce           uint64            `form:"nonce" json:"nonce"`
+	GasPrice        *big.Int          `form:"gasPrice" json:"gasPrice"`
+	GasLimit        *big.Int          `form:"gasLimit" json:"gasLimit"`
+	ValidFromRound  uint32            `form:"validFromRound" json:"validFromRound"`
+	ValidUntilRound uint32            `form:"validUntilRound" json:"validUntilRound"`
+	Signature       string            `form:"signature" json:"signature"`
 }
 
 // TxResponse represents the structure on which the response will be validated against
 type TxResponse struct {
 	SendTxRequest
-	ShardID     uint32 `json:"shardId"`
-	Hash        string `json:"hash"`
-	BlockNumber uint64 `json:"blockNumber"`
-	BlockHash   string `json:"blockHash"`
-	Timestamp   uint64 `json:"timestamp"`
+	ShardID     uint32            `json:"shardId"`
+	Hash        string            `json:"hash"`
+	BlockNumber uint64            `json:"blockNumber"`
+	BlockHash   string            `json:"blockHash"`
+	Timestamp   uint64            `json:"timestamp"`
+	Transfers   []TransferRequest `json:"transfers,omitempty"`
+	ChainID     string            `json:"chainID"`
+	Version     uint32            `json:"version"`
+}
+
+// TxStatusResponse represents the structure of the execution result returned for a transaction
+type TxStatusResponse struct {
+	Status        string `json:"status"`
+	Error         string `json:"error,omitempty"`
+	GasUsed       uint64 `json:"gasUsed"`
+	BlockNonce    uint64 `json:"blockNonce"`
+	BlockHash     string `json:"blockHash"`
+	MiniBlockHash string `json:"miniBlockHash"`
+	SndShardID    uint32 `json:"sndShardId"`
+	RcvShardID    uint32 `json:"rcvShardId"`
 }
 
 // Routes defines transaction related routes
@@ -65,7 +103,9 @@ func Routes(router *gin.RouterGroup) {
 	router.POST("/generate-and-send-multiple", GenerateAndSendBulkTransactions)
 	router.POST("/generate-and-send-multiple-one-by-one", GenerateAndSendBulkTransactionsOneByOne)
 	router.POST("/send", SendTransaction)
+	router.POST("/send-batch", SendBatchTransaction)
 	router.GET("/:txhash", GetTransaction)
+	router.GET("/:txhash/status", GetTransactionStatus)
 }
 
 // RoutesForTransactionsLists defines routes related to lists of transactions. Used separately so
@@ -119,7 +159,63 @@ func SendTransaction(c *gin.Context) {
 		return
 	}
 
-	tx, err := ef.SendTransaction(gtx.Nonce, gtx.Sender, gtx.Receiver, gtx.Value, gtx.Data, signature)
+	gasPrice, okPrice := gasValue(gtx.GasPrice)
+	gasLimit, okLimit := gasValue(gtx.GasLimit)
+	if !okPrice || !okLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidGas.Error()})
+		return
+	}
+
+	tx, err := ef.SendTransaction(gtx.Nonce, gtx.Sender, gtx.Receiver, gtx.Value, gasPrice, gasLimit, gtx.ValidFromRound, gtx.ValidUntilRound, gtx.Data, signature)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrTxGenerationFailed.Error(), err.Error())})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transaction": txResponseFromTransaction(tx)})
+}
+
+// SendBatchTransaction will receive a batch transfer transaction from the client and propagate it for processing
+func SendBatchTransaction(c *gin.Context) {
+	ef, ok := c.MustGet("numbatFacade").(TxService)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	var gtx = SendBatchTxRequest{}
+	err := c.ShouldBindJSON(&gtx)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), err.Error())})
+		return
+	}
+
+	if len(gtx.Transfers) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrValidationEmptyTransfers.Error())})
+		return
+	}
+
+	signature, err := hex.DecodeString(gtx.Signature)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrInvalidSignatureHex.Error(), err.Error())})
+		return
+	}
+
+	gasPrice, okPrice := gasValue(gtx.GasPrice)
+	gasLimit, okLimit := gasValue(gtx.GasLimit)
+	if !okPrice || !okLimit {
+		c.JSON(http.StatusBadRequest, gin.H{"error": errors.ErrInvalidGas.Error()})
+		return
+	}
+
+	receivers := make([]string, len(gtx.Transfers))
+	values := make([]*big.Int, len(gtx.Transfers))
+	for i, transfer := range gtx.Transfers {
+		receivers[i] = transfer.Receiver
+		values[i] = transfer.Value
+	}
+
+	tx, err := ef.SendBatchTransaction(gtx.Nonce, gtx.Sender, receivers, values, gasPrice, gasLimit, gtx.ValidFromRound, gtx.ValidUntilRound, signature)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrTxGenerationFailed.Error(), err.Error())})
 		return
@@ -128,6 +224,18 @@ func SendTransaction(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"transaction": txResponseFromTransaction(tx)})
 }
 
+// gasValue converts an optional gas request value to uint64, returning false if it does not fit
+func gasValue(value *big.Int) (uint64, bool) {
+	if value == nil {
+		return 0, true
+	}
+	if !value.IsUint64() {
+		return 0, false
+	}
+
+	return value.Uint64(), true
+}
+
 // GenerateAndSendBulkTransactions generates multipleTransactions
 func GenerateAndSendBulkTransactions(c *gin.Context) {
 	ef, ok := c.MustGet("numbatFacade").(TxService)
@@ -205,6 +313,34 @@ func GetTransaction(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"transaction": txResponseFromTransaction(tx)})
 }
 
+// GetTransactionStatus returns the execution result for a given txhash
+func GetTransactionStatus(c *gin.Context) {
+	ef, ok := c.MustGet("numbatFacade").(TxService)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": errors.ErrInvalidAppContext.Error()})
+		return
+	}
+
+	txhash := c.Param("txhash")
+	if txhash == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrValidation.Error(), errors.ErrValidationEmptyTxHash.Error())})
+		return
+	}
+
+	result, err := ef.GetTransactionStatus(txhash)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("%s: %s", errors.ErrGetTransactionStatus.Error(), err.Error())})
+		return
+	}
+
+	if result == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": errors.ErrTxNotFound.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": txStatusResponseFromExecutionResult(result)})
+}
+
 // RecentTransactions returns the list of latest transactions from all shards
 func RecentTransactions(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"transactions": buildDummyRecentTransactions()})
@@ -229,6 +365,9 @@ func buildDummyRecentTransactions() []TxResponse {
 			10,
 			"0x000000000",
 			1558361492,
+			nil,
+			"",
+			1,
 		})
 	}
 	return txs
@@ -245,6 +384,29 @@ func txResponseFromTransaction(tx *transaction.Transaction) TxResponse {
 	response.Value = tx.Value
 	response.GasLimit = big.NewInt(int64(tx.GasLimit))
 	response.GasPrice = big.NewInt(int64(tx.GasPrice))
+	response.ValidFromRound = tx.ValidFromRound
+	response.ValidUntilRound = tx.ValidUntilRound
+	response.ChainID = string(tx.ChainID)
+	response.Version = tx.Version
+	for _, transfer := range tx.Transfers {
+		response.Transfers = append(response.Transfers, TransferRequest{
+			Receiver: hex.EncodeToString(transfer.RcvAddr),
+			Value:    transfer.Value,
+		})
+	}
 
 	return response
 }
+
+func txStatusResponseFromExecutionResult(result *transaction.ExecutionResult) TxStatusResponse {
+	return TxStatusResponse{
+		Status:        string(result.Status),
+		Error:         result.Error,
+		GasUsed:       result.GasUsed,
+		BlockNonce:    result.BlockNonce,
+		BlockHash:     hex.EncodeToString(result.BlockHash),
+		MiniBlockHash: hex.EncodeToString(result.MiniBlockHash),
+		SndShardID:    result.SndShardID,
+		RcvShardID:    result.RcvShardID,
+	}
+}
@@ -32,6 +32,11 @@ type TransactionResponse struct {
 	TxResp *transaction.TxResponse `json:"transaction,omitempty"`
 }
 
+type TransactionStatusResponse struct {
+	GeneralResponse
+	Status *transaction.TxStatusResponse `json:"status,omitempty"`
+}
+
 func init() {
 	gin.SetMode(gin.TestMode)
 }
@@ -213,6 +218,84 @@ func TestGetTransaction_WithUnknownHashShouldReturnNil(t *testing.T) {
 	assert.Nil(t, transactionResponse.TxResp)
 }
 
+func TestGetTransactionStatus_WithCorrectHashShouldReturnStatus(t *testing.T) {
+	t.Parallel()
+
+	hash := "hash"
+	facade := mock.Facade{
+		GetTransactionStatusHandler: func(txHash string) (*tr.ExecutionResult, error) {
+			if txHash != hash {
+				return nil, nil
+			}
+			return &tr.ExecutionResult{
+				Status:     tr.TxStatusFailed,
+				Error:      "execution failed",
+				GasUsed:    5,
+				BlockNonce: 7,
+				BlockHash:  []byte("block hash"),
+			}, nil
+		},
+	}
+
+	req, _ := http.NewRequest("GET", "/transaction/"+hash+"/status", nil)
+	ws := startNodeServer(&facade)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	statusResponse := TransactionStatusResponse{}
+	loadResponse(resp.Body, &statusResponse)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, string(tr.TxStatusFailed), statusResponse.Status.Status)
+	assert.Equal(t, "execution failed", statusResponse.Status.Error)
+	assert.Equal(t, uint64(5), statusResponse.Status.GasUsed)
+	assert.Equal(t, uint64(7), statusResponse.Status.BlockNonce)
+	assert.Equal(t, hex.EncodeToString([]byte("block hash")), statusResponse.Status.BlockHash)
+}
+
+func TestGetTransactionStatus_WithUnknownHashShouldReturnNotFound(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{
+		GetTransactionStatusHandler: func(txHash string) (*tr.ExecutionResult, error) {
+			return nil, nil
+		},
+	}
+
+	req, _ := http.NewRequest("GET", "/transaction/unknown/status", nil)
+	ws := startNodeServer(&facade)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	statusResponse := TransactionStatusResponse{}
+	loadResponse(resp.Body, &statusResponse)
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+	assert.Nil(t, statusResponse.Status)
+	assert.Equal(t, errors2.ErrTxNotFound.Error(), statusResponse.Error)
+}
+
+func TestGetTransactionStatus_FacadeErrorsShouldReturnInternalServerError(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{
+		GetTransactionStatusHandler: func(txHash string) (*tr.ExecutionResult, error) {
+			return nil, errors.New("expected error")
+		},
+	}
+
+	req, _ := http.NewRequest("GET", "/transaction/hash/status", nil)
+	ws := startNodeServer(&facade)
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	statusResponse := TransactionStatusResponse{}
+	loadResponse(resp.Body, &statusResponse)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Contains(t, statusResponse.Error, errors2.ErrGetTransactionStatus.Error())
+}
+
 func TestGenerateTransaction_WithBadJsonShouldReturnBadRequest(t *testing.T) {
 	t.Parallel()
 
@@ -415,6 +498,38 @@ func TestSendTransaction_InvalidHexSignatureShouldError(t *testing.T) {
 	assert.Empty(t, transactionResponse.TxResp)
 }
 
+func TestSendTransaction_InvalidGasPriceShouldError(t *testing.T) {
+	t.Parallel()
+	sender := "sender"
+	receiver := "receiver"
+	value := big.NewInt(10)
+	data := "data"
+	signature := "aabbccdd"
+
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	jsonStr := fmt.Sprintf(
+		`{"sender":"%s",`+
+			`"receiver":"%s",`+
+			`"value":%s,`+
+			`"gasPrice":-1,`+
+			`"signature":"%s",`+
+			`"data":"%s"}`, sender, receiver, value, signature, data)
+
+	req, _ := http.NewRequest("POST", "/transaction/send", bytes.NewBuffer([]byte(jsonStr)))
+
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	transactionResponse := TransactionResponse{}
+	loadResponse(resp.Body, &transactionResponse)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, transactionResponse.Error, errors2.ErrInvalidGas.Error())
+	assert.Empty(t, transactionResponse.TxResp)
+}
+
 func TestSendTransaction_ErrorWhenFacadeSendTransactionError(t *testing.T) {
 	t.Parallel()
 	sender := "sender"
@@ -426,7 +541,7 @@ func TestSendTransaction_ErrorWhenFacadeSendTransactionError(t *testing.T) {
 
 	facade := mock.Facade{
 		SendTransactionHandler: func(nonce uint64, sender string, receiver string, value *big.Int,
-			code string, signature []byte) (transaction *tr.Transaction, e error) {
+			gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, code string, signature []byte) (transaction *tr.Transaction, e error) {
 			return nil, errors.New(errorString)
 		},
 	}
@@ -463,7 +578,7 @@ func TestSendTransaction_ReturnsSuccessfully(t *testing.T) {
 
 	facade := mock.Facade{
 		SendTransactionHandler: func(nonce uint64, sender string, receiver string, value *big.Int,
-			code string, signature []byte) (transaction *tr.Transaction, e error) {
+			gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, code string, signature []byte) (transaction *tr.Transaction, e error) {
 			return &tr.Transaction{
 				Nonce:     nonce,
 				SndAddr:   []byte(sender),
@@ -498,6 +613,77 @@ func TestSendTransaction_ReturnsSuccessfully(t *testing.T) {
 	assert.Equal(t, transactionResponse.TxResp.Nonce, nonce)
 }
 
+func TestSendBatchTransaction_EmptyTransfersShouldError(t *testing.T) {
+	t.Parallel()
+
+	facade := mock.Facade{}
+	ws := startNodeServer(&facade)
+
+	jsonStr := `{"nonce": 1, "sender": "sender", "transfers": [], "signature": "aabbccdd"}`
+	req, _ := http.NewRequest("POST", "/transaction/send-batch", bytes.NewBuffer([]byte(jsonStr)))
+
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	transactionResponse := TransactionResponse{}
+	loadResponse(resp.Body, &transactionResponse)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, transactionResponse.Error, errors2.ErrValidationEmptyTransfers.Error())
+}
+
+func TestSendBatchTransaction_ReturnsSuccessfully(t *testing.T) {
+	t.Parallel()
+	nonce := uint64(1)
+	sender := "sender"
+	signature := "aabbccdd"
+
+	var sentReceivers []string
+	var sentValues []*big.Int
+	facade := mock.Facade{
+		SendBatchTransactionHandler: func(nonce uint64, sender string, receivers []string, values []*big.Int,
+			gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, signature []byte) (transaction *tr.Transaction, e error) {
+			sentReceivers = receivers
+			sentValues = values
+			transfers := make([]tr.Transfer, len(receivers))
+			for i := range receivers {
+				transfers[i] = tr.Transfer{RcvAddr: []byte(receivers[i]), Value: values[i]}
+			}
+
+			return &tr.Transaction{
+				Nonce:     nonce,
+				SndAddr:   []byte(sender),
+				RcvAddr:   []byte(sender),
+				Value:     big.NewInt(0),
+				Signature: signature,
+				Transfers: transfers,
+			}, nil
+		},
+	}
+	ws := startNodeServer(&facade)
+
+	jsonStr := fmt.Sprintf(`{
+		"nonce": %d,
+		"sender": "%s",
+		"transfers": [{"receiver": "rcv1", "value": 10}, {"receiver": "rcv2", "value": 20}],
+		"signature": "%s"
+	}`, nonce, sender, signature)
+
+	req, _ := http.NewRequest("POST", "/transaction/send-batch", bytes.NewBuffer([]byte(jsonStr)))
+
+	resp := httptest.NewRecorder()
+	ws.ServeHTTP(resp, req)
+
+	transactionResponse := TransactionResponse{}
+	loadResponse(resp.Body, &transactionResponse)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Empty(t, transactionResponse.Error)
+	assert.Equal(t, []string{"rcv1", "rcv2"}, sentReceivers)
+	assert.Equal(t, big.NewInt(20), sentValues[1])
+	assert.Equal(t, 2, len(transactionResponse.TxResp.Transfers))
+}
+
 func loadResponse(rsp io.Reader, destination interface{}) {
 	jsonParser := json.NewDecoder(rsp)
 	err := jsonParser.Decode(destination)
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"os"
 	"os/signal"
 	"path/filepath"
@@ -37,11 +38,13 @@ import (
 	"github.com/numbatx/gn-numbat/crypto/signing/kyber/singlesig"
 	"github.com/numbatx/gn-numbat/crypto/signing/multisig"
 	"github.com/numbatx/gn-numbat/data"
+	dataBlock "github.com/numbatx/gn-numbat/data/block"
 	"github.com/numbatx/gn-numbat/data/blockchain"
 	"github.com/numbatx/gn-numbat/data/state"
 	"github.com/numbatx/gn-numbat/data/state/addressConverters"
 	factoryState "github.com/numbatx/gn-numbat/data/state/factory"
-	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/data/state/snapshot"
+	factoryTrie "github.com/numbatx/gn-numbat/data/trie/factory"
 	"github.com/numbatx/gn-numbat/data/typeConverters"
 	"github.com/numbatx/gn-numbat/data/typeConverters/uint64ByteSlice"
 	"github.com/numbatx/gn-numbat/dataRetriever"
@@ -51,6 +54,7 @@ import (
 	shardfactoryDataRetriever "github.com/numbatx/gn-numbat/dataRetriever/factory/shard"
 	"github.com/numbatx/gn-numbat/dataRetriever/resolvers"
 	"github.com/numbatx/gn-numbat/dataRetriever/shardedData"
+	"github.com/numbatx/gn-numbat/dataRetriever/txpool"
 	"github.com/numbatx/gn-numbat/facade"
 	"github.com/numbatx/gn-numbat/hashing"
 	"github.com/numbatx/gn-numbat/hashing/blake2b"
@@ -63,7 +67,9 @@ import (
 	"github.com/numbatx/gn-numbat/p2p/libp2p"
 	factoryP2P "github.com/numbatx/gn-numbat/p2p/libp2p/factory"
 	"github.com/numbatx/gn-numbat/p2p/loadBalancer"
+	"github.com/numbatx/gn-numbat/process"
 	"github.com/numbatx/gn-numbat/process/block"
+	"github.com/numbatx/gn-numbat/process/economics"
 	"github.com/numbatx/gn-numbat/process/factory"
 	"github.com/numbatx/gn-numbat/process/factory/metachain"
 	"github.com/numbatx/gn-numbat/process/factory/shard"
@@ -85,6 +91,9 @@ const (
 	blsConsensusType   = "bls"
 	bnConsensusType    = "bn"
 	maxTxsToRequest    = 100
+	// requestedTxsSize is the number of requested transaction hashes remembered to let their responses through the
+	// validity window check of the interceptors and through the admission policy of the transactions pool
+	requestedTxsSize = 100000
 )
 
 var (
@@ -169,6 +178,25 @@ VERSION:
 		Name:  "storage-cleanup",
 		Usage: "If set the node will start from scratch, otherwise it starts from the last state stored on disk",
 	}
+	// stateSnapshot defines the state snapshot a shard node imports to start from the block it was exported at,
+	// instead of replaying all the blocks
+	stateSnapshot = cli.StringFlag{
+		Name:  "state-snapshot",
+		Usage: "Path of a state snapshot, written by the state export command, the node is bootstrapped from",
+		Value: "",
+	}
+	// trustedHeaderHash defines the hash of the block header the imported state snapshot has to be taken at
+	trustedHeaderHash = cli.StringFlag{
+		Name:  "trusted-header-hash",
+		Usage: "Hex encoded hash of the final block header the state snapshot or the synced state is trusted for",
+		Value: "",
+	}
+	// stateSync defines a flag for downloading from peers the state of the trusted header instead of replaying all
+	// the blocks
+	stateSync = cli.BoolFlag{
+		Name:  "state-sync",
+		Usage: "If set, the shard node downloads from its peers the state of the block with the trusted header hash",
+	}
 
 	configurationFile        = "./config/config.toml"
 	p2pConfigurationFile     = "./config/p2p.toml"
@@ -247,7 +275,7 @@ func main() {
 	app.Name = "Numbat Node CLI App"
 	app.Version = "v0.0.1"
 	app.Usage = "This is the entry point for starting a new Numbat node - the app will start after the genesis timestamp"
-	app.Flags = []cli.Flag{genesisFile, nodesFile, port, txSignSk, sk, profileMode, txSignSkIndex, skIndex, numOfNodes, storageCleanup}
+	app.Flags = []cli.Flag{genesisFile, nodesFile, port, txSignSk, sk, profileMode, txSignSkIndex, skIndex, numOfNodes, storageCleanup, stateSnapshot, trustedHeaderHash, stateSync}
 	app.Authors = []cli.Author{
 		{
 			Name:  "The Team Numbat",
@@ -586,11 +614,6 @@ func createShardNode(
 		return nil, nil, nil, errors.New("could not create marshalizer: " + err.Error())
 	}
 
-	tr, err := getTrie(config.AccountsTrieStorage, hasher)
-	if err != nil {
-		return nil, nil, nil, errors.New("error creating trie: " + err.Error())
-	}
-
 	addressConverter, err := addressConverters.NewPlainAddressConverter(config.Address.Length, config.Address.Prefix)
 	if err != nil {
 		return nil, nil, nil, errors.New("could not create address converter: " + err.Error())
@@ -601,6 +624,16 @@ func createShardNode(
 		return nil, nil, nil, errors.New("could not create account factory: " + err.Error())
 	}
 
+	accountsTrieStorage, err := createAccountsTrieStorage(config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tr, statePruner, err := getTrie(config, accountsTrieStorage, marshalizer, hasher, accountFactory)
+	if err != nil {
+		return nil, nil, nil, errors.New("error creating trie: " + err.Error())
+	}
+
 	accountsAdapter, err := state.NewAccountsDB(tr, hasher, marshalizer, accountFactory)
 	if err != nil {
 		return nil, nil, nil, errors.New("could not create accounts adapter: " + err.Error())
@@ -633,7 +666,27 @@ func createShardNode(
 		return nil, nil, nil, err
 	}
 
-	transactionProcessor, err := transaction.NewTxProcessor(accountsAdapter, hasher, addressConverter, marshalizer, shardCoordinator)
+	feeHandler := economics.NewFeeHandler(
+		config.FeeSettings.MinGasPrice,
+		config.FeeSettings.MinGasLimit,
+		config.FeeSettings.GasPerDataByte,
+	)
+
+	rewardsAddress, err := addressConverter.CreateAddressFromHex(config.FeeSettings.RewardsAddressForShard(shardCoordinator.SelfId()))
+	if err != nil {
+		return nil, nil, nil, errors.New("could not create rewards address: " + err.Error())
+	}
+
+	maxTxsInBlock, maxGasLimitInBlock := config.BlockLimits.LimitsForShard(shardCoordinator.SelfId())
+
+	transactionProcessor, err := transaction.NewTxProcessor(
+		accountsAdapter,
+		hasher,
+		addressConverter,
+		marshalizer,
+		shardCoordinator,
+		feeHandler,
+	)
 	if err != nil {
 		return nil, nil, nil, errors.New("could not create transaction processor: " + err.Error())
 	}
@@ -643,13 +696,19 @@ func createShardNode(
 		return nil, nil, nil, errors.New("could not create block chain: " + err.Error())
 	}
 
-	store, err := createShardDataStoreFromConfig(config)
+	store, err := createShardDataStoreFromConfig(config, shardCoordinator)
 	if err != nil {
 		return nil, nil, nil, errors.New("could not create local data store: " + err.Error())
 	}
+	store.AddStorer(dataRetriever.AccountsTrieUnit, accountsTrieStorage)
+
+	requestedTxs, err := storage.NewCache(storage.LRUCache, requestedTxsSize, 1)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	uint64ByteSliceConverter := uint64ByteSlice.NewBigEndianConverter()
-	datapool, err := createShardDataPoolFromConfig(config, uint64ByteSliceConverter)
+	datapool, err := createShardDataPoolFromConfig(config, uint64ByteSliceConverter, requestedTxs)
 	if err != nil {
 		return nil, nil, nil, errors.New("could not create shard data pools: " + err.Error())
 	}
@@ -716,6 +775,15 @@ func createShardNode(
 
 	log.Info("Starting with tx sign public key: " + getPkEncoded(txSignPubKey))
 
+	rounder, err := round.NewRound(
+		time.Unix(nodesConfig.StartTime, 0),
+		syncer.CurrentTime(),
+		time.Millisecond*time.Duration(nodesConfig.RoundDuration),
+		syncer)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	//TODO add a real chronology validator and remove null chronology validator
 	interceptorContainerFactory, err := shard.NewInterceptorsContainerFactory(
 		shardCoordinator,
@@ -729,6 +797,9 @@ func createShardNode(
 		datapool,
 		addressConverter,
 		&nullChronologyValidator{},
+		rounder,
+		requestedTxs,
+		[]byte(config.GeneralSettings.ChainID),
 		tpsBenchmark,
 	)
 	if err != nil {
@@ -764,15 +835,6 @@ func createShardNode(
 		return nil, nil, nil, err
 	}
 
-	rounder, err := round.NewRound(
-		time.Unix(nodesConfig.StartTime, 0),
-		syncer.CurrentTime(),
-		time.Millisecond*time.Duration(nodesConfig.RoundDuration),
-		syncer)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-
 	forkDetector, err := processSync.NewBasicForkDetector(rounder)
 	if err != nil {
 		return nil, nil, nil, err
@@ -793,80 +855,669 @@ func createShardNode(
 		shardCoordinator,
 		forkDetector,
 		blockTracker,
-		createTxRequestHandler(resolversFinder, factory.TransactionTopic, log),
+		createTxRequestHandler(resolversFinder, factory.TransactionTopic, requestedTxs, log),
 		createRequestHandler(resolversFinder, factory.MiniBlocksTopic, log),
+		feeHandler,
+		rewardsAddress,
+		maxTxsInBlock,
+		maxGasLimitInBlock,
+	)
+
+	if err != nil {
+		return nil, nil, nil, errors.New("could not create block processor: " + err.Error())
+	}
+
+	if config.ParallelExecution.Enabled {
+		txsExecutor, err := transaction.NewParallelExecutor(
+			transactionProcessor,
+			accountsAdapter,
+			hasher,
+			addressConverter,
+			marshalizer,
+			shardCoordinator,
+			feeHandler,
+			accountFactory,
+			int(config.ParallelExecution.MaxWorkers),
+		)
+		if err != nil {
+			return nil, nil, nil, errors.New("could not create parallel transactions executor: " + err.Error())
+		}
+
+		err = blockProcessor.SetTransactionsExecutor(txsExecutor)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	err = blockProcessor.SetUint64Converter(uint64ByteSliceConverter)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if statePruner != nil {
+		err = blockProcessor.SetStatePruner(statePruner)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if config.GeneralSettings.RoundsPerEpoch > 0 {
+		err = blockProcessor.SetRoundsPerEpoch(config.GeneralSettings.RoundsPerEpoch)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if config.SupplyInvariant.Enabled {
+		genesisSupply := big.NewInt(0)
+		for _, balance := range inBalanceForShard {
+			genesisSupply.Add(genesisSupply, balance)
+		}
+
+		supplyChecker, err := economics.NewSupplyChecker(
+			genesisSupply,
+			[]uint32{shardCoordinator.SelfId()},
+			config.SupplyInvariant.HaltOnViolation,
+		)
+		if err != nil {
+			return nil, nil, nil, errors.New("could not create supply checker: " + err.Error())
+		}
+
+		err = blockProcessor.SetSupplyChecker(supplyChecker)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	nd, err := node.NewNode(
+		node.WithMessenger(netMessenger),
+		node.WithHasher(hasher),
+		node.WithMarshalizer(marshalizer),
+		node.WithInitialNodesPubKeys(initialPubKeys),
+		node.WithInitialNodesBalances(inBalanceForShard),
+		node.WithAddressConverter(addressConverter),
+		node.WithAccountsAdapter(accountsAdapter),
+		node.WithBlockChain(blkc),
+		node.WithDataStore(store),
+		node.WithRoundDuration(nodesConfig.RoundDuration),
+		node.WithConsensusGroupSize(int(nodesConfig.ConsensusGroupSize)),
+		node.WithSyncer(syncer),
+		node.WithBlockProcessor(blockProcessor),
+		node.WithBlockTracker(blockTracker),
+		node.WithGenesisTime(time.Unix(nodesConfig.StartTime, 0)),
+		node.WithRounder(rounder),
+		node.WithDataPool(datapool),
+		node.WithShardCoordinator(shardCoordinator),
+		node.WithUint64ByteSliceConverter(uint64ByteSliceConverter),
+		node.WithSingleSigner(singleSigner),
+		node.WithMultiSigner(multiSigner),
+		node.WithKeyGen(keyGen),
+		node.WithTxSignPubKey(txSignPubKey),
+		node.WithTxSignPrivKey(txSignPrivKey),
+		node.WithPubKey(pubKey),
+		node.WithPrivKey(privKey),
+		node.WithForkDetector(forkDetector),
+		node.WithFeeHandler(feeHandler),
+		node.WithInterceptorsContainer(interceptorsContainer),
+		node.WithResolversFinder(resolversFinder),
+		node.WithConsensusType(config.Consensus.Type),
+		node.WithTxSingleSigner(txSingleSigner),
+		node.WithActiveMetachain(nodesConfig.MetaChainActive),
+		node.WithTxStorageSize(config.TxStorage.Cache.Size),
+		node.WithChainID([]byte(config.GeneralSettings.ChainID)),
+	)
+	if err != nil {
+		return nil, nil, nil, errors.New("error creating node: " + err.Error())
+	}
+
+	if ctx.GlobalBool(stateSync.Name) {
+		stateSyncer, headerHash, err := createStateSyncer(
+			ctx,
+			config,
+			datapool,
+			store,
+			blkc,
+			marshalizer,
+			hasher,
+			forkDetector,
+			resolversFinder,
+			accountsAdapter,
+			accountFactory,
+		)
+		if err != nil {
+			return nil, nil, nil, errors.New("could not create state syncer: " + err.Error())
+		}
+
+		err = nd.ApplyOptions(node.WithStateSyncer(stateSyncer, headerHash))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	err = nd.CreateShardedStores()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	err = nd.StartHeartbeat(config.Heartbeat)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	externalResolver, err := external.NewExternalResolver(
+		shardCoordinator,
+		blkc,
+		store,
+		marshalizer,
+		&mockProposerResolver{},
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	err = nd.CreateShardGenesisBlock()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if ctx.GlobalString(stateSnapshot.Name) != "" {
+		err = importStateSnapshot(
+			ctx,
+			config,
+			accountsTrieStorage,
+			marshalizer,
+			hasher,
+			accountFactory,
+			accountsAdapter,
+			blkc,
+			store,
+			forkDetector,
+			log,
+		)
+		if err != nil {
+			return nil, nil, nil, errors.New("could not import state snapshot: " + err.Error())
+		}
+	}
+
+	if ctx.GlobalString(stateSnapshot.Name) == "" && !ctx.GlobalBool(stateSync.Name) {
+		err = resumeShardChain(
+			store,
+			marshalizer,
+			accountsAdapter,
+			blkc,
+			forkDetector,
+			blockTracker,
+			shardCoordinator,
+			uint64ByteSliceConverter,
+			log,
+		)
+		if err != nil {
+			return nil, nil, nil, errors.New("could not resume the chain from storage: " + err.Error())
+		}
+	}
+
+	return nd, externalResolver, tpsBenchmark, nil
+}
+
+// importStateSnapshot rebuilds the accounts trie storage from the state snapshot and makes the trusted header it
+// was taken at the current block, so the bootstrap only syncs the blocks that follow it
+func importStateSnapshot(
+	ctx *cli.Context,
+	config *config.Config,
+	accountsTrieStorage storage.Storer,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	accountFactory state.AccountFactory,
+	accountsAdapter state.AccountsAdapter,
+	blkc data.ChainHandler,
+	store dataRetriever.StorageService,
+	forkDetector process.ForkDetector,
+	log *logger.Logger,
+) error {
+
+	if config.StateTrie.Type != factoryTrie.PatriciaMerkleTrie {
+		return errors.New("state snapshots can only be imported in the " + factoryTrie.PatriciaMerkleTrie + " state trie")
+	}
+
+	headerHash, err := hex.DecodeString(ctx.GlobalString(trustedHeaderHash.Name))
+	if err != nil || len(headerHash) == 0 {
+		return errors.New("a valid trusted header hash has to be provided")
+	}
+
+	file, err := os.Open(ctx.GlobalString(stateSnapshot.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	// the snapshot is written through a trie which is not pruned, so the imported state reaches the storage
+	destination, err := factoryTrie.NewStateTrie(config.StateTrie.Type, accountsTrieStorage, marshalizer, hasher)
+	if err != nil {
+		return err
+	}
+
+	importer, err := snapshot.NewImporter(marshalizer, hasher, accountFactory)
+	if err != nil {
+		return err
+	}
+
+	log.Info("importing state snapshot...")
+	header, err := importer.Import(file, destination, headerHash)
+	if err != nil {
+		return err
+	}
+
+	err = accountsAdapter.RecreateTrie(header.RootHash)
+	if err != nil {
+		return err
+	}
+
+	headerBuff, err := marshalizer.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	err = store.Put(dataRetriever.BlockHeaderUnit, headerHash, headerBuff)
+	if err != nil {
+		return err
+	}
+
+	err = blkc.SetCurrentBlockHeader(header)
+	if err != nil {
+		return err
+	}
+	blkc.SetCurrentBlockHeaderHash(headerHash)
+
+	err = forkDetector.AddHeader(header, headerHash, process.BHProcessed)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("state snapshot imported, starting from block with nonce %d and root hash %s",
+		header.Nonce,
+		hex.EncodeToString(header.RootHash)))
+
+	return nil
+}
+
+// createStateSyncer creates the syncer downloading from peers the state of the trusted header, which becomes the
+// block the bootstrap continues from
+func createStateSyncer(
+	ctx *cli.Context,
+	config *config.Config,
+	datapool dataRetriever.PoolsHolder,
+	store dataRetriever.StorageService,
+	blkc data.ChainHandler,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	forkDetector process.ForkDetector,
+	resolversFinder dataRetriever.ResolversFinder,
+	accountsAdapter state.AccountsAdapter,
+	accountFactory state.AccountFactory,
+) (process.StateSyncer, []byte, error) {
+
+	if config.StateTrie.Type != factoryTrie.PatriciaMerkleTrie {
+		return nil, nil, errors.New("the state can only be synced in the " + factoryTrie.PatriciaMerkleTrie + " state trie")
+	}
+	if ctx.GlobalString(stateSnapshot.Name) != "" {
+		return nil, nil, errors.New("the state can either be imported from a snapshot or synced from peers")
+	}
+
+	headerHash, err := hex.DecodeString(ctx.GlobalString(trustedHeaderHash.Name))
+	if err != nil || len(headerHash) == 0 {
+		return nil, nil, errors.New("a valid trusted header hash has to be provided")
+	}
+
+	leafRootHash, err := state.NewDataTrieRootHashGetter(marshalizer, hasher, accountFactory)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stateSyncer, err := processSync.NewStateSyncer(
+		datapool,
+		store,
+		blkc,
+		hasher,
+		marshalizer,
+		forkDetector,
+		resolversFinder,
+		accountsAdapter,
+		leafRootHash,
+		config.StateSync.BatchSize,
+		time.Millisecond*time.Duration(config.StateSync.RequestTimeInMs),
+		config.StateSync.MaxRequestRetries,
 	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stateSyncer, headerHash, nil
+}
+
+// errNoResumableBlock signals that none of the committed blocks found in storage has its state persisted
+var errNoResumableBlock = errors.New("no committed block with a persisted state found")
+
+// resumeShardChain makes the last block committed before the node was stopped the current block, so the bootstrap
+// only syncs the blocks that follow it. When the state of that block was not persisted, as the state pruning only
+// persists the state of some of the final blocks, the closest previous block having its state persisted is resumed
+// from instead. The node starts from genesis if there is no such block
+func resumeShardChain(
+	store dataRetriever.StorageService,
+	marshalizer marshal.Marshalizer,
+	accountsAdapter state.AccountsAdapter,
+	blkc data.ChainHandler,
+	forkDetector process.ForkDetector,
+	blockTracker process.BlocksTracker,
+	shardCoordinator sharding.Coordinator,
+	uint64Converter typeConverters.Uint64ByteSliceConverter,
+	log *logger.Logger,
+) error {
+
+	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(shardCoordinator.SelfId())
+	headerHash, err := process.GetLastCommittedHeaderHash(store, shardCoordinator.SelfId())
+	if err != nil {
+		log.Info("no committed block found in storage, starting from genesis")
+		err = process.DiscardPendingCommit(store, marshalizer, shardCoordinator.SelfId(), nil)
+		if err != nil {
+			return err
+		}
+
+		return discardIncompleteBlocks(store, hdrNonceHashDataUnit, uint64Converter, 0, log)
+	}
+
+	err = process.DiscardPendingCommit(store, marshalizer, shardCoordinator.SelfId(), headerHash)
+	if err != nil {
+		return err
+	}
+
+	header, err := process.GetShardHeaderFromStorage(headerHash, marshalizer, store)
+	if err != nil {
+		return err
+	}
+
+	var body dataBlock.Body
+	for {
+		body, err = getShardBlockBodyFromStorage(store, marshalizer, header)
+		if err != nil {
+			return err
+		}
+
+		err = accountsAdapter.RecreateTrie(header.RootHash)
+		if err == nil {
+			break
+		}
+
+		if header.Nonce <= 1 {
+			log.Info(errNoResumableBlock.Error() + ", starting from genesis")
+			return discardIncompleteBlocks(store, hdrNonceHashDataUnit, uint64Converter, 0, log)
+		}
+
+		log.Info(fmt.Sprintf("state of block with nonce %d is not persisted, trying its previous block", header.Nonce))
+		headerHash = header.PrevHash
+		header, err = process.GetShardHeaderFromStorage(headerHash, marshalizer, store)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = discardIncompleteBlocks(store, hdrNonceHashDataUnit, uint64Converter, header.Nonce, log)
+	if err != nil {
+		return err
+	}
+
+	err = blkc.SetCurrentBlockBody(body)
+	if err != nil {
+		return err
+	}
+
+	err = blkc.SetCurrentBlockHeader(header)
+	if err != nil {
+		return err
+	}
+	blkc.SetCurrentBlockHeaderHash(headerHash)
+
+	err = forkDetector.AddHeader(header, headerHash, process.BHProcessed)
+	if err != nil {
+		return err
+	}
+	blockTracker.AddBlock(header)
+
+	log.Info(fmt.Sprintf("resumed from committed block with nonce %d and hash %s",
+		header.Nonce,
+		hex.EncodeToString(headerHash)))
+
+	return nil
+}
+
+// discardIncompleteBlocks removes from the given nonce to hash storage unit the entries of the headers following the
+// resumed one. Their blocks were either being committed when the node was stopped, their data being removed with the
+// pending commit, or have no persisted state, thus they must not be resumed from nor served to the other nodes
+func discardIncompleteBlocks(
+	store dataRetriever.StorageService,
+	hdrNonceHashDataUnit dataRetriever.UnitType,
+	uint64Converter typeConverters.Uint64ByteSliceConverter,
+	lastCommittedNonce uint64,
+	log *logger.Logger,
+) error {
+
+	hdrNonceHashStore := store.GetStorer(hdrNonceHashDataUnit)
+	if hdrNonceHashStore == nil {
+		return process.ErrNilHeadersNoncesStorage
+	}
+
+	for nonce := lastCommittedNonce + 1; ; nonce++ {
+		nonceToByteSlice := uint64Converter.ToByteSlice(nonce)
+		headerHash, err := hdrNonceHashStore.Get(nonceToByteSlice)
+		if err != nil {
+			return nil
+		}
+
+		err = hdrNonceHashStore.Remove(nonceToByteSlice)
+		if err != nil {
+			return err
+		}
+
+		log.Info(fmt.Sprintf("discarded incompletely committed block with nonce %d and hash %s",
+			nonce,
+			hex.EncodeToString(headerHash)))
+	}
+}
+
+func getShardBlockBodyFromStorage(
+	store dataRetriever.StorageService,
+	marshalizer marshal.Marshalizer,
+	header *dataBlock.Header,
+) (dataBlock.Body, error) {
+
+	hashes := make([][]byte, len(header.MiniBlockHeaders))
+	for i := 0; i < len(header.MiniBlockHeaders); i++ {
+		hashes[i] = header.MiniBlockHeaders[i].Hash
+	}
+
+	buffs, err := store.GetAll(dataRetriever.MiniBlockUnit, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	body := make(dataBlock.Body, len(hashes))
+	for i, hash := range hashes {
+		miniBlock := &dataBlock.MiniBlock{}
+		err = marshalizer.Unmarshal(miniBlock, buffs[string(hash)])
+		if err != nil {
+			return nil, err
+		}
+		body[i] = miniBlock
+	}
+
+	return body, nil
+}
+
+// resumeMetaChain makes the last metablock committed before the node was stopped, or the closest previous one
+// having its state persisted, the current dataBlock. The last shard headers notarized by the resumed metablock and its
+// predecessors become the last notarized headers the next shard headers are validated against
+func resumeMetaChain(
+	store dataRetriever.StorageService,
+	marshalizer marshal.Marshalizer,
+	accountsAdapter state.AccountsAdapter,
+	metaChain data.ChainHandler,
+	forkDetector process.ForkDetector,
+	blockTracker process.BlocksTracker,
+	setLastNotarizedHdrs func(shardHeaderBlocks map[uint32]data.HeaderHandler) error,
+	shardsGenesisBlocks map[uint32]data.HeaderHandler,
+	uint64Converter typeConverters.Uint64ByteSliceConverter,
+	log *logger.Logger,
+) error {
+
+	headerHash, err := process.GetLastCommittedHeaderHash(store, sharding.MetachainShardId)
+	if err != nil {
+		log.Info("no committed metablock found in storage, starting from genesis")
+		err = process.DiscardPendingCommit(store, marshalizer, sharding.MetachainShardId, nil)
+		if err != nil {
+			return err
+		}
+
+		return discardIncompleteBlocks(store, dataRetriever.MetaHdrNonceHashDataUnit, uint64Converter, 0, log)
+	}
+
+	err = process.DiscardPendingCommit(store, marshalizer, sharding.MetachainShardId, headerHash)
+	if err != nil {
+		return err
+	}
+
+	header, err := getMetaHeaderFromStorage(store, marshalizer, headerHash)
+	if err != nil {
+		return err
+	}
+
+	for {
+		err = accountsAdapter.RecreateTrie(header.RootHash)
+		if err == nil {
+			break
+		}
+
+		if header.Nonce <= 1 {
+			log.Info(errNoResumableBlock.Error() + ", starting from genesis")
+			return discardIncompleteBlocks(store, dataRetriever.MetaHdrNonceHashDataUnit, uint64Converter, 0, log)
+		}
+
+		log.Info(fmt.Sprintf("state of metablock with nonce %d is not persisted, trying its previous block", header.Nonce))
+		headerHash = header.PrevHash
+		header, err = getMetaHeaderFromStorage(store, marshalizer, headerHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = discardIncompleteBlocks(store, dataRetriever.MetaHdrNonceHashDataUnit, uint64Converter, header.Nonce, log)
+	if err != nil {
+		return err
+	}
 
+	lastNotarizedHdrs, err := getLastNotarizedShardHeaders(store, marshalizer, header, shardsGenesisBlocks)
 	if err != nil {
-		return nil, nil, nil, errors.New("could not create block processor: " + err.Error())
+		return err
 	}
 
-	nd, err := node.NewNode(
-		node.WithMessenger(netMessenger),
-		node.WithHasher(hasher),
-		node.WithMarshalizer(marshalizer),
-		node.WithInitialNodesPubKeys(initialPubKeys),
-		node.WithInitialNodesBalances(inBalanceForShard),
-		node.WithAddressConverter(addressConverter),
-		node.WithAccountsAdapter(accountsAdapter),
-		node.WithBlockChain(blkc),
-		node.WithDataStore(store),
-		node.WithRoundDuration(nodesConfig.RoundDuration),
-		node.WithConsensusGroupSize(int(nodesConfig.ConsensusGroupSize)),
-		node.WithSyncer(syncer),
-		node.WithBlockProcessor(blockProcessor),
-		node.WithBlockTracker(blockTracker),
-		node.WithGenesisTime(time.Unix(nodesConfig.StartTime, 0)),
-		node.WithRounder(rounder),
-		node.WithDataPool(datapool),
-		node.WithShardCoordinator(shardCoordinator),
-		node.WithUint64ByteSliceConverter(uint64ByteSliceConverter),
-		node.WithSingleSigner(singleSigner),
-		node.WithMultiSigner(multiSigner),
-		node.WithKeyGen(keyGen),
-		node.WithTxSignPubKey(txSignPubKey),
-		node.WithTxSignPrivKey(txSignPrivKey),
-		node.WithPubKey(pubKey),
-		node.WithPrivKey(privKey),
-		node.WithForkDetector(forkDetector),
-		node.WithInterceptorsContainer(interceptorsContainer),
-		node.WithResolversFinder(resolversFinder),
-		node.WithConsensusType(config.Consensus.Type),
-		node.WithTxSingleSigner(txSingleSigner),
-		node.WithActiveMetachain(nodesConfig.MetaChainActive),
-		node.WithTxStorageSize(config.TxStorage.Cache.Size),
-	)
+	err = setLastNotarizedHdrs(lastNotarizedHdrs)
 	if err != nil {
-		return nil, nil, nil, errors.New("error creating node: " + err.Error())
+		return err
 	}
 
-	err = nd.CreateShardedStores()
+	err = metaChain.SetCurrentBlockBody(&dataBlock.MetaBlockBody{})
 	if err != nil {
-		return nil, nil, nil, err
+		return err
 	}
 
-	err = nd.StartHeartbeat(config.Heartbeat)
+	err = metaChain.SetCurrentBlockHeader(header)
 	if err != nil {
-		return nil, nil, nil, err
+		return err
 	}
+	metaChain.SetCurrentBlockHeaderHash(headerHash)
 
-	externalResolver, err := external.NewExternalResolver(
-		shardCoordinator,
-		blkc,
-		store,
-		marshalizer,
-		&mockProposerResolver{},
-	)
+	err = forkDetector.AddHeader(header, headerHash, process.BHProcessed)
 	if err != nil {
-		return nil, nil, nil, err
+		return err
 	}
+	blockTracker.AddBlock(header)
 
-	err = nd.CreateShardGenesisBlock()
+	log.Info(fmt.Sprintf("resumed from committed metablock with nonce %d and hash %s",
+		header.Nonce,
+		hex.EncodeToString(headerHash)))
+
+	return nil
+}
+
+// getLastNotarizedShardHeaders walks back the metablocks, starting with the given one, until the last notarized
+// header of every shard is found. The genesis header is kept for a shard without notarized headers
+func getLastNotarizedShardHeaders(
+	store dataRetriever.StorageService,
+	marshalizer marshal.Marshalizer,
+	header *dataBlock.MetaBlock,
+	shardsGenesisBlocks map[uint32]data.HeaderHandler,
+) (map[uint32]data.HeaderHandler, error) {
+
+	lastNotarizedHdrs := make(map[uint32]data.HeaderHandler, len(shardsGenesisBlocks))
+	for shardId, genesisBlock := range shardsGenesisBlocks {
+		lastNotarizedHdrs[shardId] = genesisBlock
+	}
+
+	foundShards := make(map[uint32]struct{})
+	for {
+		foundInHeader := make(map[uint32]struct{})
+		for _, shardData := range header.ShardInfo {
+			_, found := foundShards[shardData.ShardId]
+			if found {
+				continue
+			}
+
+			shardHeader, err := process.GetShardHeaderFromStorage(shardData.HeaderHash, marshalizer, store)
+			if err != nil {
+				return nil, err
+			}
+
+			_, foundBefore := foundInHeader[shardData.ShardId]
+			if !foundBefore || shardHeader.Nonce > lastNotarizedHdrs[shardData.ShardId].GetNonce() {
+				lastNotarizedHdrs[shardData.ShardId] = shardHeader
+			}
+			foundInHeader[shardData.ShardId] = struct{}{}
+		}
+
+		for shardId := range foundInHeader {
+			foundShards[shardId] = struct{}{}
+		}
+
+		if len(foundShards) == len(shardsGenesisBlocks) || header.Nonce <= 1 {
+			return lastNotarizedHdrs, nil
+		}
+
+		prevHeader, err := getMetaHeaderFromStorage(store, marshalizer, header.PrevHash)
+		if err != nil {
+			return nil, err
+		}
+		header = prevHeader
+	}
+}
+
+func getMetaHeaderFromStorage(
+	store dataRetriever.StorageService,
+	marshalizer marshal.Marshalizer,
+	headerHash []byte,
+) (*dataBlock.MetaBlock, error) {
+
+	buff, err := store.Get(dataRetriever.MetaBlockUnit, headerHash)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, err
 	}
 
-	return nd, externalResolver, tpsBenchmark, nil
+	header := &dataBlock.MetaBlock{}
+	err = marshalizer.Unmarshal(header, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
 }
 
 func createMetaNode(
@@ -893,11 +1544,6 @@ func createMetaNode(
 		return nil, nil, nil, errors.New("could not create marshalizer: " + err.Error())
 	}
 
-	tr, err := getTrie(config.AccountsTrieStorage, hasher)
-	if err != nil {
-		return nil, nil, nil, errors.New("error creating trie: " + err.Error())
-	}
-
 	addressConverter, err := addressConverters.NewPlainAddressConverter(config.Address.Length, config.Address.Prefix)
 	if err != nil {
 		return nil, nil, nil, errors.New("could not create address converter: " + err.Error())
@@ -908,6 +1554,16 @@ func createMetaNode(
 		return nil, nil, nil, errors.New("could not create account factory: " + err.Error())
 	}
 
+	accountsTrieStorage, err := createAccountsTrieStorage(config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tr, statePruner, err := getTrie(config, accountsTrieStorage, marshalizer, hasher, accountFactory)
+	if err != nil {
+		return nil, nil, nil, errors.New("error creating trie: " + err.Error())
+	}
+
 	accountsAdapter, err := state.NewAccountsDB(tr, hasher, marshalizer, accountFactory)
 	if err != nil {
 		return nil, nil, nil, errors.New("could not create accounts adapter: " + err.Error())
@@ -945,7 +1601,7 @@ func createMetaNode(
 		return nil, nil, nil, errors.New("could not create block chain: " + err.Error())
 	}
 
-	metaStore, err := createMetaChainDataStoreFromConfig(config)
+	metaStore, err := createMetaChainDataStoreFromConfig(config, shardCoordinator)
 	if err != nil {
 		return nil, nil, nil, errors.New("could not create local data store: " + err.Error())
 	}
@@ -1076,6 +1732,7 @@ func createMetaNode(
 		genesisConfig,
 		shardCoordinator,
 		addressConverter,
+		config.StateTrie.Type,
 		hasher,
 		marshalizer,
 	)
@@ -1102,6 +1759,37 @@ func createMetaNode(
 		return nil, nil, nil, err
 	}
 
+	err = metaProcessor.SetUint64Converter(uint64ByteSliceConverter)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if statePruner != nil {
+		err = metaProcessor.SetStatePruner(statePruner)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if config.GeneralSettings.RoundsPerEpoch > 0 {
+		err = metaProcessor.SetRoundsPerEpoch(config.GeneralSettings.RoundsPerEpoch)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if config.SupplyInvariant.Enabled {
+		supplyChecker, err := createMetaSupplyChecker(config, genesisConfig, shardCoordinator, addressConverter)
+		if err != nil {
+			return nil, nil, nil, errors.New("could not create supply checker: " + err.Error())
+		}
+
+		err = metaProcessor.SetSupplyChecker(supplyChecker)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
 	nd, err := node.NewNode(
 		node.WithMessenger(netMessenger),
 		node.WithHasher(hasher),
@@ -1155,11 +1843,36 @@ func createMetaNode(
 		return nil, nil, nil, err
 	}
 
+	err = resumeMetaChain(
+		metaStore,
+		marshalizer,
+		accountsAdapter,
+		metaChain,
+		forkDetector,
+		blockTracker,
+		metaProcessor.SetLastNotarizedHeadersSlice,
+		shardsGenesisBlocks,
+		uint64ByteSliceConverter,
+		log,
+	)
+	if err != nil {
+		return nil, nil, nil, errors.New("could not resume the chain from storage: " + err.Error())
+	}
+
 	return nd, externalResolver, tpsBenchmark, nil
 }
 
-func createTxRequestHandler(resolversFinder dataRetriever.ResolversFinder, baseTopic string, log *logger.Logger) func(destShardID uint32, txHashes [][]byte) {
+func createTxRequestHandler(
+	resolversFinder dataRetriever.ResolversFinder,
+	baseTopic string,
+	requestedTxs storage.Cacher,
+	log *logger.Logger,
+) func(destShardID uint32, txHashes [][]byte) {
 	return func(destShardID uint32, txHashes [][]byte) {
+		for _, txHash := range txHashes {
+			requestedTxs.Put(txHash, struct{}{})
+		}
+
 		log.Debug(fmt.Sprintf("Requesting %d transactions from shard %d from network...\n", len(txHashes), destShardID))
 		resolver, err := resolversFinder.CrossShardResolver(baseTopic, destShardID)
 		if err != nil {
@@ -1296,22 +2009,54 @@ func getPkEncoded(pubKey crypto.PublicKey) string {
 	return encodeAddress(pk)
 }
 
-func getTrie(cfg config.StorageConfig, hasher hashing.Hasher) (*trie.Trie, error) {
+func createAccountsTrieStorage(cfg *config.Config) (storage.Storer, error) {
+	dbConfig := getDBFromConfig(cfg.AccountsTrieStorage.DB)
+	bloomConfig := getBloomFromConfig(cfg.AccountsTrieStorage.Bloom)
+	// the bloom filter is only kept in memory, so an empty one would hide the trie nodes stored by a previous run of
+	// the node, which are needed to resume its chain
+	_, err := os.Stat(dbConfig.FilePath)
+	if err == nil {
+		bloomConfig = storage.BloomConfig{}
+	}
+
 	accountsTrieStorage, err := storage.NewStorageUnitFromConf(
-		getCacherFromConfig(cfg.Cache),
-		getDBFromConfig(cfg.DB),
-		getBloomFromConfig(cfg.Bloom),
+		getCacherFromConfig(cfg.AccountsTrieStorage.Cache),
+		dbConfig,
+		bloomConfig,
 	)
 	if err != nil {
 		return nil, errors.New("error creating accountsTrieStorage: " + err.Error())
 	}
 
-	dbWriteCache, err := trie.NewDBWriteCache(accountsTrieStorage)
+	return accountsTrieStorage, nil
+}
+
+func getTrie(
+	cfg *config.Config,
+	accountsTrieStorage storage.Storer,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	accountFactory state.AccountFactory,
+) (data.Trie, data.StatePruner, error) {
+
+	if cfg.StatePruning.ArchiveMode {
+		tr, err := factoryTrie.NewStateTrie(cfg.StateTrie.Type, accountsTrieStorage, marshalizer, hasher)
+		return tr, nil, err
+	}
+
+	leafRootHash, err := state.NewDataTrieRootHashGetter(marshalizer, hasher, accountFactory)
 	if err != nil {
-		return nil, errors.New("error creating dbWriteCache: " + err.Error())
+		return nil, nil, err
 	}
 
-	return trie.NewTrie(make([]byte, 32), dbWriteCache, hasher)
+	return factoryTrie.NewPruningStateTrie(
+		cfg.StateTrie.Type,
+		accountsTrieStorage,
+		marshalizer,
+		hasher,
+		leafRootHash,
+		cfg.StatePruning.RetainedFinalRoots,
+	)
 }
 
 func getHasherFromConfig(cfg *config.Config) (hashing.Hasher, error) {
@@ -1367,6 +2112,14 @@ func getDBFromConfig(cfg config.DBConfig) storage.DBConfig {
 	}
 }
 
+// getShardHdrNonceHashDBFromConfig returns the database config of the given shard's header nonce to hash index
+func getShardHdrNonceHashDBFromConfig(cfg config.DBConfig, shardId uint32) storage.DBConfig {
+	dbConfig := getDBFromConfig(cfg)
+	dbConfig.FilePath = fmt.Sprintf("%s_%d", dbConfig.FilePath, shardId)
+
+	return dbConfig
+}
+
 func getBloomFromConfig(cfg config.BloomFilterConfig) storage.BloomConfig {
 	var hashFuncs []storage.HasherType
 	if cfg.HashFunc != nil {
@@ -1385,11 +2138,17 @@ func getBloomFromConfig(cfg config.BloomFilterConfig) storage.BloomConfig {
 func createShardDataPoolFromConfig(
 	config *config.Config,
 	uint64ByteSliceConverter typeConverters.Uint64ByteSliceConverter,
+	requestedTxs storage.Cacher,
 ) (dataRetriever.PoolsHolder, error) {
 
 	fmt.Println("creatingShardDataPool from config")
 
-	txPool, err := shardedData.NewShardedData(getCacherFromConfig(config.TxDataPool))
+	txPool, err := txpool.NewShardedTxPool(
+		config.TxDataPool.Size,
+		config.TxPoolSettings.MaxTxsPerSender,
+		config.TxPoolSettings.MinGasPriceBumpPercentage,
+		requestedTxs,
+	)
 	if err != nil {
 		fmt.Println("error creating txpool")
 		return nil, err
@@ -1447,6 +2206,13 @@ func createShardDataPoolFromConfig(
 		return nil, err
 	}
 
+	cacherCfg = getCacherFromConfig(config.TrieNodesDataPool)
+	trieNodes, err := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
+	if err != nil {
+		fmt.Println("error creating trieNodes")
+		return nil, err
+	}
+
 	return dataPool.NewShardedDataPool(
 		txPool,
 		hdrPool,
@@ -1455,6 +2221,7 @@ func createShardDataPoolFromConfig(
 		peerChangeBlockBody,
 		metaBlockBody,
 		metaBlockNonces,
+		trieNodes,
 	)
 }
 
@@ -1477,8 +2244,13 @@ func createBlockChainFromConfig(config *config.Config) (data.ChainHandler, error
 	return blockChain, err
 }
 
-func createShardDataStoreFromConfig(config *config.Config) (dataRetriever.StorageService, error) {
-	var headerUnit, peerBlockUnit, miniBlockUnit, txUnit, metachainHeaderUnit *storage.Unit
+func createShardDataStoreFromConfig(
+	config *config.Config,
+	shardCoordinator sharding.Coordinator,
+) (dataRetriever.StorageService, error) {
+
+	var headerNonceHashUnit, peerBlockUnit, metachainHeaderUnit, metaHdrNonceHashUnit, stateDiffUnit, bootstrapUnit *storage.Unit
+	var headerUnit, miniBlockUnit, txUnit, txResultsUnit storage.Storer
 	var err error
 
 	defer func() {
@@ -1487,6 +2259,9 @@ func createShardDataStoreFromConfig(config *config.Config) (dataRetriever.Storag
 			if headerUnit != nil {
 				_ = headerUnit.DestroyUnit()
 			}
+			if headerNonceHashUnit != nil {
+				_ = headerNonceHashUnit.DestroyUnit()
+			}
 			if peerBlockUnit != nil {
 				_ = peerBlockUnit.DestroyUnit()
 			}
@@ -1496,24 +2271,35 @@ func createShardDataStoreFromConfig(config *config.Config) (dataRetriever.Storag
 			if txUnit != nil {
 				_ = txUnit.DestroyUnit()
 			}
+			if txResultsUnit != nil {
+				_ = txResultsUnit.DestroyUnit()
+			}
 			if metachainHeaderUnit != nil {
 				_ = metachainHeaderUnit.DestroyUnit()
 			}
+			if metaHdrNonceHashUnit != nil {
+				_ = metaHdrNonceHashUnit.DestroyUnit()
+			}
+			if stateDiffUnit != nil {
+				_ = stateDiffUnit.DestroyUnit()
+			}
+			if bootstrapUnit != nil {
+				_ = bootstrapUnit.DestroyUnit()
+			}
 		}
 	}()
 
-	txUnit, err = storage.NewStorageUnitFromConf(
-		getCacherFromConfig(config.TxStorage.Cache),
-		getDBFromConfig(config.TxStorage.DB),
-		getBloomFromConfig(config.TxStorage.Bloom))
+	txUnit, err = createEpochStorerFromConfig(config.TxStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	txResultsUnit, err = createEpochStorerFromConfig(config.TxResultsStorage)
 	if err != nil {
 		return nil, err
 	}
 
-	miniBlockUnit, err = storage.NewStorageUnitFromConf(
-		getCacherFromConfig(config.MiniBlocksStorage.Cache),
-		getDBFromConfig(config.MiniBlocksStorage.DB),
-		getBloomFromConfig(config.MiniBlocksStorage.Bloom))
+	miniBlockUnit, err = createEpochStorerFromConfig(config.MiniBlocksStorage)
 	if err != nil {
 		return nil, err
 	}
@@ -1526,10 +2312,15 @@ func createShardDataStoreFromConfig(config *config.Config) (dataRetriever.Storag
 		return nil, err
 	}
 
-	headerUnit, err = storage.NewStorageUnitFromConf(
-		getCacherFromConfig(config.BlockHeaderStorage.Cache),
-		getDBFromConfig(config.BlockHeaderStorage.DB),
-		getBloomFromConfig(config.BlockHeaderStorage.Bloom))
+	headerUnit, err = createEpochStorerFromConfig(config.BlockHeaderStorage)
+	if err != nil {
+		return nil, err
+	}
+
+	headerNonceHashUnit, err = storage.NewStorageUnitFromConf(
+		getCacherFromConfig(config.ShardHdrNonceHashStorage.Cache),
+		getShardHdrNonceHashDBFromConfig(config.ShardHdrNonceHashStorage.DB, shardCoordinator.SelfId()),
+		getBloomFromConfig(config.ShardHdrNonceHashStorage.Bloom))
 	if err != nil {
 		return nil, err
 	}
@@ -1542,16 +2333,74 @@ func createShardDataStoreFromConfig(config *config.Config) (dataRetriever.Storag
 		return nil, err
 	}
 
+	metaHdrNonceHashUnit, err = storage.NewStorageUnitFromConf(
+		getCacherFromConfig(config.MetaHdrNonceHashStorage.Cache),
+		getDBFromConfig(config.MetaHdrNonceHashStorage.DB),
+		getBloomFromConfig(config.MetaHdrNonceHashStorage.Bloom))
+	if err != nil {
+		return nil, err
+	}
+
+	stateDiffUnit, err = storage.NewStorageUnitFromConf(
+		getCacherFromConfig(config.StateDiffStorage.Cache),
+		getDBFromConfig(config.StateDiffStorage.DB),
+		getBloomFromConfig(config.StateDiffStorage.Bloom))
+	if err != nil {
+		return nil, err
+	}
+
+	bootstrapUnit, err = storage.NewStorageUnitFromConf(
+		getCacherFromConfig(config.BootstrapStorage.Cache),
+		getDBFromConfig(config.BootstrapStorage.DB),
+		getBloomFromConfig(config.BootstrapStorage.Bloom))
+	if err != nil {
+		return nil, err
+	}
+
 	store := dataRetriever.NewChainStorer()
 	store.AddStorer(dataRetriever.TransactionUnit, txUnit)
+	store.AddStorer(dataRetriever.TransactionResultUnit, txResultsUnit)
 	store.AddStorer(dataRetriever.MiniBlockUnit, miniBlockUnit)
 	store.AddStorer(dataRetriever.PeerChangesUnit, peerBlockUnit)
 	store.AddStorer(dataRetriever.BlockHeaderUnit, headerUnit)
+	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(shardCoordinator.SelfId())
+	store.AddStorer(hdrNonceHashDataUnit, headerNonceHashUnit)
 	store.AddStorer(dataRetriever.MetaBlockUnit, metachainHeaderUnit)
+	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, metaHdrNonceHashUnit)
+	store.AddStorer(dataRetriever.StateDiffUnit, stateDiffUnit)
+	store.AddStorer(dataRetriever.BootstrapUnit, bootstrapUnit)
 
 	return store, err
 }
 
+// createEpochStorerFromConfig creates a storer keeping its data per epoch if the storage config sets a number of
+// epochs to keep, or a storage unit with a single database otherwise
+func createEpochStorerFromConfig(cfg config.StorageConfig) (storage.Storer, error) {
+	// the units are checked before being returned, so a failure does not give a non nil storer holding a nil unit
+	if cfg.NumEpochsToKeep == 0 {
+		unit, err := storage.NewStorageUnitFromConf(
+			getCacherFromConfig(cfg.Cache),
+			getDBFromConfig(cfg.DB),
+			getBloomFromConfig(cfg.Bloom))
+		if err != nil {
+			return nil, err
+		}
+
+		return unit, nil
+	}
+
+	epochUnit, err := storage.NewEpochUnitFromConf(
+		getCacherFromConfig(cfg.Cache),
+		getDBFromConfig(cfg.DB),
+		cfg.NumEpochsToKeep,
+		cfg.Archive)
+	if err != nil {
+		return nil, err
+	}
+
+	return epochUnit, nil
+}
+
 func createMetaDataPoolFromConfig(
 	config *config.Config,
 	uint64ByteSliceConverter typeConverters.Uint64ByteSliceConverter,
@@ -1610,8 +2459,13 @@ func createMetaChainFromConfig(config *config.Config) (*blockchain.MetaChain, er
 	return metaChain, err
 }
 
-func createMetaChainDataStoreFromConfig(config *config.Config) (dataRetriever.StorageService, error) {
-	var peerDataUnit, shardDataUnit, metaBlockUnit, headerUnit *storage.Unit
+func createMetaChainDataStoreFromConfig(
+	config *config.Config,
+	shardCoordinator sharding.Coordinator,
+) (dataRetriever.StorageService, error) {
+
+	var peerDataUnit, shardDataUnit, metaBlockUnit, headerUnit, metaHdrNonceHashUnit, bootstrapUnit *storage.Unit
+	shardHdrNonceHashUnits := make([]*storage.Unit, shardCoordinator.NumberOfShards())
 	var err error
 
 	defer func() {
@@ -1629,6 +2483,17 @@ func createMetaChainDataStoreFromConfig(config *config.Config) (dataRetriever.St
 			if headerUnit != nil {
 				_ = headerUnit.DestroyUnit()
 			}
+			if metaHdrNonceHashUnit != nil {
+				_ = metaHdrNonceHashUnit.DestroyUnit()
+			}
+			for _, shardHdrNonceHashUnit := range shardHdrNonceHashUnits {
+				if shardHdrNonceHashUnit != nil {
+					_ = shardHdrNonceHashUnit.DestroyUnit()
+				}
+			}
+			if bootstrapUnit != nil {
+				_ = bootstrapUnit.DestroyUnit()
+			}
 		}
 	}()
 
@@ -1664,11 +2529,44 @@ func createMetaChainDataStoreFromConfig(config *config.Config) (dataRetriever.St
 		return nil, err
 	}
 
+	metaHdrNonceHashUnit, err = storage.NewStorageUnitFromConf(
+		getCacherFromConfig(config.MetaHdrNonceHashStorage.Cache),
+		getDBFromConfig(config.MetaHdrNonceHashStorage.DB),
+		getBloomFromConfig(config.MetaHdrNonceHashStorage.Bloom))
+	if err != nil {
+		return nil, err
+	}
+
+	// the metachain stores the headers of all shards, so each shard gets its own nonce to hash unit
+	for i := range shardHdrNonceHashUnits {
+		shardHdrNonceHashUnits[i], err = storage.NewStorageUnitFromConf(
+			getCacherFromConfig(config.ShardHdrNonceHashStorage.Cache),
+			getShardHdrNonceHashDBFromConfig(config.ShardHdrNonceHashStorage.DB, uint32(i)),
+			getBloomFromConfig(config.ShardHdrNonceHashStorage.Bloom))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bootstrapUnit, err = storage.NewStorageUnitFromConf(
+		getCacherFromConfig(config.BootstrapStorage.Cache),
+		getDBFromConfig(config.BootstrapStorage.DB),
+		getBloomFromConfig(config.BootstrapStorage.Bloom))
+	if err != nil {
+		return nil, err
+	}
+
 	store := dataRetriever.NewChainStorer()
 	store.AddStorer(dataRetriever.MetaBlockUnit, metaBlockUnit)
 	store.AddStorer(dataRetriever.MetaShardDataUnit, shardDataUnit)
 	store.AddStorer(dataRetriever.MetaPeerDataUnit, peerDataUnit)
 	store.AddStorer(dataRetriever.BlockHeaderUnit, headerUnit)
+	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, metaHdrNonceHashUnit)
+	for i, shardHdrNonceHashUnit := range shardHdrNonceHashUnits {
+		hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(uint32(i))
+		store.AddStorer(hdrNonceHashDataUnit, shardHdrNonceHashUnit)
+	}
+	store.AddStorer(dataRetriever.BootstrapUnit, bootstrapUnit)
 
 	return store, err
 }
@@ -1718,6 +2616,7 @@ func generateGenesisHeadersForMetachainInit(
 	genesisConfig *sharding.Genesis,
 	shardCoordinator sharding.Coordinator,
 	addressConverter state.AddressConverter,
+	trieType string,
 	hasher hashing.Hasher,
 	marshalizer marshal.Marshalizer,
 ) (map[uint32]data.HeaderHandler, error) {
@@ -1747,7 +2646,11 @@ func generateGenesisHeadersForMetachainInit(
 			return nil, err
 		}
 
-		accounts := generateInMemoryAccountsAdapter(accountFactory, hasher, marshalizer)
+		accounts, err := generateInMemoryAccountsAdapter(accountFactory, trieType, hasher, marshalizer)
+		if err != nil {
+			return nil, err
+		}
+
 		initialBalances, err := genesisConfig.InitialNodesBalances(newShardCoordinator, addressConverter)
 		if err != nil {
 			return nil, err
@@ -1770,17 +2673,50 @@ func generateGenesisHeadersForMetachainInit(
 	return shardsGenesisBlocks, nil
 }
 
+// createMetaSupplyChecker creates the supply checker of a metachain node, which tracks all the shards and starts from
+// the sum of their genesis balances
+func createMetaSupplyChecker(
+	config *config.Config,
+	genesisConfig *sharding.Genesis,
+	shardCoordinator sharding.Coordinator,
+	addressConverter state.AddressConverter,
+) (process.SupplyChecker, error) {
+
+	genesisSupply := big.NewInt(0)
+	trackedShards := make([]uint32, 0, shardCoordinator.NumberOfShards())
+	for shardId := uint32(0); shardId < shardCoordinator.NumberOfShards(); shardId++ {
+		newShardCoordinator, err := sharding.NewMultiShardCoordinator(shardCoordinator.NumberOfShards(), shardId)
+		if err != nil {
+			return nil, err
+		}
+
+		initialBalances, err := genesisConfig.InitialNodesBalances(newShardCoordinator, addressConverter)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, balance := range initialBalances {
+			genesisSupply.Add(genesisSupply, balance)
+		}
+		trackedShards = append(trackedShards, shardId)
+	}
+
+	return economics.NewSupplyChecker(genesisSupply, trackedShards, config.SupplyInvariant.HaltOnViolation)
+}
+
 func generateInMemoryAccountsAdapter(
 	accountFactory state.AccountFactory,
+	trieType string,
 	hasher hashing.Hasher,
 	marshalizer marshal.Marshalizer,
-) state.AccountsAdapter {
+) (state.AccountsAdapter, error) {
 
-	dbw, _ := trie.NewDBWriteCache(createMemUnit())
-	tr, _ := trie.NewTrie(make([]byte, 32), dbw, hasher)
-	adb, _ := state.NewAccountsDB(tr, sha256.Sha256{}, marshalizer, accountFactory)
+	tr, err := factoryTrie.NewStateTrie(trieType, createMemUnit(), marshalizer, hasher)
+	if err != nil {
+		return nil, err
+	}
 
-	return adb
+	return state.NewAccountsDB(tr, sha256.Sha256{}, marshalizer, accountFactory)
 }
 
 func createMemUnit() storage.Storer {
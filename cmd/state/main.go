@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/numbatx/gn-numbat/data/block"
+	factoryState "github.com/numbatx/gn-numbat/data/state/factory"
+	"github.com/numbatx/gn-numbat/data/state/snapshot"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/hashing/blake2b"
+	"github.com/numbatx/gn-numbat/hashing/sha256"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/storage"
+	"github.com/urfave/cli"
+)
+
+const cacheSize = 100000
+
+var (
+	stateHelpTemplate = `NAME:
+   {{.Name}} - {{.Usage}}
+USAGE:
+   {{.HelpName}} {{if .VisibleFlags}}[global options]{{end}} command [command options]
+   {{if len .Authors}}
+AUTHOR:
+   {{range .Authors}}{{ . }}{{end}}
+   {{end}}{{if .Commands}}
+COMMANDS:
+   {{range .Commands}}{{join .Names ", "}}{{ "\t" }}{{.Usage}}
+   {{end}}{{end}}{{if .VisibleFlags}}
+GLOBAL OPTIONS:
+   {{range .VisibleFlags}}{{.}}
+   {{end}}{{end}}
+VERSION:
+   {{.Version}}
+   {{end}}
+`
+	// accountsDB defines the AccountsTrie database of the node the state is exported from
+	accountsDB = cli.StringFlag{
+		Name:  "accounts-db",
+		Usage: "Path of the AccountsTrie LevelDB database holding the state in the trie format",
+		Value: "",
+	}
+	// headersDB defines the BlockHeaders database of the node the state is exported from
+	headersDB = cli.StringFlag{
+		Name:  "headers-db",
+		Usage: "Path of the BlockHeaders LevelDB database holding the header the state is exported at",
+		Value: "",
+	}
+	// headerHash defines the hash of the block header whose state is exported
+	headerHash = cli.StringFlag{
+		Name:  "header-hash",
+		Usage: "Hex encoded hash of the final shard block header whose state is exported",
+		Value: "",
+	}
+	// output defines the file the state snapshot is written to
+	output = cli.StringFlag{
+		Name:  "output",
+		Usage: "Path of the state snapshot file to be written",
+		Value: "state.snapshot",
+	}
+	// chunkSize defines the maximum number of records of a state snapshot chunk
+	chunkSize = cli.IntFlag{
+		Name:  "chunk-size",
+		Usage: "Maximum number of trie leaves written in a checksummed chunk of the snapshot",
+		Value: 10000,
+	}
+	// hasherType defines the hasher used by the node, as set in config.toml
+	hasherType = cli.StringFlag{
+		Name:  "hasher",
+		Usage: "Hasher used by the node. Available options: blake2b, sha256",
+		Value: "blake2b",
+	}
+	// marshalizerType defines the marshalizer used by the node, as set in config.toml
+	marshalizerType = cli.StringFlag{
+		Name:  "marshalizer",
+		Usage: "Marshalizer used by the node. Available options: json",
+		Value: "json",
+	}
+)
+
+func main() {
+	app := cli.NewApp()
+	cli.AppHelpTemplate = stateHelpTemplate
+	app.Name = "State CLI App"
+	app.Usage = "This tool exports the accounts state of a shard node at a final block in a state snapshot, " +
+		"which a new node imports with the --state-snapshot flag instead of replaying all the blocks"
+	app.Version = "v0.0.1"
+	app.Authors = []cli.Author{
+		{
+			Name:  "The Team Numbat",
+			Email: "contact@numbatx.com",
+		},
+	}
+	app.Commands = []cli.Command{
+		{
+			Name:  "export",
+			Usage: "Writes the state found at the provided block header in a state snapshot",
+			Flags: []cli.Flag{accountsDB, headersDB, headerHash, output, chunkSize, hasherType, marshalizerType},
+			Action: func(c *cli.Context) error {
+				return export(c)
+			},
+		},
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+func export(ctx *cli.Context) error {
+	if ctx.String(accountsDB.Name) == "" || ctx.String(headersDB.Name) == "" {
+		return errors.New("the accounts and the headers databases have to be provided")
+	}
+
+	hash, err := hex.DecodeString(ctx.String(headerHash.Name))
+	if err != nil || len(hash) == 0 {
+		return errors.New("a valid header hash has to be provided")
+	}
+
+	hasher, err := getHasher(ctx.String(hasherType.Name))
+	if err != nil {
+		return err
+	}
+
+	marshalizer, err := getMarshalizer(ctx.String(marshalizerType.Name))
+	if err != nil {
+		return err
+	}
+
+	headers, headersPersister, err := createStorer(ctx.String(headersDB.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = headersPersister.Close()
+	}()
+
+	headerBuff, err := headers.Get(hash)
+	if err != nil {
+		return errors.New("could not find the header: " + err.Error())
+	}
+
+	header := &block.Header{}
+	err = marshalizer.Unmarshal(header, headerBuff)
+	if err != nil {
+		return err
+	}
+
+	accounts, accountsPersister, err := createStorer(ctx.String(accountsDB.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = accountsPersister.Close()
+	}()
+
+	file, err := os.Create(ctx.String(output.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	exporter, err := snapshot.NewExporter(marshalizer, hasher, factoryState.NewAccountCreator())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Exporting the state of block %d...\n", header.Nonce)
+	err = exporter.Export(header, accounts, file, ctx.Int(chunkSize.Name))
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("State exported, root hash: %s\n", hex.EncodeToString(header.RootHash))
+
+	return nil
+}
+
+func createStorer(path string) (storage.Storer, storage.Persister, error) {
+	cache, err := storage.NewCache(storage.LRUCache, cacheSize, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	persister, err := storage.NewDB(storage.LvlDB, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	storer, err := storage.NewStorageUnit(cache, persister)
+	if err != nil {
+		_ = persister.Close()
+		return nil, nil, err
+	}
+
+	return storer, persister, nil
+}
+
+func getHasher(hasherType string) (hashing.Hasher, error) {
+	switch hasherType {
+	case "sha256":
+		return sha256.Sha256{}, nil
+	case "blake2b":
+		return blake2b.Blake2b{}, nil
+	}
+
+	return nil, errors.New("unknown hasher type " + hasherType)
+}
+
+func getMarshalizer(marshalizerType string) (marshal.Marshalizer, error) {
+	switch marshalizerType {
+	case "json":
+		return marshal.JsonMarshalizer{}, nil
+	}
+
+	return nil, errors.New("unknown marshalizer type " + marshalizerType)
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/numbatx/gn-numbat/data/state"
+)
+
+const (
+	csvFormat  = "csv"
+	jsonFormat = "json"
+)
+
+// dumpedAccount is the representation of an account in the dump, with the byte fields hex encoded
+type dumpedAccount struct {
+	Address  string `json:"address"`
+	Nonce    uint64 `json:"nonce"`
+	Balance  string `json:"balance"`
+	CodeHash string `json:"codeHash"`
+	RootHash string `json:"rootHash"`
+}
+
+// dumpWriter writes the accounts, as they are read, in a csv file having a header line or in a json array
+type dumpWriter struct {
+	w          io.Writer
+	format     string
+	csvWriter  *csv.Writer
+	numWritten int
+}
+
+func newDumpWriter(w io.Writer, format string) (*dumpWriter, error) {
+	dw := &dumpWriter{
+		w:      w,
+		format: format,
+	}
+
+	if format == jsonFormat {
+		_, err := io.WriteString(w, "[")
+		return dw, err
+	}
+
+	dw.csvWriter = csv.NewWriter(w)
+	err := dw.csvWriter.Write([]string{"address", "nonce", "balance", "codeHash", "rootHash"})
+
+	return dw, err
+}
+
+func (dw *dumpWriter) write(account *state.Account) error {
+	entry := dumpedAccount{
+		Address:  hex.EncodeToString(account.AddressContainer().Bytes()),
+		Nonce:    account.Nonce,
+		Balance:  account.Balance.String(),
+		CodeHash: hex.EncodeToString(account.CodeHash),
+		RootHash: hex.EncodeToString(account.RootHash),
+	}
+	dw.numWritten++
+
+	if dw.format == jsonFormat {
+		return dw.writeJSON(entry)
+	}
+
+	return dw.csvWriter.Write([]string{
+		entry.Address,
+		strconv.FormatUint(entry.Nonce, 10),
+		entry.Balance,
+		entry.CodeHash,
+		entry.RootHash,
+	})
+}
+
+func (dw *dumpWriter) writeJSON(entry dumpedAccount) error {
+	buff, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n  "
+	if dw.numWritten == 1 {
+		separator = "\n  "
+	}
+
+	_, err = io.WriteString(dw.w, separator+string(buff))
+	return err
+}
+
+// close ends the json array or flushes the csv records
+func (dw *dumpWriter) close() error {
+	if dw.format == jsonFormat {
+		_, err := io.WriteString(dw.w, "\n]\n")
+		return err
+	}
+
+	dw.csvWriter.Flush()
+	return dw.csvWriter.Error()
+}
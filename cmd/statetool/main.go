@@ -0,0 +1,331 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/numbatx/gn-numbat/config"
+	"github.com/numbatx/gn-numbat/core"
+	"github.com/numbatx/gn-numbat/core/logger"
+	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/data/state"
+	factoryState "github.com/numbatx/gn-numbat/data/state/factory"
+	"github.com/numbatx/gn-numbat/data/trie/factory"
+	"github.com/numbatx/gn-numbat/data/typeConverters/uint64ByteSlice"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/hashing/blake2b"
+	"github.com/numbatx/gn-numbat/hashing/sha256"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/storage"
+	"github.com/urfave/cli"
+)
+
+var (
+	stateToolHelpTemplate = `NAME:
+   {{.Name}} - {{.Usage}}
+USAGE:
+   {{.HelpName}} {{if .VisibleFlags}}[global options]{{end}}
+   {{if len .Authors}}
+AUTHOR:
+   {{range .Authors}}{{ . }}{{end}}
+   {{end}}{{if .Commands}}
+GLOBAL OPTIONS:
+   {{range .VisibleFlags}}{{.}}
+   {{end}}
+VERSION:
+   {{.Version}}
+   {{end}}
+`
+	// configurationFile defines the configuration file of the node whose state is inspected
+	configurationFile = cli.StringFlag{
+		Name:  "config",
+		Usage: "The node's main configuration file",
+		Value: "./config/config.toml",
+	}
+	// dbPath defines the folder holding the databases of the node whose state is inspected
+	dbPath = cli.StringFlag{
+		Name:  "db-path",
+		Usage: "The folder holding the node's databases",
+		Value: config.DefaultPath(),
+	}
+	// rootHash defines the root hash of the inspected state
+	rootHash = cli.StringFlag{
+		Name:  "root-hash",
+		Usage: "Hex encoded root hash of the inspected state. The state of the latest committed block is inspected if empty",
+		Value: "",
+	}
+	// shardId defines the shard of the node whose state is inspected
+	shardId = cli.UintFlag{
+		Name:  "shard-id",
+		Usage: "The shard of the node, whose committed headers are looked up when no root hash is provided",
+		Value: 0,
+	}
+	// topHolders defines how many of the accounts with the highest balances are displayed
+	topHolders = cli.IntFlag{
+		Name:  "top",
+		Usage: "Number of accounts with the highest balances to be displayed",
+		Value: 10,
+	}
+	// output defines the file the accounts are dumped in
+	output = cli.StringFlag{
+		Name:  "output",
+		Usage: "Path of the file the accounts are dumped in. No dump is written if empty",
+		Value: "",
+	}
+	// outputFormat defines the format of the accounts dump
+	outputFormat = cli.StringFlag{
+		Name:  "format",
+		Usage: "Format of the accounts dump. Available options: csv, json",
+		Value: csvFormat,
+	}
+)
+
+func main() {
+	log := logger.DefaultLogger()
+
+	app := cli.NewApp()
+	cli.AppHelpTemplate = stateToolHelpTemplate
+	app.Name = "State tool CLI App"
+	app.Usage = "This tool reads the accounts state of a shard node from its AccountsTrie database, without modifying it, " +
+		"and displays the account count, the total supply, the balances histogram and the top holders, " +
+		"optionally dumping all the accounts"
+	app.Flags = []cli.Flag{configurationFile, dbPath, rootHash, shardId, topHolders, output, outputFormat}
+	app.Version = "v0.0.1"
+	app.Authors = []cli.Author{
+		{
+			Name:  "The Team Numbat",
+			Email: "contact@numbatx.com",
+		},
+	}
+
+	app.Action = func(c *cli.Context) error {
+		return inspect(c, log)
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+func inspect(ctx *cli.Context, log *logger.Logger) error {
+	format := ctx.GlobalString(outputFormat.Name)
+	if format != csvFormat && format != jsonFormat {
+		return errors.New("unknown dump format " + format)
+	}
+	if ctx.GlobalInt(topHolders.Name) < 0 {
+		return errors.New("the number of top holders can not be negative")
+	}
+
+	generalConfig := &config.Config{}
+	err := core.LoadTomlFile(generalConfig, ctx.GlobalString(configurationFile.Name), log)
+	if err != nil {
+		return err
+	}
+
+	hasher, err := getHasherFromConfig(generalConfig)
+	if err != nil {
+		return err
+	}
+
+	marshalizer, err := getMarshalizerFromConfig(generalConfig)
+	if err != nil {
+		return err
+	}
+
+	path := ctx.GlobalString(dbPath.Name)
+	root, err := getRootHash(ctx, path, generalConfig, marshalizer)
+	if err != nil {
+		return err
+	}
+
+	accountsTrieStorage, err := createReadOnlyUnit(path, generalConfig.AccountsTrieStorage)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = accountsTrieStorage.Close()
+	}()
+
+	tr, err := factory.NewStateTrie(generalConfig.StateTrie.Type, accountsTrieStorage, marshalizer, hasher)
+	if err != nil {
+		return err
+	}
+
+	accounts, err := state.NewAccountsDB(tr, hasher, marshalizer, factoryState.NewAccountCreator())
+	if err != nil {
+		return err
+	}
+
+	var dw *dumpWriter
+	dumpPath := ctx.GlobalString(output.Name)
+	if dumpPath != "" {
+		file, err := os.Create(dumpPath)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = file.Close()
+		}()
+
+		dw, err = newDumpWriter(file, format)
+		if err != nil {
+			return err
+		}
+	}
+
+	stats := newStatistics(ctx.GlobalInt(topHolders.Name))
+	fmt.Printf("Reading the accounts of the state with root hash %s...\n", hex.EncodeToString(root))
+	err = accounts.ForEachAccount(root, func(accountHandler state.AccountHandler) error {
+		account, ok := accountHandler.(*state.Account)
+		if !ok {
+			return errors.New("account is not of type with balance and nonce")
+		}
+
+		stats.add(account)
+		if dw == nil {
+			return nil
+		}
+
+		return dw.write(account)
+	})
+	if err != nil {
+		return err
+	}
+
+	if dw != nil {
+		err = dw.close()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Accounts dumped in %s\n", dumpPath)
+	}
+
+	stats.display(os.Stdout)
+
+	return nil
+}
+
+// getRootHash returns the provided root hash or, if none was provided, the root hash of the latest committed block
+func getRootHash(ctx *cli.Context, path string, generalConfig *config.Config, marshalizer marshal.Marshalizer) ([]byte, error) {
+	if ctx.GlobalString(rootHash.Name) != "" {
+		root, err := hex.DecodeString(ctx.GlobalString(rootHash.Name))
+		if err != nil {
+			return nil, errors.New("invalid root hash: " + err.Error())
+		}
+
+		return root, nil
+	}
+
+	nonceHashConfig := generalConfig.ShardHdrNonceHashStorage
+	nonceHashConfig.DB.FilePath = fmt.Sprintf("%s_%d", nonceHashConfig.DB.FilePath, ctx.GlobalUint(shardId.Name))
+	nonceHashStorage, err := createReadOnlyUnit(path, nonceHashConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = nonceHashStorage.Close()
+	}()
+
+	headerStorage, err := createReadOnlyUnit(path, generalConfig.BlockHeaderStorage)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = headerStorage.Close()
+	}()
+
+	header, err := getLatestHeader(nonceHashStorage, headerStorage, marshalizer)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("Latest committed block has nonce %d\n", header.Nonce)
+
+	return header.RootHash, nil
+}
+
+// getLatestHeader returns the committed shard block header with the highest nonce. As the committed blocks have
+// consecutive nonces starting from 1, the highest nonce is searched for in the nonce to hash storage by doubling
+// the nonce until it is missing and then halving the interval
+func getLatestHeader(nonceHashStorage storage.Storer, headerStorage storage.Storer, marshalizer marshal.Marshalizer) (*block.Header, error) {
+	converter := uint64ByteSlice.NewBigEndianConverter()
+	isCommitted := func(nonce uint64) bool {
+		return nonceHashStorage.Has(converter.ToByteSlice(nonce)) == nil
+	}
+
+	if !isCommitted(1) {
+		return nil, errors.New("no committed block found, the root hash has to be provided")
+	}
+
+	low, high := uint64(1), uint64(2)
+	for isCommitted(high) {
+		low, high = high, high*2
+	}
+	for high-low > 1 {
+		middle := low + (high-low)/2
+		if isCommitted(middle) {
+			low = middle
+		} else {
+			high = middle
+		}
+	}
+
+	hash, err := nonceHashStorage.Get(converter.ToByteSlice(low))
+	if err != nil {
+		return nil, err
+	}
+
+	buff, err := headerStorage.Get(hash)
+	if err != nil {
+		return nil, errors.New("could not find the latest header: " + err.Error())
+	}
+
+	header := &block.Header{}
+	err = marshalizer.Unmarshal(header, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// createReadOnlyUnit opens the database of the storage unit as read only. The bloom filter is left out as it is
+// only kept in memory, so an empty one would hide the stored keys
+func createReadOnlyUnit(path string, cfg config.StorageConfig) (*storage.Unit, error) {
+	cacheConfig := storage.CacheConfig{
+		Size:   cfg.Cache.Size,
+		Type:   storage.CacheType(cfg.Cache.Type),
+		Shards: cfg.Cache.Shards,
+	}
+	dbConfig := storage.DBConfig{
+		FilePath: filepath.Join(path, cfg.DB.FilePath),
+		Type:     storage.LvlDBReadOnly,
+	}
+
+	return storage.NewStorageUnitFromConf(cacheConfig, dbConfig, storage.BloomConfig{})
+}
+
+func getHasherFromConfig(cfg *config.Config) (hashing.Hasher, error) {
+	switch cfg.Hasher.Type {
+	case "sha256":
+		return sha256.Sha256{}, nil
+	case "blake2b":
+		return blake2b.Blake2b{}, nil
+	}
+
+	return nil, errors.New("no hasher provided in config file")
+}
+
+func getMarshalizerFromConfig(cfg *config.Config) (marshal.Marshalizer, error) {
+	switch cfg.Marshalizer.Type {
+	case "json":
+		return marshal.JsonMarshalizer{}, nil
+	}
+
+	return nil, errors.New("no marshalizer provided in config file")
+}
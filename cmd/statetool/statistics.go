@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/numbatx/gn-numbat/data/state"
+)
+
+// statistics gathers the account count, the total supply, the balances histogram and the top holders of a state.
+// The histogram groups the balances by their number of decimal digits, so bucket k holds the balances in the
+// [10^(k-1), 10^k) interval and bucket 0 the zero balances
+type statistics struct {
+	numAccounts   uint64
+	numWithCode   uint64
+	totalSupply   *big.Int
+	histogram     map[int]uint64
+	maxBucket     int
+	maxTopHolders int
+	topHolders    []*state.Account
+}
+
+func newStatistics(maxTopHolders int) *statistics {
+	return &statistics{
+		totalSupply:   big.NewInt(0),
+		histogram:     make(map[int]uint64),
+		maxTopHolders: maxTopHolders,
+		topHolders:    make([]*state.Account, 0, maxTopHolders+1),
+	}
+}
+
+func (s *statistics) add(account *state.Account) {
+	if account.Balance == nil {
+		account.Balance = big.NewInt(0)
+	}
+	balance := account.Balance
+
+	s.numAccounts++
+	if len(account.CodeHash) > 0 {
+		s.numWithCode++
+	}
+	s.totalSupply.Add(s.totalSupply, balance)
+
+	bucket := 0
+	if balance.Sign() > 0 {
+		bucket = len(balance.String())
+	}
+	s.histogram[bucket]++
+	if bucket > s.maxBucket {
+		s.maxBucket = bucket
+	}
+
+	s.addTopHolder(account)
+}
+
+// addTopHolder keeps the accounts with the highest balances sorted in descending order
+func (s *statistics) addTopHolder(account *state.Account) {
+	if s.maxTopHolders == 0 {
+		return
+	}
+
+	balance := account.Balance
+	numHolders := len(s.topHolders)
+	if numHolders == s.maxTopHolders && balance.Cmp(s.topHolders[numHolders-1].Balance) <= 0 {
+		return
+	}
+
+	position := sort.Search(numHolders, func(i int) bool {
+		return s.topHolders[i].Balance.Cmp(balance) < 0
+	})
+	s.topHolders = append(s.topHolders, nil)
+	copy(s.topHolders[position+1:], s.topHolders[position:])
+	s.topHolders[position] = account
+
+	if len(s.topHolders) > s.maxTopHolders {
+		s.topHolders = s.topHolders[:s.maxTopHolders]
+	}
+}
+
+func (s *statistics) display(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "Accounts: %d\n", s.numAccounts)
+	_, _ = fmt.Fprintf(w, "Accounts with code: %d\n", s.numWithCode)
+	_, _ = fmt.Fprintf(w, "Total supply: %s\n", s.totalSupply.String())
+
+	_, _ = fmt.Fprintln(w, "Balances histogram:")
+	_, _ = fmt.Fprintf(w, "  %-24s %d\n", "0", s.histogram[0])
+	for bucket := 1; bucket <= s.maxBucket; bucket++ {
+		interval := fmt.Sprintf("[10^%d, 10^%d)", bucket-1, bucket)
+		_, _ = fmt.Fprintf(w, "  %-24s %d\n", interval, s.histogram[bucket])
+	}
+
+	if s.maxTopHolders == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, "Top holders:")
+	for i, account := range s.topHolders {
+		_, _ = fmt.Fprintf(w, "  %3d. %s %s\n",
+			i+1,
+			hex.EncodeToString(account.AddressContainer().Bytes()),
+			account.Balance.String())
+	}
+}
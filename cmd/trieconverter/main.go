@@ -0,0 +1,196 @@
+package main
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/converter"
+	factoryState "github.com/numbatx/gn-numbat/data/state/factory"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/hashing/blake2b"
+	"github.com/numbatx/gn-numbat/hashing/sha256"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/storage"
+	"github.com/urfave/cli"
+)
+
+const cacheSize = 100000
+
+var (
+	trieConverterHelpTemplate = `NAME:
+   {{.Name}} - {{.Usage}}
+USAGE:
+   {{.HelpName}} {{if .VisibleFlags}}[global options]{{end}}
+   {{if len .Authors}}
+AUTHOR:
+   {{range .Authors}}{{ . }}{{end}}
+   {{end}}{{if .Commands}}
+GLOBAL OPTIONS:
+   {{range .VisibleFlags}}{{.}}
+   {{end}}
+VERSION:
+   {{.Version}}
+   {{end}}
+`
+	// sourceDB defines the AccountsTrie database, in the trie format, which will be converted
+	sourceDB = cli.StringFlag{
+		Name:  "source-db",
+		Usage: "Path of the AccountsTrie LevelDB database holding the state in the trie format",
+		Value: "",
+	}
+	// destinationDB defines the database the state will be written to, in the trie2 format
+	destinationDB = cli.StringFlag{
+		Name:  "destination-db",
+		Usage: "Path of the LevelDB database the state will be written to in the trie2 format",
+		Value: "",
+	}
+	// rootHash defines the root hash of the state which will be converted
+	rootHash = cli.StringFlag{
+		Name:  "root-hash",
+		Usage: "Hex encoded root hash of the state to be converted, usually the root hash of the last committed block",
+		Value: "",
+	}
+	// hasherType defines the hasher used by the node, as set in config.toml
+	hasherType = cli.StringFlag{
+		Name:  "hasher",
+		Usage: "Hasher used by the node. Available options: blake2b, sha256",
+		Value: "blake2b",
+	}
+	// marshalizerType defines the marshalizer used by the node, as set in config.toml
+	marshalizerType = cli.StringFlag{
+		Name:  "marshalizer",
+		Usage: "Marshalizer used by the node. Available options: json",
+		Value: "json",
+	}
+	// metachain defines if the state holds metachain accounts
+	metachain = cli.BoolFlag{
+		Name:  "metachain",
+		Usage: "The state belongs to a metachain node",
+	}
+)
+
+func main() {
+	app := cli.NewApp()
+	cli.AppHelpTemplate = trieConverterHelpTemplate
+	app.Name = "Trie converter CLI App"
+	app.Usage = "This tool rebuilds an AccountsTrie database kept in the trie format as a trie2 database, " +
+		"so a node can be switched to the trie2 state trie"
+	app.Flags = []cli.Flag{sourceDB, destinationDB, rootHash, hasherType, marshalizerType, metachain}
+	app.Version = "v0.0.1"
+	app.Authors = []cli.Author{
+		{
+			Name:  "The Team Numbat",
+			Email: "contact@numbatx.com",
+		},
+	}
+
+	app.Action = func(c *cli.Context) error {
+		return convert(c)
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+func convert(ctx *cli.Context) error {
+	if ctx.GlobalString(sourceDB.Name) == "" || ctx.GlobalString(destinationDB.Name) == "" {
+		return errors.New("the source and destination databases have to be provided")
+	}
+
+	root, err := hex.DecodeString(ctx.GlobalString(rootHash.Name))
+	if err != nil {
+		return errors.New("invalid root hash: " + err.Error())
+	}
+
+	hasher, err := getHasher(ctx.GlobalString(hasherType.Name))
+	if err != nil {
+		return err
+	}
+
+	marshalizer, err := getMarshalizer(ctx.GlobalString(marshalizerType.Name))
+	if err != nil {
+		return err
+	}
+
+	var accountFactory state.AccountFactory = factoryState.NewAccountCreator()
+	if ctx.GlobalBool(metachain.Name) {
+		accountFactory = factoryState.NewMetaAccountCreator()
+	}
+
+	source, sourcePersister, err := createStorer(ctx.GlobalString(sourceDB.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = sourcePersister.Close()
+	}()
+
+	destination, destinationPersister, err := createStorer(ctx.GlobalString(destinationDB.Name))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = destinationPersister.Close()
+	}()
+
+	trieConverter, err := converter.NewTrieConverter(marshalizer, hasher, accountFactory)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("Converting state...")
+	newRootHash, err := trieConverter.Convert(root, source, destination)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("State converted, trie2 root hash: %s\n", hex.EncodeToString(newRootHash))
+
+	return nil
+}
+
+func createStorer(path string) (storage.Storer, storage.Persister, error) {
+	cache, err := storage.NewCache(storage.LRUCache, cacheSize, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	persister, err := storage.NewDB(storage.LvlDB, path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	storer, err := storage.NewStorageUnit(cache, persister)
+	if err != nil {
+		_ = persister.Close()
+		return nil, nil, err
+	}
+
+	return storer, persister, nil
+}
+
+func getHasher(hasherType string) (hashing.Hasher, error) {
+	switch hasherType {
+	case "sha256":
+		return sha256.Sha256{}, nil
+	case "blake2b":
+		return blake2b.Blake2b{}, nil
+	}
+
+	return nil, errors.New("unknown hasher type " + hasherType)
+}
+
+func getMarshalizer(marshalizerType string) (marshal.Marshalizer, error) {
+	switch marshalizerType {
+	case "json":
+		return marshal.JsonMarshalizer{}, nil
+	}
+
+	return nil, errors.New("unknown marshalizer type " + marshalizerType)
+}
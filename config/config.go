@@ -24,6 +24,10 @@ type StorageConfig struct {
 	Cache CacheConfig       `json:"cache"`
 	DB    DBConfig          `json:"db"`
 	Bloom BloomFilterConfig `json:"bloom"`
+	// NumEpochsToKeep, when greater than 0, makes the storage keep one database per epoch and drop the databases of
+	// the epochs older than the last NumEpochsToKeep ones, unless Archive is set
+	NumEpochsToKeep uint32 `json:"numEpochsToKeep"`
+	Archive         bool   `json:"archive"`
 }
 
 // LoggerConfig will map the json logger configuration
@@ -49,12 +53,21 @@ type Config struct {
 	PeerBlockBodyStorage StorageConfig
 	BlockHeaderStorage   StorageConfig
 	TxStorage            StorageConfig
+	TxResultsStorage     StorageConfig
+
+	ShardHdrNonceHashStorage StorageConfig
+	MetaHdrNonceHashStorage  StorageConfig
+	StateDiffStorage         StorageConfig
+	BootstrapStorage         StorageConfig
 
 	ShardDataStorage StorageConfig
 	MetaBlockStorage StorageConfig
 	PeerDataStorage  StorageConfig
 
 	AccountsTrieStorage StorageConfig
+	StateTrie           TypeConfig
+	StatePruning        StatePruningConfig
+	StateSync           StateSyncConfig
 	BadBlocksCache      CacheConfig
 
 	TxBlockBodyDataPool       CacheConfig
@@ -64,6 +77,7 @@ type Config struct {
 	BlockHeaderNoncesDataPool CacheConfig
 	TxDataPool                CacheConfig
 	MetaBlockBodyDataPool     CacheConfig
+	TrieNodesDataPool         CacheConfig
 
 	MiniBlockHeaderHashesDataPool CacheConfig
 	ShardHeadersDataPool          CacheConfig
@@ -75,10 +89,15 @@ type Config struct {
 	MultisigHasher TypeConfig
 	Marshalizer    TypeConfig
 
-	ResourceStats   ResourceStatsConfig
-	Heartbeat       HeartbeatConfig
-	GeneralSettings GeneralSettingsConfig
-	Consensus       TypeConfig
+	ResourceStats     ResourceStatsConfig
+	Heartbeat         HeartbeatConfig
+	GeneralSettings   GeneralSettingsConfig
+	Consensus         TypeConfig
+	FeeSettings       FeeSettingsConfig
+	BlockLimits       BlockLimitsConfig
+	TxPoolSettings    TxPoolSettingsConfig
+	ParallelExecution ParallelExecutionConfig
+	SupplyInvariant   SupplyInvariantConfig
 }
 
 // NodeConfig will hold basic p2p settings
@@ -126,4 +145,94 @@ type HeartbeatConfig struct {
 // GeneralSettingsConfig will hold the general settings for a node
 type GeneralSettingsConfig struct {
 	DestinationShardAsObserver string
+	ChainID                    string
+	RoundsPerEpoch             uint32
+}
+
+// FeeSettingsConfig will hold the transaction fee settings. The default rewards address can be overridden for
+// specific shards, as the fees of a block are credited in the shard that created it
+type FeeSettingsConfig struct {
+	MinGasPrice    uint64
+	MinGasLimit    uint64
+	GasPerDataByte uint64
+	RewardsAddress string
+	Shards         []ShardFeeSettingsConfig
+}
+
+// ShardFeeSettingsConfig will hold the rewards address of a specific shard
+type ShardFeeSettingsConfig struct {
+	ShardID        uint32
+	RewardsAddress string
+}
+
+// RewardsAddressForShard returns the hex encoded address the fees of the blocks created by the given shard are
+// credited to
+func (fsc *FeeSettingsConfig) RewardsAddressForShard(shardID uint32) string {
+	for _, shardSettings := range fsc.Shards {
+		if shardSettings.ShardID == shardID {
+			return shardSettings.RewardsAddress
+		}
+	}
+
+	return fsc.RewardsAddress
+}
+
+// TxPoolSettingsConfig will hold the policy of the transactions pool
+type TxPoolSettingsConfig struct {
+	MaxTxsPerSender           uint32
+	MinGasPriceBumpPercentage uint32
+}
+
+// ParallelExecutionConfig will hold the settings of the parallel execution of the miniblocks' transactions
+type ParallelExecutionConfig struct {
+	Enabled    bool
+	MaxWorkers uint32
+}
+
+// SupplyInvariantConfig will hold the settings of the supply conservation checks done when committing blocks
+type SupplyInvariantConfig struct {
+	Enabled         bool
+	HaltOnViolation bool
+}
+
+// StatePruningConfig will hold the state pruning settings. In archive mode every committed state is written to the
+// accounts trie storage, otherwise only the last RetainedFinalRoots final states are kept
+type StatePruningConfig struct {
+	ArchiveMode        bool
+	RetainedFinalRoots uint32
+}
+
+// StateSyncConfig will hold the settings used while downloading the accounts state from peers. BatchSize trie nodes
+// are requested at once and a request is retried after RequestTimeInMs, at most MaxRequestRetries times in a row
+type StateSyncConfig struct {
+	BatchSize         int
+	RequestTimeInMs   int
+	MaxRequestRetries int
+}
+
+// BlockLimitsConfig will hold the limits of the blocks created by a shard. The default limits can be overridden
+// for specific shards
+type BlockLimitsConfig struct {
+	MaxTxsInBlock      uint32
+	MaxGasLimitInBlock uint64
+	Shards             []ShardBlockLimitsConfig
+}
+
+// ShardBlockLimitsConfig will hold the block limits of a specific shard
+type ShardBlockLimitsConfig struct {
+	ShardID            uint32
+	MaxTxsInBlock      uint32
+	MaxGasLimitInBlock uint64
+}
+
+// LimitsForShard returns the maximum number of transactions and the maximum gas limit of a block created by the
+// given shard
+func (blc *BlockLimitsConfig) LimitsForShard(shardID uint32) (uint32, uint64) {
+	for _, shardLimits := range blc.Shards {
+		if shardLimits.ShardID == shardID {
+			return shardLimits.MaxTxsInBlock, shardLimits.MaxGasLimitInBlock
+		}
+	}
+
+	return blc.MaxTxsInBlock, blc.MaxGasLimitInBlock
 }
@@ -115,3 +115,44 @@ func TestTomlParser(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, cfgExpected, cfg)
 }
+
+func TestTomlParser_BlockLimitsWithShardOverride(t *testing.T) {
+	testString := `
+[BlockLimits]
+	MaxTxsInBlock = 100
+	MaxGasLimitInBlock = 1000
+	[[BlockLimits.Shards]]
+		ShardID = 1
+		MaxTxsInBlock = 10
+		MaxGasLimitInBlock = 50
+`
+	cfg := Config{}
+
+	err := toml.Unmarshal([]byte(testString), &cfg)
+	assert.Nil(t, err)
+
+	maxTxs, maxGasLimit := cfg.BlockLimits.LimitsForShard(0)
+	assert.Equal(t, uint32(100), maxTxs)
+	assert.Equal(t, uint64(1000), maxGasLimit)
+
+	maxTxs, maxGasLimit = cfg.BlockLimits.LimitsForShard(1)
+	assert.Equal(t, uint32(10), maxTxs)
+	assert.Equal(t, uint64(50), maxGasLimit)
+}
+
+func TestTomlParser_FeeSettingsWithShardOverride(t *testing.T) {
+	testString := `
+[FeeSettings]
+	RewardsAddress = "aa00"
+	[[FeeSettings.Shards]]
+		ShardID = 1
+		RewardsAddress = "aa01"
+`
+	cfg := Config{}
+
+	err := toml.Unmarshal([]byte(testString), &cfg)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "aa00", cfg.FeeSettings.RewardsAddressForShard(0))
+	assert.Equal(t, "aa01", cfg.FeeSettings.RewardsAddressForShard(1))
+}
@@ -208,18 +208,24 @@ func (sr *SubroundBlock) createHeader() (data.HeaderHandler, error) {
 	hdr.SetTimeStamp(uint64(sr.Rounder().TimeStamp().Unix()))
 
 	var prevRandSeed []byte
+	var prevEpoch uint32
 	if sr.Blockchain().GetCurrentBlockHeader() == nil {
 		hdr.SetNonce(1)
 		hdr.SetPrevHash(sr.Blockchain().GetGenesisHeaderHash())
 
 		prevRandSeed = sr.Blockchain().GetGenesisHeader().GetRandSeed()
+		prevEpoch = sr.Blockchain().GetGenesisHeader().GetEpoch()
 	} else {
 		hdr.SetNonce(sr.Blockchain().GetCurrentBlockHeader().GetNonce() + 1)
 		hdr.SetPrevHash(sr.Blockchain().GetCurrentBlockHeaderHash())
 
 		prevRandSeed = sr.Blockchain().GetCurrentBlockHeader().GetRandSeed()
+		prevEpoch = sr.Blockchain().GetCurrentBlockHeader().GetEpoch()
 	}
 
+	// the block processor sets the epoch of the round, which may not follow the epoch of the previous block
+	hdr.SetEpoch(process.NextEpoch(prevEpoch, hdr.GetEpoch()))
+
 	randSeed, err := sr.RandomnessSingleSigner().Sign(sr.RandomnessPrivateKey(), prevRandSeed)
 	// Cannot propose block if unable to create random seed
 	if err != nil {
@@ -762,6 +762,26 @@ func TestSubroundBlock_CreateHeaderNilMiniBlocks(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestSubroundBlock_CreateHeaderShouldMoveTheEpochForwardByAtMostOne(t *testing.T) {
+	bp := mock.InitBlockProcessorMock()
+	bp.CreateBlockHeaderCalled = func(body data.BodyHandler, round int32, haveTime func() bool) (header data.HeaderHandler, e error) {
+		return &block.Header{Epoch: 5, RootHash: []byte{}}, nil
+	}
+	container := mock.InitConsensusCore()
+	sr := *initSubroundBlockWithBlockProcessor(bp, container)
+	sr.BlockChain().(*mock.BlockChainMock).GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
+		return &block.Header{
+			Nonce: 1,
+			Epoch: 2,
+		}
+	}
+
+	header, err := sr.CreateHeader()
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(3), header.GetEpoch())
+}
+
 func TestSubroundBlock_CallFuncRemainingTimeWithStructShouldWork(t *testing.T) {
 	roundStartTime := time.Now()
 	maxTime := time.Duration(100 * time.Millisecond)
@@ -3,6 +3,7 @@ package block
 import (
 	"fmt"
 	"io"
+	"math/big"
 
 	capn "github.com/glycerine/go-capnproto"
 	"github.com/numbatx/gn-numbat/data"
@@ -57,12 +58,14 @@ type MiniBlock struct {
 }
 
 // MiniBlockHeader holds the hash of a miniblock together with sender/deastination shard id pair.
-// The shard ids are both kept in order to differentiate between cross and single shard transactions
+// The shard ids are both kept in order to differentiate between cross and single shard transactions.
+// The value is the one carried across the shards by a cross shard miniblock and is nil for the others
 type MiniBlockHeader struct {
-	Hash            []byte `capid:"0"`
-	SenderShardID   uint32 `capid:"1"`
-	ReceiverShardID uint32 `capid:"2"`
-	TxCount         uint32 `capid:"3"`
+	Hash            []byte   `capid:"0"`
+	SenderShardID   uint32   `capid:"1"`
+	ReceiverShardID uint32   `capid:"2"`
+	TxCount         uint32   `capid:"3"`
+	Value           *big.Int `capid:"4"`
 }
 
 // PeerChange holds a change in one peer to shard assignation
@@ -89,7 +92,11 @@ type Header struct {
 	PeerChanges      []PeerChange      `capid:"12"`
 	RootHash         []byte            `capid:"13"`
 	TxCount          uint32            `capid:"14"`
-	processedMBs     map[string]bool   // TODO remove this field when metachain processing is running
+	// the change of the sum of the balances of the shard caused by the block and the fees burnt by the block,
+	// verified by the validators so that the metachain can check that the shards conserve the supply
+	SupplyDelta  *big.Int        `capid:"15"`
+	BurntFees    *big.Int        `capid:"16"`
+	processedMBs map[string]bool // TODO remove this field when metachain processing is running
 }
 
 // Save saves the serialized data of a Block Header into a stream through Capnp protocol
@@ -143,6 +150,8 @@ func HeaderCapnToGo(src capnp.HeaderCapn, dest *Header) *Header {
 
 	dest.RootHash = src.RootHash()
 	dest.TxCount = src.TxCount()
+	dest.SupplyDelta = bigIntCapnToGo(src.SupplyDelta())
+	dest.BurntFees = bigIntCapnToGo(src.BurntFees())
 
 	return dest
 }
@@ -184,6 +193,8 @@ func HeaderGoToCapn(seg *capn.Segment, src *Header) capnp.HeaderCapn {
 
 	dest.SetRootHash(src.RootHash)
 	dest.SetTxCount(src.TxCount)
+	dest.SetSupplyDelta(bigIntGoToCapn(src.SupplyDelta))
+	dest.SetBurntFees(bigIntGoToCapn(src.BurntFees))
 
 	return dest
 }
@@ -310,6 +321,7 @@ func MiniBlockHeaderCapnToGo(src capnp.MiniBlockHeaderCapn, dest *MiniBlockHeade
 	dest.ReceiverShardID = src.ReceiverShardID()
 	dest.SenderShardID = src.SenderShardID()
 	dest.TxCount = src.TxCount()
+	dest.Value = bigIntCapnToGo(src.Value())
 
 	return dest
 }
@@ -322,10 +334,32 @@ func MiniBlockHeaderGoToCapn(seg *capn.Segment, src *MiniBlockHeader) capnp.Mini
 	dest.SetReceiverShardID(src.ReceiverShardID)
 	dest.SetSenderShardID(src.SenderShardID)
 	dest.SetTxCount(src.TxCount)
+	dest.SetValue(bigIntGoToCapn(src.Value))
 
 	return dest
 }
 
+// bigIntGoToCapn encodes an optional big integer, keeping its sign. A nil value is encoded as no data
+func bigIntGoToCapn(value *big.Int) []byte {
+	buff, _ := value.GobEncode()
+	return buff
+}
+
+// bigIntCapnToGo decodes an optional big integer encoded by bigIntGoToCapn
+func bigIntCapnToGo(buff []byte) *big.Int {
+	if len(buff) == 0 {
+		return nil
+	}
+
+	value := big.NewInt(0)
+	err := value.GobDecode(buff)
+	if err != nil {
+		return nil
+	}
+
+	return value
+}
+
 // GetNonce returns header nonce
 func (h *Header) GetNonce() uint64 {
 	return h.Nonce
@@ -2,6 +2,7 @@ package block_test
 
 import (
 	"bytes"
+	"math/big"
 	"testing"
 
 	"github.com/numbatx/gn-numbat/data"
@@ -17,6 +18,7 @@ func TestHeader_SaveLoad(t *testing.T) {
 		ReceiverShardID: uint32(0),
 		SenderShardID:   uint32(10),
 		TxCount:         uint32(10),
+		Value:           big.NewInt(100),
 	}
 
 	pc := block.PeerChange{
@@ -40,6 +42,8 @@ func TestHeader_SaveLoad(t *testing.T) {
 		PeerChanges:      []block.PeerChange{pc},
 		RootHash:         []byte("root hash"),
 		TxCount:          uint32(10),
+		SupplyDelta:      big.NewInt(-50),
+		BurntFees:        big.NewInt(5),
 	}
 
 	var b bytes.Buffer
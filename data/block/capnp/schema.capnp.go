@@ -12,9 +12,9 @@ import (
 
 type HeaderCapn C.Struct
 
-func NewHeaderCapn(s *C.Segment) HeaderCapn      { return HeaderCapn(s.NewStruct(40, 8)) }
-func NewRootHeaderCapn(s *C.Segment) HeaderCapn  { return HeaderCapn(s.NewRootStruct(40, 8)) }
-func AutoNewHeaderCapn(s *C.Segment) HeaderCapn  { return HeaderCapn(s.NewStructAR(40, 8)) }
+func NewHeaderCapn(s *C.Segment) HeaderCapn      { return HeaderCapn(s.NewStruct(40, 10)) }
+func NewRootHeaderCapn(s *C.Segment) HeaderCapn  { return HeaderCapn(s.NewRootStruct(40, 10)) }
+func AutoNewHeaderCapn(s *C.Segment) HeaderCapn  { return HeaderCapn(s.NewStructAR(40, 10)) }
 func ReadRootHeaderCapn(s *C.Segment) HeaderCapn { return HeaderCapn(s.Root(0).ToStruct()) }
 func (s HeaderCapn) Nonce() uint64               { return C.Struct(s).Get64(0) }
 func (s HeaderCapn) SetNonce(v uint64)           { C.Struct(s).Set64(0, v) }
@@ -52,6 +52,10 @@ func (s HeaderCapn) RootHash() []byte                     { return C.Struct(s).G
 func (s HeaderCapn) SetRootHash(v []byte)                 { C.Struct(s).SetObject(7, s.Segment.NewData(v)) }
 func (s HeaderCapn) TxCount() uint32                      { return C.Struct(s).Get32(32) }
 func (s HeaderCapn) SetTxCount(v uint32)                  { C.Struct(s).Set32(32, v) }
+func (s HeaderCapn) SupplyDelta() []byte                  { return C.Struct(s).GetObject(8).ToData() }
+func (s HeaderCapn) SetSupplyDelta(v []byte)              { C.Struct(s).SetObject(8, s.Segment.NewData(v)) }
+func (s HeaderCapn) BurntFees() []byte                    { return C.Struct(s).GetObject(9).ToData() }
+func (s HeaderCapn) SetBurntFees(v []byte)                { C.Struct(s).SetObject(9, s.Segment.NewData(v)) }
 func (s HeaderCapn) WriteJSON(w io.Writer) error {
 	b := bufio.NewWriter(w)
 	var err error
@@ -370,6 +374,44 @@ func (s HeaderCapn) WriteJSON(w io.Writer) error {
 			return err
 		}
 	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"supplyDelta\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.SupplyDelta()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"burntFees\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.BurntFees()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte('}')
 	if err != nil {
 		return err
@@ -700,6 +742,44 @@ func (s HeaderCapn) WriteCapLit(w io.Writer) error {
 			return err
 		}
 	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("supplyDelta = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.SupplyDelta()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("burntFees = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.BurntFees()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte(')')
 	if err != nil {
 		return err
@@ -716,7 +796,7 @@ func (s HeaderCapn) MarshalCapLit() ([]byte, error) {
 type HeaderCapn_List C.PointerList
 
 func NewHeaderCapnList(s *C.Segment, sz int) HeaderCapn_List {
-	return HeaderCapn_List(s.NewCompositeList(40, 8, sz))
+	return HeaderCapn_List(s.NewCompositeList(40, 10, sz))
 }
 func (s HeaderCapn_List) Len() int            { return C.PointerList(s).Len() }
 func (s HeaderCapn_List) At(i int) HeaderCapn { return HeaderCapn(C.PointerList(s).At(i).ToStruct()) }
@@ -733,13 +813,13 @@ func (s HeaderCapn_List) Set(i int, item HeaderCapn) { C.PointerList(s).Set(i, C
 type MiniBlockHeaderCapn C.Struct
 
 func NewMiniBlockHeaderCapn(s *C.Segment) MiniBlockHeaderCapn {
-	return MiniBlockHeaderCapn(s.NewStruct(16, 1))
+	return MiniBlockHeaderCapn(s.NewStruct(16, 2))
 }
 func NewRootMiniBlockHeaderCapn(s *C.Segment) MiniBlockHeaderCapn {
-	return MiniBlockHeaderCapn(s.NewRootStruct(16, 1))
+	return MiniBlockHeaderCapn(s.NewRootStruct(16, 2))
 }
 func AutoNewMiniBlockHeaderCapn(s *C.Segment) MiniBlockHeaderCapn {
-	return MiniBlockHeaderCapn(s.NewStructAR(16, 1))
+	return MiniBlockHeaderCapn(s.NewStructAR(16, 2))
 }
 func ReadRootMiniBlockHeaderCapn(s *C.Segment) MiniBlockHeaderCapn {
 	return MiniBlockHeaderCapn(s.Root(0).ToStruct())
@@ -752,6 +832,8 @@ func (s MiniBlockHeaderCapn) SenderShardID() uint32       { return C.Struct(s).G
 func (s MiniBlockHeaderCapn) SetSenderShardID(v uint32)   { C.Struct(s).Set32(4, v) }
 func (s MiniBlockHeaderCapn) TxCount() uint32             { return C.Struct(s).Get32(8) }
 func (s MiniBlockHeaderCapn) SetTxCount(v uint32)         { C.Struct(s).Set32(8, v) }
+func (s MiniBlockHeaderCapn) Value() []byte               { return C.Struct(s).GetObject(1).ToData() }
+func (s MiniBlockHeaderCapn) SetValue(v []byte)           { C.Struct(s).SetObject(1, s.Segment.NewData(v)) }
 func (s MiniBlockHeaderCapn) WriteJSON(w io.Writer) error {
 	b := bufio.NewWriter(w)
 	var err error
@@ -833,6 +915,25 @@ func (s MiniBlockHeaderCapn) WriteJSON(w io.Writer) error {
 			return err
 		}
 	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"value\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Value()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte('}')
 	if err != nil {
 		return err
@@ -926,6 +1027,25 @@ func (s MiniBlockHeaderCapn) WriteCapLit(w io.Writer) error {
 			return err
 		}
 	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("value = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Value()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte(')')
 	if err != nil {
 		return err
@@ -942,7 +1062,7 @@ func (s MiniBlockHeaderCapn) MarshalCapLit() ([]byte, error) {
 type MiniBlockHeaderCapn_List C.PointerList
 
 func NewMiniBlockHeaderCapnList(s *C.Segment, sz int) MiniBlockHeaderCapn_List {
-	return MiniBlockHeaderCapn_List(s.NewCompositeList(16, 1, sz))
+	return MiniBlockHeaderCapn_List(s.NewCompositeList(16, 2, sz))
 }
 func (s MiniBlockHeaderCapn_List) Len() int { return C.PointerList(s).Len() }
 func (s MiniBlockHeaderCapn_List) At(i int) MiniBlockHeaderCapn {
@@ -326,3 +326,28 @@ func TestMetaBlock_SetTxCount(t *testing.T) {
 
 	assert.Equal(t, txCount, m.GetTxCount())
 }
+
+func TestMetaBlock_GetMiniBlockHeadersWithDstShouldSkipTheHeadersOfTheDestinationShard(t *testing.T) {
+	t.Parallel()
+
+	m := block.MetaBlock{
+		ShardInfo: []block.ShardData{
+			{
+				ShardId: 0,
+				ShardMiniBlockHeaders: []block.ShardMiniBlockHeader{
+					{Hash: []byte("sent"), SenderShardId: 0, ReceiverShardId: 1},
+				},
+			},
+			{
+				ShardId: 1,
+				ShardMiniBlockHeaders: []block.ShardMiniBlockHeader{
+					{Hash: []byte("sent"), SenderShardId: 0, ReceiverShardId: 1},
+					{Hash: []byte("intra"), SenderShardId: 1, ReceiverShardId: 1},
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]uint32{"sent": 0}, m.GetMiniBlockHeadersWithDst(1))
+	assert.Equal(t, 0, len(m.GetMiniBlockHeadersWithDst(0)))
+}
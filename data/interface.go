@@ -56,3 +56,28 @@ type ChainHandler interface {
 	HasBadBlock(blockHash []byte) bool
 	PutBadBlock(blockHash []byte)
 }
+
+// Trie is the Patricia Merkle trie abstraction the state is built on. Getting a missing key returns a nil value and
+// a nil error, updating a key with an empty value removes it and the root hash of an empty trie is a fixed hash
+// specific to each implementation. Recreate returns a new trie, sharing the same storage, rooted at the provided hash.
+// GetLeaves returns at most maxLeaves keys, starting from startKey or from the first key if startKey is empty, along
+// with their values. Keys are walked in the trie order, which is the byte order except that a key comes after all
+// the keys it is a prefix of
+type Trie interface {
+	Get(key []byte) ([]byte, error)
+	Update(key, value []byte) error
+	Delete(key []byte) error
+	Root() ([]byte, error)
+	Commit() error
+	Recreate(root []byte) (Trie, error)
+	RecreateDataTrie(root []byte) (Trie, error)
+	Prove(key []byte) ([][]byte, error)
+	GetLeaves(startKey []byte, maxLeaves int) (keys [][]byte, values [][]byte, err error)
+}
+
+// StatePruner removes the trie nodes only referenced by states which are no longer needed. AddRoot registers the
+// root hash committed for a block and Prune is called with the nonce of the highest final block
+type StatePruner interface {
+	AddRoot(nonce uint64, rootHash []byte)
+	Prune(finalNonce uint64) error
+}
@@ -1,13 +1,13 @@
 package mock
 
 import (
+	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/data/state"
-	"github.com/numbatx/gn-numbat/data/trie"
 )
 
 type AccountWrapMock struct {
 	MockValue         int
-	dataTrie          trie.PatriciaMerkelTree
+	dataTrie          data.Trie
 	code              []byte
 	codeHash          []byte
 	rootHash          []byte
@@ -63,11 +63,11 @@ func (awm *AccountWrapMock) SetCode(code []byte) {
 	awm.code = code
 }
 
-func (awm *AccountWrapMock) DataTrie() trie.PatriciaMerkelTree {
+func (awm *AccountWrapMock) DataTrie() data.Trie {
 	return awm.dataTrie
 }
 
-func (awm *AccountWrapMock) SetDataTrie(trie trie.PatriciaMerkelTree) {
+func (awm *AccountWrapMock) SetDataTrie(trie data.Trie) {
 	awm.dataTrie = trie
 	awm.trackableDataTrie.SetDataTrie(trie)
 }
@@ -1,6 +1,6 @@
 package mock
 
-import "github.com/numbatx/gn-numbat/data/trie"
+import "github.com/numbatx/gn-numbat/data"
 
 type DataTrieTrackerStub struct {
 	ClearDataCachesCalled func()
@@ -8,8 +8,8 @@ type DataTrieTrackerStub struct {
 	OriginalValueCalled   func(key []byte) []byte
 	RetrieveValueCalled   func(key []byte) ([]byte, error)
 	SaveKeyValueCalled    func(key []byte, value []byte)
-	SetDataTrieCalled     func(tr trie.PatriciaMerkelTree)
-	DataTrieCalled        func() trie.PatriciaMerkelTree
+	SetDataTrieCalled     func(tr data.Trie)
+	DataTrieCalled        func() data.Trie
 }
 
 func (dtts *DataTrieTrackerStub) ClearDataCaches() {
@@ -32,10 +32,10 @@ func (dtts *DataTrieTrackerStub) SaveKeyValue(key []byte, value []byte) {
 	dtts.SaveKeyValueCalled(key, value)
 }
 
-func (dtts *DataTrieTrackerStub) SetDataTrie(tr trie.PatriciaMerkelTree) {
+func (dtts *DataTrieTrackerStub) SetDataTrie(tr data.Trie) {
 	dtts.SetDataTrieCalled(tr)
 }
 
-func (dtts *DataTrieTrackerStub) DataTrie() trie.PatriciaMerkelTree {
+func (dtts *DataTrieTrackerStub) DataTrie() data.Trie {
 	return dtts.DataTrieCalled()
 }
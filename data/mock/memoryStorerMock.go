@@ -1,7 +1,9 @@
 package mock
 
 import (
+	"bytes"
 	"errors"
+	"sort"
 	"sync"
 
 	"github.com/numbatx/gn-numbat/data/trie/encoding"
@@ -34,6 +36,20 @@ func (msm *MemoryStorerMock) Put(key, data []byte) error {
 	return nil
 }
 
+func (msm *MemoryStorerMock) PutBatch(data map[string][]byte) error {
+	if msm.Fail {
+		return errMemoryStorerMock
+	}
+
+	msm.lock.Lock()
+	defer msm.lock.Unlock()
+
+	for key, val := range data {
+		msm.db[key] = encoding.CopyBytes(val)
+	}
+	return nil
+}
+
 func (msm *MemoryStorerMock) Get(key []byte) ([]byte, error) {
 	if msm.Fail {
 		return nil, errMemoryStorerMock
@@ -80,6 +96,38 @@ func (msm *MemoryStorerMock) HasOrAdd(key []byte, value []byte) error {
 	return nil
 }
 
+func (msm *MemoryStorerMock) Iterate(prefix []byte, start []byte, end []byte, handler func(key []byte, val []byte) bool) error {
+	if msm.Fail {
+		return errMemoryStorerMock
+	}
+
+	msm.lock.RLock()
+	keys := make([]string, 0)
+	for key := range msm.db {
+		if !bytes.HasPrefix([]byte(key), prefix) || bytes.Compare([]byte(key), start) < 0 {
+			continue
+		}
+		if end != nil && bytes.Compare([]byte(key), end) >= 0 {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	vals := make([][]byte, len(keys))
+	for i, key := range keys {
+		vals[i] = encoding.CopyBytes(msm.db[key])
+	}
+	msm.lock.RUnlock()
+
+	for i, key := range keys {
+		if !handler([]byte(key), vals[i]) {
+			break
+		}
+	}
+	return nil
+}
+
 func (msm *MemoryStorerMock) Remove(key []byte) error {
 	if msm.Fail {
 		return errMemoryStorerMock
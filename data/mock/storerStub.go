@@ -2,9 +2,11 @@ package mock
 
 type StorerStub struct {
 	PutCalled         func(key, data []byte) error
+	PutBatchCalled    func(data map[string][]byte) error
 	GetCalled         func(key []byte) ([]byte, error)
 	HasCalled         func(key []byte) error
 	HasOrAddCalled    func(key []byte, value []byte) error
+	IterateCalled     func(prefix []byte, start []byte, end []byte, handler func(key []byte, val []byte) bool) error
 	RemoveCalled      func(key []byte) error
 	ClearCacheCalled  func()
 	DestroyUnitCalled func() error
@@ -14,6 +16,10 @@ func (ss *StorerStub) Put(key, data []byte) error {
 	return ss.PutCalled(key, data)
 }
 
+func (ss *StorerStub) PutBatch(data map[string][]byte) error {
+	return ss.PutBatchCalled(data)
+}
+
 func (ss *StorerStub) Get(key []byte) ([]byte, error) {
 	return ss.GetCalled(key)
 }
@@ -26,6 +32,10 @@ func (ss *StorerStub) HasOrAdd(key []byte, value []byte) error {
 	return ss.HasOrAddCalled(key, value)
 }
 
+func (ss *StorerStub) Iterate(prefix []byte, start []byte, end []byte, handler func(key []byte, val []byte) bool) error {
+	return ss.IterateCalled(prefix, start, end, handler)
+}
+
 func (ss *StorerStub) Remove(key []byte) error {
 	return ss.RemoveCalled(key)
 }
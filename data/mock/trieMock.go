@@ -4,12 +4,12 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/davecgh/go-spew/spew"
-	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/data/trie/encoding"
-	"github.com/numbatx/gn-numbat/storage"
 )
 
 var errMockTrie = errors.New("TrieMock generic error")
@@ -26,7 +26,7 @@ type TrieMock struct {
 	TTFGet       int
 	FailUpdate   bool
 	TTFUpdate    int
-	mdbwc        trie.DBWriteCacher
+	mdbwc        *DBWriteCacherMock
 }
 
 // NewMockTrie creates a new TrieMock obj
@@ -38,18 +38,13 @@ func NewMockTrie() *TrieMock {
 }
 
 // NewMockTrieWithDBW creates a new TrieMock obj on the same cacher object
-func NewMockTrieWithDBW(cacher trie.DBWriteCacher) *TrieMock {
+func NewMockTrieWithDBW(cacher *DBWriteCacherMock) *TrieMock {
 	mt := TrieMock{mutData: sync.RWMutex{}, data: make(map[string][]byte, 0), keys: make([]string, 0), Fail: false}
 	mt.mdbwc = cacher
 
 	return &mt
 }
 
-// SetCacheLimit is an empty func
-func (mt *TrieMock) SetCacheLimit(l uint16) {
-	//nothing
-}
-
 // Get returns the data from the 'trie'
 func (mt *TrieMock) Get(key []byte) ([]byte, error) {
 	if mt.Fail {
@@ -121,7 +116,11 @@ func (mt *TrieMock) Delete(key []byte) error {
 }
 
 // Root returns the hash of the entire 'trie'
-func (mt *TrieMock) Root() []byte {
+func (mt *TrieMock) Root() ([]byte, error) {
+	return mt.root(), nil
+}
+
+func (mt *TrieMock) root() []byte {
 	if len(mt.data) == 0 {
 		return make([]byte, encoding.HashLength)
 	}
@@ -142,24 +141,18 @@ func (mt *TrieMock) Root() []byte {
 }
 
 // Commit make changes to be permanent (calls the commit on underlining cacher)
-func (mt *TrieMock) Commit(onleaf trie.LeafCallback) (root []byte, err error) {
+func (mt *TrieMock) Commit() error {
 	if mt.Fail {
-		return nil, errMockTrie
+		return errMockTrie
 	}
 
-	mock := mt.mdbwc.(*DBWriteCacherMock)
-	mock.AppendMockTrie(mt.Copy().(*TrieMock))
-
-	return mt.Root(), nil
-}
+	mt.mdbwc.AppendMockTrie(mt.Copy())
 
-// DBW returns the underling cacher
-func (mt *TrieMock) DBW() trie.DBWriteCacher {
-	return mt.mdbwc
+	return nil
 }
 
 // Recreate returns a new 'trie' based on the root hash and underlying cacher
-func (mt *TrieMock) Recreate(root []byte, dbw trie.DBWriteCacher) (trie.PatriciaMerkelTree, error) {
+func (mt *TrieMock) Recreate(root []byte) (data.Trie, error) {
 	if mt.Fail {
 		return nil, errMockTrie
 	}
@@ -168,12 +161,21 @@ func (mt *TrieMock) Recreate(root []byte, dbw trie.DBWriteCacher) (trie.Patricia
 		return nil, errMockTrie
 	}
 
-	mock := dbw.(*DBWriteCacherMock)
-	return mock.RetrieveMockTrie(root)
+	tr, err := mt.mdbwc.RetrieveMockTrie(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return tr, nil
+}
+
+// RecreateDataTrie returns a new 'trie' based on the root hash and underlying cacher
+func (mt *TrieMock) RecreateDataTrie(root []byte) (data.Trie, error) {
+	return mt.Recreate(root)
 }
 
 // Copy returns a new 'trie'
-func (mt *TrieMock) Copy() trie.PatriciaMerkelTree {
+func (mt *TrieMock) Copy() *TrieMock {
 	newTrie := NewMockTrieWithDBW(mt.mdbwc)
 
 	mt.mutData.RLock()
@@ -187,8 +189,8 @@ func (mt *TrieMock) Copy() trie.PatriciaMerkelTree {
 	copy(keys, mt.keys)
 	newTrie.keys = keys
 
-	rootC := newTrie.Root()
-	rootS := mt.Root()
+	rootC := newTrie.root()
+	rootS := mt.root()
 
 	if !bytes.Equal(rootC, rootS) {
 		fmt.Println("New")
@@ -201,80 +203,30 @@ func (mt *TrieMock) Copy() trie.PatriciaMerkelTree {
 	return newTrie
 }
 
-// DBWriteCacherMock that will be used for testing
+// DBWriteCacherMock holds the committed 'tries', by their root hash
 type DBWriteCacherMock struct {
 	mutTries sync.RWMutex
-	tries    map[string]trie.PatriciaMerkelTree
+	tries    map[string]*TrieMock
 }
 
 // NewMockDBWriteCacher returns a new DBWriteCacherMock
 func NewMockDBWriteCacher() *DBWriteCacherMock {
 	mdbwc := DBWriteCacherMock{}
 	mdbwc.mutTries = sync.RWMutex{}
-	mdbwc.tries = make(map[string]trie.PatriciaMerkelTree)
+	mdbwc.tries = make(map[string]*TrieMock)
 	return &mdbwc
 }
 
-// PersistDB is an empty func
-func (mdbw *DBWriteCacherMock) Storer() storage.Storer {
-	panic("implement me")
-}
-
-// InsertBlob is an empty func
-func (mdbw *DBWriteCacherMock) InsertBlob(hash []byte, blob []byte) {
-	panic("implement me")
-}
-
-// Node is an empty func
-func (mdbw *DBWriteCacherMock) Node(hash []byte) ([]byte, error) {
-	panic("implement me")
-}
-
-// Reference is an empty func
-func (mdbw *DBWriteCacherMock) Reference(child []byte, parent []byte) {
-	panic("implement me")
-}
-
-// Dereference is an empty func
-func (mdbw *DBWriteCacherMock) Dereference(root []byte) {
-	panic("implement me")
-}
-
-// Cap is an empty func
-func (mdbw *DBWriteCacherMock) Cap(limit float64) error {
-	panic("implement me")
-}
-
-// Commit is an empty func
-func (mdbw *DBWriteCacherMock) Commit(node []byte, report bool) error {
-	panic("implement me")
-}
-
-// Size is an empty func
-func (mdbw *DBWriteCacherMock) Size() (float64, float64) {
-	panic("implement me")
-}
-
-// InsertWithLock is an empty func
-func (mdbw *DBWriteCacherMock) InsertWithLock(hash []byte, blob []byte, node trie.Node) {
-
-}
-
-// CachedNode is an empty func
-func (mdbw *DBWriteCacherMock) CachedNode(hash []byte, cachegen uint16) trie.Node {
-	panic("implement me")
-}
-
 // AppendMockTrie adds a new 'trie'
 func (mdbw *DBWriteCacherMock) AppendMockTrie(mockTrie *TrieMock) {
 	mdbw.mutTries.Lock()
 	defer mdbw.mutTries.Unlock()
 
-	mdbw.tries[string(mockTrie.Root())] = mockTrie
+	mdbw.tries[string(mockTrie.root())] = mockTrie
 }
 
 // RetrieveMockTrie retrieves a stored 'trie' or nil if not found
-func (mdbw *DBWriteCacherMock) RetrieveMockTrie(root []byte) (trie.PatriciaMerkelTree, error) {
+func (mdbw *DBWriteCacherMock) RetrieveMockTrie(root []byte) (*TrieMock, error) {
 	mdbw.mutTries.Lock()
 	defer mdbw.mutTries.Unlock()
 
@@ -292,3 +244,45 @@ func (mdbw *DBWriteCacherMock) RetrieveMockTrie(root []byte) (trie.PatriciaMerke
 
 	return val.Copy(), nil
 }
+
+// Prove returns a single element 'proof' holding the value stored for the key
+func (mt *TrieMock) Prove(key []byte) ([][]byte, error) {
+	if mt.Fail {
+		return nil, errMockTrie
+	}
+
+	mt.mutData.RLock()
+	defer mt.mutData.RUnlock()
+
+	return [][]byte{mt.data[string(key)]}, nil
+}
+
+// GetLeaves returns, sorted by key, at most maxLeaves keys not lower than startKey along with their values
+func (mt *TrieMock) GetLeaves(startKey []byte, maxLeaves int) ([][]byte, [][]byte, error) {
+	if mt.Fail {
+		return nil, nil, errMockTrie
+	}
+
+	mt.mutData.RLock()
+	defer mt.mutData.RUnlock()
+
+	sortedKeys := make([]string, 0, len(mt.keys))
+	for _, key := range mt.keys {
+		if key >= string(startKey) {
+			sortedKeys = append(sortedKeys, key)
+		}
+	}
+	sort.Strings(sortedKeys)
+	if len(sortedKeys) > maxLeaves {
+		sortedKeys = sortedKeys[:maxLeaves]
+	}
+
+	keys := make([][]byte, len(sortedKeys))
+	values := make([][]byte, len(sortedKeys))
+	for i, key := range sortedKeys {
+		keys[i] = []byte(key)
+		values[i] = mt.data[key]
+	}
+
+	return keys, values, nil
+}
@@ -1,23 +1,20 @@
 package mock
 
 import (
-	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/data"
 )
 
 type TrieStub struct {
-	SetCacheLimitCalled func(l uint16)
-	GetCalled           func(key []byte) ([]byte, error)
-	UpdateCalled        func(key, value []byte) error
-	DeleteCalled        func(key []byte) error
-	RootCalled          func() []byte
-	CommitCalled        func(onleaf trie.LeafCallback) (root []byte, err error)
-	DBWCalled           func() trie.DBWriteCacher
-	RecreateCalled      func(root []byte, dbw trie.DBWriteCacher) (trie.PatriciaMerkelTree, error)
-	CopyCalled          func() trie.PatriciaMerkelTree
-}
+	GetCalled              func(key []byte) ([]byte, error)
+	UpdateCalled           func(key, value []byte) error
+	DeleteCalled           func(key []byte) error
+	RootCalled             func() ([]byte, error)
+	CommitCalled           func() error
+	RecreateCalled         func(root []byte) (data.Trie, error)
+	RecreateDataTrieCalled func(root []byte) (data.Trie, error)
+	ProveCalled            func(key []byte) ([][]byte, error)
 
-func (ts *TrieStub) SetCacheLimit(l uint16) {
-	ts.SetCacheLimitCalled(l)
+	GetLeavesCalled func(startKey []byte, maxLeaves int) ([][]byte, [][]byte, error)
 }
 
 func (ts *TrieStub) Get(key []byte) ([]byte, error) {
@@ -32,22 +29,26 @@ func (ts *TrieStub) Delete(key []byte) error {
 	return ts.DeleteCalled(key)
 }
 
-func (ts *TrieStub) Root() []byte {
+func (ts *TrieStub) Root() ([]byte, error) {
 	return ts.RootCalled()
 }
 
-func (ts *TrieStub) Commit(onleaf trie.LeafCallback) (root []byte, err error) {
-	return ts.CommitCalled(onleaf)
+func (ts *TrieStub) Commit() error {
+	return ts.CommitCalled()
+}
+
+func (ts *TrieStub) Recreate(root []byte) (data.Trie, error) {
+	return ts.RecreateCalled(root)
 }
 
-func (ts *TrieStub) DBW() trie.DBWriteCacher {
-	return ts.DBWCalled()
+func (ts *TrieStub) RecreateDataTrie(root []byte) (data.Trie, error) {
+	return ts.RecreateDataTrieCalled(root)
 }
 
-func (ts *TrieStub) Recreate(root []byte, dbw trie.DBWriteCacher) (trie.PatriciaMerkelTree, error) {
-	return ts.RecreateCalled(root, dbw)
+func (ts *TrieStub) Prove(key []byte) ([][]byte, error) {
+	return ts.ProveCalled(key)
 }
 
-func (ts *TrieStub) Copy() trie.PatriciaMerkelTree {
-	return ts.CopyCalled()
+func (ts *TrieStub) GetLeaves(startKey []byte, maxLeaves int) ([][]byte, [][]byte, error) {
+	return ts.GetLeavesCalled(startKey, maxLeaves)
 }
@@ -3,7 +3,7 @@ package state
 import (
 	"math/big"
 
-	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/data"
 )
 
 // Account is the struct used in serialization/deserialization
@@ -128,12 +128,12 @@ func (a *Account) SetRootHashWithJournal(rootHash []byte) error {
 }
 
 // DataTrie returns the trie that holds the current account's data
-func (a *Account) DataTrie() trie.PatriciaMerkelTree {
+func (a *Account) DataTrie() data.Trie {
 	return a.dataTrieTracker.DataTrie()
 }
 
 // SetDataTrie sets the trie that holds the current account's data
-func (a *Account) SetDataTrie(trie trie.PatriciaMerkelTree) {
+func (a *Account) SetDataTrie(trie data.Trie) {
 	a.dataTrieTracker.SetDataTrie(trie)
 }
 
@@ -6,25 +6,36 @@ import (
 	"strconv"
 	"sync"
 
-	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/hashing"
 	"github.com/numbatx/gn-numbat/marshal"
 )
 
+// accountsPageSize is the number of accounts trie leaves read at once when iterating all the accounts of a state
+const accountsPageSize = 1000
+
 // AccountsDB is the struct used for accessing accounts
 type AccountsDB struct {
-	mainTrie       trie.PatriciaMerkelTree
+	mainTrie       data.Trie
 	hasher         hashing.Hasher
 	marshalizer    marshal.Marshalizer
 	accountFactory AccountFactory
 
 	entries    []JournalEntry
 	mutEntries sync.RWMutex
+
+	// lastRootHash is the root hash of the last committed or recreated state, which the state diff is computed
+	// against, and the touched keys are the accounts and codes written in the main trie since then. It is nil
+	// until the first commit or recreation
+	lastRootHash    []byte
+	touchedAccounts map[string]struct{}
+	touchedCodes    map[string]struct{}
+	mutTouched      sync.Mutex
 }
 
 // NewAccountsDB creates a new account manager
 func NewAccountsDB(
-	trie trie.PatriciaMerkelTree,
+	trie data.Trie,
 	hasher hashing.Hasher,
 	marshalizer marshal.Marshalizer,
 	accountFactory AccountFactory,
@@ -43,12 +54,14 @@ func NewAccountsDB(
 	}
 
 	return &AccountsDB{
-		mainTrie:       trie,
-		hasher:         hasher,
-		marshalizer:    marshalizer,
-		accountFactory: accountFactory,
-		entries:        make([]JournalEntry, 0),
-		mutEntries:     sync.RWMutex{},
+		mainTrie:        trie,
+		hasher:          hasher,
+		marshalizer:     marshalizer,
+		accountFactory:  accountFactory,
+		entries:         make([]JournalEntry, 0),
+		mutEntries:      sync.RWMutex{},
+		touchedAccounts: make(map[string]struct{}),
+		touchedCodes:    make(map[string]struct{}),
 	}, nil
 }
 
@@ -90,6 +103,7 @@ func (adb *AccountsDB) addCodeToTrieIfMissing(codeHash []byte, code []byte) erro
 			return err
 		}
 		adb.Journalize(entry)
+		adb.markTouchedCode(codeHash)
 		return adb.mainTrie.Update(codeHash, code)
 	}
 
@@ -98,6 +112,7 @@ func (adb *AccountsDB) addCodeToTrieIfMissing(codeHash []byte, code []byte) erro
 
 // RemoveCode deletes the code from the trie. It writes an empty byte slice at codeHash "address"
 func (adb *AccountsDB) RemoveCode(codeHash []byte) error {
+	adb.markTouchedCode(codeHash)
 	return adb.mainTrie.Update(codeHash, make([]byte, 0))
 }
 
@@ -112,7 +127,7 @@ func (adb *AccountsDB) LoadDataTrie(accountHandler AccountHandler) error {
 		return NewErrorTrieNotNormalized(HashLength, len(accountHandler.GetRootHash()))
 	}
 
-	dataTrie, err := adb.mainTrie.Recreate(accountHandler.GetRootHash(), adb.mainTrie.DBW())
+	dataTrie, err := adb.mainTrie.RecreateDataTrie(accountHandler.GetRootHash())
 	if err != nil {
 		//error as there is an inconsistent state:
 		//account has data root hash but does not contain the actual trie
@@ -129,7 +144,7 @@ func (adb *AccountsDB) SaveDataTrie(accountHandler AccountHandler) error {
 	flagHasDirtyData := false
 
 	if accountHandler.DataTrie() == nil {
-		dataTrie, err := adb.mainTrie.Recreate(make([]byte, 0), adb.mainTrie.DBW())
+		dataTrie, err := adb.mainTrie.RecreateDataTrie(make([]byte, 0))
 
 		if err != nil {
 			return err
@@ -168,7 +183,12 @@ func (adb *AccountsDB) SaveDataTrie(accountHandler AccountHandler) error {
 	}
 
 	adb.Journalize(entry)
-	err = accountHandler.SetRootHashWithJournal(accountHandler.DataTrie().Root())
+	rootHash, err := accountHandler.DataTrie().Root()
+	if err != nil {
+		return err
+	}
+
+	err = accountHandler.SetRootHashWithJournal(rootHash)
 	if err != nil {
 		return err
 	}
@@ -225,12 +245,14 @@ func (adb *AccountsDB) SaveAccount(accountHandler AccountHandler) error {
 		return err
 	}
 
+	adb.markTouchedAccount(accountHandler.AddressContainer().Bytes())
 	return adb.mainTrie.Update(accountHandler.AddressContainer().Bytes(), buff)
 }
 
 // RemoveAccount removes the account data from underlying trie.
 // It basically calls Update with empty slice
 func (adb *AccountsDB) RemoveAccount(addressContainer AddressContainer) error {
+	adb.markTouchedAccount(addressContainer.Bytes())
 	return adb.mainTrie.Update(addressContainer.Bytes(), make([]byte, 0))
 }
 
@@ -366,7 +388,7 @@ func (adb *AccountsDB) Commit() ([]byte, error) {
 		jed, found := jEntries[i].(*BaseJournalEntryData)
 
 		if found {
-			_, err := jed.Trie().Commit(nil)
+			err := jed.Trie().Commit()
 			if err != nil {
 				return nil, err
 			}
@@ -377,12 +399,18 @@ func (adb *AccountsDB) Commit() ([]byte, error) {
 	adb.clearJournal()
 
 	//Step 3. commit main trie
-	hash, err := adb.mainTrie.Commit(nil)
+	err := adb.mainTrie.Commit()
 	if err != nil {
 		return nil, err
 	}
 
-	return hash, nil
+	rootHash, err := adb.mainTrie.Root()
+	if err != nil {
+		return nil, err
+	}
+
+	adb.resetTouched(rootHash)
+	return rootHash, nil
 }
 
 // loadCode retrieves and saves the SC code inside AccountState object. Errors if something went wrong
@@ -404,14 +432,19 @@ func (adb *AccountsDB) loadCode(accountHandler AccountHandler) error {
 	return nil
 }
 
-// RootHash returns the main trie's root hash
+// RootHash returns the main trie's root hash or nil if it could not be computed
 func (adb *AccountsDB) RootHash() []byte {
-	return adb.mainTrie.Root()
+	rootHash, err := adb.mainTrie.Root()
+	if err != nil {
+		return nil
+	}
+
+	return rootHash
 }
 
 // RecreateTrie is used to reload the trie based on an existing rootHash
 func (adb *AccountsDB) RecreateTrie(rootHash []byte) error {
-	newTrie, err := adb.mainTrie.Recreate(rootHash, adb.mainTrie.DBW())
+	newTrie, err := adb.mainTrie.Recreate(rootHash)
 	if err != nil {
 		return err
 	}
@@ -420,9 +453,132 @@ func (adb *AccountsDB) RecreateTrie(rootHash []byte) error {
 	}
 
 	adb.mainTrie = newTrie
+	adb.resetTouched(rootHash)
 	return nil
 }
 
+// GetAccountProof returns the value stored for the address in the state having the provided root hash, along with
+// the Merkle proof of it. The proof is built on a trie recreated from the root hash, so the live state is not disturbed.
+// A nil value signals that the proof shows the absence of the account
+func (adb *AccountsDB) GetAccountProof(rootHash []byte, addressContainer AddressContainer) ([]byte, [][]byte, error) {
+	if addressContainer == nil {
+		return nil, nil, ErrNilAddressContainer
+	}
+
+	tr, err := adb.mainTrie.Recreate(rootHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tr == nil {
+		return nil, nil, ErrNilTrie
+	}
+
+	value, err := tr.Get(addressContainer.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proof, err := tr.Prove(addressContainer.Bytes())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return value, proof, nil
+}
+
+// GetAccountFromRoot returns the account stored for the address in the state having the provided root hash. The
+// account is read from a trie recreated from the root hash and is never journalized, so the live state is not
+// disturbed. Its code and data trie are not loaded
+func (adb *AccountsDB) GetAccountFromRoot(rootHash []byte, addressContainer AddressContainer) (AccountHandler, error) {
+	if addressContainer == nil {
+		return nil, ErrNilAddressContainer
+	}
+
+	tr, err := adb.mainTrie.Recreate(rootHash)
+	if err != nil {
+		return nil, err
+	}
+	if tr == nil {
+		return nil, ErrNilTrie
+	}
+
+	val, err := tr.Get(addressContainer.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if val == nil {
+		return nil, ErrAccNotFound
+	}
+
+	acnt, err := adb.accountFactory.CreateAccount(addressContainer, &leafAccountTracker{})
+	if err != nil {
+		return nil, err
+	}
+
+	err = adb.marshalizer.Unmarshal(acnt, val)
+	if err != nil {
+		return nil, err
+	}
+
+	return acnt, nil
+}
+
+// ForEachAccount calls the handler, in trie order, for every account stored in the state having the provided root
+// hash, skipping the leaves holding smart contract codes. The accounts are read from a trie recreated from the root
+// hash, in pages of accountsPageSize leaves, and are never journalized, so the live state is not disturbed. Their
+// code and data trie are not loaded. The iteration stops at the first error returned by the handler
+func (adb *AccountsDB) ForEachAccount(rootHash []byte, handler func(account AccountHandler) error) error {
+	if handler == nil {
+		return ErrNilAccountsIterationHandler
+	}
+
+	tr, err := adb.mainTrie.Recreate(rootHash)
+	if err != nil {
+		return err
+	}
+	if tr == nil {
+		return ErrNilTrie
+	}
+
+	startKey := make([]byte, 0)
+	for {
+		keys, values, err := tr.GetLeaves(startKey, accountsPageSize+1)
+		if err != nil {
+			return err
+		}
+
+		for i := 0; i < len(keys) && i < accountsPageSize; i++ {
+			err = adb.callAccountHandler(keys[i], values[i], handler)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(keys) <= accountsPageSize {
+			return nil
+		}
+		startKey = keys[accountsPageSize]
+	}
+}
+
+func (adb *AccountsDB) callAccountHandler(key []byte, value []byte, handler func(account AccountHandler) error) error {
+	if len(value) == 0 || IsCodeLeaf(adb.hasher, key, value) {
+		return nil
+	}
+
+	acnt, err := adb.accountFactory.CreateAccount(NewAddress(key), &leafAccountTracker{})
+	if err != nil {
+		return err
+	}
+
+	err = adb.marshalizer.Unmarshal(acnt, value)
+	if err != nil {
+		return err
+	}
+
+	return handler(acnt)
+}
+
 // Journalize adds a new object to entries list. Concurrent safe.
 func (adb *AccountsDB) Journalize(entry JournalEntry) {
 	if entry == nil {
@@ -8,14 +8,15 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/data/mock"
 	"github.com/numbatx/gn-numbat/data/state"
-	"github.com/numbatx/gn-numbat/data/trie"
 	"github.com/numbatx/gn-numbat/data/trie/encoding"
+	trieFactory "github.com/numbatx/gn-numbat/data/trie/factory"
 	"github.com/stretchr/testify/assert"
 )
 
-func generateAccountDBFromTrie(trie trie.PatriciaMerkelTree) *state.AccountsDB {
+func generateAccountDBFromTrie(trie data.Trie) *state.AccountsDB {
 	accnt, _ := state.NewAccountsDB(trie, mock.HasherMock{}, &mock.MarshalizerMock{}, &mock.AccountsFactoryStub{
 		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error) {
 			return mock.NewAccountWrapMock(address, tracker), nil
@@ -704,17 +705,19 @@ func TestAccountsDB_LoadDataWithSomeValuesShouldWork(t *testing.T) {
 	account.SetRootHash(rootHash)
 
 	//create a data trie with some values
-	dataTrie, err := mockTrie.Recreate(make([]byte, encoding.HashLength), mockTrie.DBW())
+	dataTrie, err := mockTrie.Recreate(make([]byte, encoding.HashLength))
 	assert.Nil(t, err)
 	err = dataTrie.Update([]byte{65, 66, 67}, []byte{32, 33, 34})
 	assert.Nil(t, err)
 	err = dataTrie.Update([]byte{68, 69, 70}, []byte{35, 36, 37})
 	assert.Nil(t, err)
-	_, err = dataTrie.Commit(nil)
+	err = dataTrie.Commit()
 	assert.Nil(t, err)
 
 	//link the new created trie to account's data root
-	account.SetRootHash(dataTrie.Root())
+	dataRootHash, err := dataTrie.Root()
+	assert.Nil(t, err)
+	account.SetRootHash(dataRootHash)
 
 	//should not return error
 	err = adb.LoadDataTrie(account)
@@ -740,9 +743,12 @@ func TestAccountsDB_CommitWithSomeValuesShouldWork(t *testing.T) {
 	commitCalled := 0
 
 	trieStub := mock.TrieStub{}
-	trieStub.CommitCalled = func(onleaf trie.LeafCallback) (root []byte, err error) {
+	trieStub.CommitCalled = func() error {
 		commitCalled++
 
+		return nil
+	}
+	trieStub.RootCalled = func() ([]byte, error) {
 		return nil, nil
 	}
 	adb := generateAccountDBFromTrie(&trieStub)
@@ -765,13 +771,10 @@ func TestAccountsDB_RecreateTrieMalfunctionTrieShouldErr(t *testing.T) {
 
 	errExpected := errors.New("failure")
 	trieStub := mock.TrieStub{}
-	trieStub.RecreateCalled = func(root []byte, dbw trie.DBWriteCacher) (tree trie.PatriciaMerkelTree, e error) {
+	trieStub.RecreateCalled = func(root []byte) (data.Trie, error) {
 		wasCalled = true
 		return nil, errExpected
 	}
-	trieStub.DBWCalled = func() trie.DBWriteCacher {
-		return nil
-	}
 
 	adb := generateAccountDBFromTrie(&trieStub)
 
@@ -786,13 +789,10 @@ func TestAccountsDB_RecreateTrieOutputsNilTrieShouldErr(t *testing.T) {
 	wasCalled := false
 
 	trieStub := mock.TrieStub{}
-	trieStub.RecreateCalled = func(root []byte, dbw trie.DBWriteCacher) (tree trie.PatriciaMerkelTree, e error) {
+	trieStub.RecreateCalled = func(root []byte) (data.Trie, error) {
 		wasCalled = true
 		return nil, nil
 	}
-	trieStub.DBWCalled = func() trie.DBWriteCacher {
-		return nil
-	}
 
 	adb := generateAccountDBFromTrie(&trieStub)
 	err := adb.RecreateTrie(nil)
@@ -808,13 +808,10 @@ func TestAccountsDB_RecreateTrieOkValsShouldWork(t *testing.T) {
 	wasCalled := false
 
 	trieStub := mock.TrieStub{}
-	trieStub.RecreateCalled = func(root []byte, dbw trie.DBWriteCacher) (tree trie.PatriciaMerkelTree, e error) {
+	trieStub.RecreateCalled = func(root []byte) (data.Trie, error) {
 		wasCalled = true
 		return &mock.TrieStub{}, nil
 	}
-	trieStub.DBWCalled = func() trie.DBWriteCacher {
-		return nil
-	}
 
 	adb := generateAccountDBFromTrie(&trieStub)
 	err := adb.RecreateTrie(nil)
@@ -824,6 +821,202 @@ func TestAccountsDB_RecreateTrieOkValsShouldWork(t *testing.T) {
 
 }
 
+//------- GetAccountProof
+
+func TestAccountsDB_GetAccountProofNilAddressShouldErr(t *testing.T) {
+	t.Parallel()
+
+	adb := generateAccountDBFromTrie(&mock.TrieStub{})
+	value, proof, err := adb.GetAccountProof([]byte("root hash"), nil)
+
+	assert.Nil(t, value)
+	assert.Nil(t, proof)
+	assert.Equal(t, state.ErrNilAddressContainer, err)
+}
+
+func TestAccountsDB_GetAccountProofRecreateFailsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("failure")
+	trieStub := mock.TrieStub{}
+	trieStub.RecreateCalled = func(root []byte) (data.Trie, error) {
+		return nil, errExpected
+	}
+
+	adb := generateAccountDBFromTrie(&trieStub)
+	_, _, err := adb.GetAccountProof([]byte("root hash"), mock.NewAddressMock())
+
+	assert.Equal(t, errExpected, err)
+}
+
+func TestAccountsDB_GetAccountProofShouldProveOnRecreatedTrie(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	adr := mock.NewAddressMock()
+	value := []byte("account")
+	expectedProof := [][]byte{[]byte("node1"), []byte("node2")}
+
+	recreatedTrie := &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			if bytes.Equal(key, adr.Bytes()) {
+				return value, nil
+			}
+			return nil, nil
+		},
+		ProveCalled: func(key []byte) ([][]byte, error) {
+			return expectedProof, nil
+		},
+	}
+
+	trieStub := mock.TrieStub{}
+	trieStub.RecreateCalled = func(root []byte) (data.Trie, error) {
+		assert.Equal(t, rootHash, root)
+		return recreatedTrie, nil
+	}
+
+	adb := generateAccountDBFromTrie(&trieStub)
+	actualValue, proof, err := adb.GetAccountProof(rootHash, adr)
+
+	assert.Nil(t, err)
+	assert.Equal(t, value, actualValue)
+	assert.Equal(t, expectedProof, proof)
+}
+
+//------- GetAccountFromRoot
+
+func TestAccountsDB_GetAccountFromRootNilAddressShouldErr(t *testing.T) {
+	t.Parallel()
+
+	adb := generateAccountDBFromTrie(&mock.TrieStub{})
+	acnt, err := adb.GetAccountFromRoot([]byte("root hash"), nil)
+
+	assert.Nil(t, acnt)
+	assert.Equal(t, state.ErrNilAddressContainer, err)
+}
+
+func TestAccountsDB_GetAccountFromRootMissingAccountShouldErr(t *testing.T) {
+	t.Parallel()
+
+	recreatedTrie := &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			return nil, nil
+		},
+	}
+	trieStub := mock.TrieStub{}
+	trieStub.RecreateCalled = func(root []byte) (data.Trie, error) {
+		return recreatedTrie, nil
+	}
+
+	adb := generateAccountDBFromTrie(&trieStub)
+	acnt, err := adb.GetAccountFromRoot([]byte("root hash"), mock.NewAddressMock())
+
+	assert.Nil(t, acnt)
+	assert.Equal(t, state.ErrAccNotFound, err)
+}
+
+func TestAccountsDB_GetAccountFromRootShouldReadRecreatedTrieWithoutJournalizing(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	adr := mock.NewAddressMock()
+	marshalizer := &mock.MarshalizerMock{}
+	stored := mock.NewAccountWrapMock(adr, nil)
+	stored.MockValue = 37
+	buff, _ := marshalizer.Marshal(stored)
+
+	recreatedTrie := &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			if bytes.Equal(key, adr.Bytes()) {
+				return buff, nil
+			}
+			return nil, nil
+		},
+	}
+	trieStub := mock.TrieStub{}
+	trieStub.RecreateCalled = func(root []byte) (data.Trie, error) {
+		assert.Equal(t, rootHash, root)
+		return recreatedTrie, nil
+	}
+
+	adb := generateAccountDBFromTrie(&trieStub)
+	acnt, err := adb.GetAccountFromRoot(rootHash, adr)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 37, acnt.(*mock.AccountWrapMock).MockValue)
+	assert.Equal(t, 0, adb.JournalLen())
+}
+
+//------- ForEachAccount
+
+func TestAccountsDB_ForEachAccountNilHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	adb := generateAccountDBFromTrie(&mock.TrieStub{})
+	err := adb.ForEachAccount([]byte("root hash"), nil)
+
+	assert.Equal(t, state.ErrNilAccountsIterationHandler, err)
+}
+
+func TestAccountsDB_ForEachAccountShouldVisitAllAccountsButNotCodes(t *testing.T) {
+	t.Parallel()
+
+	numAccounts := 1500
+	for _, trieType := range stateDiffTrieTypes {
+		adb := createStateDiffAccountsDB(trieType)
+		addresses := make(map[string]struct{})
+		for i := 0; i < numAccounts; i++ {
+			addr := mock.NewAddressMock()
+			addresses[string(addr.Bytes())] = struct{}{}
+			account := setBalanceAndNonce(adb, addr, int64(i), uint64(i))
+			if i%100 == 0 {
+				_ = adb.PutCode(account, []byte(fmt.Sprintf("code %d", i)))
+			}
+		}
+		rootHash, _ := adb.Commit()
+
+		visited := 0
+		totalBalance := big.NewInt(0)
+		withCode := 0
+		err := adb.ForEachAccount(rootHash, func(accountHandler state.AccountHandler) error {
+			account := accountHandler.(*state.Account)
+			_, ok := addresses[string(account.AddressContainer().Bytes())]
+			assert.True(t, ok)
+			visited++
+			totalBalance.Add(totalBalance, account.Balance)
+			if len(account.CodeHash) > 0 {
+				withCode++
+			}
+			return nil
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, numAccounts, visited)
+		assert.Equal(t, big.NewInt(int64(numAccounts*(numAccounts-1)/2)), totalBalance)
+		assert.Equal(t, numAccounts/100, withCode)
+		assert.Equal(t, 0, adb.JournalLen())
+	}
+}
+
+func TestAccountsDB_ForEachAccountHandlerErrorShouldStop(t *testing.T) {
+	t.Parallel()
+
+	adb := createStateDiffAccountsDB(trieFactory.PatriciaMerkleTrie)
+	setBalanceAndNonce(adb, mock.NewAddressMock(), 1, 1)
+	setBalanceAndNonce(adb, mock.NewAddressMock(), 2, 2)
+	rootHash, _ := adb.Commit()
+
+	expectedErr := errors.New("expected error")
+	visited := 0
+	err := adb.ForEachAccount(rootHash, func(accountHandler state.AccountHandler) error {
+		visited++
+		return expectedErr
+	})
+
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, 1, visited)
+}
+
 //------- Functionality test
 
 func TestAccountsDBTestCreateModifyComitSaveGet(t *testing.T) {
@@ -0,0 +1,257 @@
+package state
+
+import (
+	"sync"
+
+	"github.com/numbatx/gn-numbat/marshal"
+)
+
+// AccountsView is an in-memory accounts adapter holding copies of a fixed set of accounts, taken from a source
+// accounts adapter. Changes done on the view do not reach the source, so that several views holding disjoint sets of
+// accounts can be modified concurrently. A view can not work with accounts which were not loaded, nor with code and
+// data tries, and it can not be committed
+type AccountsView struct {
+	marshalizer    marshal.Marshalizer
+	accountFactory AccountFactory
+
+	mutAccounts sync.RWMutex
+	accounts    map[string]AccountHandler
+	loaded      map[string]struct{}
+
+	mutEntries sync.RWMutex
+	entries    []JournalEntry
+}
+
+// NewAccountsView creates a new, empty, accounts view
+func NewAccountsView(marshalizer marshal.Marshalizer, accountFactory AccountFactory) (*AccountsView, error) {
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if accountFactory == nil {
+		return nil, ErrNilAccountFactory
+	}
+
+	return &AccountsView{
+		marshalizer:    marshalizer,
+		accountFactory: accountFactory,
+		accounts:       make(map[string]AccountHandler),
+		loaded:         make(map[string]struct{}),
+		entries:        make([]JournalEntry, 0),
+	}, nil
+}
+
+// LoadAccount copies the account found at the provided address in the source accounts adapter into the view.
+// Missing accounts are loaded as well, as they can be created by the view
+func (av *AccountsView) LoadAccount(source AccountsAdapter, addressContainer AddressContainer) error {
+	if source == nil {
+		return ErrNilAccountsAdapter
+	}
+	if addressContainer == nil {
+		return ErrNilAddressContainer
+	}
+
+	av.mutAccounts.Lock()
+	defer av.mutAccounts.Unlock()
+
+	key := string(addressContainer.Bytes())
+	av.loaded[key] = struct{}{}
+
+	sourceAccount, err := source.GetExistingAccount(addressContainer)
+	if err == ErrAccNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	buff, err := av.marshalizer.Marshal(sourceAccount)
+	if err != nil {
+		return err
+	}
+
+	account, err := av.accountFactory.CreateAccount(addressContainer, av)
+	if err != nil {
+		return err
+	}
+
+	err = av.marshalizer.Unmarshal(account, buff)
+	if err != nil {
+		return err
+	}
+	account.SetCode(sourceAccount.GetCode())
+
+	av.accounts[key] = account
+	return nil
+}
+
+// GetAccountWithJournal returns the loaded account found at the provided address, creating it if it is missing
+func (av *AccountsView) GetAccountWithJournal(addressContainer AddressContainer) (AccountHandler, error) {
+	if addressContainer == nil {
+		return nil, ErrNilAddressContainer
+	}
+
+	av.mutAccounts.Lock()
+	defer av.mutAccounts.Unlock()
+
+	key := string(addressContainer.Bytes())
+	if _, ok := av.loaded[key]; !ok {
+		return nil, ErrAccountNotLoaded
+	}
+
+	account, ok := av.accounts[key]
+	if ok {
+		return account, nil
+	}
+
+	account, err := av.accountFactory.CreateAccount(addressContainer, av)
+	if err != nil {
+		return nil, err
+	}
+
+	av.accounts[key] = account
+	return account, nil
+}
+
+// GetExistingAccount returns the loaded account found at the provided address or ErrAccNotFound if it is missing
+func (av *AccountsView) GetExistingAccount(addressContainer AddressContainer) (AccountHandler, error) {
+	if addressContainer == nil {
+		return nil, ErrNilAddressContainer
+	}
+
+	av.mutAccounts.RLock()
+	defer av.mutAccounts.RUnlock()
+
+	key := string(addressContainer.Bytes())
+	if _, ok := av.loaded[key]; !ok {
+		return nil, ErrAccountNotLoaded
+	}
+
+	account, ok := av.accounts[key]
+	if !ok {
+		return nil, ErrAccNotFound
+	}
+
+	return account, nil
+}
+
+// HasAccount returns true if the loaded account found at the provided address exists
+func (av *AccountsView) HasAccount(addressContainer AddressContainer) (bool, error) {
+	_, err := av.GetExistingAccount(addressContainer)
+	if err == ErrAccNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RemoveAccount is not supported by the accounts view
+func (av *AccountsView) RemoveAccount(addressContainer AddressContainer) error {
+	return ErrOperationNotSupportedByView
+}
+
+// Commit is not supported by the accounts view
+func (av *AccountsView) Commit() ([]byte, error) {
+	return nil, ErrOperationNotSupportedByView
+}
+
+// JournalLen returns the number of journal entries
+func (av *AccountsView) JournalLen() int {
+	av.mutEntries.RLock()
+	defer av.mutEntries.RUnlock()
+
+	return len(av.entries)
+}
+
+// RevertToSnapshot reverts the changes done on the accounts after the provided snapshot. The accounts created after
+// the snapshot are kept, holding their initial values
+func (av *AccountsView) RevertToSnapshot(snapshot int) error {
+	av.mutEntries.Lock()
+	defer av.mutEntries.Unlock()
+
+	if snapshot > len(av.entries) || snapshot < 0 {
+		return nil
+	}
+
+	for i := len(av.entries) - 1; i >= snapshot; i-- {
+		_, err := av.entries[i].Revert()
+		if err != nil {
+			return err
+		}
+	}
+
+	av.entries = av.entries[:snapshot]
+	return nil
+}
+
+// RootHash returns nil as the accounts view is not backed by a trie
+func (av *AccountsView) RootHash() []byte {
+	return nil
+}
+
+// RecreateTrie is not supported by the accounts view
+func (av *AccountsView) RecreateTrie(rootHash []byte) error {
+	return ErrOperationNotSupportedByView
+}
+
+// GetAccountProof is not supported by the accounts view
+func (av *AccountsView) GetAccountProof(rootHash []byte, addressContainer AddressContainer) ([]byte, [][]byte, error) {
+	return nil, nil, ErrOperationNotSupportedByView
+}
+
+// GetAccountFromRoot is not supported by the accounts view
+func (av *AccountsView) GetAccountFromRoot(rootHash []byte, addressContainer AddressContainer) (AccountHandler, error) {
+	return nil, ErrOperationNotSupportedByView
+}
+
+// StateDiff is not supported by the accounts view
+func (av *AccountsView) StateDiff() (*StateDiff, error) {
+	return nil, ErrOperationNotSupportedByView
+}
+
+// UnwindStateDiff is not supported by the accounts view
+func (av *AccountsView) UnwindStateDiff(diff *StateDiff) error {
+	return ErrOperationNotSupportedByView
+}
+
+// PutCode is not supported by the accounts view
+func (av *AccountsView) PutCode(accountHandler AccountHandler, code []byte) error {
+	return ErrOperationNotSupportedByView
+}
+
+// RemoveCode is not supported by the accounts view
+func (av *AccountsView) RemoveCode(codeHash []byte) error {
+	return ErrOperationNotSupportedByView
+}
+
+// LoadDataTrie is not supported by the accounts view
+func (av *AccountsView) LoadDataTrie(accountHandler AccountHandler) error {
+	return ErrOperationNotSupportedByView
+}
+
+// SaveDataTrie is not supported by the accounts view
+func (av *AccountsView) SaveDataTrie(accountHandler AccountHandler) error {
+	return ErrOperationNotSupportedByView
+}
+
+// SaveAccount does nothing as the accounts of the view are kept in memory. Implements AccountTracker
+func (av *AccountsView) SaveAccount(accountHandler AccountHandler) error {
+	if accountHandler == nil {
+		return ErrNilAccountHandler
+	}
+
+	return nil
+}
+
+// Journalize adds a new entry to the journal. Implements AccountTracker
+func (av *AccountsView) Journalize(entry JournalEntry) {
+	if entry == nil {
+		return
+	}
+
+	av.mutEntries.Lock()
+	av.entries = append(av.entries, entry)
+	av.mutEntries.Unlock()
+}
@@ -0,0 +1,163 @@
+package state_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/mock"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func createAccountFactory() state.AccountFactory {
+	return &mock.AccountsFactoryStub{
+		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error) {
+			return state.NewAccount(address, tracker)
+		},
+	}
+}
+
+func createSourceAccountsWithAccount(t *testing.T, address state.AddressContainer, balance int64) *state.AccountsDB {
+	adb, _ := state.NewAccountsDB(mock.NewMockTrie(), mock.HasherMock{}, &mock.MarshalizerMock{}, createAccountFactory())
+
+	account, err := adb.GetAccountWithJournal(address)
+	assert.Nil(t, err)
+	err = account.(*state.Account).SetBalanceWithJournal(big.NewInt(balance))
+	assert.Nil(t, err)
+
+	return adb
+}
+
+//------- NewAccountsView
+
+func TestNewAccountsView_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	av, err := state.NewAccountsView(nil, createAccountFactory())
+
+	assert.Nil(t, av)
+	assert.Equal(t, state.ErrNilMarshalizer, err)
+}
+
+func TestNewAccountsView_NilAccountFactoryShouldErr(t *testing.T) {
+	t.Parallel()
+
+	av, err := state.NewAccountsView(&mock.MarshalizerMock{}, nil)
+
+	assert.Nil(t, av)
+	assert.Equal(t, state.ErrNilAccountFactory, err)
+}
+
+func TestNewAccountsView_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	av, err := state.NewAccountsView(&mock.MarshalizerMock{}, createAccountFactory())
+
+	assert.NotNil(t, av)
+	assert.Nil(t, err)
+}
+
+//------- LoadAccount
+
+func TestAccountsView_LoadAccountNilSourceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	av, _ := state.NewAccountsView(&mock.MarshalizerMock{}, createAccountFactory())
+
+	err := av.LoadAccount(nil, mock.NewAddressMock())
+
+	assert.Equal(t, state.ErrNilAccountsAdapter, err)
+}
+
+func TestAccountsView_LoadAccountShouldCopyTheSourceAccount(t *testing.T) {
+	t.Parallel()
+
+	address := mock.NewAddressMock()
+	source := createSourceAccountsWithAccount(t, address, 100)
+	av, _ := state.NewAccountsView(&mock.MarshalizerMock{}, createAccountFactory())
+
+	err := av.LoadAccount(source, address)
+	assert.Nil(t, err)
+
+	account, err := av.GetExistingAccount(address)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), account.(*state.Account).Balance)
+
+	err = account.(*state.Account).SetBalanceWithJournal(big.NewInt(50))
+	assert.Nil(t, err)
+
+	sourceAccount, _ := source.GetExistingAccount(address)
+	assert.Equal(t, big.NewInt(100), sourceAccount.(*state.Account).Balance)
+}
+
+func TestAccountsView_LoadMissingAccountShouldCreateItOnRequest(t *testing.T) {
+	t.Parallel()
+
+	source := createSourceAccountsWithAccount(t, mock.NewAddressMock(), 100)
+	av, _ := state.NewAccountsView(&mock.MarshalizerMock{}, createAccountFactory())
+	address := mock.NewAddressMock()
+
+	err := av.LoadAccount(source, address)
+	assert.Nil(t, err)
+
+	_, err = av.GetExistingAccount(address)
+	assert.Equal(t, state.ErrAccNotFound, err)
+
+	account, err := av.GetAccountWithJournal(address)
+	assert.Nil(t, err)
+	assert.NotNil(t, account)
+
+	has, err := av.HasAccount(address)
+	assert.Nil(t, err)
+	assert.True(t, has)
+}
+
+//------- GetAccountWithJournal
+
+func TestAccountsView_GetAccountWithJournalNotLoadedShouldErr(t *testing.T) {
+	t.Parallel()
+
+	av, _ := state.NewAccountsView(&mock.MarshalizerMock{}, createAccountFactory())
+
+	account, err := av.GetAccountWithJournal(mock.NewAddressMock())
+
+	assert.Nil(t, account)
+	assert.Equal(t, state.ErrAccountNotLoaded, err)
+}
+
+//------- RevertToSnapshot
+
+func TestAccountsView_RevertToSnapshotShouldRevertChanges(t *testing.T) {
+	t.Parallel()
+
+	address := mock.NewAddressMock()
+	source := createSourceAccountsWithAccount(t, address, 100)
+	av, _ := state.NewAccountsView(&mock.MarshalizerMock{}, createAccountFactory())
+	_ = av.LoadAccount(source, address)
+
+	account, _ := av.GetAccountWithJournal(address)
+	_ = account.(*state.Account).SetBalanceWithJournal(big.NewInt(10))
+	snapshot := av.JournalLen()
+	_ = account.(*state.Account).SetBalanceWithJournal(big.NewInt(20))
+	_ = account.(*state.Account).SetNonceWithJournal(3)
+
+	err := av.RevertToSnapshot(snapshot)
+
+	assert.Nil(t, err)
+	assert.Equal(t, snapshot, av.JournalLen())
+	assert.Equal(t, big.NewInt(10), account.(*state.Account).Balance)
+	assert.Equal(t, uint64(0), account.(*state.Account).Nonce)
+}
+
+//------- Commit
+
+func TestAccountsView_CommitShouldErr(t *testing.T) {
+	t.Parallel()
+
+	av, _ := state.NewAccountsView(&mock.MarshalizerMock{}, createAccountFactory())
+
+	rootHash, err := av.Commit()
+
+	assert.Nil(t, rootHash)
+	assert.Equal(t, state.ErrOperationNotSupportedByView, err)
+}
@@ -1,6 +1,6 @@
 package state
 
-import "github.com/numbatx/gn-numbat/data/trie"
+import "github.com/numbatx/gn-numbat/data"
 
 //------- BaseJournalEntryCreation
 
@@ -89,13 +89,13 @@ func (bjer *BaseJournalEntryRootHash) Revert() (AccountHandler, error) {
 
 // BaseJournalEntryData is used to mark an account's data change
 type BaseJournalEntryData struct {
-	trie    trie.PatriciaMerkelTree
+	trie    data.Trie
 	account AccountHandler
 }
 
 // NewBaseJournalEntryData outputs a new BaseJournalEntry implementation used to keep track of data change.
 // The revert will practically empty the dirty data map
-func NewBaseJournalEntryData(account AccountHandler, trie trie.PatriciaMerkelTree) (*BaseJournalEntryData, error) {
+func NewBaseJournalEntryData(account AccountHandler, trie data.Trie) (*BaseJournalEntryData, error) {
 	if account == nil {
 		return nil, ErrNilAccountHandler
 	}
@@ -117,6 +117,6 @@ func (bjed *BaseJournalEntryData) Revert() (AccountHandler, error) {
 }
 
 // Trie returns the referenced PatriciaMerkelTree for committing the changes
-func (bjed *BaseJournalEntryData) Trie() trie.PatriciaMerkelTree {
+func (bjed *BaseJournalEntryData) Trie() data.Trie {
 	return bjed.trie
 }
@@ -0,0 +1,20 @@
+package converter
+
+import (
+	"errors"
+)
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrNilAccountFactory signals that a nil account factory has been provided
+var ErrNilAccountFactory = errors.New("nil account factory")
+
+// ErrNilStorer signals that a nil storer has been provided
+var ErrNilStorer = errors.New("nil storer")
+
+// ErrNilRootHash signals that a nil or empty root hash has been provided
+var ErrNilRootHash = errors.New("nil or empty root hash")
@@ -0,0 +1,177 @@
+package converter
+
+import (
+	"bytes"
+
+	"github.com/numbatx/gn-numbat/data"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/data/trie2"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/storage"
+)
+
+// trieConverter rebuilds an accounts state kept in a trie (data/trie) AccountsTrie database as a trie2 state.
+// Every leaf of the main trie is copied: the smart contract codes as they are and the accounts after their data
+// tries have been rebuilt in the destination and their root hashes replaced by the new ones
+type trieConverter struct {
+	marshalizer    marshal.Marshalizer
+	hasher         hashing.Hasher
+	accountFactory state.AccountFactory
+}
+
+// NewTrieConverter creates a new trieConverter object. The account factory has to create the accounts kind
+// (shard or metachain) held by the converted state
+func NewTrieConverter(
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	accountFactory state.AccountFactory,
+) (*trieConverter, error) {
+
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if hasher == nil {
+		return nil, ErrNilHasher
+	}
+	if accountFactory == nil {
+		return nil, ErrNilAccountFactory
+	}
+
+	return &trieConverter{
+		marshalizer:    marshalizer,
+		hasher:         hasher,
+		accountFactory: accountFactory,
+	}, nil
+}
+
+// Convert copies the state found at rootHash in the source storer into the destination storer, in the trie2
+// format, and returns the root hash of the converted state
+func (tc *trieConverter) Convert(rootHash []byte, source storage.Storer, destination storage.Storer) ([]byte, error) {
+	if len(rootHash) == 0 {
+		return nil, ErrNilRootHash
+	}
+	if source == nil || destination == nil {
+		return nil, ErrNilStorer
+	}
+
+	dbw, err := trie.NewDBWriteCache(source)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceTrie, err := trie.NewTrie(rootHash, dbw, tc.hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationTrie, err := trie2.NewStateTrie(destination, tc.marshalizer, tc.hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	it := trie.NewIterator(sourceTrie.NodeIterator(nil))
+	for it.Next() {
+		value, err := tc.convertLeaf(it.Key, it.Value, dbw, destinationTrie)
+		if err != nil {
+			return nil, err
+		}
+
+		err = destinationTrie.Update(it.Key, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+
+	err = destinationTrie.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return destinationTrie.Root()
+}
+
+// convertLeaf returns the value the leaf has to hold in the destination trie
+func (tc *trieConverter) convertLeaf(
+	key []byte,
+	value []byte,
+	dbw trie.DBWriteCacher,
+	destinationTrie data.Trie,
+) ([]byte, error) {
+
+	isCode := bytes.Equal(tc.hasher.Compute(string(value)), key)
+	if isCode {
+		return value, nil
+	}
+
+	account, err := tc.accountFactory.CreateAccount(state.NewAddress(key), tc)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tc.marshalizer.Unmarshal(account, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(account.GetRootHash()) == 0 {
+		return value, nil
+	}
+
+	dataRootHash, err := tc.convertDataTrie(account.GetRootHash(), dbw, destinationTrie)
+	if err != nil {
+		return nil, err
+	}
+
+	account.SetRootHash(dataRootHash)
+
+	return tc.marshalizer.Marshal(account)
+}
+
+func (tc *trieConverter) convertDataTrie(
+	rootHash []byte,
+	dbw trie.DBWriteCacher,
+	destinationTrie data.Trie,
+) ([]byte, error) {
+
+	sourceDataTrie, err := trie.NewTrie(rootHash, dbw, tc.hasher)
+	if err != nil {
+		return nil, state.NewErrMissingTrie(rootHash)
+	}
+
+	destinationDataTrie, err := destinationTrie.RecreateDataTrie(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	it := trie.NewIterator(sourceDataTrie.NodeIterator(nil))
+	for it.Next() {
+		err = destinationDataTrie.Update(it.Key, it.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if it.Err != nil {
+		return nil, it.Err
+	}
+
+	err = destinationDataTrie.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	return destinationDataTrie.Root()
+}
+
+// SaveAccount does nothing as the converted accounts are written directly in the destination trie
+func (tc *trieConverter) SaveAccount(accountHandler state.AccountHandler) error {
+	return nil
+}
+
+// Journalize does nothing as the conversion is not reverted entry by entry
+func (tc *trieConverter) Journalize(entry state.JournalEntry) {
+}
@@ -0,0 +1,202 @@
+package converter_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/mock"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/converter"
+	"github.com/numbatx/gn-numbat/data/state/factory"
+	trieFactory "github.com/numbatx/gn-numbat/data/trie/factory"
+	"github.com/numbatx/gn-numbat/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAccount struct {
+	address []byte
+	balance int64
+	code    []byte
+	data    map[string][]byte
+}
+
+func createTestAccounts() []testAccount {
+	accounts := make([]testAccount, 0)
+	for i := 0; i < 10; i++ {
+		address := make([]byte, 32)
+		address[0] = byte(i)
+		address[31] = byte(i * 3)
+
+		account := testAccount{
+			address: address,
+			balance: int64(i*1000 + 7),
+		}
+		if i%3 == 0 {
+			account.code = []byte{byte(i), 'c', 'o', 'd', 'e'}
+			account.data = map[string][]byte{
+				"key1":                  {byte(i), 1},
+				"key2":                  {byte(i), 2},
+				string([]byte{byte(i)}): []byte("value"),
+			}
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return accounts
+}
+
+func createAccountsDB(t *testing.T, trieType string, storer storage.Storer) *state.AccountsDB {
+	tr, err := trieFactory.NewStateTrie(trieType, storer, &mock.MarshalizerMock{}, mock.HasherMock{})
+	assert.Nil(t, err)
+
+	adb, err := state.NewAccountsDB(tr, mock.HasherMock{}, &mock.MarshalizerMock{}, factory.NewAccountCreator())
+	assert.Nil(t, err)
+
+	return adb
+}
+
+func saveTestAccounts(t *testing.T, adb *state.AccountsDB, accounts []testAccount) []byte {
+	for _, testAcc := range accounts {
+		accountHandler, err := adb.GetAccountWithJournal(state.NewAddress(testAcc.address))
+		assert.Nil(t, err)
+
+		err = accountHandler.(*state.Account).SetBalanceWithJournal(big.NewInt(testAcc.balance))
+		assert.Nil(t, err)
+
+		if testAcc.code != nil {
+			err = adb.PutCode(accountHandler, testAcc.code)
+			assert.Nil(t, err)
+		}
+
+		for key, value := range testAcc.data {
+			accountHandler.DataTrieTracker().SaveKeyValue([]byte(key), value)
+		}
+		if len(testAcc.data) > 0 {
+			err = adb.SaveDataTrie(accountHandler)
+			assert.Nil(t, err)
+		}
+	}
+
+	rootHash, err := adb.Commit()
+	assert.Nil(t, err)
+
+	return rootHash
+}
+
+func checkTestAccounts(t *testing.T, adb *state.AccountsDB, accounts []testAccount) {
+	for _, testAcc := range accounts {
+		accountHandler, err := adb.GetExistingAccount(state.NewAddress(testAcc.address))
+		assert.Nil(t, err)
+
+		account := accountHandler.(*state.Account)
+		assert.Equal(t, big.NewInt(testAcc.balance), account.Balance)
+		assert.Equal(t, testAcc.code, account.GetCode())
+
+		for key, value := range testAcc.data {
+			retrievedValue, err := account.DataTrieTracker().RetrieveValue([]byte(key))
+			assert.Nil(t, err)
+			assert.Equal(t, value, retrievedValue)
+		}
+	}
+}
+
+func TestNewTrieConverter_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tc, err := converter.NewTrieConverter(nil, mock.HasherMock{}, factory.NewAccountCreator())
+
+	assert.Nil(t, tc)
+	assert.Equal(t, converter.ErrNilMarshalizer, err)
+}
+
+func TestNewTrieConverter_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tc, err := converter.NewTrieConverter(&mock.MarshalizerMock{}, nil, factory.NewAccountCreator())
+
+	assert.Nil(t, tc)
+	assert.Equal(t, converter.ErrNilHasher, err)
+}
+
+func TestNewTrieConverter_NilAccountFactoryShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tc, err := converter.NewTrieConverter(&mock.MarshalizerMock{}, mock.HasherMock{}, nil)
+
+	assert.Nil(t, tc)
+	assert.Equal(t, converter.ErrNilAccountFactory, err)
+}
+
+func TestNewTrieConverter_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tc, err := converter.NewTrieConverter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+
+	assert.NotNil(t, tc)
+	assert.Nil(t, err)
+}
+
+func TestTrieConverter_ConvertNilRootHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := converter.NewTrieConverter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	rootHash, err := tc.Convert(nil, mock.NewMemoryStorerMock(), mock.NewMemoryStorerMock())
+
+	assert.Nil(t, rootHash)
+	assert.Equal(t, converter.ErrNilRootHash, err)
+}
+
+func TestTrieConverter_ConvertNilStorerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := converter.NewTrieConverter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	rootHash, err := tc.Convert(make([]byte, 32), nil, mock.NewMemoryStorerMock())
+
+	assert.Nil(t, rootHash)
+	assert.Equal(t, converter.ErrNilStorer, err)
+}
+
+func TestTrieConverter_ConvertMissingRootHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := converter.NewTrieConverter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	rootHash, err := tc.Convert([]byte("missing root hash of 32 bytes..."), mock.NewMemoryStorerMock(), mock.NewMemoryStorerMock())
+
+	assert.Nil(t, rootHash)
+	assert.NotNil(t, err)
+}
+
+func TestTrieConverter_ConvertShouldKeepAccountsContent(t *testing.T) {
+	t.Parallel()
+
+	accounts := createTestAccounts()
+	source := mock.NewMemoryStorerMock()
+	sourceRootHash := saveTestAccounts(t, createAccountsDB(t, trieFactory.PatriciaMerkleTrie, source), accounts)
+
+	tc, _ := converter.NewTrieConverter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	destination := mock.NewMemoryStorerMock()
+	rootHash, err := tc.Convert(sourceRootHash, source, destination)
+	assert.Nil(t, err)
+
+	adb := createAccountsDB(t, trieFactory.PatriciaMerkleTrie2, destination)
+	err = adb.RecreateTrie(rootHash)
+	assert.Nil(t, err)
+
+	checkTestAccounts(t, adb, accounts)
+}
+
+func TestTrieConverter_ConvertShouldGiveTheRootHashOfTheSameStateBuiltOnTrie2(t *testing.T) {
+	t.Parallel()
+
+	accounts := createTestAccounts()
+	source := mock.NewMemoryStorerMock()
+	sourceRootHash := saveTestAccounts(t, createAccountsDB(t, trieFactory.PatriciaMerkleTrie, source), accounts)
+	expectedRootHash := saveTestAccounts(t, createAccountsDB(t, trieFactory.PatriciaMerkleTrie2, mock.NewMemoryStorerMock()), accounts)
+
+	tc, _ := converter.NewTrieConverter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	rootHash, err := tc.Convert(sourceRootHash, source, mock.NewMemoryStorerMock())
+
+	assert.Nil(t, err)
+	assert.Equal(t, expectedRootHash, rootHash)
+}
@@ -0,0 +1,65 @@
+package state
+
+import (
+	"bytes"
+
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+)
+
+// leafAccountTracker is the tracker of the accounts decoded from trie leaves, which are never saved nor journalized
+type leafAccountTracker struct {
+}
+
+// SaveAccount does nothing as the decoded accounts are read only
+func (lat *leafAccountTracker) SaveAccount(accountHandler AccountHandler) error {
+	return nil
+}
+
+// Journalize does nothing as the decoded accounts are read only
+func (lat *leafAccountTracker) Journalize(entry JournalEntry) {
+}
+
+// IsCodeLeaf returns true if the accounts trie leaf holds a smart contract code instead of an account. The codes
+// are saved in the accounts trie under the hash of their value
+func IsCodeLeaf(hasher hashing.Hasher, key []byte, value []byte) bool {
+	return bytes.Equal(hasher.Compute(string(value)), key)
+}
+
+// NewDataTrieRootHashGetter returns a function which extracts the data trie root hash of the marshalized account held
+// by a leaf of the accounts trie. Leaves not holding an account, as the smart contracts codes, give a nil root hash
+func NewDataTrieRootHashGetter(
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	accountFactory AccountFactory,
+) (func(key []byte, leaf []byte) []byte, error) {
+
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if hasher == nil {
+		return nil, ErrNilHasher
+	}
+	if accountFactory == nil {
+		return nil, ErrNilAccountFactory
+	}
+
+	tracker := &leafAccountTracker{}
+	return func(key []byte, leaf []byte) []byte {
+		if IsCodeLeaf(hasher, key, leaf) {
+			return nil
+		}
+
+		account, err := accountFactory.CreateAccount(NewAddress(key), tracker)
+		if err != nil {
+			return nil
+		}
+
+		err = marshalizer.Unmarshal(account, leaf)
+		if err != nil {
+			return nil
+		}
+
+		return account.GetRootHash()
+	}, nil
+}
@@ -0,0 +1,58 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/mock"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/stretchr/testify/assert"
+)
+
+func createAccountsFactory() state.AccountFactory {
+	return &mock.AccountsFactoryStub{
+		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error) {
+			return state.NewAccount(address, tracker)
+		},
+	}
+}
+
+func TestIsCodeLeaf(t *testing.T) {
+	t.Parallel()
+
+	hasher := mock.HasherMock{}
+	code := []byte("smart contract code")
+
+	assert.True(t, state.IsCodeLeaf(hasher, hasher.Compute(string(code)), code))
+	assert.False(t, state.IsCodeLeaf(hasher, []byte("address"), code))
+}
+
+func TestNewDataTrieRootHashGetter_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	getter, err := state.NewDataTrieRootHashGetter(&mock.MarshalizerMock{}, nil, createAccountsFactory())
+
+	assert.Nil(t, getter)
+	assert.Equal(t, state.ErrNilHasher, err)
+}
+
+func TestNewDataTrieRootHashGetter_AccountLeafShouldReturnTheDataTrieRootHash(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	getter, _ := state.NewDataTrieRootHashGetter(marshalizer, mock.HasherMock{}, createAccountsFactory())
+	leaf, _ := marshalizer.Marshal(&state.Account{RootHash: []byte("data trie root")})
+
+	assert.Equal(t, []byte("data trie root"), getter([]byte("address"), leaf))
+}
+
+func TestNewDataTrieRootHashGetter_CodeLeafShouldReturnNil(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	hasher := mock.HasherMock{}
+	getter, _ := state.NewDataTrieRootHashGetter(marshalizer, hasher, createAccountsFactory())
+	// a code which would also unmarshal into an account
+	code, _ := marshalizer.Marshal(&state.Account{RootHash: []byte("data trie root")})
+
+	assert.Nil(t, getter(hasher.Compute(string(code)), code))
+}
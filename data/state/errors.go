@@ -121,3 +121,21 @@ var ErrAccNotFound = errors.New("account was not found")
 
 // ErrUnknownShardId signals that shard id is not valid
 var ErrUnknownShardId = errors.New("shard id is not valid")
+
+// ErrAccountNotLoaded signals that an account which was not loaded in an accounts view has been requested
+var ErrAccountNotLoaded = errors.New("account not loaded in accounts view")
+
+// ErrOperationNotSupportedByView signals that an operation which is not supported by an accounts view was called
+var ErrOperationNotSupportedByView = errors.New("operation not supported by accounts view")
+
+// ErrNilStateDiff signals that a nil state diff has been provided
+var ErrNilStateDiff = errors.New("nil state diff")
+
+// ErrStateDiffRootMismatch signals that unwinding a state diff does not lead to the previous root hash it holds
+var ErrStateDiffRootMismatch = errors.New("unwinding the state diff does not lead to its previous root hash")
+
+// ErrNoPreviousState signals that no state was committed or recreated yet, so no state diff can be computed
+var ErrNoPreviousState = errors.New("no committed or recreated state to compute the state diff against")
+
+// ErrNilAccountsIterationHandler signals that a nil handler has been provided for iterating the accounts
+var ErrNilAccountsIterationHandler = errors.New("nil accounts iteration handler")
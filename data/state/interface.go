@@ -1,7 +1,7 @@
 package state
 
 import (
-	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/data"
 )
 
 // HashLength defines how many bytes are used in a hash
@@ -50,8 +50,8 @@ type AccountHandler interface {
 	GetRootHash() []byte
 	SetRootHash([]byte)
 	SetRootHashWithJournal([]byte) error
-	DataTrie() trie.PatriciaMerkelTree
-	SetDataTrie(trie trie.PatriciaMerkelTree)
+	DataTrie() data.Trie
+	SetDataTrie(trie data.Trie)
 	DataTrieTracker() DataTrieTracker
 }
 
@@ -62,8 +62,8 @@ type DataTrieTracker interface {
 	OriginalValue(key []byte) []byte
 	RetrieveValue(key []byte) ([]byte, error)
 	SaveKeyValue(key []byte, value []byte)
-	SetDataTrie(tr trie.PatriciaMerkelTree)
-	DataTrie() trie.PatriciaMerkelTree
+	SetDataTrie(tr data.Trie)
+	DataTrie() data.Trie
 }
 
 // AccountsAdapter is used for the structure that manages the accounts on top of a trie.PatriciaMerkleTrie
@@ -82,6 +82,10 @@ type AccountsAdapter interface {
 	RemoveCode(codeHash []byte) error
 	LoadDataTrie(accountHandler AccountHandler) error
 	SaveDataTrie(accountHandler AccountHandler) error
+	GetAccountProof(rootHash []byte, addressContainer AddressContainer) ([]byte, [][]byte, error)
+	GetAccountFromRoot(rootHash []byte, addressContainer AddressContainer) (AccountHandler, error)
+	StateDiff() (*StateDiff, error)
+	UnwindStateDiff(diff *StateDiff) error
 }
 
 // JournalEntry will be used to implement different state changes to be able to easily revert them
@@ -3,7 +3,7 @@ package state
 import (
 	"math/big"
 
-	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/data"
 )
 
 // MiniBlockData is the data to be saved in shard account for any shard
@@ -166,12 +166,12 @@ func (a *MetaAccount) SetRootHashWithJournal(rootHash []byte) error {
 }
 
 // DataTrie returns the trie that holds the current account's data
-func (a *MetaAccount) DataTrie() trie.PatriciaMerkelTree {
+func (a *MetaAccount) DataTrie() data.Trie {
 	return a.dataTrieTracker.DataTrie()
 }
 
 // SetDataTrie sets the trie that holds the current account's data
-func (a *MetaAccount) SetDataTrie(trie trie.PatriciaMerkelTree) {
+func (a *MetaAccount) SetDataTrie(trie data.Trie) {
 	a.dataTrieTracker.SetDataTrie(trie)
 }
 
@@ -0,0 +1,121 @@
+package proof
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/data/trie/factory"
+	"github.com/numbatx/gn-numbat/data/trie2"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+)
+
+// AccountProof holds the value stored in the accounts trie for an address, along with the Merkle proof of it against
+// the state root hash of a block header. A nil value signals that the proof shows the absence of the account
+type AccountProof struct {
+	Address    []byte
+	Value      []byte
+	Proof      [][]byte
+	RootHash   []byte
+	BlockNonce uint64
+	BlockHash  []byte
+}
+
+// accountProofVerifier checks account proofs without trusting the node that provided them. The marshalizer, the
+// hasher and the trie type have to be the ones used by the nodes for the accounts trie
+type accountProofVerifier struct {
+	marshalizer marshal.Marshalizer
+	hasher      hashing.Hasher
+	trieType    string
+}
+
+// NewAccountProofVerifier creates a new accountProofVerifier object for proofs built by the provided trie type
+func NewAccountProofVerifier(
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	trieType string,
+) (*accountProofVerifier, error) {
+
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if hasher == nil {
+		return nil, ErrNilHasher
+	}
+	if trieType != factory.PatriciaMerkleTrie && trieType != factory.PatriciaMerkleTrie2 {
+		return nil, ErrUnknownTrieType
+	}
+
+	return &accountProofVerifier{
+		marshalizer: marshalizer,
+		hasher:      hasher,
+		trieType:    trieType,
+	}, nil
+}
+
+// VerifyAccount checks the account proof against the provided root hash, which has to be taken from a trusted block
+// header, and returns the proven account. A nil account and a nil error mean that the account does not exist
+func (apv *accountProofVerifier) VerifyAccount(rootHash []byte, accountProof *AccountProof) (*state.Account, error) {
+	if len(rootHash) == 0 {
+		return nil, ErrNilRootHash
+	}
+	if accountProof == nil {
+		return nil, ErrNilAccountProof
+	}
+	if len(accountProof.RootHash) > 0 && !bytes.Equal(accountProof.RootHash, rootHash) {
+		return nil, ErrRootHashMismatch
+	}
+
+	value, err := apv.verifyProof(rootHash, accountProof.Address, accountProof.Proof)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(value, accountProof.Value) {
+		return nil, ErrValueMismatch
+	}
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	account := &state.Account{}
+	err = apv.marshalizer.Unmarshal(account, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// VerifyBalance checks the account proof against the provided root hash, which has to be taken from a trusted block
+// header, and that the proven account holds the expected balance. A missing account has a zero balance
+func (apv *accountProofVerifier) VerifyBalance(rootHash []byte, accountProof *AccountProof, balance *big.Int) error {
+	if balance == nil {
+		return ErrNilBalance
+	}
+
+	account, err := apv.VerifyAccount(rootHash, accountProof)
+	if err != nil {
+		return err
+	}
+
+	provenBalance := big.NewInt(0)
+	if account != nil && account.Balance != nil {
+		provenBalance = account.Balance
+	}
+	if provenBalance.Cmp(balance) != 0 {
+		return ErrBalanceMismatch
+	}
+
+	return nil
+}
+
+func (apv *accountProofVerifier) verifyProof(rootHash []byte, key []byte, proof [][]byte) ([]byte, error) {
+	if apv.trieType == factory.PatriciaMerkleTrie2 {
+		return trie2.VerifyProofOnRoot(rootHash, key, proof, apv.marshalizer, apv.hasher)
+	}
+
+	value, _, err := trie.VerifyProof(rootHash, key, proof, apv.hasher)
+	return value, err
+}
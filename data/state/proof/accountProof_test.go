@@ -0,0 +1,195 @@
+package proof_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/mock"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/factory"
+	"github.com/numbatx/gn-numbat/data/state/proof"
+	trieFactory "github.com/numbatx/gn-numbat/data/trie/factory"
+	"github.com/stretchr/testify/assert"
+)
+
+var trieTypes = []string{trieFactory.PatriciaMerkleTrie, trieFactory.PatriciaMerkleTrie2}
+
+func createAccountsWithBalances(
+	t *testing.T,
+	trieType string,
+	balances map[string]int64,
+) (*state.AccountsDB, []byte) {
+
+	tr, _ := trieFactory.NewStateTrie(trieType, mock.NewMemoryStorerMock(), &mock.MarshalizerMock{}, mock.HasherMock{})
+	adb, _ := state.NewAccountsDB(tr, mock.HasherMock{}, &mock.MarshalizerMock{}, factory.NewAccountCreator())
+
+	for address, balance := range balances {
+		accountHandler, err := adb.GetAccountWithJournal(state.NewAddress([]byte(address)))
+		assert.Nil(t, err)
+
+		err = accountHandler.(*state.Account).SetBalanceWithJournal(big.NewInt(balance))
+		assert.Nil(t, err)
+	}
+
+	rootHash, err := adb.Commit()
+	assert.Nil(t, err)
+
+	return adb, rootHash
+}
+
+func createAccountProof(t *testing.T, adb *state.AccountsDB, rootHash []byte, address []byte) *proof.AccountProof {
+	value, proofNodes, err := adb.GetAccountProof(rootHash, state.NewAddress(address))
+	assert.Nil(t, err)
+
+	return &proof.AccountProof{
+		Address:  address,
+		Value:    value,
+		Proof:    proofNodes,
+		RootHash: rootHash,
+	}
+}
+
+func createBalances() map[string]int64 {
+	balances := make(map[string]int64)
+	for i := 0; i < 20; i++ {
+		address := make([]byte, 32)
+		address[0] = byte(i)
+		address[31] = byte(i * 7)
+		balances[string(address)] = int64(i*100 + 1)
+	}
+
+	return balances
+}
+
+func TestNewAccountProofVerifier_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	apv, err := proof.NewAccountProofVerifier(nil, mock.HasherMock{}, trieFactory.PatriciaMerkleTrie)
+
+	assert.Nil(t, apv)
+	assert.Equal(t, proof.ErrNilMarshalizer, err)
+}
+
+func TestNewAccountProofVerifier_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	apv, err := proof.NewAccountProofVerifier(&mock.MarshalizerMock{}, nil, trieFactory.PatriciaMerkleTrie)
+
+	assert.Nil(t, apv)
+	assert.Equal(t, proof.ErrNilHasher, err)
+}
+
+func TestNewAccountProofVerifier_UnknownTrieTypeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	apv, err := proof.NewAccountProofVerifier(&mock.MarshalizerMock{}, mock.HasherMock{}, "unknown")
+
+	assert.Nil(t, apv)
+	assert.Equal(t, proof.ErrUnknownTrieType, err)
+}
+
+func TestAccountProofVerifier_VerifyAccountShouldReturnProvenAccount(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		balances := createBalances()
+		adb, rootHash := createAccountsWithBalances(t, trieType, balances)
+		apv, _ := proof.NewAccountProofVerifier(&mock.MarshalizerMock{}, mock.HasherMock{}, trieType)
+
+		for address, balance := range balances {
+			accountProof := createAccountProof(t, adb, rootHash, []byte(address))
+
+			account, err := apv.VerifyAccount(rootHash, accountProof)
+			assert.Nil(t, err)
+			assert.Equal(t, big.NewInt(balance), account.Balance)
+
+			err = apv.VerifyBalance(rootHash, accountProof, big.NewInt(balance))
+			assert.Nil(t, err)
+		}
+	}
+}
+
+func TestAccountProofVerifier_VerifyAccountMissingAccountShouldReturnNil(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		adb, rootHash := createAccountsWithBalances(t, trieType, createBalances())
+		apv, _ := proof.NewAccountProofVerifier(&mock.MarshalizerMock{}, mock.HasherMock{}, trieType)
+
+		accountProof := createAccountProof(t, adb, rootHash, []byte("missing address 0123456789abcdef"))
+
+		account, err := apv.VerifyAccount(rootHash, accountProof)
+		assert.Nil(t, err)
+		assert.Nil(t, account)
+
+		err = apv.VerifyBalance(rootHash, accountProof, big.NewInt(0))
+		assert.Nil(t, err)
+	}
+}
+
+func TestAccountProofVerifier_VerifyAccountAlteredValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		adb, rootHash := createAccountsWithBalances(t, trieType, createBalances())
+		apv, _ := proof.NewAccountProofVerifier(&mock.MarshalizerMock{}, mock.HasherMock{}, trieType)
+
+		address := make([]byte, 32)
+		accountProof := createAccountProof(t, adb, rootHash, address)
+		accountProof.Value = []byte("forged account")
+
+		account, err := apv.VerifyAccount(rootHash, accountProof)
+		assert.Nil(t, account)
+		assert.Equal(t, proof.ErrValueMismatch, err)
+	}
+}
+
+func TestAccountProofVerifier_VerifyAccountAlteredProofShouldErr(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		adb, rootHash := createAccountsWithBalances(t, trieType, createBalances())
+		apv, _ := proof.NewAccountProofVerifier(&mock.MarshalizerMock{}, mock.HasherMock{}, trieType)
+
+		address := make([]byte, 32)
+		accountProof := createAccountProof(t, adb, rootHash, address)
+		last := len(accountProof.Proof) - 1
+		accountProof.Proof[last] = append([]byte{}, accountProof.Proof[last]...)
+		accountProof.Proof[last][len(accountProof.Proof[last])-1]++
+
+		account, err := apv.VerifyAccount(rootHash, accountProof)
+		assert.Nil(t, account)
+		assert.NotNil(t, err)
+	}
+}
+
+func TestAccountProofVerifier_VerifyAccountOtherRootHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		adb, rootHash := createAccountsWithBalances(t, trieType, createBalances())
+		apv, _ := proof.NewAccountProofVerifier(&mock.MarshalizerMock{}, mock.HasherMock{}, trieType)
+
+		address := make([]byte, 32)
+		accountProof := createAccountProof(t, adb, rootHash, address)
+
+		account, err := apv.VerifyAccount([]byte("trusted root hash"), accountProof)
+		assert.Nil(t, account)
+		assert.Equal(t, proof.ErrRootHashMismatch, err)
+	}
+}
+
+func TestAccountProofVerifier_VerifyBalanceWrongBalanceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		adb, rootHash := createAccountsWithBalances(t, trieType, createBalances())
+		apv, _ := proof.NewAccountProofVerifier(&mock.MarshalizerMock{}, mock.HasherMock{}, trieType)
+
+		address := make([]byte, 32)
+		accountProof := createAccountProof(t, adb, rootHash, address)
+
+		err := apv.VerifyBalance(rootHash, accountProof, big.NewInt(1000))
+		assert.Equal(t, proof.ErrBalanceMismatch, err)
+	}
+}
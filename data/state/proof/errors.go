@@ -0,0 +1,32 @@
+package proof
+
+import (
+	"errors"
+)
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrUnknownTrieType signals that an unknown trie type has been provided
+var ErrUnknownTrieType = errors.New("unknown trie type")
+
+// ErrNilAccountProof signals that a nil account proof has been provided
+var ErrNilAccountProof = errors.New("nil account proof")
+
+// ErrNilRootHash signals that a nil or empty root hash has been provided
+var ErrNilRootHash = errors.New("nil or empty root hash")
+
+// ErrNilBalance signals that a nil balance has been provided
+var ErrNilBalance = errors.New("nil balance")
+
+// ErrRootHashMismatch signals that the account proof was built against another root hash than the trusted one
+var ErrRootHashMismatch = errors.New("the proof root hash does not match the trusted root hash")
+
+// ErrValueMismatch signals that the value provided along with the proof differs from the value held by the proof
+var ErrValueMismatch = errors.New("the account value does not match the proven value")
+
+// ErrBalanceMismatch signals that the proven balance of an account differs from the expected one
+var ErrBalanceMismatch = errors.New("the proven balance does not match the expected balance")
@@ -0,0 +1,50 @@
+package snapshot
+
+import (
+	"errors"
+)
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrNilAccountFactory signals that a nil account factory has been provided
+var ErrNilAccountFactory = errors.New("nil account factory")
+
+// ErrNilStorer signals that a nil storer has been provided
+var ErrNilStorer = errors.New("nil storer")
+
+// ErrNilTrie signals that a nil trie has been provided
+var ErrNilTrie = errors.New("nil trie")
+
+// ErrNilHeader signals that a nil block header has been provided
+var ErrNilHeader = errors.New("nil block header")
+
+// ErrNilWriter signals that a nil writer has been provided
+var ErrNilWriter = errors.New("nil writer")
+
+// ErrNilReader signals that a nil reader has been provided
+var ErrNilReader = errors.New("nil reader")
+
+// ErrInvalidChunkSize signals that the number of records per chunk is not greater than 0
+var ErrInvalidChunkSize = errors.New("the number of records per chunk should be greater than 0")
+
+// ErrInvalidSnapshot signals that the file is not a state snapshot
+var ErrInvalidSnapshot = errors.New("the file is not a state snapshot")
+
+// ErrUnsupportedVersion signals that the snapshot was written in a format version this node can not read
+var ErrUnsupportedVersion = errors.New("unsupported state snapshot version")
+
+// ErrChecksumMismatch signals that a chunk of the snapshot is corrupted
+var ErrChecksumMismatch = errors.New("state snapshot chunk checksum mismatch")
+
+// ErrInvalidRecord signals that a record of the snapshot could not be decoded or is out of place
+var ErrInvalidRecord = errors.New("invalid state snapshot record")
+
+// ErrUntrustedHeader signals that the hash of the snapshot header is not the trusted header hash
+var ErrUntrustedHeader = errors.New("the snapshot header does not match the trusted header hash")
+
+// ErrRootHashMismatch signals that the imported state does not have the expected root hash
+var ErrRootHashMismatch = errors.New("the imported state root hash does not match the expected root hash")
@@ -0,0 +1,114 @@
+package snapshot
+
+import (
+	"io"
+
+	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/storage"
+)
+
+// exporter writes the accounts state of a block, kept in a trie (data/trie) AccountsTrie database, in a state
+// snapshot. The accounts trie is walked with the trie node iterator and every account is followed by the leaves
+// of its data trie, so the snapshot holds the whole state needed to bootstrap a node at that block
+type exporter struct {
+	*leafDecoder
+}
+
+// NewExporter creates a new exporter object. The account factory has to create the accounts kind held by the
+// exported state
+func NewExporter(
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	accountFactory state.AccountFactory,
+) (*exporter, error) {
+	ld, err := newLeafDecoder(marshalizer, hasher, accountFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exporter{leafDecoder: ld}, nil
+}
+
+// Export writes the state found in the source storer at the root hash of the header, along with the header, in
+// chunks of at most chunkSize records. The header should be final, as the snapshot is trusted by its hash
+func (e *exporter) Export(header *block.Header, source storage.Storer, w io.Writer, chunkSize int) error {
+	if header == nil {
+		return ErrNilHeader
+	}
+	if source == nil {
+		return ErrNilStorer
+	}
+	if w == nil {
+		return ErrNilWriter
+	}
+	if chunkSize <= 0 {
+		return ErrInvalidChunkSize
+	}
+
+	headerBuff, err := e.marshalizer.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	dbw, err := trie.NewDBWriteCache(source)
+	if err != nil {
+		return err
+	}
+
+	accountsTrie, err := trie.NewTrie(header.RootHash, dbw, e.hasher)
+	if err != nil {
+		return state.NewErrMissingTrie(header.RootHash)
+	}
+
+	cw, err := newChunkWriter(w, headerBuff, chunkSize)
+	if err != nil {
+		return err
+	}
+
+	it := trie.NewIterator(accountsTrie.NodeIterator(nil))
+	for it.Next() {
+		err = cw.write(record{kind: recordAccountsLeaf, key: it.Key, value: it.Value})
+		if err != nil {
+			return err
+		}
+
+		dataRootHash, err := e.dataTrieRootHash(it.Key, it.Value)
+		if err != nil {
+			return err
+		}
+		if len(dataRootHash) == 0 {
+			continue
+		}
+
+		err = e.exportDataTrie(dataRootHash, dbw, cw)
+		if err != nil {
+			return err
+		}
+	}
+	if it.Err != nil {
+		return it.Err
+	}
+
+	return cw.close()
+}
+
+func (e *exporter) exportDataTrie(rootHash []byte, dbw trie.DBWriteCacher, cw *chunkWriter) error {
+	dataTrie, err := trie.NewTrie(rootHash, dbw, e.hasher)
+	if err != nil {
+		return state.NewErrMissingTrie(rootHash)
+	}
+
+	it := trie.NewIterator(dataTrie.NodeIterator(nil))
+	for it.Next() {
+		err = cw.write(record{kind: recordDataLeaf, key: it.Key, value: it.Value})
+		if err != nil {
+			return err
+		}
+	}
+
+	return it.Err
+}
@@ -0,0 +1,275 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+)
+
+// A state snapshot file starts with the magic bytes, the format version and the marshalized block header whose
+// state it holds. The records follow in chunks, each one made of the number of records, the payload length, the
+// payload and the sha256 checksum of the payload. A chunk with no records ends the file.
+//
+// Every record holds a kind, a key and a value. The accounts trie leaves, either accounts or smart contract codes,
+// are written in the trie order, each account being followed by the leaves of its data trie
+const (
+	// Version is the state snapshot format version written by the exporter
+	Version = uint32(1)
+
+	recordAccountsLeaf = byte(1)
+	recordDataLeaf     = byte(2)
+
+	checksumLength = sha256.Size
+	// maxBlobLength bounds the allocations done while reading a possibly corrupted file
+	maxBlobLength = 1 << 30
+)
+
+var magic = []byte("NSNP")
+
+type record struct {
+	kind  byte
+	key   []byte
+	value []byte
+}
+
+func writeUint32(w io.Writer, value uint32) error {
+	buff := make([]byte, 4)
+	binary.BigEndian.PutUint32(buff, value)
+	_, err := w.Write(buff)
+	return err
+}
+
+func readUint32(r io.Reader) (uint32, error) {
+	buff := make([]byte, 4)
+	_, err := io.ReadFull(r, buff)
+	if err != nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint32(buff), nil
+}
+
+func writeBlob(w io.Writer, blob []byte) error {
+	err := writeUint32(w, uint32(len(blob)))
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(blob)
+	return err
+}
+
+func readBlob(r io.Reader) ([]byte, error) {
+	length, err := readUint32(r)
+	if err != nil {
+		return nil, err
+	}
+	if length > maxBlobLength {
+		return nil, ErrInvalidRecord
+	}
+
+	blob := make([]byte, length)
+	_, err = io.ReadFull(r, blob)
+	if err != nil {
+		return nil, err
+	}
+
+	return blob, nil
+}
+
+// chunkWriter writes the snapshot records, grouping them in checksummed chunks
+type chunkWriter struct {
+	w          *bufio.Writer
+	chunkSize  int
+	payload    bytes.Buffer
+	numRecords int
+}
+
+func newChunkWriter(w io.Writer, headerBuff []byte, chunkSize int) (*chunkWriter, error) {
+	cw := &chunkWriter{
+		w:         bufio.NewWriter(w),
+		chunkSize: chunkSize,
+	}
+
+	_, err := cw.w.Write(magic)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeUint32(cw.w, Version)
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeBlob(cw.w, headerBuff)
+	if err != nil {
+		return nil, err
+	}
+
+	return cw, nil
+}
+
+func (cw *chunkWriter) write(rec record) error {
+	cw.payload.WriteByte(rec.kind)
+	_ = writeBlob(&cw.payload, rec.key)
+	_ = writeBlob(&cw.payload, rec.value)
+	cw.numRecords++
+
+	if cw.numRecords < cw.chunkSize {
+		return nil
+	}
+
+	return cw.flushChunk()
+}
+
+func (cw *chunkWriter) flushChunk() error {
+	if cw.numRecords == 0 {
+		return nil
+	}
+
+	err := writeUint32(cw.w, uint32(cw.numRecords))
+	if err != nil {
+		return err
+	}
+
+	payload := cw.payload.Bytes()
+	err = writeBlob(cw.w, payload)
+	if err != nil {
+		return err
+	}
+
+	checksum := sha256.Sum256(payload)
+	_, err = cw.w.Write(checksum[:])
+	if err != nil {
+		return err
+	}
+
+	cw.payload.Reset()
+	cw.numRecords = 0
+	return nil
+}
+
+// close writes the pending records and the chunk ending the file
+func (cw *chunkWriter) close() error {
+	err := cw.flushChunk()
+	if err != nil {
+		return err
+	}
+
+	err = writeUint32(cw.w, 0)
+	if err != nil {
+		return err
+	}
+
+	return cw.w.Flush()
+}
+
+// chunkReader reads the snapshot records, checking the checksum of every chunk before handing out its records
+type chunkReader struct {
+	r       *bufio.Reader
+	records []record
+	ended   bool
+}
+
+// newChunkReader checks the magic bytes and the version of the snapshot and returns the reader of its records
+// along with the marshalized header the snapshot was taken at
+func newChunkReader(r io.Reader) (*chunkReader, []byte, error) {
+	cr := &chunkReader{
+		r: bufio.NewReader(r),
+	}
+
+	fileMagic := make([]byte, len(magic))
+	_, err := io.ReadFull(cr.r, fileMagic)
+	if err != nil || !bytes.Equal(fileMagic, magic) {
+		return nil, nil, ErrInvalidSnapshot
+	}
+
+	version, err := readUint32(cr.r)
+	if err != nil {
+		return nil, nil, ErrInvalidSnapshot
+	}
+	if version != Version {
+		return nil, nil, ErrUnsupportedVersion
+	}
+
+	headerBuff, err := readBlob(cr.r)
+	if err != nil {
+		return nil, nil, ErrInvalidSnapshot
+	}
+
+	return cr, headerBuff, nil
+}
+
+// next returns the next record, or nil once the chunk ending the file was read
+func (cr *chunkReader) next() (*record, error) {
+	for len(cr.records) == 0 {
+		if cr.ended {
+			return nil, nil
+		}
+
+		err := cr.readChunk()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rec := cr.records[0]
+	cr.records = cr.records[1:]
+	return &rec, nil
+}
+
+func (cr *chunkReader) readChunk() error {
+	numRecords, err := readUint32(cr.r)
+	if err != nil {
+		return err
+	}
+	if numRecords == 0 {
+		cr.ended = true
+		return nil
+	}
+
+	payload, err := readBlob(cr.r)
+	if err != nil {
+		return err
+	}
+
+	checksum := make([]byte, checksumLength)
+	_, err = io.ReadFull(cr.r, checksum)
+	if err != nil {
+		return err
+	}
+
+	computed := sha256.Sum256(payload)
+	if !bytes.Equal(checksum, computed[:]) {
+		return ErrChecksumMismatch
+	}
+
+	payloadReader := bytes.NewReader(payload)
+	records := make([]record, 0, numRecords)
+	for i := uint32(0); i < numRecords; i++ {
+		kind, err := payloadReader.ReadByte()
+		if err != nil {
+			return ErrInvalidRecord
+		}
+
+		key, err := readBlob(payloadReader)
+		if err != nil {
+			return ErrInvalidRecord
+		}
+
+		value, err := readBlob(payloadReader)
+		if err != nil {
+			return ErrInvalidRecord
+		}
+
+		records = append(records, record{kind: kind, key: key, value: value})
+	}
+	if payloadReader.Len() != 0 {
+		return ErrInvalidRecord
+	}
+
+	cr.records = records
+	return nil
+}
@@ -0,0 +1,167 @@
+package snapshot
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/numbatx/gn-numbat/data"
+	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+)
+
+// importer rebuilds the accounts state held by a state snapshot. The snapshot is only accepted if its header
+// hash is the trusted one and if the rebuilt state, as well as every rebuilt data trie, has the root hash it was
+// exported with
+type importer struct {
+	*leafDecoder
+}
+
+// pendingDataTrie is the data trie of the last imported account, which is being rebuilt
+type pendingDataTrie struct {
+	tr       data.Trie
+	rootHash []byte
+}
+
+// NewImporter creates a new importer object. The account factory has to create the accounts kind held by the
+// imported state
+func NewImporter(
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	accountFactory state.AccountFactory,
+) (*importer, error) {
+	ld, err := newLeafDecoder(marshalizer, hasher, accountFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	return &importer{leafDecoder: ld}, nil
+}
+
+// Import reads the snapshot and writes its state in the provided empty trie, which should be of the trie type
+// the state was exported from. The header the snapshot was taken at is returned once its hash was checked
+// against trustedHeaderHash and the root hash of the imported state was checked against the header root hash
+func (i *importer) Import(r io.Reader, destination data.Trie, trustedHeaderHash []byte) (*block.Header, error) {
+	if r == nil {
+		return nil, ErrNilReader
+	}
+	if destination == nil {
+		return nil, ErrNilTrie
+	}
+
+	cr, headerBuff, err := newChunkReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(i.hasher.Compute(string(headerBuff)), trustedHeaderHash) {
+		return nil, ErrUntrustedHeader
+	}
+
+	header := &block.Header{}
+	err = i.marshalizer.Unmarshal(header, headerBuff)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending *pendingDataTrie
+	for {
+		rec, err := cr.next()
+		if err != nil {
+			return nil, err
+		}
+		if rec == nil {
+			break
+		}
+
+		switch rec.kind {
+		case recordAccountsLeaf:
+			err = commitDataTrie(pending)
+			if err != nil {
+				return nil, err
+			}
+
+			pending, err = i.importAccountsLeaf(rec, destination)
+		case recordDataLeaf:
+			if pending == nil {
+				return nil, ErrInvalidRecord
+			}
+
+			err = pending.tr.Update(rec.key, rec.value)
+		default:
+			return nil, ErrInvalidRecord
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = commitDataTrie(pending)
+	if err != nil {
+		return nil, err
+	}
+
+	err = destination.Commit()
+	if err != nil {
+		return nil, err
+	}
+
+	rootHash, err := destination.Root()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(rootHash, header.RootHash) {
+		return nil, ErrRootHashMismatch
+	}
+
+	return header, nil
+}
+
+// importAccountsLeaf writes the leaf in the accounts trie and, if it holds an account having data, returns the
+// data trie its data leaves will be written in
+func (i *importer) importAccountsLeaf(rec *record, destination data.Trie) (*pendingDataTrie, error) {
+	err := destination.Update(rec.key, rec.value)
+	if err != nil {
+		return nil, err
+	}
+
+	dataRootHash, err := i.dataTrieRootHash(rec.key, rec.value)
+	if err != nil {
+		return nil, err
+	}
+	if len(dataRootHash) == 0 {
+		return nil, nil
+	}
+
+	dataTrie, err := destination.RecreateDataTrie(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pendingDataTrie{
+		tr:       dataTrie,
+		rootHash: dataRootHash,
+	}, nil
+}
+
+func commitDataTrie(pending *pendingDataTrie) error {
+	if pending == nil {
+		return nil
+	}
+
+	err := pending.tr.Commit()
+	if err != nil {
+		return err
+	}
+
+	rootHash, err := pending.tr.Root()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rootHash, pending.rootHash) {
+		return ErrRootHashMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package snapshot
+
+import (
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+)
+
+// leafDecoder tells apart the accounts trie leaves holding smart contract codes from the ones holding accounts
+// and extracts the data trie root hash of the latter
+type leafDecoder struct {
+	marshalizer    marshal.Marshalizer
+	hasher         hashing.Hasher
+	accountFactory state.AccountFactory
+}
+
+func newLeafDecoder(
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	accountFactory state.AccountFactory,
+) (*leafDecoder, error) {
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if hasher == nil {
+		return nil, ErrNilHasher
+	}
+	if accountFactory == nil {
+		return nil, ErrNilAccountFactory
+	}
+
+	return &leafDecoder{
+		marshalizer:    marshalizer,
+		hasher:         hasher,
+		accountFactory: accountFactory,
+	}, nil
+}
+
+// dataTrieRootHash returns the root hash of the data trie of the account held by the leaf, or nil if the leaf
+// holds a smart contract code or an account without data
+func (ld *leafDecoder) dataTrieRootHash(key []byte, value []byte) ([]byte, error) {
+	if state.IsCodeLeaf(ld.hasher, key, value) {
+		return nil, nil
+	}
+
+	account, err := ld.accountFactory.CreateAccount(state.NewAddress(key), ld)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ld.marshalizer.Unmarshal(account, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return account.GetRootHash(), nil
+}
+
+// SaveAccount does nothing as the decoded accounts are read only
+func (ld *leafDecoder) SaveAccount(accountHandler state.AccountHandler) error {
+	return nil
+}
+
+// Journalize does nothing as the decoded accounts are read only
+func (ld *leafDecoder) Journalize(entry state.JournalEntry) {
+}
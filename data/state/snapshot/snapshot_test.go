@@ -0,0 +1,241 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data"
+	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/data/mock"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/factory"
+	"github.com/numbatx/gn-numbat/data/state/snapshot"
+	trieFactory "github.com/numbatx/gn-numbat/data/trie/factory"
+	"github.com/numbatx/gn-numbat/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+type testAccount struct {
+	address []byte
+	balance int64
+	code    []byte
+	data    map[string][]byte
+}
+
+func createTestAccounts() []testAccount {
+	accounts := make([]testAccount, 0)
+	for i := 0; i < 10; i++ {
+		address := make([]byte, 32)
+		address[0] = byte(i)
+		address[31] = byte(i * 7)
+
+		account := testAccount{
+			address: address,
+			balance: int64(i*100 + 3),
+		}
+		if i%3 == 0 {
+			account.code = []byte{byte(i), 'c', 'o', 'd', 'e'}
+			account.data = map[string][]byte{
+				"key1":                  {byte(i), 1},
+				"key2":                  {byte(i), 2},
+				string([]byte{byte(i)}): []byte("value"),
+			}
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return accounts
+}
+
+func createTrie(t *testing.T, trieType string, storer storage.Storer) data.Trie {
+	tr, err := trieFactory.NewStateTrie(trieType, storer, &mock.MarshalizerMock{}, mock.HasherMock{})
+	assert.Nil(t, err)
+
+	return tr
+}
+
+func createAccountsDB(t *testing.T, tr data.Trie) *state.AccountsDB {
+	adb, err := state.NewAccountsDB(tr, mock.HasherMock{}, &mock.MarshalizerMock{}, factory.NewAccountCreator())
+	assert.Nil(t, err)
+
+	return adb
+}
+
+func saveTestAccounts(t *testing.T, adb *state.AccountsDB, accounts []testAccount) []byte {
+	for _, testAcc := range accounts {
+		accountHandler, err := adb.GetAccountWithJournal(state.NewAddress(testAcc.address))
+		assert.Nil(t, err)
+
+		err = accountHandler.(*state.Account).SetBalanceWithJournal(big.NewInt(testAcc.balance))
+		assert.Nil(t, err)
+
+		if testAcc.code != nil {
+			err = adb.PutCode(accountHandler, testAcc.code)
+			assert.Nil(t, err)
+		}
+
+		for key, value := range testAcc.data {
+			accountHandler.DataTrieTracker().SaveKeyValue([]byte(key), value)
+		}
+		if len(testAcc.data) > 0 {
+			err = adb.SaveDataTrie(accountHandler)
+			assert.Nil(t, err)
+		}
+	}
+
+	rootHash, err := adb.Commit()
+	assert.Nil(t, err)
+
+	return rootHash
+}
+
+func exportTestState(t *testing.T) ([]byte, *block.Header, []byte) {
+	storer := mock.NewMemoryStorerMock()
+	adb := createAccountsDB(t, createTrie(t, trieFactory.PatriciaMerkleTrie, storer))
+	rootHash := saveTestAccounts(t, adb, createTestAccounts())
+
+	header := &block.Header{Nonce: 12, Round: 15, RootHash: rootHash}
+	headerBuff, _ := (&mock.MarshalizerMock{}).Marshal(header)
+	headerHash := mock.HasherMock{}.Compute(string(headerBuff))
+
+	exp, _ := snapshot.NewExporter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	buff := &bytes.Buffer{}
+	err := exp.Export(header, storer, buff, 3)
+	assert.Nil(t, err)
+
+	return buff.Bytes(), header, headerHash
+}
+
+func TestNewExporter_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	exp, err := snapshot.NewExporter(nil, mock.HasherMock{}, factory.NewAccountCreator())
+
+	assert.Nil(t, exp)
+	assert.Equal(t, snapshot.ErrNilMarshalizer, err)
+}
+
+func TestNewImporter_NilAccountFactoryShouldErr(t *testing.T) {
+	t.Parallel()
+
+	imp, err := snapshot.NewImporter(&mock.MarshalizerMock{}, mock.HasherMock{}, nil)
+
+	assert.Nil(t, imp)
+	assert.Equal(t, snapshot.ErrNilAccountFactory, err)
+}
+
+func TestExporter_ExportInvalidChunkSizeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	exp, _ := snapshot.NewExporter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	err := exp.Export(&block.Header{}, mock.NewMemoryStorerMock(), &bytes.Buffer{}, 0)
+
+	assert.Equal(t, snapshot.ErrInvalidChunkSize, err)
+}
+
+func TestExporter_ExportMissingStateShouldErr(t *testing.T) {
+	t.Parallel()
+
+	exp, _ := snapshot.NewExporter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	header := &block.Header{RootHash: mock.HasherMock{}.Compute("missing root")}
+	err := exp.Export(header, mock.NewMemoryStorerMock(), &bytes.Buffer{}, 10)
+
+	assert.NotNil(t, err)
+}
+
+func TestImporter_ImportShouldRebuildTheExportedState(t *testing.T) {
+	t.Parallel()
+
+	snapshotBuff, header, headerHash := exportTestState(t)
+
+	destination := createTrie(t, trieFactory.PatriciaMerkleTrie, mock.NewMemoryStorerMock())
+	imp, _ := snapshot.NewImporter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	importedHeader, err := imp.Import(bytes.NewReader(snapshotBuff), destination, headerHash)
+	assert.Nil(t, err)
+	assert.Equal(t, header, importedHeader)
+
+	adb := createAccountsDB(t, destination)
+	err = adb.RecreateTrie(header.RootHash)
+	assert.Nil(t, err)
+	for _, testAcc := range createTestAccounts() {
+		accountHandler, err := adb.GetExistingAccount(state.NewAddress(testAcc.address))
+		assert.Nil(t, err)
+
+		account := accountHandler.(*state.Account)
+		assert.Equal(t, big.NewInt(testAcc.balance), account.Balance)
+		assert.Equal(t, testAcc.code, account.GetCode())
+		for key, value := range testAcc.data {
+			retrievedValue, err := account.DataTrieTracker().RetrieveValue([]byte(key))
+			assert.Nil(t, err)
+			assert.Equal(t, value, retrievedValue)
+		}
+	}
+}
+
+func TestImporter_ImportUntrustedHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	snapshotBuff, _, _ := exportTestState(t)
+
+	destination := createTrie(t, trieFactory.PatriciaMerkleTrie, mock.NewMemoryStorerMock())
+	imp, _ := snapshot.NewImporter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	header, err := imp.Import(bytes.NewReader(snapshotBuff), destination, []byte("other header hash"))
+
+	assert.Nil(t, header)
+	assert.Equal(t, snapshot.ErrUntrustedHeader, err)
+}
+
+func TestImporter_ImportCorruptedChunkShouldErr(t *testing.T) {
+	t.Parallel()
+
+	snapshotBuff, _, headerHash := exportTestState(t)
+	// the last bytes before the chunk ending the file belong to the checksum of the last chunk
+	snapshotBuff[len(snapshotBuff)-5] ^= 0xFF
+
+	destination := createTrie(t, trieFactory.PatriciaMerkleTrie, mock.NewMemoryStorerMock())
+	imp, _ := snapshot.NewImporter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	header, err := imp.Import(bytes.NewReader(snapshotBuff), destination, headerHash)
+
+	assert.Nil(t, header)
+	assert.Equal(t, snapshot.ErrChecksumMismatch, err)
+}
+
+func TestImporter_ImportUnsupportedVersionShouldErr(t *testing.T) {
+	t.Parallel()
+
+	snapshotBuff, _, headerHash := exportTestState(t)
+	snapshotBuff[7] = byte(snapshot.Version + 1)
+
+	destination := createTrie(t, trieFactory.PatriciaMerkleTrie, mock.NewMemoryStorerMock())
+	imp, _ := snapshot.NewImporter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	header, err := imp.Import(bytes.NewReader(snapshotBuff), destination, headerHash)
+
+	assert.Nil(t, header)
+	assert.Equal(t, snapshot.ErrUnsupportedVersion, err)
+}
+
+func TestImporter_ImportNotASnapshotShouldErr(t *testing.T) {
+	t.Parallel()
+
+	destination := createTrie(t, trieFactory.PatriciaMerkleTrie, mock.NewMemoryStorerMock())
+	imp, _ := snapshot.NewImporter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	header, err := imp.Import(bytes.NewReader([]byte("not a snapshot")), destination, nil)
+
+	assert.Nil(t, header)
+	assert.Equal(t, snapshot.ErrInvalidSnapshot, err)
+}
+
+func TestImporter_ImportInOtherTrieTypeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	snapshotBuff, _, headerHash := exportTestState(t)
+
+	destination := createTrie(t, trieFactory.PatriciaMerkleTrie2, mock.NewMemoryStorerMock())
+	imp, _ := snapshot.NewImporter(&mock.MarshalizerMock{}, mock.HasherMock{}, factory.NewAccountCreator())
+	header, err := imp.Import(bytes.NewReader(snapshotBuff), destination, headerHash)
+
+	assert.Nil(t, header)
+	assert.Equal(t, snapshot.ErrRootHashMismatch, err)
+}
@@ -0,0 +1,274 @@
+package state
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/numbatx/gn-numbat/data"
+)
+
+// AccountDiff holds the change made to an account by a block. The Created flag signals that the account did not
+// exist before the block, in which case the old fields are empty, and the Removed flag that it does not exist after
+// it, in which case the new fields are empty
+type AccountDiff struct {
+	Address     []byte
+	Created     bool
+	Removed     bool
+	OldNonce    uint64
+	NewNonce    uint64
+	OldBalance  *big.Int
+	NewBalance  *big.Int
+	OldCodeHash []byte
+	NewCodeHash []byte
+	OldRootHash []byte
+	NewRootHash []byte
+}
+
+// CodeDiff holds a smart contract code leaf added or removed by a block. A nil OldCode signals that the code was
+// added, otherwise OldCode holds the removed code
+type CodeDiff struct {
+	CodeHash []byte
+	OldCode  []byte
+}
+
+// StateDiff holds the changes made to the accounts trie by a block, which turned the state with the PrevRootHash
+// root hash in the state with the RootHash root hash. Unwinding it restores the previous state without reading the
+// trie nodes of the previous state
+type StateDiff struct {
+	PrevRootHash []byte
+	RootHash     []byte
+	Accounts     []AccountDiff
+	Codes        []CodeDiff
+}
+
+// StateDiff returns the changes made to the accounts since the last commit or trie recreation. The old values are
+// read from the trie recreated at the last committed root hash, so the live state is not disturbed. The state diff
+// is not available before the first commit or trie recreation
+func (adb *AccountsDB) StateDiff() (*StateDiff, error) {
+	adb.mutTouched.Lock()
+	prevRootHash := adb.lastRootHash
+	touchedAccounts := sortedKeys(adb.touchedAccounts)
+	touchedCodes := sortedKeys(adb.touchedCodes)
+	adb.mutTouched.Unlock()
+
+	if prevRootHash == nil {
+		return nil, ErrNoPreviousState
+	}
+
+	prevTrie, err := adb.mainTrie.Recreate(prevRootHash)
+	if err != nil {
+		return nil, NewErrMissingTrie(prevRootHash)
+	}
+	rootHash, err := adb.mainTrie.Root()
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &StateDiff{
+		PrevRootHash: prevRootHash,
+		RootHash:     rootHash,
+		Accounts:     make([]AccountDiff, 0, len(touchedAccounts)),
+		Codes:        make([]CodeDiff, 0),
+	}
+
+	for _, address := range touchedAccounts {
+		oldValue, newValue, err := adb.touchedValues(prevTrie, address)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(oldValue, newValue) {
+			continue
+		}
+
+		accountDiff, err := adb.createAccountDiff(address, oldValue, newValue)
+		if err != nil {
+			return nil, err
+		}
+		diff.Accounts = append(diff.Accounts, *accountDiff)
+	}
+
+	for _, codeHash := range touchedCodes {
+		oldCode, newCode, err := adb.touchedValues(prevTrie, codeHash)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(oldCode, newCode) {
+			continue
+		}
+
+		diff.Codes = append(diff.Codes, CodeDiff{CodeHash: codeHash, OldCode: oldCode})
+	}
+
+	return diff, nil
+}
+
+// UnwindStateDiff reverts the changes held by the state diff, which has to be the diff of the last committed state,
+// and commits the resulting state. The changes are applied on a trie recreated from the root hash of the diff, so the
+// live state is left untouched if the diff can not be unwound: the data tries of the accounts, restored through their
+// root hashes only, have to be still available and the resulting root hash has to be the previous root hash of the
+// diff, otherwise an error is returned and the caller should recreate the previous state from its trie
+func (adb *AccountsDB) UnwindStateDiff(diff *StateDiff) error {
+	if diff == nil {
+		return ErrNilStateDiff
+	}
+
+	tr, err := adb.mainTrie.Recreate(diff.RootHash)
+	if err != nil {
+		return NewErrMissingTrie(diff.RootHash)
+	}
+
+	for _, accountDiff := range diff.Accounts {
+		err = adb.checkDataTrieAvailable(accountDiff)
+		if err != nil {
+			return err
+		}
+
+		err = adb.unwindAccountDiff(tr, accountDiff)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, codeDiff := range diff.Codes {
+		err = tr.Update(codeDiff.CodeHash, codeDiff.OldCode)
+		if err != nil {
+			return err
+		}
+	}
+
+	rootHash, err := tr.Root()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(rootHash, diff.PrevRootHash) {
+		return ErrStateDiffRootMismatch
+	}
+
+	adb.mainTrie = tr
+	adb.clearJournal()
+	_, err = adb.Commit()
+
+	return err
+}
+
+// checkDataTrieAvailable checks that the data trie the account had before the block can still be loaded, as the
+// trie nodes of a previous state may have been pruned
+func (adb *AccountsDB) checkDataTrieAvailable(accountDiff AccountDiff) error {
+	if accountDiff.Created || len(accountDiff.OldRootHash) == 0 {
+		return nil
+	}
+	if bytes.Equal(accountDiff.OldRootHash, accountDiff.NewRootHash) {
+		return nil
+	}
+
+	_, err := adb.mainTrie.RecreateDataTrie(accountDiff.OldRootHash)
+	if err != nil {
+		return NewErrMissingTrie(accountDiff.OldRootHash)
+	}
+
+	return nil
+}
+
+func (adb *AccountsDB) unwindAccountDiff(tr data.Trie, accountDiff AccountDiff) error {
+	if accountDiff.Created {
+		return tr.Update(accountDiff.Address, nil)
+	}
+
+	oldAccount := &Account{
+		Nonce:    accountDiff.OldNonce,
+		Balance:  accountDiff.OldBalance,
+		CodeHash: accountDiff.OldCodeHash,
+		RootHash: accountDiff.OldRootHash,
+	}
+	buff, err := adb.marshalizer.Marshal(oldAccount)
+	if err != nil {
+		return err
+	}
+
+	return tr.Update(accountDiff.Address, buff)
+}
+
+func (adb *AccountsDB) touchedValues(prevTrie data.Trie, key []byte) ([]byte, []byte, error) {
+	oldValue, err := prevTrie.Get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	newValue, err := adb.mainTrie.Get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return oldValue, newValue, nil
+}
+
+func (adb *AccountsDB) createAccountDiff(address []byte, oldValue []byte, newValue []byte) (*AccountDiff, error) {
+	accountDiff := &AccountDiff{
+		Address: address,
+		Created: len(oldValue) == 0,
+		Removed: len(newValue) == 0,
+	}
+
+	if !accountDiff.Created {
+		oldAccount := &Account{}
+		err := adb.marshalizer.Unmarshal(oldAccount, oldValue)
+		if err != nil {
+			return nil, err
+		}
+
+		accountDiff.OldNonce = oldAccount.Nonce
+		accountDiff.OldBalance = oldAccount.Balance
+		accountDiff.OldCodeHash = oldAccount.CodeHash
+		accountDiff.OldRootHash = oldAccount.RootHash
+	}
+
+	if !accountDiff.Removed {
+		newAccount := &Account{}
+		err := adb.marshalizer.Unmarshal(newAccount, newValue)
+		if err != nil {
+			return nil, err
+		}
+
+		accountDiff.NewNonce = newAccount.Nonce
+		accountDiff.NewBalance = newAccount.Balance
+		accountDiff.NewCodeHash = newAccount.CodeHash
+		accountDiff.NewRootHash = newAccount.RootHash
+	}
+
+	return accountDiff, nil
+}
+
+func (adb *AccountsDB) markTouchedAccount(address []byte) {
+	adb.mutTouched.Lock()
+	adb.touchedAccounts[string(address)] = struct{}{}
+	adb.mutTouched.Unlock()
+}
+
+func (adb *AccountsDB) markTouchedCode(codeHash []byte) {
+	adb.mutTouched.Lock()
+	adb.touchedCodes[string(codeHash)] = struct{}{}
+	adb.mutTouched.Unlock()
+}
+
+func (adb *AccountsDB) resetTouched(rootHash []byte) {
+	adb.mutTouched.Lock()
+	adb.lastRootHash = rootHash
+	adb.touchedAccounts = make(map[string]struct{})
+	adb.touchedCodes = make(map[string]struct{})
+	adb.mutTouched.Unlock()
+}
+
+func sortedKeys(keys map[string]struct{}) [][]byte {
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	result := make([][]byte, len(sorted))
+	for i, key := range sorted {
+		result[i] = []byte(key)
+	}
+
+	return result
+}
@@ -0,0 +1,171 @@
+package state_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/mock"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/factory"
+	trieFactory "github.com/numbatx/gn-numbat/data/trie/factory"
+	"github.com/stretchr/testify/assert"
+)
+
+var stateDiffTrieTypes = []string{trieFactory.PatriciaMerkleTrie, trieFactory.PatriciaMerkleTrie2}
+
+func createStateDiffAccountsDB(trieType string) *state.AccountsDB {
+	marshalizer := &mock.MarshalizerMock{}
+	tr, _ := trieFactory.NewStateTrie(trieType, mock.NewMemoryStorerMock(), marshalizer, mock.HasherMock{})
+	adb, _ := state.NewAccountsDB(tr, mock.HasherMock{}, marshalizer, factory.NewAccountCreator())
+
+	return adb
+}
+
+func setBalanceAndNonce(adb *state.AccountsDB, address state.AddressContainer, balance int64, nonce uint64) *state.Account {
+	accHandler, _ := adb.GetAccountWithJournal(address)
+	account := accHandler.(*state.Account)
+	_ = account.SetBalanceWithJournal(big.NewInt(balance))
+	_ = account.SetNonceWithJournal(nonce)
+
+	return account
+}
+
+func TestAccountsDB_StateDiffBeforeAnyCommitShouldErr(t *testing.T) {
+	t.Parallel()
+
+	adb := createStateDiffAccountsDB(trieFactory.PatriciaMerkleTrie)
+	diff, err := adb.StateDiff()
+
+	assert.Nil(t, diff)
+	assert.Equal(t, state.ErrNoPreviousState, err)
+}
+
+func TestAccountsDB_UnwindNilStateDiffShouldErr(t *testing.T) {
+	t.Parallel()
+
+	adb := createStateDiffAccountsDB(trieFactory.PatriciaMerkleTrie)
+
+	assert.Equal(t, state.ErrNilStateDiff, adb.UnwindStateDiff(nil))
+}
+
+func TestAccountsDB_StateDiffShouldHoldTheChangedAccountsAndCodes(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range stateDiffTrieTypes {
+		adb := createStateDiffAccountsDB(trieType)
+		addr1 := mock.NewAddressMock()
+		addr2 := mock.NewAddressMock()
+		addr3 := mock.NewAddressMock()
+		setBalanceAndNonce(adb, addr1, 10, 1)
+		setBalanceAndNonce(adb, addr3, 5, 2)
+		prevRootHash, _ := adb.Commit()
+
+		setBalanceAndNonce(adb, addr1, 20, 2)
+		account2 := setBalanceAndNonce(adb, addr2, 7, 0)
+		_ = adb.PutCode(account2, []byte("smart contract code"))
+		// touched but left unchanged
+		setBalanceAndNonce(adb, addr3, 5, 2)
+
+		diff, err := adb.StateDiff()
+		assert.Nil(t, err, trieType)
+		rootHash, _ := adb.Commit()
+
+		assert.Equal(t, prevRootHash, diff.PrevRootHash, trieType)
+		assert.Equal(t, rootHash, diff.RootHash, trieType)
+		assert.Equal(t, 2, len(diff.Accounts), trieType)
+		for _, accountDiff := range diff.Accounts {
+			switch string(accountDiff.Address) {
+			case string(addr1.Bytes()):
+				assert.False(t, accountDiff.Created, trieType)
+				assert.Equal(t, big.NewInt(10), accountDiff.OldBalance, trieType)
+				assert.Equal(t, big.NewInt(20), accountDiff.NewBalance, trieType)
+				assert.Equal(t, uint64(1), accountDiff.OldNonce, trieType)
+				assert.Equal(t, uint64(2), accountDiff.NewNonce, trieType)
+			case string(addr2.Bytes()):
+				assert.True(t, accountDiff.Created, trieType)
+				assert.Equal(t, big.NewInt(7), accountDiff.NewBalance, trieType)
+				assert.Equal(t, account2.CodeHash, accountDiff.NewCodeHash, trieType)
+				assert.Nil(t, accountDiff.OldCodeHash, trieType)
+			default:
+				assert.Fail(t, "unexpected account in the state diff", trieType)
+			}
+		}
+		assert.Equal(t, 1, len(diff.Codes), trieType)
+		assert.Equal(t, account2.CodeHash, diff.Codes[0].CodeHash, trieType)
+		assert.Nil(t, diff.Codes[0].OldCode, trieType)
+	}
+}
+
+func TestAccountsDB_UnwindStateDiffShouldRestoreThePreviousState(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range stateDiffTrieTypes {
+		adb := createStateDiffAccountsDB(trieType)
+		addr1 := mock.NewAddressMock()
+		addr2 := mock.NewAddressMock()
+		setBalanceAndNonce(adb, addr1, 10, 1)
+		prevRootHash, _ := adb.Commit()
+
+		setBalanceAndNonce(adb, addr1, 20, 2)
+		account2 := setBalanceAndNonce(adb, addr2, 7, 0)
+		_ = adb.PutCode(account2, []byte("smart contract code"))
+		diff, _ := adb.StateDiff()
+		_, _ = adb.Commit()
+
+		err := adb.UnwindStateDiff(diff)
+
+		assert.Nil(t, err, trieType)
+		assert.Equal(t, prevRootHash, adb.RootHash(), trieType)
+		account1, _ := adb.GetExistingAccount(addr1)
+		assert.Equal(t, big.NewInt(10), account1.(*state.Account).Balance, trieType)
+		_, err = adb.GetExistingAccount(addr2)
+		assert.Equal(t, state.ErrAccNotFound, err, trieType)
+	}
+}
+
+func TestAccountsDB_UnwindStateDiffNotLeadingToThePreviousRootHashShouldNotChangeTheState(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range stateDiffTrieTypes {
+		adb := createStateDiffAccountsDB(trieType)
+		addr := mock.NewAddressMock()
+		setBalanceAndNonce(adb, addr, 10, 1)
+		_, _ = adb.Commit()
+
+		setBalanceAndNonce(adb, addr, 20, 2)
+		diff, _ := adb.StateDiff()
+		rootHash, _ := adb.Commit()
+		diff.PrevRootHash = []byte("another root hash")
+
+		err := adb.UnwindStateDiff(diff)
+
+		assert.Equal(t, state.ErrStateDiffRootMismatch, err, trieType)
+		assert.Equal(t, rootHash, adb.RootHash(), trieType)
+		account, _ := adb.GetExistingAccount(addr)
+		assert.Equal(t, big.NewInt(20), account.(*state.Account).Balance, trieType)
+	}
+}
+
+func TestAccountsDB_UnwindStateDiffWithMissingDataTrieShouldErr(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range stateDiffTrieTypes {
+		adb := createStateDiffAccountsDB(trieType)
+		addr := mock.NewAddressMock()
+		setBalanceAndNonce(adb, addr, 10, 1)
+		_, _ = adb.Commit()
+
+		setBalanceAndNonce(adb, addr, 20, 2)
+		diff, _ := adb.StateDiff()
+		rootHash, _ := adb.Commit()
+		//the data trie the account had before the block was pruned
+		diff.Accounts[0].OldRootHash = make([]byte, state.HashLength)
+		diff.Accounts[0].OldRootHash[0] = 1
+
+		err := adb.UnwindStateDiff(diff)
+
+		_, isMissingTrie := err.(*state.ErrMissingTrie)
+		assert.True(t, isMissingTrie, trieType)
+		assert.Equal(t, rootHash, adb.RootHash(), trieType)
+	}
+}
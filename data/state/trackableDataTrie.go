@@ -1,16 +1,16 @@
 package state
 
-import "github.com/numbatx/gn-numbat/data/trie"
+import "github.com/numbatx/gn-numbat/data"
 
 // TrackableDataTrie wraps a PatriciaMerkelTrie adding modifying data capabilities
 type TrackableDataTrie struct {
 	originalData map[string][]byte
 	dirtyData    map[string][]byte
-	tr           trie.PatriciaMerkelTree
+	tr           data.Trie
 }
 
 // NewTrackableDataTrie returns an instance of DataTrieTracker
-func NewTrackableDataTrie(tr trie.PatriciaMerkelTree) *TrackableDataTrie {
+func NewTrackableDataTrie(tr data.Trie) *TrackableDataTrie {
 	return &TrackableDataTrie{
 		tr:           tr,
 		originalData: make(map[string][]byte),
@@ -73,11 +73,11 @@ func (tdaw *TrackableDataTrie) SaveKeyValue(key []byte, value []byte) {
 }
 
 // SetDataTrie sets the internal data trie
-func (tdaw *TrackableDataTrie) SetDataTrie(tr trie.PatriciaMerkelTree) {
+func (tdaw *TrackableDataTrie) SetDataTrie(tr data.Trie) {
 	tdaw.tr = tr
 }
 
 // DataTrie sets the internal data trie
-func (tdaw *TrackableDataTrie) DataTrie() trie.PatriciaMerkelTree {
+func (tdaw *TrackableDataTrie) DataTrie() data.Trie {
 	return tdaw.tr
 }
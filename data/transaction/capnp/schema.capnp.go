@@ -12,34 +12,46 @@ import (
 
 type TransactionCapn C.Struct
 
-func NewTransactionCapn(s *C.Segment) TransactionCapn { return TransactionCapn(s.NewStruct(24, 6)) }
+func NewTransactionCapn(s *C.Segment) TransactionCapn { return TransactionCapn(s.NewStruct(40, 8)) }
 func NewRootTransactionCapn(s *C.Segment) TransactionCapn {
-	return TransactionCapn(s.NewRootStruct(24, 6))
+	return TransactionCapn(s.NewRootStruct(40, 8))
 }
 func AutoNewTransactionCapn(s *C.Segment) TransactionCapn {
-	return TransactionCapn(s.NewStructAR(24, 6))
+	return TransactionCapn(s.NewStructAR(40, 8))
 }
 func ReadRootTransactionCapn(s *C.Segment) TransactionCapn {
 	return TransactionCapn(s.Root(0).ToStruct())
 }
-func (s TransactionCapn) Nonce() uint64         { return C.Struct(s).Get64(0) }
-func (s TransactionCapn) SetNonce(v uint64)     { C.Struct(s).Set64(0, v) }
-func (s TransactionCapn) Value() []byte         { return C.Struct(s).GetObject(0).ToData() }
-func (s TransactionCapn) SetValue(v []byte)     { C.Struct(s).SetObject(0, s.Segment.NewData(v)) }
-func (s TransactionCapn) RcvAddr() []byte       { return C.Struct(s).GetObject(1).ToData() }
-func (s TransactionCapn) SetRcvAddr(v []byte)   { C.Struct(s).SetObject(1, s.Segment.NewData(v)) }
-func (s TransactionCapn) SndAddr() []byte       { return C.Struct(s).GetObject(2).ToData() }
-func (s TransactionCapn) SetSndAddr(v []byte)   { C.Struct(s).SetObject(2, s.Segment.NewData(v)) }
-func (s TransactionCapn) GasPrice() uint64      { return C.Struct(s).Get64(8) }
-func (s TransactionCapn) SetGasPrice(v uint64)  { C.Struct(s).Set64(8, v) }
-func (s TransactionCapn) GasLimit() uint64      { return C.Struct(s).Get64(16) }
-func (s TransactionCapn) SetGasLimit(v uint64)  { C.Struct(s).Set64(16, v) }
-func (s TransactionCapn) Data() []byte          { return C.Struct(s).GetObject(3).ToData() }
-func (s TransactionCapn) SetData(v []byte)      { C.Struct(s).SetObject(3, s.Segment.NewData(v)) }
-func (s TransactionCapn) Signature() []byte     { return C.Struct(s).GetObject(4).ToData() }
-func (s TransactionCapn) SetSignature(v []byte) { C.Struct(s).SetObject(4, s.Segment.NewData(v)) }
-func (s TransactionCapn) Challenge() []byte     { return C.Struct(s).GetObject(5).ToData() }
-func (s TransactionCapn) SetChallenge(v []byte) { C.Struct(s).SetObject(5, s.Segment.NewData(v)) }
+func (s TransactionCapn) Nonce() uint64               { return C.Struct(s).Get64(0) }
+func (s TransactionCapn) SetNonce(v uint64)           { C.Struct(s).Set64(0, v) }
+func (s TransactionCapn) Value() []byte               { return C.Struct(s).GetObject(0).ToData() }
+func (s TransactionCapn) SetValue(v []byte)           { C.Struct(s).SetObject(0, s.Segment.NewData(v)) }
+func (s TransactionCapn) RcvAddr() []byte             { return C.Struct(s).GetObject(1).ToData() }
+func (s TransactionCapn) SetRcvAddr(v []byte)         { C.Struct(s).SetObject(1, s.Segment.NewData(v)) }
+func (s TransactionCapn) SndAddr() []byte             { return C.Struct(s).GetObject(2).ToData() }
+func (s TransactionCapn) SetSndAddr(v []byte)         { C.Struct(s).SetObject(2, s.Segment.NewData(v)) }
+func (s TransactionCapn) GasPrice() uint64            { return C.Struct(s).Get64(8) }
+func (s TransactionCapn) SetGasPrice(v uint64)        { C.Struct(s).Set64(8, v) }
+func (s TransactionCapn) GasLimit() uint64            { return C.Struct(s).Get64(16) }
+func (s TransactionCapn) SetGasLimit(v uint64)        { C.Struct(s).Set64(16, v) }
+func (s TransactionCapn) Data() []byte                { return C.Struct(s).GetObject(3).ToData() }
+func (s TransactionCapn) SetData(v []byte)            { C.Struct(s).SetObject(3, s.Segment.NewData(v)) }
+func (s TransactionCapn) Signature() []byte           { return C.Struct(s).GetObject(4).ToData() }
+func (s TransactionCapn) SetSignature(v []byte)       { C.Struct(s).SetObject(4, s.Segment.NewData(v)) }
+func (s TransactionCapn) Challenge() []byte           { return C.Struct(s).GetObject(5).ToData() }
+func (s TransactionCapn) SetChallenge(v []byte)       { C.Struct(s).SetObject(5, s.Segment.NewData(v)) }
+func (s TransactionCapn) ValidFromRound() uint32      { return C.Struct(s).Get32(24) }
+func (s TransactionCapn) SetValidFromRound(v uint32)  { C.Struct(s).Set32(24, v) }
+func (s TransactionCapn) ValidUntilRound() uint32     { return C.Struct(s).Get32(28) }
+func (s TransactionCapn) SetValidUntilRound(v uint32) { C.Struct(s).Set32(28, v) }
+func (s TransactionCapn) Transfers() TransferCapn_List {
+	return TransferCapn_List(C.Struct(s).GetObject(6))
+}
+func (s TransactionCapn) SetTransfers(v TransferCapn_List) { C.Struct(s).SetObject(6, C.Object(v)) }
+func (s TransactionCapn) ChainID() []byte                  { return C.Struct(s).GetObject(7).ToData() }
+func (s TransactionCapn) SetChainID(v []byte)              { C.Struct(s).SetObject(7, s.Segment.NewData(v)) }
+func (s TransactionCapn) Version() uint32                  { return C.Struct(s).Get32(32) }
+func (s TransactionCapn) SetVersion(v uint32)              { C.Struct(s).Set32(32, v) }
 func (s TransactionCapn) WriteJSON(w io.Writer) error {
 	b := bufio.NewWriter(w)
 	var err error
@@ -216,6 +228,115 @@ func (s TransactionCapn) WriteJSON(w io.Writer) error {
 			return err
 		}
 	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"validFromRound\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ValidFromRound()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"validUntilRound\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ValidUntilRound()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"transfers\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Transfers()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				err = s.WriteJSON(b)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"chainID\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ChainID()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"version\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Version()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte('}')
 	if err != nil {
 		return err
@@ -404,6 +525,115 @@ func (s TransactionCapn) WriteCapLit(w io.Writer) error {
 			return err
 		}
 	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("validFromRound = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ValidFromRound()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("validUntilRound = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ValidUntilRound()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("transfers = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Transfers()
+		{
+			err = b.WriteByte('[')
+			if err != nil {
+				return err
+			}
+			for i, s := range s.ToArray() {
+				if i != 0 {
+					_, err = b.WriteString(", ")
+				}
+				if err != nil {
+					return err
+				}
+				err = s.WriteCapLit(b)
+				if err != nil {
+					return err
+				}
+			}
+			err = b.WriteByte(']')
+		}
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("chainID = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.ChainID()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("version = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Version()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
 	err = b.WriteByte(')')
 	if err != nil {
 		return err
@@ -420,7 +650,7 @@ func (s TransactionCapn) MarshalCapLit() ([]byte, error) {
 type TransactionCapn_List C.PointerList
 
 func NewTransactionCapnList(s *C.Segment, sz int) TransactionCapn_List {
-	return TransactionCapn_List(s.NewCompositeList(24, 6, sz))
+	return TransactionCapn_List(s.NewCompositeList(40, 8, sz))
 }
 func (s TransactionCapn_List) Len() int { return C.PointerList(s).Len() }
 func (s TransactionCapn_List) At(i int) TransactionCapn {
@@ -437,3 +667,543 @@ func (s TransactionCapn_List) ToArray() []TransactionCapn {
 func (s TransactionCapn_List) Set(i int, item TransactionCapn) {
 	C.PointerList(s).Set(i, C.Object(item))
 }
+
+type TransferCapn C.Struct
+
+func NewTransferCapn(s *C.Segment) TransferCapn { return TransferCapn(s.NewStruct(0, 2)) }
+func NewRootTransferCapn(s *C.Segment) TransferCapn {
+	return TransferCapn(s.NewRootStruct(0, 2))
+}
+func AutoNewTransferCapn(s *C.Segment) TransferCapn {
+	return TransferCapn(s.NewStructAR(0, 2))
+}
+func ReadRootTransferCapn(s *C.Segment) TransferCapn {
+	return TransferCapn(s.Root(0).ToStruct())
+}
+func (s TransferCapn) RcvAddr() []byte     { return C.Struct(s).GetObject(0).ToData() }
+func (s TransferCapn) SetRcvAddr(v []byte) { C.Struct(s).SetObject(0, s.Segment.NewData(v)) }
+func (s TransferCapn) Value() []byte       { return C.Struct(s).GetObject(1).ToData() }
+func (s TransferCapn) SetValue(v []byte)   { C.Struct(s).SetObject(1, s.Segment.NewData(v)) }
+func (s TransferCapn) WriteJSON(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	var err error
+	var buf []byte
+	_ = buf
+	err = b.WriteByte('{')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"rcvAddr\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.RcvAddr()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"value\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Value()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte('}')
+	if err != nil {
+		return err
+	}
+	err = b.Flush()
+	return err
+}
+func (s TransferCapn) MarshalJSON() ([]byte, error) {
+	b := bytes.Buffer{}
+	err := s.WriteJSON(&b)
+	return b.Bytes(), err
+}
+func (s TransferCapn) WriteCapLit(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	var err error
+	var buf []byte
+	_ = buf
+	err = b.WriteByte('(')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("rcvAddr = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.RcvAddr()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("value = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Value()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(')')
+	if err != nil {
+		return err
+	}
+	err = b.Flush()
+	return err
+}
+func (s TransferCapn) MarshalCapLit() ([]byte, error) {
+	b := bytes.Buffer{}
+	err := s.WriteCapLit(&b)
+	return b.Bytes(), err
+}
+
+type TransferCapn_List C.PointerList
+
+func NewTransferCapnList(s *C.Segment, sz int) TransferCapn_List {
+	return TransferCapn_List(s.NewCompositeList(0, 2, sz))
+}
+func (s TransferCapn_List) Len() int { return C.PointerList(s).Len() }
+func (s TransferCapn_List) At(i int) TransferCapn {
+	return TransferCapn(C.PointerList(s).At(i).ToStruct())
+}
+func (s TransferCapn_List) ToArray() []TransferCapn {
+	n := s.Len()
+	a := make([]TransferCapn, n)
+	for i := 0; i < n; i++ {
+		a[i] = s.At(i)
+	}
+	return a
+}
+func (s TransferCapn_List) Set(i int, item TransferCapn) {
+	C.PointerList(s).Set(i, C.Object(item))
+}
+
+type ExecutionResultCapn C.Struct
+
+func NewExecutionResultCapn(s *C.Segment) ExecutionResultCapn {
+	return ExecutionResultCapn(s.NewStruct(24, 4))
+}
+func NewRootExecutionResultCapn(s *C.Segment) ExecutionResultCapn {
+	return ExecutionResultCapn(s.NewRootStruct(24, 4))
+}
+func AutoNewExecutionResultCapn(s *C.Segment) ExecutionResultCapn {
+	return ExecutionResultCapn(s.NewStructAR(24, 4))
+}
+func ReadRootExecutionResultCapn(s *C.Segment) ExecutionResultCapn {
+	return ExecutionResultCapn(s.Root(0).ToStruct())
+}
+func (s ExecutionResultCapn) Status() string         { return C.Struct(s).GetObject(0).ToText() }
+func (s ExecutionResultCapn) SetStatus(v string)     { C.Struct(s).SetObject(0, s.Segment.NewText(v)) }
+func (s ExecutionResultCapn) Error() string          { return C.Struct(s).GetObject(1).ToText() }
+func (s ExecutionResultCapn) SetError(v string)      { C.Struct(s).SetObject(1, s.Segment.NewText(v)) }
+func (s ExecutionResultCapn) GasUsed() uint64        { return C.Struct(s).Get64(0) }
+func (s ExecutionResultCapn) SetGasUsed(v uint64)    { C.Struct(s).Set64(0, v) }
+func (s ExecutionResultCapn) BlockNonce() uint64     { return C.Struct(s).Get64(8) }
+func (s ExecutionResultCapn) SetBlockNonce(v uint64) { C.Struct(s).Set64(8, v) }
+func (s ExecutionResultCapn) BlockHash() []byte      { return C.Struct(s).GetObject(2).ToData() }
+func (s ExecutionResultCapn) SetBlockHash(v []byte)  { C.Struct(s).SetObject(2, s.Segment.NewData(v)) }
+func (s ExecutionResultCapn) MiniBlockHash() []byte  { return C.Struct(s).GetObject(3).ToData() }
+func (s ExecutionResultCapn) SetMiniBlockHash(v []byte) {
+	C.Struct(s).SetObject(3, s.Segment.NewData(v))
+}
+func (s ExecutionResultCapn) SndShardId() uint32     { return C.Struct(s).Get32(16) }
+func (s ExecutionResultCapn) SetSndShardId(v uint32) { C.Struct(s).Set32(16, v) }
+func (s ExecutionResultCapn) RcvShardId() uint32     { return C.Struct(s).Get32(20) }
+func (s ExecutionResultCapn) SetRcvShardId(v uint32) { C.Struct(s).Set32(20, v) }
+func (s ExecutionResultCapn) WriteJSON(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	var err error
+	var buf []byte
+	_ = buf
+	err = b.WriteByte('{')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"status\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Status()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"error\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Error()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"gasUsed\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.GasUsed()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"blockNonce\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.BlockNonce()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"blockHash\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.BlockHash()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"miniBlockHash\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.MiniBlockHash()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"sndShardId\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.SndShardId()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(',')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("\"rcvShardId\":")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.RcvShardId()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte('}')
+	if err != nil {
+		return err
+	}
+	err = b.Flush()
+	return err
+}
+func (s ExecutionResultCapn) MarshalJSON() ([]byte, error) {
+	b := bytes.Buffer{}
+	err := s.WriteJSON(&b)
+	return b.Bytes(), err
+}
+func (s ExecutionResultCapn) WriteCapLit(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	var err error
+	var buf []byte
+	_ = buf
+	err = b.WriteByte('(')
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("status = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Status()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("error = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.Error()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("gasUsed = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.GasUsed()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("blockNonce = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.BlockNonce()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("blockHash = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.BlockHash()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("miniBlockHash = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.MiniBlockHash()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("sndShardId = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.SndShardId()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = b.WriteString(", ")
+	if err != nil {
+		return err
+	}
+	_, err = b.WriteString("rcvShardId = ")
+	if err != nil {
+		return err
+	}
+	{
+		s := s.RcvShardId()
+		buf, err = json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = b.Write(buf)
+		if err != nil {
+			return err
+		}
+	}
+	err = b.WriteByte(')')
+	if err != nil {
+		return err
+	}
+	err = b.Flush()
+	return err
+}
+func (s ExecutionResultCapn) MarshalCapLit() ([]byte, error) {
+	b := bytes.Buffer{}
+	err := s.WriteCapLit(&b)
+	return b.Bytes(), err
+}
+
+type ExecutionResultCapn_List C.PointerList
+
+func NewExecutionResultCapnList(s *C.Segment, sz int) ExecutionResultCapn_List {
+	return ExecutionResultCapn_List(s.NewCompositeList(24, 4, sz))
+}
+func (s ExecutionResultCapn_List) Len() int { return C.PointerList(s).Len() }
+func (s ExecutionResultCapn_List) At(i int) ExecutionResultCapn {
+	return ExecutionResultCapn(C.PointerList(s).At(i).ToStruct())
+}
+func (s ExecutionResultCapn_List) ToArray() []ExecutionResultCapn {
+	n := s.Len()
+	a := make([]ExecutionResultCapn, n)
+	for i := 0; i < n; i++ {
+		a[i] = s.At(i)
+	}
+	return a
+}
+func (s ExecutionResultCapn_List) Set(i int, item ExecutionResultCapn) {
+	C.PointerList(s).Set(i, C.Object(item))
+}
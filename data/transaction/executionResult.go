@@ -0,0 +1,90 @@
+package transaction
+
+import (
+	"io"
+
+	capn "github.com/glycerine/go-capnproto"
+	"github.com/numbatx/gn-numbat/data/transaction/capnp"
+)
+
+// ExecutionStatus is the status of a transaction as seen by a shard
+type ExecutionStatus string
+
+const (
+	// TxStatusReceived signals that the transaction is in the pool, waiting to be included in a block
+	TxStatusReceived ExecutionStatus = "received"
+	// TxStatusPartiallyExecuted signals that the transaction was executed in the sender shard and is pending
+	// in the receiver shard
+	TxStatusPartiallyExecuted ExecutionStatus = "partially-executed"
+	// TxStatusExecuted signals that the transaction was successfully executed
+	TxStatusExecuted ExecutionStatus = "executed"
+	// TxStatusFailed signals that the transaction was included in a block but its execution failed
+	TxStatusFailed ExecutionStatus = "failed"
+)
+
+// ExecutionResult holds the outcome of a transaction execution in a committed block
+type ExecutionResult struct {
+	Status        ExecutionStatus `capid:"0"`
+	Error         string          `capid:"1"`
+	GasUsed       uint64          `capid:"2"`
+	BlockNonce    uint64          `capid:"3"`
+	BlockHash     []byte          `capid:"4"`
+	MiniBlockHash []byte          `capid:"5"`
+	SndShardID    uint32          `capid:"6"`
+	RcvShardID    uint32          `capid:"7"`
+}
+
+// Save saves the serialized data of an ExecutionResult into a stream through Capnp protocol
+func (er *ExecutionResult) Save(w io.Writer) error {
+	seg := capn.NewBuffer(nil)
+	ExecutionResultGoToCapn(seg, er)
+	_, err := seg.WriteTo(w)
+	return err
+}
+
+// Load loads the data from the stream into an ExecutionResult object through Capnp protocol
+func (er *ExecutionResult) Load(r io.Reader) error {
+	capMsg, err := capn.ReadFromStream(r, nil)
+	if err != nil {
+		return err
+	}
+	z := capnp.ReadRootExecutionResultCapn(capMsg)
+	ExecutionResultCapnToGo(z, er)
+	return nil
+}
+
+// ExecutionResultCapnToGo is a helper function to copy fields from an ExecutionResultCapn object to an
+// ExecutionResult object
+func ExecutionResultCapnToGo(src capnp.ExecutionResultCapn, dest *ExecutionResult) *ExecutionResult {
+	if dest == nil {
+		dest = &ExecutionResult{}
+	}
+
+	dest.Status = ExecutionStatus(src.Status())
+	dest.Error = src.Error()
+	dest.GasUsed = src.GasUsed()
+	dest.BlockNonce = src.BlockNonce()
+	dest.BlockHash = src.BlockHash()
+	dest.MiniBlockHash = src.MiniBlockHash()
+	dest.SndShardID = src.SndShardId()
+	dest.RcvShardID = src.RcvShardId()
+
+	return dest
+}
+
+// ExecutionResultGoToCapn is a helper function to copy fields from an ExecutionResult object to an
+// ExecutionResultCapn object
+func ExecutionResultGoToCapn(seg *capn.Segment, src *ExecutionResult) capnp.ExecutionResultCapn {
+	dest := capnp.AutoNewExecutionResultCapn(seg)
+
+	dest.SetStatus(string(src.Status))
+	dest.SetError(src.Error)
+	dest.SetGasUsed(src.GasUsed)
+	dest.SetBlockNonce(src.BlockNonce)
+	dest.SetBlockHash(src.BlockHash)
+	dest.SetMiniBlockHash(src.MiniBlockHash)
+	dest.SetSndShardId(src.SndShardID)
+	dest.SetRcvShardId(src.RcvShardID)
+
+	return dest
+}
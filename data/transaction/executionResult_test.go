@@ -0,0 +1,32 @@
+package transaction_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionResult_SaveLoad(t *testing.T) {
+	er := transaction.ExecutionResult{
+		Status:        transaction.TxStatusFailed,
+		Error:         "sc execution error",
+		GasUsed:       uint64(1000),
+		BlockNonce:    uint64(7),
+		BlockHash:     []byte("block_hash"),
+		MiniBlockHash: []byte("mini_block_hash"),
+		SndShardID:    uint32(1),
+		RcvShardID:    uint32(2),
+	}
+
+	var b bytes.Buffer
+	err := er.Save(&b)
+	assert.Nil(t, err)
+
+	loadEr := transaction.ExecutionResult{}
+	err = loadEr.Load(&b)
+	assert.Nil(t, err)
+
+	assert.Equal(t, er, loadEr)
+}
@@ -8,17 +8,41 @@ import (
 	"github.com/numbatx/gn-numbat/data/transaction/capnp"
 )
 
-// Transaction holds all the data needed for a value transfer
+// Transaction holds all the data needed for a value transfer. A transaction can be restricted to a window of rounds
+// through the ValidFromRound and ValidUntilRound fields, a zero value meaning that the window is not bounded on that side.
+// A transaction holding Transfers is a batch transfer: the value of every transfer is moved from the sender to the
+// transfer receiver, all under the nonce and signature of the transaction. A batch transfer is addressed to its sender
+// and carries no value of its own. The ChainID and Version fields are part of the signed payload so a transaction
+// signed for one network can not be replayed on another one
 type Transaction struct {
-	Nonce     uint64   `capid:"0"`
-	Value     *big.Int `capid:"1"`
-	RcvAddr   []byte   `capid:"2"`
-	SndAddr   []byte   `capid:"3"`
-	GasPrice  uint64   `capid:"4"`
-	GasLimit  uint64   `capid:"5"`
-	Data      []byte   `capid:"6"`
-	Signature []byte   `capid:"7"`
-	Challenge []byte   `capid:"8"`
+	Nonce           uint64     `capid:"0"`
+	Value           *big.Int   `capid:"1"`
+	RcvAddr         []byte     `capid:"2"`
+	SndAddr         []byte     `capid:"3"`
+	GasPrice        uint64     `capid:"4"`
+	GasLimit        uint64     `capid:"5"`
+	Data            []byte     `capid:"6"`
+	Signature       []byte     `capid:"7"`
+	Challenge       []byte     `capid:"8"`
+	ValidFromRound  uint32     `capid:"9"`
+	ValidUntilRound uint32     `capid:"10"`
+	Transfers       []Transfer `capid:"11"`
+	ChainID         []byte     `capid:"12"`
+	Version         uint32     `capid:"13"`
+}
+
+// CurrentVersion is the version of the transactions created by this node
+const CurrentVersion = uint32(1)
+
+// Transfer holds a receiver and the value it gets through a batch transfer transaction
+type Transfer struct {
+	RcvAddr []byte   `capid:"0"`
+	Value   *big.Int `capid:"1"`
+}
+
+// IsBatchTransfer returns true if the transaction moves value to the receivers of its transfers list
+func (tx *Transaction) IsBatchTransfer() bool {
+	return len(tx.Transfers) > 0
 }
 
 // Save saves the serialized data of a Transaction into a stream through Capnp protocol
@@ -73,6 +97,26 @@ func TransactionCapnToGo(src capnp.TransactionCapn, dest *Transaction) *Transact
 	dest.Signature = src.Signature()
 	// Challenge
 	dest.Challenge = src.Challenge()
+	// ValidFromRound
+	dest.ValidFromRound = src.ValidFromRound()
+	// ValidUntilRound
+	dest.ValidUntilRound = src.ValidUntilRound()
+	// ChainID
+	dest.ChainID = src.ChainID()
+	// Version
+	dest.Version = src.Version()
+	// Transfers
+	transfersLen := src.Transfers().Len()
+	if transfersLen > 0 {
+		dest.Transfers = make([]Transfer, transfersLen)
+		for i := 0; i < transfersLen; i++ {
+			transfer := TransferCapnToGo(src.Transfers().At(i), nil)
+			if transfer == nil {
+				return nil
+			}
+			dest.Transfers[i] = *transfer
+		}
+	}
 
 	return dest
 }
@@ -91,6 +135,51 @@ func TransactionGoToCapn(seg *capn.Segment, src *Transaction) capnp.TransactionC
 	dest.SetData(src.Data)
 	dest.SetSignature(src.Signature)
 	dest.SetChallenge(src.Challenge)
+	dest.SetValidFromRound(src.ValidFromRound)
+	dest.SetValidUntilRound(src.ValidUntilRound)
+	dest.SetChainID(src.ChainID)
+	dest.SetVersion(src.Version)
+	if len(src.Transfers) > 0 {
+		transferList := capnp.NewTransferCapnList(seg, len(src.Transfers))
+		pList := capn.PointerList(transferList)
+
+		for i, elem := range src.Transfers {
+			_ = pList.Set(i, capn.Object(TransferGoToCapn(seg, &elem)))
+		}
+		dest.SetTransfers(transferList)
+	}
+
+	return dest
+}
+
+// TransferCapnToGo is a helper function to copy fields from a TransferCapn object to a Transfer object
+func TransferCapnToGo(src capnp.TransferCapn, dest *Transfer) *Transfer {
+	if dest == nil {
+		dest = &Transfer{}
+	}
+
+	if dest.Value == nil {
+		dest.Value = big.NewInt(0)
+	}
+
+	// RcvAddr
+	dest.RcvAddr = src.RcvAddr()
+	// Value
+	err := dest.Value.GobDecode(src.Value())
+	if err != nil {
+		return nil
+	}
+
+	return dest
+}
+
+// TransferGoToCapn is a helper function to copy fields from a Transfer object to a TransferCapn object
+func TransferGoToCapn(seg *capn.Segment, src *Transfer) capnp.TransferCapn {
+	dest := capnp.AutoNewTransferCapn(seg)
+
+	value, _ := src.Value.GobEncode()
+	dest.SetRcvAddr(src.RcvAddr)
+	dest.SetValue(value)
 
 	return dest
 }
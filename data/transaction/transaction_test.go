@@ -10,16 +10,47 @@ import (
 )
 
 func TestTransaction_SaveLoad(t *testing.T) {
+	tx := transaction.Transaction{
+		Nonce:           uint64(1),
+		Value:           big.NewInt(1),
+		RcvAddr:         []byte("receiver_address"),
+		SndAddr:         []byte("sender_address"),
+		GasPrice:        uint64(10000),
+		GasLimit:        uint64(1000),
+		Data:            []byte("tx_data"),
+		Signature:       []byte("signature"),
+		Challenge:       []byte("challange"),
+		ValidFromRound:  uint32(5),
+		ValidUntilRound: uint32(50),
+		ChainID:         []byte("chain_id"),
+		Version:         uint32(1),
+	}
+
+	var b bytes.Buffer
+	tx.Save(&b)
+
+	loadTx := transaction.Transaction{}
+	loadTx.Load(&b)
+
+	assert.Equal(t, loadTx, tx)
+}
+
+func TestTransaction_SaveLoadBatchTransfer(t *testing.T) {
 	tx := transaction.Transaction{
 		Nonce:     uint64(1),
-		Value:     big.NewInt(1),
-		RcvAddr:   []byte("receiver_address"),
+		Value:     big.NewInt(0),
+		RcvAddr:   []byte("sender_address"),
 		SndAddr:   []byte("sender_address"),
 		GasPrice:  uint64(10000),
 		GasLimit:  uint64(1000),
 		Data:      []byte("tx_data"),
 		Signature: []byte("signature"),
 		Challenge: []byte("challange"),
+		ChainID:   []byte("chain_id"),
+		Transfers: []transaction.Transfer{
+			{RcvAddr: []byte("receiver_address1"), Value: big.NewInt(10)},
+			{RcvAddr: []byte("receiver_address2"), Value: big.NewInt(20)},
+		},
 	}
 
 	var b bytes.Buffer
@@ -29,4 +60,5 @@ func TestTransaction_SaveLoad(t *testing.T) {
 	loadTx.Load(&b)
 
 	assert.Equal(t, loadTx, tx)
+	assert.True(t, loadTx.IsBatchTransfer())
 }
@@ -0,0 +1,23 @@
+package factory
+
+import (
+	"errors"
+)
+
+// ErrNilStorer signals that a nil storer has been provided
+var ErrNilStorer = errors.New("nil storer")
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilHasher signals that a nil hasher has been provided
+var ErrNilHasher = errors.New("nil hasher")
+
+// ErrUnknownTrieType signals that an unknown trie type has been provided
+var ErrUnknownTrieType = errors.New("unknown trie type")
+
+// ErrNilLeafRootHashHandler signals that a nil function extracting the data trie root hash of a leaf has been provided
+var ErrNilLeafRootHashHandler = errors.New("nil leaf root hash handler")
+
+// ErrPruningNotSupported signals that the state pruning is not supported by the trie type
+var ErrPruningNotSupported = errors.New("state pruning is not supported by this trie type")
@@ -0,0 +1,105 @@
+package factory
+
+import (
+	"github.com/numbatx/gn-numbat/data"
+	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/data/trie2"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/storage"
+)
+
+const (
+	// PatriciaMerkleTrie selects the go-ethereum derived trie, which encodes its nodes with RLP
+	PatriciaMerkleTrie = "trie"
+	// PatriciaMerkleTrie2 selects the trie which encodes its nodes with the configured marshalizer
+	PatriciaMerkleTrie2 = "trie2"
+)
+
+// NewStateTrie creates an empty state trie of the provided type on top of the storer. The marshalizer is only
+// used by the tries encoding their nodes with it
+func NewStateTrie(
+	trieType string,
+	storer storage.Storer,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) (data.Trie, error) {
+
+	if storer == nil {
+		return nil, ErrNilStorer
+	}
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if hasher == nil {
+		return nil, ErrNilHasher
+	}
+
+	switch trieType {
+	case PatriciaMerkleTrie:
+		dbw, err := trie.NewDBWriteCache(storer)
+		if err != nil {
+			return nil, err
+		}
+		tr, err := trie.NewStateTrie(dbw, hasher)
+		if err != nil {
+			return nil, err
+		}
+		return tr, nil
+	case PatriciaMerkleTrie2:
+		tr, err := trie2.NewStateTrie(storer, marshalizer, hasher)
+		if err != nil {
+			return nil, err
+		}
+		return tr, nil
+	}
+
+	return nil, ErrUnknownTrieType
+}
+
+// NewPruningStateTrie creates an empty state trie of the provided type, in pruning mode, on top of the storer and
+// the state pruner which garbage collects its nodes, keeping the last retainedRoots final states. The leafRootHash
+// function returns the root hash of the data trie referenced by an account leaf, or nil for any other leaf
+func NewPruningStateTrie(
+	trieType string,
+	storer storage.Storer,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+	leafRootHash func(key []byte, leaf []byte) []byte,
+	retainedRoots uint32,
+) (data.Trie, data.StatePruner, error) {
+
+	if storer == nil {
+		return nil, nil, ErrNilStorer
+	}
+	if marshalizer == nil {
+		return nil, nil, ErrNilMarshalizer
+	}
+	if hasher == nil {
+		return nil, nil, ErrNilHasher
+	}
+	if leafRootHash == nil {
+		return nil, nil, ErrNilLeafRootHashHandler
+	}
+
+	switch trieType {
+	case PatriciaMerkleTrie:
+		dbw, err := trie.NewDBWriteCache(storer)
+		if err != nil {
+			return nil, nil, err
+		}
+		tr, err := trie.NewPruningStateTrie(dbw, hasher, leafRootHash)
+		if err != nil {
+			return nil, nil, err
+		}
+		pruner, err := trie.NewStatePruner(dbw, retainedRoots)
+		if err != nil {
+			return nil, nil, err
+		}
+		return tr, pruner, nil
+	case PatriciaMerkleTrie2:
+		return nil, nil, ErrPruningNotSupported
+	}
+
+	return nil, nil, ErrUnknownTrieType
+}
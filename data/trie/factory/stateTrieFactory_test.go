@@ -0,0 +1,193 @@
+package factory_test
+
+import (
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/mock"
+	"github.com/numbatx/gn-numbat/data/trie/factory"
+	"github.com/stretchr/testify/assert"
+)
+
+var trieTypes = []string{factory.PatriciaMerkleTrie, factory.PatriciaMerkleTrie2}
+
+func TestNewStateTrie_NilStorerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tr, err := factory.NewStateTrie(factory.PatriciaMerkleTrie, nil, &mock.MarshalizerMock{}, mock.HasherMock{})
+
+	assert.Nil(t, tr)
+	assert.Equal(t, factory.ErrNilStorer, err)
+}
+
+func TestNewStateTrie_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tr, err := factory.NewStateTrie(factory.PatriciaMerkleTrie, mock.NewMemoryStorerMock(), nil, mock.HasherMock{})
+
+	assert.Nil(t, tr)
+	assert.Equal(t, factory.ErrNilMarshalizer, err)
+}
+
+func TestNewStateTrie_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tr, err := factory.NewStateTrie(factory.PatriciaMerkleTrie, mock.NewMemoryStorerMock(), &mock.MarshalizerMock{}, nil)
+
+	assert.Nil(t, tr)
+	assert.Equal(t, factory.ErrNilHasher, err)
+}
+
+func TestNewStateTrie_UnknownTrieTypeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tr, err := factory.NewStateTrie("unknown", mock.NewMemoryStorerMock(), &mock.MarshalizerMock{}, mock.HasherMock{})
+
+	assert.Nil(t, tr)
+	assert.Equal(t, factory.ErrUnknownTrieType, err)
+}
+
+func TestNewStateTrie_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		tr, err := factory.NewStateTrie(trieType, mock.NewMemoryStorerMock(), &mock.MarshalizerMock{}, mock.HasherMock{})
+
+		assert.NotNil(t, tr)
+		assert.Nil(t, err)
+	}
+}
+
+func TestStateTrie_GetMissingKeyShouldReturnNil(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		tr, _ := factory.NewStateTrie(trieType, mock.NewMemoryStorerMock(), &mock.MarshalizerMock{}, mock.HasherMock{})
+
+		value, err := tr.Get([]byte("missing"))
+		assert.Nil(t, value)
+		assert.Nil(t, err)
+
+		_ = tr.Update([]byte("dog"), []byte("puppy"))
+		value, err = tr.Get([]byte("missing"))
+		assert.Nil(t, value)
+		assert.Nil(t, err)
+	}
+}
+
+func TestStateTrie_UpdateWithEmptyValueShouldRemoveTheKey(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		tr, _ := factory.NewStateTrie(trieType, mock.NewMemoryStorerMock(), &mock.MarshalizerMock{}, mock.HasherMock{})
+		emptyRoot, _ := tr.Root()
+
+		_ = tr.Update([]byte("dog"), []byte("puppy"))
+		err := tr.Update([]byte("dog"), make([]byte, 0))
+		assert.Nil(t, err)
+
+		value, _ := tr.Get([]byte("dog"))
+		assert.Nil(t, value)
+		root, _ := tr.Root()
+		assert.Equal(t, emptyRoot, root)
+	}
+}
+
+func TestStateTrie_CommitAndRecreateShouldKeepTheValues(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		tr, _ := factory.NewStateTrie(trieType, mock.NewMemoryStorerMock(), &mock.MarshalizerMock{}, mock.HasherMock{})
+		_ = tr.Update([]byte("doe"), []byte("reindeer"))
+		_ = tr.Update([]byte("dog"), []byte("puppy"))
+		_ = tr.Update([]byte("dogglesworth"), []byte("cat"))
+
+		err := tr.Commit()
+		assert.Nil(t, err)
+		root, _ := tr.Root()
+
+		_ = tr.Update([]byte("dog"), []byte("doggy"))
+
+		recreatedTrie, err := tr.Recreate(root)
+		assert.Nil(t, err)
+
+		value, _ := recreatedTrie.Get([]byte("dog"))
+		assert.Equal(t, []byte("puppy"), value)
+		value, _ = recreatedTrie.Get([]byte("dogglesworth"))
+		assert.Equal(t, []byte("cat"), value)
+		recreatedRoot, _ := recreatedTrie.Root()
+		assert.Equal(t, root, recreatedRoot)
+	}
+}
+
+func TestStateTrie_RecreateFromEmptyRootShouldReturnEmptyTrie(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		tr, _ := factory.NewStateTrie(trieType, mock.NewMemoryStorerMock(), &mock.MarshalizerMock{}, mock.HasherMock{})
+		emptyRoot, _ := tr.Root()
+		_ = tr.Update([]byte("dog"), []byte("puppy"))
+
+		recreatedTrie, err := tr.Recreate(emptyRoot)
+		assert.Nil(t, err)
+
+		value, _ := recreatedTrie.Get([]byte("dog"))
+		assert.Nil(t, value)
+	}
+}
+
+func TestStateTrie_GetLeavesShouldReturnTheKeysInTrieOrder(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		tr, _ := factory.NewStateTrie(trieType, mock.NewMemoryStorerMock(), &mock.MarshalizerMock{}, mock.HasherMock{})
+		_ = tr.Update([]byte("dogglesworth"), []byte("cat"))
+		_ = tr.Update([]byte("dog"), []byte("puppy"))
+		_ = tr.Update([]byte("horse"), []byte("stallion"))
+		_ = tr.Update([]byte("doe"), []byte("reindeer"))
+		_ = tr.Commit()
+		root, _ := tr.Root()
+		recreatedTrie, _ := tr.Recreate(root)
+
+		keys, values, err := recreatedTrie.GetLeaves(nil, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte("doe"), []byte("dogglesworth"), []byte("dog"), []byte("horse")}, keys, trieType)
+		assert.Equal(t, [][]byte{[]byte("reindeer"), []byte("cat"), []byte("puppy"), []byte("stallion")}, values, trieType)
+
+		keys, values, err = recreatedTrie.GetLeaves([]byte("dog"), 2)
+		assert.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte("dog"), []byte("horse")}, keys, trieType)
+		assert.Equal(t, [][]byte{[]byte("puppy"), []byte("stallion")}, values, trieType)
+
+		keys, _, err = recreatedTrie.GetLeaves([]byte("doga"), 1)
+		assert.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte("dogglesworth")}, keys, trieType)
+
+		keys, _, err = recreatedTrie.GetLeaves([]byte("e"), 10)
+		assert.Nil(t, err)
+		assert.Equal(t, [][]byte{[]byte("horse")}, keys, trieType)
+	}
+}
+
+func TestStateTrie_GetLeavesOnEmptyTrieShouldReturnNoLeaves(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		tr, _ := factory.NewStateTrie(trieType, mock.NewMemoryStorerMock(), &mock.MarshalizerMock{}, mock.HasherMock{})
+
+		keys, values, err := tr.GetLeaves(nil, 10)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, len(keys), trieType)
+		assert.Equal(t, 0, len(values), trieType)
+	}
+}
+
+func TestStateTrie_CommitOnEmptyTrieShouldWork(t *testing.T) {
+	t.Parallel()
+
+	for _, trieType := range trieTypes {
+		tr, _ := factory.NewStateTrie(trieType, mock.NewMemoryStorerMock(), &mock.MarshalizerMock{}, mock.HasherMock{})
+
+		err := tr.Commit()
+		assert.Nil(t, err, trieType)
+	}
+}
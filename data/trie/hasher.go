@@ -66,7 +66,8 @@ func returnHasherToPool(h *hasher) {
 
 // hash collapses a node down into a hash node, also returning a copy of the
 // original node initialized with the computed hash to replace the original one.
-func (h *hasher) hash(n Node, db DBWriteCacher, force bool) (Node, Node, error) {
+// The path holds the hex nibbles leading from the root to the node.
+func (h *hasher) hash(n Node, db DBWriteCacher, force bool, path []byte) (Node, Node, error) {
 	// If we're not storing the node, just hashing, use available cached data
 	if hash, dirty := n.cache(); hash != nil {
 		if db == nil {
@@ -83,11 +84,11 @@ func (h *hasher) hash(n Node, db DBWriteCacher, force bool) (Node, Node, error)
 		}
 	}
 	// Trie not processed yet or needs storage, walk the children
-	collapsed, cached, err := h.hashChildren(n, db)
+	collapsed, cached, err := h.hashChildren(n, db, path)
 	if err != nil {
 		return hashNode{}, n, err
 	}
-	hashed, err := h.store(collapsed, db, force)
+	hashed, err := h.store(collapsed, db, force, path)
 	if err != nil {
 		return hashNode{}, n, err
 	}
@@ -113,7 +114,7 @@ func (h *hasher) hash(n Node, db DBWriteCacher, force bool) (Node, Node, error)
 // hashChildren replaces the children of a node with their hashes if the encoded
 // size of the child is larger than a hash, returning the collapsed node as well
 // as a replacement for the original node with the child hashes cached in.
-func (h *hasher) hashChildren(original Node, db DBWriteCacher) (Node, Node, error) {
+func (h *hasher) hashChildren(original Node, db DBWriteCacher, path []byte) (Node, Node, error) {
 	var err error
 
 	switch n := original.(type) {
@@ -124,7 +125,7 @@ func (h *hasher) hashChildren(original Node, db DBWriteCacher) (Node, Node, erro
 		cached.Key = encoding.CopyBytes(n.Key)
 
 		if _, ok := n.Val.(valueNode); !ok {
-			collapsed.Val, cached.Val, err = h.hash(n.Val, db, false)
+			collapsed.Val, cached.Val, err = h.hash(n.Val, db, false, appendNibbles(path, n.Key...))
 			if err != nil {
 				return original, original, err
 			}
@@ -137,7 +138,7 @@ func (h *hasher) hashChildren(original Node, db DBWriteCacher) (Node, Node, erro
 
 		for i := 0; i < 16; i++ {
 			if n.Children[i] != nil {
-				collapsed.Children[i], cached.Children[i], err = h.hash(n.Children[i], db, false)
+				collapsed.Children[i], cached.Children[i], err = h.hash(n.Children[i], db, false, appendNibbles(path, byte(i)))
 				if err != nil {
 					return original, original, err
 				}
@@ -155,7 +156,7 @@ func (h *hasher) hashChildren(original Node, db DBWriteCacher) (Node, Node, erro
 // store hashes the node n and if we have a storage layer specified, it writes
 // the key/value pair to it and tracks any node->child references as well as any
 // node->external trie references.
-func (h *hasher) store(n Node, db DBWriteCacher, force bool) (Node, error) {
+func (h *hasher) store(n Node, db DBWriteCacher, force bool, path []byte) (Node, error) {
 	// Don't store hashes or empty nodes.
 	if _, isHash := n.(hashNode); n == nil || isHash {
 		return n, nil
@@ -183,12 +184,12 @@ func (h *hasher) store(n Node, db DBWriteCacher, force bool) (Node, error) {
 			switch n := n.(type) {
 			case *shortNode:
 				if child, ok := n.Val.(valueNode); ok {
-					h.onleaf(child, hash)
+					h.onleaf(leafKey(appendNibbles(path, encoding.CompactToHex(n.Key)...)), child, hash)
 				}
 			case *fullNode:
 				for i := 0; i < 16; i++ {
 					if child, ok := n.Children[i].(valueNode); ok {
-						h.onleaf(child, hash)
+						h.onleaf(leafKey(appendNibbles(path, byte(i))), child, hash)
 					}
 				}
 			}
@@ -197,6 +198,27 @@ func (h *hasher) store(n Node, db DBWriteCacher, force bool) (Node, error) {
 	return hash, nil
 }
 
+// appendNibbles returns a new path made of the given path followed by the nibbles
+func appendNibbles(path []byte, nibbles ...byte) []byte {
+	newPath := make([]byte, 0, len(path)+len(nibbles))
+	newPath = append(newPath, path...)
+	return append(newPath, nibbles...)
+}
+
+// leafKey converts the hex nibbles path of a leaf into the key of the leaf
+func leafKey(path []byte) []byte {
+	if encoding.HasTerm(path) {
+		path = path[:len(path)-1]
+	}
+	if len(path)%2 != 0 {
+		return nil
+	}
+
+	key := make([]byte, len(path)/2)
+	encoding.DecodeNibbles(path, key)
+	return key
+}
+
 func (h *hasher) makeHashNode(data []byte) hashNode {
 	return hashNode(h.hsh.Compute(string(data)))
 }
@@ -23,9 +23,9 @@ import (
 )
 
 // LeafCallback is a callback type invoked when a trie operation reaches a leaf
-// node. It's used by state sync and commit to allow handling external references
-// between account and storage tries.
-type LeafCallback func(leaf []byte, parent []byte) error
+// node, with the key and value of the leaf. It's used by state sync and commit to
+// allow handling external references between account and storage tries.
+type LeafCallback func(key []byte, leaf []byte, parent []byte) error
 
 // PatriciaMerkelTree used in all tries implementations
 type PatriciaMerkelTree interface {
@@ -38,6 +38,8 @@ type PatriciaMerkelTree interface {
 	DBW() DBWriteCacher
 	Recreate(root []byte, dbw DBWriteCacher) (PatriciaMerkelTree, error)
 	Copy() PatriciaMerkelTree
+	Prove(key []byte) ([][]byte, error)
+	NodeIterator(start []byte) NodeIterator
 }
 
 // DBWriteCacher used in Patricia Merkel Tree sub layer
@@ -0,0 +1,46 @@
+package trie
+
+// ChildReference is the hash of a child node referenced by an encoded trie node, along with the path of hex nibbles
+// leading to the child from the trie root
+type ChildReference struct {
+	Hash []byte
+	Path []byte
+}
+
+// LeafReference is a leaf held by an encoded trie node
+type LeafReference struct {
+	Key   []byte
+	Value []byte
+}
+
+// NodeReferences decodes an encoded trie node, as persisted in the trie storage, and returns the hashes of the
+// child nodes it references together with the leaves it holds. Nodes embedded in their parent are walked as
+// well, so the returned hashes are all the storage entries the node depends on. The path holds the hex nibbles
+// leading to the node from the trie root and is used to compute the paths of the children and the keys of the leaves
+func NodeReferences(path []byte, encodedNode []byte) (children []ChildReference, leaves []LeafReference, err error) {
+	n, err := decodeNode(nil, encodedNode, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	children = make([]ChildReference, 0)
+	leaves = make([]LeafReference, 0)
+	collectReferences(n, path, &children, &leaves)
+
+	return children, leaves, nil
+}
+
+func collectReferences(n Node, path []byte, children *[]ChildReference, leaves *[]LeafReference) {
+	switch n := n.(type) {
+	case *shortNode:
+		collectReferences(n.Val, appendNibbles(path, n.Key...), children, leaves)
+	case *fullNode:
+		for i, child := range &n.Children {
+			collectReferences(child, appendNibbles(path, byte(i)), children, leaves)
+		}
+	case hashNode:
+		*children = append(*children, ChildReference{Hash: []byte(n), Path: path})
+	case valueNode:
+		*leaves = append(*leaves, LeafReference{Key: leafKey(path), Value: []byte(n)})
+	}
+}
@@ -0,0 +1,108 @@
+package trie
+
+import (
+	"errors"
+	"sync"
+)
+
+// committedRoot holds the root hash committed for a block which is not final yet
+type committedRoot struct {
+	nonce    uint64
+	rootHash []byte
+}
+
+// statePruner garbage collects, from the DBWriteCacher of a state trie in pruning mode, the nodes only referenced by
+// old states. The root hashes committed for the blocks which are not final yet, as well as the last retained final
+// root hashes, are kept referenced. When a final root hash leaves the retained history it is dereferenced, so the
+// nodes it was the only one to reference never reach the storer. Every retainedRoots dereferenced root hashes, the
+// leaving state is flushed to the storer as a checkpoint, which bounds the memory used by the DBWriteCacher
+type statePruner struct {
+	dbw             DBWriteCacher
+	retainedRoots   int
+	pendingRoots    []committedRoot
+	finalRoots      [][]byte
+	numExpiredRoots int
+	mutRoots        sync.Mutex
+}
+
+// NewStatePruner creates a new statePruner object which keeps the last retainedRoots final states
+func NewStatePruner(dbw DBWriteCacher, retainedRoots uint32) (*statePruner, error) {
+	if dbw == nil {
+		return nil, errors.New("nil database provided")
+	}
+	if retainedRoots == 0 {
+		return nil, errors.New("the number of retained roots should be greater than 0")
+	}
+
+	return &statePruner{
+		dbw:           dbw,
+		retainedRoots: int(retainedRoots),
+		pendingRoots:  make([]committedRoot, 0),
+		finalRoots:    make([][]byte, 0),
+	}, nil
+}
+
+// AddRoot keeps the state committed for the block with the provided nonce until the block gets final and its
+// state leaves the retained history
+func (sp *statePruner) AddRoot(nonce uint64, rootHash []byte) {
+	sp.mutRoots.Lock()
+	defer sp.mutRoots.Unlock()
+
+	sp.dbw.Reference(rootHash, nil)
+	sp.pendingRoots = append(sp.pendingRoots, committedRoot{
+		nonce:    nonce,
+		rootHash: rootHash,
+	})
+}
+
+// Prune moves the root hashes of the blocks with nonces lower or equal than finalNonce in the retained history and
+// dereferences the states of the reverted blocks and the states which left the retained history
+func (sp *statePruner) Prune(finalNonce uint64) error {
+	sp.mutRoots.Lock()
+	defer sp.mutRoots.Unlock()
+
+	stillPending := make([]committedRoot, 0, len(sp.pendingRoots))
+	for i, pending := range sp.pendingRoots {
+		if sp.wasReverted(i) {
+			// a block with the same or a lower nonce was committed afterwards, so this one was rolled back
+			sp.dbw.Dereference(pending.rootHash)
+			continue
+		}
+		if pending.nonce > finalNonce {
+			stillPending = append(stillPending, pending)
+			continue
+		}
+
+		sp.finalRoots = append(sp.finalRoots, pending.rootHash)
+	}
+	sp.pendingRoots = stillPending
+
+	for len(sp.finalRoots) > sp.retainedRoots {
+		expiredRoot := sp.finalRoots[0]
+
+		isCheckpoint := (sp.numExpiredRoots+1)%sp.retainedRoots == 0
+		if isCheckpoint {
+			// on failure the root is kept in the retained history so the flush is retried on the next call
+			err := sp.dbw.Commit(expiredRoot, false)
+			if err != nil {
+				return err
+			}
+		}
+
+		sp.dbw.Dereference(expiredRoot)
+		sp.finalRoots = sp.finalRoots[1:]
+		sp.numExpiredRoots++
+	}
+
+	return nil
+}
+
+func (sp *statePruner) wasReverted(index int) bool {
+	for _, next := range sp.pendingRoots[index+1:] {
+		if next.nonce <= sp.pendingRoots[index].nonce {
+			return true
+		}
+	}
+
+	return false
+}
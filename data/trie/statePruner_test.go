@@ -0,0 +1,238 @@
+package trie_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data"
+	"github.com/numbatx/gn-numbat/data/mock"
+	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/hashing/keccak"
+	"github.com/numbatx/gn-numbat/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func noDataTrieRootHash(key []byte, leaf []byte) []byte {
+	return nil
+}
+
+func createPruningTrie(t *testing.T, storer storage.Storer, retainedRoots uint32) (data.Trie, data.StatePruner) {
+	dbw, _ := trie.NewDBWriteCache(storer)
+	tr, err := trie.NewPruningStateTrie(dbw, keccak.Keccak{}, noDataTrieRootHash)
+	assert.Nil(t, err)
+
+	for i := 0; i < 10; i++ {
+		_ = tr.Update([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+	}
+
+	pruner, err := trie.NewStatePruner(dbw, retainedRoots)
+	assert.Nil(t, err)
+
+	return tr, pruner
+}
+
+func commitBlock(t *testing.T, tr data.Trie, pruner data.StatePruner, nonce uint64, value string) []byte {
+	err := tr.Update([]byte("changing key"), []byte(value))
+	assert.Nil(t, err)
+
+	err = tr.Commit()
+	assert.Nil(t, err)
+
+	root, _ := tr.Root()
+	pruner.AddRoot(nonce, root)
+
+	return root
+}
+
+func isRootAvailable(tr data.Trie, root []byte) bool {
+	recreatedTrie, err := tr.Recreate(root)
+	if err != nil {
+		return false
+	}
+
+	value, err := recreatedTrie.Get([]byte("key5"))
+	return err == nil && string(value) == "value5"
+}
+
+func TestNewStatePruner_NilDBShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, err := trie.NewStatePruner(nil, 1)
+
+	assert.Nil(t, sp)
+	assert.NotNil(t, err)
+}
+
+func TestNewStatePruner_ZeroRetainedRootsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dbw, _ := trie.NewDBWriteCache(mock.NewMemoryStorerMock())
+	sp, err := trie.NewStatePruner(dbw, 0)
+
+	assert.Nil(t, sp)
+	assert.NotNil(t, err)
+}
+
+func TestNewStatePruner_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	dbw, _ := trie.NewDBWriteCache(mock.NewMemoryStorerMock())
+	sp, err := trie.NewStatePruner(dbw, 1)
+
+	assert.NotNil(t, sp)
+	assert.Nil(t, err)
+}
+
+func TestNewPruningStateTrie_NilLeafRootHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dbw, _ := trie.NewDBWriteCache(mock.NewMemoryStorerMock())
+	tr, err := trie.NewPruningStateTrie(dbw, keccak.Keccak{}, nil)
+
+	assert.Nil(t, tr)
+	assert.NotNil(t, err)
+}
+
+func TestPruningStateTrie_CommitShouldNotWriteToStorer(t *testing.T) {
+	t.Parallel()
+
+	storer := mock.NewMemoryStorerMock()
+	tr, _ := createPruningTrie(t, storer, 1)
+
+	err := tr.Commit()
+	assert.Nil(t, err)
+
+	root, _ := tr.Root()
+	err = storer.Has(root)
+	assert.NotNil(t, err)
+	assert.True(t, isRootAvailable(tr, root))
+}
+
+func TestStatePruner_PruneShouldKeepTheRetainedRootsAndFlushCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	storer := mock.NewMemoryStorerMock()
+	tr, pruner := createPruningTrie(t, storer, 2)
+
+	roots := make([][]byte, 0)
+	for nonce := uint64(1); nonce <= 5; nonce++ {
+		roots = append(roots, commitBlock(t, tr, pruner, nonce, fmt.Sprintf("block %d", nonce)))
+	}
+
+	err := pruner.Prune(5)
+	assert.Nil(t, err)
+
+	// roots of blocks 1, 2 and 3 left the retained history, the one of block 2 being flushed as a checkpoint
+	assert.False(t, isRootAvailable(tr, roots[0]))
+	assert.True(t, isRootAvailable(tr, roots[1]))
+	assert.False(t, isRootAvailable(tr, roots[2]))
+	assert.True(t, isRootAvailable(tr, roots[3]))
+	assert.True(t, isRootAvailable(tr, roots[4]))
+
+	assert.Nil(t, storer.Has(roots[1]))
+	assert.NotNil(t, storer.Has(roots[3]))
+}
+
+func TestStatePruner_PruneShouldKeepTheRootsOfNotFinalBlocks(t *testing.T) {
+	t.Parallel()
+
+	tr, pruner := createPruningTrie(t, mock.NewMemoryStorerMock(), 1)
+
+	roots := make([][]byte, 0)
+	for nonce := uint64(1); nonce <= 4; nonce++ {
+		roots = append(roots, commitBlock(t, tr, pruner, nonce, fmt.Sprintf("block %d", nonce)))
+	}
+
+	err := pruner.Prune(1)
+	assert.Nil(t, err)
+
+	for _, root := range roots {
+		assert.True(t, isRootAvailable(tr, root))
+	}
+}
+
+func TestStatePruner_PruneShouldDereferenceTheRootsOfRevertedBlocks(t *testing.T) {
+	t.Parallel()
+
+	tr, pruner := createPruningTrie(t, mock.NewMemoryStorerMock(), 10)
+
+	rootBlock1 := commitBlock(t, tr, pruner, 1, "block 1")
+	rootRevertedBlock2 := commitBlock(t, tr, pruner, 2, "reverted block 2")
+
+	var err error
+	tr, err = tr.Recreate(rootBlock1)
+	assert.Nil(t, err)
+	rootBlock2 := commitBlock(t, tr, pruner, 2, "block 2")
+
+	err = pruner.Prune(0)
+	assert.Nil(t, err)
+
+	assert.True(t, isRootAvailable(tr, rootBlock1))
+	assert.False(t, isRootAvailable(tr, rootRevertedBlock2))
+	assert.True(t, isRootAvailable(tr, rootBlock2))
+}
+
+func TestStatePruner_PruneShouldKeepRootsCommittedTwice(t *testing.T) {
+	t.Parallel()
+
+	tr, pruner := createPruningTrie(t, mock.NewMemoryStorerMock(), 1)
+
+	rootBlock1 := commitBlock(t, tr, pruner, 1, "same state")
+	rootBlock2 := commitBlock(t, tr, pruner, 2, "same state")
+	assert.Equal(t, rootBlock1, rootBlock2)
+
+	err := pruner.Prune(2)
+	assert.Nil(t, err)
+
+	assert.True(t, isRootAvailable(tr, rootBlock2))
+}
+
+func TestPruningStateTrie_RecreateDataTrieShouldNotCallTheAccountLeafCallback(t *testing.T) {
+	t.Parallel()
+
+	numLeafCalls := 0
+	countLeafCalls := func(key []byte, leaf []byte) []byte {
+		numLeafCalls++
+		return nil
+	}
+	dbw, _ := trie.NewDBWriteCache(mock.NewMemoryStorerMock())
+	tr, _ := trie.NewPruningStateTrie(dbw, keccak.Keccak{}, countLeafCalls)
+
+	accountsTrie, err := tr.Recreate(nil)
+	assert.Nil(t, err)
+	_ = accountsTrie.Update([]byte("account"), []byte("account value"))
+	_ = accountsTrie.Commit()
+	assert.Equal(t, 1, numLeafCalls)
+
+	dataTrie, err := tr.RecreateDataTrie(nil)
+	assert.Nil(t, err)
+	_ = dataTrie.Update([]byte("storage key"), []byte("storage value"))
+	err = dataTrie.Commit()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numLeafCalls)
+}
+
+func TestPruningStateTrie_CommitShouldCallTheLeafCallbackWithTheLeafKey(t *testing.T) {
+	t.Parallel()
+
+	leaves := make(map[string]string)
+	collectLeaves := func(key []byte, leaf []byte) []byte {
+		leaves[string(key)] = string(leaf)
+		return nil
+	}
+	dbw, _ := trie.NewDBWriteCache(mock.NewMemoryStorerMock())
+	tr, _ := trie.NewPruningStateTrie(dbw, keccak.Keccak{}, collectLeaves)
+
+	expected := make(map[string]string)
+	for i := 0; i < 20; i++ {
+		key := keccak.Keccak{}.Compute(fmt.Sprintf("account%d", i))
+		value := fmt.Sprintf("an account value long enough not to be embedded in its parent %d", i)
+		expected[string(key)] = value
+		_ = tr.Update(key, []byte(value))
+	}
+	err := tr.Commit()
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, leaves)
+}
@@ -0,0 +1,166 @@
+package trie
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/numbatx/gn-numbat/data"
+	"github.com/numbatx/gn-numbat/data/trie/encoding"
+	"github.com/numbatx/gn-numbat/hashing"
+)
+
+// stateTrie adapts a PatriciaMerkelTree to the data.Trie abstraction the state is built on. Committing the trie
+// also flushes its nodes from the DBWriteCacher to the underlying storer, so the state can be recreated later
+// from the persisted AccountsTrie database. In pruning mode the committed nodes are only kept in the
+// DBWriteCacher, where a state pruner either garbage collects or flushes them
+type stateTrie struct {
+	tr      PatriciaMerkelTree
+	pruning bool
+	onleaf  LeafCallback
+}
+
+// NewStateTrie creates a new data.Trie on top of the provided storage, starting from an empty root
+func NewStateTrie(dbw DBWriteCacher, hsh hashing.Hasher) (*stateTrie, error) {
+	if dbw == nil {
+		return nil, errors.New("nil database provided")
+	}
+	if hsh == nil {
+		return nil, errors.New("nil hasher provided")
+	}
+
+	tr, err := NewTrie(make([]byte, 32), dbw, hsh)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stateTrie{tr: tr}, nil
+}
+
+// NewPruningStateTrie creates a new data.Trie, in pruning mode, on top of the provided storage. The leafRootHash
+// function returns the root hash of the data trie referenced by an account leaf, or nil if the leaf does not hold
+// an account, so that the data tries are kept alive as long as the accounts referencing them
+func NewPruningStateTrie(
+	dbw DBWriteCacher,
+	hsh hashing.Hasher,
+	leafRootHash func(key []byte, leaf []byte) []byte,
+) (*stateTrie, error) {
+
+	if leafRootHash == nil {
+		return nil, errors.New("nil leaf root hash function provided")
+	}
+
+	st, err := NewStateTrie(dbw, hsh)
+	if err != nil {
+		return nil, err
+	}
+
+	st.pruning = true
+	st.onleaf = func(key []byte, leaf []byte, parent []byte) error {
+		rootHash := leafRootHash(key, leaf)
+		if len(rootHash) > 0 {
+			dbw.Reference(rootHash, parent)
+		}
+
+		return nil
+	}
+
+	return st, nil
+}
+
+// Get returns the value stored for the key or nil if the key is missing
+func (st *stateTrie) Get(key []byte) ([]byte, error) {
+	return st.tr.Get(key)
+}
+
+// Update sets the value for the key. An empty value removes the key from the trie
+func (st *stateTrie) Update(key, value []byte) error {
+	return st.tr.Update(key, value)
+}
+
+// Delete removes the key from the trie
+func (st *stateTrie) Delete(key []byte) error {
+	return st.tr.Delete(key)
+}
+
+// Root returns the root hash of the trie
+func (st *stateTrie) Root() ([]byte, error) {
+	return st.tr.Root(), nil
+}
+
+// Commit writes the dirty nodes in the DBWriteCacher and, if not in pruning mode, flushes them to the storer
+func (st *stateTrie) Commit() error {
+	root, err := st.tr.Commit(st.onleaf)
+	if err != nil {
+		return err
+	}
+	rootHash := encoding.BytesToHash(root)
+	isEmptyTrie := rootHash == (encoding.Hash{}) || rootHash == emptyRoot
+	if isEmptyTrie || st.pruning {
+		return nil
+	}
+
+	return st.tr.DBW().Commit(root, false)
+}
+
+// Recreate returns a new trie, sharing the same storage, rooted at the provided hash
+func (st *stateTrie) Recreate(root []byte) (data.Trie, error) {
+	tr, err := st.tr.Recreate(root, st.tr.DBW())
+	if err != nil {
+		return nil, err
+	}
+
+	return &stateTrie{
+		tr:      tr,
+		pruning: st.pruning,
+		onleaf:  st.onleaf,
+	}, nil
+}
+
+// RecreateDataTrie returns a new trie, sharing the same storage, rooted at the provided hash, which holds the data
+// of an account. Its leaves are not accounts, so, unlike the accounts trie, it does not reference data tries on commit
+func (st *stateTrie) RecreateDataTrie(root []byte) (data.Trie, error) {
+	tr, err := st.tr.Recreate(root, st.tr.DBW())
+	if err != nil {
+		return nil, err
+	}
+
+	return &stateTrie{
+		tr:      tr,
+		pruning: st.pruning,
+	}, nil
+}
+
+// Prove returns the Merkle proof of the key, which can be checked with VerifyProof
+func (st *stateTrie) Prove(key []byte) ([][]byte, error) {
+	return st.tr.Prove(key)
+}
+
+// GetLeaves returns at most maxLeaves keys, starting from startKey in the trie order, along with their values
+func (st *stateTrie) GetLeaves(startKey []byte, maxLeaves int) ([][]byte, [][]byte, error) {
+	keys := make([][]byte, 0)
+	values := make([][]byte, 0)
+	if maxLeaves <= 0 {
+		return keys, values, nil
+	}
+
+	// the iterator seeks on the key without its terminator so the keys startKey is a prefix of, which come before
+	// it in the trie order, are skipped here
+	hexStartKey := make([]byte, 0)
+	if len(startKey) > 0 {
+		hexStartKey = encoding.KeybytesToHex(startKey)
+	}
+	it := NewIterator(st.tr.NodeIterator(startKey))
+	for len(keys) < maxLeaves && it.Next() {
+		if bytes.Compare(encoding.KeybytesToHex(it.Key), hexStartKey) < 0 {
+			continue
+		}
+
+		keys = append(keys, it.Key)
+		values = append(values, it.Value)
+	}
+	if it.Err != nil {
+		return nil, nil, it.Err
+	}
+
+	return keys, values, nil
+}
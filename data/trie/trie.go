@@ -60,7 +60,7 @@ type Trie struct {
 // If the trie does not contain a value for key, the returned proof contains all
 // nodes of the longest existing prefix of the key (at least the root node), ending
 // with the node that proves the absence of the key.
-func (t *Trie) Prove(key []byte, fromLevel uint) ([][]byte, error) {
+func (t *Trie) Prove(key []byte) ([][]byte, error) {
 	var proof [][]byte
 	// Collect all nodes on the path to key.
 	key = encoding.KeybytesToHex(key)
@@ -97,17 +97,13 @@ func (t *Trie) Prove(key []byte, fromLevel uint) ([][]byte, error) {
 	for i, n := range nodes {
 		// Don't bother checking for errors here since hasher panics
 		// if encoding doesn't work and we're not writing to any database.
-		n, _, _ = hasher.hashChildren(n, nil)
-		hn, _ := hasher.store(n, nil, false)
+		n, _, _ = hasher.hashChildren(n, nil, nil)
+		hn, _ := hasher.store(n, nil, false, nil)
 		if _, ok := hn.(hashNode); ok || i == 0 {
 			// If the node's database encoding is a hash (or is the
 			// root node), it becomes a proof element.
-			if fromLevel > 0 {
-				fromLevel--
-			} else {
-				enc, _ := rlp.EncodeToBytes(n)
-				proof = append(proof, enc)
-			}
+			enc, _ := rlp.EncodeToBytes(n)
+			proof = append(proof, enc)
 		}
 	}
 	return proof, nil
@@ -115,15 +111,23 @@ func (t *Trie) Prove(key []byte, fromLevel uint) ([][]byte, error) {
 
 // VerifyProof checks merkle proofs. The given proof must contain the value for
 // key in a trie with the given root hash. VerifyProof returns an error if the
-// proof contains invalid trie nodes or the wrong value.
-func VerifyProof(rootHash []byte, key []byte, proof [][]byte) (value []byte, nodes int, err error) {
+// proof contains invalid trie nodes, nodes not matching the hashes referenced
+// on the path from the root or the wrong value.
+func VerifyProof(rootHash []byte, key []byte, proof [][]byte, hsh hashing.Hasher) (value []byte, nodes int, err error) {
+	if hsh == nil {
+		return nil, 0, errors.New("nil hasher")
+	}
+
 	key = encoding.KeybytesToHex(key)
 	wantHash := rootHash
 	for i := 0; ; i++ {
-		buf := proof[i]
-		if buf == nil {
+		if i >= len(proof) || proof[i] == nil {
 			return nil, i, fmt.Errorf("proof node %d (hash %064x) missing", i, wantHash)
 		}
+		buf := proof[i]
+		if !bytes.Equal(hsh.Compute(string(buf)), wantHash) {
+			return nil, i, fmt.Errorf("proof node %d does not match hash %064x", i, wantHash)
+		}
 		n, err := decodeNode(wantHash[:], buf, 0)
 		if err != nil {
 			return nil, i, fmt.Errorf("bad proof node %d: %v", i, err)
@@ -135,7 +139,7 @@ func VerifyProof(rootHash []byte, key []byte, proof [][]byte) (value []byte, nod
 			return nil, i, nil
 		case hashNode:
 			key = keyrest
-			copy(wantHash[:], cld)
+			wantHash = cld
 		case valueNode:
 			return cld, i + 1, nil
 		}
@@ -548,7 +552,7 @@ func (t *Trie) hashRoot(db DBWriteCacher, onleaf LeafCallback) (Node, Node, erro
 	}
 	h := newHasher(t.cachegen, t.cachelimit, onleaf, t.hsh)
 	defer returnHasherToPool(h)
-	return h.hash(t.root, db, true)
+	return h.hash(t.root, db, true, nil)
 }
 
 // DBW returns underlying DB manager object
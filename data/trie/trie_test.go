@@ -675,10 +675,10 @@ func TestPatriciaMerkleTree_Prove(t *testing.T) {
 	tr := initTrie()
 	root := tr.Root()
 
-	proof, err := tr.Prove([]byte("dog"), 0)
+	proof, err := tr.Prove([]byte("dog"))
 	assert.Nil(t, err)
 	assert.NotNil(t, proof)
-	val, _, err := trie.VerifyProof(root, []byte("dog"), proof)
+	val, _, err := trie.VerifyProof(root, []byte("dog"), proof, testHasher)
 	assert.Nil(t, err)
 	assert.NotNil(t, val)
 }
@@ -688,26 +688,66 @@ func TestPatriciaMerkleTree_ProveCollapsedTrie(t *testing.T) {
 	root := tr.Root()
 	tr.Commit(nil)
 
-	proof, err := tr.Prove([]byte("dog"), 0)
+	proof, err := tr.Prove([]byte("dog"))
 	assert.Nil(t, err)
 	assert.NotNil(t, proof)
-	val, _, err := trie.VerifyProof(root, []byte("dog"), proof)
+	val, _, err := trie.VerifyProof(root, []byte("dog"), proof, testHasher)
 	assert.Nil(t, err)
 	assert.NotNil(t, val)
 }
 
+func TestPatriciaMerkleTree_VerifyProofTamperedNodeShouldErr(t *testing.T) {
+	tr, val := initTrieMultipleValues(50)
+	root := tr.Root()
+
+	proof, _ := tr.Prove(val[0])
+	last := len(proof) - 1
+	proof[last] = append([]byte{}, proof[last]...)
+	proof[last][len(proof[last])-1]++
+
+	value, _, err := trie.VerifyProof(root, val[0], proof, testHasher)
+	assert.NotNil(t, err)
+	assert.Nil(t, value)
+}
+
+func TestPatriciaMerkleTree_VerifyProofOfAnotherKeyShouldErr(t *testing.T) {
+	tr, val := initTrieMultipleValues(50)
+	root := tr.Root()
+
+	// the proof of a key does not hold the nodes found on the path of another key below their common prefix
+	proof, _ := tr.Prove(val[0])
+
+	value, _, err := trie.VerifyProof(root, val[1], proof, testHasher)
+	assert.NotNil(t, err)
+	assert.Nil(t, value)
+}
+
+func TestPatriciaMerkleTree_VerifyProofMissingNodesShouldErr(t *testing.T) {
+	tr, val := initTrieMultipleValues(50)
+	root := tr.Root()
+
+	proof, _ := tr.Prove(val[0])
+
+	value, _, err := trie.VerifyProof(root, val[0], proof[:len(proof)-1], testHasher)
+	assert.NotNil(t, err)
+	assert.Nil(t, value)
+}
+
 func TestPatriciaMerkleTree_VerifyProof(t *testing.T) {
 	tr, val := initTrieMultipleValues(50)
 	root := tr.Root()
 
 	for i := range val {
-		proof, _ := tr.Prove(val[i], 0)
+		proof, _ := tr.Prove(val[i])
 
-		val, _, err := trie.VerifyProof(root, val[i], proof)
+		val, _, err := trie.VerifyProof(root, val[i], proof, testHasher)
 		assert.Nil(t, err)
 		assert.NotNil(t, val)
 
-		val, _, err = trie.VerifyProof(root, []byte("dog"+strconv.Itoa(i)), proof)
+		absentKey := []byte("dog" + strconv.Itoa(i))
+		absenceProof, _ := tr.Prove(absentKey)
+
+		val, _, err = trie.VerifyProof(root, absentKey, absenceProof, testHasher)
 		assert.Nil(t, err)
 		assert.Nil(t, val)
 	}
@@ -719,13 +759,16 @@ func TestPatriciaMerkleTree_VerifyProofCollapsedTrie(t *testing.T) {
 	tr.Commit(nil)
 
 	for i := range val {
-		proof, _ := tr.Prove(val[i], 0)
+		proof, _ := tr.Prove(val[i])
 
-		val, _, err := trie.VerifyProof(root, val[i], proof)
+		val, _, err := trie.VerifyProof(root, val[i], proof, testHasher)
 		assert.Nil(t, err)
 		assert.NotNil(t, val)
 
-		val, _, err = trie.VerifyProof(root, []byte("dog"+strconv.Itoa(i)), proof)
+		absentKey := []byte("dog" + strconv.Itoa(i))
+		absenceProof, _ := tr.Prove(absentKey)
+
+		val, _, err = trie.VerifyProof(root, absentKey, absenceProof, testHasher)
 		assert.Nil(t, err)
 		assert.Nil(t, val)
 	}
@@ -864,7 +907,7 @@ func BenchmarkPatriciaMerkleTree_Prove(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		tr.Prove(values[i%nrValuesInTrie], 0)
+		tr.Prove(values[i%nrValuesInTrie])
 	}
 }
 
@@ -883,7 +926,7 @@ func BenchmarkPatriciaMerkleTree_ProveCollapsedTrie(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		tr.Prove(values[i%nrValuesInTrie], 0)
+		tr.Prove(values[i%nrValuesInTrie])
 	}
 }
 
@@ -903,13 +946,13 @@ func BenchmarkPatriciaMerkleTree_VerifyProof(b *testing.B) {
 		tr.Update(values[i], values[i])
 	}
 	for i := 0; i < nrProofs; i++ {
-		proofs[i], err = tr.Prove(values[i], 0)
+		proofs[i], err = tr.Prove(values[i])
 		assert.Nil(b, err)
 	}
 	root := tr.Root()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		trie.VerifyProof(root, values[i%nrProofs], proofs[i%nrProofs])
+		trie.VerifyProof(root, values[i%nrProofs], proofs[i%nrProofs], testHasher)
 	}
 }
 
@@ -929,7 +972,7 @@ func BenchmarkPatriciaMerkleTree_VerifyProofCollapsedTrie(b *testing.B) {
 		tr.Update(values[i], values[i])
 	}
 	for i := 0; i < nrProofs; i++ {
-		proofs[i], err = tr.Prove(values[i], 0)
+		proofs[i], err = tr.Prove(values[i])
 		assert.Nil(b, err)
 	}
 	tr.Commit(nil)
@@ -937,7 +980,7 @@ func BenchmarkPatriciaMerkleTree_VerifyProofCollapsedTrie(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		trie.VerifyProof(root, values[i%nrProofs], proofs[i%nrProofs])
+		trie.VerifyProof(root, values[i%nrProofs], proofs[i%nrProofs], testHasher)
 	}
 }
 
@@ -956,3 +999,40 @@ func BenchmarkPatriciaMerkleTree_Commit(b *testing.B) {
 		tr.Commit(nil)
 	}
 }
+
+func TestNodeReferences_WalkFromRootShouldReachAllLeaves(t *testing.T) {
+	tr := newEmpty()
+	values := make(map[string]string)
+	for i := 0; i < 100; i++ {
+		key := testHasher.Compute(strconv.Itoa(i))
+		value := fmt.Sprintf("a value long enough not to be embedded in its parent %d", i)
+		values[string(key)] = value
+		tr.Update(key, []byte(value))
+	}
+	root, _ := tr.Commit(nil)
+	tr.DBW().Commit(root, true)
+
+	found := make(map[string]string)
+	queue := []trie.ChildReference{{Hash: root}}
+	for len(queue) > 0 {
+		encodedNode, err := tr.DBW().Storer().Get(queue[0].Hash)
+		assert.Nil(t, err)
+
+		children, leaves, err := trie.NodeReferences(queue[0].Path, encodedNode)
+		assert.Nil(t, err)
+		queue = append(queue[1:], children...)
+		for _, leaf := range leaves {
+			found[string(leaf.Key)] = string(leaf.Value)
+		}
+	}
+
+	assert.Equal(t, values, found)
+}
+
+func TestNodeReferences_InvalidNodeShouldErr(t *testing.T) {
+	children, leaves, err := trie.NodeReferences(nil, []byte("invalid node"))
+
+	assert.NotNil(t, err)
+	assert.Nil(t, children)
+	assert.Nil(t, leaves)
+}
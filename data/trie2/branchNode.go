@@ -238,6 +238,7 @@ func (bn *branchNode) insert(n *leafNode, db DBWriteCacher, marshalizer marshal.
 		return true, bn, nil
 	}
 	bn.children[childPos] = newLeafNode(n.Key, n.Value)
+	bn.dirty = true
 	return true, bn, nil
 }
 
@@ -258,6 +259,9 @@ func (bn *branchNode) delete(key []byte, db DBWriteCacher, marshalizer marshal.M
 	if err != nil {
 		return false, nil, err
 	}
+	if bn.children[childPos] == nil {
+		return false, bn, nil
+	}
 
 	dirty, newNode, err := bn.children[childPos].delete(key, db, marshalizer)
 	if !dirty || err != nil {
@@ -273,7 +277,7 @@ func (bn *branchNode) delete(key []byte, db DBWriteCacher, marshalizer marshal.M
 	nrOfChildren, pos := getChildPosition(bn)
 
 	if nrOfChildren == 1 {
-		err = bn.resolveCollapsed(byte(pos), db, marshalizer)
+		err = resolveIfCollapsed(bn, byte(pos), db, marshalizer)
 		if err != nil {
 			return false, nil, err
 		}
@@ -30,6 +30,15 @@ func keyBytesToHex(str []byte) []byte {
 	return nibbles
 }
 
+// hexToKeyBytes transforms hex nibbles, without the terminator, back in key bytes
+func hexToKeyBytes(hex []byte) []byte {
+	key := make([]byte, len(hex)/2)
+	for i := range key {
+		key[i] = hex[i*2]*hexTerminator + hex[i*2+1]
+	}
+	return key
+}
+
 // prefixLen returns the length of the common prefix of a and b.
 func prefixLen(a, b []byte) int {
 	i := 0
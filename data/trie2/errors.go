@@ -39,3 +39,6 @@ var ErrEmptyNode = errors.New("the node is empty")
 
 // ErrNilNode is raised when we reach a nil node
 var ErrNilNode = errors.New("the node is nil")
+
+// ErrInvalidProof is raised when a Merkle proof does not match the root hash it is checked against
+var ErrInvalidProof = errors.New("invalid proof")
@@ -9,6 +9,8 @@ type Trie interface {
 	Prove(key []byte) ([][]byte, error)
 	VerifyProof(proofs [][]byte, key []byte) (bool, error)
 	Commit() error
+	Recreate(root []byte) (Trie, error)
+	GetLeaves(startKey []byte, maxLeaves int) ([][]byte, [][]byte, error)
 }
 
 // DBWriteCacher is used to cache changes made to the trie, and only write to the database when it's needed
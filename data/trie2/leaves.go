@@ -0,0 +1,123 @@
+package trie2
+
+import (
+	"bytes"
+
+	"github.com/numbatx/gn-numbat/marshal"
+)
+
+// leavesCollector walks a trie in the trie order, collecting the leaves starting from startKey until maxLeaves of
+// them are found. Keys are compared as hex nibbles ending in the terminator, which is greater than any nibble, so
+// a key comes after all the keys it is a prefix of. Collapsed nodes are resolved from the database on the way
+type leavesCollector struct {
+	startKey    []byte
+	maxLeaves   int
+	db          DBWriteCacher
+	marshalizer marshal.Marshalizer
+	keys        [][]byte
+	values      [][]byte
+}
+
+// GetLeaves returns at most maxLeaves keys, starting from startKey in the trie order, along with their values
+func (tr *patriciaMerkleTrie) GetLeaves(startKey []byte, maxLeaves int) ([][]byte, [][]byte, error) {
+	lc := &leavesCollector{
+		startKey:    make([]byte, 0),
+		maxLeaves:   maxLeaves,
+		db:          tr.db,
+		marshalizer: tr.marshalizer,
+		keys:        make([][]byte, 0),
+		values:      make([][]byte, 0),
+	}
+	if len(startKey) > 0 {
+		lc.startKey = keyBytesToHex(startKey)
+	}
+
+	if tr.root == nil || maxLeaves <= 0 {
+		return lc.keys, lc.values, nil
+	}
+
+	err := lc.collect(tr.root, make([]byte, 0))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return lc.keys, lc.values, nil
+}
+
+func (lc *leavesCollector) isFull() bool {
+	return len(lc.keys) >= lc.maxLeaves
+}
+
+// isBeforeStart returns true if all the keys starting with the provided nibbles come before the start key
+func (lc *leavesCollector) isBeforeStart(path []byte) bool {
+	length := len(path)
+	if len(lc.startKey) < length {
+		length = len(lc.startKey)
+	}
+
+	return bytes.Compare(path, lc.startKey[:length]) < 0
+}
+
+func (lc *leavesCollector) collect(n node, path []byte) error {
+	if lc.isFull() || lc.isBeforeStart(path) {
+		return nil
+	}
+
+	switch n := n.(type) {
+	case *leafNode:
+		return lc.collectLeaf(n, path)
+	case *extensionNode:
+		return lc.collectExtension(n, path)
+	case *branchNode:
+		return lc.collectBranch(n, path)
+	}
+
+	return ErrInvalidNode
+}
+
+func (lc *leavesCollector) collectLeaf(ln *leafNode, path []byte) error {
+	fullPath := concat(path, ln.Key...)
+	if bytes.Compare(fullPath, lc.startKey) < 0 {
+		return nil
+	}
+	if hasTerm(fullPath) {
+		fullPath = fullPath[:len(fullPath)-1]
+	}
+
+	lc.keys = append(lc.keys, hexToKeyBytes(fullPath))
+	lc.values = append(lc.values, ln.Value)
+
+	return nil
+}
+
+func (lc *leavesCollector) collectExtension(en *extensionNode, path []byte) error {
+	if en.child == nil {
+		err := en.resolveCollapsed(0, lc.db, lc.marshalizer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return lc.collect(en.child, concat(path, en.Key...))
+}
+
+func (lc *leavesCollector) collectBranch(bn *branchNode, path []byte) error {
+	for pos := byte(0); pos < nrOfChildren; pos++ {
+		if bn.children[pos] == nil {
+			err := bn.resolveCollapsed(pos, lc.db, lc.marshalizer)
+			if err != nil {
+				return err
+			}
+		}
+		if bn.children[pos] == nil {
+			continue
+		}
+
+		err := lc.collect(bn.children[pos], concat(path, pos))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -56,20 +56,15 @@ func (tr *patriciaMerkleTrie) Update(key, value []byte) error {
 			tr.root = newLeafNode(hexKey, value)
 			return nil
 		}
-		_, newRoot, err := tr.root.insert(node, tr.db, tr.marshalizer)
+		dirty, newRoot, err := tr.root.insert(node, tr.db, tr.marshalizer)
 		if err != nil {
 			return err
 		}
-		tr.root = newRoot
-	} else {
-		if tr.root == nil {
-			return nil
-		}
-		_, newRoot, err := tr.root.delete(hexKey, tr.db, tr.marshalizer)
-		if err != nil {
-			return err
+		if dirty {
+			tr.root = newRoot
 		}
-		tr.root = newRoot
+	} else {
+		return tr.Delete(key)
 	}
 	return nil
 }
@@ -80,11 +75,13 @@ func (tr *patriciaMerkleTrie) Delete(key []byte) error {
 	if tr.root == nil {
 		return nil
 	}
-	_, newRoot, err := tr.root.delete(hexKey, tr.db, tr.marshalizer)
+	dirty, newRoot, err := tr.root.delete(hexKey, tr.db, tr.marshalizer)
 	if err != nil {
 		return err
 	}
-	tr.root = newRoot
+	if dirty {
+		tr.root = newRoot
+	}
 	return nil
 }
 
@@ -104,7 +101,8 @@ func (tr *patriciaMerkleTrie) Root() ([]byte, error) {
 	return tr.root.getHash(), nil
 }
 
-// Prove returns the Merkle proof for the given key
+// Prove returns the Merkle proof for the given key. If the key is not in the trie, the proof holds the nodes
+// on the path to the node showing the absence of the key
 func (tr *patriciaMerkleTrie) Prove(key []byte) ([][]byte, error) {
 	if tr.root == nil {
 		return nil, ErrNilNode
@@ -127,6 +125,9 @@ func (tr *patriciaMerkleTrie) Prove(key []byte) ([][]byte, error) {
 		proof = append(proof, encNode)
 
 		node, hexKey, err = node.getNext(hexKey, tr.db, tr.marshalizer)
+		if err == ErrNodeNotFound {
+			return proof, nil
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -168,6 +169,30 @@ func (tr *patriciaMerkleTrie) VerifyProof(proofs [][]byte, key []byte) (bool, er
 	return false, nil
 }
 
+// Recreate returns a new trie, sharing the same database, having as root the node stored at the given hash.
+// An empty root hash gives an empty trie
+func (tr *patriciaMerkleTrie) Recreate(root []byte) (Trie, error) {
+	return tr.recreate(root)
+}
+
+func (tr *patriciaMerkleTrie) recreate(root []byte) (*patriciaMerkleTrie, error) {
+	newTr, err := NewTrie(tr.db, tr.marshalizer, tr.hasher)
+	if err != nil {
+		return nil, err
+	}
+	if len(root) == 0 {
+		return newTr, nil
+	}
+
+	newRoot, err := getNodeFromDBAndDecode(root, tr.db, tr.marshalizer)
+	if err != nil {
+		return nil, err
+	}
+	newTr.root = newRoot
+
+	return newTr, nil
+}
+
 // Commit adds all the dirty nodes to the database
 func (tr *patriciaMerkleTrie) Commit() error {
 	if tr.root == nil {
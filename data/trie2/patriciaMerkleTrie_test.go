@@ -286,6 +286,21 @@ func TestPatriciaMerkleTree_DeleteAfterCommit(t *testing.T) {
 	assert.Equal(t, root2, root1)
 }
 
+func TestPatriciaMerkleTree_DeleteAfterCommitShouldKeepTheUpdatedSibling(t *testing.T) {
+	tr := emptyTrie()
+	_ = tr.Update([]byte("aaaa"), []byte("v1"))
+	_ = tr.Update([]byte("bbbb"), []byte("v2"))
+	_ = tr.Commit()
+
+	_ = tr.Update([]byte("aaaa"), []byte("v3"))
+	err := tr.Delete([]byte("bbbb"))
+	assert.Nil(t, err)
+
+	value, err := tr.Get([]byte("aaaa"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("v3"), value)
+}
+
 func emptyTrie() trie2.Trie {
 	db, _ := memorydb.New()
 	tr, _ := trie2.NewTrie(db, marshal.JsonMarshalizer{}, keccak.Keccak{})
@@ -0,0 +1,70 @@
+package trie2
+
+import (
+	"bytes"
+
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+)
+
+// VerifyProofOnRoot checks the Merkle proof of a key against the provided root hash, without needing the trie.
+// Every node of the proof has to hash to the value referenced by its parent, starting from the root hash.
+// It returns the value of the key or nil if the proof shows that the key is not in the trie. The empty hash of the
+// hasher is the root hash of an empty trie, which holds no key
+func VerifyProofOnRoot(
+	rootHash []byte,
+	key []byte,
+	proof [][]byte,
+	marshalizer marshal.Marshalizer,
+	hasher hashing.Hasher,
+) ([]byte, error) {
+
+	if marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if hasher == nil {
+		return nil, ErrNilHasher
+	}
+
+	if bytes.Equal(rootHash, hasher.EmptyHash()) {
+		return nil, nil
+	}
+
+	hexKey := keyBytesToHex(key)
+	wantHash := rootHash
+	for i := range proof {
+		if !bytes.Equal(hasher.Compute(string(proof[i])), wantHash) {
+			return nil, ErrInvalidProof
+		}
+
+		n, err := decodeNode(proof[i], marshalizer)
+		if err != nil {
+			return nil, err
+		}
+
+		switch n := n.(type) {
+		case *leafNode:
+			if bytes.Equal(hexKey, n.Key) {
+				return n.Value, nil
+			}
+			return nil, nil
+		case *extensionNode:
+			if len(hexKey) < len(n.Key) || !bytes.Equal(n.Key, hexKey[:len(n.Key)]) {
+				return nil, nil
+			}
+			hexKey = hexKey[len(n.Key):]
+			wantHash = n.EncodedChild
+		case *branchNode:
+			if len(hexKey) == 0 || childPosOutOfRange(hexKey[firstByte]) {
+				return nil, ErrInvalidProof
+			}
+			wantHash = n.EncodedChildren[hexKey[firstByte]]
+			hexKey = hexKey[1:]
+			if wantHash == nil {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, ErrInvalidProof
+}
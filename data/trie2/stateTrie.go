@@ -0,0 +1,102 @@
+package trie2
+
+import (
+	"bytes"
+
+	"github.com/numbatx/gn-numbat/data"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+)
+
+// stateTrie adapts a patriciaMerkleTrie to the data.Trie abstraction the state is built on. Missing keys are
+// reported as nil values and the root hash of an empty trie is the empty hash of the hasher
+type stateTrie struct {
+	tr *patriciaMerkleTrie
+}
+
+// NewStateTrie creates a new data.Trie on top of the provided database, starting from an empty root
+func NewStateTrie(db DBWriteCacher, msh marshal.Marshalizer, hsh hashing.Hasher) (*stateTrie, error) {
+	tr, err := NewTrie(db, msh, hsh)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stateTrie{tr: tr}, nil
+}
+
+// Get returns the value stored for the key or nil if the key is missing
+func (st *stateTrie) Get(key []byte) ([]byte, error) {
+	if st.tr.root == nil {
+		return nil, nil
+	}
+
+	value, err := st.tr.Get(key)
+	if err == ErrNodeNotFound {
+		return nil, nil
+	}
+
+	return value, err
+}
+
+// Update sets the value for the key. An empty value removes the key from the trie
+func (st *stateTrie) Update(key, value []byte) error {
+	return st.tr.Update(key, value)
+}
+
+// Delete removes the key from the trie
+func (st *stateTrie) Delete(key []byte) error {
+	return st.tr.Delete(key)
+}
+
+// Root returns the root hash of the trie
+func (st *stateTrie) Root() ([]byte, error) {
+	if st.tr.root == nil {
+		return st.tr.hasher.EmptyHash(), nil
+	}
+
+	return st.tr.Root()
+}
+
+// Commit writes the dirty nodes to the database
+func (st *stateTrie) Commit() error {
+	if st.tr.root == nil {
+		return nil
+	}
+
+	return st.tr.Commit()
+}
+
+// Recreate returns a new trie, sharing the same database, rooted at the provided hash
+func (st *stateTrie) Recreate(root []byte) (data.Trie, error) {
+	if bytes.Equal(root, st.tr.hasher.EmptyHash()) {
+		root = nil
+	}
+
+	tr, err := st.tr.recreate(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stateTrie{tr: tr}, nil
+}
+
+// RecreateDataTrie returns a new trie, sharing the same database, rooted at the provided hash, which holds the data
+// of an account
+func (st *stateTrie) RecreateDataTrie(root []byte) (data.Trie, error) {
+	return st.Recreate(root)
+}
+
+// Prove returns the Merkle proof of the key, which can be checked with VerifyProofOnRoot. The proof of any key
+// in an empty trie is empty
+func (st *stateTrie) Prove(key []byte) ([][]byte, error) {
+	if st.tr.root == nil {
+		return make([][]byte, 0), nil
+	}
+
+	return st.tr.Prove(key)
+}
+
+// GetLeaves returns, in key order, at most maxLeaves keys not lower than startKey along with their values
+func (st *stateTrie) GetLeaves(startKey []byte, maxLeaves int) ([][]byte, [][]byte, error) {
+	return st.tr.GetLeaves(startKey, maxLeaves)
+}
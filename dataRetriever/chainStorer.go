@@ -82,6 +82,21 @@ func (bc *ChainStorer) Put(unitType UnitType, key []byte, value []byte) error {
 	return storer.Put(key, value)
 }
 
+// PutBatch stores all the key, value pairs in the selected storage unit, in a single atomic write if the
+// unit's persister supports it. It can return an error if the provided unit type is not supported
+// or if the storage unit underlying implementation reports an error
+func (bc *ChainStorer) PutBatch(unitType UnitType, data map[string][]byte) error {
+	bc.lock.RLock()
+	storer := bc.chain[unitType]
+	bc.lock.RUnlock()
+
+	if storer == nil {
+		return ErrNoSuchStorageUnit
+	}
+
+	return storer.PutBatch(data)
+}
+
 // GetAll gets all the elements with keys in the keys array, from the selected storage unit
 // It can report an error if the provided unit type is not supported, if there is a missing
 // key in the unit, or if the underlying implementation of the storage unit reports an error.
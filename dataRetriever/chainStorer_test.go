@@ -77,6 +77,29 @@ func TestPut_ReturnsCorrectly(t *testing.T) {
 	assert.Equal(t, putErr, err)
 }
 
+func TestPutBatch_ErrorWhenStorerIsMissing(t *testing.T) {
+	s := &mock.StorerStub{}
+
+	b := dataRetriever.NewChainStorer()
+	b.AddStorer(1, s)
+	err := b.PutBatch(2, map[string][]byte{"whatever": []byte("whatever value")})
+
+	assert.Equal(t, dataRetriever.ErrNoSuchStorageUnit, err)
+}
+
+func TestPutBatch_ReturnsCorrectly(t *testing.T) {
+	s := &mock.StorerStub{}
+	putErr := errors.New("error")
+	s.PutBatchCalled = func(data map[string][]byte) error {
+		return putErr
+	}
+
+	b := dataRetriever.NewChainStorer()
+	b.AddStorer(1, s)
+	err := b.PutBatch(1, map[string][]byte{"whatever": []byte("whatever value")})
+	assert.Equal(t, putErr, err)
+}
+
 func TestGetAll_ErrorWhenStorerIsMissing(t *testing.T) {
 	s := &mock.StorerStub{}
 
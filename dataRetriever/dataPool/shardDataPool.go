@@ -13,6 +13,7 @@ type shardedDataPool struct {
 	metaHdrNonces     dataRetriever.Uint64Cacher
 	miniBlocks        storage.Cacher
 	peerChangesBlocks storage.Cacher
+	trieNodes         storage.Cacher
 }
 
 // NewShardedDataPool creates a data pools holder object
@@ -24,6 +25,7 @@ func NewShardedDataPool(
 	peerChangesBlocks storage.Cacher,
 	metaBlocks storage.Cacher,
 	metaHdrNonces dataRetriever.Uint64Cacher,
+	trieNodes storage.Cacher,
 ) (*shardedDataPool, error) {
 
 	if transactions == nil {
@@ -47,6 +49,9 @@ func NewShardedDataPool(
 	if metaBlocks == nil {
 		return nil, dataRetriever.ErrNilMetaBlockPool
 	}
+	if trieNodes == nil {
+		return nil, dataRetriever.ErrNilTrieNodesPool
+	}
 
 	return &shardedDataPool{
 		transactions:      transactions,
@@ -56,6 +61,7 @@ func NewShardedDataPool(
 		miniBlocks:        miniBlocks,
 		peerChangesBlocks: peerChangesBlocks,
 		metaBlocks:        metaBlocks,
+		trieNodes:         trieNodes,
 	}, nil
 }
 
@@ -93,3 +99,8 @@ func (tdp *shardedDataPool) MetaBlocks() storage.Cacher {
 func (tdp *shardedDataPool) MetaHeadersNonces() dataRetriever.Uint64Cacher {
 	return tdp.metaHdrNonces
 }
+
+// TrieNodes returns the holder for the encoded trie nodes received while syncing the state
+func (tdp *shardedDataPool) TrieNodes() storage.Cacher {
+	return tdp.trieNodes
+}
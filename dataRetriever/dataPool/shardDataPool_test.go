@@ -21,6 +21,7 @@ func TestNewShardedDataPool_NilTransactionsShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, data.ErrNilTxDataPool, err)
@@ -36,6 +37,7 @@ func TestNewShardedDataPool_NilHeadersShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, data.ErrNilHeadersDataPool, err)
@@ -51,6 +53,7 @@ func TestNewShardedDataPool_NilHeaderNoncesShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, data.ErrNilHeadersNoncesDataPool, err)
@@ -66,6 +69,7 @@ func TestNewShardedDataPool_NilTxBlocksShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, data.ErrNilTxBlockDataPool, err)
@@ -81,6 +85,7 @@ func TestNewShardedDataPool_NilPeerBlocksShouldErr(t *testing.T) {
 		nil,
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, data.ErrNilPeerChangeBlockDataPool, err)
@@ -96,6 +101,7 @@ func TestNewShardedDataPool_NilMetaBlocksShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		nil,
 		&mock.Uint64CacherStub{},
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, data.ErrNilMetaBlockPool, err)
@@ -111,12 +117,29 @@ func TestNewShardedDataPool_NilMetaHeaderNoncesShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.CacherStub{},
 		nil,
+		&mock.CacherStub{},
 	)
 
 	assert.Equal(t, dataRetriever.ErrNilMetaBlockNoncesPool, err)
 	assert.Nil(t, tdp)
 }
 
+func TestNewShardedDataPool_NilTrieNodesShouldErr(t *testing.T) {
+	tdp, err := dataPool.NewShardedDataPool(
+		&mock.ShardedDataStub{},
+		&mock.CacherStub{},
+		&mock.Uint64CacherStub{},
+		&mock.CacherStub{},
+		&mock.CacherStub{},
+		&mock.CacherStub{},
+		&mock.Uint64CacherStub{},
+		nil,
+	)
+
+	assert.Equal(t, dataRetriever.ErrNilTrieNodesPool, err)
+	assert.Nil(t, tdp)
+}
+
 func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 	transactions := &mock.ShardedDataStub{}
 	headers := &mock.CacherStub{}
@@ -125,6 +148,7 @@ func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 	peersBlock := &mock.CacherStub{}
 	metaChainBlocks := &mock.CacherStub{}
 	metaHeaderNonces := &mock.Uint64CacherStub{}
+	trieNodes := &mock.CacherStub{}
 	tdp, err := dataPool.NewShardedDataPool(
 		transactions,
 		headers,
@@ -133,6 +157,7 @@ func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 		peersBlock,
 		metaChainBlocks,
 		metaHeaderNonces,
+		trieNodes,
 	)
 
 	assert.Nil(t, err)
@@ -143,4 +168,5 @@ func TestNewShardedDataPool_OkValsShouldWork(t *testing.T) {
 	assert.True(t, txBlocks == tdp.MiniBlocks())
 	assert.True(t, peersBlock == tdp.PeerChangesBlocks())
 	assert.True(t, metaChainBlocks == tdp.MetaBlocks())
+	assert.True(t, trieNodes == tdp.TrieNodes())
 }
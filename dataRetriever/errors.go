@@ -79,9 +79,15 @@ var ErrNilDataToProcess = errors.New("nil data to process")
 // ErrNilTxStorage signals that a nil transaction storage has been provided
 var ErrNilTxStorage = errors.New("nil transaction storage")
 
+// ErrNilTrieNodesStorage signals that a nil trie nodes storage has been provided
+var ErrNilTrieNodesStorage = errors.New("nil trie nodes storage")
+
 // ErrNilHeadersStorage signals that a nil header storage has been provided
 var ErrNilHeadersStorage = errors.New("nil headers storage")
 
+// ErrNilHeadersNoncesStorage signals that a nil header nonce to hash storage has been provided
+var ErrNilHeadersNoncesStorage = errors.New("nil headers nonces storage")
+
 // ErrNilResolverSender signals that a nil resolver sender object has been provided
 var ErrNilResolverSender = errors.New("nil resolver sender")
 
@@ -152,6 +158,9 @@ var ErrNilMiniBlockHashesPool = errors.New("nil meta block mini block hashes dat
 // ErrNilShardHeaderPool signals that a nil meta block data pool was provided
 var ErrNilShardHeaderPool = errors.New("nil meta block shard header data pool")
 
+// ErrNilTrieNodesPool signals that a nil trie nodes data pool was provided
+var ErrNilTrieNodesPool = errors.New("nil trie nodes data pool")
+
 // ErrNilMetaBlockNoncesPool signals that a nil meta block data pool was provided
 var ErrNilMetaBlockNoncesPool = errors.New("nil meta block nonces data pool")
 
@@ -166,3 +175,12 @@ var ErrRequestTypeNotImplemented = errors.New("request type is not implemented")
 
 // ErrNilDataPacker signals that a nil data packer has been provided
 var ErrNilDataPacker = errors.New("nil data packer provided")
+
+// ErrInvalidTxPoolCapacity signals that an invalid transactions pool capacity has been provided
+var ErrInvalidTxPoolCapacity = errors.New("invalid transactions pool capacity")
+
+// ErrInvalidMaxTxsPerSender signals that an invalid maximum number of transactions per sender has been provided
+var ErrInvalidMaxTxsPerSender = errors.New("invalid maximum number of transactions per sender")
+
+// ErrNilRequestedTxsCache signals that a nil cache of the requested transactions has been provided
+var ErrNilRequestedTxsCache = errors.New("nil requested transactions cache")
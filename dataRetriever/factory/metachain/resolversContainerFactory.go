@@ -165,6 +165,7 @@ func (rcf *resolversContainerFactory) generateMetaChainHeaderResolvers() ([]stri
 
 func (rcf *resolversContainerFactory) createMetaChainHeaderResolver(identifier string) (dataRetriever.Resolver, error) {
 	hdrStorer := rcf.store.GetStorer(dataRetriever.MetaBlockUnit)
+	hdrNoncesStorer := rcf.store.GetStorer(dataRetriever.MetaHdrNonceHashDataUnit)
 
 	resolverSender, err := topicResolverSender.NewTopicResolverSender(
 		rcf.messenger,
@@ -180,6 +181,7 @@ func (rcf *resolversContainerFactory) createMetaChainHeaderResolver(identifier s
 		rcf.dataPools.MetaChainBlocks(),
 		rcf.dataPools.MetaBlockNonces(),
 		hdrStorer,
+		hdrNoncesStorer,
 		rcf.marshalizer,
 		rcf.uint64ByteSliceConverter,
 	)
@@ -126,6 +126,15 @@ func (rcf *resolversContainerFactory) Create() (dataRetriever.ResolversContainer
 		return nil, err
 	}
 
+	keys, resolverSlice, err = rcf.generateTrieNodesResolver()
+	if err != nil {
+		return nil, err
+	}
+	err = container.AddMultiple(keys, resolverSlice)
+	if err != nil {
+		return nil, err
+	}
+
 	return container, nil
 }
 
@@ -207,6 +216,8 @@ func (rcf *resolversContainerFactory) generateHdrResolver() ([]string, []dataRet
 	//only one intrashard header topic
 	identifierHdr := factory.HeadersTopic + shardC.CommunicationIdentifier(shardC.SelfId())
 	hdrStorer := rcf.store.GetStorer(dataRetriever.BlockHeaderUnit)
+	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(shardC.SelfId())
+	hdrNoncesStorer := rcf.store.GetStorer(hdrNonceHashDataUnit)
 	resolverSender, err := topicResolverSender.NewTopicResolverSender(
 		rcf.messenger,
 		identifierHdr,
@@ -221,6 +232,7 @@ func (rcf *resolversContainerFactory) generateHdrResolver() ([]string, []dataRet
 		rcf.dataPools.Headers(),
 		rcf.dataPools.HeadersNonces(),
 		hdrStorer,
+		hdrNoncesStorer,
 		rcf.marshalizer,
 		rcf.uint64ByteSliceConverter,
 	)
@@ -353,6 +365,8 @@ func (rcf *resolversContainerFactory) generateMetachainShardHeaderResolver() ([]
 	//example: shardHeadersForMetachain_0
 	identifierHdr := factory.ShardHeadersForMetachainTopic + shardC.CommunicationIdentifier(sharding.MetachainShardId)
 	hdrStorer := rcf.store.GetStorer(dataRetriever.BlockHeaderUnit)
+	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(shardC.SelfId())
+	hdrNoncesStorer := rcf.store.GetStorer(hdrNonceHashDataUnit)
 	resolverSender, err := topicResolverSender.NewTopicResolverSender(
 		rcf.messenger,
 		identifierHdr,
@@ -368,6 +382,7 @@ func (rcf *resolversContainerFactory) generateMetachainShardHeaderResolver() ([]
 		rcf.dataPools.Headers(),
 		rcf.dataPools.HeadersNonces(),
 		hdrStorer,
+		hdrNoncesStorer,
 		rcf.marshalizer,
 		rcf.uint64ByteSliceConverter,
 	)
@@ -394,6 +409,7 @@ func (rcf *resolversContainerFactory) generateMetablockHeaderResolver() ([]strin
 	//this is: metachainBlocks
 	identifierHdr := factory.MetachainBlocksTopic
 	hdrStorer := rcf.store.GetStorer(dataRetriever.MetaBlockUnit)
+	hdrNoncesStorer := rcf.store.GetStorer(dataRetriever.MetaHdrNonceHashDataUnit)
 
 	resolverSender, err := topicResolverSender.NewTopicResolverSender(
 		rcf.messenger,
@@ -410,6 +426,7 @@ func (rcf *resolversContainerFactory) generateMetablockHeaderResolver() ([]strin
 		rcf.dataPools.MetaBlocks(),
 		rcf.dataPools.MetaHeadersNonces(),
 		hdrStorer,
+		hdrNoncesStorer,
 		rcf.marshalizer,
 		rcf.uint64ByteSliceConverter,
 	)
@@ -428,3 +445,44 @@ func (rcf *resolversContainerFactory) generateMetablockHeaderResolver() ([]strin
 
 	return []string{identifierHdr}, []dataRetriever.Resolver{resolver}, nil
 }
+
+//------- TrieNodes resolver
+
+func (rcf *resolversContainerFactory) generateTrieNodesResolver() ([]string, []dataRetriever.Resolver, error) {
+	shardC := rcf.shardCoordinator
+
+	//only one intrashard trie nodes topic
+	identifierTrieNodes := factory.TrieNodesTopic + shardC.CommunicationIdentifier(shardC.SelfId())
+	trieStorer := rcf.store.GetStorer(dataRetriever.AccountsTrieUnit)
+
+	resolverSender, err := topicResolverSender.NewTopicResolverSender(
+		rcf.messenger,
+		identifierTrieNodes,
+		rcf.marshalizer,
+		rcf.intRandomizer,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolver, err := resolvers.NewTrieNodeResolver(
+		resolverSender,
+		trieStorer,
+		rcf.marshalizer,
+		rcf.dataPacker,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//add on the request topic
+	_, err = rcf.createTopicAndAssignHandler(
+		identifierTrieNodes+resolverSender.TopicRequestSuffix(),
+		resolver,
+		false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []string{identifierTrieNodes}, []dataRetriever.Resolver{resolver}, nil
+}
@@ -419,8 +419,9 @@ func TestResolversContainerFactory_With4ShardsShouldWork(t *testing.T) {
 	numResolverPeerChanges := 1
 	numResolverMetachainShardHeaders := 1
 	numResolverMetaBlockHeaders := 1
+	numResolverTrieNodes := 1
 	totalResolvers := numResolverTxs + numResolverHeaders + numResolverMiniBlocks + numResolverPeerChanges +
-		numResolverMetachainShardHeaders + numResolverMetaBlockHeaders
+		numResolverMetachainShardHeaders + numResolverMetaBlockHeaders + numResolverTrieNodes
 
 	assert.Equal(t, totalResolvers, container.Len())
 }
@@ -29,6 +29,12 @@ type MiniBlocksResolver interface {
 	GetMiniBlocks(hashes [][]byte) block.MiniBlockSlice
 }
 
+// TrieNodesResolver defines what a trie nodes resolver should do
+type TrieNodesResolver interface {
+	Resolver
+	RequestDataFromHashArray(hashes [][]byte) error
+}
+
 // TopicResolverSender defines what sending operations are allowed for a topic resolver
 type TopicResolverSender interface {
 	SendOnRequestTopic(rd *RequestData) error
@@ -125,8 +131,28 @@ const (
 	MetaShardDataUnit UnitType = 5
 	// MetaPeerDataUnit is the metachain peer data unit identifier
 	MetaPeerDataUnit UnitType = 6
+	// TransactionResultUnit is the transactions execution results storage unit identifier
+	TransactionResultUnit UnitType = 7
+	// MetaHdrNonceHashDataUnit is the committed metachain block header nonce to header hash storage unit identifier
+	MetaHdrNonceHashDataUnit UnitType = 8
+	// AccountsTrieUnit is the accounts trie nodes storage unit identifier
+	AccountsTrieUnit UnitType = 9
+	// StateDiffUnit is the committed shard block header hash to block state diff storage unit identifier
+	StateDiffUnit UnitType = 10
+	// BootstrapUnit is the storage unit identifier of the data a restarted node resumes its chain from
+	BootstrapUnit UnitType = 11
 )
 
+// shardHdrNonceHashDataUnitBase is the first of the unit type identifiers reserved to the committed shard block header
+// nonce to header hash storage units, one per shard. The other unit types have to remain below it
+const shardHdrNonceHashDataUnitBase UnitType = 128
+
+// ShardHdrNonceHashDataUnitFor returns the committed shard block header nonce to header hash storage unit identifier
+// of the given shard
+func ShardHdrNonceHashDataUnitFor(shardId uint32) UnitType {
+	return shardHdrNonceHashDataUnitBase + UnitType(shardId)
+}
+
 // UnitType is the type for Storage unit identifiers
 type UnitType uint8
 
@@ -152,6 +178,13 @@ type ShardedDataCacherNotifier interface {
 	CreateShardStore(cacheId string)
 }
 
+// SenderTxQueuer defines a transactions cacher which keeps, for every sender, its pending transactions ordered by nonce
+type SenderTxQueuer interface {
+	storage.Cacher
+	// SortedTxHashesBySender returns, for every sender, the hashes of its pending transactions ordered by nonce
+	SortedTxHashesBySender() map[string][][]byte
+}
+
 // Uint64Cacher defines a cacher-type struct that uses uint64 keys and []byte values (usually hashes)
 type Uint64Cacher interface {
 	Clear()
@@ -176,6 +209,7 @@ type PoolsHolder interface {
 	PeerChangesBlocks() storage.Cacher
 	MetaBlocks() storage.Cacher
 	MetaHeadersNonces() Uint64Cacher
+	TrieNodes() storage.Cacher
 }
 
 // MetaPoolsHolder defines getter for data pools for metachain
@@ -198,6 +232,8 @@ type StorageService interface {
 	Get(unitType UnitType, key []byte) ([]byte, error)
 	// Put stores the key, value pair in the selected storage unit
 	Put(unitType UnitType, key []byte, value []byte) error
+	// PutBatch stores all the key, value pairs in the selected storage unit, atomically if the unit supports it
+	PutBatch(unitType UnitType, data map[string][]byte) error
 	// GetAll gets all the elements with keys in the keys array, from the selected storage unit
 	// If there is a missing key in the unit, it returns an error
 	GetAll(unitType UnitType, keys [][]byte) (map[string][]byte, error)
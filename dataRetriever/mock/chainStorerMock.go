@@ -13,6 +13,7 @@ type ChainStorerMock struct {
 	HasCalled       func(unitType dataRetriever.UnitType, key []byte) error
 	GetCalled       func(unitType dataRetriever.UnitType, key []byte) ([]byte, error)
 	PutCalled       func(unitType dataRetriever.UnitType, key []byte, value []byte) error
+	PutBatchCalled  func(unitType dataRetriever.UnitType, data map[string][]byte) error
 	GetAllCalled    func(unitType dataRetriever.UnitType, keys [][]byte) (map[string][]byte, error)
 	DestroyCalled   func() error
 }
@@ -62,6 +63,22 @@ func (bc *ChainStorerMock) Put(unitType dataRetriever.UnitType, key []byte, valu
 	return nil
 }
 
+// PutBatch stores all the key, value pairs in the selected storage unit. When no behaviour is set, the pairs are
+// stored one by one through Put
+func (bc *ChainStorerMock) PutBatch(unitType dataRetriever.UnitType, data map[string][]byte) error {
+	if bc.PutBatchCalled != nil {
+		return bc.PutBatchCalled(unitType, data)
+	}
+
+	for key, value := range data {
+		err := bc.Put(unitType, []byte(key), value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetAll gets all the elements with keys in the keys array, from the selected storage unit
 // It can report an error if the provided unit type is not supported, if there is a missing
 // key in the unit, or if the underlying implementation of the storage unit reports an error.
@@ -15,6 +15,7 @@ type PoolsHolderFake struct {
 	hdrNonces         dataRetriever.Uint64Cacher
 	miniBlocks        storage.Cacher
 	peerChangesBlocks storage.Cacher
+	trieNodes         storage.Cacher
 }
 
 func NewPoolsHolderFake() *PoolsHolderFake {
@@ -29,6 +30,7 @@ func NewPoolsHolderFake() *PoolsHolderFake {
 	)
 	phf.miniBlocks, _ = storage.NewCache(storage.LRUCache, 10000, 1)
 	phf.peerChangesBlocks, _ = storage.NewCache(storage.LRUCache, 10000, 1)
+	phf.trieNodes, _ = storage.NewCache(storage.LRUCache, 10000, 1)
 	return phf
 }
 
@@ -55,3 +57,7 @@ func (phf *PoolsHolderFake) PeerChangesBlocks() storage.Cacher {
 func (phf *PoolsHolderFake) MetaBlocks() storage.Cacher {
 	return phf.metaBlocks
 }
+
+func (phf *PoolsHolderFake) TrieNodes() storage.Cacher {
+	return phf.trieNodes
+}
@@ -16,9 +16,10 @@ var log = logger.DefaultLogger()
 // HeaderResolver is a wrapper over Resolver that is specialized in resolving headers requests
 type HeaderResolver struct {
 	*HeaderResolverBase
-	hdrNonces      dataRetriever.Uint64Cacher
-	headers        storage.Cacher
-	nonceConverter typeConverters.Uint64ByteSliceConverter
+	hdrNonces        dataRetriever.Uint64Cacher
+	hdrNoncesStorage storage.Storer
+	headers          storage.Cacher
+	nonceConverter   typeConverters.Uint64ByteSliceConverter
 }
 
 // NewHeaderResolver creates a new header resolver
@@ -27,6 +28,7 @@ func NewHeaderResolver(
 	headers storage.Cacher,
 	headersNonces dataRetriever.Uint64Cacher,
 	hdrStorage storage.Storer,
+	hdrNoncesStorage storage.Storer,
 	marshalizer marshal.Marshalizer,
 	nonceConverter typeConverters.Uint64ByteSliceConverter,
 ) (*HeaderResolver, error) {
@@ -40,6 +42,9 @@ func NewHeaderResolver(
 	if headersNonces == nil {
 		return nil, dataRetriever.ErrNilHeadersNoncesDataPool
 	}
+	if hdrNoncesStorage == nil {
+		return nil, dataRetriever.ErrNilHeadersNoncesStorage
+	}
 	if nonceConverter == nil {
 		return nil, dataRetriever.ErrNilNonceConverter
 	}
@@ -55,6 +60,7 @@ func NewHeaderResolver(
 
 	hdrResolver := &HeaderResolver{
 		hdrNonces:          headersNonces,
+		hdrNoncesStorage:   hdrNoncesStorage,
 		headers:            headers,
 		nonceConverter:     nonceConverter,
 		HeaderResolverBase: hdrResolverBase,
@@ -100,8 +106,11 @@ func (hdrRes *HeaderResolver) resolveHeaderFromNonce(key []byte) ([]byte, error)
 		return nil, dataRetriever.ErrInvalidNonceByteSlice
 	}
 
-	//Step 2. search the nonce-key pair
+	//Step 2. search the nonce-key pair, in storage if it is no longer cached
 	hash, _ := hdrRes.hdrNonces.Get(nonce)
+	if hash == nil {
+		hash, _ = hdrRes.hdrNoncesStorage.Get(key)
+	}
 	if hash == nil {
 		return nil, nil
 	}
@@ -22,6 +22,7 @@ func TestNewHeaderResolver_NilSenderResolverShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		&mock.MarshalizerMock{},
 		mock.NewNonceHashConverterMock(),
 	)
@@ -38,6 +39,7 @@ func TestNewHeaderResolver_NilHeadersPoolShouldErr(t *testing.T) {
 		nil,
 		&mock.Uint64CacherStub{},
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		&mock.MarshalizerMock{},
 		mock.NewNonceHashConverterMock(),
 	)
@@ -54,6 +56,7 @@ func TestNewHeaderResolver_NilHeadersNoncesPoolShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		nil,
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		&mock.MarshalizerMock{},
 		mock.NewNonceHashConverterMock(),
 	)
@@ -70,6 +73,7 @@ func TestNewHeaderResolver_NilHeadersStorageShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
 		nil,
+		&mock.StorerStub{},
 		&mock.MarshalizerMock{},
 		mock.NewNonceHashConverterMock(),
 	)
@@ -78,6 +82,23 @@ func TestNewHeaderResolver_NilHeadersStorageShouldErr(t *testing.T) {
 	assert.Nil(t, hdrRes)
 }
 
+func TestNewHeaderResolver_NilHeadersNoncesStorageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hdrRes, err := resolvers.NewHeaderResolver(
+		&mock.TopicResolverSenderStub{},
+		&mock.CacherStub{},
+		&mock.Uint64CacherStub{},
+		&mock.StorerStub{},
+		nil,
+		&mock.MarshalizerMock{},
+		mock.NewNonceHashConverterMock(),
+	)
+
+	assert.Equal(t, dataRetriever.ErrNilHeadersNoncesStorage, err)
+	assert.Nil(t, hdrRes)
+}
+
 func TestNewHeaderResolver_NilNonceConverterShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -86,6 +107,7 @@ func TestNewHeaderResolver_NilNonceConverterShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		&mock.MarshalizerMock{},
 		nil,
 	)
@@ -102,6 +124,7 @@ func TestNewHeaderResolver_OkValsShouldWork(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		&mock.MarshalizerMock{},
 		mock.NewNonceHashConverterMock(),
 	)
@@ -120,6 +143,7 @@ func TestHeaderResolver_ProcessReceivedMessageNilValueShouldErr(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		&mock.MarshalizerMock{},
 		mock.NewNonceHashConverterMock(),
 	)
@@ -136,6 +160,7 @@ func TestHeaderResolver_ProcessReceivedMessageRequestUnknownTypeShouldErr(t *tes
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		&mock.MarshalizerMock{},
 		mock.NewNonceHashConverterMock(),
 	)
@@ -175,6 +200,7 @@ func TestHeaderResolver_ValidateRequestHashTypeFoundInHdrPoolShouldSearchAndSend
 		headers,
 		&mock.Uint64CacherStub{},
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		marshalizer,
 		mock.NewNonceHashConverterMock(),
 	)
@@ -221,6 +247,7 @@ func TestHeaderResolver_ProcessReceivedMessageRequestHashTypeFoundInHdrPoolMarsh
 		headers,
 		&mock.Uint64CacherStub{},
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		marshalizerStub,
 		mock.NewNonceHashConverterMock(),
 	)
@@ -265,6 +292,7 @@ func TestHeaderResolver_ProcessReceivedMessageRequestRetFromStorageShouldRetValA
 		headers,
 		&mock.Uint64CacherStub{},
 		store,
+		&mock.StorerStub{},
 		marshalizer,
 		mock.NewNonceHashConverterMock(),
 	)
@@ -283,6 +311,7 @@ func TestHeaderResolver_ProcessReceivedMessageRequestNonceTypeInvalidSliceShould
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		&mock.MarshalizerMock{},
 		mock.NewNonceHashConverterMock(),
 	)
@@ -291,7 +320,7 @@ func TestHeaderResolver_ProcessReceivedMessageRequestNonceTypeInvalidSliceShould
 	assert.Equal(t, dataRetriever.ErrInvalidNonceByteSlice, err)
 }
 
-func TestHeaderResolver_ProcessReceivedMessageRequestNonceTypeNotFoundInHdrNoncePoolShouldRetNilAndNotSend(t *testing.T) {
+func TestHeaderResolver_ProcessReceivedMessageRequestNonceTypeNotFoundInHdrNoncePoolAndStorageShouldRetNilAndNotSend(t *testing.T) {
 	t.Parallel()
 
 	requestedNonce := uint64(67)
@@ -315,6 +344,11 @@ func TestHeaderResolver_ProcessReceivedMessageRequestNonceTypeNotFoundInHdrNonce
 		&mock.CacherStub{},
 		headersNonces,
 		&mock.StorerStub{},
+		&mock.StorerStub{
+			GetCalled: func(key []byte) (i []byte, e error) {
+				return nil, errors.New("key not found")
+			},
+		},
 		&mock.MarshalizerMock{},
 		nonceConverter,
 	)
@@ -326,6 +360,67 @@ func TestHeaderResolver_ProcessReceivedMessageRequestNonceTypeNotFoundInHdrNonce
 	assert.False(t, wasSent)
 }
 
+func TestHeaderResolver_ProcessReceivedMessageRequestNonceTypeNotFoundInHdrNoncePoolShouldRetFromNoncesStorageAndSend(t *testing.T) {
+	t.Parallel()
+
+	requestedNonce := uint64(67)
+	nonceConverter := mock.NewNonceHashConverterMock()
+
+	headersNonces := &mock.Uint64CacherStub{}
+	headersNonces.GetCalled = func(u uint64) (i []byte, b bool) {
+		return nil, false
+	}
+
+	headers := &mock.CacherStub{}
+	headers.PeekCalled = func(key []byte) (value interface{}, ok bool) {
+		return nil, false
+	}
+
+	hdrNoncesStorage := &mock.StorerStub{}
+	hdrNoncesStorage.GetCalled = func(key []byte) (i []byte, e error) {
+		if bytes.Equal(key, nonceConverter.ToByteSlice(requestedNonce)) {
+			return []byte("aaaa"), nil
+		}
+
+		return nil, errors.New("key not found")
+	}
+
+	wasResolved := false
+	hdrStorage := &mock.StorerStub{}
+	hdrStorage.GetCalled = func(key []byte) (i []byte, e error) {
+		if bytes.Equal(key, []byte("aaaa")) {
+			wasResolved = true
+			return make([]byte, 0), nil
+		}
+
+		return nil, errors.New("key not found")
+	}
+
+	wasSent := false
+	hdrRes, _ := resolvers.NewHeaderResolver(
+		&mock.TopicResolverSenderStub{
+			SendCalled: func(buff []byte, peer p2p.PeerID) error {
+				wasSent = true
+				return nil
+			},
+		},
+		headers,
+		headersNonces,
+		hdrStorage,
+		hdrNoncesStorage,
+		&mock.MarshalizerMock{},
+		nonceConverter,
+	)
+
+	err := hdrRes.ProcessReceivedMessage(createRequestMsg(
+		dataRetriever.NonceType,
+		nonceConverter.ToByteSlice(requestedNonce)))
+
+	assert.Nil(t, err)
+	assert.True(t, wasResolved)
+	assert.True(t, wasSent)
+}
+
 func TestHeaderResolver_ProcessReceivedMessageRequestNonceTypeFoundInHdrNoncePoolShouldRetFromPoolAndSend(t *testing.T) {
 	t.Parallel()
 
@@ -366,6 +461,7 @@ func TestHeaderResolver_ProcessReceivedMessageRequestNonceTypeFoundInHdrNoncePoo
 		headers,
 		headersNonces,
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		marshalizer,
 		nonceConverter,
 	)
@@ -424,6 +520,7 @@ func TestHeaderResolver_ProcessReceivedMessageRequestNonceTypeFoundInHdrNoncePoo
 		headers,
 		headersNonces,
 		store,
+		&mock.StorerStub{},
 		marshalizer,
 		nonceConverter,
 	)
@@ -479,6 +576,7 @@ func TestHeaderResolver_ProcessReceivedMessageRequestNonceTypeFoundInHdrNoncePoo
 		headers,
 		headersNonces,
 		store,
+		&mock.StorerStub{},
 		marshalizer,
 		nonceConverter,
 	)
@@ -514,6 +612,7 @@ func TestHeaderResolver_RequestDataFromNonceShouldWork(t *testing.T) {
 		&mock.CacherStub{},
 		&mock.Uint64CacherStub{},
 		&mock.StorerStub{},
+		&mock.StorerStub{},
 		&mock.MarshalizerMock{},
 		nonceConverter,
 	)
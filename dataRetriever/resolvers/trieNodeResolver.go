@@ -0,0 +1,140 @@
+package resolvers
+
+import (
+	"github.com/numbatx/gn-numbat/dataRetriever"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/p2p"
+	"github.com/numbatx/gn-numbat/storage"
+)
+
+// maxBuffToSendTrieNodes represents max buffer size to send in bytes
+var maxBuffToSendTrieNodes = 2 << 17 //128KB
+
+// TrieNodeResolver is a wrapper over Resolver that is specialized in resolving trie nodes requests. The encoded
+// nodes are served from the accounts trie storage, where they are kept under their hash
+type TrieNodeResolver struct {
+	dataRetriever.TopicResolverSender
+	trieStorage storage.Storer
+	marshalizer marshal.Marshalizer
+	dataPacker  dataRetriever.DataPacker
+}
+
+// NewTrieNodeResolver creates a new trie node resolver
+func NewTrieNodeResolver(
+	senderResolver dataRetriever.TopicResolverSender,
+	trieStorage storage.Storer,
+	marshalizer marshal.Marshalizer,
+	dataPacker dataRetriever.DataPacker,
+) (*TrieNodeResolver, error) {
+
+	if senderResolver == nil {
+		return nil, dataRetriever.ErrNilResolverSender
+	}
+	if trieStorage == nil {
+		return nil, dataRetriever.ErrNilTrieNodesStorage
+	}
+	if marshalizer == nil {
+		return nil, dataRetriever.ErrNilMarshalizer
+	}
+	if dataPacker == nil {
+		return nil, dataRetriever.ErrNilDataPacker
+	}
+
+	return &TrieNodeResolver{
+		TopicResolverSender: senderResolver,
+		trieStorage:         trieStorage,
+		marshalizer:         marshalizer,
+		dataPacker:          dataPacker,
+	}, nil
+}
+
+// ProcessReceivedMessage will be the callback func from the p2p.Messenger and will be called each time a new message was received
+// (for the topic this validator was registered to, usually a request topic)
+func (tnRes *TrieNodeResolver) ProcessReceivedMessage(message p2p.MessageP2P) error {
+	rd := &dataRetriever.RequestData{}
+	err := rd.Unmarshal(tnRes.marshalizer, message)
+	if err != nil {
+		return err
+	}
+
+	if rd.Value == nil {
+		return dataRetriever.ErrNilValue
+	}
+
+	switch rd.Type {
+	case dataRetriever.HashType:
+		buff, err := tnRes.resolveTrieNodeRequestByHash(rd.Value)
+		if err != nil {
+			return err
+		}
+		return tnRes.Send(buff, message.Peer())
+	case dataRetriever.HashArrayType:
+		return tnRes.resolveTrieNodeRequestByHashArray(rd.Value, message.Peer())
+	default:
+		return dataRetriever.ErrRequestTypeNotImplemented
+	}
+}
+
+func (tnRes *TrieNodeResolver) resolveTrieNodeRequestByHash(hash []byte) ([]byte, error) {
+	node, err := tnRes.trieStorage.Get(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return tnRes.marshalizer.Marshal([][]byte{node})
+}
+
+func (tnRes *TrieNodeResolver) resolveTrieNodeRequestByHashArray(hashesBuff []byte, pid p2p.PeerID) error {
+	hashes := make([][]byte, 0)
+	err := tnRes.marshalizer.Unmarshal(&hashes, hashesBuff)
+	if err != nil {
+		return err
+	}
+
+	nodes := make([][]byte, 0)
+	for _, hash := range hashes {
+		node, err := tnRes.trieStorage.Get(hash)
+		if err != nil {
+			//the node might be missing (it was pruned, for example) but should continue
+			// as to send back as many as it can
+			log.Debug(err.Error())
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+
+	buffsToSend, err := tnRes.dataPacker.PackDataInChunks(nodes, maxBuffToSendTrieNodes)
+	if err != nil {
+		return err
+	}
+
+	for _, buff := range buffsToSend {
+		err = tnRes.Send(buff, pid)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RequestDataFromHash requests a trie node from other peers having input the node hash
+func (tnRes *TrieNodeResolver) RequestDataFromHash(hash []byte) error {
+	return tnRes.SendOnRequestTopic(&dataRetriever.RequestData{
+		Type:  dataRetriever.HashType,
+		Value: hash,
+	})
+}
+
+// RequestDataFromHashArray requests a list of trie nodes from other peers having input their hashes
+func (tnRes *TrieNodeResolver) RequestDataFromHashArray(hashes [][]byte) error {
+	buffHashes, err := tnRes.marshalizer.Marshal(hashes)
+	if err != nil {
+		return err
+	}
+
+	return tnRes.SendOnRequestTopic(&dataRetriever.RequestData{
+		Type:  dataRetriever.HashArrayType,
+		Value: buffHashes,
+	})
+}
@@ -0,0 +1,239 @@
+package resolvers_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/dataRetriever"
+	"github.com/numbatx/gn-numbat/dataRetriever/mock"
+	"github.com/numbatx/gn-numbat/dataRetriever/resolvers"
+	"github.com/numbatx/gn-numbat/p2p"
+	"github.com/stretchr/testify/assert"
+)
+
+//------- NewTrieNodeResolver
+
+func TestNewTrieNodeResolver_NilResolverShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tnRes, err := resolvers.NewTrieNodeResolver(
+		nil,
+		&mock.StorerStub{},
+		&mock.MarshalizerMock{},
+		&mock.DataPackerStub{},
+	)
+
+	assert.Equal(t, dataRetriever.ErrNilResolverSender, err)
+	assert.Nil(t, tnRes)
+}
+
+func TestNewTrieNodeResolver_NilTrieStorageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tnRes, err := resolvers.NewTrieNodeResolver(
+		&mock.TopicResolverSenderStub{},
+		nil,
+		&mock.MarshalizerMock{},
+		&mock.DataPackerStub{},
+	)
+
+	assert.Equal(t, dataRetriever.ErrNilTrieNodesStorage, err)
+	assert.Nil(t, tnRes)
+}
+
+func TestNewTrieNodeResolver_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tnRes, err := resolvers.NewTrieNodeResolver(
+		&mock.TopicResolverSenderStub{},
+		&mock.StorerStub{},
+		nil,
+		&mock.DataPackerStub{},
+	)
+
+	assert.Equal(t, dataRetriever.ErrNilMarshalizer, err)
+	assert.Nil(t, tnRes)
+}
+
+func TestNewTrieNodeResolver_NilDataPackerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tnRes, err := resolvers.NewTrieNodeResolver(
+		&mock.TopicResolverSenderStub{},
+		&mock.StorerStub{},
+		&mock.MarshalizerMock{},
+		nil,
+	)
+
+	assert.Equal(t, dataRetriever.ErrNilDataPacker, err)
+	assert.Nil(t, tnRes)
+}
+
+func TestNewTrieNodeResolver_OkValsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tnRes, err := resolvers.NewTrieNodeResolver(
+		&mock.TopicResolverSenderStub{},
+		&mock.StorerStub{},
+		&mock.MarshalizerMock{},
+		&mock.DataPackerStub{},
+	)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tnRes)
+}
+
+//------- ProcessReceivedMessage
+
+func TestTrieNodeResolver_ProcessReceivedMessageWrongTypeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tnRes, _ := resolvers.NewTrieNodeResolver(
+		&mock.TopicResolverSenderStub{},
+		&mock.StorerStub{},
+		&mock.MarshalizerMock{},
+		&mock.DataPackerStub{},
+	)
+
+	err := tnRes.ProcessReceivedMessage(createRequestMsg(dataRetriever.NonceType, []byte("aaa")))
+
+	assert.Equal(t, dataRetriever.ErrRequestTypeNotImplemented, err)
+}
+
+func TestTrieNodeResolver_ProcessReceivedMessageNilValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tnRes, _ := resolvers.NewTrieNodeResolver(
+		&mock.TopicResolverSenderStub{},
+		&mock.StorerStub{},
+		&mock.MarshalizerMock{},
+		&mock.DataPackerStub{},
+	)
+
+	err := tnRes.ProcessReceivedMessage(createRequestMsg(dataRetriever.HashType, nil))
+
+	assert.Equal(t, dataRetriever.ErrNilValue, err)
+}
+
+func TestTrieNodeResolver_ProcessReceivedMessageFoundInStorageShouldSend(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	node := []byte("encoded node")
+	var sentBuff []byte
+	tnRes, _ := resolvers.NewTrieNodeResolver(
+		&mock.TopicResolverSenderStub{
+			SendCalled: func(buff []byte, peer p2p.PeerID) error {
+				sentBuff = buff
+				return nil
+			},
+		},
+		&mock.StorerStub{
+			GetCalled: func(key []byte) ([]byte, error) {
+				if bytes.Equal([]byte("aaa"), key) {
+					return node, nil
+				}
+
+				return nil, errors.New("not found")
+			},
+		},
+		marshalizer,
+		&mock.DataPackerStub{},
+	)
+
+	err := tnRes.ProcessReceivedMessage(createRequestMsg(dataRetriever.HashType, []byte("aaa")))
+
+	assert.Nil(t, err)
+	expectedBuff, _ := marshalizer.Marshal([][]byte{node})
+	assert.Equal(t, expectedBuff, sentBuff)
+}
+
+func TestTrieNodeResolver_ProcessReceivedMessageMissingFromStorageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	tnRes, _ := resolvers.NewTrieNodeResolver(
+		&mock.TopicResolverSenderStub{},
+		&mock.StorerStub{
+			GetCalled: func(key []byte) ([]byte, error) {
+				return nil, errExpected
+			},
+		},
+		&mock.MarshalizerMock{},
+		&mock.DataPackerStub{},
+	)
+
+	err := tnRes.ProcessReceivedMessage(createRequestMsg(dataRetriever.HashType, []byte("aaa")))
+
+	assert.Equal(t, errExpected, err)
+}
+
+func TestTrieNodeResolver_ProcessReceivedMessageHashArrayShouldPackFoundNodes(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	packedNodes := make([][]byte, 0)
+	numSent := 0
+	tnRes, _ := resolvers.NewTrieNodeResolver(
+		&mock.TopicResolverSenderStub{
+			SendCalled: func(buff []byte, peer p2p.PeerID) error {
+				numSent++
+				return nil
+			},
+		},
+		&mock.StorerStub{
+			GetCalled: func(key []byte) ([]byte, error) {
+				if bytes.Equal([]byte("missing"), key) {
+					return nil, errors.New("not found")
+				}
+
+				return append([]byte("node of "), key...), nil
+			},
+		},
+		marshalizer,
+		&mock.DataPackerStub{
+			PackDataInChunksCalled: func(data [][]byte, limit int) ([][]byte, error) {
+				packedNodes = data
+				return [][]byte{[]byte("chunk1"), []byte("chunk2")}, nil
+			},
+		},
+	)
+
+	buff, _ := marshalizer.Marshal([][]byte{[]byte("hash1"), []byte("missing"), []byte("hash2")})
+	err := tnRes.ProcessReceivedMessage(createRequestMsg(dataRetriever.HashArrayType, buff))
+
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{[]byte("node of hash1"), []byte("node of hash2")}, packedNodes)
+	assert.Equal(t, 2, numSent)
+}
+
+//------- RequestDataFromHashArray
+
+func TestTrieNodeResolver_RequestDataFromHashArrayShouldWork(t *testing.T) {
+	t.Parallel()
+
+	requested := &dataRetriever.RequestData{}
+	res := &mock.TopicResolverSenderStub{}
+	res.SendOnRequestTopicCalled = func(rd *dataRetriever.RequestData) error {
+		requested = rd
+		return nil
+	}
+
+	marshalizer := &mock.MarshalizerMock{}
+	tnRes, _ := resolvers.NewTrieNodeResolver(
+		res,
+		&mock.StorerStub{},
+		marshalizer,
+		&mock.DataPackerStub{},
+	)
+
+	hashes := [][]byte{[]byte("aaaa"), []byte("bbbb")}
+	buff, _ := marshalizer.Marshal(hashes)
+
+	assert.Nil(t, tnRes.RequestDataFromHashArray(hashes))
+	assert.Equal(t, &dataRetriever.RequestData{
+		Type:  dataRetriever.HashArrayType,
+		Value: buff,
+	}, requested)
+}
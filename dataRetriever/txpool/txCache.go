@@ -0,0 +1,406 @@
+package txpool
+
+import (
+	"bytes"
+	"container/heap"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/numbatx/gn-numbat/data/transaction"
+	"github.com/numbatx/gn-numbat/dataRetriever"
+	"github.com/numbatx/gn-numbat/storage"
+)
+
+// pooledTx is the entry held by the cache for a pending transaction
+type pooledTx struct {
+	tx        *transaction.Transaction
+	txHash    []byte
+	heapIndex int
+}
+
+// txCache is a cacher for transactions which, besides holding the transactions by their hashes, keeps
+// for every sender a queue of pending transactions ordered by nonce. Transactions with future nonces are held
+// in the queue until the nonce gap is filled.
+//
+// The cache is bounded: a sender can not have more than maxTxsPerSender pending transactions and, once the cache
+// is full, the lowest priced transaction ending a sender's queue is evicted first, so no nonce gap is left behind.
+// A pending transaction can be replaced by another one having the same sender and nonce only if the gas price is
+// bumped by at least minGasPriceBumpPercentage percents. The transactions requested for a block are added regardless
+// of this policy, as the block can not be processed without them
+type txCache struct {
+	mutTxs       sync.RWMutex
+	txs          map[string]*pooledTx
+	senderQueues map[string][]*pooledTx
+	tailsByPrice pooledTxsHeap
+	requestedTxs storage.Cacher
+
+	capacity                  uint32
+	maxTxsPerSender           uint32
+	minGasPriceBumpPercentage uint32
+
+	mutAddedDataHandlers sync.RWMutex
+	addedDataHandlers    []func(key []byte)
+}
+
+// NewTxCache creates a new transactions cacher holding at most capacity transactions, besides the ones whose hashes
+// are in requestedTxs
+func NewTxCache(
+	capacity uint32,
+	maxTxsPerSender uint32,
+	minGasPriceBumpPercentage uint32,
+	requestedTxs storage.Cacher,
+) (*txCache, error) {
+	if capacity == 0 {
+		return nil, dataRetriever.ErrInvalidTxPoolCapacity
+	}
+	if maxTxsPerSender == 0 {
+		return nil, dataRetriever.ErrInvalidMaxTxsPerSender
+	}
+	if requestedTxs == nil {
+		return nil, dataRetriever.ErrNilRequestedTxsCache
+	}
+
+	return &txCache{
+		txs:                       make(map[string]*pooledTx),
+		senderQueues:              make(map[string][]*pooledTx),
+		tailsByPrice:              make(pooledTxsHeap, 0),
+		requestedTxs:              requestedTxs,
+		capacity:                  capacity,
+		maxTxsPerSender:           maxTxsPerSender,
+		minGasPriceBumpPercentage: minGasPriceBumpPercentage,
+		addedDataHandlers:         make([]func(key []byte), 0),
+	}, nil
+}
+
+// Clear is used to completely clear the cache
+func (tc *txCache) Clear() {
+	tc.mutTxs.Lock()
+	tc.txs = make(map[string]*pooledTx)
+	tc.senderQueues = make(map[string][]*pooledTx)
+	tc.tailsByPrice = make(pooledTxsHeap, 0)
+	tc.mutTxs.Unlock()
+}
+
+// Put adds a transaction to the cache. Returns true if an eviction occurred. Values which are not transactions
+// and transactions rejected by the pool policy are not added
+func (tc *txCache) Put(key []byte, value interface{}) (evicted bool) {
+	_, evicted = tc.AddTx(key, value)
+	return evicted
+}
+
+// Get looks up a key's value from the cache
+func (tc *txCache) Get(key []byte) (value interface{}, ok bool) {
+	return tc.Peek(key)
+}
+
+// Has checks if a key is in the cache
+func (tc *txCache) Has(key []byte) bool {
+	tc.mutTxs.RLock()
+	_, ok := tc.txs[string(key)]
+	tc.mutTxs.RUnlock()
+
+	return ok
+}
+
+// Peek returns the key value (or undefined if not found)
+func (tc *txCache) Peek(key []byte) (value interface{}, ok bool) {
+	tc.mutTxs.RLock()
+	ptx, ok := tc.txs[string(key)]
+	tc.mutTxs.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+
+	return ptx.tx, true
+}
+
+// HasOrAdd checks if a key is in the cache and if not, tries to add the value.
+// Returns whether found and whether an eviction occurred
+func (tc *txCache) HasOrAdd(key []byte, value interface{}) (ok, evicted bool) {
+	if tc.Has(key) {
+		return true, false
+	}
+
+	_, evicted = tc.AddTx(key, value)
+	return false, evicted
+}
+
+// AddTx tries to add a transaction to the cache, applying the pool policy. Returns whether the transaction has
+// been added and whether another transaction has been evicted or replaced in order to make room for it
+func (tc *txCache) AddTx(key []byte, value interface{}) (added bool, evicted bool) {
+	tx, ok := value.(*transaction.Transaction)
+	if !ok || tx == nil {
+		return false, false
+	}
+
+	tc.mutTxs.Lock()
+	added, evicted = tc.addTxNoLock(key, tx)
+	tc.mutTxs.Unlock()
+
+	if added {
+		tc.callAddedDataHandlers(key)
+	}
+
+	return added, evicted
+}
+
+func (tc *txCache) addTxNoLock(key []byte, tx *transaction.Transaction) (added bool, evicted bool) {
+	if _, exists := tc.txs[string(key)]; exists {
+		return false, false
+	}
+
+	isRequested := tc.requestedTxs.Has(key)
+	sender := string(tx.SndAddr)
+	replacedTx := tc.txWithNonceNoLock(sender, tx.Nonce)
+	if replacedTx != nil {
+		if !isRequested && !tc.isGasPriceBumped(replacedTx.tx.GasPrice, tx.GasPrice) {
+			return false, false
+		}
+
+		tc.removeNoLock(replacedTx.txHash)
+		evicted = true
+	}
+
+	if !isRequested {
+		if replacedTx == nil && uint32(len(tc.senderQueues[sender])) >= tc.maxTxsPerSender {
+			return false, false
+		}
+
+		if uint32(len(tc.txs)) >= tc.capacity {
+			if !tc.evictCheaperTailNoLock(tx) {
+				return false, evicted
+			}
+
+			evicted = true
+		}
+	}
+
+	tc.insertNoLock(&pooledTx{tx: tx, txHash: key})
+
+	return true, evicted
+}
+
+// evictCheaperTailNoLock evicts the lowest priced transaction ending a sender's queue if it is cheaper than the
+// given transaction. The transaction preceding the given one in its sender's queue is not evicted, as that would
+// leave it after a nonce gap
+func (tc *txCache) evictCheaperTailNoLock(tx *transaction.Transaction) bool {
+	if len(tc.tailsByPrice) == 0 {
+		return false
+	}
+
+	lowestPricedTail := tc.tailsByPrice[0]
+	if tx.GasPrice <= lowestPricedTail.tx.GasPrice {
+		return false
+	}
+	if bytes.Equal(lowestPricedTail.tx.SndAddr, tx.SndAddr) && lowestPricedTail.tx.Nonce < tx.Nonce {
+		return false
+	}
+
+	tc.removeNoLock(lowestPricedTail.txHash)
+
+	return true
+}
+
+func (tc *txCache) insertNoLock(ptx *pooledTx) {
+	tc.txs[string(ptx.txHash)] = ptx
+
+	sender := string(ptx.tx.SndAddr)
+	queue := tc.senderQueues[sender]
+	idx := sort.Search(len(queue), func(i int) bool {
+		return queue[i].tx.Nonce > ptx.tx.Nonce
+	})
+	queue = append(queue, nil)
+	copy(queue[idx+1:], queue[idx:])
+	queue[idx] = ptx
+	tc.senderQueues[sender] = queue
+
+	isNewTail := idx == len(queue)-1
+	if !isNewTail {
+		return
+	}
+
+	if idx > 0 {
+		heap.Remove(&tc.tailsByPrice, queue[idx-1].heapIndex)
+	}
+	heap.Push(&tc.tailsByPrice, ptx)
+}
+
+func (tc *txCache) txWithNonceNoLock(sender string, nonce uint64) *pooledTx {
+	for _, ptx := range tc.senderQueues[sender] {
+		if ptx.tx.Nonce == nonce {
+			return ptx
+		}
+	}
+
+	return nil
+}
+
+// isGasPriceBumped returns true if the new gas price is higher than the old one with at least
+// minGasPriceBumpPercentage percents
+func (tc *txCache) isGasPriceBumped(oldGasPrice uint64, newGasPrice uint64) bool {
+	if newGasPrice <= oldGasPrice {
+		return false
+	}
+
+	minGasPrice := big.NewInt(0).SetUint64(oldGasPrice)
+	minGasPrice.Mul(minGasPrice, big.NewInt(int64(100+tc.minGasPriceBumpPercentage)))
+	gasPrice := big.NewInt(0).SetUint64(newGasPrice)
+	gasPrice.Mul(gasPrice, big.NewInt(100))
+
+	return gasPrice.Cmp(minGasPrice) >= 0
+}
+
+// Remove removes the provided key from the cache
+func (tc *txCache) Remove(key []byte) {
+	tc.mutTxs.Lock()
+	tc.removeNoLock(key)
+	tc.mutTxs.Unlock()
+}
+
+func (tc *txCache) removeNoLock(key []byte) {
+	ptx, ok := tc.txs[string(key)]
+	if !ok {
+		return
+	}
+
+	delete(tc.txs, string(key))
+
+	sender := string(ptx.tx.SndAddr)
+	queue := tc.senderQueues[sender]
+	for i := range queue {
+		if queue[i] != ptx {
+			continue
+		}
+
+		wasTail := i == len(queue)-1
+		queue = append(queue[:i], queue[i+1:]...)
+		if wasTail {
+			heap.Remove(&tc.tailsByPrice, ptx.heapIndex)
+			if len(queue) > 0 {
+				heap.Push(&tc.tailsByPrice, queue[len(queue)-1])
+			}
+		}
+		break
+	}
+
+	if len(queue) == 0 {
+		delete(tc.senderQueues, sender)
+		return
+	}
+
+	tc.senderQueues[sender] = queue
+}
+
+// RemoveOldest removes the lowest priced transaction ending a sender's queue from the cache
+func (tc *txCache) RemoveOldest() {
+	tc.mutTxs.Lock()
+	if len(tc.tailsByPrice) > 0 {
+		tc.removeNoLock(tc.tailsByPrice[0].txHash)
+	}
+	tc.mutTxs.Unlock()
+}
+
+// Keys returns a slice of the keys in the cache
+func (tc *txCache) Keys() [][]byte {
+	tc.mutTxs.RLock()
+	keys := make([][]byte, 0, len(tc.txs))
+	for key := range tc.txs {
+		keys = append(keys, []byte(key))
+	}
+	tc.mutTxs.RUnlock()
+
+	return keys
+}
+
+// Len returns the number of items in the cache
+func (tc *txCache) Len() int {
+	tc.mutTxs.RLock()
+	defer tc.mutTxs.RUnlock()
+
+	return len(tc.txs)
+}
+
+// RegisterHandler registers a new handler to be called when a new data is added
+func (tc *txCache) RegisterHandler(handler func(key []byte)) {
+	if handler == nil {
+		log.Error("attempt to register a nil handler to a tx cache object")
+		return
+	}
+
+	tc.mutAddedDataHandlers.Lock()
+	tc.addedDataHandlers = append(tc.addedDataHandlers, handler)
+	tc.mutAddedDataHandlers.Unlock()
+}
+
+func (tc *txCache) callAddedDataHandlers(key []byte) {
+	tc.mutAddedDataHandlers.RLock()
+	for _, handler := range tc.addedDataHandlers {
+		go handler(key)
+	}
+	tc.mutAddedDataHandlers.RUnlock()
+}
+
+// SortedTxHashesBySender returns, for every sender, the hashes of its pending transactions ordered by nonce
+func (tc *txCache) SortedTxHashesBySender() map[string][][]byte {
+	tc.mutTxs.RLock()
+	defer tc.mutTxs.RUnlock()
+
+	txHashesBySender := make(map[string][][]byte, len(tc.senderQueues))
+	for sender, queue := range tc.senderQueues {
+		txHashes := make([][]byte, len(queue))
+		for i, ptx := range queue {
+			txHashes[i] = ptx.txHash
+		}
+
+		txHashesBySender[sender] = txHashes
+	}
+
+	return txHashesBySender
+}
+
+// pooledTxsHeap implements heap.Interface for the pooled transactions ending the senders' queues, keeping on top
+// the transaction which is evicted first: the lowest priced one. Between transactions with the same gas price, the
+// one with the highest nonce is evicted first
+type pooledTxsHeap []*pooledTx
+
+func (pth pooledTxsHeap) Len() int {
+	return len(pth)
+}
+
+func (pth pooledTxsHeap) Less(i, j int) bool {
+	txI := pth[i].tx
+	txJ := pth[j].tx
+
+	if txI.GasPrice != txJ.GasPrice {
+		return txI.GasPrice < txJ.GasPrice
+	}
+	if txI.Nonce != txJ.Nonce {
+		return txI.Nonce > txJ.Nonce
+	}
+
+	return bytes.Compare(pth[i].txHash, pth[j].txHash) < 0
+}
+
+func (pth pooledTxsHeap) Swap(i, j int) {
+	pth[i], pth[j] = pth[j], pth[i]
+	pth[i].heapIndex = i
+	pth[j].heapIndex = j
+}
+
+func (pth *pooledTxsHeap) Push(x interface{}) {
+	ptx := x.(*pooledTx)
+	ptx.heapIndex = len(*pth)
+	*pth = append(*pth, ptx)
+}
+
+func (pth *pooledTxsHeap) Pop() interface{} {
+	old := *pth
+	n := len(old)
+	ptx := old[n-1]
+	old[n-1] = nil
+	*pth = old[:n-1]
+
+	return ptx
+}
@@ -0,0 +1,284 @@
+package txpool_test
+
+import (
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/transaction"
+	"github.com/numbatx/gn-numbat/dataRetriever"
+	"github.com/numbatx/gn-numbat/dataRetriever/txpool"
+	"github.com/numbatx/gn-numbat/storage"
+	"github.com/numbatx/gn-numbat/storage/lrucache"
+	"github.com/stretchr/testify/assert"
+)
+
+const testCapacity = 1000
+const testMaxTxsPerSender = 100
+const testGasPriceBump = 10
+
+func newRequestedTxs(requestedHashes ...[]byte) storage.Cacher {
+	requestedTxs, _ := lrucache.NewCache(10)
+	for _, hash := range requestedHashes {
+		requestedTxs.Put(hash, struct{}{})
+	}
+
+	return requestedTxs
+}
+
+func newTestTxCache() storageTxCache {
+	tc, _ := txpool.NewTxCache(testCapacity, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs())
+	return tc
+}
+
+type storageTxCache interface {
+	dataRetriever.SenderTxQueuer
+	AddTx(key []byte, value interface{}) (added bool, evicted bool)
+}
+
+func TestNewTxCache_ZeroCapacityShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tc, err := txpool.NewTxCache(0, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs())
+
+	assert.Equal(t, dataRetriever.ErrInvalidTxPoolCapacity, err)
+	assert.Nil(t, tc)
+}
+
+func TestNewTxCache_ZeroMaxTxsPerSenderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tc, err := txpool.NewTxCache(testCapacity, 0, testGasPriceBump, newRequestedTxs())
+
+	assert.Equal(t, dataRetriever.ErrInvalidMaxTxsPerSender, err)
+	assert.Nil(t, tc)
+}
+
+func TestNewTxCache_NilRequestedTxsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tc, err := txpool.NewTxCache(testCapacity, testMaxTxsPerSender, testGasPriceBump, nil)
+
+	assert.Equal(t, dataRetriever.ErrNilRequestedTxsCache, err)
+	assert.Nil(t, tc)
+}
+
+func TestNewTxCache_GoodConfigShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tc, err := txpool.NewTxCache(testCapacity, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs())
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tc)
+}
+
+func TestTxCache_SortedTxHashesBySenderShouldOrderByNonce(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestTxCache()
+	tc.Put([]byte("a3"), &transaction.Transaction{Nonce: 3, SndAddr: []byte("a")})
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")})
+	tc.HasOrAdd([]byte("b0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("b")})
+	tc.Put([]byte("a2"), &transaction.Transaction{Nonce: 2, SndAddr: []byte("a")})
+
+	txHashesBySender := tc.SortedTxHashesBySender()
+
+	assert.Equal(t, 2, len(txHashesBySender))
+	assert.Equal(t, [][]byte{[]byte("a1"), []byte("a2"), []byte("a3")}, txHashesBySender["a"])
+	assert.Equal(t, [][]byte{[]byte("b0")}, txHashesBySender["b"])
+}
+
+func TestTxCache_RemoveShouldRemoveFromSenderQueue(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestTxCache()
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")})
+	tc.Put([]byte("a2"), &transaction.Transaction{Nonce: 2, SndAddr: []byte("a")})
+	tc.Put([]byte("b0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("b")})
+
+	tc.Remove([]byte("a1"))
+	tc.Remove([]byte("b0"))
+
+	txHashesBySender := tc.SortedTxHashesBySender()
+	assert.Equal(t, 1, len(txHashesBySender))
+	assert.Equal(t, [][]byte{[]byte("a2")}, txHashesBySender["a"])
+	assert.False(t, tc.Has([]byte("a1")))
+}
+
+func TestTxCache_PutSameKeyTwiceShouldQueueOnce(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestTxCache()
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")})
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")})
+	ok, _ := tc.HasOrAdd([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")})
+
+	txHashesBySender := tc.SortedTxHashesBySender()
+	assert.True(t, ok)
+	assert.Equal(t, [][]byte{[]byte("a1")}, txHashesBySender["a"])
+}
+
+func TestTxCache_ClearShouldEmptySenderQueues(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestTxCache()
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")})
+
+	tc.Clear()
+
+	assert.Equal(t, 0, tc.Len())
+	assert.Equal(t, 0, len(tc.SortedTxHashesBySender()))
+}
+
+func TestTxCache_NonTransactionValuesShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestTxCache()
+	tc.Put([]byte("key"), "value")
+
+	assert.False(t, tc.Has([]byte("key")))
+	assert.Equal(t, 0, len(tc.SortedTxHashesBySender()))
+}
+
+func TestTxCache_SameNonceWithoutGasPriceBumpShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestTxCache()
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 100})
+
+	added, _ := tc.AddTx([]byte("a1-bis"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 109})
+
+	assert.False(t, added)
+	assert.True(t, tc.Has([]byte("a1")))
+	assert.False(t, tc.Has([]byte("a1-bis")))
+}
+
+func TestTxCache_SameNonceWithGasPriceBumpShouldReplace(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestTxCache()
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 100})
+	tc.Put([]byte("a2"), &transaction.Transaction{Nonce: 2, SndAddr: []byte("a"), GasPrice: 100})
+
+	added, evicted := tc.AddTx([]byte("a1-bis"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 110})
+
+	assert.True(t, added)
+	assert.True(t, evicted)
+	assert.False(t, tc.Has([]byte("a1")))
+	assert.Equal(t, [][]byte{[]byte("a1-bis"), []byte("a2")}, tc.SortedTxHashesBySender()["a"])
+}
+
+func TestTxCache_SenderOverCapShouldBeRejected(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := txpool.NewTxCache(testCapacity, 2, testGasPriceBump, newRequestedTxs())
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")})
+	tc.Put([]byte("a2"), &transaction.Transaction{Nonce: 2, SndAddr: []byte("a")})
+
+	added, _ := tc.AddTx([]byte("a3"), &transaction.Transaction{Nonce: 3, SndAddr: []byte("a")})
+	assert.False(t, added)
+
+	added, _ = tc.AddTx([]byte("b1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("b")})
+	assert.True(t, added)
+}
+
+func TestTxCache_FullCacheShouldEvictLowestPricedTx(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := txpool.NewTxCache(3, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs())
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 5})
+	tc.Put([]byte("a2"), &transaction.Transaction{Nonce: 2, SndAddr: []byte("a"), GasPrice: 5})
+	tc.Put([]byte("b1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("b"), GasPrice: 10})
+
+	added, evicted := tc.AddTx([]byte("c1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("c"), GasPrice: 7})
+
+	assert.True(t, added)
+	assert.True(t, evicted)
+	assert.Equal(t, 3, tc.Len())
+	//between the equally priced transactions, the one with the highest nonce is evicted
+	assert.False(t, tc.Has([]byte("a2")))
+	assert.Equal(t, [][]byte{[]byte("a1")}, tc.SortedTxHashesBySender()["a"])
+}
+
+func TestTxCache_FullCacheShouldRejectCheaperTx(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := txpool.NewTxCache(2, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs())
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 5})
+	tc.Put([]byte("b1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("b"), GasPrice: 10})
+
+	added, evicted := tc.AddTx([]byte("c1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("c"), GasPrice: 5})
+
+	assert.False(t, added)
+	assert.False(t, evicted)
+	assert.True(t, tc.Has([]byte("a1")))
+	assert.True(t, tc.Has([]byte("b1")))
+}
+
+func TestTxCache_RemoveOldestShouldRemoveLowestPricedTx(t *testing.T) {
+	t.Parallel()
+
+	tc := newTestTxCache()
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 10})
+	tc.Put([]byte("b1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("b"), GasPrice: 3})
+
+	tc.RemoveOldest()
+
+	assert.False(t, tc.Has([]byte("b1")))
+	assert.True(t, tc.Has([]byte("a1")))
+}
+
+func TestTxCache_FullCacheShouldEvictOnlyFromTheEndOfASenderQueue(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := txpool.NewTxCache(4, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs())
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 10})
+	tc.Put([]byte("a2"), &transaction.Transaction{Nonce: 2, SndAddr: []byte("a"), GasPrice: 1})
+	tc.Put([]byte("a3"), &transaction.Transaction{Nonce: 3, SndAddr: []byte("a"), GasPrice: 10})
+	tc.Put([]byte("b1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("b"), GasPrice: 5})
+
+	added, evicted := tc.AddTx([]byte("c1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("c"), GasPrice: 6})
+
+	assert.True(t, added)
+	assert.True(t, evicted)
+	assert.False(t, tc.Has([]byte("b1")))
+	assert.Equal(t, [][]byte{[]byte("a1"), []byte("a2"), []byte("a3")}, tc.SortedTxHashesBySender()["a"])
+}
+
+func TestTxCache_FullCacheShouldNotEvictThePreviousNonceOfTheSameSender(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := txpool.NewTxCache(1, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs())
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 1})
+
+	added, evicted := tc.AddTx([]byte("a2"), &transaction.Transaction{Nonce: 2, SndAddr: []byte("a"), GasPrice: 10})
+
+	assert.False(t, added)
+	assert.False(t, evicted)
+	assert.True(t, tc.Has([]byte("a1")))
+}
+
+func TestTxCache_RequestedTxShouldReplaceTheSameNonceWithoutGasPriceBump(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := txpool.NewTxCache(testCapacity, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs([]byte("a1")))
+	tc.Put([]byte("a1'"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 10})
+
+	added, evicted := tc.AddTx([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 5})
+
+	assert.True(t, added)
+	assert.True(t, evicted)
+	assert.False(t, tc.Has([]byte("a1'")))
+	assert.Equal(t, [][]byte{[]byte("a1")}, tc.SortedTxHashesBySender()["a"])
+}
+
+func TestTxCache_RequestedTxShouldBeAddedOverTheLimits(t *testing.T) {
+	t.Parallel()
+
+	tc, _ := txpool.NewTxCache(1, 1, testGasPriceBump, newRequestedTxs([]byte("a2")))
+	tc.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a"), GasPrice: 10})
+
+	added, evicted := tc.AddTx([]byte("a2"), &transaction.Transaction{Nonce: 2, SndAddr: []byte("a"), GasPrice: 1})
+
+	assert.True(t, added)
+	assert.False(t, evicted)
+	assert.Equal(t, 2, tc.Len())
+}
@@ -0,0 +1,209 @@
+package txpool
+
+import (
+	"sync"
+
+	"github.com/numbatx/gn-numbat/core/logger"
+	"github.com/numbatx/gn-numbat/storage"
+)
+
+var log = logger.DefaultLogger()
+
+// shardedTxPool holds the pending transactions organised by sender-destination shard caches. Each of its shard
+// stores is a bounded txCache which keeps a queue per sender, supports replacing a pending transaction by fee and
+// evicts the lowest priced transactions first when it is full
+type shardedTxPool struct {
+	mutShardStores sync.RWMutex
+	shardStores    map[string]*txCache
+
+	capacity                  uint32
+	maxTxsPerSender           uint32
+	minGasPriceBumpPercentage uint32
+	requestedTxs              storage.Cacher
+
+	mutAddedDataHandlers sync.RWMutex
+	addedDataHandlers    []func(key []byte)
+}
+
+// NewShardedTxPool creates a sharded data pool for transactions. Each of its shard stores holds at most capacity
+// transactions, at most maxTxsPerSender of them coming from the same sender. A pending transaction is replaced by
+// another one with the same sender and nonce only if the gas price is bumped with at least minGasPriceBumpPercentage
+// percents. The transactions whose hashes are in requestedTxs are added regardless of these limits
+func NewShardedTxPool(
+	capacity uint32,
+	maxTxsPerSender uint32,
+	minGasPriceBumpPercentage uint32,
+	requestedTxs storage.Cacher,
+) (*shardedTxPool, error) {
+
+	_, err := NewTxCache(capacity, maxTxsPerSender, minGasPriceBumpPercentage, requestedTxs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &shardedTxPool{
+		shardStores:               make(map[string]*txCache),
+		capacity:                  capacity,
+		maxTxsPerSender:           maxTxsPerSender,
+		minGasPriceBumpPercentage: minGasPriceBumpPercentage,
+		requestedTxs:              requestedTxs,
+		addedDataHandlers:         make([]func(key []byte), 0),
+	}, nil
+}
+
+// CreateShardStore creates a new, empty, shard store for the given cacheId
+func (stp *shardedTxPool) CreateShardStore(cacheId string) {
+	stp.mutShardStores.Lock()
+	stp.newShardStoreNoLock(cacheId)
+	stp.mutShardStores.Unlock()
+}
+
+func (stp *shardedTxPool) newShardStoreNoLock(cacheId string) *txCache {
+	cache, err := NewTxCache(stp.capacity, stp.maxTxsPerSender, stp.minGasPriceBumpPercentage, stp.requestedTxs)
+	log.LogIfError(err)
+
+	stp.shardStores[cacheId] = cache
+	return cache
+}
+
+func (stp *shardedTxPool) shardStore(cacheId string) *txCache {
+	stp.mutShardStores.RLock()
+	cache := stp.shardStores[cacheId]
+	stp.mutShardStores.RUnlock()
+
+	return cache
+}
+
+// ShardDataStore returns the shard store holding the transactions associated with the given cacheId
+func (stp *shardedTxPool) ShardDataStore(cacheId string) (c storage.Cacher) {
+	cache := stp.shardStore(cacheId)
+	if cache == nil {
+		return nil
+	}
+
+	return cache
+}
+
+// AddData tries to add a transaction to the corresponding shard store. The registered handlers are called only if
+// the transaction has been accepted by the pool
+func (stp *shardedTxPool) AddData(key []byte, data interface{}, cacheId string) {
+	stp.mutShardStores.Lock()
+	cache := stp.shardStores[cacheId]
+	if cache == nil {
+		cache = stp.newShardStoreNoLock(cacheId)
+	}
+	stp.mutShardStores.Unlock()
+
+	if cache.Has(key) {
+		return
+	}
+
+	added, _ := cache.AddTx(key, data)
+	if !added {
+		return
+	}
+
+	stp.mutAddedDataHandlers.RLock()
+	for _, handler := range stp.addedDataHandlers {
+		go handler(key)
+	}
+	stp.mutAddedDataHandlers.RUnlock()
+}
+
+// SearchFirstData searches the key against all shard stores, retrieving the first value found
+func (stp *shardedTxPool) SearchFirstData(key []byte) (value interface{}, ok bool) {
+	stp.mutShardStores.RLock()
+	defer stp.mutShardStores.RUnlock()
+
+	for _, cache := range stp.shardStores {
+		value, ok = cache.Peek(key)
+		if ok {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// RemoveData removes the transaction from the corresponding shard store
+func (stp *shardedTxPool) RemoveData(key []byte, cacheId string) {
+	cache := stp.shardStore(cacheId)
+	if cache != nil {
+		cache.Remove(key)
+	}
+}
+
+// RemoveSetOfDataFromPool removes a list of transactions from the corresponding shard store
+func (stp *shardedTxPool) RemoveSetOfDataFromPool(keys [][]byte, cacheId string) {
+	for _, key := range keys {
+		stp.RemoveData(key, cacheId)
+	}
+}
+
+// RemoveDataFromAllShards removes the transaction from all the shard stores
+func (stp *shardedTxPool) RemoveDataFromAllShards(key []byte) {
+	stp.mutShardStores.RLock()
+	for _, cache := range stp.shardStores {
+		cache.Remove(key)
+	}
+	stp.mutShardStores.RUnlock()
+}
+
+// MergeShardStores moves all the transactions associated with the sourceCacheId to the destCacheId and then
+// removes the source shard store
+func (stp *shardedTxPool) MergeShardStores(sourceCacheID, destCacheID string) {
+	sourceStore := stp.shardStore(sourceCacheID)
+	if sourceStore != nil {
+		for _, key := range sourceStore.Keys() {
+			val, _ := sourceStore.Peek(key)
+			stp.AddData(key, val, destCacheID)
+		}
+	}
+
+	stp.mutShardStores.Lock()
+	delete(stp.shardStores, sourceCacheID)
+	stp.mutShardStores.Unlock()
+}
+
+// MoveData moves the given transactions associated with the sourceCacheId to the destCacheId
+func (stp *shardedTxPool) MoveData(sourceCacheID, destCacheID string, keys [][]byte) {
+	sourceStore := stp.shardStore(sourceCacheID)
+	if sourceStore == nil {
+		return
+	}
+
+	for _, key := range keys {
+		val, ok := sourceStore.Peek(key)
+		if ok {
+			stp.AddData(key, val, destCacheID)
+			stp.RemoveData(key, sourceCacheID)
+		}
+	}
+}
+
+// Clear deletes all shard stores and the transactions they hold
+func (stp *shardedTxPool) Clear() {
+	stp.mutShardStores.Lock()
+	stp.shardStores = make(map[string]*txCache)
+	stp.mutShardStores.Unlock()
+}
+
+// ClearShardStore deletes all the transactions associated with the given cacheId
+func (stp *shardedTxPool) ClearShardStore(cacheId string) {
+	cache := stp.shardStore(cacheId)
+	if cache != nil {
+		cache.Clear()
+	}
+}
+
+// RegisterHandler registers a new handler to be called when a new transaction is added
+func (stp *shardedTxPool) RegisterHandler(handler func(key []byte)) {
+	if handler == nil {
+		log.Error("attempt to register a nil handler to a sharded tx pool object")
+		return
+	}
+
+	stp.mutAddedDataHandlers.Lock()
+	stp.addedDataHandlers = append(stp.addedDataHandlers, handler)
+	stp.mutAddedDataHandlers.Unlock()
+}
@@ -0,0 +1,81 @@
+package txpool_test
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/numbatx/gn-numbat/data/transaction"
+	"github.com/numbatx/gn-numbat/dataRetriever"
+	"github.com/numbatx/gn-numbat/dataRetriever/txpool"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShardedTxPool_ZeroCapacityShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txPool, err := txpool.NewShardedTxPool(0, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs())
+
+	assert.Equal(t, dataRetriever.ErrInvalidTxPoolCapacity, err)
+	assert.Nil(t, txPool)
+}
+
+func TestNewShardedTxPool_ShardStoresShouldBeSenderTxQueuers(t *testing.T) {
+	t.Parallel()
+
+	txPool, err := txpool.NewShardedTxPool(testCapacity, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs())
+	assert.Nil(t, err)
+
+	txPool.AddData([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")}, "0")
+	_, ok := txPool.ShardDataStore("0").(dataRetriever.SenderTxQueuer)
+
+	assert.True(t, ok)
+}
+
+func TestShardedTxPool_AddDataShouldNotifyOnlyAcceptedTxs(t *testing.T) {
+	t.Parallel()
+
+	txPool, _ := txpool.NewShardedTxPool(testCapacity, 1, testGasPriceBump, newRequestedTxs())
+	notified := int32(0)
+	txPool.RegisterHandler(func(key []byte) {
+		atomic.AddInt32(&notified, 1)
+	})
+
+	txPool.AddData([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")}, "0")
+	txPool.AddData([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")}, "0")
+	txPool.AddData([]byte("a2"), &transaction.Transaction{Nonce: 2, SndAddr: []byte("a")}, "0")
+
+	time.Sleep(time.Millisecond * 100)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&notified))
+	assert.Equal(t, 1, txPool.ShardDataStore("0").Len())
+}
+
+func TestShardedTxPool_SearchFirstDataAndRemoveFromAllShardsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	txPool, _ := txpool.NewShardedTxPool(testCapacity, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs())
+	tx := &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")}
+	txPool.AddData([]byte("a1"), tx, "1")
+
+	value, ok := txPool.SearchFirstData([]byte("a1"))
+	assert.True(t, ok)
+	assert.Equal(t, tx, value)
+
+	txPool.RemoveDataFromAllShards([]byte("a1"))
+	_, ok = txPool.SearchFirstData([]byte("a1"))
+	assert.False(t, ok)
+}
+
+func TestShardedTxPool_MergeShardStoresShouldMoveTxs(t *testing.T) {
+	t.Parallel()
+
+	txPool, _ := txpool.NewShardedTxPool(testCapacity, testMaxTxsPerSender, testGasPriceBump, newRequestedTxs())
+	txPool.AddData([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("a")}, "0")
+	txPool.AddData([]byte("b1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("b")}, "1")
+
+	txPool.MergeShardStores("0", "1")
+
+	assert.Nil(t, txPool.ShardDataStore("0"))
+	assert.Equal(t, 2, txPool.ShardDataStore("1").Len())
+}
@@ -4,6 +4,7 @@ import (
 	"math/big"
 
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/proof"
 	"github.com/numbatx/gn-numbat/data/transaction"
 	"github.com/numbatx/gn-numbat/node/external"
 	"github.com/numbatx/gn-numbat/node/heartbeat"
@@ -34,11 +35,17 @@ type NodeWrapper interface {
 	GenerateTransaction(senderHex string, receiverHex string, amount *big.Int, code string) (*transaction.Transaction, error)
 
 	//SendTransaction will send a new transaction on the topic channel
-	SendTransaction(nonce uint64, senderHex string, receiverHex string, value *big.Int, transactionData string, signature []byte) (*transaction.Transaction, error)
+	SendTransaction(nonce uint64, senderHex string, receiverHex string, value *big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, transactionData string, signature []byte) (*transaction.Transaction, error)
+
+	//SendBatchTransaction will send a new batch transfer transaction on the topic channel
+	SendBatchTransaction(nonce uint64, senderHex string, receiversHex []string, values []*big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, signature []byte) (*transaction.Transaction, error)
 
 	//GetTransaction gets the transaction
 	GetTransaction(hash string) (*transaction.Transaction, error)
 
+	//GetTransactionStatus gets the execution result of a transaction
+	GetTransactionStatus(hash string) (*transaction.ExecutionResult, error)
+
 	// GetCurrentPublicKey gets the current nodes public Key
 	GetCurrentPublicKey() string
 
@@ -54,6 +61,30 @@ type NodeWrapper interface {
 	//  about the account corelated with provided address
 	GetAccount(address string) (*state.Account, error)
 
+	// GetAccountAtBlockNonce returns the account with the provided address, as it was after the committed block
+	//  with the provided nonce
+	GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error)
+
+	// GetAccountAtBlockHash returns the account with the provided address, as it was after the committed block
+	//  with the provided hex encoded hash
+	GetAccountAtBlockHash(address string, blockHash string) (*state.Account, error)
+
+	// GetAccountProof returns the Merkle proof of the account with the provided address against the state root hash
+	//  of the latest committed block
+	GetAccountProof(address string) (*proof.AccountProof, error)
+
+	// GetAccountStorageValue returns the value stored for the provided hex encoded key in the data trie of the account
+	//  with the provided address
+	GetAccountStorageValue(address string, key string) (*external.StorageValue, error)
+
+	// GetAccountStorage returns at most maxEntries entries of the data trie of the account with the provided address,
+	//  starting from the provided hex encoded key
+	GetAccountStorage(address string, startKey string, maxEntries int) (*external.StoragePage, error)
+
+	// GetBlockStateDiff returns the changes made to the accounts by the committed block with the provided hex encoded
+	//  hash
+	GetBlockStateDiff(blockHash string) (*state.StateDiff, error)
+
 	// GetHeartbeats returns the heartbeat status for each public key defined in genesis.json
 	GetHeartbeats() []heartbeat.PubKeyHeartbeat
 }
@@ -4,7 +4,9 @@ import (
 	"math/big"
 
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/proof"
 	"github.com/numbatx/gn-numbat/data/transaction"
+	"github.com/numbatx/gn-numbat/node/external"
 	"github.com/numbatx/gn-numbat/node/heartbeat"
 )
 
@@ -19,8 +21,16 @@ type NodeMock struct {
 	GetBalanceHandler                              func(address string) (*big.Int, error)
 	GenerateTransactionHandler                     func(sender string, receiver string, amount *big.Int, code string) (*transaction.Transaction, error)
 	GetTransactionHandler                          func(hash string) (*transaction.Transaction, error)
-	SendTransactionHandler                         func(nonce uint64, sender string, receiver string, amount *big.Int, code string, signature []byte) (*transaction.Transaction, error)
+	GetTransactionStatusHandler                    func(hash string) (*transaction.ExecutionResult, error)
+	SendBatchTransactionHandler                    func(nonce uint64, sender string, receivers []string, values []*big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, signature []byte) (*transaction.Transaction, error)
+	SendTransactionHandler                         func(nonce uint64, sender string, receiver string, amount *big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, code string, signature []byte) (*transaction.Transaction, error)
 	GetAccountHandler                              func(address string) (*state.Account, error)
+	GetAccountProofHandler                         func(address string) (*proof.AccountProof, error)
+	GetAccountStorageValueHandler                  func(address string, key string) (*external.StorageValue, error)
+	GetAccountStorageHandler                       func(address string, startKey string, maxEntries int) (*external.StoragePage, error)
+	GetAccountAtBlockNonceHandler                  func(address string, blockNonce uint64) (*state.Account, error)
+	GetBlockStateDiffHandler                       func(blockHash string) (*state.StateDiff, error)
+	GetAccountAtBlockHashHandler                   func(address string, blockHash string) (*state.Account, error)
 	GetCurrentPublicKeyHandler                     func() string
 	GenerateAndSendBulkTransactionsHandler         func(destination string, value *big.Int, nrTransactions uint64) error
 	GenerateAndSendBulkTransactionsOneByOneHandler func(destination string, value *big.Int, nrTransactions uint64) error
@@ -67,8 +77,16 @@ func (nm *NodeMock) GetTransaction(hash string) (*transaction.Transaction, error
 	return nm.GetTransactionHandler(hash)
 }
 
-func (nm *NodeMock) SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, transactionData string, signature []byte) (*transaction.Transaction, error) {
-	return nm.SendTransactionHandler(nonce, sender, receiver, value, transactionData, signature)
+func (nm *NodeMock) GetTransactionStatus(hash string) (*transaction.ExecutionResult, error) {
+	return nm.GetTransactionStatusHandler(hash)
+}
+
+func (nm *NodeMock) SendTransaction(nonce uint64, sender string, receiver string, value *big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, transactionData string, signature []byte) (*transaction.Transaction, error) {
+	return nm.SendTransactionHandler(nonce, sender, receiver, value, gasPrice, gasLimit, validFromRound, validUntilRound, transactionData, signature)
+}
+
+func (nm *NodeMock) SendBatchTransaction(nonce uint64, sender string, receivers []string, values []*big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, signature []byte) (*transaction.Transaction, error) {
+	return nm.SendBatchTransactionHandler(nonce, sender, receivers, values, gasPrice, gasLimit, validFromRound, validUntilRound, signature)
 }
 
 func (nm *NodeMock) GetCurrentPublicKey() string {
@@ -87,6 +105,30 @@ func (nm *NodeMock) GetAccount(address string) (*state.Account, error) {
 	return nm.GetAccountHandler(address)
 }
 
+func (nm *NodeMock) GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error) {
+	return nm.GetAccountAtBlockNonceHandler(address, blockNonce)
+}
+
+func (nm *NodeMock) GetAccountAtBlockHash(address string, blockHash string) (*state.Account, error) {
+	return nm.GetAccountAtBlockHashHandler(address, blockHash)
+}
+
+func (nm *NodeMock) GetAccountProof(address string) (*proof.AccountProof, error) {
+	return nm.GetAccountProofHandler(address)
+}
+
+func (nm *NodeMock) GetAccountStorageValue(address string, key string) (*external.StorageValue, error) {
+	return nm.GetAccountStorageValueHandler(address, key)
+}
+
+func (nm *NodeMock) GetAccountStorage(address string, startKey string, maxEntries int) (*external.StoragePage, error) {
+	return nm.GetAccountStorageHandler(address, startKey, maxEntries)
+}
+
+func (nm *NodeMock) GetBlockStateDiff(blockHash string) (*state.StateDiff, error) {
+	return nm.GetBlockStateDiffHandler(blockHash)
+}
+
 func (nm *NodeMock) GetHeartbeats() []heartbeat.PubKeyHeartbeat {
 	return nm.GetHeartbeatsHandler()
 }
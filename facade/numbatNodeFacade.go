@@ -8,6 +8,7 @@ import (
 	"github.com/numbatx/gn-numbat/core/logger"
 	"github.com/numbatx/gn-numbat/core/statistics"
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/proof"
 	"github.com/numbatx/gn-numbat/data/transaction"
 	"github.com/numbatx/gn-numbat/node/external"
 	"github.com/numbatx/gn-numbat/node/heartbeat"
@@ -113,11 +114,52 @@ func (ef *NumbatNodeFacade) SendTransaction(
 	senderHex string,
 	receiverHex string,
 	value *big.Int,
+	gasPrice uint64,
+	gasLimit uint64,
+	validFromRound uint32,
+	validUntilRound uint32,
 	transactionData string,
 	signature []byte,
 ) (*transaction.Transaction, error) {
 
-	return ef.node.SendTransaction(nonce, senderHex, receiverHex, value, transactionData, signature)
+	return ef.node.SendTransaction(
+		nonce,
+		senderHex,
+		receiverHex,
+		value,
+		gasPrice,
+		gasLimit,
+		validFromRound,
+		validUntilRound,
+		transactionData,
+		signature,
+	)
+}
+
+// SendBatchTransaction will send a new batch transfer transaction on the topic channel
+func (ef *NumbatNodeFacade) SendBatchTransaction(
+	nonce uint64,
+	senderHex string,
+	receiversHex []string,
+	values []*big.Int,
+	gasPrice uint64,
+	gasLimit uint64,
+	validFromRound uint32,
+	validUntilRound uint32,
+	signature []byte,
+) (*transaction.Transaction, error) {
+
+	return ef.node.SendBatchTransaction(
+		nonce,
+		senderHex,
+		receiversHex,
+		values,
+		gasPrice,
+		gasLimit,
+		validFromRound,
+		validUntilRound,
+		signature,
+	)
 }
 
 // GetTransaction gets the transaction with a specified hash
@@ -125,12 +167,50 @@ func (ef *NumbatNodeFacade) GetTransaction(hash string) (*transaction.Transactio
 	return ef.node.GetTransaction(hash)
 }
 
+// GetTransactionStatus gets the execution result of the transaction with a specified hash
+func (ef *NumbatNodeFacade) GetTransactionStatus(hash string) (*transaction.ExecutionResult, error) {
+	return ef.node.GetTransactionStatus(hash)
+}
+
 // GetAccount returns an accountResponse containing information
 // about the account correlated with provided address
 func (ef *NumbatNodeFacade) GetAccount(address string) (*state.Account, error) {
 	return ef.node.GetAccount(address)
 }
 
+// GetAccountAtBlockNonce returns the account correlated with provided address, as it was after the committed
+// block with the provided nonce
+func (ef *NumbatNodeFacade) GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error) {
+	return ef.node.GetAccountAtBlockNonce(address, blockNonce)
+}
+
+// GetAccountAtBlockHash returns the account correlated with provided address, as it was after the committed
+// block with the provided hex encoded hash
+func (ef *NumbatNodeFacade) GetAccountAtBlockHash(address string, blockHash string) (*state.Account, error) {
+	return ef.node.GetAccountAtBlockHash(address, blockHash)
+}
+
+// GetAccountProof returns the Merkle proof of the account correlated with provided address
+func (ef *NumbatNodeFacade) GetAccountProof(address string) (*proof.AccountProof, error) {
+	return ef.node.GetAccountProof(address)
+}
+
+// GetAccountStorageValue returns the value stored for the provided key in the storage of the account correlated
+// with provided address
+func (ef *NumbatNodeFacade) GetAccountStorageValue(address string, key string) (*external.StorageValue, error) {
+	return ef.node.GetAccountStorageValue(address, key)
+}
+
+// GetAccountStorage returns a page of the storage of the account correlated with provided address
+func (ef *NumbatNodeFacade) GetAccountStorage(address string, startKey string, maxEntries int) (*external.StoragePage, error) {
+	return ef.node.GetAccountStorage(address, startKey, maxEntries)
+}
+
+// GetBlockStateDiff returns the changes made to the accounts by the committed block with the provided hex encoded hash
+func (ef *NumbatNodeFacade) GetBlockStateDiff(blockHash string) (*state.StateDiff, error) {
+	return ef.node.GetBlockStateDiff(blockHash)
+}
+
 // GetCurrentPublicKey gets the current nodes public Key
 func (ef *NumbatNodeFacade) GetCurrentPublicKey() string {
 	return ef.node.GetCurrentPublicKey()
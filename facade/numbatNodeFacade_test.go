@@ -8,8 +8,10 @@ import (
 
 	"github.com/numbatx/gn-numbat/core/logger"
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/proof"
 	"github.com/numbatx/gn-numbat/data/transaction"
 	"github.com/numbatx/gn-numbat/facade/mock"
+	"github.com/numbatx/gn-numbat/node/external"
 	"github.com/numbatx/gn-numbat/node/heartbeat"
 	"github.com/stretchr/testify/assert"
 )
@@ -372,6 +374,25 @@ func TestNumbatFacade_GetTransactionWithUnknowHashShouldReturnNilAndNoError(t *t
 	assert.Nil(t, tx)
 }
 
+func TestNumbatFacade_GetTransactionStatusShouldCallNode(t *testing.T) {
+	testHash := "testHash"
+	testResult := &transaction.ExecutionResult{Status: transaction.TxStatusExecuted}
+	node := &mock.NodeMock{
+		GetTransactionStatusHandler: func(hash string) (*transaction.ExecutionResult, error) {
+			if hash == testHash {
+				return testResult, nil
+			}
+			return nil, nil
+		},
+	}
+
+	ef := createNumbatNodeFacadeWithMockResolver(node)
+
+	result, err := ef.GetTransactionStatus(testHash)
+	assert.Nil(t, err)
+	assert.Equal(t, testResult, result)
+}
+
 func TestNumbatNodeFacade_SetLogger(t *testing.T) {
 	node := &mock.NodeMock{}
 
@@ -393,12 +414,24 @@ func TestNumbatNodeFacade_SetSyncer(t *testing.T) {
 func TestNumbatNodeFacade_SendTransaction(t *testing.T) {
 	called := 0
 	node := &mock.NodeMock{}
-	node.SendTransactionHandler = func(nonce uint64, sender string, receiver string, amount *big.Int, code string, signature []byte) (i *transaction.Transaction, e error) {
+	node.SendTransactionHandler = func(nonce uint64, sender string, receiver string, amount *big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, code string, signature []byte) (i *transaction.Transaction, e error) {
+		called++
+		return nil, nil
+	}
+	ef := createNumbatNodeFacadeWithMockResolver(node)
+	ef.SendTransaction(1, "test", "test", big.NewInt(0), 0, 0, 0, 0, "code", []byte{})
+	assert.Equal(t, called, 1)
+}
+
+func TestNumbatNodeFacade_SendBatchTransaction(t *testing.T) {
+	called := 0
+	node := &mock.NodeMock{}
+	node.SendBatchTransactionHandler = func(nonce uint64, sender string, receivers []string, values []*big.Int, gasPrice uint64, gasLimit uint64, validFromRound uint32, validUntilRound uint32, signature []byte) (i *transaction.Transaction, e error) {
 		called++
 		return nil, nil
 	}
 	ef := createNumbatNodeFacadeWithMockResolver(node)
-	ef.SendTransaction(1, "test", "test", big.NewInt(0), "code", []byte{})
+	ef.SendBatchTransaction(1, "test", []string{"test"}, []*big.Int{big.NewInt(0)}, 0, 0, 0, 0, []byte{})
 	assert.Equal(t, called, 1)
 }
 
@@ -414,6 +447,78 @@ func TestNumbatNodeFacade_GetAccount(t *testing.T) {
 	assert.Equal(t, called, 1)
 }
 
+func TestNumbatNodeFacade_GetAccountProof(t *testing.T) {
+	called := 0
+	node := &mock.NodeMock{}
+	node.GetAccountProofHandler = func(address string) (*proof.AccountProof, error) {
+		called++
+		return nil, nil
+	}
+	ef := createNumbatNodeFacadeWithMockResolver(node)
+	ef.GetAccountProof("test")
+	assert.Equal(t, called, 1)
+}
+
+func TestNumbatNodeFacade_GetAccountStorageValue(t *testing.T) {
+	called := 0
+	node := &mock.NodeMock{}
+	node.GetAccountStorageValueHandler = func(address string, key string) (*external.StorageValue, error) {
+		called++
+		return nil, nil
+	}
+	ef := createNumbatNodeFacadeWithMockResolver(node)
+	ef.GetAccountStorageValue("test", "aabb")
+	assert.Equal(t, called, 1)
+}
+
+func TestNumbatNodeFacade_GetAccountStorage(t *testing.T) {
+	called := 0
+	node := &mock.NodeMock{}
+	node.GetAccountStorageHandler = func(address string, startKey string, maxEntries int) (*external.StoragePage, error) {
+		called++
+		return nil, nil
+	}
+	ef := createNumbatNodeFacadeWithMockResolver(node)
+	ef.GetAccountStorage("test", "", 10)
+	assert.Equal(t, called, 1)
+}
+
+func TestNumbatNodeFacade_GetBlockStateDiff(t *testing.T) {
+	called := 0
+	node := &mock.NodeMock{}
+	node.GetBlockStateDiffHandler = func(blockHash string) (*state.StateDiff, error) {
+		called++
+		return nil, nil
+	}
+	ef := createNumbatNodeFacadeWithMockResolver(node)
+	ef.GetBlockStateDiff("aabb")
+	assert.Equal(t, called, 1)
+}
+
+func TestNumbatNodeFacade_GetAccountAtBlockNonce(t *testing.T) {
+	called := 0
+	node := &mock.NodeMock{}
+	node.GetAccountAtBlockNonceHandler = func(address string, blockNonce uint64) (*state.Account, error) {
+		called++
+		return nil, nil
+	}
+	ef := createNumbatNodeFacadeWithMockResolver(node)
+	ef.GetAccountAtBlockNonce("test", 3)
+	assert.Equal(t, called, 1)
+}
+
+func TestNumbatNodeFacade_GetAccountAtBlockHash(t *testing.T) {
+	called := 0
+	node := &mock.NodeMock{}
+	node.GetAccountAtBlockHashHandler = func(address string, blockHash string) (*state.Account, error) {
+		called++
+		return nil, nil
+	}
+	ef := createNumbatNodeFacadeWithMockResolver(node)
+	ef.GetAccountAtBlockHash("test", "aabb")
+	assert.Equal(t, called, 1)
+}
+
 func TestNumbatNodeFacade_GetCurrentPublicKey(t *testing.T) {
 	called := 0
 	node := &mock.NodeMock{}
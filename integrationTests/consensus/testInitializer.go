@@ -27,7 +27,7 @@ import (
 	"github.com/numbatx/gn-numbat/data/typeConverters/uint64ByteSlice"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/dataRetriever/dataPool"
-	"github.com/numbatx/gn-numbat/dataRetriever/shardedData"
+	"github.com/numbatx/gn-numbat/dataRetriever/txpool"
 	"github.com/numbatx/gn-numbat/hashing"
 	"github.com/numbatx/gn-numbat/hashing/blake2b"
 	"github.com/numbatx/gn-numbat/hashing/sha256"
@@ -138,17 +138,24 @@ func createMemUnit() storage.Storer {
 func createTestStore() dataRetriever.StorageService {
 	store := dataRetriever.NewChainStorer()
 	store.AddStorer(dataRetriever.TransactionUnit, createMemUnit())
+	store.AddStorer(dataRetriever.TransactionResultUnit, createMemUnit())
+	store.AddStorer(dataRetriever.ShardHdrNonceHashDataUnitFor(0), createMemUnit())
+	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MiniBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.PeerChangesUnit, createMemUnit())
 	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
+	store.AddStorer(dataRetriever.AccountsTrieUnit, createMemUnit())
+	store.AddStorer(dataRetriever.StateDiffUnit, createMemUnit())
+	store.AddStorer(dataRetriever.BootstrapUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaShardDataUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaPeerDataUnit, createMemUnit())
 	return store
 }
 
 func createTestShardDataPool() dataRetriever.PoolsHolder {
-	txPool, _ := shardedData.NewShardedData(storage.CacheConfig{Size: 100000, Type: storage.LRUCache})
+	requestedTxs, _ := storage.NewCache(storage.LRUCache, 100000, 1)
+	txPool, _ := txpool.NewShardedTxPool(100000, 100000, 10, requestedTxs)
 	cacherCfg := storage.CacheConfig{Size: 100, Type: storage.LRUCache}
 	hdrPool, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
@@ -167,7 +174,8 @@ func createTestShardDataPool() dataRetriever.PoolsHolder {
 	metaHdrNonces, _ := dataPool.NewNonceToHashCacher(metaHdrNoncesCacher, uint64ByteSlice.NewBigEndianConverter())
 	metaBlocks, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
-	cacherCfg = storage.CacheConfig{Size: 10, Type: storage.LRUCache}
+	cacherCfg = storage.CacheConfig{Size: 100000, Type: storage.LRUCache}
+	trieNodes, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
 	dPool, _ := dataPool.NewShardedDataPool(
 		txPool,
@@ -177,6 +185,7 @@ func createTestShardDataPool() dataRetriever.PoolsHolder {
 		peerChangeBlockBody,
 		metaBlocks,
 		metaHdrNonces,
+		trieNodes,
 	)
 
 	return dPool
@@ -184,7 +193,7 @@ func createTestShardDataPool() dataRetriever.PoolsHolder {
 
 func createAccountsDB(marshalizer marshal.Marshalizer) state.AccountsAdapter {
 	dbw, _ := trie.NewDBWriteCache(createMemUnit())
-	tr, _ := trie.NewTrie(make([]byte, 32), dbw, sha256.Sha256{})
+	tr, _ := trie.NewStateTrie(dbw, sha256.Sha256{})
 	adb, _ := state.NewAccountsDB(tr, sha256.Sha256{}, marshalizer, &mock.AccountsFactoryStub{
 		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (wrapper state.AccountHandler, e error) {
 			return state.NewAccount(address, tracker)
@@ -356,6 +365,7 @@ func createConsensusOnlyNode(
 		node.WithBlockProcessor(blockProcessor),
 		node.WithDataPool(createTestShardDataPool()),
 		node.WithDataStore(createTestStore()),
+		node.WithUint64ByteSliceConverter(uint64ByteSlice.NewBigEndianConverter()),
 		node.WithResolversFinder(resolverFinder),
 		node.WithConsensusType(consensusType),
 		node.WithBlockTracker(blockTracker),
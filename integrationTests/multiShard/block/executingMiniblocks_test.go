@@ -1,10 +1,12 @@
 package block
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"sort"
 	"testing"
 	"time"
 
@@ -105,7 +107,7 @@ func TestShouldProcessBlocksInMultiShardArchitecture(t *testing.T) {
 			err := n.blkProcessor.ProcessBlock(
 				n.blkc,
 				n.headers[0],
-				block.Body(n.miniblocks),
+				miniBlocksInHeaderOrder(n),
 				func() time.Duration {
 					//fair enough to process a few transactions
 					return time.Second * 2
@@ -184,7 +186,7 @@ func TestShouldProcessBlocksInMultiShardArchitecture(t *testing.T) {
 				err := n.blkProcessor.ProcessBlock(
 					n.blkc,
 					n.headers[0],
-					block.Body(n.miniblocks),
+					miniBlocksInHeaderOrder(n),
 					func() time.Duration {
 						// time 5 seconds as they have to request from leader the TXs
 						return time.Second * 5
@@ -196,7 +198,7 @@ func TestShouldProcessBlocksInMultiShardArchitecture(t *testing.T) {
 					return
 				}
 
-				err = n.blkProcessor.CommitBlock(n.blkc, n.headers[0], block.Body(n.miniblocks))
+				err = n.blkProcessor.CommitBlock(n.blkc, n.headers[0], miniBlocksInHeaderOrder(n))
 			}
 		}
 	}
@@ -222,6 +224,27 @@ func TestShouldProcessBlocksInMultiShardArchitecture(t *testing.T) {
 
 }
 
+// miniBlocksInHeaderOrder returns the received miniblocks in the order they are referenced by the received header,
+// which is the order in which they have to be executed
+func miniBlocksInHeaderOrder(n *testNode) block.Body {
+	hdr, ok := n.headers[0].(*block.Header)
+	if !ok {
+		return block.Body(n.miniblocks)
+	}
+
+	body := make(block.Body, 0, len(n.miniblocks))
+	for _, mbHeader := range hdr.MiniBlockHeaders {
+		for i, mbHash := range n.miniblocksHashes {
+			if bytes.Equal(mbHash, mbHeader.Hash) {
+				body = append(body, n.miniblocks[i])
+				break
+			}
+		}
+	}
+
+	return body
+}
+
 func generateAndDisseminateTxs(
 	n *node.Node,
 	senders []crypto.PrivateKey,
@@ -229,17 +252,31 @@ func generateAndDisseminateTxs(
 	valToTransfer *big.Int,
 ) {
 
+	//the transactions of a sender are generated in ascending order of the receiver shards, so that increasing
+	//nonces will be found in the miniblocks in the same order they are executed
+	shardIds := make([]uint32, 0, len(receiversPrivateKeys))
+	for shardId := range receiversPrivateKeys {
+		shardIds = append(shardIds, shardId)
+	}
+	sort.Slice(shardIds, func(i, j int) bool {
+		return shardIds[i] < shardIds[j]
+	})
+
 	for i := 0; i < len(senders); i++ {
 		senderKey := senders[i]
 		incrementalNonce := uint64(0)
-		for _, recvPrivateKeys := range receiversPrivateKeys {
-			receiverKey := recvPrivateKeys[i]
+		for _, shardId := range shardIds {
+			receiverKey := receiversPrivateKeys[shardId][i]
 			tx := generateTransferTx(incrementalNonce, senderKey, receiverKey, valToTransfer)
 			n.SendTransaction(
 				tx.Nonce,
 				hex.EncodeToString(tx.SndAddr),
 				hex.EncodeToString(tx.RcvAddr),
 				tx.Value,
+				tx.GasPrice,
+				tx.GasLimit,
+				tx.ValidFromRound,
+				tx.ValidUntilRound,
 				string(tx.Data),
 				tx.Signature,
 			)
@@ -260,6 +297,8 @@ func generateTransferTx(
 		RcvAddr: skToPk(receiver),
 		SndAddr: skToPk(sender),
 		Data:    make([]byte, 0),
+		ChainID: testChainID,
+		Version: transaction.CurrentVersion,
 	}
 	txBuff, _ := testMarshalizer.Marshal(&tx)
 	signer := &singlesig.SchnorrSigner{}
@@ -281,16 +320,14 @@ func createMintingForSenders(
 ) {
 
 	for _, n := range nodes {
-		//only sender shard nodes will be minted
-		if n.shardId != senderShard {
-			continue
-		}
-
-		for _, sk := range sendersPrivateKeys {
-			pkBuff, _ := sk.GeneratePublic().ToByteArray()
-			adr, _ := testAddressConverter.CreateAddressFromPublicKeyBytes(pkBuff)
-			account, _ := n.accntState.GetAccountWithJournal(adr)
-			account.(*state.Account).SetBalanceWithJournal(value)
+		//only sender shard nodes will be minted, all of them commit their genesis state
+		if n.shardId == senderShard {
+			for _, sk := range sendersPrivateKeys {
+				pkBuff, _ := sk.GeneratePublic().ToByteArray()
+				adr, _ := testAddressConverter.CreateAddressFromPublicKeyBytes(pkBuff)
+				account, _ := n.accntState.GetAccountWithJournal(adr)
+				account.(*state.Account).SetBalanceWithJournal(value)
+			}
 		}
 
 		n.accntState.Commit()
@@ -34,6 +34,7 @@ import (
 	factoryDataRetriever "github.com/numbatx/gn-numbat/dataRetriever/factory/shard"
 	"github.com/numbatx/gn-numbat/dataRetriever/resolvers"
 	"github.com/numbatx/gn-numbat/dataRetriever/shardedData"
+	"github.com/numbatx/gn-numbat/dataRetriever/txpool"
 	"github.com/numbatx/gn-numbat/display"
 	"github.com/numbatx/gn-numbat/hashing/sha256"
 	"github.com/numbatx/gn-numbat/integrationTests/mock"
@@ -45,12 +46,14 @@ import (
 	"github.com/numbatx/gn-numbat/p2p/loadBalancer"
 	"github.com/numbatx/gn-numbat/process"
 	"github.com/numbatx/gn-numbat/process/block"
+	"github.com/numbatx/gn-numbat/process/economics"
 	"github.com/numbatx/gn-numbat/process/factory"
 	metaProcess "github.com/numbatx/gn-numbat/process/factory/metachain"
 	"github.com/numbatx/gn-numbat/process/factory/shard"
 	"github.com/numbatx/gn-numbat/process/transaction"
 	"github.com/numbatx/gn-numbat/sharding"
 	"github.com/numbatx/gn-numbat/storage"
+	"github.com/numbatx/gn-numbat/storage/lrucache"
 	"github.com/numbatx/gn-numbat/storage/memorydb"
 )
 
@@ -59,6 +62,7 @@ var testHasher = sha256.Sha256{}
 var testMarshalizer = &marshal.JsonMarshalizer{}
 var testAddressConverter, _ = addressConverters.NewPlainAddressConverter(32, "0x")
 var testMultiSig = mock.NewMultiSigner(1)
+var testChainID = []byte("integration tests chain ID")
 var rootHash = []byte("root hash")
 
 func init() {
@@ -107,19 +111,27 @@ func createMemUnit() storage.Storer {
 	return unit
 }
 
-func createTestShardStore() dataRetriever.StorageService {
+func createTestShardStore(shardCoordinator sharding.Coordinator) dataRetriever.StorageService {
 	store := dataRetriever.NewChainStorer()
 	store.AddStorer(dataRetriever.TransactionUnit, createMemUnit())
+	store.AddStorer(dataRetriever.TransactionResultUnit, createMemUnit())
+	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(shardCoordinator.SelfId())
+	store.AddStorer(hdrNonceHashDataUnit, createMemUnit())
+	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MiniBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.PeerChangesUnit, createMemUnit())
 	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
+	store.AddStorer(dataRetriever.AccountsTrieUnit, createMemUnit())
+	store.AddStorer(dataRetriever.StateDiffUnit, createMemUnit())
+	store.AddStorer(dataRetriever.BootstrapUnit, createMemUnit())
 
 	return store
 }
 
 func createTestShardDataPool() dataRetriever.PoolsHolder {
-	txPool, _ := shardedData.NewShardedData(storage.CacheConfig{Size: 100000, Type: storage.LRUCache})
+	requestedTxs, _ := storage.NewCache(storage.LRUCache, 100000, 1)
+	txPool, _ := txpool.NewShardedTxPool(100000, 100000, 10, requestedTxs)
 	cacherCfg := storage.CacheConfig{Size: 100, Type: storage.LRUCache}
 	hdrPool, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
@@ -138,7 +150,8 @@ func createTestShardDataPool() dataRetriever.PoolsHolder {
 	metaHdrNonces, _ := dataPool.NewNonceToHashCacher(metaHdrNoncesCacher, uint64ByteSlice.NewBigEndianConverter())
 	metaBlocks, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
-	cacherCfg = storage.CacheConfig{Size: 10, Type: storage.LRUCache}
+	cacherCfg = storage.CacheConfig{Size: 100000, Type: storage.LRUCache}
+	trieNodes, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
 	dPool, _ := dataPool.NewShardedDataPool(
 		txPool,
@@ -148,6 +161,7 @@ func createTestShardDataPool() dataRetriever.PoolsHolder {
 		peerChangeBlockBody,
 		metaBlocks,
 		metaHdrNonces,
+		trieNodes,
 	)
 
 	return dPool
@@ -155,7 +169,7 @@ func createTestShardDataPool() dataRetriever.PoolsHolder {
 
 func createAccountsDB() *state.AccountsDB {
 	dbw, _ := trie.NewDBWriteCache(createMemUnit())
-	tr, _ := trie.NewTrie(make([]byte, 32), dbw, sha256.Sha256{})
+	tr, _ := trie.NewStateTrie(dbw, sha256.Sha256{})
 	adb, _ := state.NewAccountsDB(tr, sha256.Sha256{}, testMarshalizer, &mock.AccountsFactoryStub{
 		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (wrapper state.AccountHandler, e error) {
 			return state.NewAccount(address, tracker)
@@ -197,10 +211,12 @@ func createNetNode(
 	fmt.Printf("Found pk: %s\n", hex.EncodeToString(pkBuff))
 
 	blkc := createTestShardChain()
-	store := createTestShardStore()
+	store := createTestShardStore(shardCoordinator)
 	uint64Converter := uint64ByteSlice.NewBigEndianConverter()
 	dataPacker, _ := partitioning.NewSizeDataPacker(testMarshalizer)
 
+	requestedTxs, _ := lrucache.NewCache(1000)
+
 	interceptorContainerFactory, _ := shard.NewInterceptorsContainerFactory(
 		shardCoordinator,
 		messenger,
@@ -213,6 +229,9 @@ func createNetNode(
 		dPool,
 		testAddressConverter,
 		&mock.ChronologyValidatorMock{},
+		&mock.RounderMock{},
+		requestedTxs,
+		testChainID,
 		nil,
 	)
 	interceptorsContainer, err := interceptorContainerFactory.Create()
@@ -231,12 +250,16 @@ func createNetNode(
 	)
 	resolversContainer, _ := resolversContainerFactory.Create()
 	resolversFinder, _ := containers.NewResolversFinder(resolversContainer, shardCoordinator)
+	feeHandler := economics.NewFeeHandler(0, 0, 0)
+	//the last byte of the address selects its shard, so the rewards address is in the shard of the node
+	rewardsAddress, _ := testAddressConverter.CreateAddressFromHex(fmt.Sprintf("%s%02x", strings.Repeat("0", 62), shardCoordinator.SelfId()))
 	txProcessor, _ := transaction.NewTxProcessor(
 		accntAdapter,
 		testHasher,
 		testAddressConverter,
 		testMarshalizer,
 		shardCoordinator,
+		feeHandler,
 	)
 
 	blockProcessor, _ := block.NewShardProcessor(
@@ -286,6 +309,10 @@ func createNetNode(
 				fmt.Println(err.Error())
 			}
 		},
+		feeHandler,
+		rewardsAddress,
+		15000,
+		1000000000,
 	)
 
 	n, err := node.NewNode(
@@ -297,6 +324,7 @@ func createNetNode(
 		node.WithAccountsAdapter(accntAdapter),
 		node.WithKeyGen(keyGen),
 		node.WithShardCoordinator(shardCoordinator),
+		node.WithChainID(testChainID),
 		node.WithBlockChain(blkc),
 		node.WithUint64ByteSliceConverter(uint64Converter),
 		node.WithMultiSigner(testMultiSig),
@@ -555,12 +583,18 @@ func createTestMetaChain() data.ChainHandler {
 	return metaChain
 }
 
-func createTestMetaStore() dataRetriever.StorageService {
+func createTestMetaStore(shardCoordinator sharding.Coordinator) dataRetriever.StorageService {
 	store := dataRetriever.NewChainStorer()
 	store.AddStorer(dataRetriever.MetaBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaPeerDataUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaShardDataUnit, createMemUnit())
 	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
+	store.AddStorer(dataRetriever.AccountsTrieUnit, createMemUnit())
+	store.AddStorer(dataRetriever.BootstrapUnit, createMemUnit())
+	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, createMemUnit())
+	for i := uint32(0); i < shardCoordinator.NumberOfShards(); i++ {
+		store.AddStorer(dataRetriever.ShardHdrNonceHashDataUnitFor(i), createMemUnit())
+	}
 
 	return store
 }
@@ -608,7 +642,7 @@ func createMetaNetNode(
 	fmt.Printf("Found pk: %s\n", hex.EncodeToString(pkBuff))
 
 	tn.blkc = createTestMetaChain()
-	store := createTestMetaStore()
+	store := createTestMetaStore(shardCoordinator)
 	uint64Converter := uint64ByteSlice.NewBigEndianConverter()
 
 	interceptorContainerFactory, _ := metaProcess.NewInterceptorsContainerFactory(
@@ -31,6 +31,7 @@ import (
 	metafactoryDataRetriever "github.com/numbatx/gn-numbat/dataRetriever/factory/metachain"
 	shard2 "github.com/numbatx/gn-numbat/dataRetriever/factory/shard"
 	"github.com/numbatx/gn-numbat/dataRetriever/shardedData"
+	"github.com/numbatx/gn-numbat/dataRetriever/txpool"
 	"github.com/numbatx/gn-numbat/display"
 	"github.com/numbatx/gn-numbat/hashing/sha256"
 	"github.com/numbatx/gn-numbat/integrationTests/mock"
@@ -48,6 +49,7 @@ import (
 	mock2 "github.com/numbatx/gn-numbat/process/mock"
 	"github.com/numbatx/gn-numbat/sharding"
 	"github.com/numbatx/gn-numbat/storage"
+	"github.com/numbatx/gn-numbat/storage/lrucache"
 	"github.com/numbatx/gn-numbat/storage/memorydb"
 )
 
@@ -56,6 +58,7 @@ var testHasher = sha256.Sha256{}
 var testMarshalizer = &marshal.JsonMarshalizer{}
 var testAddressConverter, _ = addressConverters.NewPlainAddressConverter(32, "0x")
 var testMultiSig = mock.NewMultiSigner(1)
+var testChainID = []byte("integration tests chain ID")
 
 func init() {
 	r = rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -87,7 +90,7 @@ func createMemUnit() storage.Storer {
 
 func createAccountsDB() *state.AccountsDB {
 	dbw, _ := trie.NewDBWriteCache(createMemUnit())
-	tr, _ := trie.NewTrie(make([]byte, 32), dbw, sha256.Sha256{})
+	tr, _ := trie.NewStateTrie(dbw, sha256.Sha256{})
 	adb, _ := state.NewAccountsDB(tr, sha256.Sha256{}, testMarshalizer, &mock.AccountsFactoryStub{
 		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (wrapper state.AccountHandler, e error) {
 			return state.NewAccount(address, tracker)
@@ -203,19 +206,27 @@ func createTestShardChain() *blockchain.BlockChain {
 	return blockChain
 }
 
-func createTestShardStore() dataRetriever.StorageService {
+func createTestShardStore(shardCoordinator sharding.Coordinator) dataRetriever.StorageService {
 	store := dataRetriever.NewChainStorer()
 	store.AddStorer(dataRetriever.TransactionUnit, createMemUnit())
+	store.AddStorer(dataRetriever.TransactionResultUnit, createMemUnit())
+	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(shardCoordinator.SelfId())
+	store.AddStorer(hdrNonceHashDataUnit, createMemUnit())
+	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MiniBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.PeerChangesUnit, createMemUnit())
 	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
+	store.AddStorer(dataRetriever.AccountsTrieUnit, createMemUnit())
+	store.AddStorer(dataRetriever.StateDiffUnit, createMemUnit())
+	store.AddStorer(dataRetriever.BootstrapUnit, createMemUnit())
 
 	return store
 }
 
 func createTestShardDataPool() dataRetriever.PoolsHolder {
-	txPool, _ := shardedData.NewShardedData(storage.CacheConfig{Size: 100000, Type: storage.LRUCache})
+	requestedTxs, _ := storage.NewCache(storage.LRUCache, 100000, 1)
+	txPool, _ := txpool.NewShardedTxPool(100000, 100000, 10, requestedTxs)
 	cacherCfg := storage.CacheConfig{Size: 100, Type: storage.LRUCache}
 	hdrPool, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
@@ -234,7 +245,8 @@ func createTestShardDataPool() dataRetriever.PoolsHolder {
 	metaHdrNonces, _ := dataPool.NewNonceToHashCacher(metaHdrNoncesCacher, uint64ByteSlice.NewBigEndianConverter())
 	metaBlocks, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
-	cacherCfg = storage.CacheConfig{Size: 10, Type: storage.LRUCache}
+	cacherCfg = storage.CacheConfig{Size: 100000, Type: storage.LRUCache}
+	trieNodes, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
 	dPool, _ := dataPool.NewShardedDataPool(
 		txPool,
@@ -244,6 +256,7 @@ func createTestShardDataPool() dataRetriever.PoolsHolder {
 		peerChangeBlockBody,
 		metaBlocks,
 		metaHdrNonces,
+		trieNodes,
 	)
 
 	return dPool
@@ -268,12 +281,14 @@ func createShardNetNode(
 	fmt.Printf("Found pk: %s\n", hex.EncodeToString(pkBuff))
 
 	blkc := createTestShardChain()
-	store := createTestShardStore()
+	store := createTestShardStore(shardCoordinator)
 	uint64Converter := uint64ByteSlice.NewBigEndianConverter()
 	tpsBenchmark, _ := statistics.NewTPSBenchmark(1, uint64(time.Second*4))
 	addConverter, _ := addressConverters.NewPlainAddressConverter(32, "")
 	dataPacker, _ := partitioning.NewSizeDataPacker(testMarshalizer)
 
+	requestedTxs, _ := lrucache.NewCache(1000)
+
 	interceptorContainerFactory, _ := shard.NewInterceptorsContainerFactory(
 		shardCoordinator,
 		tn.messenger,
@@ -286,6 +301,9 @@ func createShardNetNode(
 		dPool,
 		addConverter,
 		&mock.ChronologyValidatorMock{},
+		&mock.RounderMock{},
+		requestedTxs,
+		testChainID,
 		tpsBenchmark,
 	)
 	interceptorsContainer, err := interceptorContainerFactory.Create()
@@ -334,6 +352,10 @@ func createShardNetNode(
 		func(shardId uint32, miniblockHash []byte) {
 
 		},
+		&mock2.FeeHandlerStub{},
+		mock2.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	n, err := node.NewNode(
@@ -345,6 +367,7 @@ func createShardNetNode(
 		node.WithAccountsAdapter(accntAdapter),
 		node.WithKeyGen(keyGen),
 		node.WithShardCoordinator(shardCoordinator),
+		node.WithChainID(testChainID),
 		node.WithBlockChain(blkc),
 		node.WithUint64ByteSliceConverter(uint64Converter),
 		node.WithMultiSigner(testMultiSig),
@@ -390,12 +413,18 @@ func createTestMetaChain() data.ChainHandler {
 	return metaChain
 }
 
-func createTestMetaStore() dataRetriever.StorageService {
+func createTestMetaStore(shardCoordinator sharding.Coordinator) dataRetriever.StorageService {
 	store := dataRetriever.NewChainStorer()
 	store.AddStorer(dataRetriever.MetaBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaPeerDataUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaShardDataUnit, createMemUnit())
 	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
+	store.AddStorer(dataRetriever.AccountsTrieUnit, createMemUnit())
+	store.AddStorer(dataRetriever.BootstrapUnit, createMemUnit())
+	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, createMemUnit())
+	for i := uint32(0); i < shardCoordinator.NumberOfShards(); i++ {
+		store.AddStorer(dataRetriever.ShardHdrNonceHashDataUnitFor(i), createMemUnit())
+	}
 
 	return store
 }
@@ -443,7 +472,7 @@ func createMetaNetNode(
 	fmt.Printf("Found pk: %s\n", hex.EncodeToString(pkBuff))
 
 	blkc := createTestMetaChain()
-	store := createTestMetaStore()
+	store := createTestMetaStore(shardCoordinator)
 	uint64Converter := uint64ByteSlice.NewBigEndianConverter()
 
 	interceptorContainerFactory, _ := metaProcess.NewInterceptorsContainerFactory(
@@ -28,7 +28,7 @@ import (
 	"github.com/numbatx/gn-numbat/dataRetriever/dataPool"
 	"github.com/numbatx/gn-numbat/dataRetriever/factory/containers"
 	factoryDataRetriever "github.com/numbatx/gn-numbat/dataRetriever/factory/shard"
-	"github.com/numbatx/gn-numbat/dataRetriever/shardedData"
+	"github.com/numbatx/gn-numbat/dataRetriever/txpool"
 	"github.com/numbatx/gn-numbat/display"
 	"github.com/numbatx/gn-numbat/hashing"
 	"github.com/numbatx/gn-numbat/hashing/sha256"
@@ -43,10 +43,12 @@ import (
 	"github.com/numbatx/gn-numbat/process/factory/shard"
 	"github.com/numbatx/gn-numbat/sharding"
 	"github.com/numbatx/gn-numbat/storage"
+	"github.com/numbatx/gn-numbat/storage/lrucache"
 	"github.com/numbatx/gn-numbat/storage/memorydb"
 )
 
 var r *rand.Rand
+var chainID = []byte("integration tests chain ID")
 
 func init() {
 	r = rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -82,19 +84,27 @@ func createMemUnit() storage.Storer {
 	return unit
 }
 
-func createTestStore() dataRetriever.StorageService {
+func createTestStore(shardCoordinator sharding.Coordinator) dataRetriever.StorageService {
 	store := dataRetriever.NewChainStorer()
 	store.AddStorer(dataRetriever.TransactionUnit, createMemUnit())
+	store.AddStorer(dataRetriever.TransactionResultUnit, createMemUnit())
+	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(shardCoordinator.SelfId())
+	store.AddStorer(hdrNonceHashDataUnit, createMemUnit())
+	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MiniBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.PeerChangesUnit, createMemUnit())
 	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
+	store.AddStorer(dataRetriever.AccountsTrieUnit, createMemUnit())
+	store.AddStorer(dataRetriever.StateDiffUnit, createMemUnit())
+	store.AddStorer(dataRetriever.BootstrapUnit, createMemUnit())
 
 	return store
 }
 
 func createTestDataPool() dataRetriever.PoolsHolder {
-	txPool, _ := shardedData.NewShardedData(storage.CacheConfig{Size: 100000, Type: storage.LRUCache})
+	requestedTxs, _ := storage.NewCache(storage.LRUCache, 100000, 1)
+	txPool, _ := txpool.NewShardedTxPool(100000, 100000, 10, requestedTxs)
 	cacherCfg := storage.CacheConfig{Size: 100, Type: storage.LRUCache}
 	hdrPool, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
@@ -111,7 +121,8 @@ func createTestDataPool() dataRetriever.PoolsHolder {
 	metaHdrNoncesCacher, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 	metaHdrNonces, _ := dataPool.NewNonceToHashCacher(metaHdrNoncesCacher, uint64ByteSlice.NewBigEndianConverter())
 	metaBlocks, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
-	cacherCfg = storage.CacheConfig{Size: 10, Type: storage.LRUCache}
+	cacherCfg = storage.CacheConfig{Size: 100000, Type: storage.LRUCache}
+	trieNodes, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
 	dPool, _ := dataPool.NewShardedDataPool(
 		txPool,
@@ -121,6 +132,7 @@ func createTestDataPool() dataRetriever.PoolsHolder {
 		peerChangeBlockBody,
 		metaBlocks,
 		metaHdrNonces,
+		trieNodes,
 	)
 
 	return dPool
@@ -158,7 +170,7 @@ func createDummyHexAddressInShard(
 func createAccountsDB() *state.AccountsDB {
 	marsh := &marshal.JsonMarshalizer{}
 	dbw, _ := trie.NewDBWriteCache(createMemUnit())
-	tr, _ := trie.NewTrie(make([]byte, 32), dbw, sha256.Sha256{})
+	tr, _ := trie.NewStateTrie(dbw, sha256.Sha256{})
 	adb, _ := state.NewAccountsDB(tr, sha256.Sha256{}, marsh, &mock.AccountsFactoryStub{
 		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (wrapper state.AccountHandler, e error) {
 			return state.NewAccount(address, tracker)
@@ -220,10 +232,12 @@ func createNetNode(
 
 	multiSigner, _ := createMultiSigner(sk, pk, keyGen, hasher)
 	blkc := createTestBlockChain()
-	store := createTestStore()
+	store := createTestStore(shardCoordinator)
 	uint64Converter := uint64ByteSlice.NewBigEndianConverter()
 	dataPacker, _ := partitioning.NewSizeDataPacker(marshalizer)
 
+	requestedTxs, _ := lrucache.NewCache(1000)
+
 	interceptorContainerFactory, _ := shard.NewInterceptorsContainerFactory(
 		shardCoordinator,
 		messenger,
@@ -236,6 +250,9 @@ func createNetNode(
 		dPool,
 		addrConverter,
 		&mock.ChronologyValidatorMock{},
+		&mock.RounderMock{},
+		requestedTxs,
+		chainID,
 		nil,
 	)
 	interceptorsContainer, _ := interceptorContainerFactory.Create()
@@ -261,6 +278,7 @@ func createNetNode(
 		node.WithAccountsAdapter(accntAdapter),
 		node.WithKeyGen(keyGen),
 		node.WithShardCoordinator(shardCoordinator),
+		node.WithChainID(chainID),
 		node.WithBlockChain(blkc),
 		node.WithUint64ByteSliceConverter(uint64Converter),
 		node.WithMultiSigner(multiSigner),
@@ -27,7 +27,7 @@ import (
 	"github.com/numbatx/gn-numbat/dataRetriever/dataPool"
 	"github.com/numbatx/gn-numbat/dataRetriever/factory/containers"
 	factoryDataRetriever "github.com/numbatx/gn-numbat/dataRetriever/factory/shard"
-	"github.com/numbatx/gn-numbat/dataRetriever/shardedData"
+	"github.com/numbatx/gn-numbat/dataRetriever/txpool"
 	"github.com/numbatx/gn-numbat/hashing"
 	"github.com/numbatx/gn-numbat/hashing/sha256"
 	"github.com/numbatx/gn-numbat/integrationTests/mock"
@@ -40,10 +40,12 @@ import (
 	"github.com/numbatx/gn-numbat/process/factory/shard"
 	"github.com/numbatx/gn-numbat/sharding"
 	"github.com/numbatx/gn-numbat/storage"
+	"github.com/numbatx/gn-numbat/storage/lrucache"
 	"github.com/numbatx/gn-numbat/storage/memorydb"
 )
 
 var r io.Reader
+var chainID = []byte("integration tests chain ID")
 
 func init() {
 	r = rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -60,13 +62,20 @@ func createTestBlockChain() data.ChainHandler {
 	return blockChain
 }
 
-func createTestStore() dataRetriever.StorageService {
+func createTestStore(shardCoordinator sharding.Coordinator) dataRetriever.StorageService {
 	store := dataRetriever.NewChainStorer()
 	store.AddStorer(dataRetriever.TransactionUnit, createMemUnit())
+	store.AddStorer(dataRetriever.TransactionResultUnit, createMemUnit())
+	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(shardCoordinator.SelfId())
+	store.AddStorer(hdrNonceHashDataUnit, createMemUnit())
+	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MiniBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.MetaBlockUnit, createMemUnit())
 	store.AddStorer(dataRetriever.PeerChangesUnit, createMemUnit())
 	store.AddStorer(dataRetriever.BlockHeaderUnit, createMemUnit())
+	store.AddStorer(dataRetriever.AccountsTrieUnit, createMemUnit())
+	store.AddStorer(dataRetriever.StateDiffUnit, createMemUnit())
+	store.AddStorer(dataRetriever.BootstrapUnit, createMemUnit())
 
 	return store
 }
@@ -80,7 +89,8 @@ func createMemUnit() storage.Storer {
 }
 
 func createTestDataPool() dataRetriever.PoolsHolder {
-	txPool, _ := shardedData.NewShardedData(storage.CacheConfig{Size: 100, Type: storage.LRUCache})
+	requestedTxs, _ := storage.NewCache(storage.LRUCache, 100, 1)
+	txPool, _ := txpool.NewShardedTxPool(100, 100, 10, requestedTxs)
 	cacherCfg := storage.CacheConfig{Size: 100, Type: storage.LRUCache}
 	hdrPool, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
@@ -99,7 +109,8 @@ func createTestDataPool() dataRetriever.PoolsHolder {
 	metaHdrNonces, _ := dataPool.NewNonceToHashCacher(metaHdrNoncesCacher, uint64ByteSlice.NewBigEndianConverter())
 	metaBlocks, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
-	cacherCfg = storage.CacheConfig{Size: 10, Type: storage.LRUCache}
+	cacherCfg = storage.CacheConfig{Size: 100000, Type: storage.LRUCache}
+	trieNodes, _ := storage.NewCache(cacherCfg.Type, cacherCfg.Size, cacherCfg.Shards)
 
 	dPool, _ := dataPool.NewShardedDataPool(
 		txPool,
@@ -109,6 +120,7 @@ func createTestDataPool() dataRetriever.PoolsHolder {
 		peerChangeBlockBody,
 		metaBlocks,
 		metaHdrNonces,
+		trieNodes,
 	)
 
 	return dPool
@@ -133,7 +145,7 @@ func createAccountsDB() *state.AccountsDB {
 	marsh := &marshal.JsonMarshalizer{}
 
 	dbw, _ := trie.NewDBWriteCache(createMemUnit())
-	tr, _ := trie.NewTrie(make([]byte, 32), dbw, sha256.Sha256{})
+	tr, _ := trie.NewStateTrie(dbw, sha256.Sha256{})
 	adb, _ := state.NewAccountsDB(tr, sha256.Sha256{}, marsh, &mock.AccountsFactoryStub{
 		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (wrapper state.AccountHandler, e error) {
 			return state.NewAccount(address, tracker)
@@ -166,10 +178,12 @@ func createNetNode(
 	sk, pk := keyGen.GeneratePair()
 	multiSigner, _ := createMultiSigner(sk, pk, keyGen, hasher)
 	blkc := createTestBlockChain()
-	store := createTestStore()
+	store := createTestStore(shardCoordinator)
 	uint64Converter := uint64ByteSlice.NewBigEndianConverter()
 	dataPacker, _ := partitioning.NewSizeDataPacker(marshalizer)
 
+	requestedTxs, _ := lrucache.NewCache(1000)
+
 	interceptorContainerFactory, _ := shard.NewInterceptorsContainerFactory(
 		shardCoordinator,
 		messenger,
@@ -182,6 +196,9 @@ func createNetNode(
 		dPool,
 		addrConverter,
 		&mock.ChronologyValidatorMock{},
+		&mock.RounderMock{},
+		requestedTxs,
+		chainID,
 		nil,
 	)
 	interceptorsContainer, _ := interceptorContainerFactory.Create()
@@ -211,6 +228,7 @@ func createNetNode(
 		node.WithTxSignPrivKey(sk),
 		node.WithTxSignPubKey(pk),
 		node.WithShardCoordinator(shardCoordinator),
+		node.WithChainID(chainID),
 		node.WithBlockChain(blkc),
 		node.WithUint64ByteSliceConverter(uint64Converter),
 		node.WithInterceptorsContainer(interceptorsContainer),
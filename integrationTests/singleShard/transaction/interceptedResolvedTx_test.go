@@ -65,6 +65,8 @@ func TestNode_RequestInterceptTransactionWithMessenger(t *testing.T) {
 		RcvAddr: hasher.Compute("receiver"),
 		SndAddr: buffPk1,
 		Data:    []byte("tx notarized data"),
+		ChainID: chainID,
+		Version: transaction.CurrentVersion,
 	}
 
 	txBuff, _ := marshalizer.Marshal(&tx)
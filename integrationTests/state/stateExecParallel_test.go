@@ -0,0 +1,137 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/addressConverters"
+	transaction2 "github.com/numbatx/gn-numbat/data/transaction"
+	"github.com/numbatx/gn-numbat/hashing/sha256"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/node/mock"
+	"github.com/numbatx/gn-numbat/process"
+	"github.com/numbatx/gn-numbat/process/economics"
+	"github.com/numbatx/gn-numbat/process/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func createAccountsWithBalance(t *testing.T, accnts *state.AccountsDB, addresses []state.AddressContainer, balance *big.Int) {
+	for _, address := range addresses {
+		account, err := accnts.GetAccountWithJournal(address)
+		assert.Nil(t, err)
+		err = account.(*state.Account).SetBalanceWithJournal(balance)
+		assert.Nil(t, err)
+	}
+
+	_, err := accnts.Commit()
+	assert.Nil(t, err)
+}
+
+func createParallelExecutor(accnts *state.AccountsDB, maxWorkers int) (process.TransactionProcessor, process.TransactionsExecutor) {
+	hasher := sha256.Sha256{}
+	marshalizer := &marshal.JsonMarshalizer{}
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	addrConv, _ := addressConverters.NewPlainAddressConverter(32, "0x")
+	feeHandler := economics.NewFeeHandler(1, 1, 0)
+
+	txProcessor, _ := transaction.NewTxProcessor(accnts, hasher, addrConv, marshalizer, shardCoordinator, feeHandler)
+	executor, _ := transaction.NewParallelExecutor(
+		txProcessor,
+		accnts,
+		hasher,
+		addrConv,
+		marshalizer,
+		shardCoordinator,
+		feeHandler,
+		&accountFactory{},
+		maxWorkers,
+	)
+
+	return txProcessor, executor
+}
+
+func createMixedTransactions(senders []state.AddressContainer, receivers []state.AddressContainer) []*transaction2.Transaction {
+	txs := make([]*transaction2.Transaction, 0)
+	nonces := make(map[string]uint64)
+	addTx := func(snd state.AddressContainer, rcv state.AddressContainer, value int64) {
+		txs = append(txs, &transaction2.Transaction{
+			Nonce:    nonces[string(snd.Bytes())],
+			Value:    big.NewInt(value),
+			SndAddr:  snd.Bytes(),
+			RcvAddr:  rcv.Bytes(),
+			GasPrice: 1,
+			GasLimit: 1,
+		})
+		nonces[string(snd.Bytes())]++
+	}
+
+	// independent transfers, transfers towards a common receiver and a chain of transfers between senders
+	for i := range senders {
+		addTx(senders[i], receivers[(i/2)%len(receivers)], int64(i+1))
+	}
+	for i := 0; i < len(senders)-1; i += 2 {
+		addTx(senders[i], senders[i+1], 7)
+		addTx(senders[i+1], senders[i], 3)
+	}
+	for i := range senders {
+		addTx(senders[i], createDummyAddress(), 5)
+	}
+
+	return txs
+}
+
+func TestExecTransaction_ParallelExecutionShouldGiveTheSameRootHashAsSequentialExecution(t *testing.T) {
+	t.Parallel()
+
+	senders := make([]state.AddressContainer, 20)
+	for i := range senders {
+		senders[i] = createDummyAddress()
+	}
+	receivers := []state.AddressContainer{createDummyAddress(), createDummyAddress(), createDummyAddress()}
+	txs := createMixedTransactions(senders, receivers)
+
+	accntsSequential := adbCreateAccountsDB()
+	createAccountsWithBalance(t, accntsSequential, senders, big.NewInt(1000))
+	txProcessor, _ := createParallelExecutor(accntsSequential, 1)
+	for _, tx := range txs {
+		err := txProcessor.ProcessTransaction(tx, 0)
+		assert.Nil(t, err)
+	}
+	rootHashSequential, err := accntsSequential.Commit()
+	assert.Nil(t, err)
+
+	accntsParallel := adbCreateAccountsDB()
+	createAccountsWithBalance(t, accntsParallel, senders, big.NewInt(1000))
+	_, executor := createParallelExecutor(accntsParallel, 4)
+	err = executor.ExecuteTransactions(txs, 0)
+	assert.Nil(t, err)
+	rootHashParallel, err := accntsParallel.Commit()
+	assert.Nil(t, err)
+
+	assert.Equal(t, rootHashSequential, rootHashParallel)
+}
+
+func TestExecTransaction_ParallelExecutionWithBadTransactionShouldErrAndKeepState(t *testing.T) {
+	t.Parallel()
+
+	senders := make([]state.AddressContainer, 10)
+	for i := range senders {
+		senders[i] = createDummyAddress()
+	}
+	receivers := []state.AddressContainer{createDummyAddress(), createDummyAddress()}
+	txs := createMixedTransactions(senders, receivers)
+	txs[len(txs)-1].Value = big.NewInt(1000000)
+
+	accnts := adbCreateAccountsDB()
+	createAccountsWithBalance(t, accnts, senders, big.NewInt(1000))
+	rootHash := accnts.RootHash()
+
+	_, executor := createParallelExecutor(accnts, 4)
+	err := executor.ExecuteTransactions(txs, 0)
+	assert.Equal(t, process.ErrInsufficientFunds, err)
+
+	err = accnts.RevertToSnapshot(0)
+	assert.Nil(t, err)
+	assert.Equal(t, rootHash, accnts.RootHash())
+}
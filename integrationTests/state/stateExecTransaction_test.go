@@ -12,6 +12,7 @@ import (
 	"github.com/numbatx/gn-numbat/hashing/sha256"
 	"github.com/numbatx/gn-numbat/marshal"
 	"github.com/numbatx/gn-numbat/node/mock"
+	"github.com/numbatx/gn-numbat/process/economics"
 	"github.com/numbatx/gn-numbat/process/transaction"
 	"github.com/stretchr/testify/assert"
 )
@@ -32,7 +33,7 @@ func TestExecTransaction_SelfTransactionShouldWork(t *testing.T) {
 	shardCoordinator := mock.NewOneShardCoordinatorMock()
 	addrConv, _ := addressConverters.NewPlainAddressConverter(32, "0x")
 
-	txProcessor, _ := transaction.NewTxProcessor(accnts, hasher, addrConv, marshalizer, shardCoordinator)
+	txProcessor, _ := transaction.NewTxProcessor(accnts, hasher, addrConv, marshalizer, shardCoordinator, economics.NewFeeHandler(0, 0, 0))
 
 	nonce := uint64(6)
 	balance := big.NewInt(10000)
@@ -76,7 +77,7 @@ func TestExecTransaction_SelfTransactionWithRevertShouldWork(t *testing.T) {
 	shardCoordinator := mock.NewOneShardCoordinatorMock()
 	addrConv, _ := addressConverters.NewPlainAddressConverter(32, "0x")
 
-	txProcessor, _ := transaction.NewTxProcessor(accnts, hasher, addrConv, marshalizer, shardCoordinator)
+	txProcessor, _ := transaction.NewTxProcessor(accnts, hasher, addrConv, marshalizer, shardCoordinator, economics.NewFeeHandler(0, 0, 0))
 
 	nonce := uint64(6)
 	balance := big.NewInt(10000)
@@ -107,6 +108,46 @@ func TestExecTransaction_SelfTransactionWithRevertShouldWork(t *testing.T) {
 	assert.Equal(t, balance, accountAfterExec.(*state.Account).Balance)
 }
 
+func TestExecTransaction_TransactionShouldChargeFee(t *testing.T) {
+	t.Parallel()
+
+	accnts := adbCreateAccountsDB()
+
+	hasher := sha256.Sha256{}
+	marshalizer := &marshal.JsonMarshalizer{}
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	addrConv, _ := addressConverters.NewPlainAddressConverter(32, "0x")
+
+	txProcessor, _ := transaction.NewTxProcessor(accnts, hasher, addrConv, marshalizer, shardCoordinator, economics.NewFeeHandler(2, 10, 1))
+
+	sender, _ := addrConv.CreateAddressFromHex(createDummyHexAddress(64))
+	receiver, _ := addrConv.CreateAddressFromHex(createDummyHexAddress(64))
+
+	account, _ := accnts.GetAccountWithJournal(sender)
+	account.(*state.Account).SetBalanceWithJournal(big.NewInt(1000))
+
+	_, _ = accnts.Commit()
+
+	//a transfer with 5 bytes of data needs 10+5 gas, paid with a gas price of 3
+	tx := &transaction2.Transaction{
+		Nonce:    0,
+		Value:    big.NewInt(100),
+		SndAddr:  sender.Bytes(),
+		RcvAddr:  receiver.Bytes(),
+		GasPrice: 3,
+		GasLimit: 20,
+		Data:     []byte("hello"),
+	}
+
+	err := txProcessor.ProcessTransaction(tx, 0)
+	assert.Nil(t, err)
+
+	senderAfterExec, _ := accnts.GetExistingAccount(sender)
+	receiverAfterExec, _ := accnts.GetExistingAccount(receiver)
+	assert.Equal(t, big.NewInt(1000-100-45), senderAfterExec.(*state.Account).Balance)
+	assert.Equal(t, big.NewInt(100), receiverAfterExec.(*state.Account).Balance)
+}
+
 func TestExecTransaction_MoreTransactionsWithRevertShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -147,7 +188,7 @@ func testExecTransactionsMoreTxWithRevert(
 	shardCoordinator := mock.NewOneShardCoordinatorMock()
 	addrConv, _ := addressConverters.NewPlainAddressConverter(32, "0x")
 
-	txProcessor, _ := transaction.NewTxProcessor(accnts, hasher, addrConv, marshalizer, shardCoordinator)
+	txProcessor, _ := transaction.NewTxProcessor(accnts, hasher, addrConv, marshalizer, shardCoordinator, economics.NewFeeHandler(0, 0, 0))
 
 	txToGenerate := 15000
 
@@ -0,0 +1,99 @@
+package state
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/state"
+	trieFactory "github.com/numbatx/gn-numbat/data/trie/factory"
+	"github.com/numbatx/gn-numbat/hashing/sha256"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func createAccountsDBOnStorer(t *testing.T, storer storage.Storer) *state.AccountsDB {
+	tr, err := trieFactory.NewStateTrie(trieFactory.PatriciaMerkleTrie, storer, &marshal.JsonMarshalizer{}, sha256.Sha256{})
+	assert.Nil(t, err)
+
+	adb, err := state.NewAccountsDB(tr, sha256.Sha256{}, &marshal.JsonMarshalizer{}, &accountFactory{})
+	assert.Nil(t, err)
+
+	return adb
+}
+
+func TestStatePruning_CheckpointShouldHoldTheDataTriesOfUnchangedAccounts(t *testing.T) {
+	t.Parallel()
+
+	marsh := &marshal.JsonMarshalizer{}
+	storer := createMemUnit()
+	leafRootHash, _ := state.NewDataTrieRootHashGetter(marsh, sha256.Sha256{}, &accountFactory{})
+	tr, pruner, err := trieFactory.NewPruningStateTrie(
+		trieFactory.PatriciaMerkleTrie,
+		storer,
+		marsh,
+		sha256.Sha256{},
+		leafRootHash,
+		2,
+	)
+	assert.Nil(t, err)
+
+	adb, _ := state.NewAccountsDB(tr, sha256.Sha256{}, marsh, &accountFactory{})
+	adrWithData := createDummyAddress()
+	adrWithBalance := createDummyAddress()
+
+	// the data trie is only written by the first block, the next blocks only change the balance of another account
+	accountWithData, _ := adb.GetAccountWithJournal(adrWithData)
+	accountWithData.DataTrieTracker().SaveKeyValue([]byte("key"), []byte("value"))
+	err = adb.SaveDataTrie(accountWithData)
+	assert.Nil(t, err)
+
+	roots := make([][]byte, 0)
+	for nonce := uint64(1); nonce <= 4; nonce++ {
+		accountWithBalance, _ := adb.GetAccountWithJournal(adrWithBalance)
+		err = accountWithBalance.(*state.Account).SetBalanceWithJournal(big.NewInt(int64(nonce)))
+		assert.Nil(t, err)
+
+		rootHash, err := adb.Commit()
+		assert.Nil(t, err)
+
+		pruner.AddRoot(nonce, rootHash)
+		roots = append(roots, rootHash)
+	}
+
+	err = pruner.Prune(4)
+	assert.Nil(t, err)
+
+	// the state of block 2 is the only one written to the storer, as a checkpoint, and it has to be usable
+	// after a restart, without the nodes kept in memory by the pruning trie
+	restartedAdb := createAccountsDBOnStorer(t, storer)
+
+	err = restartedAdb.RecreateTrie(roots[0])
+	assert.NotNil(t, err)
+
+	err = restartedAdb.RecreateTrie(roots[1])
+	assert.Nil(t, err)
+
+	account, err := restartedAdb.GetExistingAccount(adrWithData)
+	assert.Nil(t, err)
+	value, err := account.DataTrieTracker().RetrieveValue([]byte("key"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	account, err = restartedAdb.GetExistingAccount(adrWithBalance)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(2), account.(*state.Account).Balance)
+
+	// the retained states are still served by the pruning trie
+	for _, rootHash := range roots[2:] {
+		err = adb.RecreateTrie(rootHash)
+		assert.Nil(t, err, fmt.Sprintf("retained root %v should be available", rootHash))
+
+		account, err = adb.GetExistingAccount(adrWithData)
+		assert.Nil(t, err)
+		value, err = account.DataTrieTracker().RetrieveValue([]byte("key"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("value"), value)
+	}
+}
@@ -29,7 +29,7 @@ func adbCreateAccountsDB() *state.AccountsDB {
 	marsh := &marshal.JsonMarshalizer{}
 
 	dbw, _ := trie.NewDBWriteCache(createMemUnit())
-	tr, _ := trie.NewTrie(make([]byte, 32), dbw, sha256.Sha256{})
+	tr, _ := trie.NewStateTrie(dbw, sha256.Sha256{})
 	adb, _ := state.NewAccountsDB(tr, sha256.Sha256{}, marsh, &accountFactory{})
 
 	return adb
@@ -521,7 +521,8 @@ func TestAccountsDB_RevertDataStepByStepAccountDataShouldWork(t *testing.T) {
 	assert.Nil(t, err)
 	snapshotCreated1 := adb.JournalLen()
 	hrCreated1 := base64.StdEncoding.EncodeToString(adb.RootHash())
-	hrRoot1 := base64.StdEncoding.EncodeToString(state1.DataTrie().Root())
+	hrRoot1Hash, _ := state1.DataTrie().Root()
+	hrRoot1 := base64.StdEncoding.EncodeToString(hrRoot1Hash)
 
 	fmt.Printf("State root - created 1-st account: %v\n", hrCreated1)
 	fmt.Printf("Data root - 1-st account: %v\n", hrRoot1)
@@ -533,7 +534,8 @@ func TestAccountsDB_RevertDataStepByStepAccountDataShouldWork(t *testing.T) {
 	assert.Nil(t, err)
 	snapshotCreated2 := adb.JournalLen()
 	hrCreated2 := base64.StdEncoding.EncodeToString(adb.RootHash())
-	hrRoot2 := base64.StdEncoding.EncodeToString(state1.DataTrie().Root())
+	hrRoot2Hash, _ := state1.DataTrie().Root()
+	hrRoot2 := base64.StdEncoding.EncodeToString(hrRoot2Hash)
 
 	fmt.Printf("State root - created 2-nd account: %v\n", hrCreated2)
 	fmt.Printf("Data root - 2-nd account: %v\n", hrRoot2)
@@ -582,7 +584,8 @@ func TestAccountsDB_RevertDataStepByStepWithCommitsAccountDataShouldWork(t *test
 	assert.Nil(t, err)
 	snapshotCreated1 := adb.JournalLen()
 	hrCreated1 := base64.StdEncoding.EncodeToString(adb.RootHash())
-	hrRoot1 := base64.StdEncoding.EncodeToString(state1.DataTrie().Root())
+	hrRoot1Hash, _ := state1.DataTrie().Root()
+	hrRoot1 := base64.StdEncoding.EncodeToString(hrRoot1Hash)
 
 	fmt.Printf("State root - created 1-st account: %v\n", hrCreated1)
 	fmt.Printf("Data root - 1-st account: %v\n", hrRoot1)
@@ -594,7 +597,8 @@ func TestAccountsDB_RevertDataStepByStepWithCommitsAccountDataShouldWork(t *test
 	assert.Nil(t, err)
 	snapshotCreated2 := adb.JournalLen()
 	hrCreated2 := base64.StdEncoding.EncodeToString(adb.RootHash())
-	hrRoot2 := base64.StdEncoding.EncodeToString(state1.DataTrie().Root())
+	hrRoot2Hash, _ := state1.DataTrie().Root()
+	hrRoot2 := base64.StdEncoding.EncodeToString(hrRoot2Hash)
 
 	fmt.Printf("State root - created 2-nd account: %v\n", hrCreated2)
 	fmt.Printf("Data root - 2-nd account: %v\n", hrRoot2)
@@ -617,7 +621,8 @@ func TestAccountsDB_RevertDataStepByStepWithCommitsAccountDataShouldWork(t *test
 	err = adb.SaveDataTrie(state2)
 	assert.Nil(t, err)
 	hrCreated2p1 := base64.StdEncoding.EncodeToString(adb.RootHash())
-	hrRoot2p1 := base64.StdEncoding.EncodeToString(state2.DataTrie().Root())
+	hrRoot2p1Hash, _ := state2.DataTrie().Root()
+	hrRoot2p1 := base64.StdEncoding.EncodeToString(hrRoot2p1Hash)
 
 	fmt.Printf("State root - modified 2-nd account: %v\n", hrCreated2p1)
 	fmt.Printf("Data root - 2-nd account: %v\n", hrRoot2p1)
@@ -633,7 +638,8 @@ func TestAccountsDB_RevertDataStepByStepWithCommitsAccountDataShouldWork(t *test
 	err = adb.RevertToSnapshot(snapshotMod)
 	assert.Nil(t, err)
 	hrCreated2Rev := base64.StdEncoding.EncodeToString(adb.RootHash())
-	hrRoot2Rev := base64.StdEncoding.EncodeToString(state2.DataTrie().Root())
+	hrRoot2RevHash, _ := state2.DataTrie().Root()
+	hrRoot2Rev := base64.StdEncoding.EncodeToString(hrRoot2RevHash)
 	fmt.Printf("State root - reverted 2-nd account: %v\n", hrCreated2Rev)
 	fmt.Printf("Data root - 2-nd account: %v\n", hrRoot2Rev)
 	assert.Equal(t, hrCommit, hrCreated2Rev)
@@ -342,6 +342,17 @@ func WithInterceptorsContainer(interceptorsContainer process.InterceptorsContain
 	}
 }
 
+// WithFeeHandler sets up the fee handler option for the Node, used to set the gas of the generated transactions
+func WithFeeHandler(feeHandler process.FeeHandler) Option {
+	return func(n *Node) error {
+		if feeHandler == nil {
+			return ErrNilFeeHandler
+		}
+		n.feeHandler = feeHandler
+		return nil
+	}
+}
+
 // WithResolversFinder sets up the resolvers finder option for the Node
 func WithResolversFinder(resolversFinder dataRetriever.ResolversFinder) Option {
 	return func(n *Node) error {
@@ -377,3 +388,30 @@ func WithTxStorageSize(txStorageSize uint32) Option {
 		return nil
 	}
 }
+
+// WithChainID sets up the chain ID option for the Node. The chain ID is set on the transactions sent by the node
+func WithChainID(chainID []byte) Option {
+	return func(n *Node) error {
+		if len(chainID) == 0 {
+			return ErrNilChainID
+		}
+		n.chainID = chainID
+		return nil
+	}
+}
+
+// WithStateSyncer sets up the state syncer option for the Node. Before the blocks are synchronized, the state
+// syncer downloads from peers the accounts state of the trusted block header, which becomes the current block
+func WithStateSyncer(stateSyncer process.StateSyncer, trustedHeaderHash []byte) Option {
+	return func(n *Node) error {
+		if stateSyncer == nil {
+			return ErrNilStateSyncer
+		}
+		if len(trustedHeaderHash) == 0 {
+			return ErrNilTrustedHeaderHash
+		}
+		n.stateSyncer = stateSyncer
+		n.trustedHeaderHash = trustedHeaderHash
+		return nil
+	}
+}
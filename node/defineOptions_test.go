@@ -675,6 +675,31 @@ func TestWithForkDetector_NilForkDetectorShouldErr(t *testing.T) {
 	assert.Equal(t, ErrNilForkDetector, err)
 }
 
+func TestWithFeeHandler_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	feeHandler := &mock.FeeHandlerStub{}
+	opt := WithFeeHandler(feeHandler)
+	err := opt(node)
+
+	assert.True(t, node.feeHandler == feeHandler)
+	assert.Nil(t, err)
+}
+
+func TestWithFeeHandler_NilFeeHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	opt := WithFeeHandler(nil)
+	err := opt(node)
+
+	assert.Nil(t, node.feeHandler)
+	assert.Equal(t, ErrNilFeeHandler, err)
+}
+
 func TestWithInterceptorsContainer_ShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -739,3 +764,67 @@ func TestWithConsensusBls_ShouldWork(t *testing.T) {
 	assert.Equal(t, consensusType, node.consensusType)
 	assert.Nil(t, err)
 }
+
+func TestWithChainID_EmptyChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	opt := WithChainID(make([]byte, 0))
+	err := opt(node)
+
+	assert.Nil(t, node.chainID)
+	assert.Equal(t, ErrNilChainID, err)
+}
+
+func TestWithChainID_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	chainID := []byte("chain ID")
+	opt := WithChainID(chainID)
+	err := opt(node)
+
+	assert.Equal(t, chainID, node.chainID)
+	assert.Nil(t, err)
+}
+
+func TestWithStateSyncer_NilStateSyncerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	opt := WithStateSyncer(nil, []byte("header hash"))
+	err := opt(node)
+
+	assert.Nil(t, node.stateSyncer)
+	assert.Equal(t, ErrNilStateSyncer, err)
+}
+
+func TestWithStateSyncer_EmptyHeaderHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	opt := WithStateSyncer(&mock.StateSyncerStub{}, nil)
+	err := opt(node)
+
+	assert.Nil(t, node.stateSyncer)
+	assert.Equal(t, ErrNilTrustedHeaderHash, err)
+}
+
+func TestWithStateSyncer_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	node, _ := NewNode()
+
+	stateSyncer := &mock.StateSyncerStub{}
+	headerHash := []byte("header hash")
+	opt := WithStateSyncer(stateSyncer, headerHash)
+	err := opt(node)
+
+	assert.True(t, node.stateSyncer == stateSyncer)
+	assert.Equal(t, headerHash, node.trustedHeaderHash)
+	assert.Nil(t, err)
+}
@@ -70,6 +70,9 @@ var ErrNilSingleSig = errors.New("trying to set nil schnorrSingleSigner")
 // ErrNilMultiSig signals that a nil multiSigner object has been provided
 var ErrNilMultiSig = errors.New("trying to set nil multiSigner")
 
+// ErrNilFeeHandler signals that a nil fee handler has been provided
+var ErrNilFeeHandler = errors.New("nil fee handler")
+
 // ErrNilForkDetector signals that a nil forkdetector object has been provided
 var ErrNilForkDetector = errors.New("nil fork detector")
 
@@ -112,8 +115,32 @@ var ErrWrongValues = errors.New("wrong values for heartbeat parameters")
 // ErrGenesisBlockNotInitialized signals that genesis block is not initialized
 var ErrGenesisBlockNotInitialized = errors.New("genesis block is not initialized")
 
+// ErrBlockNotFound signals that the requested block was not committed or is no longer stored
+var ErrBlockNotFound = errors.New("block not found")
+
 // ErrNilTransactionPool signals that a nil transaction pool was used
 var ErrNilTransactionPool = errors.New("nil transaction pool")
 
 // ErrTooManyTransactionsInPool signals that are too many transactions in pool
 var ErrTooManyTransactionsInPool = errors.New("too many transactions in pool")
+
+// ErrInvalidBatchTransfers signals that the receivers and values of a batch transfer are missing or do not match
+var ErrInvalidBatchTransfers = errors.New("invalid batch transfers: receivers and values must be non empty and of equal length")
+
+// ErrNilChainID signals that a nil or empty chain ID has been provided
+var ErrNilChainID = errors.New("nil or empty chain ID")
+
+// ErrNilStateSyncer signals that a nil state syncer has been provided
+var ErrNilStateSyncer = errors.New("nil state syncer")
+
+// ErrNilTrustedHeaderHash signals that a nil or empty trusted header hash has been provided
+var ErrNilTrustedHeaderHash = errors.New("nil or empty trusted header hash")
+
+// ErrInvalidStorageKey signals that the provided storage key is not a valid non empty hex string
+var ErrInvalidStorageKey = errors.New("invalid storage key")
+
+// ErrInvalidStoragePageSize signals that a storage page with no entries has been requested
+var ErrInvalidStoragePageSize = errors.New("invalid storage page size")
+
+// ErrStateDiffNotFound signals that the state diff of the requested block was not persisted or is no longer stored
+var ErrStateDiffNotFound = errors.New("state diff not found")
@@ -53,3 +53,25 @@ type ShardBlockInfo struct {
 	BlockHeader
 	Transactions []TransactionInfo
 }
+
+// StorageValue is the entity used to hold the value stored for a key of a smart contract account storage, read
+// from the account data trie having the RootHash root hash. A missing key has a nil value
+type StorageValue struct {
+	Key      []byte
+	Value    []byte
+	RootHash []byte
+}
+
+// StorageEntry is the entity used to hold a key of a smart contract account storage along with its value
+type StorageEntry struct {
+	Key   []byte
+	Value []byte
+}
+
+// StoragePage is the entity used to hold a page of a smart contract account storage, read from the account data
+// trie having the RootHash root hash. NextKey is the key the next page starts from, nil for the last page
+type StoragePage struct {
+	Entries  []StorageEntry
+	RootHash []byte
+	NextKey  []byte
+}
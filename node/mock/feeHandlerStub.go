@@ -0,0 +1,46 @@
+package mock
+
+import (
+	"math/big"
+
+	"github.com/numbatx/gn-numbat/data/transaction"
+)
+
+type FeeHandlerStub struct {
+	MinGasPriceCalled func() uint64
+	GasUsedCalled     func(tx *transaction.Transaction) uint64
+	CheckTxGasCalled  func(tx *transaction.Transaction) error
+	ComputeFeeCalled  func(tx *transaction.Transaction) *big.Int
+}
+
+func (fhs *FeeHandlerStub) MinGasPrice() uint64 {
+	if fhs.MinGasPriceCalled != nil {
+		return fhs.MinGasPriceCalled()
+	}
+
+	return 0
+}
+
+func (fhs *FeeHandlerStub) GasUsed(tx *transaction.Transaction) uint64 {
+	if fhs.GasUsedCalled != nil {
+		return fhs.GasUsedCalled(tx)
+	}
+
+	return 0
+}
+
+func (fhs *FeeHandlerStub) CheckTxGas(tx *transaction.Transaction) error {
+	if fhs.CheckTxGasCalled != nil {
+		return fhs.CheckTxGasCalled(tx)
+	}
+
+	return nil
+}
+
+func (fhs *FeeHandlerStub) ComputeFee(tx *transaction.Transaction) *big.Int {
+	if fhs.ComputeFeeCalled != nil {
+		return fhs.ComputeFeeCalled(tx)
+	}
+
+	return big.NewInt(0)
+}
@@ -13,6 +13,7 @@ type PoolsHolderStub struct {
 	MiniBlocksCalled        func() storage.Cacher
 	MetaBlocksCalled        func() storage.Cacher
 	MetaHeadersNoncesCalled func() dataRetriever.Uint64Cacher
+	TrieNodesCalled         func() storage.Cacher
 }
 
 func (phs *PoolsHolderStub) Headers() storage.Cacher {
@@ -42,3 +43,7 @@ func (phs *PoolsHolderStub) MetaBlocks() storage.Cacher {
 func (phs *PoolsHolderStub) MetaHeadersNonces() dataRetriever.Uint64Cacher {
 	return phs.MetaHeadersNoncesCalled()
 }
+
+func (phs *PoolsHolderStub) TrieNodes() storage.Cacher {
+	return phs.TrieNodesCalled()
+}
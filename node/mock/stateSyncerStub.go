@@ -0,0 +1,9 @@
+package mock
+
+type StateSyncerStub struct {
+	SyncStateCalled func(headerHash []byte) error
+}
+
+func (sss *StateSyncerStub) SyncState(headerHash []byte) error {
+	return sss.SyncStateCalled(headerHash)
+}
@@ -0,0 +1,54 @@
+package mock
+
+import (
+	"github.com/numbatx/gn-numbat/data"
+)
+
+type TrieStub struct {
+	GetCalled              func(key []byte) ([]byte, error)
+	UpdateCalled           func(key, value []byte) error
+	DeleteCalled           func(key []byte) error
+	RootCalled             func() ([]byte, error)
+	CommitCalled           func() error
+	RecreateCalled         func(root []byte) (data.Trie, error)
+	RecreateDataTrieCalled func(root []byte) (data.Trie, error)
+	ProveCalled            func(key []byte) ([][]byte, error)
+
+	GetLeavesCalled func(startKey []byte, maxLeaves int) ([][]byte, [][]byte, error)
+}
+
+func (ts *TrieStub) Get(key []byte) ([]byte, error) {
+	return ts.GetCalled(key)
+}
+
+func (ts *TrieStub) Update(key, value []byte) error {
+	return ts.UpdateCalled(key, value)
+}
+
+func (ts *TrieStub) Delete(key []byte) error {
+	return ts.DeleteCalled(key)
+}
+
+func (ts *TrieStub) Root() ([]byte, error) {
+	return ts.RootCalled()
+}
+
+func (ts *TrieStub) Commit() error {
+	return ts.CommitCalled()
+}
+
+func (ts *TrieStub) Recreate(root []byte) (data.Trie, error) {
+	return ts.RecreateCalled(root)
+}
+
+func (ts *TrieStub) RecreateDataTrie(root []byte) (data.Trie, error) {
+	return ts.RecreateDataTrieCalled(root)
+}
+
+func (ts *TrieStub) Prove(key []byte) ([][]byte, error) {
+	return ts.ProveCalled(key)
+}
+
+func (ts *TrieStub) GetLeaves(startKey []byte, maxLeaves int) ([][]byte, [][]byte, error) {
+	return ts.GetLeavesCalled(startKey, maxLeaves)
+}
@@ -1,7 +1,9 @@
 package node
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
@@ -21,11 +23,13 @@ import (
 	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/data/block"
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/state/proof"
 	"github.com/numbatx/gn-numbat/data/transaction"
 	"github.com/numbatx/gn-numbat/data/typeConverters"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/hashing"
 	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/node/external"
 	"github.com/numbatx/gn-numbat/node/heartbeat"
 	"github.com/numbatx/gn-numbat/ntp"
 	"github.com/numbatx/gn-numbat/p2p"
@@ -88,6 +92,10 @@ type Node struct {
 	txSingleSigner crypto.SingleSigner
 	multiSigner    crypto.MultiSigner
 	forkDetector   process.ForkDetector
+	feeHandler     process.FeeHandler
+
+	stateSyncer       process.StateSyncer
+	trustedHeaderHash []byte
 
 	blkc             data.ChainHandler
 	dataPool         dataRetriever.PoolsHolder
@@ -101,6 +109,7 @@ type Node struct {
 	isRunning         bool
 	isMetachainActive bool
 	txStorageSize     uint32
+	chainID           []byte
 }
 
 // ApplyOptions can set up different configurable options of a Node instance
@@ -212,6 +221,13 @@ func (n *Node) StartConsensus() error {
 		return ErrGenesisBlockNotInitialized
 	}
 
+	if n.stateSyncer != nil {
+		err := n.stateSyncer.SyncState(n.trustedHeaderHash)
+		if err != nil {
+			return err
+		}
+	}
+
 	chronologyHandler, err := n.createChronologyHandler(n.rounder)
 	if err != nil {
 		return err
@@ -449,6 +465,7 @@ func (n *Node) createShardBootstrapper(rounder consensus.Rounder) (process.Boots
 		n.resolversFinder,
 		n.shardCoordinator,
 		n.accounts,
+		n.uint64ByteSliceConverter,
 	)
 	if err != nil {
 		return nil, err
@@ -471,6 +488,7 @@ func (n *Node) createMetaChainBootstrapper(rounder consensus.Rounder) (process.B
 		n.resolversFinder,
 		n.shardCoordinator,
 		n.accounts,
+		n.uint64ByteSliceConverter,
 	)
 
 	if err != nil {
@@ -563,12 +581,17 @@ func (n *Node) createConsensusTopic(messageProcessor p2p.MessageProcessor, shard
 	return n.messenger.RegisterMessageProcessor(n.consensusTopic, messageProcessor)
 }
 
-// SendTransaction will send a new transaction on the topic channel
+// SendTransaction will send a new transaction on the topic channel. The chain ID of the node and the current
+// transaction version are set on the transaction, so the provided signature has to cover them
 func (n *Node) SendTransaction(
 	nonce uint64,
 	senderHex string,
 	receiverHex string,
 	value *big.Int,
+	gasPrice uint64,
+	gasLimit uint64,
+	validFromRound uint32,
+	validUntilRound uint32,
 	transactionData string,
 	signature []byte) (*transaction.Transaction, error) {
 
@@ -589,22 +612,100 @@ func (n *Node) SendTransaction(
 	senderShardId := n.shardCoordinator.ComputeId(sender)
 
 	tx := transaction.Transaction{
-		Nonce:     nonce,
-		Value:     value,
-		RcvAddr:   receiver.Bytes(),
-		SndAddr:   sender.Bytes(),
-		Data:      []byte(transactionData),
-		Signature: signature,
+		Nonce:           nonce,
+		Value:           value,
+		RcvAddr:         receiver.Bytes(),
+		SndAddr:         sender.Bytes(),
+		GasPrice:        gasPrice,
+		GasLimit:        gasLimit,
+		Data:            []byte(transactionData),
+		Signature:       signature,
+		ValidFromRound:  validFromRound,
+		ValidUntilRound: validUntilRound,
+		ChainID:         n.chainID,
+		Version:         transaction.CurrentVersion,
+	}
+
+	err = n.broadcastTransaction(&tx, senderShardId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
+// SendBatchTransaction will send a new batch transfer transaction on the topic channel. The transaction moves
+// values[i] from the sender to receiversHex[i], for every provided receiver
+func (n *Node) SendBatchTransaction(
+	nonce uint64,
+	senderHex string,
+	receiversHex []string,
+	values []*big.Int,
+	gasPrice uint64,
+	gasLimit uint64,
+	validFromRound uint32,
+	validUntilRound uint32,
+	signature []byte) (*transaction.Transaction, error) {
+
+	if n.shardCoordinator == nil {
+		return nil, ErrNilShardCoordinator
+	}
+	if len(receiversHex) == 0 || len(receiversHex) != len(values) {
+		return nil, ErrInvalidBatchTransfers
+	}
+
+	sender, err := n.addrConverter.CreateAddressFromHex(senderHex)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := make([]transaction.Transfer, len(receiversHex))
+	for i, receiverHex := range receiversHex {
+		receiver, err := n.addrConverter.CreateAddressFromHex(receiverHex)
+		if err != nil {
+			return nil, err
+		}
+
+		transfers[i] = transaction.Transfer{
+			RcvAddr: receiver.Bytes(),
+			Value:   values[i],
+		}
 	}
 
-	txBuff, err := n.marshalizer.Marshal(&tx)
+	senderShardId := n.shardCoordinator.ComputeId(sender)
+
+	tx := transaction.Transaction{
+		Nonce:           nonce,
+		Value:           big.NewInt(0),
+		RcvAddr:         sender.Bytes(),
+		SndAddr:         sender.Bytes(),
+		GasPrice:        gasPrice,
+		GasLimit:        gasLimit,
+		Signature:       signature,
+		ValidFromRound:  validFromRound,
+		ValidUntilRound: validUntilRound,
+		Transfers:       transfers,
+		ChainID:         n.chainID,
+		Version:         transaction.CurrentVersion,
+	}
+
+	err = n.broadcastTransaction(&tx, senderShardId)
 	if err != nil {
 		return nil, err
 	}
 
+	return &tx, nil
+}
+
+func (n *Node) broadcastTransaction(tx *transaction.Transaction, senderShardId uint32) error {
+	txBuff, err := n.marshalizer.Marshal(tx)
+	if err != nil {
+		return err
+	}
+
 	marshalizedTx, err := n.marshalizer.Marshal([][]byte{txBuff})
 	if err != nil {
-		return nil, errors.New("could not marshal transaction")
+		return errors.New("could not marshal transaction")
 	}
 
 	//the topic identifier is made of the current shard id and sender's shard id
@@ -616,7 +717,7 @@ func (n *Node) SendTransaction(
 		marshalizedTx,
 	)
 
-	return &tx, nil
+	return nil
 }
 
 // GetTransaction gets the transaction
@@ -624,6 +725,73 @@ func (n *Node) GetTransaction(hash string) (*transaction.Transaction, error) {
 	return nil, fmt.Errorf("not yet implemented")
 }
 
+// GetTransactionStatus returns the execution result of the transaction with the given hex encoded hash. Transactions
+// that were not yet included in a committed block but can be found in the pool are reported as received. If the
+// transaction is not known at all, nil is returned
+func (n *Node) GetTransactionStatus(hash string) (*transaction.ExecutionResult, error) {
+	if n.store == nil {
+		return nil, ErrNilStore
+	}
+	if n.marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+	if n.dataPool == nil {
+		return nil, ErrNilDataPool
+	}
+
+	txHash, err := hex.DecodeString(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	buff, err := n.store.Get(dataRetriever.TransactionResultUnit, txHash)
+	if err == nil && buff != nil {
+		result := &transaction.ExecutionResult{}
+		err = n.marshalizer.Unmarshal(result, buff)
+		if err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+
+	_, ok := n.dataPool.Transactions().SearchFirstData(txHash)
+	if ok {
+		return &transaction.ExecutionResult{Status: transaction.TxStatusReceived}, nil
+	}
+
+	return nil, nil
+}
+
+// GetBlockStateDiff returns the changes made to the accounts by the committed shard block with the given hex encoded
+// hash
+func (n *Node) GetBlockStateDiff(blockHash string) (*state.StateDiff, error) {
+	if n.store == nil {
+		return nil, ErrNilStore
+	}
+	if n.marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+
+	hash, err := hex.DecodeString(blockHash)
+	if err != nil {
+		return nil, errors.New("invalid block hash, could not decode from hex: " + err.Error())
+	}
+
+	buff, err := n.store.Get(dataRetriever.StateDiffUnit, hash)
+	if err != nil {
+		return nil, ErrStateDiffNotFound
+	}
+
+	diff := &state.StateDiff{}
+	err = n.marshalizer.Unmarshal(diff, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
+
 // GetCurrentPublicKey will return the current node's public key
 func (n *Node) GetCurrentPublicKey() string {
 	if n.txSignPubKey != nil {
@@ -657,6 +825,249 @@ func (n *Node) GetAccount(address string) (*state.Account, error) {
 	return account, nil
 }
 
+// GetAccountAtBlockNonce will return the account details for a given address, as they were after the committed
+// block with the given nonce
+func (n *Node) GetAccountAtBlockNonce(address string, blockNonce uint64) (*state.Account, error) {
+	header, err := n.getHeaderByNonce(blockNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.getAccountAtHeader(address, header)
+}
+
+// GetAccountAtBlockHash will return the account details for a given address, as they were after the committed
+// block with the given hex encoded hash
+func (n *Node) GetAccountAtBlockHash(address string, blockHash string) (*state.Account, error) {
+	hash, err := hex.DecodeString(blockHash)
+	if err != nil {
+		return nil, errors.New("invalid block hash, could not decode from hex: " + err.Error())
+	}
+
+	header, err := n.getHeaderByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.getAccountAtHeader(address, header)
+}
+
+// getAccountAtHeader reads the account from a trie recreated at the root hash of the header, so the live state
+// is not disturbed. An account missing at that block is signalled through state.ErrAccNotFound
+func (n *Node) getAccountAtHeader(address string, header data.HeaderHandler) (*state.Account, error) {
+	if n.addrConverter == nil || n.accounts == nil {
+		return nil, errors.New("initialize AccountsAdapter and AddressConverter first")
+	}
+
+	addr, err := n.addrConverter.CreateAddressFromHex(address)
+	if err != nil {
+		return nil, errors.New("could not create address object from provided string")
+	}
+
+	accWrp, err := n.accounts.GetAccountFromRoot(header.GetRootHash(), addr)
+	if err == state.ErrAccNotFound {
+		return nil, err
+	}
+	if err != nil {
+		return nil, errors.New("could not fetch the account at the requested block: " + err.Error())
+	}
+
+	account, ok := accWrp.(*state.Account)
+	if !ok {
+		return nil, errors.New("account is not of type with balance and nonce")
+	}
+
+	return account, nil
+}
+
+// getHeaderByNonce returns the committed shard block header with the given nonce, resolving its hash through the
+// nonce to hash storage unit. The genesis header is never stored, so it is taken from the blockchain
+func (n *Node) getHeaderByNonce(nonce uint64) (data.HeaderHandler, error) {
+	if n.blkc == nil {
+		return nil, ErrNilBlockchain
+	}
+	if nonce == 0 {
+		return n.getGenesisHeader()
+	}
+	if n.store == nil {
+		return nil, ErrNilStore
+	}
+	if n.uint64ByteSliceConverter == nil {
+		return nil, ErrNilUint64ByteSliceConverter
+	}
+	if n.shardCoordinator == nil {
+		return nil, ErrNilShardCoordinator
+	}
+
+	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(n.shardCoordinator.SelfId())
+	hash, err := n.store.Get(hdrNonceHashDataUnit, n.uint64ByteSliceConverter.ToByteSlice(nonce))
+	if err != nil {
+		return nil, ErrBlockNotFound
+	}
+
+	return n.getHeaderByHash(hash)
+}
+
+// getHeaderByHash returns the committed shard block header with the given hash
+func (n *Node) getHeaderByHash(hash []byte) (data.HeaderHandler, error) {
+	if n.blkc == nil {
+		return nil, ErrNilBlockchain
+	}
+	if bytes.Equal(hash, n.blkc.GetGenesisHeaderHash()) {
+		return n.getGenesisHeader()
+	}
+	if n.store == nil {
+		return nil, ErrNilStore
+	}
+	if n.marshalizer == nil {
+		return nil, ErrNilMarshalizer
+	}
+
+	buff, err := n.store.Get(dataRetriever.BlockHeaderUnit, hash)
+	if err != nil {
+		return nil, ErrBlockNotFound
+	}
+
+	header := &block.Header{}
+	err = n.marshalizer.Unmarshal(header, buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+func (n *Node) getGenesisHeader() (data.HeaderHandler, error) {
+	header := n.blkc.GetGenesisHeader()
+	if header == nil {
+		return nil, ErrGenesisBlockNotInitialized
+	}
+
+	return header, nil
+}
+
+// GetAccountProof returns the Merkle proof of the account with the given hex address against the state root hash
+// of the latest committed block header, or of the genesis header if no block was committed yet
+func (n *Node) GetAccountProof(address string) (*proof.AccountProof, error) {
+	if n.addrConverter == nil || n.accounts == nil {
+		return nil, errors.New("initialize AccountsAdapter and AddressConverter first")
+	}
+	if n.blkc == nil {
+		return nil, ErrNilBlockchain
+	}
+
+	addr, err := n.addrConverter.CreateAddressFromHex(address)
+	if err != nil {
+		return nil, errors.New("could not create address object from provided string")
+	}
+
+	header := n.blkc.GetCurrentBlockHeader()
+	headerHash := n.blkc.GetCurrentBlockHeaderHash()
+	if header == nil {
+		header = n.blkc.GetGenesisHeader()
+		headerHash = n.blkc.GetGenesisHeaderHash()
+	}
+	if header == nil {
+		return nil, ErrGenesisBlockNotInitialized
+	}
+
+	value, proofNodes, err := n.accounts.GetAccountProof(header.GetRootHash(), addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proof.AccountProof{
+		Address:    addr.Bytes(),
+		Value:      value,
+		Proof:      proofNodes,
+		RootHash:   header.GetRootHash(),
+		BlockNonce: header.GetNonce(),
+		BlockHash:  headerHash,
+	}, nil
+}
+
+// GetAccountStorageValue returns the value stored for the hex encoded key in the data trie of the account with the
+// given hex address, along with the root hash of the data trie. A missing key has a nil value
+func (n *Node) GetAccountStorageValue(address string, key string) (*external.StorageValue, error) {
+	keyBytes, err := hex.DecodeString(key)
+	if err != nil || len(keyBytes) == 0 {
+		return nil, ErrInvalidStorageKey
+	}
+
+	account, err := n.getExistingAccountHandler(address)
+	if err != nil {
+		return nil, err
+	}
+
+	storageValue := &external.StorageValue{
+		Key:      keyBytes,
+		RootHash: account.GetRootHash(),
+	}
+	if account.DataTrie() == nil {
+		return storageValue, nil
+	}
+
+	storageValue.Value, err = account.DataTrieTracker().RetrieveValue(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return storageValue, nil
+}
+
+// GetAccountStorage returns at most maxEntries entries of the data trie of the account with the given hex address,
+// starting from the hex encoded startKey, or from the first key of the data trie if startKey is empty
+func (n *Node) GetAccountStorage(address string, startKey string, maxEntries int) (*external.StoragePage, error) {
+	if maxEntries <= 0 {
+		return nil, ErrInvalidStoragePageSize
+	}
+	startKeyBytes, err := hex.DecodeString(startKey)
+	if err != nil {
+		return nil, ErrInvalidStorageKey
+	}
+
+	account, err := n.getExistingAccountHandler(address)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &external.StoragePage{
+		Entries:  make([]external.StorageEntry, 0),
+		RootHash: account.GetRootHash(),
+	}
+	if account.DataTrie() == nil {
+		return page, nil
+	}
+
+	// one more leaf is read to find out where the next page starts
+	keys, values, err := account.DataTrie().GetLeaves(startKeyBytes, maxEntries+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) > maxEntries {
+		page.NextKey = keys[maxEntries]
+		keys = keys[:maxEntries]
+	}
+	for i := range keys {
+		page.Entries = append(page.Entries, external.StorageEntry{Key: keys[i], Value: values[i]})
+	}
+
+	return page, nil
+}
+
+func (n *Node) getExistingAccountHandler(address string) (state.AccountHandler, error) {
+	if n.addrConverter == nil || n.accounts == nil {
+		return nil, errors.New("initialize AccountsAdapter and AddressConverter first")
+	}
+
+	addr, err := n.addrConverter.CreateAddressFromHex(address)
+	if err != nil {
+		return nil, errors.New("could not create address object from provided string")
+	}
+
+	return n.accounts.GetExistingAccount(addr)
+}
+
 func (n *Node) sendMessage(cnsDta *consensus.Message) {
 	cnsDtaBuff, err := n.marshalizer.Marshal(cnsDta)
 	if err != nil {
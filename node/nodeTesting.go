@@ -258,6 +258,13 @@ func (n *Node) generateAndSignSingleTx(
 		RcvAddr: rcvAddrBytes,
 		SndAddr: sndAddrBytes,
 		Data:    dataBytes,
+		ChainID: n.chainID,
+		Version: transaction.CurrentVersion,
+	}
+
+	if n.feeHandler != nil {
+		tx.GasPrice = n.feeHandler.MinGasPrice()
+		tx.GasLimit = n.feeHandler.GasUsed(&tx)
 	}
 
 	marshalizedTx, err := n.marshalizer.Marshal(&tx)
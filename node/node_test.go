@@ -2,6 +2,7 @@ package node_test
 
 import (
 	"bytes"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
@@ -15,6 +16,7 @@ import (
 	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/data/block"
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/transaction"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/node"
 	"github.com/numbatx/gn-numbat/node/mock"
@@ -330,6 +332,236 @@ func TestGetBalance(t *testing.T) {
 	assert.Equal(t, big.NewInt(100), balance)
 }
 
+//------- GetAccountProof
+
+func TestGetAccountProof_NilBlockchainShouldError(t *testing.T) {
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithAccountsAdapter(&mock.AccountsStub{}),
+	)
+
+	accProof, err := n.GetAccountProof(createDummyHexAddress(64))
+	assert.Nil(t, accProof)
+	assert.Equal(t, node.ErrNilBlockchain, err)
+}
+
+func TestGetAccountProof_NoHeaderShouldError(t *testing.T) {
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithAccountsAdapter(&mock.AccountsStub{}),
+		node.WithBlockChain(&mock.BlockChainMock{}),
+	)
+
+	accProof, err := n.GetAccountProof(createDummyHexAddress(64))
+	assert.Nil(t, accProof)
+	assert.Equal(t, node.ErrGenesisBlockNotInitialized, err)
+}
+
+func TestGetAccountProof_ShouldUseGenesisHeaderIfNoBlockWasCommitted(t *testing.T) {
+	rootHash := []byte("genesis root hash")
+	genesisHash := []byte("genesis hash")
+	value := []byte("account")
+	proofNodes := [][]byte{[]byte("node")}
+
+	accAdapter := &mock.AccountsStub{
+		GetAccountProofCalled: func(rh []byte, addressContainer state.AddressContainer) ([]byte, [][]byte, error) {
+			if bytes.Equal(rh, rootHash) {
+				return value, proofNodes, nil
+			}
+			return nil, nil, errors.New("unexpected root hash")
+		},
+	}
+	blkc := &mock.BlockChainMock{
+		GetGenesisHeaderCalled: func() data.HeaderHandler {
+			return &block.Header{RootHash: rootHash}
+		},
+		GetGenesisHeaderHashCalled: func() []byte {
+			return genesisHash
+		},
+	}
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithAccountsAdapter(accAdapter),
+		node.WithBlockChain(blkc),
+	)
+
+	accProof, err := n.GetAccountProof(createDummyHexAddress(64))
+	assert.Nil(t, err)
+	assert.Equal(t, value, accProof.Value)
+	assert.Equal(t, proofNodes, accProof.Proof)
+	assert.Equal(t, rootHash, accProof.RootHash)
+	assert.Equal(t, genesisHash, accProof.BlockHash)
+	assert.Equal(t, uint64(0), accProof.BlockNonce)
+}
+
+func TestGetAccountProof_ShouldUseCurrentHeader(t *testing.T) {
+	rootHash := []byte("root hash")
+	headerHash := []byte("header hash")
+	value := []byte("account")
+	proofNodes := [][]byte{[]byte("node")}
+
+	accAdapter := &mock.AccountsStub{
+		GetAccountProofCalled: func(rh []byte, addressContainer state.AddressContainer) ([]byte, [][]byte, error) {
+			if bytes.Equal(rh, rootHash) {
+				return value, proofNodes, nil
+			}
+			return nil, nil, errors.New("unexpected root hash")
+		},
+	}
+	blkc := &mock.BlockChainMock{
+		GetCurrentBlockHeaderCalled: func() data.HeaderHandler {
+			return &block.Header{Nonce: 7, RootHash: rootHash}
+		},
+		GetCurrentBlockHeaderHashCalled: func() []byte {
+			return headerHash
+		},
+	}
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithAccountsAdapter(accAdapter),
+		node.WithBlockChain(blkc),
+	)
+
+	accProof, err := n.GetAccountProof(createDummyHexAddress(64))
+	assert.Nil(t, err)
+	assert.Equal(t, value, accProof.Value)
+	assert.Equal(t, proofNodes, accProof.Proof)
+	assert.Equal(t, rootHash, accProof.RootHash)
+	assert.Equal(t, headerHash, accProof.BlockHash)
+	assert.Equal(t, uint64(7), accProof.BlockNonce)
+}
+
+//------- GetAccountAtBlockNonce / GetAccountAtBlockHash
+
+func TestGetAccountAtBlockNonce_GenesisShouldUseGenesisHeader(t *testing.T) {
+	rootHash := []byte("genesis root hash")
+	accAdapter := &mock.AccountsStub{
+		GetAccountFromRootCalled: func(rh []byte, addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			if bytes.Equal(rh, rootHash) {
+				return &state.Account{Nonce: 1, Balance: big.NewInt(10)}, nil
+			}
+			return nil, errors.New("unexpected root hash")
+		},
+	}
+	blkc := &mock.BlockChainMock{
+		GetGenesisHeaderCalled: func() data.HeaderHandler {
+			return &block.Header{RootHash: rootHash}
+		},
+	}
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithAccountsAdapter(accAdapter),
+		node.WithBlockChain(blkc),
+	)
+
+	account, err := n.GetAccountAtBlockNonce(createDummyHexAddress(64), 0)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), account.Balance)
+}
+
+func TestGetAccountAtBlockNonce_UnknownNonceShouldErr(t *testing.T) {
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithAccountsAdapter(&mock.AccountsStub{}),
+		node.WithBlockChain(&mock.BlockChainMock{}),
+		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithUint64ByteSliceConverter(mock.NewNonceHashConverterMock()),
+		node.WithDataStore(&mock.ChainStorerMock{
+			GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+				return nil, errors.New("key not found")
+			},
+		}),
+	)
+
+	account, err := n.GetAccountAtBlockNonce(createDummyHexAddress(64), 5)
+	assert.Nil(t, account)
+	assert.Equal(t, node.ErrBlockNotFound, err)
+}
+
+func TestGetAccountAtBlockNonce_ShouldResolveHeaderThroughNonceHashUnit(t *testing.T) {
+	rootHash := []byte("root hash")
+	headerHash := []byte("header hash")
+	converter := mock.NewNonceHashConverterMock()
+	accAdapter := &mock.AccountsStub{
+		GetAccountFromRootCalled: func(rh []byte, addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			if bytes.Equal(rh, rootHash) {
+				return &state.Account{Nonce: 4, Balance: big.NewInt(99)}, nil
+			}
+			return nil, errors.New("unexpected root hash")
+		},
+	}
+	store := &mock.ChainStorerMock{
+		GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+			if unitType == dataRetriever.ShardHdrNonceHashDataUnitFor(0) && bytes.Equal(key, converter.ToByteSlice(5)) {
+				return headerHash, nil
+			}
+			if unitType == dataRetriever.BlockHeaderUnit && bytes.Equal(key, headerHash) {
+				return []byte("marshalized header"), nil
+			}
+			return nil, errors.New("key not found")
+		},
+	}
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithAccountsAdapter(accAdapter),
+		node.WithBlockChain(&mock.BlockChainMock{}),
+		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithUint64ByteSliceConverter(converter),
+		node.WithDataStore(store),
+		node.WithMarshalizer(mock.MarshalizerMock{
+			UnmarshalHandler: func(obj interface{}, buff []byte) error {
+				obj.(*block.Header).RootHash = rootHash
+				return nil
+			},
+		}),
+	)
+
+	account, err := n.GetAccountAtBlockNonce(createDummyHexAddress(64), 5)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), account.Nonce)
+	assert.Equal(t, big.NewInt(99), account.Balance)
+}
+
+func TestGetAccountAtBlockHash_InvalidHashShouldErr(t *testing.T) {
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithAccountsAdapter(&mock.AccountsStub{}),
+		node.WithBlockChain(&mock.BlockChainMock{}),
+	)
+
+	account, err := n.GetAccountAtBlockHash(createDummyHexAddress(64), "not hex")
+	assert.Nil(t, account)
+	assert.NotNil(t, err)
+}
+
+func TestGetAccountAtBlockHash_AccountMissingShouldErr(t *testing.T) {
+	headerHash := []byte("header hash")
+	accAdapter := &mock.AccountsStub{
+		GetAccountFromRootCalled: func(rh []byte, addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return nil, state.ErrAccNotFound
+		},
+	}
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithAccountsAdapter(accAdapter),
+		node.WithBlockChain(&mock.BlockChainMock{}),
+		node.WithDataStore(&mock.ChainStorerMock{
+			GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+				return []byte("marshalized header"), nil
+			},
+		}),
+		node.WithMarshalizer(mock.MarshalizerMock{
+			UnmarshalHandler: func(obj interface{}, buff []byte) error {
+				return nil
+			},
+		}),
+	)
+
+	account, err := n.GetAccountAtBlockHash(createDummyHexAddress(64), hex.EncodeToString(headerHash))
+	assert.Nil(t, account)
+	assert.Equal(t, state.ErrAccNotFound, err)
+}
+
 //------- GenerateTransaction
 
 func TestGenerateTransaction_NoAddrConverterShouldError(t *testing.T) {
@@ -573,6 +805,7 @@ func TestSendTransaction_ShouldWork(t *testing.T) {
 		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
 		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
 		node.WithMessenger(mes),
+		node.WithChainID([]byte("chain ID")),
 	)
 
 	nonce := uint64(50)
@@ -587,11 +820,82 @@ func TestSendTransaction_ShouldWork(t *testing.T) {
 		sender,
 		receiver,
 		value,
+		0,
+		0,
+		5,
+		10,
 		txData,
 		signature)
 
 	assert.Nil(t, err)
 	assert.NotNil(t, tx)
+	assert.Equal(t, uint32(5), tx.ValidFromRound)
+	assert.Equal(t, uint32(10), tx.ValidUntilRound)
+	assert.Equal(t, []byte("chain ID"), tx.ChainID)
+	assert.Equal(t, transaction.CurrentVersion, tx.Version)
+	assert.True(t, txSent)
+}
+
+func TestSendBatchTransaction_MismatchedTransfersShouldErr(t *testing.T) {
+	n, _ := node.NewNode(
+		node.WithMarshalizer(&mock.MarshalizerFake{}),
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithMessenger(&mock.MessengerStub{}),
+	)
+
+	tx, err := n.SendBatchTransaction(
+		0,
+		createDummyHexAddress(64),
+		[]string{createDummyHexAddress(64), createDummyHexAddress(64)},
+		[]*big.Int{big.NewInt(1)},
+		0,
+		0,
+		0,
+		0,
+		[]byte("signature"))
+
+	assert.Nil(t, tx)
+	assert.Equal(t, node.ErrInvalidBatchTransfers, err)
+}
+
+func TestSendBatchTransaction_ShouldWork(t *testing.T) {
+	txSent := false
+	mes := &mock.MessengerStub{
+		BroadcastOnChannelCalled: func(pipe string, topic string, buff []byte) {
+			txSent = true
+		},
+	}
+
+	n, _ := node.NewNode(
+		node.WithMarshalizer(&mock.MarshalizerFake{}),
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithShardCoordinator(mock.NewOneShardCoordinatorMock()),
+		node.WithMessenger(mes),
+	)
+
+	sender := createDummyHexAddress(64)
+	receivers := []string{createDummyHexAddress(64), createDummyHexAddress(64)}
+	values := []*big.Int{big.NewInt(10), big.NewInt(20)}
+
+	tx, err := n.SendBatchTransaction(
+		50,
+		sender,
+		receivers,
+		values,
+		0,
+		0,
+		0,
+		0,
+		[]byte("signature"))
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tx)
+	assert.True(t, tx.IsBatchTransfer())
+	assert.Equal(t, tx.SndAddr, tx.RcvAddr)
+	assert.Equal(t, big.NewInt(0), tx.Value)
+	assert.Equal(t, 2, len(tx.Transfers))
+	assert.Equal(t, values[1], tx.Transfers[1].Value)
 	assert.True(t, txSent)
 }
 
@@ -1369,6 +1673,38 @@ func TestNode_StartConsensusGenesisBlockNotInitializedShouldErr(t *testing.T) {
 
 }
 
+func TestNode_StartConsensusStateSyncFailsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	errExpected := errors.New("expected error")
+	trustedHeaderHash := []byte("trusted header hash")
+	var syncedHeaderHash []byte
+	n, _ := node.NewNode(
+		node.WithBlockChain(&mock.ChainHandlerStub{
+			GetGenesisHeaderHashCalled: func() []byte {
+				return []byte("genesis hash")
+			},
+			GetGenesisHeaderCalled: func() data.HeaderHandler {
+				return &block.Header{}
+			},
+		}),
+		node.WithStateSyncer(
+			&mock.StateSyncerStub{
+				SyncStateCalled: func(headerHash []byte) error {
+					syncedHeaderHash = headerHash
+					return errExpected
+				},
+			},
+			trustedHeaderHash,
+		),
+	)
+
+	err := n.StartConsensus()
+
+	assert.Equal(t, errExpected, err)
+	assert.Equal(t, trustedHeaderHash, syncedHeaderHash)
+}
+
 func TestNode_CreateMetaGenesisBlockShouldCreateSaveAndStoreMetaBlock(t *testing.T) {
 	t.Parallel()
 
@@ -1422,3 +1758,278 @@ func TestNode_CreateMetaGenesisBlockShouldCreateSaveAndStoreMetaBlock(t *testing
 	assert.Equal(t, savedHeaderHash, storedHeaderHash)
 	assert.Equal(t, savedHeaderHash, storedHeaderKey)
 }
+
+func TestNode_GetTransactionStatusNilStoreShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithMarshalizer(mock.MarshalizerMock{}),
+		node.WithDataPool(&mock.PoolsHolderStub{}),
+	)
+
+	result, err := n.GetTransactionStatus("aa")
+	assert.Nil(t, result)
+	assert.Equal(t, node.ErrNilStore, err)
+}
+
+func TestNode_GetTransactionStatusInvalidHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithMarshalizer(mock.MarshalizerMock{}),
+		node.WithDataStore(&mock.ChainStorerMock{}),
+		node.WithDataPool(&mock.PoolsHolderStub{}),
+	)
+
+	result, err := n.GetTransactionStatus("not hex")
+	assert.Nil(t, result)
+	assert.NotNil(t, err)
+}
+
+func TestNode_GetTransactionStatusFromStorageShouldWork(t *testing.T) {
+	t.Parallel()
+
+	txHash := []byte("tx hash")
+	n, _ := node.NewNode(
+		node.WithMarshalizer(mock.MarshalizerMock{
+			UnmarshalHandler: func(obj interface{}, buff []byte) error {
+				obj.(*transaction.ExecutionResult).Status = transaction.ExecutionStatus(buff)
+				return nil
+			},
+		}),
+		node.WithDataStore(&mock.ChainStorerMock{
+			GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+				if unitType == dataRetriever.TransactionResultUnit && bytes.Equal(key, txHash) {
+					return []byte(transaction.TxStatusFailed), nil
+				}
+				return nil, errors.New("key not found")
+			},
+		}),
+		node.WithDataPool(&mock.PoolsHolderStub{}),
+	)
+
+	result, err := n.GetTransactionStatus(hex.EncodeToString(txHash))
+	assert.Nil(t, err)
+	assert.Equal(t, transaction.TxStatusFailed, result.Status)
+}
+
+func TestNode_GetBlockStateDiffNilStoreShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithMarshalizer(mock.MarshalizerMock{}),
+	)
+
+	diff, err := n.GetBlockStateDiff("aa")
+	assert.Nil(t, diff)
+	assert.Equal(t, node.ErrNilStore, err)
+}
+
+func TestNode_GetBlockStateDiffInvalidHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithMarshalizer(mock.MarshalizerMock{}),
+		node.WithDataStore(&mock.ChainStorerMock{}),
+	)
+
+	diff, err := n.GetBlockStateDiff("not hex")
+	assert.Nil(t, diff)
+	assert.NotNil(t, err)
+}
+
+func TestNode_GetBlockStateDiffMissingShouldErr(t *testing.T) {
+	t.Parallel()
+
+	n, _ := node.NewNode(
+		node.WithMarshalizer(mock.MarshalizerMock{}),
+		node.WithDataStore(&mock.ChainStorerMock{
+			GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+				return nil, errors.New("key not found")
+			},
+		}),
+	)
+
+	diff, err := n.GetBlockStateDiff("aabb")
+	assert.Nil(t, diff)
+	assert.Equal(t, node.ErrStateDiffNotFound, err)
+}
+
+func TestNode_GetBlockStateDiffShouldWork(t *testing.T) {
+	t.Parallel()
+
+	blockHash := []byte("block hash")
+	rootHash := []byte("root hash")
+	n, _ := node.NewNode(
+		node.WithMarshalizer(mock.MarshalizerMock{
+			UnmarshalHandler: func(obj interface{}, buff []byte) error {
+				obj.(*state.StateDiff).RootHash = buff
+				return nil
+			},
+		}),
+		node.WithDataStore(&mock.ChainStorerMock{
+			GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+				if unitType == dataRetriever.StateDiffUnit && bytes.Equal(key, blockHash) {
+					return rootHash, nil
+				}
+				return nil, errors.New("key not found")
+			},
+		}),
+	)
+
+	diff, err := n.GetBlockStateDiff(hex.EncodeToString(blockHash))
+	assert.Nil(t, err)
+	assert.Equal(t, rootHash, diff.RootHash)
+}
+
+func TestNode_GetTransactionStatusFromPoolShouldReturnReceived(t *testing.T) {
+	t.Parallel()
+
+	txHash := []byte("tx hash")
+	n, _ := node.NewNode(
+		node.WithMarshalizer(mock.MarshalizerMock{}),
+		node.WithDataStore(&mock.ChainStorerMock{
+			GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+				return nil, errors.New("key not found")
+			},
+		}),
+		node.WithDataPool(&mock.PoolsHolderStub{
+			TransactionsCalled: func() dataRetriever.ShardedDataCacherNotifier {
+				return &mock.ShardedDataStub{
+					SearchFirstDataCalled: func(key []byte) (value interface{}, ok bool) {
+						return &transaction.Transaction{}, bytes.Equal(key, txHash)
+					},
+				}
+			},
+		}),
+	)
+
+	result, err := n.GetTransactionStatus(hex.EncodeToString(txHash))
+	assert.Nil(t, err)
+	assert.Equal(t, transaction.TxStatusReceived, result.Status)
+
+	result, err = n.GetTransactionStatus(hex.EncodeToString([]byte("unknown")))
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+//------- GetAccountStorageValue / GetAccountStorage
+
+func createNodeWithAccount(account state.AccountHandler) *node.Node {
+	accAdapter := &mock.AccountsStub{
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			return account, nil
+		},
+	}
+	n, _ := node.NewNode(
+		node.WithAddressConverter(mock.NewAddressConverterFake(32, "0x")),
+		node.WithAccountsAdapter(accAdapter),
+	)
+
+	return n
+}
+
+func createAccountWithDataTrie(rootHash []byte, dataTrie data.Trie) *state.Account {
+	acc, _ := state.NewAccount(state.NewAddress([]byte("address")), &mock.AccountTrackerStub{})
+	acc.RootHash = rootHash
+	if dataTrie != nil {
+		acc.SetDataTrie(dataTrie)
+	}
+
+	return acc
+}
+
+func TestGetAccountStorageValue_InvalidKeyShouldErr(t *testing.T) {
+	n := createNodeWithAccount(createAccountWithDataTrie(nil, nil))
+
+	for _, key := range []string{"", "not hex"} {
+		storageValue, err := n.GetAccountStorageValue(createDummyHexAddress(64), key)
+		assert.Nil(t, storageValue)
+		assert.Equal(t, node.ErrInvalidStorageKey, err)
+	}
+}
+
+func TestGetAccountStorageValue_AccountWithoutDataTrieShouldReturnNilValue(t *testing.T) {
+	n := createNodeWithAccount(createAccountWithDataTrie(nil, nil))
+
+	storageValue, err := n.GetAccountStorageValue(createDummyHexAddress(64), "aabb")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{0xaa, 0xbb}, storageValue.Key)
+	assert.Nil(t, storageValue.Value)
+	assert.Nil(t, storageValue.RootHash)
+}
+
+func TestGetAccountStorageValue_ShouldReadTheDataTrie(t *testing.T) {
+	rootHash := []byte("data root hash")
+	dataTrie := &mock.TrieStub{
+		GetCalled: func(key []byte) ([]byte, error) {
+			if bytes.Equal(key, []byte{0xaa, 0xbb}) {
+				return []byte("value"), nil
+			}
+			return nil, nil
+		},
+	}
+	n := createNodeWithAccount(createAccountWithDataTrie(rootHash, dataTrie))
+
+	storageValue, err := n.GetAccountStorageValue(createDummyHexAddress(64), "aabb")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value"), storageValue.Value)
+	assert.Equal(t, rootHash, storageValue.RootHash)
+}
+
+func TestGetAccountStorage_InvalidParametersShouldErr(t *testing.T) {
+	n := createNodeWithAccount(createAccountWithDataTrie(nil, nil))
+
+	page, err := n.GetAccountStorage(createDummyHexAddress(64), "", 0)
+	assert.Nil(t, page)
+	assert.Equal(t, node.ErrInvalidStoragePageSize, err)
+
+	page, err = n.GetAccountStorage(createDummyHexAddress(64), "not hex", 10)
+	assert.Nil(t, page)
+	assert.Equal(t, node.ErrInvalidStorageKey, err)
+}
+
+func TestGetAccountStorage_AccountWithoutDataTrieShouldReturnEmptyPage(t *testing.T) {
+	n := createNodeWithAccount(createAccountWithDataTrie(nil, nil))
+
+	page, err := n.GetAccountStorage(createDummyHexAddress(64), "", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(page.Entries))
+	assert.Nil(t, page.NextKey)
+}
+
+func TestGetAccountStorage_ShouldReturnThePageAndTheNextKey(t *testing.T) {
+	rootHash := []byte("data root hash")
+	dataTrie := &mock.TrieStub{
+		GetLeavesCalled: func(startKey []byte, maxLeaves int) ([][]byte, [][]byte, error) {
+			if !bytes.Equal(startKey, []byte{0xaa}) || maxLeaves != 3 {
+				return nil, nil, errors.New("unexpected call")
+			}
+			return [][]byte{[]byte("key1"), []byte("key2"), []byte("key3")},
+				[][]byte{[]byte("value1"), []byte("value2"), []byte("value3")}, nil
+		},
+	}
+	n := createNodeWithAccount(createAccountWithDataTrie(rootHash, dataTrie))
+
+	page, err := n.GetAccountStorage(createDummyHexAddress(64), "aa", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(page.Entries))
+	assert.Equal(t, []byte("key2"), page.Entries[1].Key)
+	assert.Equal(t, []byte("value2"), page.Entries[1].Value)
+	assert.Equal(t, rootHash, page.RootHash)
+	assert.Equal(t, []byte("key3"), page.NextKey)
+}
+
+func TestGetAccountStorage_LastPageShouldHaveNoNextKey(t *testing.T) {
+	dataTrie := &mock.TrieStub{
+		GetLeavesCalled: func(startKey []byte, maxLeaves int) ([][]byte, [][]byte, error) {
+			return [][]byte{[]byte("key1")}, [][]byte{[]byte("value1")}, nil
+		},
+	}
+	n := createNodeWithAccount(createAccountWithDataTrie([]byte("data root hash"), dataTrie))
+
+	page, err := n.GetAccountStorage(createDummyHexAddress(64), "", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(page.Entries))
+	assert.Nil(t, page.NextKey)
+}
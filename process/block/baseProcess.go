@@ -8,12 +8,14 @@ import (
 	"github.com/numbatx/gn-numbat/core/logger"
 	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/typeConverters"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/display"
 	"github.com/numbatx/gn-numbat/hashing"
 	"github.com/numbatx/gn-numbat/marshal"
 	"github.com/numbatx/gn-numbat/process"
 	"github.com/numbatx/gn-numbat/sharding"
+	"github.com/numbatx/gn-numbat/storage"
 )
 
 var log = logger.DefaultLogger()
@@ -25,6 +27,10 @@ type baseProcessor struct {
 	hasher           hashing.Hasher
 	marshalizer      marshal.Marshalizer
 	store            dataRetriever.StorageService
+	statePruner      data.StatePruner
+	supplyChecker    process.SupplyChecker
+	uint64Converter  typeConverters.Uint64ByteSliceConverter
+	roundsPerEpoch   uint32
 }
 
 func checkForNils(
@@ -53,6 +59,131 @@ func (bp *baseProcessor) RevertAccountState() {
 	}
 }
 
+// SetStatePruner sets the pruner which is notified of the state committed for every block and of the highest final
+// block. When it is not set, the committed states are all kept
+func (bp *baseProcessor) SetStatePruner(statePruner data.StatePruner) error {
+	if statePruner == nil {
+		return process.ErrNilStatePruner
+	}
+
+	bp.statePruner = statePruner
+	return nil
+}
+
+// SetSupplyChecker sets the checker verifying that the committed blocks conserve the supply. When it is not set, the
+// supply is not checked
+func (bp *baseProcessor) SetSupplyChecker(supplyChecker process.SupplyChecker) error {
+	if supplyChecker == nil {
+		return process.ErrNilSupplyChecker
+	}
+
+	bp.supplyChecker = supplyChecker
+	return nil
+}
+
+// SetUint64Converter sets the converter of the committed headers nonces, which are then persisted along with the
+// headers hashes so the headers can be looked up by nonce. When it is not set, the nonces are not persisted
+func (bp *baseProcessor) SetUint64Converter(converter typeConverters.Uint64ByteSliceConverter) error {
+	if converter == nil {
+		return process.ErrNilUint64Converter
+	}
+
+	bp.uint64Converter = converter
+	return nil
+}
+
+// SetRoundsPerEpoch sets the number of rounds of an epoch, from which the epoch of the created and processed headers
+// is computed. When it is not set, all the headers are in epoch 0
+func (bp *baseProcessor) SetRoundsPerEpoch(roundsPerEpoch uint32) error {
+	if roundsPerEpoch == 0 {
+		return process.ErrInvalidRoundsPerEpoch
+	}
+
+	bp.roundsPerEpoch = roundsPerEpoch
+	return nil
+}
+
+// epochOfRound returns the epoch the given round belongs to
+func (bp *baseProcessor) epochOfRound(round uint32) uint32 {
+	if bp.roundsPerEpoch == 0 {
+		return 0
+	}
+
+	return round / bp.roundsPerEpoch
+}
+
+// checkEpoch verifies that the epoch of the given header is the one of its round, moved forward by at most one epoch
+// from the epoch of the previous header
+func (bp *baseProcessor) checkEpoch(prevHeader data.HeaderHandler, headerHandler data.HeaderHandler) error {
+	prevEpoch := uint32(0)
+	if prevHeader != nil {
+		prevEpoch = prevHeader.GetEpoch()
+	}
+
+	expectedEpoch := process.NextEpoch(prevEpoch, bp.epochOfRound(headerHandler.GetRound()))
+	if headerHandler.GetEpoch() != expectedEpoch {
+		log.Info(fmt.Sprintf("epoch not match: expected epoch %d and node received block with epoch %d\n",
+			expectedEpoch, headerHandler.GetEpoch()))
+
+		return process.ErrInvalidEpoch
+	}
+
+	return nil
+}
+
+// saveNonceToHash persists the hash of the committed header with the given nonce in the given nonce to hash storage
+// unit, if the nonces converter is set
+func (bp *baseProcessor) saveNonceToHash(unitType dataRetriever.UnitType, nonce uint64, headerHash []byte) error {
+	if bp.uint64Converter == nil {
+		return nil
+	}
+
+	return bp.store.Put(unitType, bp.uint64Converter.ToByteSlice(nonce), headerHash)
+}
+
+// stageNonceToHash stages in the batch the hash of the committed header with the given nonce, to be written in the
+// given nonce to hash storage unit, if the nonces converter is set
+func (bp *baseProcessor) stageNonceToHash(
+	batch *commitBatch,
+	unitType dataRetriever.UnitType,
+	nonce uint64,
+	headerHash []byte,
+) {
+	if bp.uint64Converter == nil {
+		return
+	}
+
+	batch.stage(unitType, bp.uint64Converter.ToByteSlice(nonce), headerHash)
+}
+
+// setStorersEpoch moves to the given epoch the storers of the given units which keep their data per epoch, so the
+// data of the block being committed is written in the storage of its epoch
+func (bp *baseProcessor) setStorersEpoch(epoch uint32, unitTypes ...dataRetriever.UnitType) error {
+	for _, unitType := range unitTypes {
+		epochStorer, ok := bp.store.GetStorer(unitType).(storage.EpochStorer)
+		if !ok {
+			continue
+		}
+
+		err := epochStorer.SetEpoch(epoch)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pruneState registers the state committed for the block and prunes the states which are no longer needed
+func (bp *baseProcessor) pruneState(nonce uint64, rootHash []byte) error {
+	if bp.statePruner == nil {
+		return nil
+	}
+
+	bp.statePruner.AddRoot(nonce, rootHash)
+	return bp.statePruner.Prune(bp.forkDetector.GetHighestFinalBlockNonce())
+}
+
 // checkBlockValidity method checks if the given block is valid
 func (bp *baseProcessor) checkBlockValidity(
 	chainHandler data.ChainHandler,
@@ -69,7 +200,7 @@ func (bp *baseProcessor) checkBlockValidity(
 		if headerHandler.GetNonce() == 1 { // first block after genesis
 			if bytes.Equal(headerHandler.GetPrevHash(), chainHandler.GetGenesisHeaderHash()) {
 				// TODO: add genesis block verification
-				return nil
+				return bp.checkEpoch(chainHandler.GetGenesisHeader(), headerHandler)
 			}
 
 			log.Info(fmt.Sprintf("hash not match: local block hash is empty and node received block with previous hash %s\n",
@@ -103,6 +234,11 @@ func (bp *baseProcessor) checkBlockValidity(
 		return process.ErrInvalidBlockHash
 	}
 
+	err = bp.checkEpoch(chainHandler.GetCurrentBlockHeader(), headerHandler)
+	if err != nil {
+		return err
+	}
+
 	if bodyHandler != nil {
 		// TODO: add bodyHandler verification here
 	}
@@ -222,10 +222,17 @@ func initMetaDataPool() *mock.MetaPoolsHolderStub {
 func initStore() *dataRetriever.ChainStorer {
 	store := dataRetriever.NewChainStorer()
 	store.AddStorer(dataRetriever.TransactionUnit, generateTestUnit())
+	store.AddStorer(dataRetriever.TransactionResultUnit, generateTestUnit())
+	store.AddStorer(dataRetriever.ShardHdrNonceHashDataUnitFor(0), generateTestUnit())
 	store.AddStorer(dataRetriever.MiniBlockUnit, generateTestUnit())
 	store.AddStorer(dataRetriever.MetaBlockUnit, generateTestUnit())
+	store.AddStorer(dataRetriever.MetaShardDataUnit, generateTestUnit())
+	store.AddStorer(dataRetriever.MetaPeerDataUnit, generateTestUnit())
+	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, generateTestUnit())
 	store.AddStorer(dataRetriever.PeerChangesUnit, generateTestUnit())
 	store.AddStorer(dataRetriever.BlockHeaderUnit, generateTestUnit())
+	store.AddStorer(dataRetriever.StateDiffUnit, generateTestUnit())
+	store.AddStorer(dataRetriever.BootstrapUnit, generateTestUnit())
 	return store
 }
 
@@ -303,6 +310,10 @@ func TestBlockProcessor_CheckBlockValidity(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	blkc := createTestBlockchain()
 	body := &block.Body{}
@@ -351,6 +362,77 @@ func TestBlockProcessor_CheckBlockValidity(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestBlockProcessor_SetRoundsPerEpochZeroShouldErr(t *testing.T) {
+	t.Parallel()
+	bp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		&mock.AccountsStub{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	assert.Equal(t, process.ErrInvalidRoundsPerEpoch, bp.SetRoundsPerEpoch(0))
+}
+
+func TestBlockProcessor_CheckBlockValidityShouldCheckTheEpoch(t *testing.T) {
+	t.Parallel()
+	bp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		&mock.AccountsStub{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+	_ = bp.SetRoundsPerEpoch(10)
+	blkc := createTestBlockchain()
+	prevHeader := &block.Header{Nonce: 1, Round: 15, Epoch: 1}
+	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
+		return prevHeader
+	}
+	body := &block.Body{}
+	hdr := &block.Header{
+		Nonce:    2,
+		PrevHash: computeHash(prevHeader, &mock.MarshalizerMock{}, &mock.HasherMock{}),
+	}
+
+	hdr.Round, hdr.Epoch = 19, 1
+	assert.Nil(t, bp.CheckBlockValidity(blkc, hdr, body))
+
+	hdr.Round, hdr.Epoch = 19, 2
+	assert.Equal(t, process.ErrInvalidEpoch, bp.CheckBlockValidity(blkc, hdr, body))
+
+	hdr.Round, hdr.Epoch = 20, 2
+	assert.Nil(t, bp.CheckBlockValidity(blkc, hdr, body))
+
+	//an epoch without blocks is not skipped
+	hdr.Round, hdr.Epoch = 45, 4
+	assert.Equal(t, process.ErrInvalidEpoch, bp.CheckBlockValidity(blkc, hdr, body))
+
+	hdr.Round, hdr.Epoch = 45, 2
+	assert.Nil(t, bp.CheckBlockValidity(blkc, hdr, body))
+}
+
 func TestVerifyStateRoot_ShouldWork(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool()
@@ -375,6 +457,10 @@ func TestVerifyStateRoot_ShouldWork(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.True(t, bp.VerifyStateRoot(rootHash))
 }
@@ -398,6 +484,10 @@ func TestBlockProcessor_computeHeaderHashMarshalizerFail1ShouldErr(t *testing.T)
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	hdr, txBlock := createTestHdrTxBlockBody()
 	expectedError := errors.New("marshalizer fail")
@@ -433,6 +523,10 @@ func TestBlockPorcessor_ComputeNewNoncePrevHashShouldWork(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	hdr, txBlock := createTestHdrTxBlockBody()
 	marshalizer.MarshalCalled = func(obj interface{}) (bytes []byte, e error) {
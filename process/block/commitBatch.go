@@ -0,0 +1,104 @@
+package block
+
+import (
+	"sort"
+
+	"github.com/numbatx/gn-numbat/dataRetriever"
+	"github.com/numbatx/gn-numbat/process"
+)
+
+// commitBatch stages the data of a block being committed, grouped by storage unit. Each unit is then written with
+// a single batch, atomic if the unit supports it, and the units are written in the order given at creation, followed
+// by the units first staged afterwards. This way, a block found in the last written unit is complete in all of them
+type commitBatch struct {
+	units []dataRetriever.UnitType
+	data  map[dataRetriever.UnitType]map[string][]byte
+}
+
+func newCommitBatch(units ...dataRetriever.UnitType) *commitBatch {
+	cb := &commitBatch{
+		units: make([]dataRetriever.UnitType, 0, len(units)),
+		data:  make(map[dataRetriever.UnitType]map[string][]byte),
+	}
+
+	for _, unitType := range units {
+		cb.addUnit(unitType)
+	}
+
+	return cb
+}
+
+func (cb *commitBatch) addUnit(unitType dataRetriever.UnitType) map[string][]byte {
+	unitData, ok := cb.data[unitType]
+	if ok {
+		return unitData
+	}
+
+	unitData = make(map[string][]byte)
+	cb.data[unitType] = unitData
+	cb.units = append(cb.units, unitType)
+
+	return unitData
+}
+
+// stage adds the (key, value) pair to the data to be written in the given unit
+func (cb *commitBatch) stage(unitType dataRetriever.UnitType, key []byte, value []byte) {
+	cb.addUnit(unitType)[string(key)] = value
+}
+
+// flush writes the staged data unit by unit, stopping at the first unit which could not be written
+func (cb *commitBatch) flush(store dataRetriever.StorageService) error {
+	for _, unitType := range cb.units {
+		unitData := cb.data[unitType]
+		if len(unitData) == 0 {
+			continue
+		}
+
+		err := store.PutBatch(unitType, unitData)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pendingCommit lists the staged keys of each unit, in the order the units are written, so they can be removed if
+// the flush of the block with the given header hash is interrupted. The keys already found in storage, written by a
+// previously committed block, are left out so their removal does not take that block's data with them
+func (cb *commitBatch) pendingCommit(store dataRetriever.StorageService, headerHash []byte) *process.PendingCommit {
+	pendingCommit := &process.PendingCommit{
+		HeaderHash: headerHash,
+		Units:      make([]process.PendingCommitUnit, 0, len(cb.units)),
+	}
+
+	for _, unitType := range cb.units {
+		unitData := cb.data[unitType]
+		if len(unitData) == 0 {
+			continue
+		}
+
+		keys := make([]string, 0, len(unitData))
+		for key := range unitData {
+			if store.Has(unitType, []byte(key)) == nil {
+				continue
+			}
+			keys = append(keys, key)
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		sort.Strings(keys)
+
+		unit := process.PendingCommitUnit{
+			Unit: unitType,
+			Keys: make([][]byte, len(keys)),
+		}
+		for i, key := range keys {
+			unit.Keys[i] = []byte(key)
+		}
+		pendingCommit.Units = append(pendingCommit.Units, unit)
+	}
+
+	return pendingCommit
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/numbatx/gn-numbat/data/block"
 	"github.com/numbatx/gn-numbat/data/transaction"
 	"github.com/numbatx/gn-numbat/display"
+	"github.com/numbatx/gn-numbat/process"
 	"github.com/numbatx/gn-numbat/storage"
 )
 
@@ -34,6 +35,14 @@ func (sp *shardProcessor) GetTransactionFromPool(senderShardID, destShardID uint
 	return sp.getTransactionFromPool(senderShardID, destShardID, txHash)
 }
 
+func (sp *shardProcessor) ComputeSupplyChange(body block.Body, miniBlockHashes [][]byte) (*process.ShardSupplyChange, error) {
+	return sp.computeSupplyChange(body, miniBlockHashes)
+}
+
+func (sp *shardProcessor) VerifySupplyChange(header *block.Header, body block.Body) error {
+	return sp.verifySupplyChange(header, body)
+}
+
 func (sp *shardProcessor) RequestBlockTransactions(body block.Body) int {
 	return sp.requestBlockTransactions(body)
 }
@@ -54,10 +63,25 @@ func (sp *shardProcessor) DisplayShardBlock(header *block.Header, txBlock block.
 	sp.displayShardBlock(header, txBlock)
 }
 
-func SortTxByNonce(txShardStore storage.Cacher) ([]*transaction.Transaction, [][]byte, error) {
+func SortTxByNonce(txShardStore storage.Cacher) (map[string][][]byte, error) {
 	return sortTxByNonce(txShardStore)
 }
 
+func (sp *shardProcessor) GetTxs(txShardStores []storage.Cacher) ([]*transaction.Transaction, [][]byte, []int) {
+	orderedTxs := sp.getTxs(txShardStores)
+
+	transactions := make([]*transaction.Transaction, len(orderedTxs))
+	txHashes := make([][]byte, len(orderedTxs))
+	storeIndexes := make([]int, len(orderedTxs))
+	for i, stx := range orderedTxs {
+		transactions[i] = stx.tx
+		txHashes[i] = stx.txHash
+		storeIndexes[i] = stx.storeIndex
+	}
+
+	return transactions, txHashes, storeIndexes
+}
+
 func (sp *shardProcessor) GetAllTxsFromMiniBlock(mb *block.MiniBlock, haveTime func() bool) ([]*transaction.Transaction, [][]byte, error) {
 	return sp.getAllTxsFromMiniBlock(mb, haveTime)
 }
@@ -183,3 +207,15 @@ func (mp *metaProcessor) IsShardHeaderValidFinal(currHdr *block.Header, lastHdr
 func (mp *metaProcessor) ChRcvAllHdrs() chan bool {
 	return mp.chRcvAllHdrs
 }
+
+func (sp *shardProcessor) RemoveExpiredTransactions(round uint32) {
+	sp.removeExpiredTransactions(round)
+}
+
+func (sp *shardProcessor) CheckBatchTransfers(body block.Body) error {
+	return sp.checkBatchTransfers(body)
+}
+
+func (sp *shardProcessor) CheckBlockLimits(body block.Body) error {
+	return sp.checkBlockLimits(body)
+}
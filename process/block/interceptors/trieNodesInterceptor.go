@@ -0,0 +1,68 @@
+package interceptors
+
+import (
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/p2p"
+	"github.com/numbatx/gn-numbat/process"
+	"github.com/numbatx/gn-numbat/storage"
+)
+
+// TrieNodesInterceptor represents an interceptor used for the encoded trie nodes sent by the trie nodes resolvers
+// of the peers. The nodes are added in the cache under their hash, where the state syncer picks them up
+type TrieNodesInterceptor struct {
+	*messageChecker
+	marshalizer marshal.Marshalizer
+	cache       storage.Cacher
+	hasher      hashing.Hasher
+}
+
+// NewTrieNodesInterceptor creates a new instance of a TrieNodesInterceptor
+func NewTrieNodesInterceptor(
+	marshalizer marshal.Marshalizer,
+	cache storage.Cacher,
+	hasher hashing.Hasher,
+) (*TrieNodesInterceptor, error) {
+
+	if marshalizer == nil {
+		return nil, process.ErrNilMarshalizer
+	}
+	if cache == nil {
+		return nil, process.ErrNilCacher
+	}
+	if hasher == nil {
+		return nil, process.ErrNilHasher
+	}
+
+	return &TrieNodesInterceptor{
+		messageChecker: &messageChecker{},
+		marshalizer:    marshalizer,
+		cache:          cache,
+		hasher:         hasher,
+	}, nil
+}
+
+// ProcessReceivedMessage will be the callback func from the p2p.Messenger and will be called each time a new message was received
+// (for the topic this validator was registered to)
+func (tni *TrieNodesInterceptor) ProcessReceivedMessage(message p2p.MessageP2P) error {
+	err := tni.checkMessage(message)
+	if err != nil {
+		return err
+	}
+
+	nodes := make([][]byte, 0)
+	err = tni.marshalizer.Unmarshal(&nodes, message.Data())
+	if err != nil {
+		return err
+	}
+
+	for _, node := range nodes {
+		if len(node) == 0 {
+			continue
+		}
+
+		tni.cache.HasOrAdd(tni.hasher.Compute(string(node)), node)
+	}
+
+	return nil
+}
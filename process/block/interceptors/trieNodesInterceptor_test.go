@@ -0,0 +1,84 @@
+package interceptors_test
+
+import (
+	"testing"
+
+	"github.com/numbatx/gn-numbat/process"
+	"github.com/numbatx/gn-numbat/process/block/interceptors"
+	"github.com/numbatx/gn-numbat/process/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+//------- NewTrieNodesInterceptor
+
+func TestNewTrieNodesInterceptor_NilMarshalizerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tni, err := interceptors.NewTrieNodesInterceptor(nil, &mock.CacherStub{}, mock.HasherMock{})
+
+	assert.Equal(t, process.ErrNilMarshalizer, err)
+	assert.Nil(t, tni)
+}
+
+func TestNewTrieNodesInterceptor_NilCacheShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tni, err := interceptors.NewTrieNodesInterceptor(&mock.MarshalizerMock{}, nil, mock.HasherMock{})
+
+	assert.Equal(t, process.ErrNilCacher, err)
+	assert.Nil(t, tni)
+}
+
+func TestNewTrieNodesInterceptor_NilHasherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tni, err := interceptors.NewTrieNodesInterceptor(&mock.MarshalizerMock{}, &mock.CacherStub{}, nil)
+
+	assert.Equal(t, process.ErrNilHasher, err)
+	assert.Nil(t, tni)
+}
+
+func TestNewTrieNodesInterceptor_OkValsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tni, err := interceptors.NewTrieNodesInterceptor(&mock.MarshalizerMock{}, &mock.CacherStub{}, mock.HasherMock{})
+
+	assert.Nil(t, err)
+	assert.NotNil(t, tni)
+}
+
+//------- ProcessReceivedMessage
+
+func TestTrieNodesInterceptor_ProcessReceivedMessageNilMessageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tni, _ := interceptors.NewTrieNodesInterceptor(&mock.MarshalizerMock{}, &mock.CacherStub{}, mock.HasherMock{})
+
+	assert.Equal(t, process.ErrNilMessage, tni.ProcessReceivedMessage(nil))
+}
+
+func TestTrieNodesInterceptor_ProcessReceivedMessageShouldAddNodesUnderTheirHash(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	hasher := mock.HasherMock{}
+	added := make(map[string][]byte)
+	cache := &mock.CacherStub{
+		HasOrAddCalled: func(key []byte, value interface{}) (ok, evicted bool) {
+			added[string(key)] = value.([]byte)
+			return false, false
+		},
+	}
+	tni, _ := interceptors.NewTrieNodesInterceptor(marshalizer, cache, hasher)
+
+	node1 := []byte("node1")
+	node2 := []byte("node2")
+	buff, _ := marshalizer.Marshal([][]byte{node1, node2})
+
+	err := tni.ProcessReceivedMessage(&mock.P2PMessageMock{DataField: buff})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(added))
+	assert.Equal(t, node1, added[string(hasher.Compute(string(node1)))])
+	assert.Equal(t, node2, added[string(hasher.Compute(string(node2)))])
+}
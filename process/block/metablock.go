@@ -337,10 +337,6 @@ func (mp *metaProcessor) CommitBlock(
 	}
 
 	headerHash := mp.hasher.Compute(string(buff))
-	err = mp.store.Put(dataRetriever.MetaBlockUnit, headerHash, buff)
-	if err != nil {
-		return err
-	}
 
 	header, ok := headerHandler.(*block.MetaBlock)
 	if !ok {
@@ -354,17 +350,37 @@ func (mp *metaProcessor) CommitBlock(
 		return err
 	}
 
-	for i := 0; i < len(header.ShardInfo); i++ {
-		buff, err = mp.marshalizer.Marshal(header.ShardInfo[i])
+	// the supply is checked before anything is written, so that a block violating it leaves no trace in storage
+	if mp.supplyChecker != nil {
+		err = mp.checkSupply(header)
 		if err != nil {
 			return err
 		}
+	}
 
-		shardDataHash := mp.hasher.Compute(string(buff))
-		err = mp.store.Put(dataRetriever.MetaShardDataUnit, shardDataHash, buff)
+	// the notarized data and shard headers are written unit by unit, before the metablock and its nonce index. A
+	// metablock found in storage has therefore all its data persisted, while the last committed header hash, saved
+	// last, marks the metablock committed
+	units := []dataRetriever.UnitType{
+		dataRetriever.MetaShardDataUnit,
+		dataRetriever.MetaPeerDataUnit,
+		dataRetriever.BlockHeaderUnit,
+	}
+	for i := uint32(0); i < mp.shardCoordinator.NumberOfShards(); i++ {
+		units = append(units, dataRetriever.ShardHdrNonceHashDataUnitFor(i))
+	}
+	units = append(units, dataRetriever.MetaBlockUnit, dataRetriever.MetaHdrNonceHashDataUnit)
+	batch := newCommitBatch(units...)
+	batch.stage(dataRetriever.MetaBlockUnit, headerHash, buff)
+
+	for i := 0; i < len(header.ShardInfo); i++ {
+		buff, err = mp.marshalizer.Marshal(header.ShardInfo[i])
 		if err != nil {
 			return err
 		}
+
+		shardDataHash := mp.hasher.Compute(string(buff))
+		batch.stage(dataRetriever.MetaShardDataUnit, shardDataHash, buff)
 	}
 
 	for i := 0; i < len(header.PeerInfo); i++ {
@@ -374,10 +390,7 @@ func (mp *metaProcessor) CommitBlock(
 		}
 
 		peerDataHash := mp.hasher.Compute(string(buff))
-		err = mp.store.Put(dataRetriever.MetaPeerDataUnit, peerDataHash, buff)
-		if err != nil {
-			return err
-		}
+		batch.stage(dataRetriever.MetaPeerDataUnit, peerDataHash, buff)
 	}
 
 	headerNoncePool := mp.dataPool.MetaBlockNonces()
@@ -386,7 +399,7 @@ func (mp *metaProcessor) CommitBlock(
 		return err
 	}
 
-	_ = headerNoncePool.Put(headerHandler.GetNonce(), headerHash)
+	mp.stageNonceToHash(batch, dataRetriever.MetaHdrNonceHashDataUnit, headerHandler.GetNonce(), headerHash)
 
 	for i := 0; i < len(header.ShardInfo); i++ {
 		shardData := header.ShardInfo[i]
@@ -400,13 +413,27 @@ func (mp *metaProcessor) CommitBlock(
 			return err
 		}
 
-		err = mp.store.Put(dataRetriever.BlockHeaderUnit, shardData.HeaderHash, buff)
-		if err != nil {
-			return err
-		}
+		batch.stage(dataRetriever.BlockHeaderUnit, shardData.HeaderHash, buff)
+
+		hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(header.ShardId)
+		mp.stageNonceToHash(batch, hdrNonceHashDataUnit, header.Nonce, shardData.HeaderHash)
 	}
 
-	_, err = mp.accounts.Commit()
+	// the staged keys are persisted first, so that a restarted node can remove the data of a metablock whose flush
+	// was interrupted
+	err = process.SavePendingCommit(mp.store, mp.marshalizer, mp.shardCoordinator.SelfId(), batch.pendingCommit(mp.store, headerHash))
+	if err != nil {
+		return err
+	}
+
+	err = batch.flush(mp.store)
+	if err != nil {
+		return err
+	}
+
+	_ = headerNoncePool.Put(headerHandler.GetNonce(), headerHash)
+
+	rootHash, err := mp.accounts.Commit()
 	if err != nil {
 		return err
 	}
@@ -438,11 +465,49 @@ func (mp *metaProcessor) CommitBlock(
 		log.Info(errNotCritical.Error())
 	}
 
+	errNotCritical = mp.pruneState(header.Nonce, rootHash)
+	if errNotCritical != nil {
+		log.Info(errNotCritical.Error())
+	}
+
+	// the pending commit is kept if the marker could not be written, so the metablock data is removed on restart
+	errNotCritical = process.SaveLastCommittedHeaderHash(mp.store, mp.shardCoordinator.SelfId(), headerHash)
+	if errNotCritical == nil {
+		errNotCritical = process.RemovePendingCommit(mp.store, mp.shardCoordinator.SelfId())
+	}
+	if errNotCritical != nil {
+		log.Info(errNotCritical.Error())
+	}
+
 	go mp.displayMetaBlock(header)
 
 	return nil
 }
 
+// checkSupply hands to the supply checker the supply changes carried by the shard headers notarized by the given
+// metablock, then lets it match the value of the cross shard miniblocks whose processing the metablock notarizes
+func (mp *metaProcessor) checkSupply(header *block.MetaBlock) error {
+	for i := 0; i < len(header.ShardInfo); i++ {
+		shardData := header.ShardInfo[i]
+		shardHeader, err := process.GetShardHeaderFromPool(shardData.HeaderHash, mp.dataPool.ShardHeaders())
+		if err != nil {
+			return err
+		}
+
+		change := supplyChangeFromHeader(shardHeader)
+		if change == nil {
+			return process.ErrNilSupplyDelta
+		}
+
+		err = mp.supplyChecker.CheckShardBlock(change)
+		if err != nil {
+			return err
+		}
+	}
+
+	return mp.supplyChecker.CheckMetaBlock(header)
+}
+
 func (mp *metaProcessor) createLastNotarizedHdrs(header *block.MetaBlock) error {
 	mp.mutLastNotarizedHdrs.Lock()
 	defer mp.mutLastNotarizedHdrs.Unlock()
@@ -919,6 +984,7 @@ func (mp *metaProcessor) CreateBlockHeader(bodyHandler data.BodyHandler, round i
 	header := &block.MetaBlock{
 		ShardInfo:    make([]block.ShardData, 0),
 		PeerInfo:     make([]block.PeerData, 0),
+		Epoch:        mp.epochOfRound(uint32(round)),
 		PrevRandSeed: make([]byte, 0),
 		RandSeed:     make([]byte, 0),
 	}
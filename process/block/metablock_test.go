@@ -3,6 +3,7 @@ package block_test
 import (
 	"bytes"
 	"errors"
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/numbatx/gn-numbat/process"
 	blproc "github.com/numbatx/gn-numbat/process/block"
 	"github.com/numbatx/gn-numbat/process/mock"
+	"github.com/numbatx/gn-numbat/sharding"
 	"github.com/numbatx/gn-numbat/storage"
 	"github.com/stretchr/testify/assert"
 )
@@ -534,7 +536,10 @@ func TestMetaProcessor_CommitBlockStorageFailsForHeaderShouldErr(t *testing.T) {
 	hdr := createMetaBlockHeader()
 	body := &block.MetaBlockBody{}
 	hdrUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return errPersister
 		},
 	}
@@ -573,7 +578,10 @@ func TestMetaProcessor_CommitBlockStorageFailsForShardDataShouldErr(t *testing.T
 	body := &block.MetaBlockBody{}
 
 	shardDataUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return errPersister
 		},
 	}
@@ -616,12 +624,18 @@ func TestMetaProcessor_CommitBlockStorageFailsForPeerDataShouldErr(t *testing.T)
 	body := &block.MetaBlockBody{}
 
 	shardDataUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return nil
 		},
 	}
 	peerDataUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return errPersister
 		},
 	}
@@ -663,12 +677,18 @@ func TestMetaProcessor_CommitBlockNilNoncesDataPoolShouldErr(t *testing.T) {
 	hdr := createMetaBlockHeader()
 	body := &block.MetaBlockBody{}
 	shardDataUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return nil
 		},
 	}
 	peerDataUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return nil
 		},
 	}
@@ -711,12 +731,18 @@ func TestMetaProcessor_CommitBlockNoTxInPoolShouldErr(t *testing.T) {
 	fd := &mock.ForkDetectorMock{}
 	hasher := &mock.HasherStub{}
 	shardDataUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return nil
 		},
 	}
 	peerDataUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return nil
 		},
 	}
@@ -776,17 +802,26 @@ func TestMetaProcessor_CommitBlockOkValsShouldWork(t *testing.T) {
 	}
 	hasher := &mock.HasherStub{}
 	blockHeaderUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return nil
 		},
 	}
 	shardDataUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return nil
 		},
 	}
 	peerDataUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return nil
 		},
 	}
@@ -836,6 +871,355 @@ func TestMetaProcessor_CommitBlockOkValsShouldWork(t *testing.T) {
 	time.Sleep(time.Second)
 }
 
+func TestMetaProcessor_CommitBlockShouldSaveHeadersHashesByNonce(t *testing.T) {
+	t.Parallel()
+
+	mdp := initMetaDataPool()
+	rootHash := []byte("rootHash")
+	hdr := createMetaBlockHeader()
+	accounts := &mock.AccountsStub{
+		CommitCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+		RootHashCalled: func() []byte {
+			return rootHash
+		},
+	}
+	fd := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState) error {
+			return nil
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return []byte("meta hash")
+	}
+	store := initStore()
+	store.AddStorer(dataRetriever.MetaShardDataUnit, generateTestUnit())
+	store.AddStorer(dataRetriever.MetaPeerDataUnit, generateTestUnit())
+	store.AddStorer(dataRetriever.MetaHdrNonceHashDataUnit, generateTestUnit())
+	store.AddStorer(dataRetriever.ShardHdrNonceHashDataUnitFor(0), generateTestUnit())
+	converter := mock.NewNonceHashConverterMock()
+
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(1)
+	shardCoordinator.CurrentShard = sharding.MetachainShardId
+
+	mp, _ := blproc.NewMetaProcessor(
+		accounts,
+		mdp,
+		fd,
+		shardCoordinator,
+		hasher,
+		&mock.MarshalizerMock{},
+		store,
+		func(shardID uint32, hdrHash []byte) {},
+	)
+	mp.SetLastNotarizedHeadersSlice(map[uint32]data.HeaderHandler{0: &block.Header{Nonce: 0, Round: 0}})
+	_ = mp.SetUint64Converter(converter)
+
+	mdp.ShardHeadersCalled = func() storage.Cacher {
+		cs := &mock.CacherStub{}
+		cs.RegisterHandlerCalled = func(i func(key []byte)) {
+		}
+		cs.PeekCalled = func(key []byte) (value interface{}, ok bool) {
+			return &block.Header{ShardId: 0, Nonce: 7}, true
+		}
+		cs.RemoveCalled = func(key []byte) {
+		}
+		cs.LenCalled = func() int {
+			return 0
+		}
+		return cs
+	}
+
+	err := mp.CommitBlock(createTestBlockchain(), hdr, &block.MetaBlockBody{})
+	assert.Nil(t, err)
+
+	metaHash, err := store.Get(dataRetriever.MetaHdrNonceHashDataUnit, converter.ToByteSlice(hdr.Nonce))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("meta hash"), metaHash)
+	shardHash, err := store.Get(dataRetriever.ShardHdrNonceHashDataUnitFor(0), converter.ToByteSlice(7))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("hdr_hash1"), shardHash)
+	lastHash, err := process.GetLastCommittedHeaderHash(store, sharding.MetachainShardId)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("meta hash"), lastHash)
+	_, err = store.Get(dataRetriever.BootstrapUnit, process.PendingCommitKey(sharding.MetachainShardId))
+	assert.NotNil(t, err)
+	//this should sleep as there is an async call to display current header and block in CommitBlock
+	time.Sleep(time.Second)
+}
+
+func TestMetaProcessor_CommitBlockInterruptedFlushShouldLeaveThePendingCommit(t *testing.T) {
+	t.Parallel()
+
+	mdp := initMetaDataPool()
+	hdr := createMetaBlockHeader()
+	accounts := &mock.AccountsStub{
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return []byte("meta hash")
+	}
+	errFlush := errors.New("flush error")
+	store := initStore()
+	store.AddStorer(dataRetriever.MetaBlockUnit, &mock.StorerStub{
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
+			return errFlush
+		},
+	})
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(1)
+	shardCoordinator.CurrentShard = sharding.MetachainShardId
+	mp, _ := blproc.NewMetaProcessor(
+		accounts,
+		mdp,
+		&mock.ForkDetectorMock{},
+		shardCoordinator,
+		hasher,
+		&mock.MarshalizerMock{},
+		store,
+		func(shardID uint32, hdrHash []byte) {},
+	)
+	mdp.ShardHeadersCalled = func() storage.Cacher {
+		return &mock.CacherStub{
+			PeekCalled: func(key []byte) (value interface{}, ok bool) {
+				return &block.Header{ShardId: 0, Nonce: 7}, true
+			},
+		}
+	}
+
+	err := mp.CommitBlock(createTestBlockchain(), hdr, &block.MetaBlockBody{})
+	assert.Equal(t, errFlush, err)
+
+	buff, err := store.Get(dataRetriever.BootstrapUnit, process.PendingCommitKey(sharding.MetachainShardId))
+	assert.Nil(t, err)
+	pendingCommit := &process.PendingCommit{}
+	_ = (&mock.MarshalizerMock{}).Unmarshal(pendingCommit, buff)
+	assert.Equal(t, []byte("meta hash"), pendingCommit.HeaderHash)
+	assert.Equal(t, dataRetriever.MetaShardDataUnit, pendingCommit.Units[0].Unit)
+	assert.Equal(t, dataRetriever.BlockHeaderUnit, pendingCommit.Units[2].Unit)
+	assert.Equal(t, dataRetriever.MetaBlockUnit, pendingCommit.Units[3].Unit)
+
+	_, err = store.Get(dataRetriever.BlockHeaderUnit, []byte("hdr_hash1"))
+	assert.Nil(t, err)
+	store.AddStorer(dataRetriever.MetaBlockUnit, generateTestUnit())
+
+	err = process.DiscardPendingCommit(store, &mock.MarshalizerMock{}, sharding.MetachainShardId, nil)
+	assert.Nil(t, err)
+	_, err = store.Get(dataRetriever.BlockHeaderUnit, []byte("hdr_hash1"))
+	assert.NotNil(t, err)
+	_, err = store.Get(dataRetriever.BootstrapUnit, process.PendingCommitKey(sharding.MetachainShardId))
+	assert.NotNil(t, err)
+}
+
+func TestMetaProcessor_CommitBlockSupplyViolationShouldNotCommit(t *testing.T) {
+	t.Parallel()
+
+	mdp := initMetaDataPool()
+	hdr := createMetaBlockHeader()
+	commitCalled := false
+	accounts := &mock.AccountsStub{
+		CommitCalled: func() (i []byte, e error) {
+			commitCalled = true
+			return nil, nil
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+	putCalled := false
+	store := initStore()
+	for _, unit := range []dataRetriever.UnitType{
+		dataRetriever.MetaBlockUnit,
+		dataRetriever.BlockHeaderUnit,
+		dataRetriever.MetaShardDataUnit,
+		dataRetriever.MetaPeerDataUnit,
+	} {
+		store.AddStorer(unit, &mock.StorerStub{
+			HasCalled: func(key []byte) error {
+				return errors.New("key not found")
+			},
+			PutBatchCalled: func(data map[string][]byte) error {
+				putCalled = true
+				return nil
+			},
+		})
+	}
+	mp, _ := blproc.NewMetaProcessor(
+		accounts,
+		mdp,
+		&mock.ForkDetectorMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		store,
+		func(shardID uint32, hdrHash []byte) {},
+	)
+	mdp.ShardHeadersCalled = func() storage.Cacher {
+		return &mock.CacherStub{
+			PeekCalled: func(key []byte) (value interface{}, ok bool) {
+				return &block.Header{SupplyDelta: big.NewInt(0)}, true
+			},
+		}
+	}
+	var checkedHeader *block.MetaBlock
+	_ = mp.SetSupplyChecker(&mock.SupplyCheckerStub{
+		CheckMetaBlockCalled: func(header *block.MetaBlock) error {
+			checkedHeader = header
+			return process.ErrSupplyInvariantViolated
+		},
+	})
+
+	err := mp.CommitBlock(createTestBlockchain(), hdr, &block.MetaBlockBody{})
+
+	assert.Equal(t, process.ErrSupplyInvariantViolated, err)
+	assert.Equal(t, hdr, checkedHeader)
+	assert.False(t, commitCalled)
+	assert.False(t, putCalled)
+}
+
+func TestMetaProcessor_CommitBlockShardHeaderWithoutSupplyChangeShouldNotCommit(t *testing.T) {
+	t.Parallel()
+
+	mdp := initMetaDataPool()
+	hdr := createMetaBlockHeader()
+	commitCalled := false
+	accounts := &mock.AccountsStub{
+		CommitCalled: func() (i []byte, e error) {
+			commitCalled = true
+			return nil, nil
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+	putCalled := false
+	store := initStore()
+	for _, unit := range []dataRetriever.UnitType{
+		dataRetriever.MetaBlockUnit,
+		dataRetriever.BlockHeaderUnit,
+		dataRetriever.MetaShardDataUnit,
+		dataRetriever.MetaPeerDataUnit,
+	} {
+		store.AddStorer(unit, &mock.StorerStub{
+			HasCalled: func(key []byte) error {
+				return errors.New("key not found")
+			},
+			PutBatchCalled: func(data map[string][]byte) error {
+				putCalled = true
+				return nil
+			},
+		})
+	}
+	mp, _ := blproc.NewMetaProcessor(
+		accounts,
+		mdp,
+		&mock.ForkDetectorMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		store,
+		func(shardID uint32, hdrHash []byte) {},
+	)
+	mdp.ShardHeadersCalled = func() storage.Cacher {
+		return &mock.CacherStub{
+			PeekCalled: func(key []byte) (value interface{}, ok bool) {
+				return &block.Header{}, true
+			},
+		}
+	}
+	var checkedHeader *block.MetaBlock
+	_ = mp.SetSupplyChecker(&mock.SupplyCheckerStub{
+		CheckMetaBlockCalled: func(header *block.MetaBlock) error {
+			checkedHeader = header
+			return process.ErrSupplyInvariantViolated
+		},
+	})
+
+	err := mp.CommitBlock(createTestBlockchain(), hdr, &block.MetaBlockBody{})
+
+	assert.Equal(t, process.ErrNilSupplyDelta, err)
+	assert.Nil(t, checkedHeader)
+	assert.False(t, commitCalled)
+	assert.False(t, putCalled)
+}
+
+func TestMetaProcessor_CommitBlockShouldFeedTheSupplyChangesOfTheNotarizedShardHeaders(t *testing.T) {
+	t.Parallel()
+
+	mdp := initMetaDataPool()
+	hdr := createMetaBlockHeader()
+	accounts := &mock.AccountsStub{
+		CommitCalled: func() (i []byte, e error) {
+			return nil, nil
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+	mp, _ := blproc.NewMetaProcessor(
+		accounts,
+		mdp,
+		&mock.ForkDetectorMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		initStore(),
+		func(shardID uint32, hdrHash []byte) {},
+	)
+	shardHeader := &block.Header{
+		ShardId: 0,
+		Nonce:   7,
+		MiniBlockHeaders: []block.MiniBlockHeader{
+			{Hash: []byte("outgoing"), SenderShardID: 0, ReceiverShardID: 1, Value: big.NewInt(2)},
+		},
+		SupplyDelta: big.NewInt(-3),
+		BurntFees:   big.NewInt(1),
+	}
+	mdp.ShardHeadersCalled = func() storage.Cacher {
+		return &mock.CacherStub{
+			PeekCalled: func(key []byte) (value interface{}, ok bool) {
+				return shardHeader, true
+			},
+		}
+	}
+	checkedChanges := make([]*process.ShardSupplyChange, 0)
+	metaChecked := false
+	_ = mp.SetSupplyChecker(&mock.SupplyCheckerStub{
+		CheckShardBlockCalled: func(change *process.ShardSupplyChange) error {
+			assert.False(t, metaChecked)
+			checkedChanges = append(checkedChanges, change)
+			return nil
+		},
+		CheckMetaBlockCalled: func(header *block.MetaBlock) error {
+			metaChecked = true
+			return process.ErrSupplyInvariantViolated
+		},
+	})
+
+	err := mp.CommitBlock(createTestBlockchain(), hdr, &block.MetaBlockBody{})
+
+	assert.Equal(t, process.ErrSupplyInvariantViolated, err)
+	assert.True(t, metaChecked)
+	assert.Equal(t, len(hdr.ShardInfo), len(checkedChanges))
+	assert.Equal(t, &process.ShardSupplyChange{
+		ShardId: 0,
+		Nonce:   7,
+		Delta:   big.NewInt(-3),
+		Outgoing: map[string]*process.CrossShardValue{
+			"outgoing": {ShardId: 1, Value: big.NewInt(2)},
+		},
+		Incoming:  make(map[string]*process.CrossShardValue),
+		BurntFees: big.NewInt(1),
+	}, checkedChanges[0])
+}
+
 func TestBlockProc_RequestTransactionFromNetwork(t *testing.T) {
 	t.Parallel()
 
@@ -1,7 +1,10 @@
 package block
 
 import (
+	"bytes"
+	"container/heap"
 	"fmt"
+	"math/big"
 	"sort"
 	"sync"
 	"time"
@@ -24,8 +27,6 @@ var txCounterMutex = sync.RWMutex{}
 var txsCurrentBlockProcessed = 0
 var txsTotalProcessed = 0
 
-const maxTransactionsInBlock = 15000
-
 // shardProcessor implements shardProcessor interface and actually it tries to execute block
 type shardProcessor struct {
 	*baseProcessor
@@ -39,6 +40,13 @@ type shardProcessor struct {
 	mutCrossTxsForBlock  sync.RWMutex
 	crossTxsForBlock     map[string]*transaction.Transaction
 	onRequestMiniBlock   func(shardId uint32, mbHash []byte)
+	feeHandler           process.FeeHandler
+	rewardsAddress       state.AddressContainer
+	maxTxsInBlock        uint32
+	maxGasLimitInBlock   uint64
+	txsExecutor          process.TransactionsExecutor
+	mutLastPrunedRound   sync.Mutex
+	lastPrunedRound      uint32
 }
 
 // NewShardProcessor creates a new shardProcessor object
@@ -54,6 +62,10 @@ func NewShardProcessor(
 	blocksTracker process.BlocksTracker,
 	requestTransactionHandler func(shardId uint32, txHashes [][]byte),
 	requestMiniBlockHandler func(shardId uint32, miniblockHash []byte),
+	feeHandler process.FeeHandler,
+	rewardsAddress state.AddressContainer,
+	maxTxsInBlock uint32,
+	maxGasLimitInBlock uint64,
 ) (*shardProcessor, error) {
 
 	err := checkProcessorNilParameters(
@@ -82,6 +94,21 @@ func NewShardProcessor(
 	if requestMiniBlockHandler == nil {
 		return nil, process.ErrNilMiniBlocksRequestHandler
 	}
+	if feeHandler == nil {
+		return nil, process.ErrNilFeeHandler
+	}
+	if rewardsAddress == nil {
+		return nil, process.ErrNilRewardsAddress
+	}
+	if shardCoordinator.ComputeId(rewardsAddress) != shardCoordinator.SelfId() {
+		return nil, process.ErrRewardsAddressNotInSelfShard
+	}
+	if maxTxsInBlock == 0 {
+		return nil, process.ErrInvalidMaxTxsInBlock
+	}
+	if maxGasLimitInBlock == 0 {
+		return nil, process.ErrInvalidMaxGasLimitInBlock
+	}
 
 	base := &baseProcessor{
 		accounts:         accounts,
@@ -93,10 +120,14 @@ func NewShardProcessor(
 	}
 
 	sp := shardProcessor{
-		baseProcessor: base,
-		dataPool:      dataPool,
-		txProcessor:   txProcessor,
-		blocksTracker: blocksTracker,
+		baseProcessor:      base,
+		dataPool:           dataPool,
+		txProcessor:        txProcessor,
+		blocksTracker:      blocksTracker,
+		feeHandler:         feeHandler,
+		rewardsAddress:     rewardsAddress,
+		maxTxsInBlock:      maxTxsInBlock,
+		maxGasLimitInBlock: maxGasLimitInBlock,
 	}
 
 	sp.chRcvAllTxs = make(chan bool)
@@ -154,6 +185,8 @@ func (sp *shardProcessor) ProcessBlock(
 		return process.ErrWrongTypeAssertion
 	}
 
+	sp.removeExpiredTransactions(header.Round)
+
 	log.Info(fmt.Sprintf("Total txs in pool: %d\n", sp.getNrTxsWithDst(header.ShardId)))
 
 	requestedTxs := sp.requestBlockTransactions(body)
@@ -175,22 +208,43 @@ func (sp *shardProcessor) ProcessBlock(
 		return process.ErrAccountStateDirty
 	}
 
+	err = sp.checkBlockLimits(body)
+	if err != nil {
+		return err
+	}
+
+	err = sp.checkBatchTransfers(body)
+	if err != nil {
+		return err
+	}
+
 	defer func() {
 		if err != nil {
 			sp.RevertAccountState()
 		}
 	}()
 
+	sp.txProcessor.ClearExecutionErrors()
 	err = sp.processBlockTransactions(body, int32(header.Round), haveTime)
 	if err != nil {
 		return err
 	}
 
+	err = sp.creditBlockFees(body)
+	if err != nil {
+		return err
+	}
+
 	if !sp.verifyStateRoot(header.GetRootHash()) {
 		err = process.ErrRootStateMissmatch
 		return err
 	}
 
+	err = sp.verifySupplyChange(header, body)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -341,8 +395,18 @@ func (sp *shardProcessor) restoreMetaBlockIntoPool(miniBlockHashes map[int][]byt
 // CreateBlockBody creates a a list of miniblocks by filling them with transactions out of the transactions pools
 // as long as the transactions limit for the block has not been reached and there is still time to add transactions
 func (sp *shardProcessor) CreateBlockBody(round int32, haveTime func() bool) (data.BodyHandler, error) {
-	miniBlocks, err := sp.createMiniBlocks(sp.shardCoordinator.NumberOfShards(), maxTransactionsInBlock, round, haveTime)
+	if round >= 0 {
+		sp.removeExpiredTransactions(uint32(round))
+	}
 
+	sp.txProcessor.ClearExecutionErrors()
+	miniBlocks, err := sp.createMiniBlocks(sp.shardCoordinator.NumberOfShards(), int(sp.maxTxsInBlock), round, haveTime)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = sp.creditBlockFees(miniBlocks)
 	if err != nil {
 		return nil, err
 	}
@@ -350,12 +414,82 @@ func (sp *shardProcessor) CreateBlockBody(round int32, haveTime func() bool) (da
 	return miniBlocks, nil
 }
 
+// computeBlockFees returns the sum of the fees paid by the transactions of the given block body. Only the
+// transactions sent from this shard are counted, as their fees were charged by this shard
+func (sp *shardProcessor) computeBlockFees(body block.Body) (*big.Int, error) {
+	totalFees := big.NewInt(0)
+
+	for _, miniBlock := range body {
+		if miniBlock.SenderShardID != sp.shardCoordinator.SelfId() {
+			continue
+		}
+
+		for _, txHash := range miniBlock.TxHashes {
+			tx := sp.getTransactionFromPool(miniBlock.SenderShardID, miniBlock.ReceiverShardID, txHash)
+			if tx == nil {
+				return nil, process.ErrMissingTransaction
+			}
+			if sp.isCrossShardBatchTransfer(tx, miniBlock) {
+				continue
+			}
+
+			totalFees.Add(totalFees, sp.feeHandler.ComputeFee(tx))
+		}
+	}
+
+	return totalFees, nil
+}
+
+// creditBlockFees adds the fees accumulated in the given block body to the rewards address balance, so that they
+// become part of the block state root and get persisted when the block is committed. The rewards address is
+// always an address of this shard, so the fees stay in the shard that collected them
+func (sp *shardProcessor) creditBlockFees(body block.Body) error {
+	totalFees, err := sp.computeBlockFees(body)
+	if err != nil {
+		return err
+	}
+
+	if totalFees.Sign() == 0 {
+		return nil
+	}
+
+	accountHandler, err := sp.accounts.GetAccountWithJournal(sp.rewardsAddress)
+	if err != nil {
+		return err
+	}
+
+	account, ok := accountHandler.(*state.Account)
+	if !ok {
+		return process.ErrWrongTypeAssertion
+	}
+
+	return account.SetBalanceWithJournal(big.NewInt(0).Add(account.Balance, totalFees))
+}
+
 func (sp *shardProcessor) processBlockTransactions(body block.Body, round int32, haveTime func() time.Duration) error {
 	// basic validation already done in interceptors
 	txPool := sp.dataPool.Transactions()
 
 	for i := 0; i < len(body); i++ {
 		miniBlock := body[i]
+		if sp.txsExecutor != nil {
+			if haveTime() < 0 {
+				return process.ErrTimeIsOut
+			}
+
+			snapshot := sp.accounts.JournalLen()
+			err := sp.executeMiniBlockTransactions(miniBlock, round)
+			if err == nil {
+				continue
+			}
+
+			// the miniblock is processed again, sequentially, so that the bad transaction is found and removed
+			errAccountState := sp.accounts.RevertToSnapshot(snapshot)
+			if errAccountState != nil {
+				return errAccountState
+			}
+		}
+
 		for j := 0; j < len(miniBlock.TxHashes); j++ {
 			if haveTime() < 0 {
 				return process.ErrTimeIsOut
@@ -363,6 +497,10 @@ func (sp *shardProcessor) processBlockTransactions(body block.Body, round int32,
 
 			txHash := miniBlock.TxHashes[j]
 			tx := sp.getTransactionFromPool(miniBlock.SenderShardID, miniBlock.ReceiverShardID, txHash)
+			if tx != nil && sp.isCrossShardBatchTransfer(tx, miniBlock) {
+				continue
+			}
+
 			err := sp.processAndRemoveBadTransaction(
 				txHash,
 				tx,
@@ -405,10 +543,6 @@ func (sp *shardProcessor) CommitBlock(
 	}
 
 	headerHash := sp.hasher.Compute(string(buff))
-	err = sp.store.Put(dataRetriever.BlockHeaderUnit, headerHash, buff)
-	if err != nil {
-		return err
-	}
 
 	header, ok := headerHandler.(*block.Header)
 	if !ok {
@@ -422,17 +556,37 @@ func (sp *shardProcessor) CommitBlock(
 		return err
 	}
 
-	for i := 0; i < len(body); i++ {
-		buff, err = sp.marshalizer.Marshal(body[i])
+	// the supply is checked before anything is written, so that a block violating it leaves no trace in storage
+	if sp.supplyChecker != nil {
+		err = sp.checkSupply(header)
 		if err != nil {
 			return err
 		}
+	}
 
-		miniBlockHash := sp.hasher.Compute(string(buff))
-		err = sp.store.Put(dataRetriever.MiniBlockUnit, miniBlockHash, buff)
+	// the block data is written unit by unit, before the header and its nonce index. A header found in storage has
+	// therefore all its data persisted, while the last committed header hash, saved last, marks the block committed
+	hdrNonceHashDataUnit := dataRetriever.ShardHdrNonceHashDataUnitFor(sp.shardCoordinator.SelfId())
+	batch := newCommitBatch(
+		dataRetriever.TransactionUnit,
+		dataRetriever.TransactionResultUnit,
+		dataRetriever.MiniBlockUnit,
+		dataRetriever.BlockHeaderUnit,
+		hdrNonceHashDataUnit,
+	)
+	batch.stage(dataRetriever.BlockHeaderUnit, headerHash, buff)
+
+	miniBlockHashes := make([][]byte, len(body))
+	for i := 0; i < len(body); i++ {
+		buff, err = sp.marshalizer.Marshal(body[i])
 		if err != nil {
 			return err
 		}
+
+		miniBlockHash := sp.hasher.Compute(string(buff))
+		batch.stage(dataRetriever.MiniBlockUnit, miniBlockHash, buff)
+
+		miniBlockHashes[i] = miniBlockHash
 	}
 
 	headerNoncePool := sp.dataPool.HeadersNonces()
@@ -441,7 +595,7 @@ func (sp *shardProcessor) CommitBlock(
 		return err
 	}
 
-	_ = headerNoncePool.Put(headerHandler.GetNonce(), headerHash)
+	sp.stageNonceToHash(batch, hdrNonceHashDataUnit, headerHandler.GetNonce(), headerHash)
 
 	for i := 0; i < len(body); i++ {
 		miniBlock := (body)[i]
@@ -458,25 +612,70 @@ func (sp *shardProcessor) CommitBlock(
 				return err
 			}
 
-			err = sp.store.Put(dataRetriever.TransactionUnit, txHash, buff)
+			batch.stage(dataRetriever.TransactionUnit, txHash, buff)
+
+			if sp.isCrossShardBatchTransfer(tx, miniBlock) {
+				// the execution result is saved for the intra shard miniblock
+				continue
+			}
+
+			err = sp.stageExecutionResult(batch, tx, txHash, miniBlock, miniBlockHashes[i], header, headerHash)
 			if err != nil {
 				return err
 			}
 		}
 	}
 
-	_, err = sp.accounts.Commit()
+	err = sp.setStorersEpoch(
+		header.Epoch,
+		dataRetriever.TransactionUnit,
+		dataRetriever.TransactionResultUnit,
+		dataRetriever.MiniBlockUnit,
+		dataRetriever.BlockHeaderUnit,
+	)
 	if err != nil {
 		return err
 	}
 
+	// the staged keys are persisted first, so that a restarted node can remove the data of a block whose flush was
+	// interrupted
+	err = process.SavePendingCommit(sp.store, sp.marshalizer, sp.shardCoordinator.SelfId(), batch.pendingCommit(sp.store, headerHash))
+	if err != nil {
+		return err
+	}
+
+	err = batch.flush(sp.store)
+	if err != nil {
+		return err
+	}
+
+	_ = headerNoncePool.Put(headerHandler.GetNonce(), headerHash)
+
+	stateDiff, errNotCritical := sp.accounts.StateDiff()
+	if errNotCritical != nil {
+		log.Info(errNotCritical.Error())
+	}
+
+	if stateDiff != nil {
+		errNotCritical = sp.saveStateDiff(headerHash, stateDiff)
+		if errNotCritical != nil {
+			log.Info(errNotCritical.Error())
+		}
+	}
+
+	rootHash, err := sp.accounts.Commit()
+	if err != nil {
+		return err
+	}
+
+	sp.txProcessor.ClearExecutionErrors()
 	sp.blocksTracker.AddBlock(header)
 
 	log.Info(fmt.Sprintf("shardBlock with nonce %d and hash %s has been committed successfully\n",
 		header.Nonce,
 		core.ToB64(headerHash)))
 
-	errNotCritical := sp.removeTxBlockFromPools(body)
+	errNotCritical = sp.removeTxBlockFromPools(body)
 	if errNotCritical != nil {
 		log.Info(errNotCritical.Error())
 	}
@@ -491,6 +690,11 @@ func (sp *shardProcessor) CommitBlock(
 		log.Info(errNotCritical.Error())
 	}
 
+	errNotCritical = sp.pruneState(header.Nonce, rootHash)
+	if errNotCritical != nil {
+		log.Info(errNotCritical.Error())
+	}
+
 	err = chainHandler.SetCurrentBlockBody(body)
 	if err != nil {
 		return err
@@ -503,12 +707,231 @@ func (sp *shardProcessor) CommitBlock(
 
 	chainHandler.SetCurrentBlockHeaderHash(headerHash)
 
+	// the pending commit is kept if the marker could not be written, so the block data is removed on restart
+	errNotCritical = process.SaveLastCommittedHeaderHash(sp.store, sp.shardCoordinator.SelfId(), headerHash)
+	if errNotCritical == nil {
+		errNotCritical = process.RemovePendingCommit(sp.store, sp.shardCoordinator.SelfId())
+	}
+	if errNotCritical != nil {
+		log.Info(errNotCritical.Error())
+	}
+
 	// write data to log
 	go sp.displayShardBlock(header, body)
 
 	return nil
 }
 
+// saveStateDiff persists the changes made to the accounts by the block being committed, so they can be audited and
+// unwound on rollback without needing the trie nodes of the previous state
+func (sp *shardProcessor) saveStateDiff(headerHash []byte, diff *state.StateDiff) error {
+	buff, err := sp.marshalizer.Marshal(diff)
+	if err != nil {
+		return err
+	}
+
+	return sp.store.Put(dataRetriever.StateDiffUnit, headerHash, buff)
+}
+
+// checkSupply hands to the supply checker the supply change carried by the header of the block being committed,
+// which was verified against the block execution in ProcessBlock
+func (sp *shardProcessor) checkSupply(header *block.Header) error {
+	change := supplyChangeFromHeader(header)
+	if change == nil {
+		return process.ErrNilSupplyDelta
+	}
+
+	return sp.supplyChecker.CheckShardBlock(change)
+}
+
+// computeBlockSupplyChange returns the supply change caused by the given block body, the change of the balances
+// being taken from the not yet committed changes of the accounts
+func (sp *shardProcessor) computeBlockSupplyChange(
+	body block.Body,
+	miniBlockHashes [][]byte,
+	diff *state.StateDiff,
+) (*process.ShardSupplyChange, error) {
+
+	change, err := sp.computeSupplyChange(body, miniBlockHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, accountDiff := range diff.Accounts {
+		if accountDiff.NewBalance != nil {
+			change.Delta.Add(change.Delta, accountDiff.NewBalance)
+		}
+		if accountDiff.OldBalance != nil {
+			change.Delta.Sub(change.Delta, accountDiff.OldBalance)
+		}
+	}
+
+	return change, nil
+}
+
+// setHeaderSupplyChange fills the given header with the supply change caused by its block
+func setHeaderSupplyChange(header *block.Header, change *process.ShardSupplyChange) {
+	header.SupplyDelta = change.Delta
+	header.BurntFees = change.BurntFees
+
+	for i := range header.MiniBlockHeaders {
+		header.MiniBlockHeaders[i].Value = crossShardValue(change, header.MiniBlockHeaders[i].Hash)
+	}
+}
+
+// verifySupplyChange checks that the supply change carried by the given header is the one caused by the execution
+// of its block
+func (sp *shardProcessor) verifySupplyChange(header *block.Header, body block.Body) error {
+	if header.SupplyDelta == nil {
+		return process.ErrNilSupplyDelta
+	}
+
+	diff, err := sp.accounts.StateDiff()
+	if err != nil {
+		return err
+	}
+
+	miniBlockHashes := make([][]byte, len(body))
+	for i := 0; i < len(body); i++ {
+		buff, err := sp.marshalizer.Marshal(body[i])
+		if err != nil {
+			return err
+		}
+
+		miniBlockHashes[i] = sp.hasher.Compute(string(buff))
+	}
+
+	change, err := sp.computeBlockSupplyChange(body, miniBlockHashes, diff)
+	if err != nil {
+		return err
+	}
+
+	if !isSameValue(header.SupplyDelta, change.Delta) || !isSameValue(header.BurntFees, change.BurntFees) {
+		return process.ErrSupplyChangeMismatch
+	}
+
+	for _, miniBlockHeader := range header.MiniBlockHeaders {
+		if !isSameValue(miniBlockHeader.Value, crossShardValue(change, miniBlockHeader.Hash)) {
+			return process.ErrSupplyChangeMismatch
+		}
+	}
+
+	return nil
+}
+
+// computeSupplyChange returns the value the given block body moves across the shard boundaries, keyed by the hash of
+// the cross shard miniblock carrying it. The transactions of a miniblock sent from this shard take their value out of
+// the shard, while those of a miniblock received from another shard bring theirs in. Only the transfers to the
+// receivers in the receiver shard of the miniblock are counted for a batch transfer, the others being either
+// intra shard or carried by other miniblocks
+func (sp *shardProcessor) computeSupplyChange(body block.Body, miniBlockHashes [][]byte) (*process.ShardSupplyChange, error) {
+	selfId := sp.shardCoordinator.SelfId()
+	change := &process.ShardSupplyChange{
+		ShardId:   selfId,
+		Delta:     big.NewInt(0),
+		Outgoing:  make(map[string]*process.CrossShardValue),
+		Incoming:  make(map[string]*process.CrossShardValue),
+		BurntFees: big.NewInt(0),
+	}
+
+	for i, miniBlock := range body {
+		if miniBlock.SenderShardID == miniBlock.ReceiverShardID {
+			continue
+		}
+
+		value := &process.CrossShardValue{Value: big.NewInt(0)}
+		switch selfId {
+		case miniBlock.SenderShardID:
+			value.ShardId = miniBlock.ReceiverShardID
+			change.Outgoing[string(miniBlockHashes[i])] = value
+		case miniBlock.ReceiverShardID:
+			value.ShardId = miniBlock.SenderShardID
+			change.Incoming[string(miniBlockHashes[i])] = value
+		default:
+			continue
+		}
+
+		for _, txHash := range miniBlock.TxHashes {
+			tx := sp.getTransactionFromPool(miniBlock.SenderShardID, miniBlock.ReceiverShardID, txHash)
+			if tx == nil {
+				return nil, process.ErrMissingTransaction
+			}
+
+			if !tx.IsBatchTransfer() {
+				if tx.Value != nil {
+					value.Value.Add(value.Value, tx.Value)
+				}
+				continue
+			}
+
+			for _, transfer := range tx.Transfers {
+				isInReceiverShard := sp.shardCoordinator.ComputeId(state.NewAddress(transfer.RcvAddr)) == miniBlock.ReceiverShardID
+				if isInReceiverShard && transfer.Value != nil {
+					value.Value.Add(value.Value, transfer.Value)
+				}
+			}
+		}
+	}
+
+	return change, nil
+}
+
+// stageExecutionResult stages in the batch the outcome of a transaction executed in the block being committed
+func (sp *shardProcessor) stageExecutionResult(
+	batch *commitBatch,
+	tx *transaction.Transaction,
+	txHash []byte,
+	miniBlock *block.MiniBlock,
+	miniBlockHash []byte,
+	header *block.Header,
+	headerHash []byte,
+) error {
+
+	result := &transaction.ExecutionResult{
+		Status:        transaction.TxStatusExecuted,
+		GasUsed:       sp.feeHandler.GasUsed(tx),
+		BlockNonce:    header.Nonce,
+		BlockHash:     headerHash,
+		MiniBlockHash: miniBlockHash,
+		SndShardID:    miniBlock.SenderShardID,
+		RcvShardID:    miniBlock.ReceiverShardID,
+	}
+
+	if miniBlock.ReceiverShardID != sp.shardCoordinator.SelfId() || sp.hasCrossShardTransfers(tx, miniBlock) {
+		result.Status = transaction.TxStatusPartiallyExecuted
+	}
+
+	execErr := sp.txProcessor.ExecutionError(txHash)
+	if execErr != nil {
+		result.Status = transaction.TxStatusFailed
+		result.Error = execErr.Error()
+	}
+
+	buff, err := sp.marshalizer.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	batch.stage(dataRetriever.TransactionResultUnit, txHash, buff)
+	return nil
+}
+
+// hasCrossShardTransfers returns true if the given transaction is a batch transfer sent from this shard and having
+// receivers in other shards, which still have to credit them
+func (sp *shardProcessor) hasCrossShardTransfers(tx *transaction.Transaction, miniBlock *block.MiniBlock) bool {
+	if !tx.IsBatchTransfer() || miniBlock.SenderShardID != sp.shardCoordinator.SelfId() {
+		return false
+	}
+
+	for _, shardId := range process.BatchTransferShards(tx, sp.shardCoordinator) {
+		if shardId != sp.shardCoordinator.SelfId() {
+			return true
+		}
+	}
+
+	return false
+}
+
 // removeMetaBlockFromPool removes meta blocks from associated pool
 func (sp *shardProcessor) removeMetaBlockFromPool(body block.Body) error {
 	if body == nil {
@@ -574,6 +997,10 @@ func (sp *shardProcessor) removeMetaBlockFromPool(body block.Body) error {
 			if err != nil {
 				return err
 			}
+			err = sp.saveNonceToHash(dataRetriever.MetaHdrNonceHashDataUnit, hdr.GetNonce(), metaBlockKey)
+			if err != nil {
+				return err
+			}
 			sp.dataPool.MetaBlocks().Remove(metaBlockKey)
 			log.Info(fmt.Sprintf("metablock with nonce %d has been processed completly and removed from pool\n",
 				hdr.GetNonce()))
@@ -583,6 +1010,149 @@ func (sp *shardProcessor) removeMetaBlockFromPool(body block.Body) error {
 	return nil
 }
 
+// SetTransactionsExecutor sets the executor used to process the transactions of a miniblock as a whole. When it is
+// not set, the transactions are processed one by one by the transaction processor
+func (sp *shardProcessor) SetTransactionsExecutor(executor process.TransactionsExecutor) error {
+	if executor == nil {
+		return process.ErrNilTransactionsExecutor
+	}
+
+	sp.txsExecutor = executor
+	return nil
+}
+
+func (sp *shardProcessor) executeMiniBlockTransactions(miniBlock *block.MiniBlock, round int32) error {
+	txs := make([]*transaction.Transaction, 0, len(miniBlock.TxHashes))
+	for _, txHash := range miniBlock.TxHashes {
+		tx := sp.getTransactionFromPool(miniBlock.SenderShardID, miniBlock.ReceiverShardID, txHash)
+		if tx != nil && sp.isCrossShardBatchTransfer(tx, miniBlock) {
+			continue
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return sp.txsExecutor.ExecuteTransactions(txs, round)
+}
+
+// isCrossShardBatchTransfer returns true if the given transaction is a batch transfer carried by a miniblock sent
+// from this shard to another shard. Such a batch transfer is executed by this shard as part of its intra shard
+// miniblock, the cross shard miniblocks only carrying it towards the shards of its receivers
+func (sp *shardProcessor) isCrossShardBatchTransfer(tx *transaction.Transaction, miniBlock *block.MiniBlock) bool {
+	return tx.IsBatchTransfer() &&
+		miniBlock.SenderShardID == sp.shardCoordinator.SelfId() &&
+		miniBlock.ReceiverShardID != sp.shardCoordinator.SelfId()
+}
+
+// checkBatchTransfers verifies that every batch transfer sent from this shard is executed with the intra shard
+// miniblock and that it is carried by the miniblocks towards all the other shards holding its receivers, and only
+// by them. Otherwise the receivers could be credited without the sender being charged, or the other way around
+func (sp *shardProcessor) checkBatchTransfers(body block.Body) error {
+	selfId := sp.shardCoordinator.SelfId()
+	executedBatches := make(map[string]*transaction.Transaction)
+	carryingShards := make(map[string]map[uint32]struct{})
+
+	for _, miniBlock := range body {
+		if miniBlock.SenderShardID != selfId {
+			continue
+		}
+
+		for _, txHash := range miniBlock.TxHashes {
+			tx := sp.getTransactionFromPool(miniBlock.SenderShardID, miniBlock.ReceiverShardID, txHash)
+			if tx == nil || !tx.IsBatchTransfer() {
+				continue
+			}
+
+			if miniBlock.ReceiverShardID == selfId {
+				executedBatches[string(txHash)] = tx
+				continue
+			}
+
+			shards, ok := carryingShards[string(txHash)]
+			if !ok {
+				shards = make(map[uint32]struct{})
+				carryingShards[string(txHash)] = shards
+			}
+			shards[miniBlock.ReceiverShardID] = struct{}{}
+		}
+	}
+
+	for txHash := range carryingShards {
+		_, ok := executedBatches[txHash]
+		if !ok {
+			return process.ErrInconsistentBatchTransfer
+		}
+	}
+
+	for txHash, tx := range executedBatches {
+		shards := carryingShards[txHash]
+		numCrossShards := 0
+		for _, shardId := range process.BatchTransferShards(tx, sp.shardCoordinator) {
+			if shardId == selfId {
+				continue
+			}
+
+			_, ok := shards[shardId]
+			if !ok {
+				return process.ErrInconsistentBatchTransfer
+			}
+			numCrossShards++
+		}
+
+		if len(shards) != numCrossShards {
+			return process.ErrInconsistentBatchTransfer
+		}
+	}
+
+	return nil
+}
+
+// removeExpiredTransactions removes from the pool the transactions sent from this shard whose validity window ended
+// before the given round. The transactions received from other shards are kept, as they were already accepted by
+// their sender shard. The pool is checked at most once per round
+func (sp *shardProcessor) removeExpiredTransactions(round uint32) {
+	sp.mutLastPrunedRound.Lock()
+	if round <= sp.lastPrunedRound {
+		sp.mutLastPrunedRound.Unlock()
+		return
+	}
+	sp.lastPrunedRound = round
+	sp.mutLastPrunedRound.Unlock()
+
+	txPool := sp.dataPool.Transactions()
+	if txPool == nil {
+		return
+	}
+
+	removedTxs := 0
+	for shardId := uint32(0); shardId < sp.shardCoordinator.NumberOfShards(); shardId++ {
+		strCache := process.ShardCacherIdentifier(sp.shardCoordinator.SelfId(), shardId)
+		txStore := txPool.ShardDataStore(strCache)
+		if txStore == nil {
+			continue
+		}
+
+		for _, txHash := range txStore.Keys() {
+			val, ok := txStore.Peek(txHash)
+			if !ok {
+				continue
+			}
+
+			tx, ok := val.(*transaction.Transaction)
+			if !ok || !process.IsTxExpired(tx, round) {
+				continue
+			}
+
+			txPool.RemoveData(txHash, strCache)
+			removedTxs++
+		}
+	}
+
+	if removedTxs > 0 {
+		log.Info(fmt.Sprintf("removed %d expired txs from pool in round %d\n", removedTxs, round))
+	}
+}
+
 // getTransactionFromPool gets the transaction from a given shard id and a given transaction hash
 func (sp *shardProcessor) getTransactionFromPool(
 	senderShardID uint32,
@@ -773,7 +1343,8 @@ func (sp *shardProcessor) processAndRemoveBadTransaction(
 
 	err := sp.txProcessor.ProcessTransaction(transaction, round)
 	if err == process.ErrLowerNonceInTransaction ||
-		err == process.ErrInsufficientFunds {
+		err == process.ErrInsufficientFunds ||
+		err == process.ErrTxExpired {
 		strCache := process.ShardCacherIdentifier(sndShardId, dstShardId)
 		txPool.RemoveData(transactionHash, strCache)
 	}
@@ -858,15 +1429,19 @@ func (sp *shardProcessor) processMiniBlockComplete(
 	}
 
 	snapshot := sp.accounts.JournalLen()
-	for index := range miniBlockTxs {
-		if !haveTime() {
-			err = process.ErrTimeIsOut
-			break
-		}
+	if sp.txsExecutor != nil {
+		err = sp.txsExecutor.ExecuteTransactions(miniBlockTxs, round)
+	} else {
+		for index := range miniBlockTxs {
+			if !haveTime() {
+				err = process.ErrTimeIsOut
+				break
+			}
 
-		err = sp.txProcessor.ProcessTransaction(miniBlockTxs[index], round)
-		if err != nil {
-			break
+			err = sp.txProcessor.ProcessTransaction(miniBlockTxs[index], round)
+			if err != nil {
+				break
+			}
 		}
 	}
 	// all txs from miniblock has to be processed together
@@ -881,36 +1456,38 @@ func (sp *shardProcessor) processMiniBlockComplete(
 	return err
 }
 
-// full verification through metachain header
+// full verification through metachain header. The number of transactions and the sum of the gas limits of the
+// processed miniblocks are returned, as they count towards the block limits
 func (sp *shardProcessor) createAndProcessCrossMiniBlocksDstMe(
 	noShards uint32,
 	maxTxInBlock int,
 	round int32,
 	haveTime func() bool,
-) (block.MiniBlockSlice, uint32, error) {
+) (block.MiniBlockSlice, uint32, uint64, error) {
 
 	metaBlockCache := sp.dataPool.MetaBlocks()
 	if metaBlockCache == nil {
-		return nil, 0, process.ErrNilMetaBlockPool
+		return nil, 0, 0, process.ErrNilMetaBlockPool
 	}
 
 	miniBlockCache := sp.dataPool.MiniBlocks()
 	if miniBlockCache == nil {
-		return nil, 0, process.ErrNilMiniBlockPool
+		return nil, 0, 0, process.ErrNilMiniBlockPool
 	}
 
 	txPool := sp.dataPool.Transactions()
 	if txPool == nil {
-		return nil, 0, process.ErrNilTransactionPool
+		return nil, 0, 0, process.ErrNilTransactionPool
 	}
 
 	miniBlocks := make(block.MiniBlockSlice, 0)
 	nrTxAdded := uint32(0)
+	gasLimitInBlock := uint64(0)
 	// parse all the metablock headers
 	for _, key := range metaBlockCache.Keys() {
 		if !haveTime() {
 			log.Info(fmt.Sprintf("time is up after putting %d cross txs with destination to current shard \n", nrTxAdded))
-			return miniBlocks, nrTxAdded, nil
+			return miniBlocks, nrTxAdded, gasLimitInBlock, nil
 		}
 
 		val, _ := metaBlockCache.Peek(key)
@@ -947,7 +1524,7 @@ func (sp *shardProcessor) createAndProcessCrossMiniBlocksDstMe(
 			// overflow would happen if processing would continue
 			txOverFlow := nrTxAdded+uint32(len(miniBlock.TxHashes)) > uint32(maxTxInBlock)
 			if txOverFlow {
-				return miniBlocks, nrTxAdded, nil
+				return miniBlocks, nrTxAdded, gasLimitInBlock, nil
 			}
 
 			requestedTxs := sp.requestBlockTransactionsForMiniBlock(miniBlock)
@@ -955,6 +1532,11 @@ func (sp *shardProcessor) createAndProcessCrossMiniBlocksDstMe(
 				continue
 			}
 
+			miniBlockGasLimit := sp.miniBlockGasLimit(miniBlock)
+			if !sp.fitsGasLimitInBlock(gasLimitInBlock, miniBlockGasLimit) {
+				continue
+			}
+
 			err := sp.processMiniBlockComplete(miniBlock, round, haveTime)
 			if err != nil {
 				continue
@@ -963,10 +1545,26 @@ func (sp *shardProcessor) createAndProcessCrossMiniBlocksDstMe(
 			// all txs processed, add to processed miniblocks
 			miniBlocks = append(miniBlocks, miniBlock)
 			nrTxAdded = nrTxAdded + uint32(len(miniBlock.TxHashes))
+			gasLimitInBlock += miniBlockGasLimit
+		}
+	}
+
+	return miniBlocks, nrTxAdded, gasLimitInBlock, nil
+}
+
+// miniBlockGasLimit returns the sum of the gas limits of the miniblock transactions found in the pool
+func (sp *shardProcessor) miniBlockGasLimit(miniBlock *block.MiniBlock) uint64 {
+	gasLimit := uint64(0)
+	for _, txHash := range miniBlock.TxHashes {
+		tx := sp.getTransactionFromPool(miniBlock.SenderShardID, miniBlock.ReceiverShardID, txHash)
+		if tx == nil {
+			continue
 		}
+
+		gasLimit += tx.GasLimit
 	}
 
-	return miniBlocks, nrTxAdded, nil
+	return gasLimit
 }
 
 func (sp *shardProcessor) createMiniBlocks(
@@ -995,7 +1593,7 @@ func (sp *shardProcessor) createMiniBlocks(
 		return nil, process.ErrNilTransactionPool
 	}
 
-	destMeMiniBlocks, txs, err := sp.createAndProcessCrossMiniBlocksDstMe(noShards, maxTxInBlock, round, haveTime)
+	destMeMiniBlocks, txs, gasLimitInBlock, err := sp.createAndProcessCrossMiniBlocksDstMe(noShards, maxTxInBlock, round, haveTime)
 	if err != nil {
 		log.Info(err.Error())
 	}
@@ -1014,33 +1612,43 @@ func (sp *shardProcessor) createMiniBlocks(
 		return miniBlocks, nil
 	}
 
+	txShardStores := make([]storage.Cacher, noShards)
 	for i := 0; i < int(noShards); i++ {
 		strCache := process.ShardCacherIdentifier(sp.shardCoordinator.SelfId(), uint32(i))
-		txStore := txPool.ShardDataStore(strCache)
+		txShardStores[i] = txPool.ShardDataStore(strCache)
+	}
 
-		timeBefore := time.Now()
-		orderedTxes, orderedTxHashes, err := getTxs(txStore)
-		timeAfter := time.Now()
+	timeBefore := time.Now()
+	orderedTxs := sp.getTxs(txShardStores)
+	timeAfter := time.Now()
 
-		if !haveTime() {
-			log.Info(fmt.Sprintf("time is up after ordered %d txs in %v sec\n", len(orderedTxes), timeAfter.Sub(timeBefore).Seconds()))
-			return miniBlocks, nil
-		}
+	if !haveTime() {
+		log.Info(fmt.Sprintf("time is up after ordered txs in %v sec\n", timeAfter.Sub(timeBefore).Seconds()))
+		return miniBlocks, nil
+	}
 
-		log.Info(fmt.Sprintf("time elapsed to ordered %d txs: %v sec\n", len(orderedTxes), timeAfter.Sub(timeBefore).Seconds()))
+	log.Info(fmt.Sprintf("time elapsed to ordered txs: %v sec\n", timeAfter.Sub(timeBefore).Seconds()))
 
-		if err != nil {
-			log.Debug(fmt.Sprintf("when trying to order txs: %s", err.Error()))
-			continue
+	selectedTxs := sp.selectTxs(orderedTxs, noShards, uint32(maxTxInBlock)-txs, gasLimitInBlock)
+
+	// the miniblocks of all receiver shards are filled at once, as a batch transfer executed with the intra shard
+	// miniblock is also carried by the miniblocks towards the shards of its receivers
+	txMiniBlocks := make([]*block.MiniBlock, noShards)
+	for i := 0; i < int(noShards); i++ {
+		txMiniBlocks[i] = &block.MiniBlock{
+			SenderShardID:   sp.shardCoordinator.SelfId(),
+			ReceiverShardID: uint32(i),
+			TxHashes:        make([][]byte, 0),
 		}
+	}
 
-		miniBlock := block.MiniBlock{}
-		miniBlock.SenderShardID = sp.shardCoordinator.SelfId()
-		miniBlock.ReceiverShardID = uint32(i)
-		miniBlock.TxHashes = make([][]byte, 0)
-		log.Info(fmt.Sprintf("creating mini blocks has been started: have %d txs in pool for shard id %d\n", len(orderedTxes), miniBlock.ReceiverShardID))
+	// the selected transactions are executed shard store by shard store, in the order their miniblocks are placed in
+	// the block body, so they are executed the same way when the block is processed
+	for i := 0; i < int(noShards); i++ {
+		miniBlock := txMiniBlocks[i]
+		log.Info(fmt.Sprintf("creating mini blocks has been started: have %d txs in pool for shard id %d\n", len(selectedTxs[i]), miniBlock.ReceiverShardID))
 
-		for index := range orderedTxes {
+		for _, stx := range selectedTxs[i] {
 			if !haveTime() {
 				break
 			}
@@ -1049,8 +1657,8 @@ func (sp *shardProcessor) createMiniBlocks(
 
 			// execute transaction to change the trie root hash
 			err := sp.processAndRemoveBadTransaction(
-				orderedTxHashes[index],
-				orderedTxes[index],
+				stx.txHash,
+				stx.tx,
 				txPool,
 				round,
 				miniBlock.SenderShardID,
@@ -1067,41 +1675,142 @@ func (sp *shardProcessor) createMiniBlocks(
 			}
 
 			sp.mutCrossTxsForBlock.Lock()
-			sp.crossTxsForBlock[string(orderedTxHashes[index])] = orderedTxes[index]
+			sp.crossTxsForBlock[string(stx.txHash)] = stx.tx
 			sp.mutCrossTxsForBlock.Unlock()
-			miniBlock.TxHashes = append(miniBlock.TxHashes, orderedTxHashes[index])
-			txs++
+			miniBlock.TxHashes = append(miniBlock.TxHashes, stx.txHash)
+			sp.addBatchTransferToCrossMiniBlocks(stx.txHash, stx.tx, txMiniBlocks)
+		}
 
-			if txs >= uint32(maxTxInBlock) { // max transactions count in one block was reached
-				log.Info(fmt.Sprintf("max txs accepted in one block is reached: added %d txs from %d txs\n", len(miniBlock.TxHashes), len(orderedTxes)))
+		if !haveTime() {
+			log.Info(fmt.Sprintf("time is up: added %d txs from %d txs\n", len(miniBlock.TxHashes), len(selectedTxs[i])))
 
-				if len(miniBlock.TxHashes) > 0 {
-					miniBlocks = append(miniBlocks, &miniBlock)
-				}
+			miniBlocks = appendNotEmptyMiniBlocks(miniBlocks, txMiniBlocks)
+			log.Info(fmt.Sprintf("creating mini blocks has been finished: created %d mini blocks\n", len(miniBlocks)))
+			return miniBlocks, nil
+		}
+	}
 
-				log.Info(fmt.Sprintf("creating mini blocks has been finished: created %d mini blocks\n", len(miniBlocks)))
-				return miniBlocks, nil
-			}
+	miniBlocks = appendNotEmptyMiniBlocks(miniBlocks, txMiniBlocks)
+	log.Info(fmt.Sprintf("creating mini blocks has been finished: created %d mini blocks\n", len(miniBlocks)))
+	return miniBlocks, nil
+}
+
+// selectTxs picks, in the given gas price order, the transactions which fit in the block alongside the ones already
+// added, and groups them by the shard store they were found in. Once a transaction of a sender does not fit in the
+// block, the following ones of the same sender are skipped as they would not be executable without it
+func (sp *shardProcessor) selectTxs(
+	orderedTxs []*senderTx,
+	noShards uint32,
+	maxTxs uint32,
+	gasLimitInBlock uint64,
+) [][]*senderTx {
+
+	selectedTxs := make([][]*senderTx, noShards)
+	skippedSenders := make(map[string]struct{})
+	nrSelected := uint32(0)
+
+	for _, stx := range orderedTxs {
+		if nrSelected >= maxTxs {
+			log.Info(fmt.Sprintf("max txs accepted in one block is reached: selected %d txs from %d txs\n", nrSelected, len(orderedTxs)))
+			break
+		}
+		if stx.storeIndex >= int(noShards) {
+			continue
 		}
 
-		if !haveTime() {
-			log.Info(fmt.Sprintf("time is up: added %d txs from %d txs\n", len(miniBlock.TxHashes), len(orderedTxes)))
+		sender := string(stx.tx.SndAddr)
+		_, isSkipped := skippedSenders[sender]
+		if isSkipped {
+			continue
+		}
 
-			if len(miniBlock.TxHashes) > 0 {
-				miniBlocks = append(miniBlocks, &miniBlock)
-			}
+		if !sp.fitsGasLimitInBlock(gasLimitInBlock, stx.tx.GasLimit) {
+			skippedSenders[sender] = struct{}{}
+			continue
+		}
 
-			log.Info(fmt.Sprintf("creating mini blocks has been finished: created %d mini blocks\n", len(miniBlocks)))
-			return miniBlocks, nil
+		selectedTxs[stx.storeIndex] = append(selectedTxs[stx.storeIndex], stx)
+		gasLimitInBlock += stx.tx.GasLimit
+		nrSelected++
+	}
+
+	return selectedTxs
+}
+
+// addBatchTransferToCrossMiniBlocks adds the hash of a batch transfer, executed with the intra shard miniblock, to
+// the miniblocks towards the other shards holding its receivers
+func (sp *shardProcessor) addBatchTransferToCrossMiniBlocks(
+	txHash []byte,
+	tx *transaction.Transaction,
+	txMiniBlocks []*block.MiniBlock,
+) {
+	if !tx.IsBatchTransfer() {
+		return
+	}
+
+	for _, shardId := range process.BatchTransferShards(tx, sp.shardCoordinator) {
+		if shardId == sp.shardCoordinator.SelfId() || shardId >= uint32(len(txMiniBlocks)) {
+			continue
 		}
 
+		txMiniBlocks[shardId].TxHashes = append(txMiniBlocks[shardId].TxHashes, txHash)
+	}
+}
+
+func appendNotEmptyMiniBlocks(miniBlocks block.Body, txMiniBlocks []*block.MiniBlock) block.Body {
+	for _, miniBlock := range txMiniBlocks {
 		if len(miniBlock.TxHashes) > 0 {
-			miniBlocks = append(miniBlocks, &miniBlock)
+			miniBlocks = append(miniBlocks, miniBlock)
 		}
 	}
 
-	log.Info(fmt.Sprintf("creating mini blocks has been finished: created %d mini blocks\n", len(miniBlocks)))
-	return miniBlocks, nil
+	return miniBlocks
+}
+
+// checkBlockLimits verifies a proposed block body against the limits applied when creating the miniblocks: the
+// block can not hold more than maxTxsInBlock transactions, a batch transfer carried by several miniblocks being
+// counted once, and the gas limits of its transactions, including the ones with destination in the self shard, can
+// not sum up to more than maxGasLimitInBlock
+func (sp *shardProcessor) checkBlockLimits(body block.Body) error {
+	txHashes := make(map[string]struct{})
+	gasLimitInBlock := uint64(0)
+
+	for _, miniBlock := range body {
+		for _, txHash := range miniBlock.TxHashes {
+			_, isCounted := txHashes[string(txHash)]
+			if isCounted {
+				continue
+			}
+
+			txHashes[string(txHash)] = struct{}{}
+			if uint32(len(txHashes)) > sp.maxTxsInBlock {
+				return process.ErrMaxTxsInBlockExceeded
+			}
+
+			// the missing transactions are reported when the block transactions are processed
+			tx := sp.getTransactionFromPool(miniBlock.SenderShardID, miniBlock.ReceiverShardID, txHash)
+			if tx == nil {
+				continue
+			}
+
+			if !sp.fitsGasLimitInBlock(gasLimitInBlock, tx.GasLimit) {
+				return process.ErrMaxGasLimitInBlockExceeded
+			}
+			gasLimitInBlock += tx.GasLimit
+		}
+	}
+
+	return nil
+}
+
+// fitsGasLimitInBlock returns true if a transaction with the given gas limit can be added to a block which already
+// holds transactions summing up to gasLimitInBlock
+func (sp *shardProcessor) fitsGasLimitInBlock(gasLimitInBlock uint64, txGasLimit uint64) bool {
+	if txGasLimit > sp.maxGasLimitInBlock {
+		return false
+	}
+
+	return gasLimitInBlock <= sp.maxGasLimitInBlock-txGasLimit
 }
 
 // CreateBlockHeader creates a miniblock header list given a block body
@@ -1111,6 +1820,7 @@ func (sp *shardProcessor) CreateBlockHeader(bodyHandler data.BodyHandler, round
 		MiniBlockHeaders: make([]block.MiniBlockHeader, 0),
 		RootHash:         sp.getRootHash(),
 		ShardId:          sp.shardCoordinator.SelfId(),
+		Epoch:            sp.epochOfRound(uint32(round)),
 		PrevRandSeed:     make([]byte, 0),
 		RandSeed:         make([]byte, 0),
 	}
@@ -1127,6 +1837,7 @@ func (sp *shardProcessor) CreateBlockHeader(bodyHandler data.BodyHandler, round
 	mbLen := len(body)
 	totalTxCount := 0
 	miniBlockHeaders := make([]block.MiniBlockHeader, mbLen)
+	miniBlockHashes := make([][]byte, mbLen)
 	for i := 0; i < mbLen; i++ {
 		txCount := len(body[i].TxHashes)
 		totalTxCount += txCount
@@ -1138,14 +1849,27 @@ func (sp *shardProcessor) CreateBlockHeader(bodyHandler data.BodyHandler, round
 
 		miniBlockHeaders[i] = block.MiniBlockHeader{
 			Hash:            mbHash,
-			SenderShardID:   sp.shardCoordinator.SelfId(),
+			SenderShardID:   body[i].SenderShardID,
 			ReceiverShardID: body[i].ReceiverShardID,
 			TxCount:         uint32(txCount),
 		}
+		miniBlockHashes[i] = mbHash
 	}
 
 	header.MiniBlockHeaders = miniBlockHeaders
 	header.TxCount = uint32(totalTxCount)
+
+	diff, err := sp.accounts.StateDiff()
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := sp.computeBlockSupplyChange(body, miniBlockHashes, diff)
+	if err != nil {
+		return nil, err
+	}
+
+	setHeaderSupplyChange(header, change)
 	return header, nil
 }
 
@@ -1276,18 +2000,20 @@ func displayTxBlockBody(lines []*display.LineData, body block.Body) []*display.L
 	return lines
 }
 
-func sortTxByNonce(txShardStore storage.Cacher) ([]*transaction.Transaction, [][]byte, error) {
+// sortTxByNonce groups the transactions from the shard store by sender, ordering the hashes of each sender's
+// transactions by nonce
+func sortTxByNonce(txShardStore storage.Cacher) (map[string][][]byte, error) {
 	if txShardStore == nil {
-		return nil, nil, process.ErrNilCacher
+		return nil, process.ErrNilCacher
 	}
 
-	transactions := make([]*transaction.Transaction, 0)
-	txHashes := make([][]byte, 0)
-
-	mTxHashes := make(map[uint64][][]byte)
-	mTransactions := make(map[uint64][]*transaction.Transaction)
+	senderTxQueuer, ok := txShardStore.(dataRetriever.SenderTxQueuer)
+	if ok {
+		return senderTxQueuer.SortedTxHashesBySender(), nil
+	}
 
-	nonces := make([]uint64, 0)
+	txHashesBySender := make(map[string][][]byte)
+	noncesBySender := make(map[string][]uint64)
 
 	for _, key := range txShardStore.Keys() {
 		val, _ := txShardStore.Peek(key)
@@ -1300,30 +2026,35 @@ func sortTxByNonce(txShardStore storage.Cacher) ([]*transaction.Transaction, [][
 			continue
 		}
 
-		if mTxHashes[tx.Nonce] == nil {
-			nonces = append(nonces, tx.Nonce)
-			mTxHashes[tx.Nonce] = make([][]byte, 0)
-			mTransactions[tx.Nonce] = make([]*transaction.Transaction, 0)
-		}
+		sender := string(tx.SndAddr)
+		txHashesBySender[sender] = append(txHashesBySender[sender], key)
+		noncesBySender[sender] = append(noncesBySender[sender], tx.Nonce)
+	}
 
-		mTxHashes[tx.Nonce] = append(mTxHashes[tx.Nonce], key)
-		mTransactions[tx.Nonce] = append(mTransactions[tx.Nonce], tx)
+	for sender, txHashes := range txHashesBySender {
+		sort.Sort(&txHashesByNonce{txHashes: txHashes, nonces: noncesBySender[sender]})
 	}
 
-	sort.Slice(nonces, func(i, j int) bool {
-		return nonces[i] < nonces[j]
-	})
+	return txHashesBySender, nil
+}
 
-	for _, nonce := range nonces {
-		keys := mTxHashes[nonce]
+// txHashesByNonce implements sort.Interface for a sender's transaction hashes, ordering them by nonce
+type txHashesByNonce struct {
+	txHashes [][]byte
+	nonces   []uint64
+}
 
-		for idx, key := range keys {
-			txHashes = append(txHashes, key)
-			transactions = append(transactions, mTransactions[nonce][idx])
-		}
-	}
+func (thbn *txHashesByNonce) Len() int {
+	return len(thbn.txHashes)
+}
 
-	return transactions, txHashes, nil
+func (thbn *txHashesByNonce) Less(i, j int) bool {
+	return thbn.nonces[i] < thbn.nonces[j]
+}
+
+func (thbn *txHashesByNonce) Swap(i, j int) {
+	thbn.txHashes[i], thbn.txHashes[j] = thbn.txHashes[j], thbn.txHashes[i]
+	thbn.nonces[i], thbn.nonces[j] = thbn.nonces[j], thbn.nonces[i]
 }
 
 func (sp *shardProcessor) getNrTxsWithDst(dstShardId uint32) int {
@@ -1399,30 +2130,191 @@ func (sp *shardProcessor) MarshalizedDataToBroadcast(
 	return mrsData, mrsTxs, nil
 }
 
-func getTxs(txShardStore storage.Cacher) ([]*transaction.Transaction, [][]byte, error) {
-	if txShardStore == nil {
-		return nil, nil, process.ErrNilCacher
-	}
+// senderTx holds a pending transaction together with the index of the shard store in which it was found
+type senderTx struct {
+	tx         *transaction.Transaction
+	txHash     []byte
+	storeIndex int
+}
 
-	transactions := make([]*transaction.Transaction, 0)
-	txHashes := make([][]byte, 0)
+// getTxs returns the executable transactions of all the provided shard stores, ordered by gas price. The transactions
+// of every sender are merged from all the shard stores and only the ones having consecutive nonces, starting from the
+// current nonce of the sender's account, are selected, as long as they keep the nonce order when executed in the
+// order of the shard stores (the same order in which the resulting miniblocks are placed in the block body).
+// Transactions having a lower nonce than the account can never be executed so they are removed from their shard
+// store, while the ones placed after a nonce gap are kept in the store until the gap is filled
+func (sp *shardProcessor) getTxs(txShardStores []storage.Cacher) []*senderTx {
+	txsBySender := make(map[string][]*senderTx)
+	for storeIndex, txShardStore := range txShardStores {
+		txHashesBySender, err := sortTxByNonce(txShardStore)
+		if err != nil {
+			continue
+		}
 
-	for _, key := range txShardStore.Keys() {
-		val, _ := txShardStore.Peek(key)
-		if val == nil {
+		for sender, txHashes := range txHashesBySender {
+			for _, txHash := range txHashes {
+				val, _ := txShardStore.Peek(txHash)
+				if val == nil {
+					continue
+				}
+
+				tx, ok := val.(*transaction.Transaction)
+				if !ok {
+					continue
+				}
+
+				// a batch transfer is kept in the stores of all its receiver shards but it is executed from
+				// the intra shard store only
+				if tx.IsBatchTransfer() && uint32(storeIndex) != sp.shardCoordinator.SelfId() {
+					continue
+				}
+
+				txsBySender[sender] = append(txsBySender[sender], &senderTx{
+					tx:         tx,
+					txHash:     txHash,
+					storeIndex: storeIndex,
+				})
+			}
+		}
+	}
+
+	executableTxs := make([]*senderTx, 0)
+	for sender, senderTxs := range txsBySender {
+		accountNonce, err := sp.getAccountNonce([]byte(sender))
+		if err != nil {
+			log.Debug(fmt.Sprintf("can not get the nonce of sender's account: %s", err.Error()))
 			continue
 		}
 
-		tx, ok := val.(*transaction.Transaction)
+		sort.SliceStable(senderTxs, func(i, j int) bool {
+			return senderTxs[i].tx.Nonce < senderTxs[j].tx.Nonce
+		})
+
+		expectedNonce := accountNonce
+		lastStoreIndex := 0
+		for _, stx := range senderTxs {
+			if stx.tx.Nonce < accountNonce {
+				txShardStores[stx.storeIndex].Remove(stx.txHash)
+				continue
+			}
+			if stx.tx.Nonce < expectedNonce {
+				// another transaction with the same nonce has already been selected
+				continue
+			}
+			if stx.tx.Nonce > expectedNonce || stx.storeIndex < lastStoreIndex {
+				break
+			}
+
+			executableTxs = append(executableTxs, stx)
+			expectedNonce++
+			lastStoreIndex = stx.storeIndex
+		}
+	}
+
+	return orderTxsByGasPrice(executableTxs)
+}
+
+// orderTxsByGasPrice orders the executable transactions of all the shard stores so that the senders offering a higher
+// gas price are served first, while the transactions of every sender keep their nonce order. A heap holds the next
+// transaction of each sender and every transaction taken out of it is replaced by the following one of the same sender
+func orderTxsByGasPrice(transactions []*senderTx) []*senderTx {
+	queuesBySender := make(map[string]*senderTxsQueue)
+	queues := make(senderTxsHeap, 0)
+	for _, stx := range transactions {
+		queue, ok := queuesBySender[string(stx.tx.SndAddr)]
 		if !ok {
+			queue = &senderTxsQueue{}
+			queuesBySender[string(stx.tx.SndAddr)] = queue
+			queues = append(queues, queue)
+		}
+
+		queue.transactions = append(queue.transactions, stx)
+	}
+
+	heap.Init(&queues)
+
+	orderedTxs := make([]*senderTx, 0, len(transactions))
+	for queues.Len() > 0 {
+		queue := queues[0]
+		orderedTxs = append(orderedTxs, queue.transactions[queue.index])
+
+		queue.index++
+		if queue.index < len(queue.transactions) {
+			heap.Fix(&queues, 0)
 			continue
 		}
 
-		txHashes = append(txHashes, key)
-		transactions = append(transactions, tx)
+		heap.Pop(&queues)
 	}
 
-	return transactions, txHashes, nil
+	return orderedTxs
+}
+
+// senderTxsQueue holds the nonce ordered transactions of a sender together with the position of its next transaction
+type senderTxsQueue struct {
+	transactions []*senderTx
+	index        int
+}
+
+func (stq *senderTxsQueue) head() *transaction.Transaction {
+	return stq.transactions[stq.index].tx
+}
+
+// senderTxsHeap implements heap.Interface for the senders' queues, keeping on top the queue whose next transaction
+// offers the highest gas price. Ties are broken by nonce and then by sender address, giving a deterministic order
+type senderTxsHeap []*senderTxsQueue
+
+func (sth senderTxsHeap) Len() int {
+	return len(sth)
+}
+
+func (sth senderTxsHeap) Less(i, j int) bool {
+	txI := sth[i].head()
+	txJ := sth[j].head()
+
+	if txI.GasPrice != txJ.GasPrice {
+		return txI.GasPrice > txJ.GasPrice
+	}
+	if txI.Nonce != txJ.Nonce {
+		return txI.Nonce < txJ.Nonce
+	}
+
+	return bytes.Compare(txI.SndAddr, txJ.SndAddr) < 0
+}
+
+func (sth senderTxsHeap) Swap(i, j int) {
+	sth[i], sth[j] = sth[j], sth[i]
+}
+
+func (sth *senderTxsHeap) Push(x interface{}) {
+	*sth = append(*sth, x.(*senderTxsQueue))
+}
+
+func (sth *senderTxsHeap) Pop() interface{} {
+	old := *sth
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*sth = old[:n-1]
+
+	return item
+}
+
+func (sp *shardProcessor) getAccountNonce(address []byte) (uint64, error) {
+	accountHandler, err := sp.accounts.GetExistingAccount(state.NewAddress(address))
+	if err == state.ErrAccNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	account, ok := accountHandler.(*state.Account)
+	if !ok {
+		return 0, process.ErrWrongTypeAssertion
+	}
+
+	return account.Nonce, nil
 }
 
 // DecodeBlockBody method decodes block body from a given byte array
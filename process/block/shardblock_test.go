@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"reflect"
 	"sync/atomic"
@@ -13,12 +14,15 @@ import (
 	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/data/block"
 	"github.com/numbatx/gn-numbat/data/blockchain"
+	"github.com/numbatx/gn-numbat/data/state"
 	"github.com/numbatx/gn-numbat/data/transaction"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/process"
 	blproc "github.com/numbatx/gn-numbat/process/block"
 	"github.com/numbatx/gn-numbat/process/mock"
+	"github.com/numbatx/gn-numbat/sharding"
 	"github.com/numbatx/gn-numbat/storage"
+	"github.com/numbatx/gn-numbat/storage/memorydb"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,6 +53,10 @@ func TestNewBlockProcessor_NilDataPoolShouldErr(t *testing.T) {
 		&mock.BlocksTrackerMock{},
 		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, mbHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Equal(t, process.ErrNilDataPoolHolder, err)
 	assert.Nil(t, sp)
@@ -69,6 +77,10 @@ func TestNewShardProcessor_NilStoreShouldErr(t *testing.T) {
 		&mock.BlocksTrackerMock{},
 		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, mbHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Equal(t, process.ErrNilStorage, err)
 	assert.Nil(t, sp)
@@ -90,6 +102,10 @@ func TestNewShardProcessor_NilHasherShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Equal(t, process.ErrNilHasher, err)
 	assert.Nil(t, sp)
@@ -111,6 +127,10 @@ func TestNewShardProcessor_NilMarshalizerShouldWork(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Equal(t, process.ErrNilMarshalizer, err)
 	assert.Nil(t, sp)
@@ -132,6 +152,10 @@ func TestNewShardProcessor_NilTxProcessorShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Equal(t, process.ErrNilTxProcessor, err)
 	assert.Nil(t, sp)
@@ -153,6 +177,10 @@ func TestNewShardProcessor_NilAccountsAdapterShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Equal(t, process.ErrNilAccountsAdapter, err)
 	assert.Nil(t, sp)
@@ -174,6 +202,10 @@ func TestNewShardProcessor_NilShardCoordinatorShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Equal(t, process.ErrNilShardCoordinator, err)
 	assert.Nil(t, sp)
@@ -195,6 +227,10 @@ func TestNewShardProcessor_NilForkDetectorShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Equal(t, process.ErrNilForkDetector, err)
 	assert.Nil(t, sp)
@@ -216,6 +252,10 @@ func TestNewShardProcessor_NilBlocksTrackerShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Equal(t, process.ErrNilBlocksTracker, err)
 	assert.Nil(t, sp)
@@ -236,11 +276,133 @@ func TestNewShardProcessor_NilRequestTransactionHandlerShouldErr(t *testing.T) {
 		&mock.BlocksTrackerMock{},
 		nil,
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Equal(t, process.ErrNilTransactionHandler, err)
 	assert.Nil(t, sp)
 }
 
+func TestNewShardProcessor_NilFeeHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool()
+	sp, err := blproc.NewShardProcessor(
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		nil,
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+	assert.Equal(t, process.ErrNilFeeHandler, err)
+	assert.Nil(t, sp)
+}
+
+func TestNewShardProcessor_NilRewardsAddressShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool()
+	sp, err := blproc.NewShardProcessor(
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		nil,
+		15000,
+		1000000000,
+	)
+	assert.Equal(t, process.ErrNilRewardsAddress, err)
+	assert.Nil(t, sp)
+}
+
+func TestNewShardProcessor_RewardsAddressInOtherShardShouldErr(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool()
+	sp, err := blproc.NewShardProcessor(
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		createBatchTransferShardCoordinator(1),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards2")),
+		15000,
+		1000000000,
+	)
+	assert.Equal(t, process.ErrRewardsAddressNotInSelfShard, err)
+	assert.Nil(t, sp)
+}
+
+func TestNewShardProcessor_ZeroMaxTxsInBlockShouldErr(t *testing.T) {
+	t.Parallel()
+	sp, err := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		0,
+		1000000000,
+	)
+	assert.Equal(t, process.ErrInvalidMaxTxsInBlock, err)
+	assert.Nil(t, sp)
+}
+
+func TestNewShardProcessor_ZeroMaxGasLimitInBlockShouldErr(t *testing.T) {
+	t.Parallel()
+	sp, err := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		0,
+	)
+	assert.Equal(t, process.ErrInvalidMaxGasLimitInBlock, err)
+	assert.Nil(t, sp)
+}
+
 func TestNewShardProcessor_NilTransactionPoolShouldErr(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool()
@@ -260,6 +422,10 @@ func TestNewShardProcessor_NilTransactionPoolShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Equal(t, process.ErrNilTransactionPool, err)
 	assert.Nil(t, sp)
@@ -281,6 +447,10 @@ func TestNewShardProcessor_OkValsShouldWork(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	assert.Nil(t, err)
 	assert.NotNil(t, sp)
@@ -304,6 +474,10 @@ func TestShardProcessor_ProcessBlockWithNilBlockchainShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	blk := make(block.Body, 0)
 	err := sp.ProcessBlock(nil, &block.Header{}, blk, haveTime)
@@ -326,6 +500,10 @@ func TestShardProcessor_ProcessBlockWithNilHeaderShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	body := make(block.Body, 0)
 	err := sp.ProcessBlock(&blockchain.BlockChain{}, nil, body, haveTime)
@@ -348,6 +526,10 @@ func TestShardProcessor_ProcessBlockWithNilBlockBodyShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	err := sp.ProcessBlock(&blockchain.BlockChain{}, &block.Header{}, nil, haveTime)
 	assert.Equal(t, process.ErrNilBlockBody, err)
@@ -369,6 +551,10 @@ func TestShardProcessor_ProcessBlockWithNilHaveTimeFuncShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	blk := make(block.Body, 0)
 	err := sp.ProcessBlock(&blockchain.BlockChain{}, &block.Header{}, blk, nil)
@@ -407,6 +593,10 @@ func TestShardProcessor_ProcessWithDirtyAccountShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	// should return err
 	err := sp.ProcessBlock(blkc, &hdr, body, haveTime)
@@ -464,6 +654,10 @@ func TestShardProcessor_ProcessBlockWithInvalidTransactionShouldErr(t *testing.T
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	go func() {
 		sp.ChRcvAllTxs() <- true
@@ -489,6 +683,10 @@ func TestShardProcessor_ProcessWithHeaderNotFirstShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	hdr := &block.Header{
 		Nonce:         0,
@@ -520,6 +718,10 @@ func TestShardProcessor_ProcessWithHeaderNotCorrectNonceShouldErr(t *testing.T)
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	hdr := &block.Header{
 		Nonce:         0,
@@ -551,6 +753,10 @@ func TestShardProcessor_ProcessWithHeaderNotCorrectPrevHashShouldErr(t *testing.
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	hdr := &block.Header{
 		Nonce:         1,
@@ -628,6 +834,10 @@ func TestShardProcessor_ProcessBlockWithErrOnProcessBlockTransactionsCallShouldR
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	go func() {
 		sp.ChRcvAllTxs() <- true
@@ -641,7 +851,7 @@ func TestShardProcessor_ProcessBlockWithErrOnProcessBlockTransactionsCallShouldR
 func TestShardProcessor_ProcessBlockWithErrOnVerifyStateRootCallShouldRevertState(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool()
-	txHash := []byte("tx_hash1")
+	txHash := []byte("tx1_hash")
 	txProcess := func(transaction *transaction.Transaction, round int32) error {
 		return nil
 	}
@@ -695,6 +905,10 @@ func TestShardProcessor_ProcessBlockWithErrOnVerifyStateRootCallShouldRevertStat
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	go func() {
 		sp.ChRcvAllTxs() <- true
@@ -705,6 +919,162 @@ func TestShardProcessor_ProcessBlockWithErrOnVerifyStateRootCallShouldRevertStat
 	assert.True(t, wasCalled)
 }
 
+func TestShardProcessor_ProcessBlockShouldCreditFeesToRewardsAddress(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool()
+	tpm := mock.TxProcessorMock{
+		ProcessTransactionCalled: func(transaction *transaction.Transaction, round int32) error {
+			return nil
+		},
+	}
+	blkc := &blockchain.BlockChain{
+		CurrentBlockHeader: &block.Header{
+			Nonce: 0,
+		},
+	}
+	hdr := block.Header{
+		Nonce:         1,
+		PrevHash:      []byte(""),
+		Signature:     []byte("signature"),
+		PubKeysBitmap: []byte("00110"),
+		ShardId:       0,
+		RootHash:      []byte("rootHash"),
+		SupplyDelta:   big.NewInt(0),
+		BurntFees:     big.NewInt(0),
+	}
+	body := block.Body{
+		&block.MiniBlock{
+			ReceiverShardID: 0,
+			SenderShardID:   0,
+			TxHashes:        [][]byte{[]byte("tx1_hash")},
+		},
+	}
+	rewardsAddress := mock.NewAddressMock([]byte("rewards"))
+	rewardsAccount, _ := state.NewAccount(rewardsAddress, &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	})
+	rewardsAccount.Balance = big.NewInt(10)
+	sp, _ := blproc.NewShardProcessor(
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&tpm,
+		&mock.AccountsStub{
+			StateDiffCalled: func() (*state.StateDiff, error) {
+				return &state.StateDiff{}, nil
+			},
+			JournalLenCalled: func() int {
+				return 0
+			},
+			RootHashCalled: func() []byte {
+				return []byte("rootHash")
+			},
+			GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+				assert.Equal(t, rewardsAddress, addressContainer)
+				return rewardsAccount, nil
+			},
+		},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{
+			ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+				return big.NewInt(7)
+			},
+		},
+		rewardsAddress,
+		15000,
+		1000000000,
+	)
+
+	err := sp.ProcessBlock(blkc, &hdr, body, haveTime)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(17), rewardsAccount.Balance)
+}
+
+func TestShardProcessor_ProcessBlockShouldNotCreditFeesOfCrossShardTxsDstMe(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool()
+	tpm := mock.TxProcessorMock{
+		ProcessTransactionCalled: func(transaction *transaction.Transaction, round int32) error {
+			return nil
+		},
+	}
+	blkc := &blockchain.BlockChain{
+		CurrentBlockHeader: &block.Header{
+			Nonce: 0,
+		},
+	}
+	hdr := block.Header{
+		Nonce:         1,
+		PrevHash:      []byte(""),
+		Signature:     []byte("signature"),
+		PubKeysBitmap: []byte("00110"),
+		ShardId:       0,
+		RootHash:      []byte("rootHash"),
+		SupplyDelta:   big.NewInt(0),
+		BurntFees:     big.NewInt(0),
+	}
+	body := block.Body{
+		&block.MiniBlock{
+			ReceiverShardID: 0,
+			SenderShardID:   1,
+			TxHashes:        [][]byte{[]byte("tx1_hash")},
+		},
+	}
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(2)
+	shardCoordinator.ComputeIdCalled = func(address state.AddressContainer) uint32 {
+		return 0
+	}
+	getAccountCalled := false
+	sp, _ := blproc.NewShardProcessor(
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&tpm,
+		&mock.AccountsStub{
+			StateDiffCalled: func() (*state.StateDiff, error) {
+				return &state.StateDiff{}, nil
+			},
+			JournalLenCalled: func() int {
+				return 0
+			},
+			RootHashCalled: func() []byte {
+				return []byte("rootHash")
+			},
+			GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+				getAccountCalled = true
+				return nil, nil
+			},
+		},
+		shardCoordinator,
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{
+			ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+				return big.NewInt(7)
+			},
+		},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	err := sp.ProcessBlock(blkc, &hdr, body, haveTime)
+	assert.Nil(t, err)
+	assert.False(t, getAccountCalled)
+}
+
 //------- CommitBlock
 
 func TestShardProcessor_CommitBlockNilBlockchainShouldErr(t *testing.T) {
@@ -727,6 +1097,10 @@ func TestShardProcessor_CommitBlockNilBlockchainShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	blk := make(block.Body, 0)
 	err := sp.CommitBlock(nil, &block.Header{}, blk)
@@ -777,6 +1151,10 @@ func TestShardProcessor_CommitBlockMarshalizerFailForHeaderShouldErr(t *testing.
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	blkc := createTestBlockchain()
 	err := sp.CommitBlock(blkc, hdr, body)
@@ -806,7 +1184,10 @@ func TestShardProcessor_CommitBlockStorageFailsForHeaderShouldErr(t *testing.T)
 	}
 	body := make(block.Body, 0)
 	hdrUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return errPersister
 		},
 	}
@@ -826,6 +1207,10 @@ func TestShardProcessor_CommitBlockStorageFailsForHeaderShouldErr(t *testing.T)
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	blkc, _ := blockchain.NewBlockChain(
@@ -844,6 +1229,9 @@ func TestShardProcessor_CommitBlockStorageFailsForBodyShouldErr(t *testing.T) {
 		RootHashCalled: func() []byte {
 			return rootHash
 		},
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return &state.StateDiff{}, nil
+		},
 		CommitCalled: func() (i []byte, e error) {
 			return nil, nil
 		},
@@ -864,12 +1252,26 @@ func TestShardProcessor_CommitBlockStorageFailsForBodyShouldErr(t *testing.T) {
 	body = append(body, &mb)
 
 	miniBlockUnit := &mock.StorerStub{
-		PutCalled: func(key, data []byte) error {
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
 			return errPersister
 		},
 	}
+	hdrSaved := false
+	hdrUnit := &mock.StorerStub{
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
+			hdrSaved = true
+			return nil
+		},
+	}
 	store := initStore()
 	store.AddStorer(dataRetriever.MiniBlockUnit, miniBlockUnit)
+	store.AddStorer(dataRetriever.BlockHeaderUnit, hdrUnit)
 
 	sp, _ := blproc.NewShardProcessor(
 		tdp,
@@ -888,6 +1290,10 @@ func TestShardProcessor_CommitBlockStorageFailsForBodyShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	blkc, _ := blockchain.NewBlockChain(
@@ -896,6 +1302,7 @@ func TestShardProcessor_CommitBlockStorageFailsForBodyShouldErr(t *testing.T) {
 	err := sp.CommitBlock(blkc, hdr, body)
 
 	assert.Equal(t, errPersister, err)
+	assert.False(t, hdrSaved)
 }
 
 func TestShardProcessor_CommitBlockNilNoncesDataPoolShouldErr(t *testing.T) {
@@ -934,6 +1341,10 @@ func TestShardProcessor_CommitBlockNilNoncesDataPoolShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	tdp.HeadersNoncesCalled = func() dataRetriever.Uint64Cacher {
 		return nil
@@ -963,6 +1374,9 @@ func TestShardProcessor_CommitBlockNoTxInPoolShouldErr(t *testing.T) {
 	}
 	body := block.Body{&mb}
 	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return &state.StateDiff{}, nil
+		},
 		CommitCalled: func() (i []byte, e error) {
 			return rootHash, nil
 		},
@@ -997,6 +1411,10 @@ func TestShardProcessor_CommitBlockNoTxInPoolShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	txCache := &mock.CacherStub{
 		PeekCalled: func(key []byte) (value interface{}, ok bool) {
@@ -1048,6 +1466,9 @@ func TestShardProcessor_CommitBlockOkValsShouldWork(t *testing.T) {
 	}
 	body := block.Body{&mb}
 	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return &state.StateDiff{}, nil
+		},
 		CommitCalled: func() (i []byte, e error) {
 			return rootHash, nil
 		},
@@ -1088,6 +1509,10 @@ func TestShardProcessor_CommitBlockOkValsShouldWork(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	txCache := &mock.CacherStub{
 		PeekCalled: func(key []byte) (value interface{}, ok bool) {
@@ -1138,22 +1563,154 @@ func TestShardProcessor_CommitBlockOkValsShouldWork(t *testing.T) {
 	time.Sleep(time.Second)
 }
 
-func TestShardProcessor_GetTransactionFromPool(t *testing.T) {
+func TestShardProcessor_CommitBlockShouldSaveExecutionResults(t *testing.T) {
 	t.Parallel()
 	tdp := initDataPool()
+	txHashIntra := []byte("txHashIntra")
+	txHashCross := []byte("txHashCross")
+	txIntra := &transaction.Transaction{Nonce: 1}
+	txCross := &transaction.Transaction{Nonce: 2}
+	rootHash := []byte("root hash")
+	hdrHash := []byte("header hash")
+	hdr := &block.Header{
+		Nonce:         1,
+		Round:         1,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      rootHash,
+	}
+	body := block.Body{
+		&block.MiniBlock{TxHashes: [][]byte{txHashIntra}, SenderShardID: 0, ReceiverShardID: 0},
+		&block.MiniBlock{TxHashes: [][]byte{txHashCross}, SenderShardID: 0, ReceiverShardID: 1},
+	}
+	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return &state.StateDiff{}, nil
+		},
+		CommitCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+		RootHashCalled: func() []byte {
+			return rootHash
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return hdrHash
+	}
+	store := initStore()
+	errExecution := errors.New("sc execution error")
+	clearCalled := false
+	tpm := &mock.TxProcessorMock{
+		ExecutionErrorCalled: func(txHash []byte) error {
+			if bytes.Equal(txHash, txHashIntra) {
+				return errExecution
+			}
+			return nil
+		},
+		ClearExecutionErrorsCalled: func() {
+			clearCalled = true
+		},
+	}
+
 	sp, _ := blproc.NewShardProcessor(
 		tdp,
-		&mock.ChainStorerMock{},
-		&mock.HasherStub{},
+		store,
+		hasher,
 		&mock.MarshalizerMock{},
-		&mock.TxProcessorMock{},
-		initAccountsMock(),
-		mock.NewOneShardCoordinatorMock(),
-		&mock.ForkDetectorMock{},
-		&mock.BlocksTrackerMock{},
-		func(destShardID uint32, txHashes [][]byte) {
+		tpm,
+		accounts,
+		mock.NewMultiShardsCoordinatorMock(2),
+		&mock.ForkDetectorMock{
+			AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState) error {
+				return nil
+			},
+		},
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+		},
+		func(destShardID uint32, txHashes [][]byte) {
+		},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{
+			GasUsedCalled: func(tx *transaction.Transaction) uint64 {
+				return 10
+			},
+		},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+	txCache := &mock.CacherStub{
+		PeekCalled: func(key []byte) (value interface{}, ok bool) {
+			if bytes.Equal(txHashIntra, key) {
+				return txIntra, true
+			}
+			if bytes.Equal(txHashCross, key) {
+				return txCross, true
+			}
+			return nil, false
+		},
+		LenCalled: func() int {
+			return 0
+		},
+	}
+	tdp.TransactionsCalled = func() dataRetriever.ShardedDataCacherNotifier {
+		return &mock.ShardedDataStub{
+			ShardDataStoreCalled: func(id string) (c storage.Cacher) {
+				return txCache
+			},
+			RemoveSetOfDataFromPoolCalled: func(keys [][]byte, id string) {
+			},
+		}
+	}
+	blkc := createTestBlockchain()
+
+	err := sp.CommitBlock(blkc, hdr, body)
+	assert.Nil(t, err)
+	assert.True(t, clearCalled)
+
+	marshalizer := &mock.MarshalizerMock{}
+	buff, err := store.Get(dataRetriever.TransactionResultUnit, txHashIntra)
+	assert.Nil(t, err)
+	resultIntra := &transaction.ExecutionResult{}
+	_ = marshalizer.Unmarshal(resultIntra, buff)
+	assert.Equal(t, transaction.TxStatusFailed, resultIntra.Status)
+	assert.Equal(t, errExecution.Error(), resultIntra.Error)
+	assert.Equal(t, uint64(10), resultIntra.GasUsed)
+	assert.Equal(t, uint64(1), resultIntra.BlockNonce)
+	assert.Equal(t, hdrHash, resultIntra.BlockHash)
+
+	buff, err = store.Get(dataRetriever.TransactionResultUnit, txHashCross)
+	assert.Nil(t, err)
+	resultCross := &transaction.ExecutionResult{}
+	_ = marshalizer.Unmarshal(resultCross, buff)
+	assert.Equal(t, transaction.TxStatusPartiallyExecuted, resultCross.Status)
+	assert.Equal(t, "", resultCross.Error)
+	assert.Equal(t, uint32(1), resultCross.RcvShardID)
+}
+func TestShardProcessor_GetTransactionFromPool(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool()
+	sp, _ := blproc.NewShardProcessor(
+		tdp,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	txHash := []byte("tx1_hash")
 	tx := sp.GetTransactionFromPool(1, 1, txHash)
@@ -1176,6 +1733,10 @@ func TestShardProcessor_RequestTransactionFromNetwork(t *testing.T) {
 		&mock.BlocksTrackerMock{},
 		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	shardId := uint32(1)
 	txHash1 := []byte("tx_hash1")
@@ -1205,6 +1766,10 @@ func TestShardProcessor_RequestBlockTransactionFromMiniBlockFromNetwork(t *testi
 		&mock.BlocksTrackerMock{},
 		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	shardId := uint32(1)
 	txHash1 := []byte("tx_hash1")
@@ -1240,6 +1805,10 @@ func TestShardProcessor_CreateTxBlockBodyWithDirtyAccStateShouldErr(t *testing.T
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	bl, err := sp.CreateBlockBody(0, func() bool { return true })
 	// nil block
@@ -1272,6 +1841,10 @@ func TestShardProcessor_CreateTxBlockBodyWithNoTimeShouldEmptyBlock(t *testing.T
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	haveTime := func() bool {
 		return false
@@ -1314,6 +1887,10 @@ func TestShardProcessor_CreateTxBlockBodyOK(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	blk, err := sp.CreateBlockBody(0, haveTime)
 	assert.NotNil(t, blk)
@@ -1336,6 +1913,10 @@ func TestShardProcessor_RemoveBlockTxsFromPoolNilBlockShouldErr(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	err := sp.RemoveTxBlockFromPools(nil)
 	assert.NotNil(t, err)
@@ -1358,6 +1939,10 @@ func TestShardProcessor_RemoveBlockTxsFromPoolOK(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	body := make(block.Body, 0)
 	txHash := []byte("txHash")
@@ -1393,6 +1978,10 @@ func TestNode_ComputeNewNoncePrevHashShouldWork(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	hdr, txBlock := createTestHdrTxBlockBody()
 	marshalizer.MarshalCalled = func(obj interface{}) (bytes []byte, e error) {
@@ -1483,6 +2072,10 @@ func TestShardProcessor_DisplayLogInfo(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	hdr.PrevHash = hasher.Compute("prev hash")
 	sp.DisplayShardBlock(hdr, txBlock)
@@ -1492,18 +2085,16 @@ func TestShardProcessor_DisplayLogInfo(t *testing.T) {
 
 func TestSortTxByNonce_NilCacherShouldErr(t *testing.T) {
 	t.Parallel()
-	transactions, txHashes, err := blproc.SortTxByNonce(nil)
-	assert.Nil(t, transactions)
-	assert.Nil(t, txHashes)
+	txHashesBySender, err := blproc.SortTxByNonce(nil)
+	assert.Nil(t, txHashesBySender)
 	assert.Equal(t, process.ErrNilCacher, err)
 }
 
 func TestSortTxByNonce_EmptyCacherShouldReturnEmpty(t *testing.T) {
 	t.Parallel()
 	cacher, _ := storage.NewCache(storage.LRUCache, 100, 1)
-	transactions, txHashes, err := blproc.SortTxByNonce(cacher)
-	assert.Equal(t, 0, len(transactions))
-	assert.Equal(t, 0, len(txHashes))
+	txHashesBySender, err := blproc.SortTxByNonce(cacher)
+	assert.Equal(t, 0, len(txHashesBySender))
 	assert.Nil(t, err)
 }
 
@@ -1512,20 +2103,20 @@ func TestSortTxByNonce_OneTxShouldWork(t *testing.T) {
 	cacher, _ := storage.NewCache(storage.LRUCache, 100, 1)
 	hash, tx := createRandTx(r)
 	cacher.HasOrAdd(hash, tx)
-	transactions, txHashes, err := blproc.SortTxByNonce(cacher)
-	assert.Equal(t, 1, len(transactions))
-	assert.Equal(t, 1, len(txHashes))
+	txHashesBySender, err := blproc.SortTxByNonce(cacher)
 	assert.Nil(t, err)
-	assert.True(t, hashInSlice(hash, txHashes))
-	assert.True(t, txInSlice(tx, transactions))
+	assert.Equal(t, 1, len(txHashesBySender))
+	assert.True(t, hashInSlice(hash, txHashesBySender[string(tx.SndAddr)]))
 }
 
 func createRandTx(rand *rand.Rand) ([]byte, *transaction.Transaction) {
 	mutex.Lock()
 	nonce := rand.Uint64()
+	sender := []byte(fmt.Sprintf("sender%d", rand.Intn(10)))
 	mutex.Unlock()
 	tx := &transaction.Transaction{
-		Nonce: nonce,
+		Nonce:   nonce,
+		SndAddr: sender,
 	}
 	marshalizer := &mock.MarshalizerMock{}
 	buffTx, _ := marshalizer.Marshal(tx)
@@ -1554,80 +2145,60 @@ func txInSlice(tx *transaction.Transaction, transactions []*transaction.Transact
 func TestSortTxByNonce_MoreTransactionsShouldNotErr(t *testing.T) {
 	t.Parallel()
 	cache, _, _ := genCacherTransactionsHashes(100)
-	_, _, err := blproc.SortTxByNonce(cache)
+	_, err := blproc.SortTxByNonce(cache)
 	assert.Nil(t, err)
 }
 
 func TestSortTxByNonce_MoreTransactionsShouldRetSameSize(t *testing.T) {
 	t.Parallel()
 	cache, genTransactions, _ := genCacherTransactionsHashes(100)
-	transactions, txHashes, _ := blproc.SortTxByNonce(cache)
-	assert.Equal(t, len(genTransactions), len(transactions))
-	assert.Equal(t, len(genTransactions), len(txHashes))
+	txHashesBySender, _ := blproc.SortTxByNonce(cache)
+	numTxHashes := 0
+	for _, txHashes := range txHashesBySender {
+		numTxHashes += len(txHashes)
+	}
+	assert.Equal(t, len(genTransactions), numTxHashes)
 }
 
 func TestSortTxByNonce_MoreTransactionsShouldContainSameElements(t *testing.T) {
 	t.Parallel()
 	cache, genTransactions, genHashes := genCacherTransactionsHashes(100)
-	transactions, txHashes, _ := blproc.SortTxByNonce(cache)
+	txHashesBySender, _ := blproc.SortTxByNonce(cache)
 	for i := 0; i < len(genTransactions); i++ {
-		assert.True(t, hashInSlice(genHashes[i], txHashes))
-		assert.True(t, txInSlice(genTransactions[i], transactions))
+		assert.True(t, hashInSlice(genHashes[i], txHashesBySender[string(genTransactions[i].SndAddr)]))
 	}
 }
 
-func TestSortTxByNonce_MoreTransactionsShouldContainSortedElements(t *testing.T) {
+func TestSortTxByNonce_MoreTransactionsShouldContainSortedElementsForEachSender(t *testing.T) {
 	t.Parallel()
 	cache, _, _ := genCacherTransactionsHashes(100)
-	transactions, _, _ := blproc.SortTxByNonce(cache)
-	lastNonce := uint64(0)
-	for i := 0; i < len(transactions); i++ {
-		tx := transactions[i]
-		assert.True(t, lastNonce <= tx.Nonce)
-		fmt.Println(tx.Nonce)
-		lastNonce = tx.Nonce
+	txHashesBySender, _ := blproc.SortTxByNonce(cache)
+	for sender, txHashes := range txHashesBySender {
+		lastNonce := uint64(0)
+		for _, txHash := range txHashes {
+			val, _ := cache.Peek(txHash)
+			tx := val.(*transaction.Transaction)
+			assert.Equal(t, sender, string(tx.SndAddr))
+			assert.True(t, lastNonce <= tx.Nonce)
+			lastNonce = tx.Nonce
+		}
 	}
 }
 
-func TestSortTxByNonce_TransactionsWithSameNonceShouldGetSorted(t *testing.T) {
+func TestSortTxByNonce_SenderTxQueuerShouldReturnItsQueues(t *testing.T) {
 	t.Parallel()
-	transactions := []*transaction.Transaction{
-		{Nonce: 1, Signature: []byte("sig1")},
-		{Nonce: 2, Signature: []byte("sig2")},
-		{Nonce: 1, Signature: []byte("sig3")},
-		{Nonce: 2, Signature: []byte("sig4")},
-		{Nonce: 3, Signature: []byte("sig5")},
-	}
-	cache, _ := storage.NewCache(storage.LRUCache, uint32(len(transactions)), 1)
-	for _, tx := range transactions {
-		marshalizer := &mock.MarshalizerMock{}
-		buffTx, _ := marshalizer.Marshal(tx)
-		hash := mock.HasherMock{}.Compute(string(buffTx))
-
-		cache.Put(hash, tx)
-	}
-	sortedTxs, _, _ := blproc.SortTxByNonce(cache)
-	lastNonce := uint64(0)
-	for i := 0; i < len(sortedTxs); i++ {
-		tx := sortedTxs[i]
-		assert.True(t, lastNonce <= tx.Nonce)
-		fmt.Printf("tx.Nonce: %d, tx.Sig: %s\n", tx.Nonce, tx.Signature)
-		lastNonce = tx.Nonce
-	}
-	assert.Equal(t, len(sortedTxs), len(transactions))
-	//test if one transaction from transactions might not be in sortedTx
-	for _, tx := range transactions {
-		found := false
-		for _, stx := range sortedTxs {
-			if reflect.DeepEqual(tx, stx) {
-				found = true
-				break
-			}
-		}
-		if !found {
-			assert.Fail(t, "Not found tx in sorted slice for sig: "+string(tx.Signature))
-		}
+	txHashesBySender := map[string][][]byte{
+		"sender": {[]byte("tx1"), []byte("tx2")},
+	}
+	cache := &mock.SenderTxQueuerStub{
+		CacherStub: mock.CacherStub{},
+		SortedTxHashesBySenderCalled: func() map[string][][]byte {
+			return txHashesBySender
+		},
 	}
+	result, err := blproc.SortTxByNonce(cache)
+	assert.Nil(t, err)
+	assert.Equal(t, txHashesBySender, result)
 }
 
 func genCacherTransactionsHashes(noOfTx int) (storage.Cacher, []*transaction.Transaction, [][]byte) {
@@ -1648,8 +2219,231 @@ func BenchmarkSortTxByNonce1(b *testing.B) {
 	cache, _, _ := genCacherTransactionsHashes(10000)
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _, _ = blproc.SortTxByNonce(cache)
+		_, _ = blproc.SortTxByNonce(cache)
+	}
+}
+
+//------- getTxs
+
+func createAccountsMockWithNonces(accountNonces map[string]uint64) *mock.AccountsStub {
+	accounts := initAccountsMock()
+	accounts.GetExistingAccountCalled = func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+		nonce, ok := accountNonces[string(addressContainer.Bytes())]
+		if !ok {
+			return nil, state.ErrAccNotFound
+		}
+
+		acnt, _ := state.NewAccount(addressContainer, &mock.AccountTrackerStub{})
+		acnt.Nonce = nonce
+		return acnt, nil
+	}
+
+	return accounts
+}
+
+func TestShardProcessor_GetTxsNilCacherShouldReturnEmpty(t *testing.T) {
+	t.Parallel()
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		createAccountsMockWithNonces(make(map[string]uint64)),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	transactions, txHashes, _ := sp.GetTxs([]storage.Cacher{nil})
+
+	assert.Equal(t, 0, len(transactions))
+	assert.Equal(t, 0, len(txHashes))
+}
+
+func TestShardProcessor_GetTxsShouldReturnOnlyExecutableTxsAndRemoveStaleOnes(t *testing.T) {
+	t.Parallel()
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		createAccountsMockWithNonces(map[string]uint64{"sndA": 5, "sndB": 0}),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	cache, _ := storage.NewCache(storage.LRUCache, 100, 1)
+	cache.Put([]byte("a4"), &transaction.Transaction{Nonce: 4, SndAddr: []byte("sndA")})
+	cache.Put([]byte("a5"), &transaction.Transaction{Nonce: 5, SndAddr: []byte("sndA")})
+	cache.Put([]byte("a6"), &transaction.Transaction{Nonce: 6, SndAddr: []byte("sndA")})
+	cache.Put([]byte("a8"), &transaction.Transaction{Nonce: 8, SndAddr: []byte("sndA")})
+	cache.Put([]byte("b1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("sndB")})
+	cache.Put([]byte("c0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("sndC")})
+
+	transactions, txHashes, _ := sp.GetTxs([]storage.Cacher{cache})
+
+	assert.Equal(t, [][]byte{[]byte("c0"), []byte("a5"), []byte("a6")}, txHashes)
+	assert.Equal(t, 3, len(transactions))
+	//stale transaction removed, the one after the nonce gap and the future one are kept
+	assert.False(t, cache.Has([]byte("a4")))
+	assert.True(t, cache.Has([]byte("a8")))
+	assert.True(t, cache.Has([]byte("b1")))
+}
+
+func TestShardProcessor_GetTxsSameNonceShouldSelectOnlyOne(t *testing.T) {
+	t.Parallel()
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		createAccountsMockWithNonces(map[string]uint64{"sndA": 0}),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	cache, _ := storage.NewCache(storage.LRUCache, 100, 1)
+	cache.Put([]byte("a0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("sndA")})
+	cache.Put([]byte("a0bis"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("sndA"), Data: []byte("bis")})
+	cache.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("sndA")})
+
+	transactions, _, _ := sp.GetTxs([]storage.Cacher{cache})
+
+	assert.Equal(t, 2, len(transactions))
+	assert.Equal(t, uint64(0), transactions[0].Nonce)
+	assert.Equal(t, uint64(1), transactions[1].Nonce)
+	assert.True(t, cache.Has([]byte("a0")))
+	assert.True(t, cache.Has([]byte("a0bis")))
+}
+
+func TestShardProcessor_GetTxsShouldMergeSenderTxsFromAllShardStores(t *testing.T) {
+	t.Parallel()
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		createAccountsMockWithNonces(make(map[string]uint64)),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	cacheShard0, _ := storage.NewCache(storage.LRUCache, 100, 1)
+	cacheShard1, _ := storage.NewCache(storage.LRUCache, 100, 1)
+	cacheShard0.Put([]byte("a0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("sndA")})
+	cacheShard1.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("sndA")})
+	cacheShard1.Put([]byte("b0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("sndB")})
+	cacheShard0.Put([]byte("b1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("sndB")})
+
+	_, txHashes, storeIndexes := sp.GetTxs([]storage.Cacher{cacheShard0, cacheShard1})
+
+	//b1 can not be selected as it would be executed before b0, its miniblock being placed first in block body
+	assert.Equal(t, [][]byte{[]byte("a0"), []byte("b0"), []byte("a1")}, txHashes)
+	assert.Equal(t, []int{0, 1, 1}, storeIndexes)
+	assert.True(t, cacheShard0.Has([]byte("b1")))
+}
+
+func TestShardProcessor_GetTxsShouldOrderSendersByGasPriceKeepingNonceOrder(t *testing.T) {
+	t.Parallel()
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		createAccountsMockWithNonces(make(map[string]uint64)),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	cache, _ := storage.NewCache(storage.LRUCache, 100, 1)
+	cache.Put([]byte("a0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("sndA"), GasPrice: 1})
+	cache.Put([]byte("a1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("sndA"), GasPrice: 100})
+	cache.Put([]byte("b0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("sndB"), GasPrice: 5})
+	cache.Put([]byte("b1"), &transaction.Transaction{Nonce: 1, SndAddr: []byte("sndB"), GasPrice: 3})
+	cache.Put([]byte("c0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("sndC"), GasPrice: 4})
+
+	_, txHashes, _ := sp.GetTxs([]storage.Cacher{cache})
+
+	//a1 offers the highest gas price but it can only be selected after a0, which offers the lowest one
+	expectedTxHashes := [][]byte{
+		[]byte("b0"),
+		[]byte("c0"),
+		[]byte("b1"),
+		[]byte("a0"),
+		[]byte("a1"),
 	}
+	assert.Equal(t, expectedTxHashes, txHashes)
+}
+
+func TestShardProcessor_GetTxsShouldOrderTheTxsOfAllShardStoresByGasPrice(t *testing.T) {
+	t.Parallel()
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		createAccountsMockWithNonces(make(map[string]uint64)),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	cacheShard0, _ := storage.NewCache(storage.LRUCache, 100, 1)
+	cacheShard1, _ := storage.NewCache(storage.LRUCache, 100, 1)
+	cacheShard0.Put([]byte("a0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("sndA"), GasPrice: 1})
+	cacheShard1.Put([]byte("b0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("sndB"), GasPrice: 10})
+	cacheShard1.Put([]byte("c0"), &transaction.Transaction{Nonce: 0, SndAddr: []byte("sndC"), GasPrice: 5})
+
+	_, txHashes, storeIndexes := sp.GetTxs([]storage.Cacher{cacheShard0, cacheShard1})
+
+	assert.Equal(t, [][]byte{[]byte("b0"), []byte("c0"), []byte("a0")}, txHashes)
+	assert.Equal(t, []int{1, 1, 0}, storeIndexes)
 }
 
 func TestBlockProcessor_CreateBlockHeaderShouldNotReturnNil(t *testing.T) {
@@ -1667,6 +2461,10 @@ func TestBlockProcessor_CreateBlockHeaderShouldNotReturnNil(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	mbHeaders, err := bp.CreateBlockHeader(nil, 0, func() bool {
 		return true
@@ -1691,6 +2489,10 @@ func TestShardProcessor_CreateBlockHeaderShouldErrWhenMarshalizerErrors(t *testi
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	body := block.Body{
 		{
@@ -1724,13 +2526,17 @@ func TestShardProcessor_CreateBlockHeaderReturnsOK(t *testing.T) {
 		&mock.HasherStub{},
 		&mock.MarshalizerMock{},
 		&mock.TxProcessorMock{},
-		initAccountsMock(),
+		createSupplyChangeAccounts(&state.StateDiff{}, nil),
 		mock.NewOneShardCoordinatorMock(),
 		&mock.ForkDetectorMock{},
 		&mock.BlocksTrackerMock{},
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	body := block.Body{
 		{
@@ -1754,93 +2560,298 @@ func TestShardProcessor_CreateBlockHeaderReturnsOK(t *testing.T) {
 	})
 	assert.Nil(t, err)
 	assert.Equal(t, len(body), len(mbHeaders.(*block.Header).MiniBlockHeaders))
+	assert.Equal(t, big.NewInt(0), mbHeaders.(*block.Header).SupplyDelta)
 }
 
-func TestShardProcessor_CommitBlockShouldRevertAccountStateWhenErr(t *testing.T) {
+func TestShardProcessor_CreateBlockHeaderShouldSetTheEpochOfTheRound(t *testing.T) {
 	t.Parallel()
-	// set accounts dirty
-	journalEntries := 3
-	revToSnapshot := func(snapshot int) error {
-		journalEntries = 0
-		return nil
-	}
 	bp, _ := blproc.NewShardProcessor(
 		initDataPool(),
 		initStore(),
 		&mock.HasherStub{},
 		&mock.MarshalizerMock{},
 		&mock.TxProcessorMock{},
-		&mock.AccountsStub{
-			RevertToSnapshotCalled: revToSnapshot,
-		},
+		createSupplyChangeAccounts(&state.StateDiff{}, nil),
 		mock.NewOneShardCoordinatorMock(),
 		&mock.ForkDetectorMock{},
 		&mock.BlocksTrackerMock{},
-		func(destShardID uint32, txHashes [][]byte) {
-		},
+		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
-	err := bp.CommitBlock(nil, nil, nil)
-	assert.NotNil(t, err)
-	assert.Equal(t, 0, journalEntries)
+	_ = bp.SetRoundsPerEpoch(10)
+
+	hdr, err := bp.CreateBlockHeader(block.Body{}, 25, func() bool {
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), hdr.GetEpoch())
 }
 
-func TestShardProcessor_MarshalizedDataToBroadcastShouldWork(t *testing.T) {
-	t.Parallel()
-	tdp := initDataPool()
-	txHash0 := []byte("txHash0")
-	mb0 := block.MiniBlock{
-		ReceiverShardID: 0,
-		SenderShardID:   0,
-		TxHashes:        [][]byte{[]byte(txHash0)},
+func createSupplyChangeBody(dataPool dataRetriever.PoolsHolder) block.Body {
+	txHash := []byte("tx hash")
+	tx := &transaction.Transaction{Nonce: 1, Value: big.NewInt(5), GasPrice: 1, GasLimit: 1, SndAddr: []byte("snd0"), RcvAddr: []byte("rcv1")}
+	dataPool.Transactions().AddData(txHash, tx, process.ShardCacherIdentifier(0, 1))
+
+	return block.Body{
+		&block.MiniBlock{SenderShardID: 0, ReceiverShardID: 1, TxHashes: [][]byte{txHash}},
+		&block.MiniBlock{SenderShardID: 0, ReceiverShardID: 0, TxHashes: make([][]byte, 0)},
 	}
-	txHash1 := []byte("txHash1")
-	mb1 := block.MiniBlock{
-		ReceiverShardID: 1,
-		SenderShardID:   0,
-		TxHashes:        [][]byte{[]byte(txHash1)},
+}
+
+func createSupplyChangeAccounts(diff *state.StateDiff, diffErr error) *mock.AccountsStub {
+	return &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return diff, diffErr
+		},
+		RootHashCalled: func() []byte {
+			return []byte("rootHash")
+		},
 	}
-	body := make(block.Body, 0)
-	body = append(body, &mb0)
-	body = append(body, &mb1)
-	body = append(body, &mb0)
-	body = append(body, &mb1)
-	marshalizer := &mock.MarshalizerMock{
-		Fail: false,
+}
+
+func TestShardProcessor_CreateBlockHeaderShouldSetTheSupplyChange(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	body := createSupplyChangeBody(dataPool)
+	diff := &state.StateDiff{
+		Accounts: []state.AccountDiff{
+			{Address: []byte("snd0"), OldBalance: big.NewInt(10), NewBalance: big.NewInt(5)},
+		},
 	}
 	sp, _ := blproc.NewShardProcessor(
-		tdp,
+		dataPool,
 		initStore(),
-		&mock.HasherStub{},
-		marshalizer,
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
 		&mock.TxProcessorMock{},
-		initAccountsMock(),
+		createSupplyChangeAccounts(diff, nil),
 		mock.NewOneShardCoordinatorMock(),
 		&mock.ForkDetectorMock{},
 		&mock.BlocksTrackerMock{},
 		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
-	msh, mstx, err := sp.MarshalizedDataToBroadcast(&block.Header{}, body)
-	assert.Nil(t, err)
-	assert.NotNil(t, msh)
-	assert.NotNil(t, mstx)
-	_, found := msh[0]
-	assert.False(t, found)
 
-	expectedBody := make(block.Body, 0)
-	err = marshalizer.Unmarshal(&expectedBody, msh[1])
+	hdr, err := sp.CreateBlockHeader(body, 0, func() bool {
+		return true
+	})
+
 	assert.Nil(t, err)
-	assert.Equal(t, 2, len(expectedBody))
-	assert.Equal(t, &mb1, expectedBody[0])
-	assert.Equal(t, &mb1, expectedBody[1])
+	header := hdr.(*block.Header)
+	assert.Equal(t, big.NewInt(-5), header.SupplyDelta)
+	assert.Equal(t, big.NewInt(0), header.BurntFees)
+	assert.Equal(t, uint32(0), header.MiniBlockHeaders[0].SenderShardID)
+	assert.Equal(t, big.NewInt(5), header.MiniBlockHeaders[0].Value)
+	assert.Nil(t, header.MiniBlockHeaders[1].Value)
 }
 
-func TestShardProcessor_MarshalizedDataWrongType(t *testing.T) {
+func TestShardProcessor_CreateBlockHeaderWithoutStateDiffShouldErr(t *testing.T) {
 	t.Parallel()
-	tdp := initDataPool()
-	marshalizer := &mock.MarshalizerMock{
-		Fail: false,
+
+	dataPool := mock.NewPoolsHolderFake()
+	body := createSupplyChangeBody(dataPool)
+	sp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		createSupplyChangeAccounts(nil, state.ErrNoPreviousState),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	hdr, err := sp.CreateBlockHeader(body, 0, func() bool {
+		return true
+	})
+
+	assert.Nil(t, hdr)
+	assert.Equal(t, state.ErrNoPreviousState, err)
+}
+
+func TestShardProcessor_VerifySupplyChangeShouldRejectAChangeNotCausedByTheBlock(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	body := createSupplyChangeBody(dataPool)
+	diff := &state.StateDiff{
+		Accounts: []state.AccountDiff{
+			{Address: []byte("snd0"), OldBalance: big.NewInt(10), NewBalance: big.NewInt(5)},
+		},
+	}
+	sp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		createSupplyChangeAccounts(diff, nil),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+	hdr, _ := sp.CreateBlockHeader(body, 0, func() bool {
+		return true
+	})
+	header := hdr.(*block.Header)
+
+	assert.Nil(t, sp.VerifySupplyChange(header, body))
+
+	header.MiniBlockHeaders[0].Value = big.NewInt(4)
+	assert.Equal(t, process.ErrSupplyChangeMismatch, sp.VerifySupplyChange(header, body))
+
+	header.MiniBlockHeaders[0].Value = big.NewInt(5)
+	header.SupplyDelta = big.NewInt(-4)
+	assert.Equal(t, process.ErrSupplyChangeMismatch, sp.VerifySupplyChange(header, body))
+
+	header.SupplyDelta = nil
+	assert.Equal(t, process.ErrNilSupplyDelta, sp.VerifySupplyChange(header, body))
+}
+
+func TestShardProcessor_VerifySupplyChangeWithoutStateDiffShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	body := createSupplyChangeBody(dataPool)
+	sp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		createSupplyChangeAccounts(nil, state.ErrNoPreviousState),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	err := sp.VerifySupplyChange(&block.Header{SupplyDelta: big.NewInt(0)}, body)
+
+	assert.Equal(t, state.ErrNoPreviousState, err)
+}
+
+func TestShardProcessor_CommitBlockShouldRevertAccountStateWhenErr(t *testing.T) {
+	t.Parallel()
+	// set accounts dirty
+	journalEntries := 3
+	revToSnapshot := func(snapshot int) error {
+		journalEntries = 0
+		return nil
+	}
+	bp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		&mock.AccountsStub{
+			RevertToSnapshotCalled: revToSnapshot,
+		},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {
+		},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+	err := bp.CommitBlock(nil, nil, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, journalEntries)
+}
+
+func TestShardProcessor_MarshalizedDataToBroadcastShouldWork(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool()
+	txHash0 := []byte("txHash0")
+	mb0 := block.MiniBlock{
+		ReceiverShardID: 0,
+		SenderShardID:   0,
+		TxHashes:        [][]byte{[]byte(txHash0)},
+	}
+	txHash1 := []byte("txHash1")
+	mb1 := block.MiniBlock{
+		ReceiverShardID: 1,
+		SenderShardID:   0,
+		TxHashes:        [][]byte{[]byte(txHash1)},
+	}
+	body := make(block.Body, 0)
+	body = append(body, &mb0)
+	body = append(body, &mb1)
+	body = append(body, &mb0)
+	body = append(body, &mb1)
+	marshalizer := &mock.MarshalizerMock{
+		Fail: false,
+	}
+	sp, _ := blproc.NewShardProcessor(
+		tdp,
+		initStore(),
+		&mock.HasherStub{},
+		marshalizer,
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+	msh, mstx, err := sp.MarshalizedDataToBroadcast(&block.Header{}, body)
+	assert.Nil(t, err)
+	assert.NotNil(t, msh)
+	assert.NotNil(t, mstx)
+	_, found := msh[0]
+	assert.False(t, found)
+
+	expectedBody := make(block.Body, 0)
+	err = marshalizer.Unmarshal(&expectedBody, msh[1])
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(expectedBody))
+	assert.Equal(t, &mb1, expectedBody[0])
+	assert.Equal(t, &mb1, expectedBody[1])
+}
+
+func TestShardProcessor_MarshalizedDataWrongType(t *testing.T) {
+	t.Parallel()
+	tdp := initDataPool()
+	marshalizer := &mock.MarshalizerMock{
+		Fail: false,
 	}
 	sp, _ := blproc.NewShardProcessor(
 		tdp,
@@ -1855,6 +2866,10 @@ func TestShardProcessor_MarshalizedDataWrongType(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	wr := wrongBody{}
 	msh, mstx, err := sp.MarshalizedDataToBroadcast(&block.Header{}, wr)
@@ -1882,6 +2897,10 @@ func TestShardProcessor_MarshalizedDataNilInput(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	msh, mstx, err := sp.MarshalizedDataToBroadcast(nil, nil)
 	assert.Equal(t, process.ErrNilMiniBlocks, err)
@@ -1918,6 +2937,10 @@ func TestShardProcessor_MarshalizedDataMarshalWithoutSuccess(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	msh, mstx, err := sp.MarshalizedDataToBroadcast(&block.Header{}, body)
 	assert.Equal(t, process.ErrMarshalWithoutSuccess, err)
@@ -1974,6 +2997,10 @@ func TestShardProcessor_GetAllTxsFromMiniBlockShouldWork(t *testing.T) {
 		&mock.BlocksTrackerMock{},
 		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	mb := &block.MiniBlock{
@@ -2018,6 +3045,10 @@ func TestShardProcessor_ReceivedTransactionShouldEraseRequested(t *testing.T) {
 		&mock.BlocksTrackerMock{},
 		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	//add 3 tx hashes on requested list
@@ -2097,6 +3128,10 @@ func TestShardProcessor_ReceivedMiniBlockShouldRequestMissingTransactions(t *tes
 			}
 		},
 		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	bp.ReceivedMiniBlock(miniBlockHash)
@@ -2179,6 +3214,10 @@ func TestShardProcessor_ReceivedMetaBlockShouldRequestMissingMiniBlocks(t *testi
 				atomic.AddInt32(&miniBlockHash3Requested, 1)
 			}
 		},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	bp.ReceivedMetaBlock(metaBlockHash)
@@ -2275,6 +3314,10 @@ func TestShardProcessor_ProcessMiniBlockCompleteWithOkTxsShouldExecuteThemAndNot
 		&mock.BlocksTrackerMock{},
 		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	err := bp.ProcessMiniBlockComplete(&miniBlock, 0, func() bool {
@@ -2366,6 +3409,10 @@ func TestShardProcessor_ProcessMiniBlockCompleteWithErrorWhileProcessShouldCallR
 		&mock.BlocksTrackerMock{},
 		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	err := bp.ProcessMiniBlockComplete(&miniBlock, 0, func() bool {
@@ -2376,6 +3423,163 @@ func TestShardProcessor_ProcessMiniBlockCompleteWithErrorWhileProcessShouldCallR
 	assert.True(t, revertAccntStateCalled)
 }
 
+func TestShardProcessor_SetTransactionsExecutorNilExecutorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	err := sp.SetTransactionsExecutor(nil)
+
+	assert.Equal(t, process.ErrNilTransactionsExecutor, err)
+}
+
+func TestShardProcessor_ProcessMiniBlockCompleteWithTransactionsExecutorShouldUseIt(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+
+	txHash1 := []byte("tx hash 1")
+	txHash2 := []byte("tx hash 2")
+
+	senderShardId := uint32(1)
+	receiverShardId := uint32(2)
+
+	miniBlock := block.MiniBlock{
+		SenderShardID:   senderShardId,
+		ReceiverShardID: receiverShardId,
+		TxHashes:        [][]byte{txHash1, txHash2},
+	}
+
+	tx1 := &transaction.Transaction{Nonce: 45, Data: txHash1}
+	tx2 := &transaction.Transaction{Nonce: 46, Data: txHash2}
+	cacheId := process.ShardCacherIdentifier(senderShardId, receiverShardId)
+	dataPool.Transactions().AddData(txHash1, tx1, cacheId)
+	dataPool.Transactions().AddData(txHash2, tx2, cacheId)
+
+	bp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{
+			ProcessTransactionCalled: func(transaction *transaction.Transaction, round int32) error {
+				assert.Fail(t, "transactions should have been processed by the executor")
+				return nil
+			},
+		},
+		&mock.AccountsStub{
+			RevertToSnapshotCalled: func(snapshot int) error {
+				assert.Fail(t, "revert should have not been called")
+				return nil
+			},
+			JournalLenCalled: func() int {
+				return 0
+			},
+		},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	var executedTxs []*transaction.Transaction
+	err := bp.SetTransactionsExecutor(&mock.TransactionsExecutorStub{
+		ExecuteTransactionsCalled: func(txs []*transaction.Transaction, round int32) error {
+			executedTxs = txs
+			return nil
+		},
+	})
+	assert.Nil(t, err)
+
+	err = bp.ProcessMiniBlockComplete(&miniBlock, 0, func() bool {
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*transaction.Transaction{tx1, tx2}, executedTxs)
+}
+
+func TestShardProcessor_RemoveExpiredTransactionsShouldRemoveOnlyExpiredTxsSentFromSelfShard(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(2)
+
+	expiredTxHash := []byte("expired tx hash")
+	validTxHash := []byte("valid tx hash")
+	unboundedTxHash := []byte("unbounded tx hash")
+	crossExpiredTxHash := []byte("cross expired tx hash")
+
+	selfCacheId := process.ShardCacherIdentifier(0, 1)
+	crossCacheId := process.ShardCacherIdentifier(1, 0)
+	dataPool.Transactions().AddData(expiredTxHash, &transaction.Transaction{
+		Nonce:           1,
+		SndAddr:         []byte("sender"),
+		ValidUntilRound: 9,
+	}, selfCacheId)
+	dataPool.Transactions().AddData(validTxHash, &transaction.Transaction{
+		Nonce:           2,
+		SndAddr:         []byte("sender"),
+		ValidUntilRound: 10,
+	}, selfCacheId)
+	dataPool.Transactions().AddData(unboundedTxHash, &transaction.Transaction{
+		Nonce:   3,
+		SndAddr: []byte("sender"),
+	}, selfCacheId)
+	dataPool.Transactions().AddData(crossExpiredTxHash, &transaction.Transaction{
+		Nonce:           1,
+		SndAddr:         []byte("cross sender"),
+		ValidUntilRound: 9,
+	}, crossCacheId)
+
+	sp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		shardCoordinator,
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	sp.RemoveExpiredTransactions(10)
+
+	selfStore := dataPool.Transactions().ShardDataStore(selfCacheId)
+	assert.False(t, selfStore.Has(expiredTxHash))
+	assert.True(t, selfStore.Has(validTxHash))
+	assert.True(t, selfStore.Has(unboundedTxHash))
+	assert.True(t, dataPool.Transactions().ShardDataStore(crossCacheId).Has(crossExpiredTxHash))
+}
+
 //------- createMiniBlocks
 
 func TestShardProcessor_CreateMiniBlocksShouldWorkWithIntraShardTxs(t *testing.T) {
@@ -2446,12 +3650,21 @@ func TestShardProcessor_CreateMiniBlocksShouldWorkWithIntraShardTxs(t *testing.T
 			JournalLenCalled: func() int {
 				return 0
 			},
+			GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+				acnt, _ := state.NewAccount(addressContainer, &mock.AccountTrackerStub{})
+				acnt.Nonce = tx1Nonce
+				return acnt, nil
+			},
 		},
 		mock.NewOneShardCoordinatorMock(),
 		&mock.ForkDetectorMock{},
 		&mock.BlocksTrackerMock{},
 		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	blockBody, err := bp.CreateMiniBlocks(1, 15000, 0, func() bool {
@@ -2473,26 +3686,313 @@ func TestShardProcessor_CreateMiniBlocksShouldWorkWithIntraShardTxs(t *testing.T
 	assert.True(t, isInTxHashes(txHash3, blockBody[0].TxHashes))
 }
 
-//------- removeMetaBlockFromPool
-
-func TestShardProcessor_RemoveMetaBlockFromPoolShouldWork(t *testing.T) {
+func TestShardProcessor_CreateMiniBlocksShouldNotExceedMaxGasLimitInBlock(t *testing.T) {
 	t.Parallel()
 
-	//we have 3 metablocks in pool each containing 2 miniblocks.
-	//blockbody will have 2 + 1 miniblocks from 2 out of the 3 metablocks
-	//The test should remove only one metablock
-
-	destShardId := uint32(2)
-
-	hasher := mock.HasherMock{}
-	marshalizer := &mock.MarshalizerMock{}
 	dataPool := mock.NewPoolsHolderFake()
-
-	miniblocks := make([]*block.MiniBlock, 6)
-	miniblockHashes := make([][]byte, 6)
-
-	destShards := []uint32{1, 3, 4}
-	for i := 0; i < 6; i++ {
+	cacheId := process.ShardCacherIdentifier(0, 0)
+	dataPool.Transactions().AddData([]byte("a0"), &transaction.Transaction{
+		Nonce: 0, SndAddr: []byte("sndA"), GasPrice: 10, GasLimit: 60}, cacheId)
+	dataPool.Transactions().AddData([]byte("a1"), &transaction.Transaction{
+		Nonce: 1, SndAddr: []byte("sndA"), GasPrice: 10, GasLimit: 60}, cacheId)
+	dataPool.Transactions().AddData([]byte("a2"), &transaction.Transaction{
+		Nonce: 2, SndAddr: []byte("sndA"), GasPrice: 10, GasLimit: 10}, cacheId)
+	dataPool.Transactions().AddData([]byte("b0"), &transaction.Transaction{
+		Nonce: 0, SndAddr: []byte("sndB"), GasPrice: 5, GasLimit: 30}, cacheId)
+
+	processedTxs := make([]uint64, 0)
+	accounts := createAccountsMockWithNonces(make(map[string]uint64))
+	accounts.JournalLenCalled = func() int {
+		return 0
+	}
+	bp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{
+			ProcessTransactionCalled: func(transaction *transaction.Transaction, round int32) error {
+				processedTxs = append(processedTxs, transaction.GasLimit)
+				return nil
+			},
+		},
+		accounts,
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		100,
+	)
+
+	blockBody, err := bp.CreateMiniBlocks(1, 15000, 0, func() bool {
+		return true
+	})
+
+	assert.Nil(t, err)
+	//a1 does not fit so a2 is skipped as well, b0 still fits
+	assert.Equal(t, 1, len(blockBody))
+	assert.Equal(t, [][]byte{[]byte("a0"), []byte("b0")}, blockBody[0].TxHashes)
+	assert.Equal(t, []uint64{60, 30}, processedTxs)
+}
+
+func TestShardProcessor_CreateMiniBlocksShouldSelectByGasPriceAcrossAllShardStores(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	dataPool.Transactions().AddData([]byte("a0"), &transaction.Transaction{
+		Nonce: 0, SndAddr: []byte("sndA"), GasPrice: 1, GasLimit: 1}, process.ShardCacherIdentifier(0, 0))
+	dataPool.Transactions().AddData([]byte("b0"), &transaction.Transaction{
+		Nonce: 0, SndAddr: []byte("sndB"), GasPrice: 10, GasLimit: 1}, process.ShardCacherIdentifier(0, 1))
+
+	accounts := createAccountsMockWithNonces(make(map[string]uint64))
+	accounts.JournalLenCalled = func() int {
+		return 0
+	}
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(2)
+	bp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{
+			ProcessTransactionCalled: func(transaction *transaction.Transaction, round int32) error {
+				return nil
+			},
+		},
+		accounts,
+		shardCoordinator,
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	blockBody, err := bp.CreateMiniBlocks(2, 1, 0, func() bool {
+		return true
+	})
+
+	//the transaction towards shard 1 offers a higher gas price, so it takes the only place in the block
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(blockBody))
+	assert.Equal(t, uint32(1), blockBody[0].ReceiverShardID)
+	assert.Equal(t, [][]byte{[]byte("b0")}, blockBody[0].TxHashes)
+}
+
+func TestShardProcessor_CreateMiniBlocksShouldCountTheGasOfMiniBlocksDstMe(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	miniBlockHash := []byte("dst me miniblock")
+	dataPool.MiniBlocks().Put(miniBlockHash, &block.MiniBlock{
+		SenderShardID:   1,
+		ReceiverShardID: 0,
+		TxHashes:        [][]byte{[]byte("dstMe")},
+	})
+	metaBlock := createDummyMetaBlock(0, 1, miniBlockHash).(*block.MetaBlock)
+	metaBlock.ShardInfo[0].ShardId = 1
+	dataPool.MetaBlocks().Put([]byte("meta hash"), metaBlock)
+	dataPool.Transactions().AddData([]byte("dstMe"), &transaction.Transaction{
+		Nonce: 0, SndAddr: []byte("sndX"), GasPrice: 1, GasLimit: 60}, process.ShardCacherIdentifier(1, 0))
+	dataPool.Transactions().AddData([]byte("a0"), &transaction.Transaction{
+		Nonce: 0, SndAddr: []byte("sndA"), GasPrice: 10, GasLimit: 50}, process.ShardCacherIdentifier(0, 0))
+	dataPool.Transactions().AddData([]byte("b0"), &transaction.Transaction{
+		Nonce: 0, SndAddr: []byte("sndB"), GasPrice: 5, GasLimit: 30}, process.ShardCacherIdentifier(0, 0))
+
+	accounts := createAccountsMockWithNonces(make(map[string]uint64))
+	accounts.JournalLenCalled = func() int {
+		return 0
+	}
+	bp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{
+			ProcessTransactionCalled: func(transaction *transaction.Transaction, round int32) error {
+				return nil
+			},
+		},
+		accounts,
+		mock.NewMultiShardsCoordinatorMock(2),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		100,
+	)
+
+	blockBody, err := bp.CreateMiniBlocks(2, 15000, 0, func() bool {
+		return true
+	})
+
+	//a0 does not fit next to the miniblock with destination in self shard, b0 still fits
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(blockBody))
+	assert.Equal(t, [][]byte{[]byte("dstMe")}, blockBody[0].TxHashes)
+	assert.Equal(t, [][]byte{[]byte("b0")}, blockBody[1].TxHashes)
+}
+
+//------- batch transfers
+
+func createBatchTransferShardCoordinator(selfId uint32) sharding.Coordinator {
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	shardCoordinator.CurrentShard = selfId
+	shardCoordinator.ComputeIdCalled = func(address state.AddressContainer) uint32 {
+		addressBytes := address.Bytes()
+		return uint32(addressBytes[len(addressBytes)-1] - '0')
+	}
+
+	return shardCoordinator
+}
+
+func createBatchTransferForShards() *transaction.Transaction {
+	return &transaction.Transaction{
+		Nonce:    0,
+		Value:    big.NewInt(0),
+		SndAddr:  []byte("snd1"),
+		RcvAddr:  []byte("snd1"),
+		GasPrice: 1,
+		GasLimit: 1,
+		Transfers: []transaction.Transfer{
+			{RcvAddr: []byte("rcv0"), Value: big.NewInt(1)},
+			{RcvAddr: []byte("rcv1"), Value: big.NewInt(2)},
+			{RcvAddr: []byte("rcv2"), Value: big.NewInt(3)},
+		},
+	}
+}
+
+func TestShardProcessor_CreateMiniBlocksShouldCarryBatchTransferToReceiverShards(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	batchHash := []byte("batch")
+	batchTx := createBatchTransferForShards()
+	for shardId := uint32(0); shardId < 3; shardId++ {
+		dataPool.Transactions().AddData(batchHash, batchTx, process.ShardCacherIdentifier(1, shardId))
+	}
+	otherHash := []byte("other")
+	otherTx := &transaction.Transaction{Nonce: 0, SndAddr: []byte("oth1"), RcvAddr: []byte("rcv0"), GasPrice: 1, GasLimit: 1}
+	dataPool.Transactions().AddData(otherHash, otherTx, process.ShardCacherIdentifier(1, 0))
+
+	processedTxs := make([]*transaction.Transaction, 0)
+	accounts := createAccountsMockWithNonces(map[string]uint64{"snd1": 0, "oth1": 0})
+	accounts.JournalLenCalled = func() int {
+		return 0
+	}
+	bp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{
+			ProcessTransactionCalled: func(transaction *transaction.Transaction, round int32) error {
+				processedTxs = append(processedTxs, transaction)
+				return nil
+			},
+		},
+		accounts,
+		createBatchTransferShardCoordinator(1),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards1")),
+		15000,
+		1000000000,
+	)
+
+	blockBody, err := bp.CreateMiniBlocks(3, 15000, 0, func() bool {
+		return true
+	})
+
+	assert.Nil(t, err)
+	//the batch transfer is executed once, with the intra shard miniblock
+	assert.Equal(t, []*transaction.Transaction{otherTx, batchTx}, processedTxs)
+	assert.Equal(t, 3, len(blockBody))
+	assert.Equal(t, uint32(0), blockBody[0].ReceiverShardID)
+	assert.Equal(t, [][]byte{otherHash, batchHash}, blockBody[0].TxHashes)
+	assert.Equal(t, uint32(1), blockBody[1].ReceiverShardID)
+	assert.Equal(t, [][]byte{batchHash}, blockBody[1].TxHashes)
+	assert.Equal(t, uint32(2), blockBody[2].ReceiverShardID)
+	assert.Equal(t, [][]byte{batchHash}, blockBody[2].TxHashes)
+	assert.Nil(t, bp.CheckBatchTransfers(blockBody))
+}
+
+func TestShardProcessor_CheckBatchTransfersShouldErrWhenInconsistent(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	batchHash := []byte("batch")
+	for shardId := uint32(0); shardId < 3; shardId++ {
+		dataPool.Transactions().AddData(batchHash, createBatchTransferForShards(), process.ShardCacherIdentifier(1, shardId))
+	}
+
+	bp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		createBatchTransferShardCoordinator(1),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards1")),
+		15000,
+		1000000000,
+	)
+
+	createMiniBlock := func(receiverShardId uint32) *block.MiniBlock {
+		return &block.MiniBlock{SenderShardID: 1, ReceiverShardID: receiverShardId, TxHashes: [][]byte{batchHash}}
+	}
+
+	//not carried towards shard 2
+	err := bp.CheckBatchTransfers(block.Body{createMiniBlock(0), createMiniBlock(1)})
+	assert.Equal(t, process.ErrInconsistentBatchTransfer, err)
+
+	//not executed with the intra shard miniblock
+	err = bp.CheckBatchTransfers(block.Body{createMiniBlock(0), createMiniBlock(2)})
+	assert.Equal(t, process.ErrInconsistentBatchTransfer, err)
+
+	err = bp.CheckBatchTransfers(block.Body{createMiniBlock(0), createMiniBlock(1), createMiniBlock(2)})
+	assert.Nil(t, err)
+}
+
+//------- removeMetaBlockFromPool
+
+func TestShardProcessor_RemoveMetaBlockFromPoolShouldWork(t *testing.T) {
+	t.Parallel()
+
+	//we have 3 metablocks in pool each containing 2 miniblocks.
+	//blockbody will have 2 + 1 miniblocks from 2 out of the 3 metablocks
+	//The test should remove only one metablock
+
+	destShardId := uint32(2)
+
+	hasher := mock.HasherMock{}
+	marshalizer := &mock.MarshalizerMock{}
+	dataPool := mock.NewPoolsHolderFake()
+
+	miniblocks := make([]*block.MiniBlock, 6)
+	miniblockHashes := make([][]byte, 6)
+
+	destShards := []uint32{1, 3, 4}
+	for i := 0; i < 6; i++ {
 		mb, hash := createDummyMiniBlock(fmt.Sprintf("tx hash %d", i), marshalizer, hasher, destShardId, destShards[i/2])
 		miniblocks[i] = mb
 		miniblockHashes[i] = hash
@@ -2551,6 +4051,10 @@ func TestShardProcessor_RemoveMetaBlockFromPoolShouldWork(t *testing.T) {
 		},
 		func(destShardID uint32, txHashes [][]byte) {},
 		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	//create block body with first 3 miniblocks from miniblocks var
@@ -2587,6 +4091,10 @@ func TestBlockProcessor_RestoreBlockIntoPoolsShouldErrNilBlockChain(t *testing.T
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	err := be.RestoreBlockIntoPools(nil, nil)
 	assert.NotNil(t, err)
@@ -2609,6 +4117,10 @@ func TestBlockProcessor_RestoreBlockIntoPoolsShouldErrNilTxBlockBody(t *testing.
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	err := sp.RestoreBlockIntoPools(nil, nil)
@@ -2649,6 +4161,10 @@ func TestShardProcessor_RestoreBlockIntoPoolsShouldWork(t *testing.T) {
 		},
 		func(destShardID uint32, txHash []byte) {
 		},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 
 	txHashes := make([][]byte, 0)
@@ -2702,6 +4218,10 @@ func TestShardProcessor_DecodeBlockBody(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	body := make(block.Body, 0)
 	body = append(body, &block.MiniBlock{ReceiverShardID: 69})
@@ -2733,6 +4253,10 @@ func TestShardProcessor_DecodeBlockHeader(t *testing.T) {
 		func(destShardID uint32, txHashes [][]byte) {
 		},
 		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
 	)
 	hdr := &block.Header{}
 	hdr.Nonce = 1
@@ -2751,3 +4275,1085 @@ func TestShardProcessor_DecodeBlockHeader(t *testing.T) {
 	assert.Equal(t, hdr, dcdHdr)
 	assert.Equal(t, []byte("A"), dcdHdr.GetSignature())
 }
+
+func TestShardProcessor_SetStatePrunerNilPrunerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	err := sp.SetStatePruner(nil)
+
+	assert.Equal(t, process.ErrNilStatePruner, err)
+}
+
+func TestShardProcessor_CommitBlockShouldPruneState(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	hdrHash := []byte("header hash")
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      rootHash,
+	}
+	body := block.Body{}
+	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return &state.StateDiff{}, nil
+		},
+		CommitCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+		RootHashCalled: func() []byte {
+			return rootHash
+		},
+	}
+	fd := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState) error {
+			return nil
+		},
+		GetHighestFinalBlockNonceCalled: func() uint64 {
+			return 3
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return hdrHash
+	}
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		hasher,
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		accounts,
+		mock.NewOneShardCoordinatorMock(),
+		fd,
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+		},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	var addedNonce uint64
+	var addedRoot []byte
+	var prunedNonce uint64
+	_ = sp.SetStatePruner(&mock.StatePrunerStub{
+		AddRootCalled: func(nonce uint64, rootHash []byte) {
+			addedNonce = nonce
+			addedRoot = rootHash
+		},
+		PruneCalled: func(finalNonce uint64) error {
+			prunedNonce = finalNonce
+			return nil
+		},
+	})
+
+	blkc := createTestBlockchain()
+	err := sp.CommitBlock(blkc, hdr, body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), addedNonce)
+	assert.Equal(t, rootHash, addedRoot)
+	assert.Equal(t, uint64(3), prunedNonce)
+	//this should sleep as there is an async call to display current header and block in CommitBlock
+	time.Sleep(time.Second)
+}
+
+func TestShardProcessor_SetUint64ConverterNilConverterShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	err := sp.SetUint64Converter(nil)
+
+	assert.Equal(t, process.ErrNilUint64Converter, err)
+}
+
+func TestShardProcessor_CommitBlockShouldSaveHeaderHashByNonce(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	hdrHash := []byte("header hash")
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      rootHash,
+	}
+	body := block.Body{}
+	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return &state.StateDiff{}, nil
+		},
+		CommitCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+		RootHashCalled: func() []byte {
+			return rootHash
+		},
+	}
+	fd := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState) error {
+			return nil
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return hdrHash
+	}
+	store := initStore()
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		store,
+		hasher,
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		accounts,
+		mock.NewOneShardCoordinatorMock(),
+		fd,
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+		},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+	converter := mock.NewNonceHashConverterMock()
+	_ = sp.SetUint64Converter(converter)
+
+	blkc := createTestBlockchain()
+	err := sp.CommitBlock(blkc, hdr, body)
+	assert.Nil(t, err)
+
+	storedHash, err := store.Get(dataRetriever.ShardHdrNonceHashDataUnitFor(0), converter.ToByteSlice(5))
+	assert.Nil(t, err)
+	assert.Equal(t, hdrHash, storedHash)
+	//this should sleep as there is an async call to display current header and block in CommitBlock
+	time.Sleep(time.Second)
+}
+
+func TestShardProcessor_CommitBlockShouldSaveStateDiffByHeaderHash(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	hdrHash := []byte("header hash")
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      rootHash,
+	}
+	body := block.Body{}
+	diff := &state.StateDiff{
+		PrevRootHash: []byte("prev root hash"),
+		RootHash:     rootHash,
+		Accounts: []state.AccountDiff{
+			{
+				Address:    []byte("address"),
+				OldNonce:   1,
+				NewNonce:   2,
+				OldBalance: big.NewInt(10),
+				NewBalance: big.NewInt(5),
+			},
+		},
+	}
+	stateDiffCalled := false
+	commitCalled := false
+	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			assert.False(t, commitCalled)
+			stateDiffCalled = true
+			return diff, nil
+		},
+		CommitCalled: func() (i []byte, e error) {
+			commitCalled = true
+			return rootHash, nil
+		},
+		RootHashCalled: func() []byte {
+			return rootHash
+		},
+	}
+	fd := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState) error {
+			return nil
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return hdrHash
+	}
+	marshalizer := &mock.MarshalizerMock{}
+	store := initStore()
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		store,
+		hasher,
+		marshalizer,
+		&mock.TxProcessorMock{},
+		accounts,
+		mock.NewOneShardCoordinatorMock(),
+		fd,
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+		},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	blkc := createTestBlockchain()
+	err := sp.CommitBlock(blkc, hdr, body)
+	assert.Nil(t, err)
+	assert.True(t, stateDiffCalled)
+
+	buff, err := store.Get(dataRetriever.StateDiffUnit, hdrHash)
+	assert.Nil(t, err)
+	storedDiff := &state.StateDiff{}
+	err = marshalizer.Unmarshal(storedDiff, buff)
+	assert.Nil(t, err)
+	assert.Equal(t, diff, storedDiff)
+	//this should sleep as there is an async call to display current header and block in CommitBlock
+	time.Sleep(time.Second)
+}
+
+func TestShardProcessor_CommitBlockStateDiffErrorShouldNotStopCommit(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	hdrHash := []byte("header hash")
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      rootHash,
+	}
+	body := block.Body{}
+	commitCalled := false
+	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return nil, state.ErrNoPreviousState
+		},
+		CommitCalled: func() (i []byte, e error) {
+			commitCalled = true
+			return rootHash, nil
+		},
+		RootHashCalled: func() []byte {
+			return rootHash
+		},
+	}
+	fd := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState) error {
+			return nil
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return hdrHash
+	}
+	store := initStore()
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		store,
+		hasher,
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		accounts,
+		mock.NewOneShardCoordinatorMock(),
+		fd,
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+		},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	blkc := createTestBlockchain()
+	err := sp.CommitBlock(blkc, hdr, body)
+	assert.Nil(t, err)
+	assert.True(t, commitCalled)
+
+	_, err = store.Get(dataRetriever.StateDiffUnit, hdrHash)
+	assert.NotNil(t, err)
+	//this should sleep as there is an async call to display current header and block in CommitBlock
+	time.Sleep(time.Second)
+}
+
+func TestShardProcessor_SetSupplyCheckerNilShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		initStore(),
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	assert.Equal(t, process.ErrNilSupplyChecker, sp.SetSupplyChecker(nil))
+}
+
+func createSupplyCheckedShardProcessor(
+	accounts *mock.AccountsStub,
+	store dataRetriever.StorageService,
+	supplyChecker process.SupplyChecker,
+) process.BlockProcessor {
+
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return []byte("header hash")
+	}
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		store,
+		hasher,
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		accounts,
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{
+			AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState) error {
+				return nil
+			},
+		},
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+		},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+	_ = sp.SetSupplyChecker(supplyChecker)
+
+	return sp
+}
+
+func TestShardProcessor_CommitBlockShouldCheckTheSupplyChange(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      rootHash,
+		MiniBlockHeaders: []block.MiniBlockHeader{
+			{Hash: []byte("outgoing"), SenderShardID: 0, ReceiverShardID: 1, Value: big.NewInt(2)},
+			{Hash: []byte("intra"), SenderShardID: 0, ReceiverShardID: 0},
+		},
+		SupplyDelta: big.NewInt(-3),
+		BurntFees:   big.NewInt(1),
+	}
+	commitCalled := false
+	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return &state.StateDiff{}, nil
+		},
+		CommitCalled: func() (i []byte, e error) {
+			commitCalled = true
+			return rootHash, nil
+		},
+		RootHashCalled: func() []byte {
+			return rootHash
+		},
+	}
+	store := initStore()
+	var checkedChange *process.ShardSupplyChange
+	supplyChecker := &mock.SupplyCheckerStub{
+		CheckShardBlockCalled: func(change *process.ShardSupplyChange) error {
+			_, err := store.Get(dataRetriever.BlockHeaderUnit, []byte("header hash"))
+			assert.NotNil(t, err)
+			assert.False(t, commitCalled)
+			checkedChange = change
+			return nil
+		},
+	}
+	sp := createSupplyCheckedShardProcessor(accounts, store, supplyChecker)
+
+	err := sp.CommitBlock(createTestBlockchain(), hdr, block.Body{})
+
+	assert.Nil(t, err)
+	assert.True(t, commitCalled)
+	assert.Equal(t, uint32(0), checkedChange.ShardId)
+	assert.Equal(t, uint64(5), checkedChange.Nonce)
+	assert.Equal(t, big.NewInt(-3), checkedChange.Delta)
+	assert.Equal(t, 1, len(checkedChange.Outgoing))
+	assert.Equal(t, &process.CrossShardValue{ShardId: 1, Value: big.NewInt(2)}, checkedChange.Outgoing["outgoing"])
+	assert.Equal(t, 0, len(checkedChange.Incoming))
+	assert.Equal(t, big.NewInt(1), checkedChange.BurntFees)
+	//this should sleep as there is an async call to display current header and block in CommitBlock
+	time.Sleep(time.Second)
+}
+
+func TestShardProcessor_CommitBlockSupplyViolationShouldNotCommit(t *testing.T) {
+	t.Parallel()
+
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      []byte("root hash"),
+		SupplyDelta:   big.NewInt(1),
+	}
+	commitCalled := false
+	revertCalled := false
+	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return &state.StateDiff{}, nil
+		},
+		CommitCalled: func() (i []byte, e error) {
+			commitCalled = true
+			return nil, nil
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			revertCalled = true
+			return nil
+		},
+	}
+	supplyChecker := &mock.SupplyCheckerStub{
+		CheckShardBlockCalled: func(change *process.ShardSupplyChange) error {
+			return process.ErrSupplyInvariantViolated
+		},
+	}
+	store := initStore()
+	sp := createSupplyCheckedShardProcessor(accounts, store, supplyChecker)
+
+	err := sp.CommitBlock(createTestBlockchain(), hdr, block.Body{})
+
+	assert.Equal(t, process.ErrSupplyInvariantViolated, err)
+	assert.False(t, commitCalled)
+	assert.True(t, revertCalled)
+	_, err = store.Get(dataRetriever.BlockHeaderUnit, []byte("header hash"))
+	assert.NotNil(t, err)
+}
+
+func TestShardProcessor_CommitBlockWithoutSupplyChangeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      []byte("root hash"),
+	}
+	commitCalled := false
+	accounts := &mock.AccountsStub{
+		CommitCalled: func() (i []byte, e error) {
+			commitCalled = true
+			return []byte("root hash"), nil
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+	checkCalled := false
+	supplyChecker := &mock.SupplyCheckerStub{
+		CheckShardBlockCalled: func(change *process.ShardSupplyChange) error {
+			checkCalled = true
+			return nil
+		},
+	}
+	sp := createSupplyCheckedShardProcessor(accounts, initStore(), supplyChecker)
+
+	err := sp.CommitBlock(createTestBlockchain(), hdr, block.Body{})
+
+	assert.Equal(t, process.ErrNilSupplyDelta, err)
+	assert.False(t, checkCalled)
+	assert.False(t, commitCalled)
+}
+
+func TestShardProcessor_ComputeSupplyChangeShouldCountTheValueCrossingTheShard(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	batchHash := []byte("batch")
+	for shardId := uint32(0); shardId < 3; shardId++ {
+		dataPool.Transactions().AddData(batchHash, createBatchTransferForShards(), process.ShardCacherIdentifier(1, shardId))
+	}
+	intraHash := []byte("intra")
+	intraTx := &transaction.Transaction{Nonce: 1, Value: big.NewInt(4), GasPrice: 1, GasLimit: 1, SndAddr: []byte("snd1"), RcvAddr: []byte("rcv1")}
+	dataPool.Transactions().AddData(intraHash, intraTx, process.ShardCacherIdentifier(1, 1))
+	outgoingHash := []byte("outgoing")
+	outgoingTx := &transaction.Transaction{Nonce: 2, Value: big.NewInt(5), GasPrice: 1, GasLimit: 1, SndAddr: []byte("snd1"), RcvAddr: []byte("rcv2")}
+	dataPool.Transactions().AddData(outgoingHash, outgoingTx, process.ShardCacherIdentifier(1, 2))
+	incomingHash := []byte("incoming")
+	incomingTx := &transaction.Transaction{Nonce: 0, Value: big.NewInt(9), GasPrice: 1, GasLimit: 1, SndAddr: []byte("snd0"), RcvAddr: []byte("rcv1")}
+	dataPool.Transactions().AddData(incomingHash, incomingTx, process.ShardCacherIdentifier(0, 1))
+
+	sp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		createBatchTransferShardCoordinator(1),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{
+			ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+				return big.NewInt(2)
+			},
+		},
+		mock.NewAddressMock([]byte("rewards1")),
+		15000,
+		1000000000,
+	)
+
+	body := block.Body{
+		&block.MiniBlock{SenderShardID: 1, ReceiverShardID: 1, TxHashes: [][]byte{batchHash, intraHash}},
+		&block.MiniBlock{SenderShardID: 1, ReceiverShardID: 0, TxHashes: [][]byte{batchHash}},
+		&block.MiniBlock{SenderShardID: 1, ReceiverShardID: 2, TxHashes: [][]byte{batchHash, outgoingHash}},
+		&block.MiniBlock{SenderShardID: 0, ReceiverShardID: 1, TxHashes: [][]byte{incomingHash}},
+	}
+	miniBlockHashes := [][]byte{[]byte("mb0"), []byte("mb1"), []byte("mb2"), []byte("mb3")}
+
+	change, err := sp.ComputeSupplyChange(body, miniBlockHashes)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(1), change.ShardId)
+	assert.Equal(t, map[string]*process.CrossShardValue{
+		"mb1": {ShardId: 0, Value: big.NewInt(1)},
+		"mb2": {ShardId: 2, Value: big.NewInt(8)},
+	}, change.Outgoing)
+	assert.Equal(t, map[string]*process.CrossShardValue{
+		"mb3": {ShardId: 0, Value: big.NewInt(9)},
+	}, change.Incoming)
+	//the fees are credited in this shard so none of them are burnt
+	assert.Equal(t, big.NewInt(0), change.BurntFees)
+}
+
+func TestShardProcessor_CommitBlockShouldSaveLastCommittedHeaderHash(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	hdrHash := []byte("header hash")
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      rootHash,
+	}
+	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return &state.StateDiff{}, nil
+		},
+		CommitCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+		RootHashCalled: func() []byte {
+			return rootHash
+		},
+	}
+	hasher := &mock.HasherStub{}
+	hasher.ComputeCalled = func(s string) []byte {
+		return hdrHash
+	}
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	shardCoordinator.CurrentShard = 2
+	store := initStore()
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		store,
+		hasher,
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		accounts,
+		shardCoordinator,
+		&mock.ForkDetectorMock{
+			AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState) error {
+				return nil
+			},
+		},
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+		},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	err := sp.CommitBlock(createTestBlockchain(), hdr, block.Body{})
+	assert.Nil(t, err)
+
+	lastHash, err := process.GetLastCommittedHeaderHash(store, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, hdrHash, lastHash)
+	_, err = store.Get(dataRetriever.BootstrapUnit, process.PendingCommitKey(2))
+	assert.NotNil(t, err)
+	//this should sleep as there is an async call to display current header and block in CommitBlock
+	time.Sleep(time.Second)
+}
+
+func TestShardProcessor_CommitBlockInterruptedFlushShouldLeaveThePendingCommit(t *testing.T) {
+	t.Parallel()
+
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      []byte("root hash"),
+		SupplyDelta:   big.NewInt(0),
+	}
+	accounts := &mock.AccountsStub{
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+	errFlush := errors.New("flush error")
+	store := initStore()
+	store.AddStorer(dataRetriever.BlockHeaderUnit, &mock.StorerStub{
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
+			return errFlush
+		},
+	})
+	sp := createSupplyCheckedShardProcessor(accounts, store, &mock.SupplyCheckerStub{})
+
+	body := block.Body{&block.MiniBlock{SenderShardID: 0, ReceiverShardID: 0}}
+	err := sp.CommitBlock(createTestBlockchain(), hdr, body)
+	assert.Equal(t, errFlush, err)
+
+	buff, err := store.Get(dataRetriever.BootstrapUnit, process.PendingCommitKey(0))
+	assert.Nil(t, err)
+	pendingCommit := &process.PendingCommit{}
+	_ = (&mock.MarshalizerMock{}).Unmarshal(pendingCommit, buff)
+	assert.Equal(t, []byte("header hash"), pendingCommit.HeaderHash)
+	assert.Equal(t, process.PendingCommitUnit{
+		Unit: dataRetriever.MiniBlockUnit,
+		Keys: [][]byte{[]byte("header hash")},
+	}, pendingCommit.Units[0])
+	assert.Equal(t, dataRetriever.BlockHeaderUnit, pendingCommit.Units[1].Unit)
+
+	_, err = store.Get(dataRetriever.MiniBlockUnit, []byte("header hash"))
+	assert.Nil(t, err)
+	store.AddStorer(dataRetriever.BlockHeaderUnit, generateTestUnit())
+
+	err = process.DiscardPendingCommit(store, &mock.MarshalizerMock{}, 0, nil)
+	assert.Nil(t, err)
+	_, err = store.Get(dataRetriever.MiniBlockUnit, []byte("header hash"))
+	assert.NotNil(t, err)
+	_, err = store.Get(dataRetriever.BootstrapUnit, process.PendingCommitKey(0))
+	assert.NotNil(t, err)
+}
+
+func TestShardProcessor_CommitBlockPendingCommitShouldLeaveOutTheKeysAlreadyStored(t *testing.T) {
+	t.Parallel()
+
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      []byte("root hash"),
+		SupplyDelta:   big.NewInt(0),
+	}
+	accounts := &mock.AccountsStub{
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+	errFlush := errors.New("flush error")
+	store := initStore()
+	store.AddStorer(dataRetriever.BlockHeaderUnit, &mock.StorerStub{
+		HasCalled: func(key []byte) error {
+			return errors.New("key not found")
+		},
+		PutBatchCalled: func(data map[string][]byte) error {
+			return errFlush
+		},
+	})
+	// the miniblock was already written by a previously committed block
+	_ = store.Put(dataRetriever.MiniBlockUnit, []byte("header hash"), []byte("miniblock"))
+	sp := createSupplyCheckedShardProcessor(accounts, store, &mock.SupplyCheckerStub{})
+
+	body := block.Body{&block.MiniBlock{SenderShardID: 0, ReceiverShardID: 0}}
+	err := sp.CommitBlock(createTestBlockchain(), hdr, body)
+	assert.Equal(t, errFlush, err)
+
+	buff, _ := store.Get(dataRetriever.BootstrapUnit, process.PendingCommitKey(0))
+	pendingCommit := &process.PendingCommit{}
+	_ = (&mock.MarshalizerMock{}).Unmarshal(pendingCommit, buff)
+	assert.Equal(t, 1, len(pendingCommit.Units))
+	assert.Equal(t, dataRetriever.BlockHeaderUnit, pendingCommit.Units[0].Unit)
+
+	store.AddStorer(dataRetriever.BlockHeaderUnit, generateTestUnit())
+	err = process.DiscardPendingCommit(store, &mock.MarshalizerMock{}, 0, nil)
+	assert.Nil(t, err)
+	_, err = store.Get(dataRetriever.MiniBlockUnit, []byte("header hash"))
+	assert.Nil(t, err)
+}
+
+func TestShardProcessor_CommitBlockShouldWriteHeaderAfterBodyAndBeforeCommitMarker(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      rootHash,
+	}
+	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return &state.StateDiff{}, nil
+		},
+		CommitCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+		RootHashCalled: func() []byte {
+			return rootHash
+		},
+	}
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	shardCoordinator.CurrentShard = 2
+
+	writtenUnits := make([]dataRetriever.UnitType, 0)
+	store := &mock.ChainStorerMock{
+		PutCalled: func(unitType dataRetriever.UnitType, key []byte, value []byte) error {
+			writtenUnits = append(writtenUnits, unitType)
+			return nil
+		},
+		PutBatchCalled: func(unitType dataRetriever.UnitType, data map[string][]byte) error {
+			writtenUnits = append(writtenUnits, unitType)
+			return nil
+		},
+	}
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		store,
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		accounts,
+		shardCoordinator,
+		&mock.ForkDetectorMock{
+			AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState) error {
+				return nil
+			},
+		},
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+		},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+	_ = sp.SetUint64Converter(mock.NewNonceHashConverterMock())
+
+	body := block.Body{&block.MiniBlock{SenderShardID: 2, ReceiverShardID: 2}}
+	err := sp.CommitBlock(createTestBlockchain(), hdr, body)
+	assert.Nil(t, err)
+
+	expectedUnits := []dataRetriever.UnitType{
+		//the pending commit is written first
+		dataRetriever.BootstrapUnit,
+		dataRetriever.MiniBlockUnit,
+		dataRetriever.BlockHeaderUnit,
+		dataRetriever.ShardHdrNonceHashDataUnitFor(2),
+		dataRetriever.StateDiffUnit,
+		dataRetriever.BootstrapUnit,
+	}
+	assert.Equal(t, expectedUnits, writtenUnits)
+	//this should sleep as there is an async call to display current header and block in CommitBlock
+	time.Sleep(time.Second)
+}
+
+func TestShardProcessor_CommitBlockShouldSaveHeaderInTheStorageOfItsEpoch(t *testing.T) {
+	t.Parallel()
+
+	rootHash := []byte("root hash")
+	hdr := &block.Header{
+		Nonce:         5,
+		Round:         5,
+		Epoch:         1,
+		PubKeysBitmap: []byte("0100101"),
+		PrevHash:      []byte("zzz"),
+		Signature:     []byte("signature"),
+		RootHash:      rootHash,
+	}
+	accounts := &mock.AccountsStub{
+		StateDiffCalled: func() (*state.StateDiff, error) {
+			return &state.StateDiff{}, nil
+		},
+		CommitCalled: func() (i []byte, e error) {
+			return rootHash, nil
+		},
+		RootHashCalled: func() []byte {
+			return rootHash
+		},
+	}
+	createMemoryDb := func(path string) (storage.Persister, error) {
+		return memorydb.New()
+	}
+	hdrUnit, _ := storage.NewEpochUnit(generateTestCache(), "BlockHeaders", 2, false, createMemoryDb)
+	store := initStore()
+	store.AddStorer(dataRetriever.BlockHeaderUnit, hdrUnit)
+
+	sp, _ := blproc.NewShardProcessor(
+		initDataPool(),
+		store,
+		&mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		accounts,
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{
+			AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState) error {
+				return nil
+			},
+		},
+		&mock.BlocksTrackerMock{
+			AddBlockCalled: func(headerHandler data.HeaderHandler) {
+			},
+		},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		15000,
+		1000000000,
+	)
+
+	err := sp.CommitBlock(createTestBlockchain(), hdr, block.Body{})
+	assert.Nil(t, err)
+
+	assert.Equal(t, uint32(1), hdrUnit.Epoch())
+	lastHash, _ := process.GetLastCommittedHeaderHash(store, 0)
+	assert.Nil(t, hdrUnit.Has(lastHash))
+	//this should sleep as there is an async call to display current header and block in CommitBlock
+	time.Sleep(time.Second)
+}
+
+func checkBlockLimits(
+	dataPool dataRetriever.PoolsHolder,
+	maxTxsInBlock uint32,
+	maxGasLimitInBlock uint64,
+	body block.Body,
+) error {
+
+	bp, _ := blproc.NewShardProcessor(
+		dataPool,
+		initStore(),
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{},
+		initAccountsMock(),
+		createBatchTransferShardCoordinator(1),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, miniblockHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards1")),
+		maxTxsInBlock,
+		maxGasLimitInBlock,
+	)
+
+	return bp.CheckBlockLimits(body)
+}
+
+func TestShardProcessor_CheckBlockLimitsShouldCountBatchTransferOnce(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	batchHash := []byte("batch")
+	for shardId := uint32(0); shardId < 3; shardId++ {
+		dataPool.Transactions().AddData(batchHash, createBatchTransferForShards(), process.ShardCacherIdentifier(1, shardId))
+	}
+	otherHash := []byte("other")
+	dataPool.Transactions().AddData(otherHash, &transaction.Transaction{GasLimit: 1}, process.ShardCacherIdentifier(1, 1))
+	body := block.Body{
+		&block.MiniBlock{SenderShardID: 1, ReceiverShardID: 1, TxHashes: [][]byte{otherHash, batchHash}},
+		&block.MiniBlock{SenderShardID: 1, ReceiverShardID: 0, TxHashes: [][]byte{batchHash}},
+		&block.MiniBlock{SenderShardID: 1, ReceiverShardID: 2, TxHashes: [][]byte{batchHash}},
+	}
+
+	assert.Nil(t, checkBlockLimits(dataPool, 2, 1000, body))
+	assert.Equal(t, process.ErrMaxTxsInBlockExceeded, checkBlockLimits(dataPool, 1, 1000, body))
+}
+
+func TestShardProcessor_CheckBlockLimitsShouldSumTheGasOfAllTransactions(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	dataPool.Transactions().AddData([]byte("tx1"), &transaction.Transaction{Nonce: 1, GasLimit: 6}, process.ShardCacherIdentifier(1, 1))
+	dataPool.Transactions().AddData([]byte("tx2"), &transaction.Transaction{Nonce: 2, GasLimit: 5}, process.ShardCacherIdentifier(1, 0))
+	dataPool.Transactions().AddData([]byte("txDstMe"), &transaction.Transaction{Nonce: 3, GasLimit: 100}, process.ShardCacherIdentifier(0, 1))
+	body := block.Body{
+		&block.MiniBlock{SenderShardID: 1, ReceiverShardID: 1, TxHashes: [][]byte{[]byte("tx1")}},
+		&block.MiniBlock{SenderShardID: 1, ReceiverShardID: 0, TxHashes: [][]byte{[]byte("tx2")}},
+		&block.MiniBlock{SenderShardID: 0, ReceiverShardID: 1, TxHashes: [][]byte{[]byte("txDstMe")}},
+	}
+
+	assert.Nil(t, checkBlockLimits(dataPool, 15000, 111, body))
+	//the cross shard transactions with destination in self shard are counted as well
+	assert.Equal(t, process.ErrMaxGasLimitInBlockExceeded, checkBlockLimits(dataPool, 15000, 110, body))
+}
+
+func TestShardProcessor_ProcessBlockExceedingMaxTxsInBlockShouldErr(t *testing.T) {
+	t.Parallel()
+
+	dataPool := mock.NewPoolsHolderFake()
+	cacherId := process.ShardCacherIdentifier(0, 0)
+	dataPool.Transactions().AddData([]byte("tx1"), &transaction.Transaction{Nonce: 1}, cacherId)
+	dataPool.Transactions().AddData([]byte("tx2"), &transaction.Transaction{Nonce: 2}, cacherId)
+	processTxCalled := false
+	blkc := &blockchain.BlockChain{
+		CurrentBlockHeader: &block.Header{
+			Nonce: 0,
+		},
+	}
+	hdr := block.Header{
+		Nonce:         1,
+		PrevHash:      []byte(""),
+		Signature:     []byte("signature"),
+		PubKeysBitmap: []byte("00110"),
+		ShardId:       0,
+		RootHash:      []byte("rootHash"),
+	}
+	body := block.Body{
+		&block.MiniBlock{
+			ReceiverShardID: 0,
+			SenderShardID:   0,
+			TxHashes:        [][]byte{[]byte("tx1"), []byte("tx2")},
+		},
+	}
+	sp, _ := blproc.NewShardProcessor(
+		dataPool,
+		&mock.ChainStorerMock{},
+		&mock.HasherStub{},
+		&mock.MarshalizerMock{},
+		&mock.TxProcessorMock{
+			ProcessTransactionCalled: func(transaction *transaction.Transaction, round int32) error {
+				processTxCalled = true
+				return nil
+			},
+		},
+		&mock.AccountsStub{
+			JournalLenCalled: func() int {
+				return 0
+			},
+		},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.ForkDetectorMock{},
+		&mock.BlocksTrackerMock{},
+		func(destShardID uint32, txHashes [][]byte) {},
+		func(destShardID uint32, txHash []byte) {},
+		&mock.FeeHandlerStub{},
+		mock.NewAddressMock([]byte("rewards")),
+		1,
+		1000000000,
+	)
+
+	err := sp.ProcessBlock(blkc, &hdr, body, haveTime)
+
+	assert.Equal(t, process.ErrMaxTxsInBlockExceeded, err)
+	assert.False(t, processTxCalled)
+}
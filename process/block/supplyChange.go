@@ -0,0 +1,70 @@
+package block
+
+import (
+	"math/big"
+
+	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/process"
+)
+
+// supplyChangeFromHeader returns the supply change carried by the given shard header, the value of its cross shard
+// miniblocks being sent or received depending on which side of the miniblock the shard is. It returns nil if the
+// header carries no supply change
+func supplyChangeFromHeader(header *block.Header) *process.ShardSupplyChange {
+	if header.SupplyDelta == nil {
+		return nil
+	}
+
+	change := &process.ShardSupplyChange{
+		ShardId:   header.ShardId,
+		Nonce:     header.Nonce,
+		Delta:     header.SupplyDelta,
+		Outgoing:  make(map[string]*process.CrossShardValue),
+		Incoming:  make(map[string]*process.CrossShardValue),
+		BurntFees: header.BurntFees,
+	}
+
+	for _, miniBlockHeader := range header.MiniBlockHeaders {
+		if miniBlockHeader.Value == nil || miniBlockHeader.SenderShardID == miniBlockHeader.ReceiverShardID {
+			continue
+		}
+
+		switch header.ShardId {
+		case miniBlockHeader.SenderShardID:
+			change.Outgoing[string(miniBlockHeader.Hash)] = &process.CrossShardValue{
+				ShardId: miniBlockHeader.ReceiverShardID,
+				Value:   miniBlockHeader.Value,
+			}
+		case miniBlockHeader.ReceiverShardID:
+			change.Incoming[string(miniBlockHeader.Hash)] = &process.CrossShardValue{
+				ShardId: miniBlockHeader.SenderShardID,
+				Value:   miniBlockHeader.Value,
+			}
+		}
+	}
+
+	return change
+}
+
+// crossShardValue returns the value carried by the cross shard miniblock with the given hash, or nil if the supply
+// change holds no such miniblock
+func crossShardValue(change *process.ShardSupplyChange, miniBlockHash []byte) *big.Int {
+	value, ok := change.Outgoing[string(miniBlockHash)]
+	if !ok {
+		value, ok = change.Incoming[string(miniBlockHash)]
+	}
+	if !ok || value == nil {
+		return nil
+	}
+
+	return value.Value
+}
+
+// isSameValue returns true if both values are nil or both are set and equal
+func isSameValue(a *big.Int, b *big.Int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	return a.Cmp(b) == 0
+}
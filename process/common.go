@@ -1,9 +1,16 @@
 package process
 
 import (
+	"bytes"
+	"fmt"
+	"sort"
+
 	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/transaction"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/sharding"
 	"github.com/numbatx/gn-numbat/storage"
 )
 
@@ -88,3 +95,200 @@ func GetShardHeaderFromStorage(
 
 	return header, nil
 }
+
+// LastCommittedHeaderKey returns the key, in the bootstrap storage unit, of the hash of the last header committed
+// by the given shard
+func LastCommittedHeaderKey(shardId uint32) []byte {
+	return []byte(fmt.Sprintf("lastCommittedHeader_%d", shardId))
+}
+
+// SaveLastCommittedHeaderHash persists the hash of the last header committed by the given shard, so that a restarted
+// node resumes its chain from it. An empty hash removes the marker, the node then starting from genesis
+func SaveLastCommittedHeaderHash(storageService dataRetriever.StorageService, shardId uint32, headerHash []byte) error {
+	if storageService == nil {
+		return ErrNilStorage
+	}
+
+	if len(headerHash) > 0 {
+		return storageService.Put(dataRetriever.BootstrapUnit, LastCommittedHeaderKey(shardId), headerHash)
+	}
+
+	bootstrapStore := storageService.GetStorer(dataRetriever.BootstrapUnit)
+	if bootstrapStore == nil {
+		return ErrNilBootstrapStorage
+	}
+
+	return bootstrapStore.Remove(LastCommittedHeaderKey(shardId))
+}
+
+// GetLastCommittedHeaderHash returns the hash of the last header committed by the given shard, as persisted before
+// the node was stopped
+func GetLastCommittedHeaderHash(storageService dataRetriever.StorageService, shardId uint32) ([]byte, error) {
+	if storageService == nil {
+		return nil, ErrNilStorage
+	}
+
+	return storageService.Get(dataRetriever.BootstrapUnit, LastCommittedHeaderKey(shardId))
+}
+
+// PendingCommitUnit holds the keys written in a storage unit by a block commit
+type PendingCommitUnit struct {
+	Unit dataRetriever.UnitType
+	Keys [][]byte
+}
+
+// PendingCommit lists the keys written in each storage unit by the commit of the block with the given header hash.
+// It is persisted before the block data is written and removed once the block is marked committed, so that the data
+// of a block whose commit was interrupted can be removed when the node restarts
+type PendingCommit struct {
+	HeaderHash []byte
+	Units      []PendingCommitUnit
+}
+
+// PendingCommitKey returns the key, in the bootstrap storage unit, of the pending commit of the given shard
+func PendingCommitKey(shardId uint32) []byte {
+	return []byte(fmt.Sprintf("pendingCommit_%d", shardId))
+}
+
+// SavePendingCommit persists the keys about to be written by the commit of a block of the given shard
+func SavePendingCommit(
+	storageService dataRetriever.StorageService,
+	marshalizer marshal.Marshalizer,
+	shardId uint32,
+	pendingCommit *PendingCommit,
+) error {
+	if storageService == nil {
+		return ErrNilStorage
+	}
+	if marshalizer == nil {
+		return ErrNilMarshalizer
+	}
+	if pendingCommit == nil {
+		return ErrNilPendingCommit
+	}
+
+	buff, err := marshalizer.Marshal(pendingCommit)
+	if err != nil {
+		return err
+	}
+
+	return storageService.Put(dataRetriever.BootstrapUnit, PendingCommitKey(shardId), buff)
+}
+
+// RemovePendingCommit removes the pending commit of the given shard, once its block is marked committed
+func RemovePendingCommit(storageService dataRetriever.StorageService, shardId uint32) error {
+	if storageService == nil {
+		return ErrNilStorage
+	}
+
+	bootstrapStore := storageService.GetStorer(dataRetriever.BootstrapUnit)
+	if bootstrapStore == nil {
+		return ErrNilBootstrapStorage
+	}
+
+	return bootstrapStore.Remove(PendingCommitKey(shardId))
+}
+
+// DiscardPendingCommit removes, from every storage unit it was written in, the data of the block whose commit was
+// interrupted when the node was stopped. Nothing is removed if the pending commit is the one of the last committed
+// block, the node having been stopped after the block was marked committed
+func DiscardPendingCommit(
+	storageService dataRetriever.StorageService,
+	marshalizer marshal.Marshalizer,
+	shardId uint32,
+	lastCommittedHeaderHash []byte,
+) error {
+	if storageService == nil {
+		return ErrNilStorage
+	}
+	if marshalizer == nil {
+		return ErrNilMarshalizer
+	}
+
+	buff, err := storageService.Get(dataRetriever.BootstrapUnit, PendingCommitKey(shardId))
+	if err != nil {
+		// no commit was interrupted
+		return nil
+	}
+
+	pendingCommit := &PendingCommit{}
+	err = marshalizer.Unmarshal(pendingCommit, buff)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(pendingCommit.HeaderHash, lastCommittedHeaderHash) {
+		for _, unit := range pendingCommit.Units {
+			storer := storageService.GetStorer(unit.Unit)
+			if storer == nil {
+				return ErrNilStorage
+			}
+
+			for _, key := range unit.Keys {
+				err = storer.Remove(key)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return RemovePendingCommit(storageService, shardId)
+}
+
+// NextEpoch returns the epoch of a block built on top of a block of the given epoch, given the epoch its round belongs
+// to. The epoch moves forward by at most one epoch per block, so that an epoch without blocks is not skipped
+func NextEpoch(prevEpoch uint32, roundEpoch uint32) uint32 {
+	if roundEpoch > prevEpoch {
+		return prevEpoch + 1
+	}
+
+	return prevEpoch
+}
+
+// CheckTxValidityWindow checks that the given round is inside the window of rounds in which the transaction is valid
+func CheckTxValidityWindow(tx *transaction.Transaction, round uint32) error {
+	if tx == nil {
+		return ErrNilTransaction
+	}
+	if round < tx.ValidFromRound {
+		return ErrTxNotYetValid
+	}
+	if IsTxExpired(tx, round) {
+		return ErrTxExpired
+	}
+
+	return nil
+}
+
+// IsTxExpired returns true if the window of rounds in which the transaction is valid ended before the given round
+func IsTxExpired(tx *transaction.Transaction, round uint32) bool {
+	return tx.ValidUntilRound != 0 && round > tx.ValidUntilRound
+}
+
+// BatchTransferShards returns, in ascending order, the ids of the shards holding at least one of the receivers of
+// the given batch transfer transaction
+func BatchTransferShards(tx *transaction.Transaction, shardCoordinator sharding.Coordinator) []uint32 {
+	shards := make([]uint32, 0)
+	if tx == nil || shardCoordinator == nil {
+		return shards
+	}
+
+	foundShards := make(map[uint32]struct{})
+	for _, transfer := range tx.Transfers {
+		shardId := shardCoordinator.ComputeId(state.NewAddress(transfer.RcvAddr))
+		_, found := foundShards[shardId]
+		if found {
+			continue
+		}
+
+		foundShards[shardId] = struct{}{}
+		shards = append(shards, shardId)
+	}
+
+	sort.Slice(shards, func(i, j int) bool {
+		return shards[i] < shards[j]
+	})
+
+	return shards
+}
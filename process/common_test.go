@@ -2,9 +2,12 @@ package process_test
 
 import (
 	"bytes"
+	"math/big"
 	"testing"
 
 	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/transaction"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/process"
 	"github.com/numbatx/gn-numbat/process/mock"
@@ -235,3 +238,244 @@ func TestGetShardHeaderFromStorageShouldWork(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, hdr, header)
 }
+
+func TestSaveLastCommittedHeaderHashShouldErrNilStorage(t *testing.T) {
+	err := process.SaveLastCommittedHeaderHash(nil, 0, []byte("X"))
+
+	assert.Equal(t, process.ErrNilStorage, err)
+}
+
+func TestSaveLastCommittedHeaderHashShouldPutTheHashInBootstrapStorage(t *testing.T) {
+	hash := []byte("X")
+
+	putCalled := false
+	storageService := &mock.ChainStorerMock{
+		PutCalled: func(unitType dataRetriever.UnitType, key []byte, value []byte) error {
+			putCalled = true
+			assert.Equal(t, dataRetriever.BootstrapUnit, unitType)
+			assert.Equal(t, process.LastCommittedHeaderKey(1), key)
+			assert.Equal(t, hash, value)
+			return nil
+		},
+	}
+
+	err := process.SaveLastCommittedHeaderHash(storageService, 1, hash)
+
+	assert.Nil(t, err)
+	assert.True(t, putCalled)
+}
+
+func TestSaveLastCommittedHeaderHashEmptyHashShouldErrNilBootstrapStorage(t *testing.T) {
+	storageService := &mock.ChainStorerMock{
+		GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
+			return nil
+		},
+	}
+
+	err := process.SaveLastCommittedHeaderHash(storageService, 0, nil)
+
+	assert.Equal(t, process.ErrNilBootstrapStorage, err)
+}
+
+func TestSaveLastCommittedHeaderHashEmptyHashShouldRemoveTheMarker(t *testing.T) {
+	removeCalled := false
+	storageService := &mock.ChainStorerMock{
+		GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
+			assert.Equal(t, dataRetriever.BootstrapUnit, unitType)
+			return &mock.StorerStub{
+				RemoveCalled: func(key []byte) error {
+					removeCalled = true
+					assert.Equal(t, process.LastCommittedHeaderKey(0), key)
+					return nil
+				},
+			}
+		},
+	}
+
+	err := process.SaveLastCommittedHeaderHash(storageService, 0, nil)
+
+	assert.Nil(t, err)
+	assert.True(t, removeCalled)
+}
+
+func TestGetLastCommittedHeaderHashShouldErrNilStorage(t *testing.T) {
+	hash, err := process.GetLastCommittedHeaderHash(nil, 0)
+
+	assert.Nil(t, hash)
+	assert.Equal(t, process.ErrNilStorage, err)
+}
+
+func TestGetLastCommittedHeaderHashShouldWork(t *testing.T) {
+	hash := []byte("X")
+
+	storageService := &mock.ChainStorerMock{
+		GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+			if unitType == dataRetriever.BootstrapUnit && bytes.Equal(key, process.LastCommittedHeaderKey(2)) {
+				return hash, nil
+			}
+			return nil, errors.New("key not found")
+		},
+	}
+
+	lastHash, err := process.GetLastCommittedHeaderHash(storageService, 2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, hash, lastHash)
+}
+
+func TestLastCommittedHeaderKeyShouldDifferPerShard(t *testing.T) {
+	assert.NotEqual(t, process.LastCommittedHeaderKey(0), process.LastCommittedHeaderKey(1))
+}
+
+func TestSavePendingCommitNilPendingCommitShouldErr(t *testing.T) {
+	err := process.SavePendingCommit(&mock.ChainStorerMock{}, &mock.MarshalizerMock{}, 0, nil)
+
+	assert.Equal(t, process.ErrNilPendingCommit, err)
+}
+
+func TestDiscardPendingCommitNoPendingCommitShouldNotRemove(t *testing.T) {
+	storageService := &mock.ChainStorerMock{
+		GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+			return nil, errors.New("key not found")
+		},
+		GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
+			assert.Fail(t, "nothing should be removed")
+			return nil
+		},
+	}
+
+	err := process.DiscardPendingCommit(storageService, &mock.MarshalizerMock{}, 0, []byte("X"))
+
+	assert.Nil(t, err)
+}
+
+func createPendingCommitStorage(
+	t *testing.T,
+	pendingCommit *process.PendingCommit,
+	removedKeys map[dataRetriever.UnitType][][]byte,
+) dataRetriever.StorageService {
+
+	buff, _ := (&mock.MarshalizerMock{}).Marshal(pendingCommit)
+
+	return &mock.ChainStorerMock{
+		GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+			assert.Equal(t, dataRetriever.BootstrapUnit, unitType)
+			assert.Equal(t, process.PendingCommitKey(1), key)
+			return buff, nil
+		},
+		GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
+			return &mock.StorerStub{
+				RemoveCalled: func(key []byte) error {
+					removedKeys[unitType] = append(removedKeys[unitType], key)
+					return nil
+				},
+			}
+		},
+	}
+}
+
+func TestDiscardPendingCommitShouldRemoveTheKeysOfTheInterruptedCommit(t *testing.T) {
+	pendingCommit := &process.PendingCommit{
+		HeaderHash: []byte("Y"),
+		Units: []process.PendingCommitUnit{
+			{Unit: dataRetriever.MiniBlockUnit, Keys: [][]byte{[]byte("mb1"), []byte("mb2")}},
+			{Unit: dataRetriever.BlockHeaderUnit, Keys: [][]byte{[]byte("Y")}},
+		},
+	}
+	removedKeys := make(map[dataRetriever.UnitType][][]byte)
+	storageService := createPendingCommitStorage(t, pendingCommit, removedKeys)
+
+	err := process.DiscardPendingCommit(storageService, &mock.MarshalizerMock{}, 1, []byte("X"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[dataRetriever.UnitType][][]byte{
+		dataRetriever.MiniBlockUnit:   {[]byte("mb1"), []byte("mb2")},
+		dataRetriever.BlockHeaderUnit: {[]byte("Y")},
+		dataRetriever.BootstrapUnit:   {process.PendingCommitKey(1)},
+	}, removedKeys)
+}
+
+func TestDiscardPendingCommitOfTheLastCommittedBlockShouldOnlyRemoveThePendingCommit(t *testing.T) {
+	pendingCommit := &process.PendingCommit{
+		HeaderHash: []byte("X"),
+		Units: []process.PendingCommitUnit{
+			{Unit: dataRetriever.BlockHeaderUnit, Keys: [][]byte{[]byte("X")}},
+		},
+	}
+	removedKeys := make(map[dataRetriever.UnitType][][]byte)
+	storageService := createPendingCommitStorage(t, pendingCommit, removedKeys)
+
+	err := process.DiscardPendingCommit(storageService, &mock.MarshalizerMock{}, 1, []byte("X"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[dataRetriever.UnitType][][]byte{
+		dataRetriever.BootstrapUnit: {process.PendingCommitKey(1)},
+	}, removedKeys)
+}
+
+func TestNextEpochShouldMoveForwardByAtMostOne(t *testing.T) {
+	assert.Equal(t, uint32(2), process.NextEpoch(2, 2))
+	assert.Equal(t, uint32(3), process.NextEpoch(2, 3))
+	assert.Equal(t, uint32(3), process.NextEpoch(2, 1000))
+	assert.Equal(t, uint32(2), process.NextEpoch(2, 1))
+}
+
+func TestCheckTxValidityWindowNilTxShouldErr(t *testing.T) {
+	err := process.CheckTxValidityWindow(nil, 10)
+
+	assert.Equal(t, process.ErrNilTransaction, err)
+}
+
+func TestCheckTxValidityWindowUnboundedWindowShouldWork(t *testing.T) {
+	err := process.CheckTxValidityWindow(&transaction.Transaction{}, 10)
+
+	assert.Nil(t, err)
+}
+
+func TestCheckTxValidityWindowBeforeWindowShouldErr(t *testing.T) {
+	tx := &transaction.Transaction{ValidFromRound: 11, ValidUntilRound: 20}
+
+	err := process.CheckTxValidityWindow(tx, 10)
+
+	assert.Equal(t, process.ErrTxNotYetValid, err)
+}
+
+func TestCheckTxValidityWindowAfterWindowShouldErr(t *testing.T) {
+	tx := &transaction.Transaction{ValidFromRound: 1, ValidUntilRound: 9}
+
+	err := process.CheckTxValidityWindow(tx, 10)
+
+	assert.Equal(t, process.ErrTxExpired, err)
+}
+
+func TestCheckTxValidityWindowInsideWindowShouldWork(t *testing.T) {
+	tx := &transaction.Transaction{ValidFromRound: 10, ValidUntilRound: 10}
+
+	err := process.CheckTxValidityWindow(tx, 10)
+
+	assert.Nil(t, err)
+}
+
+func TestBatchTransferShardsNilTxShouldReturnEmpty(t *testing.T) {
+	shards := process.BatchTransferShards(nil, mock.NewMultiShardsCoordinatorMock(2))
+
+	assert.Equal(t, 0, len(shards))
+}
+
+func TestBatchTransferShardsShouldReturnSortedDistinctShards(t *testing.T) {
+	shardCoordinator := mock.NewMultiShardsCoordinatorMock(3)
+	shardCoordinator.ComputeIdCalled = func(address state.AddressContainer) uint32 {
+		return uint32(address.Bytes()[0] - '0')
+	}
+	tx := &transaction.Transaction{
+		Transfers: []transaction.Transfer{
+			{RcvAddr: []byte("2a"), Value: big.NewInt(1)},
+			{RcvAddr: []byte("0a"), Value: big.NewInt(1)},
+			{RcvAddr: []byte("2b"), Value: big.NewInt(1)},
+		},
+	}
+
+	shards := process.BatchTransferShards(tx, shardCoordinator)
+
+	assert.Equal(t, []uint32{0, 2}, shards)
+}
@@ -11,3 +11,6 @@ const (
 	// BHProposed defines ID of a proposed block header
 	BHProposed
 )
+
+// MaxTransfersInBatch defines the maximum number of transfers a batch transfer transaction can hold
+const MaxTransfersInBatch = 1000
@@ -0,0 +1,66 @@
+package economics
+
+import (
+	"math/big"
+
+	"github.com/numbatx/gn-numbat/data/transaction"
+	"github.com/numbatx/gn-numbat/process"
+)
+
+// feeHandler checks the gas settings of transactions and computes the fees they have to pay
+type feeHandler struct {
+	minGasPrice    uint64
+	minGasLimit    uint64
+	gasPerDataByte uint64
+}
+
+// NewFeeHandler creates a new fee handler. The gas needed by a transaction is computed as minGasLimit plus
+// gasPerDataByte for every byte of its data field
+func NewFeeHandler(minGasPrice uint64, minGasLimit uint64, gasPerDataByte uint64) *feeHandler {
+	return &feeHandler{
+		minGasPrice:    minGasPrice,
+		minGasLimit:    minGasLimit,
+		gasPerDataByte: gasPerDataByte,
+	}
+}
+
+// MinGasPrice returns the minimum gas price accepted for a transaction
+func (fh *feeHandler) MinGasPrice() uint64 {
+	return fh.minGasPrice
+}
+
+// GasUsed returns the amount of gas consumed by the provided transaction. A batch transfer consumes minGasLimit for
+// each of its transfers, as it does the job of that many simple transfers
+func (fh *feeHandler) GasUsed(tx *transaction.Transaction) uint64 {
+	numTransfers := uint64(1)
+	if tx.IsBatchTransfer() {
+		numTransfers = uint64(len(tx.Transfers))
+	}
+
+	return fh.minGasLimit*numTransfers + fh.gasPerDataByte*uint64(len(tx.Data))
+}
+
+// CheckTxGas verifies that the gas price and gas limit of the provided transaction are high enough
+func (fh *feeHandler) CheckTxGas(tx *transaction.Transaction) error {
+	if tx == nil {
+		return process.ErrNilTransaction
+	}
+	if tx.GasPrice < fh.minGasPrice {
+		return process.ErrInsufficientGasPrice
+	}
+	if tx.GasLimit < fh.GasUsed(tx) {
+		return process.ErrInsufficientGasLimit
+	}
+
+	return nil
+}
+
+// ComputeFee returns the fee paid by the provided transaction, that is GasPrice*gasUsed
+func (fh *feeHandler) ComputeFee(tx *transaction.Transaction) *big.Int {
+	if tx == nil {
+		return big.NewInt(0)
+	}
+
+	fee := big.NewInt(0).SetUint64(tx.GasPrice)
+	return fee.Mul(fee, big.NewInt(0).SetUint64(fh.GasUsed(tx)))
+}
@@ -0,0 +1,84 @@
+package economics_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/transaction"
+	"github.com/numbatx/gn-numbat/process"
+	"github.com/numbatx/gn-numbat/process/economics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeHandler_GasUsedShouldAddDataCost(t *testing.T) {
+	t.Parallel()
+
+	fh := economics.NewFeeHandler(10, 100, 2)
+
+	assert.Equal(t, uint64(100), fh.GasUsed(&transaction.Transaction{}))
+	assert.Equal(t, uint64(106), fh.GasUsed(&transaction.Transaction{Data: []byte("abc")}))
+}
+
+func TestFeeHandler_GasUsedBatchTransferShouldChargeEveryTransfer(t *testing.T) {
+	t.Parallel()
+
+	fh := economics.NewFeeHandler(10, 100, 2)
+	tx := &transaction.Transaction{
+		Data:      []byte("abc"),
+		Transfers: make([]transaction.Transfer, 3),
+	}
+
+	assert.Equal(t, uint64(306), fh.GasUsed(tx))
+}
+
+func TestFeeHandler_CheckTxGasNilTxShouldErr(t *testing.T) {
+	t.Parallel()
+
+	fh := economics.NewFeeHandler(10, 100, 2)
+
+	assert.Equal(t, process.ErrNilTransaction, fh.CheckTxGas(nil))
+}
+
+func TestFeeHandler_CheckTxGasLowGasPriceShouldErr(t *testing.T) {
+	t.Parallel()
+
+	fh := economics.NewFeeHandler(10, 100, 2)
+	err := fh.CheckTxGas(&transaction.Transaction{GasPrice: 9, GasLimit: 100})
+
+	assert.Equal(t, process.ErrInsufficientGasPrice, err)
+}
+
+func TestFeeHandler_CheckTxGasLowGasLimitShouldErr(t *testing.T) {
+	t.Parallel()
+
+	fh := economics.NewFeeHandler(10, 100, 2)
+	err := fh.CheckTxGas(&transaction.Transaction{GasPrice: 10, GasLimit: 105, Data: []byte("abc")})
+
+	assert.Equal(t, process.ErrInsufficientGasLimit, err)
+}
+
+func TestFeeHandler_CheckTxGasOkValsShouldWork(t *testing.T) {
+	t.Parallel()
+
+	fh := economics.NewFeeHandler(10, 100, 2)
+	err := fh.CheckTxGas(&transaction.Transaction{GasPrice: 10, GasLimit: 106, Data: []byte("abc")})
+
+	assert.Nil(t, err)
+}
+
+func TestFeeHandler_ComputeFeeShouldUseGasUsedNotGasLimit(t *testing.T) {
+	t.Parallel()
+
+	fh := economics.NewFeeHandler(10, 100, 2)
+	fee := fh.ComputeFee(&transaction.Transaction{GasPrice: 20, GasLimit: 1000, Data: []byte("abc")})
+
+	assert.Equal(t, big.NewInt(20*106), fee)
+}
+
+func TestFeeHandler_ComputeFeeNilTxShouldReturnZero(t *testing.T) {
+	t.Parallel()
+
+	fh := economics.NewFeeHandler(10, 100, 2)
+
+	assert.Equal(t, big.NewInt(0), fh.ComputeFee(nil))
+}
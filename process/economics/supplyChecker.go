@@ -0,0 +1,223 @@
+package economics
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/numbatx/gn-numbat/core/logger"
+	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/process"
+)
+
+var log = logger.DefaultLogger()
+
+// crossShardTransfer holds the value a cross shard miniblock took out of its sender shard and brought into its
+// receiver shard, as reported by the blocks of the two shards
+type crossShardTransfer struct {
+	sent     *big.Int
+	received *big.Int
+}
+
+// supplyChecker verifies that the blocks of the tracked shards conserve the supply. The balances of a shard may
+// only change by the value received from other shards, minus the value sent to other shards and the burnt fees, the
+// transfers inside the shard netting to zero. The value carried by every cross shard miniblock between two tracked
+// shards is matched once the metachain notarizes its processing by the receiver shard, and the supply of the tracked
+// shards is then compared to the genesis supply minus the burnt fees and the value still in flight
+type supplyChecker struct {
+	mut             sync.Mutex
+	genesisSupply   *big.Int
+	supply          *big.Int
+	burntFees       *big.Int
+	sent            *big.Int
+	received        *big.Int
+	trackedShards   map[uint32]struct{}
+	transfers       map[string]*crossShardTransfer
+	haltOnViolation bool
+}
+
+// NewSupplyChecker creates a new supply checker for the shards whose blocks it is fed with. The genesis supply is the
+// sum of the genesis balances of the tracked shards. A violation of the invariant is always logged and, if
+// haltOnViolation is set, it is also returned so the block is not committed
+func NewSupplyChecker(genesisSupply *big.Int, trackedShards []uint32, haltOnViolation bool) (*supplyChecker, error) {
+	if genesisSupply == nil {
+		return nil, process.ErrNilGenesisSupply
+	}
+
+	sc := &supplyChecker{
+		genesisSupply:   big.NewInt(0).Set(genesisSupply),
+		supply:          big.NewInt(0).Set(genesisSupply),
+		burntFees:       big.NewInt(0),
+		sent:            big.NewInt(0),
+		received:        big.NewInt(0),
+		trackedShards:   make(map[uint32]struct{}),
+		transfers:       make(map[string]*crossShardTransfer),
+		haltOnViolation: haltOnViolation,
+	}
+
+	for _, shardId := range trackedShards {
+		sc.trackedShards[shardId] = struct{}{}
+	}
+
+	return sc, nil
+}
+
+// CheckShardBlock verifies that the balances change caused by a shard block is explained by its cross shard
+// transfers and burnt fees. The change is recorded unless the violation halts the processing
+func (sc *supplyChecker) CheckShardBlock(change *process.ShardSupplyChange) error {
+	if change == nil || change.Delta == nil {
+		return process.ErrNilSupplyChange
+	}
+
+	sc.mut.Lock()
+	defer sc.mut.Unlock()
+
+	sent := sumCrossShardValues(change.Outgoing)
+	received := sumCrossShardValues(change.Incoming)
+	burntFees := big.NewInt(0)
+	if change.BurntFees != nil {
+		burntFees.Set(change.BurntFees)
+	}
+
+	expectedDelta := big.NewInt(0).Sub(received, sent)
+	expectedDelta.Sub(expectedDelta, burntFees)
+	if change.Delta.Cmp(expectedDelta) != 0 {
+		err := sc.violation(fmt.Sprintf("block with nonce %d of shard %d changed the supply by %s instead of %s",
+			change.Nonce, change.ShardId, change.Delta.String(), expectedDelta.String()))
+		if err != nil {
+			return err
+		}
+	}
+
+	sc.supply.Add(sc.supply, change.Delta)
+	sc.burntFees.Add(sc.burntFees, burntFees)
+	sc.sent.Add(sc.sent, sent)
+	sc.received.Add(sc.received, received)
+
+	for hash, value := range change.Outgoing {
+		if value != nil && sc.isTracked(value.ShardId) {
+			sc.transfer(hash).sent = value.Value
+		}
+	}
+	for hash, value := range change.Incoming {
+		if value != nil && sc.isTracked(value.ShardId) {
+			sc.transfer(hash).received = value.Value
+		}
+	}
+
+	return nil
+}
+
+// CheckMetaBlock verifies that every cross shard miniblock whose processing by the receiver shard is notarized by the
+// given metablock brought into the receiver shard the value it took out of the sender shard, and that the supply of
+// the tracked shards is still the genesis supply minus the burnt fees and the value in flight between shards
+func (sc *supplyChecker) CheckMetaBlock(header *block.MetaBlock) error {
+	if header == nil {
+		return process.ErrNilMetaBlockHeader
+	}
+
+	sc.mut.Lock()
+	defer sc.mut.Unlock()
+
+	completedTransfers := make([]string, 0)
+	for _, shardData := range header.ShardInfo {
+		for _, miniBlockHeader := range shardData.ShardMiniBlockHeaders {
+			isIncoming := miniBlockHeader.SenderShardId != miniBlockHeader.ReceiverShardId &&
+				miniBlockHeader.ReceiverShardId == shardData.ShardId
+			if !isIncoming {
+				continue
+			}
+
+			transfer, ok := sc.transfers[string(miniBlockHeader.Hash)]
+			if !ok {
+				continue
+			}
+			completedTransfers = append(completedTransfers, string(miniBlockHeader.Hash))
+
+			if transfer.sent == nil || transfer.received == nil || transfer.sent.Cmp(transfer.received) == 0 {
+				continue
+			}
+
+			err := sc.violation(fmt.Sprintf("miniblock %s took %s out of shard %d but brought %s into shard %d",
+				hex.EncodeToString(miniBlockHeader.Hash),
+				transfer.sent.String(),
+				miniBlockHeader.SenderShardId,
+				transfer.received.String(),
+				miniBlockHeader.ReceiverShardId))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	expectedSupply := big.NewInt(0).Sub(sc.genesisSupply, sc.burntFees)
+	expectedSupply.Sub(expectedSupply, sc.sent)
+	expectedSupply.Add(expectedSupply, sc.received)
+	if sc.supply.Cmp(expectedSupply) != 0 {
+		err := sc.violation(fmt.Sprintf("supply is %s at metablock with nonce %d instead of %s",
+			sc.supply.String(), header.Nonce, expectedSupply.String()))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, hash := range completedTransfers {
+		delete(sc.transfers, hash)
+	}
+
+	return nil
+}
+
+// Supply returns the supply held by the tracked shards, as resulted from the checked blocks
+func (sc *supplyChecker) Supply() *big.Int {
+	sc.mut.Lock()
+	defer sc.mut.Unlock()
+
+	return big.NewInt(0).Set(sc.supply)
+}
+
+// BurntFees returns the fees burnt by the checked blocks
+func (sc *supplyChecker) BurntFees() *big.Int {
+	sc.mut.Lock()
+	defer sc.mut.Unlock()
+
+	return big.NewInt(0).Set(sc.burntFees)
+}
+
+// isTracked returns true if the blocks of the given shard are checked. Only the miniblocks exchanged between two
+// tracked shards are remembered until matched, the others could never be
+func (sc *supplyChecker) isTracked(shardId uint32) bool {
+	_, ok := sc.trackedShards[shardId]
+	return ok
+}
+
+func (sc *supplyChecker) transfer(hash string) *crossShardTransfer {
+	transfer, ok := sc.transfers[hash]
+	if !ok {
+		transfer = &crossShardTransfer{}
+		sc.transfers[hash] = transfer
+	}
+
+	return transfer
+}
+
+func (sc *supplyChecker) violation(message string) error {
+	log.Error(process.ErrSupplyInvariantViolated.Error() + ": " + message)
+	if sc.haltOnViolation {
+		return process.ErrSupplyInvariantViolated
+	}
+
+	return nil
+}
+
+func sumCrossShardValues(values map[string]*process.CrossShardValue) *big.Int {
+	sum := big.NewInt(0)
+	for _, value := range values {
+		if value != nil && value.Value != nil {
+			sum.Add(sum, value.Value)
+		}
+	}
+
+	return sum
+}
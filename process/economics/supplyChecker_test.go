@@ -0,0 +1,159 @@
+package economics_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/process"
+	"github.com/numbatx/gn-numbat/process/economics"
+	"github.com/stretchr/testify/assert"
+)
+
+func createCrossShardChange(
+	shardId uint32,
+	delta int64,
+	outgoing map[string]*process.CrossShardValue,
+	incoming map[string]*process.CrossShardValue,
+	burntFees int64,
+) *process.ShardSupplyChange {
+
+	return &process.ShardSupplyChange{
+		ShardId:   shardId,
+		Nonce:     1,
+		Delta:     big.NewInt(delta),
+		Outgoing:  outgoing,
+		Incoming:  incoming,
+		BurntFees: big.NewInt(burntFees),
+	}
+}
+
+func createNotarizingMetaBlock(miniBlockHash string, senderShardId uint32, receiverShardId uint32) *block.MetaBlock {
+	return &block.MetaBlock{
+		Nonce: 1,
+		ShardInfo: []block.ShardData{
+			{
+				ShardId: receiverShardId,
+				ShardMiniBlockHeaders: []block.ShardMiniBlockHeader{
+					{Hash: []byte(miniBlockHash), SenderShardId: senderShardId, ReceiverShardId: receiverShardId},
+				},
+			},
+		},
+	}
+}
+
+func TestNewSupplyChecker_NilGenesisSupplyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sc, err := economics.NewSupplyChecker(nil, []uint32{0}, false)
+
+	assert.Nil(t, sc)
+	assert.Equal(t, process.ErrNilGenesisSupply, err)
+}
+
+func TestSupplyChecker_CheckShardBlockNilChangeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sc, _ := economics.NewSupplyChecker(big.NewInt(100), []uint32{0}, false)
+
+	assert.Equal(t, process.ErrNilSupplyChange, sc.CheckShardBlock(nil))
+	assert.Equal(t, process.ErrNilSupplyChange, sc.CheckShardBlock(&process.ShardSupplyChange{}))
+}
+
+func TestSupplyChecker_CheckShardBlockConservingValueShouldWork(t *testing.T) {
+	t.Parallel()
+
+	sc, _ := economics.NewSupplyChecker(big.NewInt(100), []uint32{0}, true)
+	change := createCrossShardChange(
+		0,
+		-4,
+		map[string]*process.CrossShardValue{"out": {ShardId: 1, Value: big.NewInt(7)}},
+		map[string]*process.CrossShardValue{"in": {ShardId: 1, Value: big.NewInt(5)}},
+		2,
+	)
+
+	err := sc.CheckShardBlock(change)
+
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(96), sc.Supply())
+	assert.Equal(t, big.NewInt(2), sc.BurntFees())
+}
+
+func TestSupplyChecker_CheckShardBlockMintingShouldErrWhenHalting(t *testing.T) {
+	t.Parallel()
+
+	sc, _ := economics.NewSupplyChecker(big.NewInt(100), []uint32{0}, true)
+
+	err := sc.CheckShardBlock(createCrossShardChange(0, 3, nil, nil, 0))
+
+	assert.Equal(t, process.ErrSupplyInvariantViolated, err)
+	assert.Equal(t, big.NewInt(100), sc.Supply())
+}
+
+func TestSupplyChecker_CheckShardBlockMintingShouldOnlyAlarmWhenNotHalting(t *testing.T) {
+	t.Parallel()
+
+	sc, _ := economics.NewSupplyChecker(big.NewInt(100), []uint32{0}, false)
+
+	err := sc.CheckShardBlock(createCrossShardChange(0, 3, nil, nil, 0))
+
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(103), sc.Supply())
+}
+
+func TestSupplyChecker_CheckMetaBlockNilHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sc, _ := economics.NewSupplyChecker(big.NewInt(100), []uint32{0}, true)
+
+	assert.Equal(t, process.ErrNilMetaBlockHeader, sc.CheckMetaBlock(nil))
+}
+
+func TestSupplyChecker_CheckMetaBlockMatchingCrossShardTransferShouldWork(t *testing.T) {
+	t.Parallel()
+
+	sc, _ := economics.NewSupplyChecker(big.NewInt(100), []uint32{0, 1}, true)
+	outgoing := map[string]*process.CrossShardValue{"mb": {ShardId: 1, Value: big.NewInt(5)}}
+	incoming := map[string]*process.CrossShardValue{"mb": {ShardId: 0, Value: big.NewInt(5)}}
+
+	err := sc.CheckShardBlock(createCrossShardChange(0, -6, outgoing, nil, 1))
+	assert.Nil(t, err)
+	//the value is in flight until the receiver shard credits it
+	err = sc.CheckMetaBlock(&block.MetaBlock{Nonce: 1})
+	assert.Nil(t, err)
+
+	err = sc.CheckShardBlock(createCrossShardChange(1, 5, nil, incoming, 0))
+	assert.Nil(t, err)
+	err = sc.CheckMetaBlock(createNotarizingMetaBlock("mb", 0, 1))
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(99), sc.Supply())
+}
+
+func TestSupplyChecker_CheckMetaBlockMismatchedCrossShardTransferShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sc, _ := economics.NewSupplyChecker(big.NewInt(100), []uint32{0, 1}, true)
+	outgoing := map[string]*process.CrossShardValue{"mb": {ShardId: 1, Value: big.NewInt(5)}}
+	incoming := map[string]*process.CrossShardValue{"mb": {ShardId: 0, Value: big.NewInt(8)}}
+
+	//each shard block is consistent on its own
+	err := sc.CheckShardBlock(createCrossShardChange(0, -5, outgoing, nil, 0))
+	assert.Nil(t, err)
+	err = sc.CheckShardBlock(createCrossShardChange(1, 8, nil, incoming, 0))
+	assert.Nil(t, err)
+
+	err = sc.CheckMetaBlock(createNotarizingMetaBlock("mb", 0, 1))
+	assert.Equal(t, process.ErrSupplyInvariantViolated, err)
+}
+
+func TestSupplyChecker_CheckMetaBlockDivergedSupplyShouldOnlyAlarmWhenNotHalting(t *testing.T) {
+	t.Parallel()
+
+	sc, _ := economics.NewSupplyChecker(big.NewInt(100), []uint32{0}, false)
+	_ = sc.CheckShardBlock(createCrossShardChange(0, 3, nil, nil, 0))
+
+	err := sc.CheckMetaBlock(&block.MetaBlock{Nonce: 1})
+
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(103), sc.Supply())
+}
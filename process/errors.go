@@ -199,6 +199,9 @@ var ErrInvalidTxInPool = errors.New("invalid transaction in the transactions poo
 // ErrNilHeadersStorage signals that a nil header storage has been provided
 var ErrNilHeadersStorage = errors.New("nil headers storage")
 
+// ErrNilHeadersNoncesStorage signals that a nil header nonce to hash storage has been provided
+var ErrNilHeadersNoncesStorage = errors.New("nil headers nonces storage")
+
 // ErrNilMetachainHeadersStorage signals that a nil metachain header storage has been provided
 var ErrNilMetachainHeadersStorage = errors.New("nil metachain headers storage")
 
@@ -303,3 +306,122 @@ var ErrMintAddressNotInThisShard = errors.New("mint address does not belong to c
 
 // ErrLastNotarizedHdrsSliceIsNil signals that the slice holding last notarized headers is nil
 var ErrLastNotarizedHdrsSliceIsNil = errors.New("last notarized shard headers slice is nil")
+
+// ErrNilFeeHandler signals that a nil fee handler has been provided
+var ErrNilFeeHandler = errors.New("nil fee handler")
+
+// ErrNilRewardsAddress signals that a nil rewards address has been provided
+var ErrNilRewardsAddress = errors.New("nil rewards address")
+
+// ErrRewardsAddressNotInSelfShard signals that the provided rewards address belongs to another shard
+var ErrRewardsAddressNotInSelfShard = errors.New("rewards address is not in self shard")
+
+// ErrInsufficientGasPrice signals that the gas price of a transaction is lower than the minimum accepted one
+var ErrInsufficientGasPrice = errors.New("insufficient gas price")
+
+// ErrInsufficientGasLimit signals that the gas limit of a transaction can not cover the gas needed to process it
+var ErrInsufficientGasLimit = errors.New("insufficient gas limit")
+
+// ErrInvalidMaxTxsInBlock signals that an invalid maximum number of transactions in a block has been provided
+var ErrInvalidMaxTxsInBlock = errors.New("invalid maximum number of transactions in block")
+
+// ErrInvalidMaxGasLimitInBlock signals that an invalid maximum gas limit of a block has been provided
+var ErrInvalidMaxGasLimitInBlock = errors.New("invalid maximum gas limit in block")
+
+// ErrMaxTxsInBlockExceeded signals that a block holds more transactions than allowed
+var ErrMaxTxsInBlockExceeded = errors.New("maximum number of transactions in block exceeded")
+
+// ErrMaxGasLimitInBlockExceeded signals that the gas limits of the transactions in a block sum up to more than allowed
+var ErrMaxGasLimitInBlockExceeded = errors.New("maximum gas limit in block exceeded")
+
+// ErrNilAccountFactory signals that a nil account factory has been provided
+var ErrNilAccountFactory = errors.New("nil account factory")
+
+// ErrInvalidMaxWorkers signals that an invalid maximum number of workers has been provided
+var ErrInvalidMaxWorkers = errors.New("invalid maximum number of workers")
+
+// ErrNilTransactionsExecutor signals that a nil transactions executor has been provided
+var ErrNilTransactionsExecutor = errors.New("nil transactions executor")
+
+// ErrNilUint64Converter signals that a nil uint64 <-> byte slice converter has been provided
+var ErrNilUint64Converter = errors.New("nil uint64 - byte slice converter")
+
+// ErrNilStatePruner signals that a nil state pruner has been provided
+var ErrNilStatePruner = errors.New("nil state pruner")
+
+// ErrInvalidValidityWindow signals that the round a transaction becomes valid is after the round its validity ends
+var ErrInvalidValidityWindow = errors.New("invalid transaction validity window")
+
+// ErrTxNotYetValid signals that a transaction has been received or processed before its validity window started
+var ErrTxNotYetValid = errors.New("transaction not yet valid")
+
+// ErrTxExpired signals that a transaction has been received or processed after its validity window ended
+var ErrTxExpired = errors.New("transaction expired")
+
+// ErrBatchTransferWithValue signals that a batch transfer transaction carries a value of its own
+var ErrBatchTransferWithValue = errors.New("batch transfer with value")
+
+// ErrInvalidBatchTransferReceiver signals that a batch transfer transaction is not addressed to its sender
+var ErrInvalidBatchTransferReceiver = errors.New("batch transfer not addressed to its sender")
+
+// ErrTooManyTransfersInBatch signals that a batch transfer transaction holds more transfers than allowed
+var ErrTooManyTransfersInBatch = errors.New("too many transfers in batch")
+
+// ErrInconsistentBatchTransfer signals that a batch transfer transaction is not carried by the miniblocks towards
+// all the shards of its receivers, or that it is not executed in the intra shard miniblock of its sender shard
+var ErrInconsistentBatchTransfer = errors.New("inconsistent batch transfer in block body")
+
+// ErrNilRequestedTxsCache signals that a nil cache of the requested transactions has been provided
+var ErrNilRequestedTxsCache = errors.New("nil requested transactions cache")
+
+// ErrNilChainID signals that a nil or empty chain ID has been provided
+var ErrNilChainID = errors.New("nil or empty chain ID")
+
+// ErrInvalidChainID signals that the chain ID of a transaction does not match the chain ID of the node
+var ErrInvalidChainID = errors.New("invalid chain ID")
+
+// ErrNilTrieNodesDataPool signals that a nil trie nodes pool has been provided
+var ErrNilTrieNodesDataPool = errors.New("nil trie nodes data pool")
+
+// ErrNilTrieNodesStorage signals that a nil trie nodes storage has been provided
+var ErrNilTrieNodesStorage = errors.New("nil trie nodes storage")
+
+// ErrNilLeafRootHashHandler signals that a nil handler extracting the data trie root hash of a leaf has been provided
+var ErrNilLeafRootHashHandler = errors.New("nil leaf root hash handler")
+
+// ErrNilStateDiffStorage signals that a nil state diff storage has been provided
+var ErrNilStateDiffStorage = errors.New("nil state diff storage")
+
+// ErrStateDiffMismatch signals that a state diff does not link the expected states
+var ErrStateDiffMismatch = errors.New("state diff does not match the expected root hashes")
+
+// ErrNilGenesisSupply signals that a nil genesis supply has been provided
+var ErrNilGenesisSupply = errors.New("nil genesis supply")
+
+// ErrNilSupplyChecker signals that a nil supply checker has been provided
+var ErrNilSupplyChecker = errors.New("nil supply checker")
+
+// ErrNilSupplyChange signals that a nil shard supply change has been provided
+var ErrNilSupplyChange = errors.New("nil shard supply change")
+
+// ErrSupplyInvariantViolated signals that the processed blocks created or destroyed value which can not be
+// accounted for by the cross shard transfers and the burnt fees
+var ErrSupplyInvariantViolated = errors.New("supply invariant violated")
+
+// ErrNilBootstrapStorage signals that a nil bootstrap storage has been provided
+var ErrNilBootstrapStorage = errors.New("nil bootstrap storage")
+
+// ErrNilSupplyDelta signals that a shard header carries no supply change
+var ErrNilSupplyDelta = errors.New("nil supply delta")
+
+// ErrSupplyChangeMismatch signals that the supply change carried by a header is not the one caused by its block
+var ErrSupplyChangeMismatch = errors.New("supply change mismatch")
+
+// ErrNilPendingCommit signals that a nil pending commit has been provided
+var ErrNilPendingCommit = errors.New("nil pending commit")
+
+// ErrInvalidRoundsPerEpoch signals that an invalid number of rounds per epoch has been provided
+var ErrInvalidRoundsPerEpoch = errors.New("invalid number of rounds per epoch")
+
+// ErrInvalidEpoch signals that the epoch of a header is not the one of its round or skips epochs
+var ErrInvalidEpoch = errors.New("invalid epoch")
@@ -13,4 +13,6 @@ const (
 	MetachainBlocksTopic = "metachainBlocks"
 	// ShardHeadersForMetachainTopic is used for sharing shards block headers to the metachain nodes
 	ShardHeadersForMetachainTopic = "shardHeadersForMetachain"
+	// TrieNodesTopic is used for sharing the accounts trie nodes of a shard while syncing its state
+	TrieNodesTopic = "trieNodes"
 )
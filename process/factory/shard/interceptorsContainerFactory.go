@@ -1,6 +1,7 @@
 package shard
 
 import (
+	"github.com/numbatx/gn-numbat/consensus"
 	"github.com/numbatx/gn-numbat/core/statistics"
 	"github.com/numbatx/gn-numbat/crypto"
 	"github.com/numbatx/gn-numbat/data/state"
@@ -13,6 +14,7 @@ import (
 	"github.com/numbatx/gn-numbat/process/factory/containers"
 	"github.com/numbatx/gn-numbat/process/transaction"
 	"github.com/numbatx/gn-numbat/sharding"
+	"github.com/numbatx/gn-numbat/storage"
 )
 
 type interceptorsContainerFactory struct {
@@ -27,6 +29,9 @@ type interceptorsContainerFactory struct {
 	dataPool            dataRetriever.PoolsHolder
 	addrConverter       state.AddressConverter
 	chronologyValidator process.ChronologyValidator
+	rounder             consensus.Rounder
+	requestedTxs        storage.Cacher
+	chainID             []byte
 	tpsBenchmark        *statistics.TpsBenchmark
 }
 
@@ -43,6 +48,9 @@ func NewInterceptorsContainerFactory(
 	dataPool dataRetriever.PoolsHolder,
 	addrConverter state.AddressConverter,
 	chronologyValidator process.ChronologyValidator,
+	rounder consensus.Rounder,
+	requestedTxs storage.Cacher,
+	chainID []byte,
 	tpsBenchmark *statistics.TpsBenchmark,
 ) (*interceptorsContainerFactory, error) {
 
@@ -79,6 +87,15 @@ func NewInterceptorsContainerFactory(
 	if chronologyValidator == nil {
 		return nil, process.ErrNilChronologyValidator
 	}
+	if rounder == nil {
+		return nil, process.ErrNilRounder
+	}
+	if requestedTxs == nil {
+		return nil, process.ErrNilRequestedTxsCache
+	}
+	if len(chainID) == 0 {
+		return nil, process.ErrNilChainID
+	}
 
 	return &interceptorsContainerFactory{
 		shardCoordinator:    shardCoordinator,
@@ -92,6 +109,9 @@ func NewInterceptorsContainerFactory(
 		dataPool:            dataPool,
 		addrConverter:       addrConverter,
 		chronologyValidator: chronologyValidator,
+		rounder:             rounder,
+		requestedTxs:        requestedTxs,
+		chainID:             chainID,
 		tpsBenchmark:        tpsBenchmark,
 	}, nil
 }
@@ -150,6 +170,16 @@ func (icf *interceptorsContainerFactory) Create() (process.InterceptorsContainer
 		return nil, err
 	}
 
+	keys, interceptorSlice, err = icf.generateTrieNodesInterceptor()
+	if err != nil {
+		return nil, err
+	}
+
+	err = container.AddMultiple(keys, interceptorSlice)
+	if err != nil {
+		return nil, err
+	}
+
 	return container, nil
 }
 
@@ -213,7 +243,10 @@ func (icf *interceptorsContainerFactory) createOneTxInterceptor(identifier strin
 		icf.hasher,
 		icf.singleSigner,
 		icf.keyGen,
-		icf.shardCoordinator)
+		icf.shardCoordinator,
+		icf.rounder,
+		icf.requestedTxs,
+		icf.chainID)
 
 	if err != nil {
 		return nil, err
@@ -346,3 +379,28 @@ func (icf *interceptorsContainerFactory) generateMetachainHeaderInterceptor() ([
 
 	return []string{identifierHdr}, []process.Interceptor{interceptor}, nil
 }
+
+//------- TrieNodes interceptor
+
+func (icf *interceptorsContainerFactory) generateTrieNodesInterceptor() ([]string, []process.Interceptor, error) {
+	shardC := icf.shardCoordinator
+
+	//only one intrashard trie nodes topic
+	identifierTrieNodes := factory.TrieNodesTopic + shardC.CommunicationIdentifier(shardC.SelfId())
+
+	interceptor, err := interceptors.NewTrieNodesInterceptor(
+		icf.marshalizer,
+		icf.dataPool.TrieNodes(),
+		icf.hasher,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	//the trie nodes are only sent to the requesting peer, so the topic is never broadcast on
+	_, err = icf.createTopicAndAssignHandler(identifierTrieNodes, interceptor, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []string{identifierTrieNodes}, []process.Interceptor{interceptor}, nil
+}
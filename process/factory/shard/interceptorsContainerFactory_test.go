@@ -65,6 +65,9 @@ func createDataPools() dataRetriever.PoolsHolder {
 	pools.MetaHeadersNoncesCalled = func() dataRetriever.Uint64Cacher {
 		return &mock.Uint64CacherStub{}
 	}
+	pools.TrieNodesCalled = func() storage.Cacher {
+		return &mock.CacherStub{}
+	}
 
 	return pools
 }
@@ -94,6 +97,9 @@ func TestNewInterceptorsContainerFactory_NilShardCoordinatorShouldErr(t *testing
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -116,6 +122,9 @@ func TestNewInterceptorsContainerFactory_NilTopicHandlerShouldErr(t *testing.T)
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -138,6 +147,9 @@ func TestNewInterceptorsContainerFactory_NilBlockchainShouldErr(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -160,6 +172,9 @@ func TestNewInterceptorsContainerFactory_NilMarshalizerShouldErr(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -182,6 +197,9 @@ func TestNewInterceptorsContainerFactory_NilHasherShouldErr(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -204,6 +222,9 @@ func TestNewInterceptorsContainerFactory_NilKeyGenShouldErr(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -226,6 +247,9 @@ func TestNewInterceptorsContainerFactory_NilSingleSignerShouldErr(t *testing.T)
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -248,6 +272,9 @@ func TestNewInterceptorsContainerFactory_NilMultiSignerShouldErr(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -270,6 +297,9 @@ func TestNewInterceptorsContainerFactory_NilDataPoolShouldErr(t *testing.T) {
 		nil,
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -292,6 +322,9 @@ func TestNewInterceptorsContainerFactory_NilAddrConverterShouldErr(t *testing.T)
 		createDataPools(),
 		nil,
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -299,6 +332,81 @@ func TestNewInterceptorsContainerFactory_NilAddrConverterShouldErr(t *testing.T)
 	assert.Equal(t, process.ErrNilAddressConverter, err)
 }
 
+func TestNewInterceptorsContainerFactory_NilRounderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	icf, err := shard.NewInterceptorsContainerFactory(
+		mock.NewOneShardCoordinatorMock(),
+		&mock.TopicHandlerStub{},
+		createStore(),
+		&mock.MarshalizerMock{},
+		&mock.HasherMock{},
+		&mock.SingleSignKeyGenMock{},
+		&mock.SignerMock{},
+		mock.NewMultiSigner(),
+		createDataPools(),
+		&mock.AddressConverterMock{},
+		&mock.ChronologyValidatorStub{},
+		nil,
+		&mock.CacherStub{},
+		[]byte("chain ID"),
+		nil,
+	)
+
+	assert.Nil(t, icf)
+	assert.Equal(t, process.ErrNilRounder, err)
+}
+
+func TestNewInterceptorsContainerFactory_NilRequestedTxsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	icf, err := shard.NewInterceptorsContainerFactory(
+		mock.NewOneShardCoordinatorMock(),
+		&mock.TopicHandlerStub{},
+		createStore(),
+		&mock.MarshalizerMock{},
+		&mock.HasherMock{},
+		&mock.SingleSignKeyGenMock{},
+		&mock.SignerMock{},
+		mock.NewMultiSigner(),
+		createDataPools(),
+		&mock.AddressConverterMock{},
+		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		nil,
+		[]byte("chain ID"),
+		nil,
+	)
+
+	assert.Nil(t, icf)
+	assert.Equal(t, process.ErrNilRequestedTxsCache, err)
+}
+
+func TestNewInterceptorsContainerFactory_NilChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	icf, err := shard.NewInterceptorsContainerFactory(
+		mock.NewOneShardCoordinatorMock(),
+		&mock.TopicHandlerStub{},
+		createStore(),
+		&mock.MarshalizerMock{},
+		&mock.HasherMock{},
+		&mock.SingleSignKeyGenMock{},
+		&mock.SignerMock{},
+		mock.NewMultiSigner(),
+		createDataPools(),
+		&mock.AddressConverterMock{},
+		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		nil,
+		nil,
+	)
+
+	assert.Nil(t, icf)
+	assert.Equal(t, process.ErrNilChainID, err)
+}
+
 func TestNewInterceptorsContainerFactory_ShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -314,6 +422,9 @@ func TestNewInterceptorsContainerFactory_ShouldWork(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -338,6 +449,9 @@ func TestInterceptorsContainerFactory_CreateTopicCreationTxFailsShouldErr(t *tes
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -362,6 +476,9 @@ func TestInterceptorsContainerFactory_CreateTopicCreationHdrFailsShouldErr(t *te
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -386,6 +503,9 @@ func TestInterceptorsContainerFactory_CreateTopicCreationMiniBlocksFailsShouldEr
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -410,6 +530,9 @@ func TestInterceptorsContainerFactory_CreateTopicCreationPeerChBlocksFailsShould
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -434,6 +557,9 @@ func TestInterceptorsContainerFactory_CreateTopicCreationMetachainHeadersFailsSh
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -458,6 +584,9 @@ func TestInterceptorsContainerFactory_CreateRegisterTxFailsShouldErr(t *testing.
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -482,6 +611,9 @@ func TestInterceptorsContainerFactory_CreateRegisterHdrFailsShouldErr(t *testing
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -506,6 +638,9 @@ func TestInterceptorsContainerFactory_CreateRegisterMiniBlocksFailsShouldErr(t *
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -530,6 +665,9 @@ func TestInterceptorsContainerFactory_CreateRegisterPeerChBlocksFailsShouldErr(t
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -554,6 +692,9 @@ func TestInterceptorsContainerFactory_CreateRegisterMetachainHeadersShouldErr(t
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -585,6 +726,9 @@ func TestInterceptorsContainerFactory_CreateShouldWork(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -622,6 +766,9 @@ func TestInterceptorsContainerFactory_With4ShardsShouldWork(t *testing.T) {
 		createDataPools(),
 		&mock.AddressConverterMock{},
 		&mock.ChronologyValidatorStub{},
+		&mock.RounderMock{},
+		&mock.CacherStub{},
+		[]byte("chain ID"),
 		nil,
 	)
 
@@ -632,8 +779,9 @@ func TestInterceptorsContainerFactory_With4ShardsShouldWork(t *testing.T) {
 	numInterceptorMiniBlocks := noOfShards
 	numInterceptorPeerChanges := 1
 	numInterceptorMetachainHeaders := 1
+	numInterceptorTrieNodes := 1
 	totalInterceptors := numInterceptorTxs + numInterceptorHeaders + numInterceptorMiniBlocks +
-		numInterceptorPeerChanges + numInterceptorMetachainHeaders
+		numInterceptorPeerChanges + numInterceptorMetachainHeaders + numInterceptorTrieNodes
 
 	assert.Equal(t, totalInterceptors, container.Len())
 }
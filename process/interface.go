@@ -1,9 +1,11 @@
 package process
 
 import (
+	"math/big"
 	"time"
 
 	"github.com/numbatx/gn-numbat/data"
+	"github.com/numbatx/gn-numbat/data/block"
 	"github.com/numbatx/gn-numbat/data/state"
 	"github.com/numbatx/gn-numbat/data/transaction"
 	"github.com/numbatx/gn-numbat/p2p"
@@ -16,6 +18,22 @@ type TransactionProcessor interface {
 	SetSCHandler(func(accountsAdapter state.AccountsAdapter, transaction *transaction.Transaction) error)
 
 	ProcessTransaction(transaction *transaction.Transaction, round int32) error
+	ExecutionError(txHash []byte) error
+	ClearExecutionErrors()
+}
+
+// TransactionsExecutor executes a set of transactions, as a whole, keeping the outcome of their execution in the
+// provided order
+type TransactionsExecutor interface {
+	ExecuteTransactions(txs []*transaction.Transaction, round int32) error
+}
+
+// FeeHandler is able to check the gas settings of a transaction and to compute the fee it has to pay
+type FeeHandler interface {
+	MinGasPrice() uint64
+	GasUsed(tx *transaction.Transaction) uint64
+	CheckTxGas(tx *transaction.Transaction) error
+	ComputeFee(tx *transaction.Transaction) *big.Int
 }
 
 // BlockProcessor is the main interface for block execution engine
@@ -72,6 +90,12 @@ type Bootstrapper interface {
 	StartSync()
 }
 
+// StateSyncer is an interface that defines the behaviour of a struct that is able to download from peers the
+// accounts state of a trusted block header, making it the block the node continues to synchronize from
+type StateSyncer interface {
+	SyncState(headerHash []byte) error
+}
+
 // ForkDetector is an interface that defines the behaviour of a struct that is able
 // to detect forks
 type ForkDetector interface {
@@ -142,3 +166,28 @@ type BlocksTracker interface {
 	SetBlockBroadcastRound(nonce uint64, round int32)
 	BlockBroadcastRound(nonce uint64) int32
 }
+
+// CrossShardValue holds the value carried by a cross shard miniblock and the id of the other shard it is exchanged with
+type CrossShardValue struct {
+	ShardId uint32
+	Value   *big.Int
+}
+
+// ShardSupplyChange holds the change of the supply held by a shard, caused by one of its blocks, along with the value
+// explaining it: the value sent to and received from other shards, keyed by the hash of the carrying miniblock, and
+// the burnt fees
+type ShardSupplyChange struct {
+	ShardId   uint32
+	Nonce     uint64
+	Delta     *big.Int
+	Outgoing  map[string]*CrossShardValue
+	Incoming  map[string]*CrossShardValue
+	BurntFees *big.Int
+}
+
+// SupplyChecker verifies that the processed blocks conserve the supply, so that a processing bug can not silently
+// create or destroy value
+type SupplyChecker interface {
+	CheckShardBlock(change *ShardSupplyChange) error
+	CheckMetaBlock(header *block.MetaBlock) error
+}
@@ -0,0 +1,11 @@
+package mock
+
+import "github.com/numbatx/gn-numbat/data/state"
+
+type AccountFactoryStub struct {
+	CreateAccountCalled func(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error)
+}
+
+func (afs *AccountFactoryStub) CreateAccount(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error) {
+	return afs.CreateAccountCalled(address, tracker)
+}
@@ -20,6 +20,10 @@ type AccountsStub struct {
 	SaveDataTrieCalled          func(acountWrapper state.AccountHandler) error
 	RootHashCalled              func() []byte
 	RecreateTrieCalled          func(rootHash []byte) error
+	GetAccountProofCalled       func(rootHash []byte, addressContainer state.AddressContainer) ([]byte, [][]byte, error)
+	StateDiffCalled             func() (*state.StateDiff, error)
+	UnwindStateDiffCalled       func(diff *state.StateDiff) error
+	GetAccountFromRootCalled    func(rootHash []byte, addressContainer state.AddressContainer) (state.AccountHandler, error)
 }
 
 func NewAccountsStub() *AccountsStub {
@@ -85,3 +89,19 @@ func (aam *AccountsStub) RootHash() []byte {
 func (aam *AccountsStub) RecreateTrie(rootHash []byte) error {
 	return aam.RecreateTrieCalled(rootHash)
 }
+
+func (aam *AccountsStub) GetAccountProof(rootHash []byte, addressContainer state.AddressContainer) ([]byte, [][]byte, error) {
+	return aam.GetAccountProofCalled(rootHash, addressContainer)
+}
+
+func (aam *AccountsStub) GetAccountFromRoot(rootHash []byte, addressContainer state.AddressContainer) (state.AccountHandler, error) {
+	return aam.GetAccountFromRootCalled(rootHash, addressContainer)
+}
+
+func (aam *AccountsStub) StateDiff() (*state.StateDiff, error) {
+	return aam.StateDiffCalled()
+}
+
+func (aam *AccountsStub) UnwindStateDiff(diff *state.StateDiff) error {
+	return aam.UnwindStateDiffCalled(diff)
+}
@@ -4,7 +4,7 @@ import (
 	"github.com/numbatx/gn-numbat/data/typeConverters/uint64ByteSlice"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/dataRetriever/dataPool"
-	"github.com/numbatx/gn-numbat/dataRetriever/shardedData"
+	"github.com/numbatx/gn-numbat/dataRetriever/txpool"
 	"github.com/numbatx/gn-numbat/storage"
 )
 
@@ -15,12 +15,14 @@ type PoolsHolderFake struct {
 	hdrNonces         dataRetriever.Uint64Cacher
 	miniBlocks        storage.Cacher
 	peerChangesBlocks storage.Cacher
+	trieNodes         storage.Cacher
 	metaHdrNonces     dataRetriever.Uint64Cacher
 }
 
 func NewPoolsHolderFake() *PoolsHolderFake {
 	phf := &PoolsHolderFake{}
-	phf.transactions, _ = shardedData.NewShardedData(storage.CacheConfig{Size: 10000, Type: storage.LRUCache})
+	requestedTxs, _ := storage.NewCache(storage.LRUCache, 10000, 1)
+	phf.transactions, _ = txpool.NewShardedTxPool(10000, 10000, 10, requestedTxs)
 	phf.headers, _ = storage.NewCache(storage.LRUCache, 10000, 1)
 	phf.metaBlocks, _ = storage.NewCache(storage.LRUCache, 10000, 1)
 	cacheHdrNonces, _ := storage.NewCache(storage.LRUCache, 10000, 1)
@@ -34,6 +36,7 @@ func NewPoolsHolderFake() *PoolsHolderFake {
 	)
 	phf.miniBlocks, _ = storage.NewCache(storage.LRUCache, 10000, 1)
 	phf.peerChangesBlocks, _ = storage.NewCache(storage.LRUCache, 10000, 1)
+	phf.trieNodes, _ = storage.NewCache(storage.LRUCache, 10000, 1)
 	return phf
 }
 
@@ -64,3 +67,7 @@ func (phf *PoolsHolderFake) MetaBlocks() storage.Cacher {
 func (phf *PoolsHolderFake) MetaHeadersNonces() dataRetriever.Uint64Cacher {
 	return phf.metaHdrNonces
 }
+
+func (phf *PoolsHolderFake) TrieNodes() storage.Cacher {
+	return phf.trieNodes
+}
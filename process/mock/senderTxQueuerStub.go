@@ -0,0 +1,10 @@
+package mock
+
+type SenderTxQueuerStub struct {
+	CacherStub
+	SortedTxHashesBySenderCalled func() map[string][][]byte
+}
+
+func (stqs *SenderTxQueuerStub) SortedTxHashesBySender() map[string][][]byte {
+	return stqs.SortedTxHashesBySenderCalled()
+}
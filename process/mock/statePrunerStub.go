@@ -0,0 +1,20 @@
+package mock
+
+type StatePrunerStub struct {
+	AddRootCalled func(nonce uint64, rootHash []byte)
+	PruneCalled   func(finalNonce uint64) error
+}
+
+func (sps *StatePrunerStub) AddRoot(nonce uint64, rootHash []byte) {
+	if sps.AddRootCalled != nil {
+		sps.AddRootCalled(nonce, rootHash)
+	}
+}
+
+func (sps *StatePrunerStub) Prune(finalNonce uint64) error {
+	if sps.PruneCalled != nil {
+		return sps.PruneCalled(finalNonce)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package mock
+
+import (
+	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/process"
+)
+
+type SupplyCheckerStub struct {
+	CheckShardBlockCalled func(change *process.ShardSupplyChange) error
+	CheckMetaBlockCalled  func(header *block.MetaBlock) error
+}
+
+func (scs *SupplyCheckerStub) CheckShardBlock(change *process.ShardSupplyChange) error {
+	if scs.CheckShardBlockCalled != nil {
+		return scs.CheckShardBlockCalled(change)
+	}
+
+	return nil
+}
+
+func (scs *SupplyCheckerStub) CheckMetaBlock(header *block.MetaBlock) error {
+	if scs.CheckMetaBlockCalled != nil {
+		return scs.CheckMetaBlockCalled(header)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package mock
+
+import (
+	"github.com/numbatx/gn-numbat/data/transaction"
+)
+
+type TransactionsExecutorStub struct {
+	ExecuteTransactionsCalled func(txs []*transaction.Transaction, round int32) error
+}
+
+func (tes *TransactionsExecutorStub) ExecuteTransactions(txs []*transaction.Transaction, round int32) error {
+	if tes.ExecuteTransactionsCalled != nil {
+		return tes.ExecuteTransactionsCalled(txs, round)
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package mock
+
+import (
+	"github.com/numbatx/gn-numbat/p2p"
+)
+
+type TrieNodesResolverMock struct {
+	RequestDataFromHashCalled      func(hash []byte) error
+	RequestDataFromHashArrayCalled func(hashes [][]byte) error
+	ProcessReceivedMessageCalled   func(message p2p.MessageP2P) error
+}
+
+func (tnrm *TrieNodesResolverMock) RequestDataFromHash(hash []byte) error {
+	return tnrm.RequestDataFromHashCalled(hash)
+}
+
+func (tnrm *TrieNodesResolverMock) RequestDataFromHashArray(hashes [][]byte) error {
+	return tnrm.RequestDataFromHashArrayCalled(hashes)
+}
+
+func (tnrm *TrieNodesResolverMock) ProcessReceivedMessage(message p2p.MessageP2P) error {
+	return tnrm.ProcessReceivedMessageCalled(message)
+}
@@ -8,8 +8,10 @@ import (
 )
 
 type TxProcessorMock struct {
-	ProcessTransactionCalled func(transaction *transaction.Transaction, round int32) error
-	SetBalancesToTrieCalled  func(accBalance map[string]*big.Int) (rootHash []byte, err error)
+	ProcessTransactionCalled   func(transaction *transaction.Transaction, round int32) error
+	SetBalancesToTrieCalled    func(accBalance map[string]*big.Int) (rootHash []byte, err error)
+	ExecutionErrorCalled       func(txHash []byte) error
+	ClearExecutionErrorsCalled func()
 }
 
 func (etm *TxProcessorMock) SCHandler() func(accountsAdapter state.AccountsAdapter, transaction *transaction.Transaction) error {
@@ -24,6 +26,20 @@ func (etm *TxProcessorMock) ProcessTransaction(transaction *transaction.Transact
 	return etm.ProcessTransactionCalled(transaction, round)
 }
 
+func (etm *TxProcessorMock) ExecutionError(txHash []byte) error {
+	if etm.ExecutionErrorCalled != nil {
+		return etm.ExecutionErrorCalled(txHash)
+	}
+
+	return nil
+}
+
+func (etm *TxProcessorMock) ClearExecutionErrors() {
+	if etm.ClearExecutionErrorsCalled != nil {
+		etm.ClearExecutionErrorsCalled()
+	}
+}
+
 func (etm *TxProcessorMock) SetBalancesToTrie(accBalance map[string]*big.Int) (rootHash []byte, err error) {
 	return etm.SetBalancesToTrieCalled(accBalance)
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/numbatx/gn-numbat/core/logger"
 	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/typeConverters"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/hashing"
 	"github.com/numbatx/gn-numbat/marshal"
@@ -42,6 +43,7 @@ type baseBootstrap struct {
 	forkDetector     process.ForkDetector
 	shardCoordinator sharding.Coordinator
 	accounts         state.AccountsAdapter
+	uint64Converter  typeConverters.Uint64ByteSliceConverter
 
 	mutHeader   sync.RWMutex
 	headerNonce *uint64
@@ -182,10 +184,21 @@ func (boot *baseBootstrap) removeHeaderFromPools(header data.HeaderHandler) (has
 	return
 }
 
-func (boot *baseBootstrap) cleanCachesOnRollback(header data.HeaderHandler, headerStore storage.Storer) {
+func (boot *baseBootstrap) cleanCachesOnRollback(
+	header data.HeaderHandler,
+	headerStore storage.Storer,
+	hdrNoncesStore storage.Storer,
+) {
+
 	hash := boot.removeHeaderFromPools(header)
 	boot.forkDetector.RemoveHeaders(header.GetNonce(), hash)
 	_ = headerStore.Remove(hash)
+	_ = hdrNoncesStore.Remove(boot.uint64Converter.ToByteSlice(header.GetNonce()))
+
+	stateDiffStore := boot.store.GetStorer(dataRetriever.StateDiffUnit)
+	if stateDiffStore != nil {
+		_ = stateDiffStore.Remove(hash)
+	}
 }
 
 // checkBootstrapNilParameters will check the imput parameters for nil values
@@ -200,6 +213,7 @@ func checkBootstrapNilParameters(
 	shardCoordinator sharding.Coordinator,
 	accounts state.AccountsAdapter,
 	store dataRetriever.StorageService,
+	uint64Converter typeConverters.Uint64ByteSliceConverter,
 ) error {
 	if blkc == nil {
 		return process.ErrNilBlockChain
@@ -231,6 +245,9 @@ func checkBootstrapNilParameters(
 	if store == nil {
 		return process.ErrNilStore
 	}
+	if uint64Converter == nil {
+		return process.ErrNilUint64Converter
+	}
 
 	return nil
 }
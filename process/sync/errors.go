@@ -38,3 +38,9 @@ func (err ErrSignedBlock) Error() string {
 
 // ErrRandomSeedNotValid signals that the random seed is not valid
 var ErrRandomSeedNotValid = errors.New("random seed is not valid")
+
+// ErrInvalidBatchSize signals that the number of trie nodes requested at once is not greater than 0
+var ErrInvalidBatchSize = errors.New("invalid batch size")
+
+// ErrTrieNodesNotReceived signals that the requested trie nodes were not received after all the retries
+var ErrTrieNodesNotReceived = errors.New("trie nodes not received")
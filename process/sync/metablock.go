@@ -9,6 +9,7 @@ import (
 	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/data/block"
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/typeConverters"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/hashing"
 	"github.com/numbatx/gn-numbat/marshal"
@@ -40,6 +41,7 @@ func NewMetaBootstrap(
 	resolversFinder dataRetriever.ResolversFinder,
 	shardCoordinator sharding.Coordinator,
 	accounts state.AccountsAdapter,
+	uint64Converter typeConverters.Uint64ByteSliceConverter,
 ) (*MetaBootstrap, error) {
 
 	if poolsHolder == nil {
@@ -63,6 +65,7 @@ func NewMetaBootstrap(
 		shardCoordinator,
 		accounts,
 		store,
+		uint64Converter,
 	)
 	if err != nil {
 		return nil, err
@@ -81,6 +84,7 @@ func NewMetaBootstrap(
 		forkDetector:     forkDetector,
 		shardCoordinator: shardCoordinator,
 		accounts:         accounts,
+		uint64Converter:  uint64Converter,
 	}
 
 	boot := MetaBootstrap{
@@ -351,6 +355,10 @@ func (boot *MetaBootstrap) rollback(header *block.MetaBlock) error {
 	if headerStore == nil {
 		return process.ErrNilHeadersStorage
 	}
+	hdrNoncesStore := boot.store.GetStorer(dataRetriever.MetaHdrNonceHashDataUnit)
+	if hdrNoncesStore == nil {
+		return process.ErrNilHeadersNoncesStorage
+	}
 
 	var err error
 	var newHeader *block.MetaBlock
@@ -381,8 +389,13 @@ func (boot *MetaBootstrap) rollback(header *block.MetaBlock) error {
 		return err
 	}
 
-	boot.cleanCachesOnRollback(header, headerStore)
-	errNotCritical := boot.blkExecutor.RestoreBlockIntoPools(header, nil)
+	errNotCritical := process.SaveLastCommittedHeaderHash(boot.store, boot.shardCoordinator.SelfId(), newHeaderHash)
+	if errNotCritical != nil {
+		log.Info(errNotCritical.Error())
+	}
+
+	boot.cleanCachesOnRollback(header, headerStore, hdrNoncesStore)
+	errNotCritical = boot.blkExecutor.RestoreBlockIntoPools(header, nil)
 	if errNotCritical != nil {
 		log.Info(errNotCritical.Error())
 	}
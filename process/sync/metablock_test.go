@@ -126,6 +126,7 @@ func TestNewMetaBootstrap_NilPoolsHolderShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -162,6 +163,7 @@ func TestNewMetaBootstrap_PoolsHolderRetNilOnHeadersShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -197,6 +199,7 @@ func TestNewMetaBootstrap_PoolsHolderRetNilOnHeadersNoncesShouldErr(t *testing.T
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -228,6 +231,7 @@ func TestNewMetaBootstrap_NilStoreShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -259,6 +263,7 @@ func TestNewMetaBootstrap_NilBlockchainShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -290,6 +295,7 @@ func TestNewMetaBootstrap_NilRounderShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -321,6 +327,7 @@ func TestNewMetaBootstrap_NilBlockProcessorShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -352,6 +359,7 @@ func TestNewMetaBootstrap_NilHasherShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -383,6 +391,7 @@ func TestNewMetaBootstrap_NilMarshalizerShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -414,6 +423,7 @@ func TestNewMetaBootstrap_NilForkDetectorShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -446,6 +456,7 @@ func TestNewMetaBootstrap_NilResolversContainerShouldErr(t *testing.T) {
 		nil,
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -477,6 +488,7 @@ func TestNewMetaBootstrap_NilShardCoordinatorShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		nil,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -508,12 +520,45 @@ func TestNewMetaBootstrap_NilAccountsAdapterShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		nil,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
 	assert.Equal(t, process.ErrNilAccountsAdapter, err)
 }
 
+func TestNewMetaBootstrap_NilUint64ConverterShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pools := createMockMetaPools()
+	blkc := initBlockchain()
+	rnd := &mock.RounderMock{}
+	blkExec := &mock.BlockProcessorMock{}
+	forkDetector := &mock.ForkDetectorMock{}
+	hasher := &mock.HasherMock{}
+	marshalizer := &mock.MarshalizerMock{}
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+
+	bs, err := sync.NewMetaBootstrap(
+		pools,
+		createStore(),
+		blkc,
+		rnd,
+		blkExec,
+		waitTime,
+		hasher,
+		marshalizer,
+		forkDetector,
+		&mock.ResolversFinderStub{},
+		shardCoordinator,
+		&mock.AccountsStub{},
+		nil,
+	)
+
+	assert.Nil(t, bs)
+	assert.Equal(t, process.ErrNilUint64Converter, err)
+}
+
 func TestNewMetaBootstrap_NilHeaderResolverShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -552,6 +597,7 @@ func TestNewMetaBootstrap_NilHeaderResolverShouldErr(t *testing.T) {
 		resFinder,
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -596,6 +642,7 @@ func TestNewMetaBootstrap_NilTxBlockBodyResolverShouldErr(t *testing.T) {
 		resFinder,
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -652,6 +699,7 @@ func TestNewMetaBootstrap_OkValsShouldWork(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.NotNil(t, bs)
@@ -711,6 +759,7 @@ func TestMetaBootstrap_SyncBlockShouldCallForkChoice(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	r := bs.SyncBlock()
@@ -762,6 +811,7 @@ func TestMetaBootstrap_ShouldReturnMissingHeader(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	r := bs.SyncBlock()
@@ -813,6 +863,7 @@ func TestMetaBootstrap_ShouldNotNeedToSync(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.StartSync()
@@ -909,6 +960,7 @@ func TestMetaBootstrap_SyncShouldSyncOneBlock(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.StartSync()
@@ -1001,6 +1053,7 @@ func TestMetaBootstrap_ShouldReturnNilErr(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	r := bs.SyncBlock()
@@ -1095,6 +1148,7 @@ func TestMetaBootstrap_SyncBlockShouldReturnErrorWhenProcessBlockFailed(t *testi
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	err := bs.SyncBlock()
@@ -1134,6 +1188,7 @@ func TestMetaBootstrap_ShouldSyncShouldReturnFalseWhenCurrentBlockIsNilAndRoundI
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, err)
@@ -1173,6 +1228,7 @@ func TestMetaBootstrap_ShouldReturnTrueWhenCurrentBlockIsNilAndRoundIndexIsGreat
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.True(t, bs.ShouldSync())
@@ -1216,6 +1272,7 @@ func TestMetaBootstrap_ShouldReturnFalseWhenNodeIsSynced(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.False(t, bs.ShouldSync())
@@ -1259,6 +1316,7 @@ func TestMetaBootstrap_ShouldReturnTrueWhenNodeIsNotSynced(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.True(t, bs.ShouldSync())
@@ -1293,6 +1351,7 @@ func TestMetaBootstrap_GetHeaderFromPoolShouldReturnNil(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs.GetHeaderFromPoolWithNonce(0))
@@ -1355,6 +1414,7 @@ func TestMetaBootstrap_GetHeaderFromPoolShouldReturnHeader(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.True(t, hdr == bs.GetHeaderFromPoolWithNonce(0))
@@ -1422,6 +1482,7 @@ func TestMetaBootstrap_ReceivedHeadersFoundInPoolShouldAddToForkDetector(t *test
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.ReceivedHeaders(addedHash)
@@ -1494,6 +1555,7 @@ func TestMetaBootstrap_ReceivedHeadersNotFoundInPoolButFoundInStorageShouldAddTo
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.ReceivedHeaders(addedHash)
@@ -1529,6 +1591,7 @@ func TestMetaBootstrap_ForkChoiceNilBlockchainHeaderShouldErr(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	err := bs.ForkChoice()
@@ -1561,6 +1624,7 @@ func TestMetaBootstrap_ForkChoiceNilParamHeaderShouldErr(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
@@ -1608,6 +1672,7 @@ func TestMetaBootstrap_ForkChoiceIsNotEmptyShouldErr(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
@@ -1729,6 +1794,7 @@ func TestMetaBootstrap_ForkChoiceIsEmptyCallRollBackOkValsShouldWork(t *testing.
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.SetForkNonce(currentHdrNonce)
@@ -1884,6 +1950,7 @@ func TestMetaBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testi
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.SetForkNonce(currentHdrNonce)
@@ -1945,6 +2012,7 @@ func TestMetaBootstrap_AddSyncStateListenerShouldAppendAnotherListener(t *testin
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	f1 := func(bool) {}
@@ -1985,6 +2053,7 @@ func TestMetaBootstrap_NotifySyncStateListenersShouldNotify(t *testing.T) {
 		createMockResolversFinderMeta(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	mutex.RLock()
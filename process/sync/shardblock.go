@@ -1,6 +1,7 @@
 package sync
 
 import (
+	"bytes"
 	"fmt"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/data/block"
 	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/typeConverters"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/hashing"
 	"github.com/numbatx/gn-numbat/marshal"
@@ -45,6 +47,7 @@ func NewShardBootstrap(
 	resolversFinder dataRetriever.ResolversFinder,
 	shardCoordinator sharding.Coordinator,
 	accounts state.AccountsAdapter,
+	uint64Converter typeConverters.Uint64ByteSliceConverter,
 ) (*ShardBootstrap, error) {
 
 	if poolsHolder == nil {
@@ -71,6 +74,7 @@ func NewShardBootstrap(
 		shardCoordinator,
 		accounts,
 		store,
+		uint64Converter,
 	)
 	if err != nil {
 		return nil, err
@@ -89,6 +93,7 @@ func NewShardBootstrap(
 		forkDetector:     forkDetector,
 		shardCoordinator: shardCoordinator,
 		accounts:         accounts,
+		uint64Converter:  uint64Converter,
 	}
 
 	boot := ShardBootstrap{
@@ -262,18 +267,27 @@ func (boot *ShardBootstrap) SyncBlock() error {
 	return nil
 }
 
-// getHeaderFromPoolWithNonce method returns the block header from a given nonce
+// getHeaderFromPoolWithNonce method returns the block header from a given nonce. The committed headers which are no
+// longer in pool are looked up in storage
 func (boot *ShardBootstrap) getHeaderFromPoolWithNonce(nonce uint64) *block.Header {
 	hash, _ := boot.headersNonces.Get(nonce)
 	if hash == nil {
-		log.Debug(fmt.Sprintf("nonce %d not found in headers-nonces cache\n", nonce))
+		hash, _ = boot.store.Get(boot.hdrNonceHashDataUnit(), boot.uint64Converter.ToByteSlice(nonce))
+	}
+	if hash == nil {
+		log.Debug(fmt.Sprintf("nonce %d not found in headers-nonces cache and storage\n", nonce))
 		return nil
 	}
 
 	hdr, ok := boot.headers.Peek(hash)
 	if !ok {
-		log.Debug(fmt.Sprintf("header with hash %s not found in headers cache\n", core.ToB64(hash)))
-		return nil
+		header, err := process.GetShardHeaderFromStorage(hash, boot.marshalizer, boot.store)
+		if err != nil {
+			log.Debug(fmt.Sprintf("header with hash %s not found in headers cache and storage\n", core.ToB64(hash)))
+			return nil
+		}
+
+		return header
 	}
 
 	header, ok := hdr.(*block.Header)
@@ -285,6 +299,11 @@ func (boot *ShardBootstrap) getHeaderFromPoolWithNonce(nonce uint64) *block.Head
 	return header
 }
 
+// hdrNonceHashDataUnit returns the nonce to hash storage unit of the headers of the node's shard
+func (boot *ShardBootstrap) hdrNonceHashDataUnit() dataRetriever.UnitType {
+	return dataRetriever.ShardHdrNonceHashDataUnitFor(boot.shardCoordinator.SelfId())
+}
+
 // requestHeader method requests a block header from network when it is not found in the pool
 func (boot *ShardBootstrap) requestHeader(nonce uint64) {
 	boot.setRequestedHeaderNonce(&nonce)
@@ -410,6 +429,12 @@ func (boot *ShardBootstrap) rollback(header *block.Header) error {
 	if headerStore == nil {
 		return process.ErrNilHeadersStorage
 	}
+	hdrNoncesStore := boot.store.GetStorer(boot.hdrNonceHashDataUnit())
+	if hdrNoncesStore == nil {
+		return process.ErrNilHeadersNoncesStorage
+	}
+
+	headerHash := boot.blkc.GetCurrentBlockHeaderHash()
 
 	var err error
 	var newHeader *block.Header
@@ -446,18 +471,23 @@ func (boot *ShardBootstrap) rollback(header *block.Header) error {
 
 	boot.blkc.SetCurrentBlockHeaderHash(newHeaderHash)
 
-	err = boot.accounts.RecreateTrie(newRootHash)
+	err = boot.restoreState(headerHash, newRootHash)
 	if err != nil {
 		return err
 	}
 
+	errNotCritical := process.SaveLastCommittedHeaderHash(boot.store, boot.shardCoordinator.SelfId(), newHeaderHash)
+	if errNotCritical != nil {
+		log.Info(errNotCritical.Error())
+	}
+
 	body, err := boot.getTxBlockBody(header)
 	if err != nil {
 		return err
 	}
 
-	boot.cleanCachesOnRollback(header, headerStore)
-	errNotCritical := boot.blkExecutor.RestoreBlockIntoPools(nil, body)
+	boot.cleanCachesOnRollback(header, headerStore, hdrNoncesStore)
+	errNotCritical = boot.blkExecutor.RestoreBlockIntoPools(nil, body)
 	if errNotCritical != nil {
 		log.Info(errNotCritical.Error())
 	}
@@ -465,6 +495,46 @@ func (boot *ShardBootstrap) rollback(header *block.Header) error {
 	return nil
 }
 
+// restoreState brings the accounts to the state with the given root hash, which the rolled back block with the given
+// hash was built upon. The state diff persisted for the block is unwound if possible, so the trie nodes of the
+// previous state are not needed, otherwise the trie is recreated from the previous root hash
+func (boot *ShardBootstrap) restoreState(headerHash []byte, rootHash []byte) error {
+	errNotCritical := boot.unwindStateDiff(headerHash, rootHash)
+	if errNotCritical == nil {
+		return nil
+	}
+
+	log.Info(fmt.Sprintf("could not unwind the state diff of block with hash %s: %s\n",
+		core.ToB64(headerHash),
+		errNotCritical.Error()))
+
+	return boot.accounts.RecreateTrie(rootHash)
+}
+
+func (boot *ShardBootstrap) unwindStateDiff(headerHash []byte, rootHash []byte) error {
+	stateDiffStore := boot.store.GetStorer(dataRetriever.StateDiffUnit)
+	if stateDiffStore == nil {
+		return process.ErrNilStateDiffStorage
+	}
+
+	buff, err := stateDiffStore.Get(headerHash)
+	if err != nil {
+		return err
+	}
+
+	diff := &state.StateDiff{}
+	err = boot.marshalizer.Unmarshal(diff, buff)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(diff.PrevRootHash, rootHash) || !bytes.Equal(diff.RootHash, boot.accounts.RootHash()) {
+		return process.ErrStateDiffMismatch
+	}
+
+	return boot.accounts.UnwindStateDiff(diff)
+}
+
 func (boot *ShardBootstrap) getPrevHeader(headerStore storage.Storer, header *block.Header) (*block.Header, error) {
 	prevHash := header.PrevHash
 	buffHeader, err := headerStore.Get(prevHash)
@@ -14,6 +14,7 @@ import (
 	"github.com/numbatx/gn-numbat/data"
 	"github.com/numbatx/gn-numbat/data/block"
 	"github.com/numbatx/gn-numbat/data/blockchain"
+	"github.com/numbatx/gn-numbat/data/state"
 	"github.com/numbatx/gn-numbat/dataRetriever"
 	"github.com/numbatx/gn-numbat/process"
 	"github.com/numbatx/gn-numbat/process/factory"
@@ -31,6 +32,7 @@ type removedFlags struct {
 	flagHdrRemovedFromNonces       bool
 	flagHdrRemovedFromHeaders      bool
 	flagHdrRemovedFromStorage      bool
+	flagHdrRemovedFromNonceStorage bool
 	flagHdrRemovedFromForkDetector bool
 }
 
@@ -283,6 +285,7 @@ func TestNewShardBootstrap_NilPoolsHolderShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -319,6 +322,7 @@ func TestNewShardBootstrap_PoolsHolderRetNilOnHeadersShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -354,6 +358,7 @@ func TestNewShardBootstrap_PoolsHolderRetNilOnHeadersNoncesShouldErr(t *testing.
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -389,6 +394,7 @@ func TestNewShardBootstrap_PoolsHolderRetNilOnTxBlockBodyShouldErr(t *testing.T)
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -420,6 +426,7 @@ func TestNewShardBootstrap_NilStoreShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -451,6 +458,7 @@ func TestNewShardBootstrap_NilBlockchainShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -482,6 +490,7 @@ func TestNewShardBootstrap_NilRounderShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -513,6 +522,7 @@ func TestNewShardBootstrap_NilBlockProcessorShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -544,6 +554,7 @@ func TestNewShardBootstrap_NilHasherShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -575,6 +586,7 @@ func TestNewShardBootstrap_NilMarshalizerShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -606,6 +618,7 @@ func TestNewShardBootstrap_NilForkDetectorShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -638,6 +651,7 @@ func TestNewShardBootstrap_NilResolversContainerShouldErr(t *testing.T) {
 		nil,
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -669,6 +683,7 @@ func TestNewShardBootstrap_NilShardCoordinatorShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		nil,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -700,12 +715,46 @@ func TestNewShardBootstrap_NilAccountsAdapterShouldErr(t *testing.T) {
 		&mock.ResolversFinderStub{},
 		shardCoordinator,
 		nil,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
 	assert.Equal(t, process.ErrNilAccountsAdapter, err)
 }
 
+func TestNewShardBootstrap_NilUint64ConverterShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pools := createMockPools()
+	blkc := initBlockchain()
+	rnd := &mock.RounderMock{}
+	blkExec := &mock.BlockProcessorMock{}
+	forkDetector := &mock.ForkDetectorMock{}
+	hasher := &mock.HasherMock{}
+	marshalizer := &mock.MarshalizerMock{}
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	account := &mock.AccountsStub{}
+
+	bs, err := sync.NewShardBootstrap(
+		pools,
+		createStore(),
+		blkc,
+		rnd,
+		blkExec,
+		waitTime,
+		hasher,
+		marshalizer,
+		forkDetector,
+		&mock.ResolversFinderStub{},
+		shardCoordinator,
+		account,
+		nil,
+	)
+
+	assert.Nil(t, bs)
+	assert.Equal(t, process.ErrNilUint64Converter, err)
+}
+
 func TestNewShardBootstrap_NilHeaderResolverShouldErr(t *testing.T) {
 	t.Parallel()
 
@@ -749,6 +798,7 @@ func TestNewShardBootstrap_NilHeaderResolverShouldErr(t *testing.T) {
 		resFinder,
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -798,6 +848,7 @@ func TestNewShardBootstrap_NilTxBlockBodyResolverShouldErr(t *testing.T) {
 		resFinder,
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs)
@@ -862,6 +913,7 @@ func TestNewShardBootstrap_OkValsShouldWork(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.NotNil(t, bs)
@@ -927,6 +979,7 @@ func TestBootstrap_SyncBlockShouldCallForkChoice(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	r := bs.SyncBlock()
@@ -978,6 +1031,7 @@ func TestBootstrap_ShouldReturnMissingHeader(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	r := bs.SyncBlock()
@@ -1056,6 +1110,7 @@ func TestBootstrap_ShouldReturnMissingBody(t *testing.T) {
 		createMockResolversFinderNilMiniBlocks(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.RequestHeader(2)
@@ -1107,6 +1162,7 @@ func TestBootstrap_ShouldNotNeedToSync(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.StartSync()
@@ -1218,6 +1274,7 @@ func TestBootstrap_SyncShouldSyncOneBlock(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.StartSync()
@@ -1325,6 +1382,7 @@ func TestBootstrap_ShouldReturnNilErr(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	r := bs.SyncBlock()
@@ -1434,6 +1492,7 @@ func TestBootstrap_SyncBlockShouldReturnErrorWhenProcessBlockFailed(t *testing.T
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	err := bs.SyncBlock()
@@ -1473,6 +1532,7 @@ func TestBootstrap_ShouldSyncShouldReturnFalseWhenCurrentBlockIsNilAndRoundIndex
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.False(t, bs.ShouldSync())
@@ -1511,6 +1571,7 @@ func TestBootstrap_ShouldReturnTrueWhenCurrentBlockIsNilAndRoundIndexIsGreaterTh
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.True(t, bs.ShouldSync())
@@ -1554,6 +1615,7 @@ func TestBootstrap_ShouldReturnFalseWhenNodeIsSynced(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.False(t, bs.ShouldSync())
@@ -1597,6 +1659,7 @@ func TestBootstrap_ShouldReturnTrueWhenNodeIsNotSynced(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.True(t, bs.ShouldSync())
@@ -1631,6 +1694,7 @@ func TestBootstrap_GetHeaderFromPoolShouldReturnNil(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.Nil(t, bs.GetHeaderFromPoolWithNonce(0))
@@ -1693,11 +1757,85 @@ func TestBootstrap_GetHeaderFromPoolShouldReturnHeader(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	assert.True(t, hdr == bs.GetHeaderFromPoolWithNonce(0))
 }
 
+func TestBootstrap_GetHeaderFromPoolNotInPoolShouldReturnHeaderFromStorage(t *testing.T) {
+	t.Parallel()
+
+	hdr := &block.Header{Nonce: 4}
+	hdrHash := []byte("aaa")
+	nonceConverter := mock.NewNonceHashConverterMock()
+	marshalizer := &mock.MarshalizerMock{}
+	hdrBuff, _ := marshalizer.Marshal(hdr)
+
+	pools := createMockPools()
+	pools.HeadersCalled = func() storage.Cacher {
+		sds := &mock.CacherStub{}
+		sds.PeekCalled = func(key []byte) (value interface{}, ok bool) {
+			return nil, false
+		}
+		sds.RegisterHandlerCalled = func(func(key []byte)) {
+		}
+
+		return sds
+	}
+	pools.HeadersNoncesCalled = func() dataRetriever.Uint64Cacher {
+		hnc := &mock.Uint64CacherStub{}
+		hnc.RegisterHandlerCalled = func(handler func(nonce uint64)) {
+		}
+		hnc.GetCalled = func(u uint64) (i []byte, b bool) {
+			return nil, false
+		}
+
+		return hnc
+	}
+	store := &mock.ChainStorerMock{
+		GetCalled: func(unitType dataRetriever.UnitType, key []byte) ([]byte, error) {
+			if unitType == dataRetriever.ShardHdrNonceHashDataUnitFor(0) && bytes.Equal(key, nonceConverter.ToByteSlice(4)) {
+				return hdrHash, nil
+			}
+
+			return nil, errors.New("key not found")
+		},
+		GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
+			return &mock.StorerStub{
+				GetCalled: func(key []byte) ([]byte, error) {
+					if unitType == dataRetriever.BlockHeaderUnit && bytes.Equal(key, hdrHash) {
+						return hdrBuff, nil
+					}
+
+					return nil, errors.New("key not found")
+				},
+			}
+		},
+	}
+
+	rnd, _ := round.NewRound(time.Now(), time.Now(), time.Duration(100*time.Millisecond), mock.SyncTimerMock{})
+
+	bs, _ := sync.NewShardBootstrap(
+		pools,
+		store,
+		initBlockchain(),
+		rnd,
+		&mock.BlockProcessorMock{},
+		waitTime,
+		&mock.HasherMock{},
+		marshalizer,
+		&mock.ForkDetectorMock{},
+		createMockResolversFinder(),
+		mock.NewOneShardCoordinatorMock(),
+		&mock.AccountsStub{},
+		nonceConverter,
+	)
+
+	assert.Equal(t, hdr, bs.GetHeaderFromPoolWithNonce(4))
+	assert.Nil(t, bs.GetHeaderFromPoolWithNonce(5))
+}
+
 func TestShardGetBlockFromPoolShouldReturnBlock(t *testing.T) {
 	blk := make(block.MiniBlockSlice, 0)
 
@@ -1738,6 +1876,7 @@ func TestShardGetBlockFromPoolShouldReturnBlock(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	mbHashes := make([][]byte, 0)
@@ -1810,6 +1949,7 @@ func TestBootstrap_ReceivedHeadersFoundInPoolShouldAddToForkDetector(t *testing.
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.ReceivedHeaders(addedHash)
@@ -1882,6 +2022,7 @@ func TestBootstrap_ReceivedHeadersNotFoundInPoolButFoundInStorageShouldAddToFork
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.ReceivedHeaders(addedHash)
@@ -1917,6 +2058,7 @@ func TestBootstrap_ForkChoiceNilBlockchainHeaderShouldErr(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	err := bs.ForkChoice()
@@ -1949,6 +2091,7 @@ func TestBootstrap_ForkChoiceNilParamHeaderShouldErr(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
@@ -1996,6 +2139,7 @@ func TestBootstrap_ForkChoiceIsNotEmptyShouldErr(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
@@ -2014,6 +2158,7 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackOkValsShouldWork(t *testing.T) {
 
 	//retain if the remove process from different storage locations has been called
 	remFlags := &removedFlags{}
+	var removedStateDiffKey []byte
 
 	currentHdrNonce := uint64(8)
 	currentHdrHash := []byte("current header hash")
@@ -2052,8 +2197,31 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackOkValsShouldWork(t *testing.T) {
 	//a mock blockchain with special header and tx block bodies stubs (defined above)
 	blkc := &mock.BlockChainMock{}
 
+	nonceConverter := mock.NewNonceHashConverterMock()
 	store := &mock.ChainStorerMock{
 		GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
+			if unitType == dataRetriever.ShardHdrNonceHashDataUnitFor(0) {
+				return &mock.StorerStub{
+					RemoveCalled: func(key []byte) error {
+						if bytes.Equal(key, nonceConverter.ToByteSlice(currentHdrNonce)) {
+							remFlags.flagHdrRemovedFromNonceStorage = true
+						}
+						return nil
+					},
+				}
+			}
+			if unitType == dataRetriever.StateDiffUnit {
+				return &mock.StorerStub{
+					RemoveCalled: func(key []byte) error {
+						removedStateDiffKey = key
+						return nil
+					},
+					GetCalled: func(key []byte) ([]byte, error) {
+						return nil, errors.New("state diff not found")
+					},
+				}
+			}
+
 			return &mock.StorerStub{
 				GetCalled: func(key []byte) ([]byte, error) {
 					return prevHdrBytes, nil
@@ -2117,6 +2285,7 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackOkValsShouldWork(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		nonceConverter,
 	)
 
 	bs.SetForkNonce(currentHdrNonce)
@@ -2156,10 +2325,325 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackOkValsShouldWork(t *testing.T) {
 	assert.True(t, remFlags.flagHdrRemovedFromNonces)
 	assert.True(t, remFlags.flagHdrRemovedFromHeaders)
 	assert.True(t, remFlags.flagHdrRemovedFromStorage)
+	assert.True(t, remFlags.flagHdrRemovedFromNonceStorage)
 	assert.True(t, remFlags.flagHdrRemovedFromForkDetector)
 	assert.Equal(t, blkc.GetCurrentBlockHeader(), prevHdr)
 	assert.Equal(t, blkc.GetCurrentBlockBody(), prevTxBlockBody)
 	assert.Equal(t, blkc.GetCurrentBlockHeaderHash(), prevHdrHash)
+	//the state diff of the rolled back block is removed
+	assert.Equal(t, currentHdrHash, removedStateDiffKey)
+}
+
+func TestBootstrap_ForkChoiceIsEmptyCallRollBackShouldUnwindStateDiff(t *testing.T) {
+	t.Parallel()
+
+	remFlags := &removedFlags{}
+	var removedStateDiffKey []byte
+
+	currentHdrNonce := uint64(8)
+	currentHdrHash := []byte("current header hash")
+	currentRootHash := []byte("current root hash")
+
+	prevTxBlockBody := make(block.Body, 0)
+
+	prevHdrHash := []byte("prev header hash")
+	prevHdrBytes := []byte("prev header bytes")
+	prevHdrRootHash := []byte("prev header root hash")
+	prevHdr := &block.Header{
+		Signature: []byte("sig of the prev header as to be unique in this context"),
+		RootHash:  prevHdrRootHash,
+	}
+
+	stateDiffBytes := []byte("state diff bytes")
+
+	pools := createMockPools()
+	pools.HeadersCalled = func() storage.Cacher {
+		return createHeadersDataPool(currentHdrHash, remFlags)
+	}
+	pools.HeadersNoncesCalled = func() dataRetriever.Uint64Cacher {
+		return createHeadersNoncesDataPool(
+			currentHdrNonce,
+			currentHdrHash,
+			currentHdrNonce,
+			remFlags,
+		)
+	}
+
+	blkc := &mock.BlockChainMock{}
+	store := &mock.ChainStorerMock{
+		GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
+			if unitType == dataRetriever.StateDiffUnit {
+				return &mock.StorerStub{
+					RemoveCalled: func(key []byte) error {
+						removedStateDiffKey = key
+						return nil
+					},
+					GetCalled: func(key []byte) ([]byte, error) {
+						if bytes.Equal(key, currentHdrHash) {
+							return stateDiffBytes, nil
+						}
+						return nil, errors.New("state diff not found")
+					},
+				}
+			}
+
+			return &mock.StorerStub{
+				GetCalled: func(key []byte) ([]byte, error) {
+					return prevHdrBytes, nil
+				},
+				RemoveCalled: func(key []byte) error {
+					remFlags.flagHdrRemovedFromStorage = true
+					return nil
+				},
+			}
+		},
+	}
+	rnd := &mock.RounderMock{}
+	blkExec := &mock.BlockProcessorMock{
+		RestoreBlockIntoPoolsCalled: func(header data.HeaderHandler, body data.BodyHandler) error {
+			return nil
+		},
+	}
+	hasher := &mock.HasherMock{}
+	marshalizer := &mock.MarshalizerStub{
+		UnmarshalCalled: func(obj interface{}, buff []byte) error {
+			if bytes.Equal(buff, prevHdrBytes) {
+				obj.(*block.Header).Signature = prevHdr.Signature
+				obj.(*block.Header).RootHash = prevHdrRootHash
+				return nil
+			}
+			if bytes.Equal(buff, stateDiffBytes) {
+				obj.(*state.StateDiff).PrevRootHash = prevHdrRootHash
+				obj.(*state.StateDiff).RootHash = currentRootHash
+				return nil
+			}
+
+			return nil
+		},
+	}
+
+	forkDetector := createForkDetector(currentHdrNonce, remFlags)
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	rootHash := currentRootHash
+	unwindCalled := false
+	account := &mock.AccountsStub{
+		RootHashCalled: func() []byte {
+			return rootHash
+		},
+		UnwindStateDiffCalled: func(diff *state.StateDiff) error {
+			unwindCalled = true
+			rootHash = diff.PrevRootHash
+			return nil
+		},
+		RecreateTrieCalled: func(rootHash []byte) error {
+			assert.Fail(t, "trie should not have been recreated")
+			return nil
+		},
+	}
+
+	bs, _ := sync.NewShardBootstrap(
+		pools,
+		store,
+		blkc,
+		rnd,
+		blkExec,
+		waitTime,
+		hasher,
+		marshalizer,
+		forkDetector,
+		createMockResolversFinder(),
+		shardCoordinator,
+		account,
+		mock.NewNonceHashConverterMock(),
+	)
+
+	bs.SetForkNonce(currentHdrNonce)
+
+	hdr := &block.Header{
+		Nonce:    currentHdrNonce,
+		PrevHash: prevHdrHash,
+	}
+	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
+		return hdr
+	}
+	blkc.SetCurrentBlockHeaderCalled = func(handler data.HeaderHandler) error {
+		hdr = prevHdr
+		return nil
+	}
+
+	body := make(block.Body, 0)
+	blkc.GetCurrentBlockBodyCalled = func() data.BodyHandler {
+		return body
+	}
+	blkc.SetCurrentBlockBodyCalled = func(handler data.BodyHandler) error {
+		body = prevTxBlockBody
+		return nil
+	}
+
+	hdrHash := currentHdrHash
+	blkc.GetCurrentBlockHeaderHashCalled = func() []byte {
+		return hdrHash
+	}
+	blkc.SetCurrentBlockHeaderHashCalled = func(i []byte) {
+		hdrHash = i
+	}
+
+	err := bs.ForkChoice()
+	assert.Nil(t, err)
+	assert.True(t, unwindCalled)
+	assert.Equal(t, prevHdrRootHash, rootHash)
+	assert.Equal(t, prevHdr, blkc.GetCurrentBlockHeader())
+	assert.Equal(t, prevHdrHash, blkc.GetCurrentBlockHeaderHash())
+	//the state diff of the rolled back block is removed
+	assert.Equal(t, currentHdrHash, removedStateDiffKey)
+}
+
+func TestBootstrap_ForkChoiceIsEmptyCallRollBackMismatchedStateDiffShouldRecreateTrie(t *testing.T) {
+	t.Parallel()
+
+	remFlags := &removedFlags{}
+	var removedStateDiffKey []byte
+
+	currentHdrNonce := uint64(8)
+	currentHdrHash := []byte("current header hash")
+
+	prevHdrHash := []byte("prev header hash")
+	prevHdrBytes := []byte("prev header bytes")
+	prevHdrRootHash := []byte("prev header root hash")
+	prevHdr := &block.Header{
+		Signature: []byte("sig of the prev header as to be unique in this context"),
+		RootHash:  prevHdrRootHash,
+	}
+
+	stateDiffBytes := []byte("state diff bytes")
+
+	pools := createMockPools()
+	pools.HeadersCalled = func() storage.Cacher {
+		return createHeadersDataPool(currentHdrHash, remFlags)
+	}
+	pools.HeadersNoncesCalled = func() dataRetriever.Uint64Cacher {
+		return createHeadersNoncesDataPool(
+			currentHdrNonce,
+			currentHdrHash,
+			currentHdrNonce,
+			remFlags,
+		)
+	}
+
+	blkc := &mock.BlockChainMock{}
+	store := &mock.ChainStorerMock{
+		GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
+			if unitType == dataRetriever.StateDiffUnit {
+				return &mock.StorerStub{
+					RemoveCalled: func(key []byte) error {
+						removedStateDiffKey = key
+						return nil
+					},
+					GetCalled: func(key []byte) ([]byte, error) {
+						return stateDiffBytes, nil
+					},
+				}
+			}
+
+			return &mock.StorerStub{
+				GetCalled: func(key []byte) ([]byte, error) {
+					return prevHdrBytes, nil
+				},
+				RemoveCalled: func(key []byte) error {
+					remFlags.flagHdrRemovedFromStorage = true
+					return nil
+				},
+			}
+		},
+	}
+	rnd := &mock.RounderMock{}
+	blkExec := &mock.BlockProcessorMock{
+		RestoreBlockIntoPoolsCalled: func(header data.HeaderHandler, body data.BodyHandler) error {
+			return nil
+		},
+	}
+	hasher := &mock.HasherMock{}
+	marshalizer := &mock.MarshalizerStub{
+		UnmarshalCalled: func(obj interface{}, buff []byte) error {
+			if bytes.Equal(buff, prevHdrBytes) {
+				obj.(*block.Header).Signature = prevHdr.Signature
+				obj.(*block.Header).RootHash = prevHdrRootHash
+				return nil
+			}
+			if bytes.Equal(buff, stateDiffBytes) {
+				obj.(*state.StateDiff).PrevRootHash = []byte("other root hash")
+				return nil
+			}
+
+			return nil
+		},
+	}
+
+	forkDetector := createForkDetector(currentHdrNonce, remFlags)
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	var recreatedRootHash []byte
+	account := &mock.AccountsStub{
+		RootHashCalled: func() []byte {
+			return []byte("current root hash")
+		},
+		UnwindStateDiffCalled: func(diff *state.StateDiff) error {
+			assert.Fail(t, "state diff should not have been unwound")
+			return nil
+		},
+		RecreateTrieCalled: func(rootHash []byte) error {
+			recreatedRootHash = rootHash
+			return nil
+		},
+	}
+
+	bs, _ := sync.NewShardBootstrap(
+		pools,
+		store,
+		blkc,
+		rnd,
+		blkExec,
+		waitTime,
+		hasher,
+		marshalizer,
+		forkDetector,
+		createMockResolversFinder(),
+		shardCoordinator,
+		account,
+		mock.NewNonceHashConverterMock(),
+	)
+
+	bs.SetForkNonce(currentHdrNonce)
+
+	hdr := &block.Header{
+		Nonce:    currentHdrNonce,
+		PrevHash: prevHdrHash,
+	}
+	blkc.GetCurrentBlockHeaderCalled = func() data.HeaderHandler {
+		return hdr
+	}
+	blkc.SetCurrentBlockHeaderCalled = func(handler data.HeaderHandler) error {
+		hdr = prevHdr
+		return nil
+	}
+	blkc.GetCurrentBlockBodyCalled = func() data.BodyHandler {
+		return make(block.Body, 0)
+	}
+	blkc.SetCurrentBlockBodyCalled = func(handler data.BodyHandler) error {
+		return nil
+	}
+	hdrHash := currentHdrHash
+	blkc.GetCurrentBlockHeaderHashCalled = func() []byte {
+		return hdrHash
+	}
+	blkc.SetCurrentBlockHeaderHashCalled = func(i []byte) {
+		hdrHash = i
+	}
+
+	err := bs.ForkChoice()
+	assert.Nil(t, err)
+	assert.Equal(t, prevHdrRootHash, recreatedRootHash)
+	//the state diff of the rolled back block is removed
+	assert.Equal(t, currentHdrHash, removedStateDiffKey)
 }
 
 func TestBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testing.T) {
@@ -2167,6 +2651,7 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testing.T
 
 	//retain if the remove process from different storage locations has been called
 	remFlags := &removedFlags{}
+	var removedStateDiffKey []byte
 
 	currentHdrNonce := uint64(1)
 	currentHdrHash := []byte("current header hash")
@@ -2210,6 +2695,18 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testing.T
 	}
 	store := &mock.ChainStorerMock{
 		GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
+			if unitType == dataRetriever.StateDiffUnit {
+				return &mock.StorerStub{
+					RemoveCalled: func(key []byte) error {
+						removedStateDiffKey = key
+						return nil
+					},
+					GetCalled: func(key []byte) ([]byte, error) {
+						return nil, errors.New("state diff not found")
+					},
+				}
+			}
+
 			return &mock.StorerStub{
 				GetCalled: func(key []byte) ([]byte, error) {
 					return prevHdrBytes, nil
@@ -2272,6 +2769,7 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testing.T
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	bs.SetForkNonce(currentHdrNonce)
@@ -2315,6 +2813,8 @@ func TestBootstrap_ForkChoiceIsEmptyCallRollBackToGenesisShouldWork(t *testing.T
 	assert.Nil(t, blkc.GetCurrentBlockHeader())
 	assert.Nil(t, blkc.GetCurrentBlockBody())
 	assert.Nil(t, blkc.GetCurrentBlockHeaderHash())
+	//the state diff of the rolled back block is removed
+	assert.Equal(t, currentHdrHash, removedStateDiffKey)
 }
 
 //------- GetTxBodyHavingHash
@@ -2364,6 +2864,7 @@ func TestBootstrap_GetTxBodyHavingHashReturnsFromCacherShouldWork(t *testing.T)
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 	txBlockRecovered := bs.GetMiniBlocks(requestedHash)
 
@@ -2413,6 +2914,7 @@ func TestBootstrap_GetTxBodyHavingHashNotFoundInCacherOrStorageShouldRetNil(t *t
 		createMockResolversFinderNilMiniBlocks(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 	txBlockRecovered := bs.GetMiniBlocks(requestedHash)
 
@@ -2469,6 +2971,7 @@ func TestBootstrap_GetTxBodyHavingHashFoundInStorageShouldWork(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 	txBlockRecovered := bs.GetMiniBlocks(requestedHash)
 
@@ -2501,6 +3004,7 @@ func TestBootstrap_AddSyncStateListenerShouldAppendAnotherListener(t *testing.T)
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	f1 := func(bool) {}
@@ -2541,6 +3045,7 @@ func TestBootstrap_NotifySyncStateListenersShouldNotify(t *testing.T) {
 		createMockResolversFinder(),
 		shardCoordinator,
 		account,
+		mock.NewNonceHashConverterMock(),
 	)
 
 	mutex.RLock()
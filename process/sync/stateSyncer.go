@@ -0,0 +1,432 @@
+package sync
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/numbatx/gn-numbat/data"
+	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/trie"
+	"github.com/numbatx/gn-numbat/dataRetriever"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/process"
+	"github.com/numbatx/gn-numbat/process/factory"
+	"github.com/numbatx/gn-numbat/storage"
+)
+
+// trieNodeRef is a trie node to be synced, along with the hex nibbles path leading to it from its trie root. The
+// leaves of the accounts trie nodes reference the data tries roots, while the leaves of the data tries nodes are
+// plain values
+type trieNodeRef struct {
+	hash       []byte
+	path       []byte
+	isDataTrie bool
+}
+
+// StateSyncer downloads from peers the accounts state of a trusted shard block header, so a new node does not
+// have to replay all the blocks. The missing trie nodes are requested breadth-first, in batches, and each received
+// node is checked against the hash it was requested for before being written in the accounts trie storage. Once
+// the trie is complete the header becomes the current block, so the bootstrap only syncs the blocks that follow it
+type StateSyncer struct {
+	headers           storage.Cacher
+	trieNodes         storage.Cacher
+	store             dataRetriever.StorageService
+	trieStorage       storage.Storer
+	blkc              data.ChainHandler
+	hasher            hashing.Hasher
+	marshalizer       marshal.Marshalizer
+	forkDetector      process.ForkDetector
+	accounts          state.AccountsAdapter
+	hdrRes            dataRetriever.HeaderResolver
+	trieNodesRes      dataRetriever.TrieNodesResolver
+	leafRootHash      func(key []byte, leaf []byte) []byte
+	batchSize         int
+	waitTime          time.Duration
+	maxRequestRetries int
+
+	mutRequestedHeader  sync.RWMutex
+	requestedHeaderHash []byte
+
+	chRcvHdr       chan bool
+	chRcvTrieNodes chan bool
+}
+
+// NewStateSyncer creates a new StateSyncer object. The leafRootHash function returns the root hash of the data
+// trie referenced by an accounts trie leaf, or nil if the leaf does not hold an account
+func NewStateSyncer(
+	poolsHolder dataRetriever.PoolsHolder,
+	store dataRetriever.StorageService,
+	blkc data.ChainHandler,
+	hasher hashing.Hasher,
+	marshalizer marshal.Marshalizer,
+	forkDetector process.ForkDetector,
+	resolversFinder dataRetriever.ResolversFinder,
+	accounts state.AccountsAdapter,
+	leafRootHash func(key []byte, leaf []byte) []byte,
+	batchSize int,
+	waitTime time.Duration,
+	maxRequestRetries int,
+) (*StateSyncer, error) {
+
+	if poolsHolder == nil {
+		return nil, process.ErrNilPoolsHolder
+	}
+	if poolsHolder.Headers() == nil {
+		return nil, process.ErrNilHeadersDataPool
+	}
+	if poolsHolder.TrieNodes() == nil {
+		return nil, process.ErrNilTrieNodesDataPool
+	}
+	if store == nil {
+		return nil, process.ErrNilStore
+	}
+	if store.GetStorer(dataRetriever.AccountsTrieUnit) == nil {
+		return nil, process.ErrNilTrieNodesStorage
+	}
+	if blkc == nil {
+		return nil, process.ErrNilBlockChain
+	}
+	if hasher == nil {
+		return nil, process.ErrNilHasher
+	}
+	if marshalizer == nil {
+		return nil, process.ErrNilMarshalizer
+	}
+	if forkDetector == nil {
+		return nil, process.ErrNilForkDetector
+	}
+	if resolversFinder == nil {
+		return nil, process.ErrNilResolverContainer
+	}
+	if accounts == nil {
+		return nil, process.ErrNilAccountsAdapter
+	}
+	if leafRootHash == nil {
+		return nil, process.ErrNilLeafRootHashHandler
+	}
+	if batchSize <= 0 {
+		return nil, ErrInvalidBatchSize
+	}
+
+	hdrResolver, err := resolversFinder.IntraShardResolver(factory.HeadersTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	trieNodesResolver, err := resolversFinder.IntraShardResolver(factory.TrieNodesTopic)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := &StateSyncer{
+		headers:           poolsHolder.Headers(),
+		trieNodes:         poolsHolder.TrieNodes(),
+		store:             store,
+		trieStorage:       store.GetStorer(dataRetriever.AccountsTrieUnit),
+		blkc:              blkc,
+		hasher:            hasher,
+		marshalizer:       marshalizer,
+		forkDetector:      forkDetector,
+		accounts:          accounts,
+		hdrRes:            hdrResolver.(dataRetriever.HeaderResolver),
+		trieNodesRes:      trieNodesResolver.(dataRetriever.TrieNodesResolver),
+		leafRootHash:      leafRootHash,
+		batchSize:         batchSize,
+		waitTime:          waitTime,
+		maxRequestRetries: maxRequestRetries,
+		chRcvHdr:          make(chan bool, 1),
+		chRcvTrieNodes:    make(chan bool, 1),
+	}
+
+	ss.headers.RegisterHandler(ss.receivedHeader)
+	ss.trieNodes.RegisterHandler(ss.receivedTrieNode)
+
+	return ss, nil
+}
+
+func (ss *StateSyncer) receivedHeader(headerHash []byte) {
+	ss.mutRequestedHeader.RLock()
+	isRequested := bytes.Equal(headerHash, ss.requestedHeaderHash)
+	ss.mutRequestedHeader.RUnlock()
+
+	if isRequested {
+		notify(ss.chRcvHdr)
+	}
+}
+
+func (ss *StateSyncer) receivedTrieNode(hash []byte) {
+	notify(ss.chRcvTrieNodes)
+}
+
+// notify signals the channel without blocking, a pending signal being enough to wake up the waiting sync
+func notify(ch chan bool) {
+	select {
+	case ch <- true:
+	default:
+	}
+}
+
+// SyncState downloads the accounts state of the shard block header with the provided hash and makes it the
+// current block
+func (ss *StateSyncer) SyncState(headerHash []byte) error {
+	if len(headerHash) == 0 {
+		return ErrNilHash
+	}
+
+	ss.mutRequestedHeader.Lock()
+	ss.requestedHeaderHash = headerHash
+	ss.mutRequestedHeader.Unlock()
+
+	header, err := ss.getHeaderRequestingIfMissing(headerHash)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("syncing the state of block with nonce %d and root hash %s\n",
+		header.Nonce,
+		hex.EncodeToString(header.RootHash)))
+
+	err = ss.SyncTrie(header.RootHash)
+	if err != nil {
+		return err
+	}
+
+	err = ss.accounts.RecreateTrie(header.RootHash)
+	if err != nil {
+		return err
+	}
+
+	headerBuff, err := ss.marshalizer.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	err = ss.store.Put(dataRetriever.BlockHeaderUnit, headerHash, headerBuff)
+	if err != nil {
+		return err
+	}
+
+	err = ss.blkc.SetCurrentBlockHeader(header)
+	if err != nil {
+		return err
+	}
+	ss.blkc.SetCurrentBlockHeaderHash(headerHash)
+
+	err = ss.forkDetector.AddHeader(header, headerHash, process.BHProcessed)
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("state synced, starting from block with nonce %d\n", header.Nonce))
+
+	return nil
+}
+
+// getHeaderRequestingIfMissing gets the header with the provided hash from the pool, requesting it from the
+// network if it is not there. The headers pool holds the intercepted headers under their computed hash
+func (ss *StateSyncer) getHeaderRequestingIfMissing(headerHash []byte) (*block.Header, error) {
+	for retries := 0; ; retries++ {
+		value, ok := ss.headers.Peek(headerHash)
+		if ok {
+			header, ok := value.(*block.Header)
+			if !ok {
+				return nil, process.ErrWrongTypeAssertion
+			}
+
+			return header, nil
+		}
+
+		if retries == ss.maxRequestRetries {
+			return nil, process.ErrMissingHeader
+		}
+
+		emptyChannel(ss.chRcvHdr)
+		err := ss.hdrRes.RequestDataFromHash(headerHash)
+		if err != nil {
+			log.Error(err.Error())
+		}
+
+		ss.waitFor(ss.chRcvHdr)
+	}
+}
+
+// SyncTrie downloads the missing nodes of the accounts trie with the provided root hash, along with the data
+// tries of its accounts, and writes them in the accounts trie storage. The nodes already in the storage are not
+// requested again, so an interrupted sync resumes from where it stopped
+func (ss *StateSyncer) SyncTrie(rootHash []byte) error {
+	visited := make(map[string]struct{})
+	pending := make([]trieNodeRef, 0)
+	pending = ss.addPending(pending, visited, trieNodeRef{hash: rootHash})
+
+	numSynced := 0
+	for len(pending) > 0 {
+		batchSize := ss.batchSize
+		if batchSize > len(pending) {
+			batchSize = len(pending)
+		}
+		batch := pending[:batchSize]
+		pending = pending[batchSize:]
+
+		missing := make([]trieNodeRef, 0, len(batch))
+		for _, ref := range batch {
+			encodedNode, err := ss.trieStorage.Get(ref.hash)
+			if err != nil {
+				missing = append(missing, ref)
+				continue
+			}
+
+			pending, err = ss.addReferences(pending, visited, ref, encodedNode)
+			if err != nil {
+				return err
+			}
+		}
+
+		received, err := ss.requestTrieNodes(missing)
+		if err != nil {
+			return err
+		}
+
+		for _, ref := range missing {
+			encodedNode := received[string(ref.hash)]
+			err = ss.trieStorage.Put(ref.hash, encodedNode)
+			if err != nil {
+				return err
+			}
+
+			pending, err = ss.addReferences(pending, visited, ref, encodedNode)
+			if err != nil {
+				return err
+			}
+		}
+
+		numSynced += len(batch)
+		log.Debug(fmt.Sprintf("synced %d trie nodes, %d pending\n", numSynced, len(pending)))
+	}
+
+	return nil
+}
+
+// addReferences appends to the pending nodes the child nodes referenced by the encoded node and, for the accounts
+// trie, the roots of the data tries referenced by its leaves
+func (ss *StateSyncer) addReferences(
+	pending []trieNodeRef,
+	visited map[string]struct{},
+	ref trieNodeRef,
+	encodedNode []byte,
+) ([]trieNodeRef, error) {
+
+	children, leaves, err := trie.NodeReferences(ref.path, encodedNode)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		pending = ss.addPending(pending, visited, trieNodeRef{hash: child.Hash, path: child.Path, isDataTrie: ref.isDataTrie})
+	}
+
+	if ref.isDataTrie {
+		return pending, nil
+	}
+
+	for _, leaf := range leaves {
+		dataTrieRootHash := ss.leafRootHash(leaf.Key, leaf.Value)
+		if len(dataTrieRootHash) == 0 {
+			continue
+		}
+
+		pending = ss.addPending(pending, visited, trieNodeRef{hash: dataTrieRootHash, isDataTrie: true})
+	}
+
+	return pending, nil
+}
+
+func (ss *StateSyncer) addPending(pending []trieNodeRef, visited map[string]struct{}, ref trieNodeRef) []trieNodeRef {
+	isEmptyTrie := bytes.Equal(ref.hash, make([]byte, len(ref.hash))) ||
+		bytes.Equal(ref.hash, trie.GetEmptyRoot(ss.hasher).Bytes())
+	if isEmptyTrie {
+		return pending
+	}
+
+	_, isVisited := visited[string(ref.hash)]
+	if isVisited {
+		return pending
+	}
+
+	visited[string(ref.hash)] = struct{}{}
+	return append(pending, ref)
+}
+
+// requestTrieNodes requests the missing nodes until all of them are received, giving up after maxRequestRetries
+// requests in a row which did not bring any of them. A received node is only accepted if it hashes to the hash
+// it was requested for
+func (ss *StateSyncer) requestTrieNodes(missing []trieNodeRef) (map[string][]byte, error) {
+	received := make(map[string][]byte, len(missing))
+	retries := 0
+	for {
+		stillMissing := make([][]byte, 0, len(missing))
+		for _, ref := range missing {
+			if _, ok := received[string(ref.hash)]; ok {
+				continue
+			}
+
+			encodedNode, ok := ss.getTrieNodeFromPool(ref.hash)
+			if !ok {
+				stillMissing = append(stillMissing, ref.hash)
+				continue
+			}
+
+			received[string(ref.hash)] = encodedNode
+			retries = 0
+		}
+
+		if len(stillMissing) == 0 {
+			return received, nil
+		}
+		if retries == ss.maxRequestRetries {
+			return nil, ErrTrieNodesNotReceived
+		}
+
+		emptyChannel(ss.chRcvTrieNodes)
+		err := ss.trieNodesRes.RequestDataFromHashArray(stillMissing)
+		if err != nil {
+			log.Error(err.Error())
+		}
+
+		ss.waitFor(ss.chRcvTrieNodes)
+		retries++
+	}
+}
+
+func (ss *StateSyncer) getTrieNodeFromPool(hash []byte) ([]byte, bool) {
+	value, ok := ss.trieNodes.Peek(hash)
+	if !ok {
+		return nil, false
+	}
+
+	ss.trieNodes.Remove(hash)
+
+	encodedNode, ok := value.([]byte)
+	if !ok {
+		return nil, false
+	}
+
+	if !bytes.Equal(ss.hasher.Compute(string(encodedNode)), hash) {
+		log.Debug(fmt.Sprintf("received trie node does not match hash %s\n", hex.EncodeToString(hash)))
+		return nil, false
+	}
+
+	return encodedNode, true
+}
+
+// waitFor waits until new data is added in the pool signaling the channel or the wait time elapses
+func (ss *StateSyncer) waitFor(ch chan bool) {
+	select {
+	case <-ch:
+	case <-time.After(ss.waitTime):
+	}
+}
@@ -0,0 +1,474 @@
+package sync_test
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/numbatx/gn-numbat/data"
+	"github.com/numbatx/gn-numbat/data/block"
+	"github.com/numbatx/gn-numbat/data/state"
+	stateFactory "github.com/numbatx/gn-numbat/data/state/factory"
+	trieFactory "github.com/numbatx/gn-numbat/data/trie/factory"
+	"github.com/numbatx/gn-numbat/dataRetriever"
+	"github.com/numbatx/gn-numbat/process"
+	"github.com/numbatx/gn-numbat/process/factory"
+	"github.com/numbatx/gn-numbat/process/mock"
+	"github.com/numbatx/gn-numbat/process/sync"
+	"github.com/numbatx/gn-numbat/storage"
+	"github.com/numbatx/gn-numbat/storage/memorydb"
+	"github.com/stretchr/testify/assert"
+)
+
+const stateSyncWaitTime = 10 * time.Millisecond
+
+func createMemStorer() storage.Storer {
+	cache, _ := storage.NewCache(storage.LRUCache, 1000, 1)
+	persister, _ := memorydb.New()
+	storer, _ := storage.NewStorageUnit(cache, persister)
+
+	return storer
+}
+
+func createAccountsDB(t *testing.T, storer storage.Storer) *state.AccountsDB {
+	tr, err := trieFactory.NewStateTrie(trieFactory.PatriciaMerkleTrie, storer, &mock.MarshalizerMock{}, mock.HasherMock{})
+	assert.Nil(t, err)
+
+	adb, err := state.NewAccountsDB(tr, mock.HasherMock{}, &mock.MarshalizerMock{}, stateFactory.NewAccountCreator())
+	assert.Nil(t, err)
+
+	return adb
+}
+
+func createTestAddress(i int) state.AddressContainer {
+	address := make([]byte, 32)
+	address[0] = byte(i)
+	address[31] = byte(i * 3)
+
+	return state.NewAddress(address)
+}
+
+// createSourceState saves some accounts, a few of them having data, and returns the committed root hash
+func createSourceState(t *testing.T, storer storage.Storer) []byte {
+	adb := createAccountsDB(t, storer)
+	for i := 0; i < 20; i++ {
+		accountHandler, err := adb.GetAccountWithJournal(createTestAddress(i))
+		assert.Nil(t, err)
+
+		err = accountHandler.(*state.Account).SetBalanceWithJournal(big.NewInt(int64(i + 1)))
+		assert.Nil(t, err)
+
+		if i%4 == 0 {
+			accountHandler.DataTrieTracker().SaveKeyValue([]byte("key"), []byte{byte(i), 1})
+			accountHandler.DataTrieTracker().SaveKeyValue([]byte("other key"), []byte{byte(i), 2})
+			err = adb.SaveDataTrie(accountHandler)
+			assert.Nil(t, err)
+		}
+	}
+
+	rootHash, err := adb.Commit()
+	assert.Nil(t, err)
+
+	return rootHash
+}
+
+func createStateSyncerPools(trieNodes storage.Cacher) *mock.PoolsHolderStub {
+	headers, _ := storage.NewCache(storage.LRUCache, 100, 1)
+
+	return &mock.PoolsHolderStub{
+		HeadersCalled: func() storage.Cacher {
+			return headers
+		},
+		TrieNodesCalled: func() storage.Cacher {
+			return trieNodes
+		},
+	}
+}
+
+func createStateSyncerStore(trieStorer storage.Storer) *mock.ChainStorerMock {
+	return &mock.ChainStorerMock{
+		GetStorerCalled: func(unitType dataRetriever.UnitType) storage.Storer {
+			if unitType == dataRetriever.AccountsTrieUnit {
+				return trieStorer
+			}
+
+			return nil
+		},
+		PutCalled: func(unitType dataRetriever.UnitType, key []byte, value []byte) error {
+			return nil
+		},
+	}
+}
+
+func createStateSyncerResolversFinder(
+	hdrResolver dataRetriever.Resolver,
+	trieNodesResolver dataRetriever.Resolver,
+) *mock.ResolversFinderStub {
+
+	return &mock.ResolversFinderStub{
+		IntraShardResolverCalled: func(baseTopic string) (dataRetriever.Resolver, error) {
+			if strings.Contains(baseTopic, factory.HeadersTopic) {
+				return hdrResolver, nil
+			}
+			if strings.Contains(baseTopic, factory.TrieNodesTopic) {
+				return trieNodesResolver, nil
+			}
+
+			return nil, errors.New("unexpected topic")
+		},
+	}
+}
+
+// createPeerTrieNodesResolver simulates the peers serving the requested trie nodes from their storage
+func createPeerTrieNodesResolver(source storage.Storer, trieNodes storage.Cacher) *mock.TrieNodesResolverMock {
+	return &mock.TrieNodesResolverMock{
+		RequestDataFromHashArrayCalled: func(hashes [][]byte) error {
+			for _, hash := range hashes {
+				encodedNode, err := source.Get(hash)
+				if err != nil {
+					continue
+				}
+
+				trieNodes.Put(hash, encodedNode)
+			}
+
+			return nil
+		},
+	}
+}
+
+func createStateSyncer(
+	t *testing.T,
+	pools dataRetriever.PoolsHolder,
+	trieStorer storage.Storer,
+	blkc data.ChainHandler,
+	forkDetector process.ForkDetector,
+	trieNodesResolver dataRetriever.Resolver,
+	accounts state.AccountsAdapter,
+	batchSize int,
+) *sync.StateSyncer {
+
+	leafRootHash, _ := state.NewDataTrieRootHashGetter(&mock.MarshalizerMock{}, mock.HasherMock{}, stateFactory.NewAccountCreator())
+	ss, err := sync.NewStateSyncer(
+		pools,
+		createStateSyncerStore(trieStorer),
+		blkc,
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		forkDetector,
+		createStateSyncerResolversFinder(&mock.HeaderResolverMock{}, trieNodesResolver),
+		accounts,
+		leafRootHash,
+		batchSize,
+		stateSyncWaitTime,
+		3,
+	)
+	assert.Nil(t, err)
+
+	return ss
+}
+
+//------- NewStateSyncer
+
+func TestNewStateSyncer_NilTrieNodesPoolShouldErr(t *testing.T) {
+	t.Parallel()
+
+	ss, err := sync.NewStateSyncer(
+		createStateSyncerPools(nil),
+		createStateSyncerStore(createMemStorer()),
+		&mock.BlockChainMock{},
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.ForkDetectorMock{},
+		createStateSyncerResolversFinder(&mock.HeaderResolverMock{}, &mock.TrieNodesResolverMock{}),
+		&mock.AccountsStub{},
+		func(key []byte, leaf []byte) []byte { return nil },
+		10,
+		stateSyncWaitTime,
+		3,
+	)
+
+	assert.Equal(t, process.ErrNilTrieNodesDataPool, err)
+	assert.Nil(t, ss)
+}
+
+func TestNewStateSyncer_NilTrieStorageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	trieNodes, _ := storage.NewCache(storage.LRUCache, 100, 1)
+	ss, err := sync.NewStateSyncer(
+		createStateSyncerPools(trieNodes),
+		createStateSyncerStore(nil),
+		&mock.BlockChainMock{},
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.ForkDetectorMock{},
+		createStateSyncerResolversFinder(&mock.HeaderResolverMock{}, &mock.TrieNodesResolverMock{}),
+		&mock.AccountsStub{},
+		func(key []byte, leaf []byte) []byte { return nil },
+		10,
+		stateSyncWaitTime,
+		3,
+	)
+
+	assert.Equal(t, process.ErrNilTrieNodesStorage, err)
+	assert.Nil(t, ss)
+}
+
+func TestNewStateSyncer_NilLeafRootHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	trieNodes, _ := storage.NewCache(storage.LRUCache, 100, 1)
+	ss, err := sync.NewStateSyncer(
+		createStateSyncerPools(trieNodes),
+		createStateSyncerStore(createMemStorer()),
+		&mock.BlockChainMock{},
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.ForkDetectorMock{},
+		createStateSyncerResolversFinder(&mock.HeaderResolverMock{}, &mock.TrieNodesResolverMock{}),
+		&mock.AccountsStub{},
+		nil,
+		10,
+		stateSyncWaitTime,
+		3,
+	)
+
+	assert.Equal(t, process.ErrNilLeafRootHashHandler, err)
+	assert.Nil(t, ss)
+}
+
+func TestNewStateSyncer_InvalidBatchSizeShouldErr(t *testing.T) {
+	t.Parallel()
+
+	trieNodes, _ := storage.NewCache(storage.LRUCache, 100, 1)
+	ss, err := sync.NewStateSyncer(
+		createStateSyncerPools(trieNodes),
+		createStateSyncerStore(createMemStorer()),
+		&mock.BlockChainMock{},
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.ForkDetectorMock{},
+		createStateSyncerResolversFinder(&mock.HeaderResolverMock{}, &mock.TrieNodesResolverMock{}),
+		&mock.AccountsStub{},
+		func(key []byte, leaf []byte) []byte { return nil },
+		0,
+		stateSyncWaitTime,
+		3,
+	)
+
+	assert.Equal(t, sync.ErrInvalidBatchSize, err)
+	assert.Nil(t, ss)
+}
+
+//------- SyncTrie
+
+func TestStateSyncer_SyncTrieShouldDownloadTheAccountsAndDataTries(t *testing.T) {
+	t.Parallel()
+
+	source := createMemStorer()
+	rootHash := createSourceState(t, source)
+
+	trieNodes, _ := storage.NewCache(storage.LRUCache, 1000, 1)
+	destination := createMemStorer()
+	numRequests := 0
+	resolver := createPeerTrieNodesResolver(source, trieNodes)
+	requestFromPeers := resolver.RequestDataFromHashArrayCalled
+	resolver.RequestDataFromHashArrayCalled = func(hashes [][]byte) error {
+		numRequests++
+		assert.True(t, len(hashes) <= 5)
+		return requestFromPeers(hashes)
+	}
+
+	ss := createStateSyncer(
+		t,
+		createStateSyncerPools(trieNodes),
+		destination,
+		&mock.BlockChainMock{},
+		&mock.ForkDetectorMock{},
+		resolver,
+		&mock.AccountsStub{},
+		5,
+	)
+
+	err := ss.SyncTrie(rootHash)
+
+	assert.Nil(t, err)
+	assert.True(t, numRequests > 1)
+
+	adb := createAccountsDB(t, destination)
+	err = adb.RecreateTrie(rootHash)
+	assert.Nil(t, err)
+	for i := 0; i < 20; i++ {
+		accountHandler, err := adb.GetExistingAccount(createTestAddress(i))
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(int64(i+1)), accountHandler.(*state.Account).Balance)
+
+		if i%4 == 0 {
+			value, err := accountHandler.DataTrieTracker().RetrieveValue([]byte("other key"))
+			assert.Nil(t, err)
+			assert.Equal(t, []byte{byte(i), 2}, value)
+		}
+	}
+}
+
+func TestStateSyncer_SyncTrieNodesAlreadyStoredShouldNotBeRequested(t *testing.T) {
+	t.Parallel()
+
+	storer := createMemStorer()
+	rootHash := createSourceState(t, storer)
+
+	trieNodes, _ := storage.NewCache(storage.LRUCache, 1000, 1)
+	ss := createStateSyncer(
+		t,
+		createStateSyncerPools(trieNodes),
+		storer,
+		&mock.BlockChainMock{},
+		&mock.ForkDetectorMock{},
+		&mock.TrieNodesResolverMock{
+			RequestDataFromHashArrayCalled: func(hashes [][]byte) error {
+				assert.Fail(t, "no trie node should have been requested")
+				return nil
+			},
+		},
+		&mock.AccountsStub{},
+		5,
+	)
+
+	err := ss.SyncTrie(rootHash)
+
+	assert.Nil(t, err)
+}
+
+func TestStateSyncer_SyncTrieNodeNotMatchingItsHashShouldErr(t *testing.T) {
+	t.Parallel()
+
+	source := createMemStorer()
+	rootHash := createSourceState(t, source)
+
+	trieNodes, _ := storage.NewCache(storage.LRUCache, 1000, 1)
+	destination := createMemStorer()
+	ss := createStateSyncer(
+		t,
+		createStateSyncerPools(trieNodes),
+		destination,
+		&mock.BlockChainMock{},
+		&mock.ForkDetectorMock{},
+		&mock.TrieNodesResolverMock{
+			RequestDataFromHashArrayCalled: func(hashes [][]byte) error {
+				for _, hash := range hashes {
+					trieNodes.Put(hash, []byte("tampered node"))
+				}
+
+				return nil
+			},
+		},
+		&mock.AccountsStub{},
+		5,
+	)
+
+	err := ss.SyncTrie(rootHash)
+
+	assert.Equal(t, sync.ErrTrieNodesNotReceived, err)
+	assert.NotNil(t, destination.Has(rootHash))
+}
+
+//------- SyncState
+
+func TestStateSyncer_SyncStateShouldSetTheTrustedHeaderAsCurrentBlock(t *testing.T) {
+	t.Parallel()
+
+	source := createMemStorer()
+	rootHash := createSourceState(t, source)
+
+	trieNodes, _ := storage.NewCache(storage.LRUCache, 1000, 1)
+	pools := createStateSyncerPools(trieNodes)
+	header := &block.Header{Nonce: 7, RootHash: rootHash}
+	headerHash := []byte("header hash")
+	pools.Headers().Put(headerHash, header)
+
+	var currentHeader data.HeaderHandler
+	var currentHeaderHash []byte
+	blkc := &mock.BlockChainMock{
+		SetCurrentBlockHeaderCalled: func(handler data.HeaderHandler) error {
+			currentHeader = handler
+			return nil
+		},
+		SetCurrentBlockHeaderHashCalled: func(hash []byte) {
+			currentHeaderHash = hash
+		},
+	}
+	var processedHash []byte
+	forkDetector := &mock.ForkDetectorMock{
+		AddHeaderCalled: func(header data.HeaderHandler, hash []byte, state process.BlockHeaderState) error {
+			if state == process.BHProcessed {
+				processedHash = hash
+			}
+			return nil
+		},
+	}
+	var recreatedRootHash []byte
+	accounts := &mock.AccountsStub{
+		RecreateTrieCalled: func(rootHash []byte) error {
+			recreatedRootHash = rootHash
+			return nil
+		},
+	}
+
+	ss := createStateSyncer(
+		t,
+		pools,
+		createMemStorer(),
+		blkc,
+		forkDetector,
+		createPeerTrieNodesResolver(source, trieNodes),
+		accounts,
+		10,
+	)
+
+	err := ss.SyncState(headerHash)
+
+	assert.Nil(t, err)
+	assert.Equal(t, rootHash, recreatedRootHash)
+	assert.Equal(t, header, currentHeader)
+	assert.Equal(t, headerHash, currentHeaderHash)
+	assert.Equal(t, headerHash, processedHash)
+}
+
+func TestStateSyncer_SyncStateMissingHeaderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	trieNodes, _ := storage.NewCache(storage.LRUCache, 1000, 1)
+	pools := createStateSyncerPools(trieNodes)
+	numRequests := 0
+	leafRootHash, _ := state.NewDataTrieRootHashGetter(&mock.MarshalizerMock{}, mock.HasherMock{}, stateFactory.NewAccountCreator())
+	ss, _ := sync.NewStateSyncer(
+		pools,
+		createStateSyncerStore(createMemStorer()),
+		&mock.BlockChainMock{},
+		mock.HasherMock{},
+		&mock.MarshalizerMock{},
+		&mock.ForkDetectorMock{},
+		createStateSyncerResolversFinder(
+			&mock.HeaderResolverMock{
+				RequestDataFromHashCalled: func(hash []byte) error {
+					numRequests++
+					return nil
+				},
+			},
+			&mock.TrieNodesResolverMock{},
+		),
+		&mock.AccountsStub{},
+		leafRootHash,
+		10,
+		stateSyncWaitTime,
+		3,
+	)
+
+	err := ss.SyncState([]byte("header hash"))
+
+	assert.Equal(t, process.ErrMissingHeader, err)
+	assert.Equal(t, 3, numRequests)
+}
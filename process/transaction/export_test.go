@@ -22,14 +22,18 @@ func (txProc *txProcessor) CallSCHandler(tx *transaction.Transaction) error {
 	return txProc.callSCHandler(tx)
 }
 
-func (txProc *txProcessor) CheckTxValues(acntSrc *state.Account, value *big.Int, nonce uint64) error {
-	return txProc.checkTxValues(acntSrc, value, nonce)
+func (txProc *txProcessor) CheckTxValues(acntSrc *state.Account, value *big.Int, fee *big.Int, nonce uint64) error {
+	return txProc.checkTxValues(acntSrc, value, fee, nonce)
 }
 
 func (txProc *txProcessor) MoveBalances(acntSrc, acntDst *state.Account, value *big.Int) error {
 	return txProc.moveBalances(acntSrc, acntDst, value)
 }
 
+func (txProc *txProcessor) ChargeFee(acntSrc *state.Account, fee *big.Int) error {
+	return txProc.chargeFee(acntSrc, fee)
+}
+
 func (txProc *txProcessor) IncreaseNonce(acntSrc *state.Account) error {
 	return txProc.increaseNonce(acntSrc)
 }
@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"bytes"
 	"math/big"
 
 	"github.com/numbatx/gn-numbat/crypto"
@@ -24,6 +25,7 @@ type InterceptedTransaction struct {
 	hash                     []byte
 	rcvShard                 uint32
 	sndShard                 uint32
+	rcvShards                []uint32
 	isAddressedToOtherShards bool
 }
 
@@ -115,14 +117,45 @@ func (inTx *InterceptedTransaction) processFields(txBuffWithSig []byte) ([]byte,
 
 	inTx.rcvShard = inTx.coordinator.ComputeId(rcvAddr)
 	inTx.sndShard = inTx.coordinator.ComputeId(sndAddr)
+	inTx.rcvShards = []uint32{inTx.rcvShard}
 
-	inTx.isAddressedToOtherShards = inTx.rcvShard != inTx.coordinator.SelfId() &&
-		inTx.sndShard != inTx.coordinator.SelfId()
+	if inTx.tx.IsBatchTransfer() {
+		err = inTx.processTransfers()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inTx.isAddressedToOtherShards = inTx.sndShard != inTx.coordinator.SelfId()
+	for _, shardId := range inTx.rcvShards {
+		if shardId == inTx.coordinator.SelfId() {
+			inTx.isAddressedToOtherShards = false
+		}
+	}
 
 	return buffCopiedTx, nil
 }
 
-// integrity checks for not nil fields and negative value
+// processTransfers checks the receivers of a batch transfer and adds their shards to the receiver shards of the
+// transaction. The batch transfer itself is addressed to its sender
+func (inTx *InterceptedTransaction) processTransfers() error {
+	for _, transfer := range inTx.tx.Transfers {
+		_, err := inTx.addrConv.CreateAddressFromPublicKeyBytes(transfer.RcvAddr)
+		if err != nil {
+			return process.ErrInvalidRcvAddr
+		}
+	}
+
+	for _, shardId := range process.BatchTransferShards(inTx.tx, inTx.coordinator) {
+		if shardId != inTx.rcvShard {
+			inTx.rcvShards = append(inTx.rcvShards, shardId)
+		}
+	}
+
+	return nil
+}
+
+// integrity checks for not nil fields, negative value, inconsistent validity window and malformed batch transfers
 func (inTx *InterceptedTransaction) integrity() error {
 	if inTx.tx.Signature == nil {
 		return process.ErrNilSignature
@@ -144,6 +177,46 @@ func (inTx *InterceptedTransaction) integrity() error {
 		return process.ErrNegativeValue
 	}
 
+	if inTx.tx.ValidUntilRound != 0 && inTx.tx.ValidFromRound > inTx.tx.ValidUntilRound {
+		return process.ErrInvalidValidityWindow
+	}
+
+	if inTx.tx.IsBatchTransfer() {
+		return inTx.batchTransferIntegrity()
+	}
+
+	return nil
+}
+
+// batchTransferIntegrity checks that a batch transfer is addressed to its sender, carries no value of its own and
+// holds a limited number of well formed transfers
+func (inTx *InterceptedTransaction) batchTransferIntegrity() error {
+	if !bytes.Equal(inTx.tx.RcvAddr, inTx.tx.SndAddr) {
+		return process.ErrInvalidBatchTransferReceiver
+	}
+
+	if inTx.tx.Value.Sign() != 0 {
+		return process.ErrBatchTransferWithValue
+	}
+
+	if len(inTx.tx.Transfers) > process.MaxTransfersInBatch {
+		return process.ErrTooManyTransfersInBatch
+	}
+
+	for _, transfer := range inTx.tx.Transfers {
+		if transfer.RcvAddr == nil {
+			return process.ErrNilRcvAddr
+		}
+
+		if transfer.Value == nil {
+			return process.ErrNilValue
+		}
+
+		if transfer.Value.Cmp(big.NewInt(0)) < 0 {
+			return process.ErrNegativeValue
+		}
+	}
+
 	return nil
 }
 
@@ -167,6 +240,12 @@ func (inTx *InterceptedTransaction) RcvShard() uint32 {
 	return inTx.rcvShard
 }
 
+// RcvShards returns the shards the transaction is addressed to. A batch transfer is addressed to the shard of its
+// sender and to the shards of all its receivers
+func (inTx *InterceptedTransaction) RcvShards() []uint32 {
+	return inTx.rcvShards
+}
+
 // SndShard returns the sender shard
 func (inTx *InterceptedTransaction) SndShard() uint32 {
 	return inTx.sndShard
@@ -447,6 +447,100 @@ func TestNewInterceptedTransaction_OkValsGettersShouldWork(t *testing.T) {
 
 	assert.Equal(t, senderShard, txi.SndShard())
 	assert.Equal(t, recvShard, txi.RcvShard())
+	assert.Equal(t, []uint32{recvShard}, txi.RcvShards())
 	assert.True(t, txi.IsAddressedToOtherShards())
 	assert.Equal(t, tx, txi.Transaction())
 }
+
+func createSignedBatchTransferTx() *dataTransaction.Transaction {
+	return &dataTransaction.Transaction{
+		Nonce:     1,
+		Value:     big.NewInt(0),
+		GasLimit:  3,
+		GasPrice:  4,
+		RcvAddr:   senderAddress,
+		SndAddr:   senderAddress,
+		Signature: sigOk,
+		Transfers: []dataTransaction.Transfer{
+			{RcvAddr: recvAddress, Value: big.NewInt(2)},
+			{RcvAddr: []byte("other receiver"), Value: big.NewInt(3)},
+		},
+	}
+}
+
+func TestNewInterceptedTransaction_BatchTransferNotAddressedToSenderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tx := createSignedBatchTransferTx()
+	tx.RcvAddr = recvAddress
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrInvalidBatchTransferReceiver, err)
+}
+
+func TestNewInterceptedTransaction_BatchTransferWithValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tx := createSignedBatchTransferTx()
+	tx.Value = big.NewInt(1)
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrBatchTransferWithValue, err)
+}
+
+func TestNewInterceptedTransaction_BatchTransferTooManyTransfersShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tx := createSignedBatchTransferTx()
+	tx.Transfers = make([]dataTransaction.Transfer, process.MaxTransfersInBatch+1)
+	for i := range tx.Transfers {
+		tx.Transfers[i] = dataTransaction.Transfer{RcvAddr: recvAddress, Value: big.NewInt(1)}
+	}
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrTooManyTransfersInBatch, err)
+}
+
+func TestNewInterceptedTransaction_BatchTransferNilTransferValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tx := createSignedBatchTransferTx()
+	tx.Transfers[1].Value = nil
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrNilValue, err)
+}
+
+func TestNewInterceptedTransaction_BatchTransferNegativeTransferValueShouldErr(t *testing.T) {
+	t.Parallel()
+
+	tx := createSignedBatchTransferTx()
+	tx.Transfers[1].Value = big.NewInt(-1)
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, txi)
+	assert.Equal(t, process.ErrNegativeValue, err)
+}
+
+func TestNewInterceptedTransaction_BatchTransferGettersShouldWork(t *testing.T) {
+	t.Parallel()
+
+	tx := createSignedBatchTransferTx()
+
+	txi, err := createInterceptedTxFromPlainTx(tx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, senderShard, txi.SndShard())
+	assert.Equal(t, senderShard, txi.RcvShard())
+	assert.Equal(t, []uint32{senderShard, recvShard, 6}, txi.RcvShards())
+	assert.False(t, txi.IsAddressedToOtherShards())
+}
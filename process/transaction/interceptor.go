@@ -1,6 +1,9 @@
 package transaction
 
 import (
+	"bytes"
+
+	"github.com/numbatx/gn-numbat/consensus"
 	"github.com/numbatx/gn-numbat/crypto"
 	"github.com/numbatx/gn-numbat/data/state"
 	"github.com/numbatx/gn-numbat/dataRetriever"
@@ -22,6 +25,9 @@ type TxInterceptor struct {
 	singleSigner             crypto.SingleSigner
 	keyGen                   crypto.KeyGenerator
 	shardCoordinator         sharding.Coordinator
+	rounder                  consensus.Rounder
+	requestedTxs             storage.Cacher
+	chainID                  []byte
 	broadcastCallbackHandler func(buffToSend []byte)
 }
 
@@ -35,6 +41,9 @@ func NewTxInterceptor(
 	singleSigner crypto.SingleSigner,
 	keyGen crypto.KeyGenerator,
 	shardCoordinator sharding.Coordinator,
+	rounder consensus.Rounder,
+	requestedTxs storage.Cacher,
+	chainID []byte,
 ) (*TxInterceptor, error) {
 
 	if marshalizer == nil {
@@ -61,6 +70,15 @@ func NewTxInterceptor(
 	if shardCoordinator == nil {
 		return nil, process.ErrNilShardCoordinator
 	}
+	if rounder == nil {
+		return nil, process.ErrNilRounder
+	}
+	if requestedTxs == nil {
+		return nil, process.ErrNilRequestedTxsCache
+	}
+	if len(chainID) == 0 {
+		return nil, process.ErrNilChainID
+	}
 
 	txIntercept := &TxInterceptor{
 		marshalizer:      marshalizer,
@@ -71,6 +89,9 @@ func NewTxInterceptor(
 		singleSigner:     singleSigner,
 		keyGen:           keyGen,
 		shardCoordinator: shardCoordinator,
+		rounder:          rounder,
+		requestedTxs:     requestedTxs,
+		chainID:          chainID,
 	}
 
 	return txIntercept, nil
@@ -113,6 +134,20 @@ func (txi *TxInterceptor) ProcessReceivedMessage(message p2p.MessageP2P) error {
 			continue
 		}
 
+		// transactions signed for other chains are rejected so they can not be replayed on this one
+		if !bytes.Equal(txIntercepted.Transaction().ChainID, txi.chainID) {
+			lastErrEncountered = process.ErrInvalidChainID
+			continue
+		}
+
+		if txi.shouldCheckValidityWindow(txIntercepted) {
+			err = process.CheckTxValidityWindow(txIntercepted.Transaction(), roundToCheck(txi.rounder.Index()))
+			if err != nil {
+				lastErrEncountered = err
+				continue
+			}
+		}
+
 		//tx is validated, add it to filtered out txs
 		filteredTxsBuffs = append(filteredTxsBuffs, txBuff)
 		if txIntercepted.IsAddressedToOtherShards() {
@@ -140,6 +175,17 @@ func (txi *TxInterceptor) ProcessReceivedMessage(message p2p.MessageP2P) error {
 	return lastErrEncountered
 }
 
+// shouldCheckValidityWindow returns true for the gossiped transactions this shard will execute. The transactions sent
+// from other shards, or requested for a block, may already be in a block of an earlier round, so they are let through
+// and the window is checked at the round of the block processing them
+func (txi *TxInterceptor) shouldCheckValidityWindow(tx *InterceptedTransaction) bool {
+	if tx.SndShard() != txi.shardCoordinator.SelfId() {
+		return false
+	}
+
+	return !txi.requestedTxs.Has(tx.Hash())
+}
+
 // SetBroadcastCallback sets the callback method to send filtered out message
 func (txi *TxInterceptor) SetBroadcastCallback(callback func(buffToSend []byte)) {
 	txi.broadcastCallbackHandler = callback
@@ -153,10 +199,19 @@ func (txi *TxInterceptor) processTransaction(tx *InterceptedTransaction) {
 		return
 	}
 
-	cacherIdentifier := process.ShardCacherIdentifier(tx.SndShard(), tx.RcvShard())
-	txi.txPool.AddData(
-		tx.Hash(),
-		tx.Transaction(),
-		cacherIdentifier,
-	)
+	// a batch transfer is stored for every destination shard as it is carried by a miniblock towards each of them.
+	// Only the caches this shard sends from, or receives into, are used
+	selfId := txi.shardCoordinator.SelfId()
+	for _, rcvShard := range tx.RcvShards() {
+		if tx.SndShard() != selfId && rcvShard != selfId {
+			continue
+		}
+
+		cacherIdentifier := process.ShardCacherIdentifier(tx.SndShard(), rcvShard)
+		txi.txPool.AddData(
+			tx.Hash(),
+			tx.Transaction(),
+			cacherIdentifier,
+		)
+	}
 }
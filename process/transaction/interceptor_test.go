@@ -17,6 +17,21 @@ import (
 )
 
 var durTimeout = time.Duration(time.Second)
+var chainID = []byte("chain ID")
+
+func createRequestedTxs(requestedHashes ...[]byte) *mock.CacherStub {
+	return &mock.CacherStub{
+		HasCalled: func(key []byte) bool {
+			for _, hash := range requestedHashes {
+				if bytes.Equal(hash, key) {
+					return true
+				}
+			}
+
+			return false
+		},
+	}
+}
 
 //------- NewTxInterceptor
 
@@ -38,7 +53,10 @@ func TestNewTxInterceptor_NilMarshalizerShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	assert.Equal(t, process.ErrNilMarshalizer, err)
 	assert.Nil(t, txi)
@@ -61,7 +79,10 @@ func TestNewTxInterceptor_NilTransactionPoolShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	assert.Equal(t, process.ErrNilTxDataPool, err)
 	assert.Nil(t, txi)
@@ -84,7 +105,10 @@ func TestNewTxInterceptor_NilStorerShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	assert.Equal(t, process.ErrNilTxStorage, err)
 	assert.Nil(t, txi)
@@ -107,7 +131,10 @@ func TestNewTxInterceptor_NilAddressConverterShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	assert.Equal(t, process.ErrNilAddressConverter, err)
 	assert.Nil(t, txi)
@@ -131,7 +158,10 @@ func TestNewTxInterceptor_NilHasherShouldErr(t *testing.T) {
 		nil,
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	assert.Equal(t, process.ErrNilHasher, err)
 	assert.Nil(t, txi)
@@ -154,7 +184,10 @@ func TestNewTxInterceptor_NilSignerShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		nil,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	assert.Equal(t, process.ErrNilSingleSigner, err)
 	assert.Nil(t, txi)
@@ -177,7 +210,10 @@ func TestNewTxInterceptor_NilKeyGenShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		nil,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	assert.Equal(t, process.ErrNilKeyGen, err)
 	assert.Nil(t, txi)
@@ -200,12 +236,93 @@ func TestNewTxInterceptor_NilShardCoordinatorShouldErr(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		nil)
+		nil,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	assert.Equal(t, process.ErrNilShardCoordinator, err)
 	assert.Nil(t, txi)
 }
 
+func TestNewTxInterceptor_NilRounderShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txPool := &mock.ShardedDataStub{}
+	addrConv := &mock.AddressConverterMock{}
+	keyGen := &mock.SingleSignKeyGenMock{}
+	storer := &mock.StorerStub{}
+	signer := &mock.SignerMock{}
+
+	txi, err := transaction.NewTxInterceptor(
+		&mock.MarshalizerMock{},
+		txPool,
+		storer,
+		addrConv,
+		mock.HasherMock{},
+		signer,
+		keyGen,
+		mock.NewOneShardCoordinatorMock(),
+		nil,
+		createRequestedTxs(),
+		chainID)
+
+	assert.Equal(t, process.ErrNilRounder, err)
+	assert.Nil(t, txi)
+}
+
+func TestNewTxInterceptor_NilRequestedTxsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txPool := &mock.ShardedDataStub{}
+	addrConv := &mock.AddressConverterMock{}
+	keyGen := &mock.SingleSignKeyGenMock{}
+	storer := &mock.StorerStub{}
+	signer := &mock.SignerMock{}
+
+	txi, err := transaction.NewTxInterceptor(
+		&mock.MarshalizerMock{},
+		txPool,
+		storer,
+		addrConv,
+		mock.HasherMock{},
+		signer,
+		keyGen,
+		mock.NewOneShardCoordinatorMock(),
+		&mock.RounderMock{},
+		nil,
+		chainID)
+
+	assert.Equal(t, process.ErrNilRequestedTxsCache, err)
+	assert.Nil(t, txi)
+}
+
+func TestNewTxInterceptor_NilChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txPool := &mock.ShardedDataStub{}
+	addrConv := &mock.AddressConverterMock{}
+	keyGen := &mock.SingleSignKeyGenMock{}
+	storer := &mock.StorerStub{}
+	signer := &mock.SignerMock{}
+
+	txi, err := transaction.NewTxInterceptor(
+		&mock.MarshalizerMock{},
+		txPool,
+		storer,
+		addrConv,
+		mock.HasherMock{},
+		signer,
+		keyGen,
+		mock.NewOneShardCoordinatorMock(),
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		nil)
+
+	assert.Equal(t, process.ErrNilChainID, err)
+	assert.Nil(t, txi)
+}
+
 func TestNewTxInterceptor_OkValsShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -224,7 +341,10 @@ func TestNewTxInterceptor_OkValsShouldWork(t *testing.T) {
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	assert.Nil(t, err)
 	assert.NotNil(t, txi)
@@ -250,7 +370,10 @@ func TestTransactionInterceptor_ProcessReceivedMessageNilMesssageShouldErr(t *te
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	err := txi.ProcessReceivedMessage(nil)
 
@@ -275,7 +398,10 @@ func TestTransactionInterceptor_ProcessReceivedMessageMilMessageDataShouldErr(t
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	msg := &mock.P2PMessageMock{}
 
@@ -308,7 +434,10 @@ func TestTransactionInterceptor_ProcessReceivedMessageMarshalizerFailsAtUnmarsha
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	msg := &mock.P2PMessageMock{
 		DataField: make([]byte, 0),
@@ -344,7 +473,10 @@ func TestTransactionInterceptor_ProcessReceivedMessageNoTransactionInMessageShou
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	msg := &mock.P2PMessageMock{
 		DataField: make([]byte, 0),
@@ -375,7 +507,10 @@ func TestTransactionInterceptor_ProcessReceivedMessageIntegrityFailedShouldErr(t
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	txNewer := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -385,6 +520,7 @@ func TestTransactionInterceptor_ProcessReceivedMessageIntegrityFailedShouldErr(t
 		GasPrice:  4,
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
+		ChainID:   chainID,
 		Signature: nil,
 	}
 	txNewerBuff, _ := marshalizer.Marshal(txNewer)
@@ -433,7 +569,10 @@ func TestTransactionInterceptor_ProcessReceivedMessageIntegrityFailedWithTwoTxsS
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	tx1 := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -443,6 +582,7 @@ func TestTransactionInterceptor_ProcessReceivedMessageIntegrityFailedWithTwoTxsS
 		GasPrice:  4,
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
+		ChainID:   chainID,
 		Signature: nil,
 	}
 	tx1Buff, _ := marshalizer.Marshal(tx1)
@@ -455,6 +595,7 @@ func TestTransactionInterceptor_ProcessReceivedMessageIntegrityFailedWithTwoTxsS
 		GasPrice:  4,
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
+		ChainID:   chainID,
 		Signature: sigOk,
 	}
 	tx2Buff, _ := marshalizer.Marshal(tx2)
@@ -510,7 +651,10 @@ func TestTransactionInterceptor_ProcessReceivedMessageVerifySigFailsShouldErr(t
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	txNewer := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -520,6 +664,7 @@ func TestTransactionInterceptor_ProcessReceivedMessageVerifySigFailsShouldErr(t
 		GasPrice:  4,
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
+		ChainID:   chainID,
 		Signature: sigOk,
 	}
 	txNewerBuff, _ := marshalizer.Marshal(txNewer)
@@ -567,7 +712,10 @@ func TestTransactionInterceptor_ProcessReceivedMessageOkValsSameShardShouldWork(
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		oneSharder)
+		oneSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	txNewer := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -577,6 +725,7 @@ func TestTransactionInterceptor_ProcessReceivedMessageOkValsSameShardShouldWork(
 		GasPrice:  4,
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
+		ChainID:   chainID,
 		Signature: sigOk,
 	}
 	txNewerBuff, _ := marshalizer.Marshal(txNewer)
@@ -636,7 +785,10 @@ func TestTransactionInterceptor_ProcessReceivedMessageOkValsOtherShardsShouldWor
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		multiSharder)
+		multiSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	txNewer := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -646,6 +798,7 @@ func TestTransactionInterceptor_ProcessReceivedMessageOkValsOtherShardsShouldWor
 		GasPrice:  4,
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
+		ChainID:   chainID,
 		Signature: sigOk,
 	}
 	txNewerBuff, _ := marshalizer.Marshal(txNewer)
@@ -671,6 +824,381 @@ func TestTransactionInterceptor_ProcessReceivedMessageOkValsOtherShardsShouldWor
 	}
 }
 
+func TestTransactionInterceptor_ProcessReceivedMessageBatchTransferShouldAddForEveryReceiverShard(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	txPool := &mock.ShardedDataStub{}
+	addrConv := &mock.AddressConverterMock{}
+	pubKey := &mock.SingleSignPublicKey{}
+	keyGen := &mock.SingleSignKeyGenMock{}
+	keyGen.PublicKeyFromByteArrayCalled = func(b []byte) (key crypto.PublicKey, e error) {
+		return pubKey, nil
+	}
+
+	multiSharder := mock.NewMultiShardsCoordinatorMock(3)
+	multiSharder.CurrentShard = 0
+	multiSharder.ComputeIdCalled = func(address state.AddressContainer) uint32 {
+		if bytes.Equal(address.Bytes(), recvAddress) {
+			return 2
+		}
+
+		return 0
+	}
+	storer := &mock.StorerStub{}
+	storer.HasCalled = func(key []byte) error {
+		return errors.New("Key not found")
+	}
+	signer := &mock.SignerMock{
+		VerifyStub: func(public crypto.PublicKey, msg []byte, sig []byte) error {
+			return nil
+		},
+	}
+
+	txi, _ := transaction.NewTxInterceptor(
+		marshalizer,
+		txPool,
+		storer,
+		addrConv,
+		mock.HasherMock{},
+		signer,
+		keyGen,
+		multiSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
+
+	tx := &dataTransaction.Transaction{
+		Nonce:     1,
+		Value:     big.NewInt(0),
+		GasLimit:  3,
+		GasPrice:  4,
+		RcvAddr:   senderAddress,
+		SndAddr:   senderAddress,
+		ChainID:   chainID,
+		Signature: sigOk,
+		Transfers: []dataTransaction.Transfer{
+			{RcvAddr: recvAddress, Value: big.NewInt(2)},
+			{RcvAddr: senderAddress, Value: big.NewInt(3)},
+		},
+	}
+	txBuff, _ := marshalizer.Marshal(tx)
+	buff, _ := marshalizer.Marshal([][]byte{txBuff})
+	msg := &mock.P2PMessageMock{
+		DataField: buff,
+	}
+
+	chanCacheIds := make(chan string, 10)
+	txPool.AddDataCalled = func(key []byte, data interface{}, cacheId string) {
+		if bytes.Equal(mock.HasherMock{}.Compute(string(txBuff)), key) {
+			chanCacheIds <- cacheId
+		}
+	}
+
+	err := txi.ProcessReceivedMessage(msg)
+
+	assert.Nil(t, err)
+	cacheIds := make([]string, 0)
+	for len(cacheIds) < 2 {
+		select {
+		case cacheId := <-chanCacheIds:
+			cacheIds = append(cacheIds, cacheId)
+		case <-time.After(durTimeout):
+			assert.Fail(t, "timeout while waiting for tx to be inserted in the pool")
+			return
+		}
+	}
+	assert.Equal(t, []string{process.ShardCacherIdentifier(0, 0), process.ShardCacherIdentifier(0, 2)}, cacheIds)
+}
+
+func TestTransactionInterceptor_ProcessReceivedMessageExpiredTxShouldErr(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	txPool := &mock.ShardedDataStub{}
+	addrConv := &mock.AddressConverterMock{}
+
+	pubKey := &mock.SingleSignPublicKey{}
+	keyGen := &mock.SingleSignKeyGenMock{}
+	keyGen.PublicKeyFromByteArrayCalled = func(b []byte) (key crypto.PublicKey, e error) {
+		return pubKey, nil
+	}
+
+	storer := &mock.StorerStub{}
+	storer.HasCalled = func(key []byte) error {
+		return errors.New("Key not found")
+	}
+	signer := &mock.SignerMock{
+		VerifyStub: func(public crypto.PublicKey, msg []byte, sig []byte) error {
+			return nil
+		},
+	}
+
+	txi, _ := transaction.NewTxInterceptor(
+		marshalizer,
+		txPool,
+		storer,
+		addrConv,
+		mock.HasherMock{},
+		signer,
+		keyGen,
+		mock.NewOneShardCoordinatorMock(),
+		&mock.RounderMock{RoundIndex: 20},
+		createRequestedTxs(),
+		chainID)
+
+	txNewer := &dataTransaction.Transaction{
+		Nonce:           1,
+		Value:           big.NewInt(2),
+		Data:            []byte("data"),
+		GasLimit:        3,
+		GasPrice:        4,
+		RcvAddr:         recvAddress,
+		SndAddr:         senderAddress,
+		ChainID:         chainID,
+		Signature:       sigOk,
+		ValidFromRound:  5,
+		ValidUntilRound: 19,
+	}
+	txNewerBuff, _ := marshalizer.Marshal(txNewer)
+
+	buff, _ := marshalizer.Marshal([][]byte{txNewerBuff})
+	msg := &mock.P2PMessageMock{
+		DataField: buff,
+	}
+
+	txPool.AddDataCalled = func(key []byte, data interface{}, cacheId string) {
+		assert.Fail(t, "the transaction should not have been added in the pool")
+	}
+
+	err := txi.ProcessReceivedMessage(msg)
+
+	assert.Equal(t, process.ErrTxExpired, err)
+}
+
+func TestTransactionInterceptor_ProcessReceivedMessageExpiredRequestedTxShouldAdd(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	chanDone := make(chan struct{}, 10)
+	txPool := &mock.ShardedDataStub{}
+	addrConv := &mock.AddressConverterMock{}
+	keyGen := &mock.SingleSignKeyGenMock{
+		PublicKeyFromByteArrayCalled: func(b []byte) (key crypto.PublicKey, e error) {
+			return &mock.SingleSignPublicKey{}, nil
+		},
+	}
+	storer := &mock.StorerStub{
+		HasCalled: func(key []byte) error {
+			return errors.New("Key not found")
+		},
+	}
+	signer := &mock.SignerMock{
+		VerifyStub: func(public crypto.PublicKey, msg []byte, sig []byte) error {
+			return nil
+		},
+	}
+
+	tx := &dataTransaction.Transaction{
+		Nonce:           1,
+		Value:           big.NewInt(2),
+		Data:            []byte("data"),
+		GasLimit:        3,
+		GasPrice:        4,
+		RcvAddr:         recvAddress,
+		SndAddr:         senderAddress,
+		ChainID:         chainID,
+		Signature:       sigOk,
+		ValidFromRound:  5,
+		ValidUntilRound: 19,
+	}
+	txBuff, _ := marshalizer.Marshal(tx)
+	txHash := mock.HasherMock{}.Compute(string(txBuff))
+
+	txi, _ := transaction.NewTxInterceptor(
+		marshalizer,
+		txPool,
+		storer,
+		addrConv,
+		mock.HasherMock{},
+		signer,
+		keyGen,
+		mock.NewOneShardCoordinatorMock(),
+		&mock.RounderMock{RoundIndex: 20},
+		createRequestedTxs(txHash),
+		chainID)
+
+	buff, _ := marshalizer.Marshal([][]byte{txBuff})
+	msg := &mock.P2PMessageMock{
+		DataField: buff,
+	}
+
+	txPool.AddDataCalled = func(key []byte, data interface{}, cacheId string) {
+		if bytes.Equal(txHash, key) {
+			chanDone <- struct{}{}
+		}
+	}
+
+	err := txi.ProcessReceivedMessage(msg)
+
+	assert.Nil(t, err)
+	select {
+	case <-chanDone:
+	case <-time.After(durTimeout):
+		assert.Fail(t, "timeout while waiting for tx to be inserted in the pool")
+	}
+}
+
+func TestTransactionInterceptor_ProcessReceivedMessageExpiredTxFromOtherShardShouldAdd(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	chanDone := make(chan struct{}, 10)
+	txPool := &mock.ShardedDataStub{}
+	addrConv := &mock.AddressConverterMock{}
+	keyGen := &mock.SingleSignKeyGenMock{
+		PublicKeyFromByteArrayCalled: func(b []byte) (key crypto.PublicKey, e error) {
+			return &mock.SingleSignPublicKey{}, nil
+		},
+	}
+	storer := &mock.StorerStub{
+		HasCalled: func(key []byte) error {
+			return errors.New("Key not found")
+		},
+	}
+	signer := &mock.SignerMock{
+		VerifyStub: func(public crypto.PublicKey, msg []byte, sig []byte) error {
+			return nil
+		},
+	}
+
+	multiSharder := mock.NewMultipleShardsCoordinatorMock()
+	multiSharder.CurrentShard = 0
+	called := uint32(0)
+	//the receiver is in this shard, the sender in shard 1
+	multiSharder.ComputeIdCalled = func(address state.AddressContainer) uint32 {
+		defer func() {
+			called++
+		}()
+
+		return called
+	}
+
+	txi, _ := transaction.NewTxInterceptor(
+		marshalizer,
+		txPool,
+		storer,
+		addrConv,
+		mock.HasherMock{},
+		signer,
+		keyGen,
+		multiSharder,
+		&mock.RounderMock{RoundIndex: 20},
+		createRequestedTxs(),
+		chainID)
+
+	tx := &dataTransaction.Transaction{
+		Nonce:           1,
+		Value:           big.NewInt(2),
+		Data:            []byte("data"),
+		GasLimit:        3,
+		GasPrice:        4,
+		RcvAddr:         recvAddress,
+		SndAddr:         senderAddress,
+		ChainID:         chainID,
+		Signature:       sigOk,
+		ValidFromRound:  5,
+		ValidUntilRound: 19,
+	}
+	txBuff, _ := marshalizer.Marshal(tx)
+	txHash := mock.HasherMock{}.Compute(string(txBuff))
+
+	buff, _ := marshalizer.Marshal([][]byte{txBuff})
+	msg := &mock.P2PMessageMock{
+		DataField: buff,
+	}
+
+	txPool.AddDataCalled = func(key []byte, data interface{}, cacheId string) {
+		if bytes.Equal(txHash, key) {
+			chanDone <- struct{}{}
+		}
+	}
+
+	err := txi.ProcessReceivedMessage(msg)
+
+	assert.Nil(t, err)
+	select {
+	case <-chanDone:
+	case <-time.After(durTimeout):
+		assert.Fail(t, "timeout while waiting for tx to be inserted in the pool")
+	}
+}
+
+func TestTransactionInterceptor_ProcessReceivedMessageInvalidValidityWindowShouldErr(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	txPool := &mock.ShardedDataStub{}
+	addrConv := &mock.AddressConverterMock{}
+
+	pubKey := &mock.SingleSignPublicKey{}
+	keyGen := &mock.SingleSignKeyGenMock{}
+	keyGen.PublicKeyFromByteArrayCalled = func(b []byte) (key crypto.PublicKey, e error) {
+		return pubKey, nil
+	}
+
+	storer := &mock.StorerStub{}
+	storer.HasCalled = func(key []byte) error {
+		return errors.New("Key not found")
+	}
+	signer := &mock.SignerMock{
+		VerifyStub: func(public crypto.PublicKey, msg []byte, sig []byte) error {
+			return nil
+		},
+	}
+
+	txi, _ := transaction.NewTxInterceptor(
+		marshalizer,
+		txPool,
+		storer,
+		addrConv,
+		mock.HasherMock{},
+		signer,
+		keyGen,
+		mock.NewOneShardCoordinatorMock(),
+		&mock.RounderMock{RoundIndex: 20},
+		createRequestedTxs(),
+		chainID)
+
+	txNewer := &dataTransaction.Transaction{
+		Nonce:           1,
+		Value:           big.NewInt(2),
+		Data:            []byte("data"),
+		GasLimit:        3,
+		GasPrice:        4,
+		RcvAddr:         recvAddress,
+		SndAddr:         senderAddress,
+		ChainID:         chainID,
+		Signature:       sigOk,
+		ValidFromRound:  19,
+		ValidUntilRound: 5,
+	}
+	txNewerBuff, _ := marshalizer.Marshal(txNewer)
+
+	buff, _ := marshalizer.Marshal([][]byte{txNewerBuff})
+	msg := &mock.P2PMessageMock{
+		DataField: buff,
+	}
+
+	txPool.AddDataCalled = func(key []byte, data interface{}, cacheId string) {
+		assert.Fail(t, "the transaction should not have been added in the pool")
+	}
+
+	err := txi.ProcessReceivedMessage(msg)
+
+	assert.Equal(t, process.ErrInvalidValidityWindow, err)
+}
+
 func TestTransactionInterceptor_ProcessReceivedMessagePresentInStorerShouldNotAdd(t *testing.T) {
 	t.Parallel()
 
@@ -712,7 +1240,10 @@ func TestTransactionInterceptor_ProcessReceivedMessagePresentInStorerShouldNotAd
 		mock.HasherMock{},
 		signer,
 		keyGen,
-		multiSharder)
+		multiSharder,
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
 
 	txNewer := &dataTransaction.Transaction{
 		Nonce:     1,
@@ -722,6 +1253,7 @@ func TestTransactionInterceptor_ProcessReceivedMessagePresentInStorerShouldNotAd
 		GasPrice:  4,
 		RcvAddr:   recvAddress,
 		SndAddr:   senderAddress,
+		ChainID:   chainID,
 		Signature: sigOk,
 	}
 	txNewerBuff, _ := marshalizer.Marshal(txNewer)
@@ -746,3 +1278,65 @@ func TestTransactionInterceptor_ProcessReceivedMessagePresentInStorerShouldNotAd
 	case <-time.After(durTimeout):
 	}
 }
+
+func TestTransactionInterceptor_ProcessReceivedMessageOtherChainIDShouldErr(t *testing.T) {
+	t.Parallel()
+
+	marshalizer := &mock.MarshalizerMock{}
+	txPool := &mock.ShardedDataStub{
+		AddDataCalled: func(key []byte, data interface{}, cacheId string) {
+			assert.Fail(t, "a transaction signed for another chain should not be added in the pool")
+		},
+	}
+	addrConv := &mock.AddressConverterMock{}
+	keyGen := &mock.SingleSignKeyGenMock{
+		PublicKeyFromByteArrayCalled: func(b []byte) (key crypto.PublicKey, e error) {
+			return &mock.SingleSignPublicKey{}, nil
+		},
+	}
+	storer := &mock.StorerStub{
+		HasCalled: func(key []byte) error {
+			return errors.New("Key not found")
+		},
+	}
+	signer := &mock.SignerMock{
+		VerifyStub: func(public crypto.PublicKey, msg []byte, sig []byte) error {
+			return nil
+		},
+	}
+
+	txi, _ := transaction.NewTxInterceptor(
+		marshalizer,
+		txPool,
+		storer,
+		addrConv,
+		mock.HasherMock{},
+		signer,
+		keyGen,
+		mock.NewOneShardCoordinatorMock(),
+		&mock.RounderMock{},
+		createRequestedTxs(),
+		chainID)
+
+	tx := &dataTransaction.Transaction{
+		Nonce:     1,
+		Value:     big.NewInt(2),
+		Data:      []byte("data"),
+		GasLimit:  3,
+		GasPrice:  4,
+		RcvAddr:   recvAddress,
+		SndAddr:   senderAddress,
+		ChainID:   []byte("other chain ID"),
+		Signature: sigOk,
+	}
+	txBuff, _ := marshalizer.Marshal(tx)
+
+	buff, _ := marshalizer.Marshal([][]byte{txBuff})
+	msg := &mock.P2PMessageMock{
+		DataField: buff,
+	}
+
+	err := txi.ProcessReceivedMessage(msg)
+
+	assert.Equal(t, process.ErrInvalidChainID, err)
+}
@@ -0,0 +1,420 @@
+package transaction
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/transaction"
+	"github.com/numbatx/gn-numbat/hashing"
+	"github.com/numbatx/gn-numbat/marshal"
+	"github.com/numbatx/gn-numbat/process"
+	"github.com/numbatx/gn-numbat/sharding"
+)
+
+// parallelExecutor executes the transactions of a miniblock concurrently. The transactions are partitioned by the
+// accounts they touch into conflict-free groups and the groups are spread over a number of workers. Each worker
+// executes its groups, keeping the miniblock order, on an isolated accounts view. The final state of every touched
+// account is then written, in the order of the addresses, on the accounts adapter, giving the same root hash as
+// the sequential execution. Miniblocks touching smart contracts or forming a single group are executed sequentially
+type parallelExecutor struct {
+	txProcessor      process.TransactionProcessor
+	accounts         state.AccountsAdapter
+	hasher           hashing.Hasher
+	adrConv          state.AddressConverter
+	marshalizer      marshal.Marshalizer
+	shardCoordinator sharding.Coordinator
+	feeHandler       process.FeeHandler
+	accountFactory   state.AccountFactory
+	maxWorkers       int
+}
+
+// txsGroup holds the indexes, in the miniblock, of a set of transactions touching the same accounts
+type txsGroup struct {
+	txIndexes []int
+	addresses map[string]state.AddressContainer
+}
+
+// workerResult holds the outcome of the execution done by a worker
+type workerResult struct {
+	failedTxIndex int
+	err           error
+}
+
+// NewParallelExecutor creates a new parallelExecutor object. The provided transaction processor is used for the
+// transactions which can not be executed concurrently
+func NewParallelExecutor(
+	txProcessor process.TransactionProcessor,
+	accounts state.AccountsAdapter,
+	hasher hashing.Hasher,
+	addressConv state.AddressConverter,
+	marshalizer marshal.Marshalizer,
+	shardCoordinator sharding.Coordinator,
+	feeHandler process.FeeHandler,
+	accountFactory state.AccountFactory,
+	maxWorkers int,
+) (*parallelExecutor, error) {
+
+	if txProcessor == nil {
+		return nil, process.ErrNilTxProcessor
+	}
+	if accounts == nil {
+		return nil, process.ErrNilAccountsAdapter
+	}
+	if hasher == nil {
+		return nil, process.ErrNilHasher
+	}
+	if addressConv == nil {
+		return nil, process.ErrNilAddressConverter
+	}
+	if marshalizer == nil {
+		return nil, process.ErrNilMarshalizer
+	}
+	if shardCoordinator == nil {
+		return nil, process.ErrNilShardCoordinator
+	}
+	if feeHandler == nil {
+		return nil, process.ErrNilFeeHandler
+	}
+	if accountFactory == nil {
+		return nil, process.ErrNilAccountFactory
+	}
+	if maxWorkers < 1 {
+		return nil, process.ErrInvalidMaxWorkers
+	}
+
+	return &parallelExecutor{
+		txProcessor:      txProcessor,
+		accounts:         accounts,
+		hasher:           hasher,
+		adrConv:          addressConv,
+		marshalizer:      marshalizer,
+		shardCoordinator: shardCoordinator,
+		feeHandler:       feeHandler,
+		accountFactory:   accountFactory,
+		maxWorkers:       maxWorkers,
+	}, nil
+}
+
+// ExecuteTransactions executes the provided transactions, as a whole, against the accounts adapter. If an error
+// occurs, the state changes done by the transactions have to be reverted by the caller
+func (pe *parallelExecutor) ExecuteTransactions(txs []*transaction.Transaction, round int32) error {
+	if pe.maxWorkers < 2 || len(txs) < 2 {
+		return pe.executeSequentially(txs, round)
+	}
+
+	groups, err := pe.computeConflictFreeGroups(txs)
+	if err != nil || len(groups) < 2 {
+		// the sequential execution will signal the error, if any, exactly as it would have been done without
+		// the parallel execution
+		return pe.executeSequentially(txs, round)
+	}
+
+	workersGroups := pe.assignGroupsToWorkers(groups)
+	views, hasSCAccounts, err := pe.createViews(workersGroups)
+	if err != nil {
+		return err
+	}
+	if hasSCAccounts {
+		return pe.executeSequentially(txs, round)
+	}
+
+	err = pe.executeOnViews(txs, round, workersGroups, views)
+	if err != nil {
+		return err
+	}
+
+	return pe.mergeViews(workersGroups, views)
+}
+
+func (pe *parallelExecutor) executeSequentially(txs []*transaction.Transaction, round int32) error {
+	for _, tx := range txs {
+		err := pe.txProcessor.ProcessTransaction(tx, round)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// computeConflictFreeGroups partitions the transactions so that two transactions touching the same account, as
+// sender or receiver, end up in the same group. The groups are ordered by the index of their first transaction
+func (pe *parallelExecutor) computeConflictFreeGroups(txs []*transaction.Transaction) ([]*txsGroup, error) {
+	parents := make(map[string]string)
+	var find func(key string) string
+	find = func(key string) string {
+		parent := parents[key]
+		if parent == key {
+			return key
+		}
+
+		root := find(parent)
+		parents[key] = root
+		return root
+	}
+
+	txsKeys := make([][]string, len(txs))
+	addresses := make(map[string]state.AddressContainer)
+	for i, tx := range txs {
+		if tx == nil {
+			return nil, process.ErrNilTransaction
+		}
+
+		keys, err := pe.inShardAddresses(tx, addresses)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			if _, ok := parents[key]; !ok {
+				parents[key] = key
+			}
+		}
+		for _, key := range keys[1:] {
+			parents[find(key)] = find(keys[0])
+		}
+
+		txsKeys[i] = keys
+	}
+
+	groups := make([]*txsGroup, 0)
+	groupsByRoot := make(map[string]*txsGroup)
+	for i, keys := range txsKeys {
+		if len(keys) == 0 {
+			// a transaction not touching any account of this shard can be executed in any group
+			groups = append(groups, &txsGroup{
+				txIndexes: []int{i},
+				addresses: make(map[string]state.AddressContainer),
+			})
+			continue
+		}
+
+		root := find(keys[0])
+		group, ok := groupsByRoot[root]
+		if !ok {
+			group = &txsGroup{addresses: make(map[string]state.AddressContainer)}
+			groupsByRoot[root] = group
+			groups = append(groups, group)
+		}
+
+		group.txIndexes = append(group.txIndexes, i)
+		for _, key := range keys {
+			group.addresses[key] = addresses[key]
+		}
+	}
+
+	return groups, nil
+}
+
+func (pe *parallelExecutor) inShardAddresses(
+	tx *transaction.Transaction,
+	addresses map[string]state.AddressContainer,
+) ([]string, error) {
+
+	// a batch transfer also touches the accounts of all its receivers
+	addressesBytes := [][]byte{tx.SndAddr, tx.RcvAddr}
+	for _, transfer := range tx.Transfers {
+		addressesBytes = append(addressesBytes, transfer.RcvAddr)
+	}
+
+	keys := make([]string, 0, len(addressesBytes))
+	for _, addressBytes := range addressesBytes {
+		address, err := pe.adrConv.CreateAddressFromPublicKeyBytes(addressBytes)
+		if err != nil {
+			return nil, err
+		}
+		if pe.shardCoordinator.ComputeId(address) != pe.shardCoordinator.SelfId() {
+			continue
+		}
+
+		key := string(address.Bytes())
+		addresses[key] = address
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (pe *parallelExecutor) assignGroupsToWorkers(groups []*txsGroup) [][]*txsGroup {
+	numWorkers := pe.maxWorkers
+	if numWorkers > len(groups) {
+		numWorkers = len(groups)
+	}
+
+	workersGroups := make([][]*txsGroup, numWorkers)
+	for i, group := range groups {
+		workersGroups[i%numWorkers] = append(workersGroups[i%numWorkers], group)
+	}
+
+	return workersGroups
+}
+
+// createViews loads, for every worker, the accounts touched by its groups into a new accounts view. It also signals
+// if any of the accounts holds a smart contract, as the changes done by a smart contract call can not be predicted
+func (pe *parallelExecutor) createViews(workersGroups [][]*txsGroup) ([]*state.AccountsView, bool, error) {
+	views := make([]*state.AccountsView, len(workersGroups))
+	for i, groups := range workersGroups {
+		view, err := state.NewAccountsView(pe.marshalizer, pe.accountFactory)
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, group := range groups {
+			for _, address := range group.addresses {
+				err = view.LoadAccount(pe.accounts, address)
+				if err != nil {
+					return nil, false, err
+				}
+
+				account, err := view.GetExistingAccount(address)
+				if err == state.ErrAccNotFound {
+					continue
+				}
+				if err != nil {
+					return nil, false, err
+				}
+				if account.GetCode() != nil {
+					return nil, true, nil
+				}
+			}
+		}
+
+		views[i] = view
+	}
+
+	return views, false, nil
+}
+
+func (pe *parallelExecutor) executeOnViews(
+	txs []*transaction.Transaction,
+	round int32,
+	workersGroups [][]*txsGroup,
+	views []*state.AccountsView,
+) error {
+
+	results := make([]workerResult, len(workersGroups))
+	wg := sync.WaitGroup{}
+	wg.Add(len(workersGroups))
+
+	for i := range workersGroups {
+		go func(workerIndex int) {
+			defer wg.Done()
+			results[workerIndex] = pe.executeWorkerGroups(txs, round, workersGroups[workerIndex], views[workerIndex])
+		}(i)
+	}
+
+	wg.Wait()
+
+	// the error of the first failed transaction, in miniblock order, is returned so that the outcome does not
+	// depend on the scheduling of the workers
+	var firstResult *workerResult
+	for i := range results {
+		if results[i].err == nil {
+			continue
+		}
+		if firstResult == nil || results[i].failedTxIndex < firstResult.failedTxIndex {
+			firstResult = &results[i]
+		}
+	}
+
+	if firstResult != nil {
+		return firstResult.err
+	}
+
+	return nil
+}
+
+func (pe *parallelExecutor) executeWorkerGroups(
+	txs []*transaction.Transaction,
+	round int32,
+	groups []*txsGroup,
+	view *state.AccountsView,
+) workerResult {
+
+	txProc, err := NewTxProcessor(view, pe.hasher, pe.adrConv, pe.marshalizer, pe.shardCoordinator, pe.feeHandler)
+	if err != nil {
+		return workerResult{err: err}
+	}
+
+	for _, group := range groups {
+		for _, txIndex := range group.txIndexes {
+			err = txProc.ProcessTransaction(txs[txIndex], round)
+			if err != nil {
+				return workerResult{failedTxIndex: txIndex, err: err}
+			}
+		}
+	}
+
+	return workerResult{}
+}
+
+// mergeViews writes the final state of the accounts held by the views on the accounts adapter. The accounts are
+// written in the order of their addresses so that the journal of the accounts adapter is built deterministically
+func (pe *parallelExecutor) mergeViews(workersGroups [][]*txsGroup, views []*state.AccountsView) error {
+	addresses := make([]state.AddressContainer, 0)
+	viewsByAddress := make(map[string]*state.AccountsView)
+	for i, groups := range workersGroups {
+		for _, group := range groups {
+			for key, address := range group.addresses {
+				addresses = append(addresses, address)
+				viewsByAddress[key] = views[i]
+			}
+		}
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+
+	for _, address := range addresses {
+		err := pe.mergeAccount(address, viewsByAddress[string(address.Bytes())])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (pe *parallelExecutor) mergeAccount(address state.AddressContainer, view *state.AccountsView) error {
+	viewAccountHandler, err := view.GetExistingAccount(address)
+	if err == state.ErrAccNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	viewAccount, ok := viewAccountHandler.(*state.Account)
+	if !ok {
+		return process.ErrWrongTypeAssertion
+	}
+
+	accountHandler, err := pe.accounts.GetAccountWithJournal(address)
+	if err != nil {
+		return err
+	}
+
+	account, ok := accountHandler.(*state.Account)
+	if !ok {
+		return process.ErrWrongTypeAssertion
+	}
+
+	if account.Nonce != viewAccount.Nonce {
+		err = account.SetNonceWithJournal(viewAccount.Nonce)
+		if err != nil {
+			return err
+		}
+	}
+
+	if account.Balance.Cmp(viewAccount.Balance) != 0 {
+		err = account.SetBalanceWithJournal(viewAccount.Balance)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,258 @@
+package transaction_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/data/state"
+	"github.com/numbatx/gn-numbat/data/transaction"
+	"github.com/numbatx/gn-numbat/process"
+	"github.com/numbatx/gn-numbat/process/mock"
+	txproc "github.com/numbatx/gn-numbat/process/transaction"
+	"github.com/stretchr/testify/assert"
+)
+
+func createAccountFactoryStub() *mock.AccountFactoryStub {
+	return &mock.AccountFactoryStub{
+		CreateAccountCalled: func(address state.AddressContainer, tracker state.AccountTracker) (state.AccountHandler, error) {
+			return state.NewAccount(address, tracker)
+		},
+	}
+}
+
+func createRecordingTxProcessor(processed *[]*transaction.Transaction) *mock.TxProcessorMock {
+	return &mock.TxProcessorMock{
+		ProcessTransactionCalled: func(transaction *transaction.Transaction, round int32) error {
+			*processed = append(*processed, transaction)
+			return nil
+		},
+	}
+}
+
+//------- NewParallelExecutor
+
+func TestNewParallelExecutor_NilTxProcessorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pe, err := txproc.NewParallelExecutor(
+		nil,
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+		createAccountFactoryStub(),
+		4,
+	)
+
+	assert.Equal(t, process.ErrNilTxProcessor, err)
+	assert.Nil(t, pe)
+}
+
+func TestNewParallelExecutor_NilAccountsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pe, err := txproc.NewParallelExecutor(
+		&mock.TxProcessorMock{},
+		nil,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+		createAccountFactoryStub(),
+		4,
+	)
+
+	assert.Equal(t, process.ErrNilAccountsAdapter, err)
+	assert.Nil(t, pe)
+}
+
+func TestNewParallelExecutor_NilAccountFactoryShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pe, err := txproc.NewParallelExecutor(
+		&mock.TxProcessorMock{},
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+		nil,
+		4,
+	)
+
+	assert.Equal(t, process.ErrNilAccountFactory, err)
+	assert.Nil(t, pe)
+}
+
+func TestNewParallelExecutor_InvalidMaxWorkersShouldErr(t *testing.T) {
+	t.Parallel()
+
+	pe, err := txproc.NewParallelExecutor(
+		&mock.TxProcessorMock{},
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+		createAccountFactoryStub(),
+		0,
+	)
+
+	assert.Equal(t, process.ErrInvalidMaxWorkers, err)
+	assert.Nil(t, pe)
+}
+
+func TestNewParallelExecutor_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	pe, err := txproc.NewParallelExecutor(
+		&mock.TxProcessorMock{},
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+		createAccountFactoryStub(),
+		4,
+	)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pe)
+}
+
+//------- ExecuteTransactions
+
+func TestParallelExecutor_ExecuteTransactionsSingleGroupShouldProcessSequentially(t *testing.T) {
+	t.Parallel()
+
+	processed := make([]*transaction.Transaction, 0)
+	pe, _ := txproc.NewParallelExecutor(
+		createRecordingTxProcessor(&processed),
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+		createAccountFactoryStub(),
+		4,
+	)
+
+	txs := []*transaction.Transaction{
+		{Nonce: 0, SndAddr: []byte("SRC"), RcvAddr: []byte("DST1")},
+		{Nonce: 1, SndAddr: []byte("SRC"), RcvAddr: []byte("DST2")},
+		{Nonce: 0, SndAddr: []byte("DST2"), RcvAddr: []byte("DST3")},
+	}
+
+	err := pe.ExecuteTransactions(txs, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, txs, processed)
+}
+
+func TestParallelExecutor_ExecuteTransactionsWrongAddressShouldProcessSequentially(t *testing.T) {
+	t.Parallel()
+
+	processed := make([]*transaction.Transaction, 0)
+	pe, _ := txproc.NewParallelExecutor(
+		createRecordingTxProcessor(&processed),
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{CreateAddressFromPublicKeyBytesRetErrForValue: []byte("DST2")},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+		createAccountFactoryStub(),
+		4,
+	)
+
+	txs := []*transaction.Transaction{
+		{Nonce: 0, SndAddr: []byte("SRC1"), RcvAddr: []byte("DST1")},
+		{Nonce: 0, SndAddr: []byte("SRC2"), RcvAddr: []byte("DST2")},
+	}
+
+	err := pe.ExecuteTransactions(txs, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, txs, processed)
+}
+
+func TestParallelExecutor_ExecuteTransactionsTouchingSCAccountShouldProcessSequentially(t *testing.T) {
+	t.Parallel()
+
+	processed := make([]*transaction.Transaction, 0)
+	accounts := &mock.AccountsStub{
+		GetExistingAccountCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			account, _ := state.NewAccount(addressContainer, &mock.AccountTrackerStub{})
+			account.Balance = big.NewInt(100)
+			if string(addressContainer.Bytes()) == "SC" {
+				account.SetCode([]byte("code"))
+			}
+
+			return account, nil
+		},
+	}
+	pe, _ := txproc.NewParallelExecutor(
+		createRecordingTxProcessor(&processed),
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+		createAccountFactoryStub(),
+		4,
+	)
+
+	txs := []*transaction.Transaction{
+		{Nonce: 0, SndAddr: []byte("SRC1"), RcvAddr: []byte("DST1")},
+		{Nonce: 0, SndAddr: []byte("SRC2"), RcvAddr: []byte("SC")},
+	}
+
+	err := pe.ExecuteTransactions(txs, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, txs, processed)
+}
+
+func TestParallelExecutor_ExecuteTransactionsBatchTransferJoiningGroupsShouldProcessSequentially(t *testing.T) {
+	t.Parallel()
+
+	processed := make([]*transaction.Transaction, 0)
+	pe, _ := txproc.NewParallelExecutor(
+		createRecordingTxProcessor(&processed),
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+		createAccountFactoryStub(),
+		4,
+	)
+
+	txs := []*transaction.Transaction{
+		{Nonce: 0, SndAddr: []byte("SRC1"), RcvAddr: []byte("DST1")},
+		{Nonce: 0, SndAddr: []byte("SRC2"), RcvAddr: []byte("DST2")},
+		{
+			Nonce:   0,
+			SndAddr: []byte("SRC3"),
+			RcvAddr: []byte("SRC3"),
+			Transfers: []transaction.Transfer{
+				{RcvAddr: []byte("DST1"), Value: big.NewInt(1)},
+				{RcvAddr: []byte("DST2"), Value: big.NewInt(1)},
+			},
+		},
+	}
+
+	err := pe.ExecuteTransactions(txs, 0)
+
+	assert.Nil(t, err)
+	assert.Equal(t, txs, processed)
+}
@@ -2,7 +2,9 @@ package transaction
 
 import (
 	"bytes"
+	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/numbatx/gn-numbat/core/logger"
 	"github.com/numbatx/gn-numbat/data/state"
@@ -23,6 +25,10 @@ type txProcessor struct {
 	scHandler        func(accountsAdapter state.AccountsAdapter, transaction *transaction.Transaction) error
 	marshalizer      marshal.Marshalizer
 	shardCoordinator sharding.Coordinator
+	feeHandler       process.FeeHandler
+
+	mutExecutionErrors sync.RWMutex
+	executionErrors    map[string]error
 }
 
 // NewTxProcessor creates a new txProcessor engine
@@ -32,6 +38,7 @@ func NewTxProcessor(
 	addressConv state.AddressConverter,
 	marshalizer marshal.Marshalizer,
 	shardCoordinator sharding.Coordinator,
+	feeHandler process.FeeHandler,
 ) (*txProcessor, error) {
 
 	if accounts == nil {
@@ -49,6 +56,9 @@ func NewTxProcessor(
 	if shardCoordinator == nil {
 		return nil, process.ErrNilShardCoordinator
 	}
+	if feeHandler == nil {
+		return nil, process.ErrNilFeeHandler
+	}
 
 	return &txProcessor{
 		accounts:         accounts,
@@ -56,6 +66,8 @@ func NewTxProcessor(
 		adrConv:          addressConv,
 		marshalizer:      marshalizer,
 		shardCoordinator: shardCoordinator,
+		feeHandler:       feeHandler,
+		executionErrors:  make(map[string]error),
 	}, nil
 }
 
@@ -75,6 +87,10 @@ func (txProc *txProcessor) ProcessTransaction(tx *transaction.Transaction, round
 		return process.ErrNilTransaction
 	}
 
+	if tx.IsBatchTransfer() {
+		return txProc.processBatchTransfer(tx, roundIndex)
+	}
+
 	adrSrc, adrDst, err := txProc.getAddresses(tx)
 	if err != nil {
 		return err
@@ -88,15 +104,35 @@ func (txProc *txProcessor) ProcessTransaction(tx *transaction.Transaction, round
 	}
 
 	value := tx.Value
+	fee := big.NewInt(0)
+	// is receiver address in node shard and this address contains a SC code
+	isSCCall := acntDst != nil && acntDst.GetCode() != nil
 
 	// is sender address in node shard
 	if acntSrc != nil {
-		err = txProc.checkTxValues(acntSrc, value, tx.Nonce)
+		err = process.CheckTxValidityWindow(tx, roundToCheck(roundIndex))
+		if err != nil {
+			return err
+		}
+
+		err = txProc.feeHandler.CheckTxGas(tx)
+		if err != nil {
+			return err
+		}
+
+		fee = txProc.feeHandler.ComputeFee(tx)
+		err = txProc.checkTxValues(acntSrc, value, fee, tx.Nonce)
 		if err != nil {
 			return err
 		}
 	}
 
+	// the state is snapshotted before any change so that a failed SC call can be reverted
+	snapshot := 0
+	if isSCCall {
+		snapshot = txProc.accounts.JournalLen()
+	}
+
 	err = txProc.moveBalances(acntSrc, acntDst, value)
 	if err != nil {
 		return err
@@ -104,17 +140,116 @@ func (txProc *txProcessor) ProcessTransaction(tx *transaction.Transaction, round
 
 	// is sender address in node shard
 	if acntSrc != nil {
+		err = txProc.chargeFee(acntSrc, fee)
+		if err != nil {
+			return err
+		}
+
 		err = txProc.increaseNonce(acntSrc)
 		if err != nil {
 			return err
 		}
 	}
 
-	// is receiver address in node shard and this address contains a SC code
-	if acntDst != nil && acntDst.GetCode() != nil {
+	if isSCCall {
 		err = txProc.callSCHandler(tx)
 		if err != nil {
-			// TODO: Revert state if SC execution failed and substract only some fee needed for SC job done
+			log.Debug(fmt.Sprintf("SC call failed, reverting its state changes: %s", err.Error()))
+			txProc.setExecutionError(tx, err)
+			return txProc.revertFailedSCCall(snapshot, acntSrc, acntDst, value, fee)
+		}
+	}
+
+	return nil
+}
+
+// processBatchTransfer moves the value of every transfer of a batch transfer from the sender to the transfer
+// receiver. Only the accounts held by the node shard are changed: the sender shard takes the total value and the fee
+// from the sender and credits its own receivers, while every receiver shard credits its own receivers. The transfers
+// only change balances, so no smart contract is called even if a receiver holds one. The batch is processed as a
+// whole, the state being reverted if any of its transfers can not be done
+func (txProc *txProcessor) processBatchTransfer(tx *transaction.Transaction, roundIndex int32) error {
+	adrSrc, err := txProc.adrConv.CreateAddressFromPublicKeyBytes(tx.SndAddr)
+	if err != nil {
+		return err
+	}
+
+	snapshot := txProc.accounts.JournalLen()
+	err = txProc.moveBatchTransferBalances(tx, roundIndex, adrSrc)
+	if err != nil {
+		errRevert := txProc.accounts.RevertToSnapshot(snapshot)
+		if errRevert != nil {
+			log.Error(errRevert.Error())
+		}
+	}
+
+	return err
+}
+
+func (txProc *txProcessor) moveBatchTransferBalances(
+	tx *transaction.Transaction,
+	roundIndex int32,
+	adrSrc state.AddressContainer,
+) error {
+	// the accounts are kept by address as the sender can also be one of the receivers
+	accounts := make(map[string]*state.Account)
+
+	srcInShard := txProc.shardCoordinator.ComputeId(adrSrc) == txProc.shardCoordinator.SelfId()
+	if srcInShard {
+		acntSrc, err := txProc.getBatchAccount(accounts, adrSrc)
+		if err != nil {
+			return err
+		}
+
+		err = process.CheckTxValidityWindow(tx, roundToCheck(roundIndex))
+		if err != nil {
+			return err
+		}
+
+		err = txProc.feeHandler.CheckTxGas(tx)
+		if err != nil {
+			return err
+		}
+
+		totalValue := big.NewInt(0)
+		for _, transfer := range tx.Transfers {
+			totalValue.Add(totalValue, transfer.Value)
+		}
+
+		fee := txProc.feeHandler.ComputeFee(tx)
+		err = txProc.checkTxValues(acntSrc, totalValue, fee, tx.Nonce)
+		if err != nil {
+			return err
+		}
+
+		err = txProc.moveBalances(acntSrc, nil, big.NewInt(0).Add(totalValue, fee))
+		if err != nil {
+			return err
+		}
+
+		err = txProc.increaseNonce(acntSrc)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, transfer := range tx.Transfers {
+		adrDst, err := txProc.adrConv.CreateAddressFromPublicKeyBytes(transfer.RcvAddr)
+		if err != nil {
+			return err
+		}
+
+		if txProc.shardCoordinator.ComputeId(adrDst) != txProc.shardCoordinator.SelfId() {
+			continue
+		}
+
+		acntDst, err := txProc.getBatchAccount(accounts, adrDst)
+		if err != nil {
+			return err
+		}
+
+		err = txProc.moveBalances(nil, acntDst, transfer.Value)
+		if err != nil {
 			return err
 		}
 	}
@@ -122,6 +257,86 @@ func (txProc *txProcessor) ProcessTransaction(tx *transaction.Transaction, round
 	return nil
 }
 
+func (txProc *txProcessor) getBatchAccount(
+	accounts map[string]*state.Account,
+	address state.AddressContainer,
+) (*state.Account, error) {
+
+	account, ok := accounts[string(address.Bytes())]
+	if ok {
+		return account, nil
+	}
+
+	accountHandler, err := txProc.accounts.GetAccountWithJournal(address)
+	if err != nil {
+		return nil, err
+	}
+
+	account, ok = accountHandler.(*state.Account)
+	if !ok {
+		return nil, process.ErrWrongTypeAssertion
+	}
+
+	accounts[string(address.Bytes())] = account
+	return account, nil
+}
+
+// revertFailedSCCall reverts all the state changes done by a transaction whose SC call failed. The sender still pays
+// the fee for the job done and its nonce is increased, so that the transaction can be included in the block.
+// If the sender is in another shard, the value was already taken out of its balance so it is kept by the receiver
+func (txProc *txProcessor) revertFailedSCCall(
+	snapshot int,
+	acntSrc, acntDst *state.Account,
+	value *big.Int,
+	fee *big.Int,
+) error {
+	err := txProc.accounts.RevertToSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+
+	if acntSrc == nil {
+		return txProc.moveBalances(nil, acntDst, value)
+	}
+
+	err = txProc.chargeFee(acntSrc, fee)
+	if err != nil {
+		return err
+	}
+
+	return txProc.increaseNonce(acntSrc)
+}
+
+// ExecutionError returns the error of the failed smart contract call of the transaction with the provided hash.
+// The transactions whose execution failed are kept until ClearExecutionErrors is called
+func (txProc *txProcessor) ExecutionError(txHash []byte) error {
+	txProc.mutExecutionErrors.RLock()
+	defer txProc.mutExecutionErrors.RUnlock()
+
+	return txProc.executionErrors[string(txHash)]
+}
+
+// ClearExecutionErrors removes all the recorded execution errors
+func (txProc *txProcessor) ClearExecutionErrors() {
+	txProc.mutExecutionErrors.Lock()
+	txProc.executionErrors = make(map[string]error)
+	txProc.mutExecutionErrors.Unlock()
+}
+
+func (txProc *txProcessor) setExecutionError(tx *transaction.Transaction, err error) {
+	buff, errMarshal := txProc.marshalizer.Marshal(tx)
+	if errMarshal != nil {
+		log.Error(errMarshal.Error())
+		return
+	}
+
+	txHash := txProc.hasher.Compute(string(buff))
+
+	txProc.mutExecutionErrors.Lock()
+	txProc.executionErrors[string(txHash)] = err
+	txProc.mutExecutionErrors.Unlock()
+}
+
 func (txProc *txProcessor) getAddresses(tx *transaction.Transaction) (adrSrc, adrDst state.AddressContainer, err error) {
 	//for now we assume that the address = public key
 	adrSrc, err = txProc.adrConv.CreateAddressFromPublicKeyBytes(tx.SndAddr)
@@ -200,18 +415,18 @@ func (txProc *txProcessor) callSCHandler(tx *transaction.Transaction) error {
 	return txProc.scHandler(txProc.accounts, tx)
 }
 
-func (txProc *txProcessor) checkTxValues(acntSrc *state.Account, value *big.Int, nonce uint64) error {
-	// TODO order transactions - than uncomment this
-	//if acntSrc.Nonce < nonce {
-	//	return process.ErrHigherNonceInTransaction
-	//}
+func (txProc *txProcessor) checkTxValues(acntSrc *state.Account, value *big.Int, fee *big.Int, nonce uint64) error {
+	if acntSrc.Nonce < nonce {
+		return process.ErrHigherNonceInTransaction
+	}
 
-	//if acntSrc.Nonce > nonce {
-	//	return process.ErrLowerNonceInTransaction
-	//}
+	if acntSrc.Nonce > nonce {
+		return process.ErrLowerNonceInTransaction
+	}
 
 	//negative balance test is done in transaction interceptor as the transaction is invalid and thus shall not disseminate
-	if acntSrc.Balance.Cmp(value) < 0 {
+	cost := big.NewInt(0).Add(value, fee)
+	if acntSrc.Balance.Cmp(cost) < 0 {
 		return process.ErrInsufficientFunds
 	}
 
@@ -243,6 +458,24 @@ func (txProc *txProcessor) moveBalances(acntSrc, acntDst *state.Account,
 	return nil
 }
 
+func (txProc *txProcessor) chargeFee(acntSrc *state.Account, fee *big.Int) error {
+	if fee.Sign() == 0 {
+		return nil
+	}
+
+	return acntSrc.SetBalanceWithJournal(big.NewInt(0).Sub(acntSrc.Balance, fee))
+}
+
 func (txProc *txProcessor) increaseNonce(acntSrc *state.Account) error {
 	return acntSrc.SetNonceWithJournal(acntSrc.Nonce + 1)
 }
+
+// roundToCheck converts the round index used for processing into the round checked against the validity window of a
+// transaction, rounds before the genesis being reported as round 0
+func roundToCheck(roundIndex int32) uint32 {
+	if roundIndex < 0 {
+		return 0
+	}
+
+	return uint32(roundIndex)
+}
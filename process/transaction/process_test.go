@@ -30,6 +30,12 @@ func createAccountStub(sndAddr, rcvAddr []byte,
 
 		return nil, errors.New("failure")
 	}
+	accounts.JournalLenCalled = func() int {
+		return 0
+	}
+	accounts.RevertToSnapshotCalled = func(snapshot int) error {
+		return nil
+	}
 
 	return &accounts
 }
@@ -41,6 +47,7 @@ func createTxProcessor() txproc.TxProcessor {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	return txProc
@@ -57,6 +64,7 @@ func TestNewTxProcessor_NilAccountsShouldErr(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	assert.Equal(t, process.ErrNilAccountsAdapter, err)
@@ -72,6 +80,7 @@ func TestNewTxProcessor_NilHasherShouldErr(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	assert.Equal(t, process.ErrNilHasher, err)
@@ -87,6 +96,7 @@ func TestNewTxProcessor_NilAddressConverterMockShouldErr(t *testing.T) {
 		nil,
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	assert.Equal(t, process.ErrNilAddressConverter, err)
@@ -102,6 +112,7 @@ func TestNewTxProcessor_NilMarshalizerMockShouldErr(t *testing.T) {
 		&mock.AddressConverterMock{},
 		nil,
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	assert.Equal(t, process.ErrNilMarshalizer, err)
@@ -117,12 +128,29 @@ func TestNewTxProcessor_NilShardCoordinatorMockShouldErr(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		nil,
+		&mock.FeeHandlerStub{},
 	)
 
 	assert.Equal(t, process.ErrNilShardCoordinator, err)
 	assert.Nil(t, txProc)
 }
 
+func TestNewTxProcessor_NilFeeHandlerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	txProc, err := txproc.NewTxProcessor(
+		&mock.AccountsStub{},
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		nil,
+	)
+
+	assert.Equal(t, process.ErrNilFeeHandler, err)
+	assert.Nil(t, txProc)
+}
+
 func TestNewTxProcessor_OkValsShouldWork(t *testing.T) {
 	t.Parallel()
 
@@ -132,6 +160,7 @@ func TestNewTxProcessor_OkValsShouldWork(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	assert.Nil(t, err)
@@ -166,6 +195,7 @@ func TestTxProcessor_GetAddressErrAddressConvShouldErr(t *testing.T) {
 		addressConv,
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	addressConv.Fail = true
@@ -202,6 +232,7 @@ func TestTxProcessor_GetAccountsShouldErrNilAddressContainer(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	adr1 := mock.NewAddressMock([]byte{65})
@@ -223,6 +254,7 @@ func TestTxProcessor_GetAccountsMalfunctionAccountsShouldErr(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	adr1 := mock.NewAddressMock([]byte{65})
@@ -261,6 +293,7 @@ func TestTxProcessor_GetAccountsOkValsSrcShouldWork(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		shardCoordinator,
+		&mock.FeeHandlerStub{},
 	)
 
 	shardCoordinator.ComputeIdCalled = func(container state.AddressContainer) uint32 {
@@ -308,6 +341,7 @@ func TestTxProcessor_GetAccountsOkValsDsthouldWork(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		shardCoordinator,
+		&mock.FeeHandlerStub{},
 	)
 
 	shardCoordinator.ComputeIdCalled = func(container state.AddressContainer) uint32 {
@@ -340,6 +374,7 @@ func TestTxProcessor_GetAccountsOkValsShouldWork(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	a1, a2, err := execTx.GetAccounts(adr1, adr2)
@@ -363,6 +398,7 @@ func TestTxProcessor_GetSameAccountShouldWork(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	a1, a2, err := execTx.GetAccounts(adr1, adr1)
@@ -397,8 +433,6 @@ func TestTxProcessor_WithCallSCHandlerShouldWork(t *testing.T) {
 //------- checkTxValues
 
 func TestTxProcessor_CheckTxValuesHigherNonceShouldErr(t *testing.T) {
-	t.Skip()
-
 	adr1 := mock.NewAddressMock([]byte{65})
 	acnt1, err := state.NewAccount(adr1, &mock.AccountTrackerStub{})
 	assert.Nil(t, err)
@@ -407,12 +441,11 @@ func TestTxProcessor_CheckTxValuesHigherNonceShouldErr(t *testing.T) {
 
 	acnt1.Nonce = 6
 
-	err = execTx.CheckTxValues(acnt1, big.NewInt(0), 7)
+	err = execTx.CheckTxValues(acnt1, big.NewInt(0), big.NewInt(0), 7)
 	assert.Equal(t, process.ErrHigherNonceInTransaction, err)
 }
 
 func TestTxProcessor_CheckTxValuesLowerNonceShouldErr(t *testing.T) {
-	t.Skip()
 	adr1 := mock.NewAddressMock([]byte{65})
 	acnt1, err := state.NewAccount(adr1, &mock.AccountTrackerStub{})
 	assert.Nil(t, err)
@@ -421,7 +454,7 @@ func TestTxProcessor_CheckTxValuesLowerNonceShouldErr(t *testing.T) {
 
 	acnt1.Nonce = 6
 
-	err = execTx.CheckTxValues(acnt1, big.NewInt(0), 5)
+	err = execTx.CheckTxValues(acnt1, big.NewInt(0), big.NewInt(0), 5)
 	assert.Equal(t, process.ErrLowerNonceInTransaction, err)
 }
 
@@ -434,7 +467,20 @@ func TestTxProcessor_CheckTxValuesInsufficientFundsShouldErr(t *testing.T) {
 
 	acnt1.Balance = big.NewInt(67)
 
-	err = execTx.CheckTxValues(acnt1, big.NewInt(68), 0)
+	err = execTx.CheckTxValues(acnt1, big.NewInt(68), big.NewInt(0), 0)
+	assert.Equal(t, process.ErrInsufficientFunds, err)
+}
+
+func TestTxProcessor_CheckTxValuesInsufficientFundsForFeeShouldErr(t *testing.T) {
+	adr1 := mock.NewAddressMock([]byte{65})
+	acnt1, err := state.NewAccount(adr1, &mock.AccountTrackerStub{})
+	assert.Nil(t, err)
+
+	execTx := *createTxProcessor()
+
+	acnt1.Balance = big.NewInt(67)
+
+	err = execTx.CheckTxValues(acnt1, big.NewInt(60), big.NewInt(8), 0)
 	assert.Equal(t, process.ErrInsufficientFunds, err)
 }
 
@@ -447,7 +493,7 @@ func TestTxProcessor_CheckTxValuesOkValsShouldErr(t *testing.T) {
 
 	acnt1.Balance = big.NewInt(67)
 
-	err = execTx.CheckTxValues(acnt1, big.NewInt(67), 0)
+	err = execTx.CheckTxValues(acnt1, big.NewInt(67), big.NewInt(0), 0)
 	assert.Nil(t, err)
 }
 
@@ -607,6 +653,7 @@ func TestTxProcessor_ProcessTransactionErrAddressConvShouldErr(t *testing.T) {
 		addressConv,
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	addressConv.Fail = true
@@ -624,6 +671,7 @@ func TestTxProcessor_ProcessTransactionMalfunctionAccountsShouldErr(t *testing.T
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	tx := transaction.Transaction{}
@@ -672,6 +720,7 @@ func TestTxProcessor_ProcessTransactionScTxShouldWork(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	wasCalled := false
@@ -687,15 +736,74 @@ func TestTxProcessor_ProcessTransactionScTxShouldWork(t *testing.T) {
 	assert.Equal(t, 3, saveAccountCalled)
 }
 
-func TestTxProcessor_ProcessTransactionScTxShouldReturnErrWhenExecutionFails(t *testing.T) {
-	journalizeCalled := 0
-	saveAccountCalled := 0
+func TestTxProcessor_ProcessTransactionScTxFailedShouldRevertAndChargeFee(t *testing.T) {
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.Nonce = 0
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = []byte("DST")
+	tx.Value = big.NewInt(40)
+
+	acntSrc, err := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	assert.Nil(t, err)
+	acntSrc.Balance = big.NewInt(45)
+	acntDst, err := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+	assert.Nil(t, err)
+	acntDst.SetCode([]byte{65})
+
+	accounts := createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst)
+	accounts.JournalLenCalled = func() int {
+		return 7
+	}
+	revertedSnapshot := -1
+	accounts.RevertToSnapshotCalled = func(snapshot int) error {
+		revertedSnapshot = snapshot
+		acntSrc.Balance = big.NewInt(45)
+		acntSrc.Nonce = 0
+		acntDst.Balance = big.NewInt(0)
+		return nil
+	}
+
+	execTx, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{
+			ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+				return big.NewInt(5)
+			},
+		},
+	)
+
+	errExecution := errors.New("sc execution error")
+	execTx.SetSCHandler(func(accountsAdapter state.AccountsAdapter, transaction *transaction.Transaction) error {
+		return errExecution
+	})
+
+	err = execTx.ProcessTransaction(&tx, 4)
+	assert.Nil(t, err)
+	buff, _ := (&mock.MarshalizerMock{}).Marshal(&tx)
+	assert.Equal(t, errExecution, execTx.ExecutionError(mock.HasherMock{}.Compute(string(buff))))
+	assert.Equal(t, 7, revertedSnapshot)
+	assert.Equal(t, uint64(1), acntSrc.Nonce)
+	assert.Equal(t, big.NewInt(40), acntSrc.Balance)
+	assert.Equal(t, big.NewInt(0), acntDst.Balance)
+}
+
+func TestTxProcessor_ProcessTransactionScTxFailedRevertErrorShouldErr(t *testing.T) {
 	tracker := &mock.AccountTrackerStub{
 		JournalizeCalled: func(entry state.JournalEntry) {
-			journalizeCalled++
 		},
 		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
-			saveAccountCalled++
 			return nil
 		},
 	}
@@ -713,7 +821,11 @@ func TestTxProcessor_ProcessTransactionScTxShouldReturnErrWhenExecutionFails(t *
 	assert.Nil(t, err)
 	acntDst.SetCode([]byte{65})
 
+	errRevert := errors.New("revert error")
 	accounts := createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst)
+	accounts.RevertToSnapshotCalled = func(snapshot int) error {
+		return errRevert
+	}
 
 	execTx, _ := txproc.NewTxProcessor(
 		accounts,
@@ -721,17 +833,67 @@ func TestTxProcessor_ProcessTransactionScTxShouldReturnErrWhenExecutionFails(t *
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
-	err = errors.New("sc execution error")
 	execTx.SetSCHandler(func(accountsAdapter state.AccountsAdapter, transaction *transaction.Transaction) error {
-		return err
+		return errors.New("sc execution error")
 	})
 
-	err2 := execTx.ProcessTransaction(&tx, 4)
-	assert.Equal(t, err, err2)
-	assert.Equal(t, 3, journalizeCalled)
-	assert.Equal(t, 3, saveAccountCalled)
+	err = execTx.ProcessTransaction(&tx, 4)
+	assert.Equal(t, errRevert, err)
+}
+
+func TestTxProcessor_ProcessTransactionScTxFailedWithSenderInOtherShardShouldKeepValue(t *testing.T) {
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.Nonce = 0
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = []byte("DST")
+	tx.Value = big.NewInt(45)
+
+	shardCoordinator.ComputeIdCalled = func(container state.AddressContainer) uint32 {
+		if bytes.Equal(container.Bytes(), tx.SndAddr) {
+			return 1
+		}
+
+		return 0
+	}
+
+	acntDst, err := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+	assert.Nil(t, err)
+	acntDst.SetCode([]byte{65})
+
+	accounts := createAccountStub(tx.SndAddr, tx.RcvAddr, nil, acntDst)
+	accounts.RevertToSnapshotCalled = func(snapshot int) error {
+		acntDst.Balance = big.NewInt(0)
+		return nil
+	}
+
+	execTx, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		shardCoordinator,
+		&mock.FeeHandlerStub{},
+	)
+
+	execTx.SetSCHandler(func(accountsAdapter state.AccountsAdapter, transaction *transaction.Transaction) error {
+		return errors.New("sc execution error")
+	})
+
+	err = execTx.ProcessTransaction(&tx, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(45), acntDst.Balance)
 }
 
 func TestTxProcessor_ProcessTransactionScTxShouldNotBeCalledWhenAdrDstIsNotInNodeShard(t *testing.T) {
@@ -778,6 +940,7 @@ func TestTxProcessor_ProcessTransactionScTxShouldNotBeCalledWhenAdrDstIsNotInNod
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		shardCoordinator,
+		&mock.FeeHandlerStub{},
 	)
 
 	wasCalled := false
@@ -815,6 +978,7 @@ func TestTxProcessor_ProcessCheckNotPassShouldErr(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -863,6 +1027,7 @@ func TestTxProcessor_ProcessCheckShouldPassWhenAdrSrcIsNotInNodeShard(t *testing
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		shardCoordinator,
+		&mock.FeeHandlerStub{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -903,6 +1068,7 @@ func TestTxProcessor_ProcessMoveBalancesShouldWork(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -953,6 +1119,7 @@ func TestTxProcessor_ProcessMoveBalancesShouldPassWhenAdrSrcIsNotInNodeShard(t *
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		shardCoordinator,
+		&mock.FeeHandlerStub{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -1003,6 +1170,7 @@ func TestTxProcessor_ProcessIncreaseNonceShouldPassWhenAdrSrcIsNotInNodeShard(t
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		shardCoordinator,
+		&mock.FeeHandlerStub{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -1047,6 +1215,7 @@ func TestTxProcessor_ProcessOkValsShouldWork(t *testing.T) {
 		&mock.AddressConverterMock{},
 		&mock.MarshalizerMock{},
 		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
 	)
 
 	err = execTx.ProcessTransaction(&tx, 4)
@@ -1057,3 +1226,405 @@ func TestTxProcessor_ProcessOkValsShouldWork(t *testing.T) {
 	assert.Equal(t, 3, journalizeCalled)
 	assert.Equal(t, 3, saveAccountCalled)
 }
+
+func TestTxProcessor_ProcessTransactionInsufficientGasShouldErr(t *testing.T) {
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.Nonce = 4
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = []byte("DST")
+	tx.Value = big.NewInt(61)
+
+	acntSrc, err := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	assert.Nil(t, err)
+	acntDst, err := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+	assert.Nil(t, err)
+
+	acntSrc.Nonce = 4
+	acntSrc.Balance = big.NewInt(90)
+
+	accounts := createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst)
+
+	execTx, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{
+			CheckTxGasCalled: func(tx *transaction.Transaction) error {
+				return process.ErrInsufficientGasPrice
+			},
+		},
+	)
+
+	err = execTx.ProcessTransaction(&tx, 4)
+	assert.Equal(t, process.ErrInsufficientGasPrice, err)
+	assert.Equal(t, uint64(4), acntSrc.Nonce)
+	assert.Equal(t, big.NewInt(90), acntSrc.Balance)
+}
+
+func TestTxProcessor_ProcessTransactionBeforeValidityWindowShouldErr(t *testing.T) {
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.Nonce = 4
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = []byte("DST")
+	tx.Value = big.NewInt(61)
+	tx.ValidFromRound = 5
+	tx.ValidUntilRound = 10
+
+	acntSrc, err := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	assert.Nil(t, err)
+	acntDst, err := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+	assert.Nil(t, err)
+
+	acntSrc.Nonce = 4
+	acntSrc.Balance = big.NewInt(90)
+
+	accounts := createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst)
+
+	execTx, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+	)
+
+	err = execTx.ProcessTransaction(&tx, 4)
+	assert.Equal(t, process.ErrTxNotYetValid, err)
+	assert.Equal(t, uint64(4), acntSrc.Nonce)
+	assert.Equal(t, big.NewInt(90), acntSrc.Balance)
+}
+
+func TestTxProcessor_ProcessTransactionAfterValidityWindowShouldErr(t *testing.T) {
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.Nonce = 4
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = []byte("DST")
+	tx.Value = big.NewInt(61)
+	tx.ValidFromRound = 5
+	tx.ValidUntilRound = 10
+
+	acntSrc, err := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	assert.Nil(t, err)
+	acntDst, err := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+	assert.Nil(t, err)
+
+	acntSrc.Nonce = 4
+	acntSrc.Balance = big.NewInt(90)
+
+	accounts := createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst)
+
+	execTx, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+	)
+
+	err = execTx.ProcessTransaction(&tx, 11)
+	assert.Equal(t, process.ErrTxExpired, err)
+	assert.Equal(t, uint64(4), acntSrc.Nonce)
+	assert.Equal(t, big.NewInt(90), acntSrc.Balance)
+}
+
+func TestTxProcessor_ProcessTransactionShouldChargeFee(t *testing.T) {
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.Nonce = 4
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = []byte("DST")
+	tx.Value = big.NewInt(61)
+
+	acntSrc, err := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	assert.Nil(t, err)
+	acntDst, err := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+	assert.Nil(t, err)
+
+	acntSrc.Nonce = 4
+	acntSrc.Balance = big.NewInt(90)
+	acntDst.Balance = big.NewInt(10)
+
+	accounts := createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst)
+
+	execTx, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{
+			ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+				return big.NewInt(9)
+			},
+		},
+	)
+
+	err = execTx.ProcessTransaction(&tx, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), acntSrc.Nonce)
+	assert.Equal(t, big.NewInt(20), acntSrc.Balance)
+	assert.Equal(t, big.NewInt(71), acntDst.Balance)
+}
+
+func TestTxProcessor_ProcessTransactionBalanceNotCoveringFeeShouldErr(t *testing.T) {
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.Nonce = 4
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = []byte("DST")
+	tx.Value = big.NewInt(61)
+
+	acntSrc, err := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	assert.Nil(t, err)
+	acntDst, err := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+	assert.Nil(t, err)
+
+	acntSrc.Nonce = 4
+	acntSrc.Balance = big.NewInt(90)
+
+	accounts := createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst)
+
+	execTx, _ := txproc.NewTxProcessor(
+		accounts,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{
+			ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+				return big.NewInt(30)
+			},
+		},
+	)
+
+	err = execTx.ProcessTransaction(&tx, 4)
+	assert.Equal(t, process.ErrInsufficientFunds, err)
+	assert.Equal(t, big.NewInt(90), acntSrc.Balance)
+}
+
+func TestTxProcessor_ClearExecutionErrorsShouldWork(t *testing.T) {
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	tx := transaction.Transaction{}
+	tx.SndAddr = []byte("SRC")
+	tx.RcvAddr = []byte("DST")
+	tx.Value = big.NewInt(0)
+
+	acntSrc, _ := state.NewAccount(mock.NewAddressMock(tx.SndAddr), tracker)
+	acntDst, _ := state.NewAccount(mock.NewAddressMock(tx.RcvAddr), tracker)
+	acntDst.SetCode([]byte{65})
+
+	execTx, _ := txproc.NewTxProcessor(
+		createAccountStub(tx.SndAddr, tx.RcvAddr, acntSrc, acntDst),
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{},
+	)
+	execTx.SetSCHandler(func(accountsAdapter state.AccountsAdapter, transaction *transaction.Transaction) error {
+		return errors.New("sc execution error")
+	})
+
+	_ = execTx.ProcessTransaction(&tx, 4)
+	// the error is found by the hash of the transaction, regardless of the instance processed
+	txCopy := tx
+	buff, _ := (&mock.MarshalizerMock{}).Marshal(&txCopy)
+	txHash := mock.HasherMock{}.Compute(string(buff))
+	assert.NotNil(t, execTx.ExecutionError(txHash))
+
+	execTx.ClearExecutionErrors()
+	assert.Nil(t, execTx.ExecutionError(txHash))
+}
+
+//------- batch transfer
+
+func createBatchTransferAccounts(t *testing.T, addresses ...string) (map[string]*state.Account, *mock.AccountsStub) {
+	tracker := &mock.AccountTrackerStub{
+		JournalizeCalled: func(entry state.JournalEntry) {
+		},
+		SaveAccountCalled: func(accountHandler state.AccountHandler) error {
+			return nil
+		},
+	}
+
+	accnts := make(map[string]*state.Account)
+	for _, address := range addresses {
+		account, err := state.NewAccount(mock.NewAddressMock([]byte(address)), tracker)
+		assert.Nil(t, err)
+		accnts[address] = account
+	}
+
+	accountsAdapter := &mock.AccountsStub{
+		GetAccountWithJournalCalled: func(addressContainer state.AddressContainer) (state.AccountHandler, error) {
+			account, ok := accnts[string(addressContainer.Bytes())]
+			if !ok {
+				return nil, errors.New("failure")
+			}
+
+			// a fresh copy is returned on every call, as the accounts adapter does
+			accountCopy := *account
+			accnts[string(addressContainer.Bytes())] = &accountCopy
+			return &accountCopy, nil
+		},
+		JournalLenCalled: func() int {
+			return 0
+		},
+		RevertToSnapshotCalled: func(snapshot int) error {
+			return nil
+		},
+	}
+
+	return accnts, accountsAdapter
+}
+
+func createBatchTransferTx() *transaction.Transaction {
+	return &transaction.Transaction{
+		Nonce:   4,
+		Value:   big.NewInt(0),
+		SndAddr: []byte("SRC"),
+		RcvAddr: []byte("SRC"),
+		Transfers: []transaction.Transfer{
+			{RcvAddr: []byte("DST1"), Value: big.NewInt(10)},
+			{RcvAddr: []byte("DST2"), Value: big.NewInt(20)},
+			{RcvAddr: []byte("SRC"), Value: big.NewInt(5)},
+		},
+	}
+}
+
+func TestTxProcessor_ProcessTransactionBatchTransferShouldMoveBalancesAndChargeFee(t *testing.T) {
+	accnts, accountsAdapter := createBatchTransferAccounts(t, "SRC", "DST1", "DST2")
+	accnts["SRC"].Nonce = 4
+	accnts["SRC"].Balance = big.NewInt(100)
+
+	execTx, _ := txproc.NewTxProcessor(
+		accountsAdapter,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{
+			ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+				return big.NewInt(9)
+			},
+		},
+	)
+
+	err := execTx.ProcessTransaction(createBatchTransferTx(), 4)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), accnts["SRC"].Nonce)
+	assert.Equal(t, big.NewInt(61), accnts["SRC"].Balance)
+	assert.Equal(t, big.NewInt(10), accnts["DST1"].Balance)
+	assert.Equal(t, big.NewInt(20), accnts["DST2"].Balance)
+}
+
+func TestTxProcessor_ProcessTransactionBatchTransferInsufficientFundsShouldErrAndRevert(t *testing.T) {
+	accnts, accountsAdapter := createBatchTransferAccounts(t, "SRC", "DST1", "DST2")
+	accnts["SRC"].Nonce = 4
+	accnts["SRC"].Balance = big.NewInt(35)
+	revertCalled := false
+	accountsAdapter.RevertToSnapshotCalled = func(snapshot int) error {
+		revertCalled = true
+		return nil
+	}
+
+	execTx, _ := txproc.NewTxProcessor(
+		accountsAdapter,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		mock.NewOneShardCoordinatorMock(),
+		&mock.FeeHandlerStub{
+			ComputeFeeCalled: func(tx *transaction.Transaction) *big.Int {
+				return big.NewInt(9)
+			},
+		},
+	)
+
+	err := execTx.ProcessTransaction(createBatchTransferTx(), 4)
+
+	assert.Equal(t, process.ErrInsufficientFunds, err)
+	assert.True(t, revertCalled)
+	assert.Equal(t, uint64(4), accnts["SRC"].Nonce)
+}
+
+func TestTxProcessor_ProcessTransactionBatchTransferSenderInOtherShardShouldCreditOnlyOwnReceivers(t *testing.T) {
+	accnts, accountsAdapter := createBatchTransferAccounts(t, "DST1")
+
+	shardCoordinator := mock.NewOneShardCoordinatorMock()
+	shardCoordinator.ComputeIdCalled = func(container state.AddressContainer) uint32 {
+		if bytes.Equal(container.Bytes(), []byte("DST1")) {
+			return 0
+		}
+
+		return 1
+	}
+
+	execTx, _ := txproc.NewTxProcessor(
+		accountsAdapter,
+		mock.HasherMock{},
+		&mock.AddressConverterMock{},
+		&mock.MarshalizerMock{},
+		shardCoordinator,
+		&mock.FeeHandlerStub{
+			CheckTxGasCalled: func(tx *transaction.Transaction) error {
+				assert.Fail(t, "the gas of the batch is checked by the sender shard")
+				return nil
+			},
+		},
+	)
+
+	err := execTx.ProcessTransaction(createBatchTransferTx(), 4)
+
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(10), accnts["DST1"].Balance)
+}
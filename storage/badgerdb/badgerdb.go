@@ -1,6 +1,7 @@
 package badgerdb
 
 import (
+	"bytes"
 	"os"
 
 	"github.com/dgraph-io/badger"
@@ -52,6 +53,22 @@ func (s *DB) Put(key, val []byte) error {
 	return err
 }
 
+// PutBatch adds all the (key, val) pairs to the storage medium in a single atomic transaction
+func (s *DB) PutBatch(data map[string][]byte) error {
+	err := s.db.Update(func(txn *badger.Txn) error {
+		for key, val := range data {
+			err := txn.Set([]byte(key), val)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
+
 // Get returns the value associated to the key
 func (s *DB) Get(key []byte) ([]byte, error) {
 	var value []byte
@@ -91,6 +108,39 @@ func (s *DB) Has(key []byte) error {
 	return err
 }
 
+// Iterate calls the handler for every (key, val) pair whose key starts with prefix and is within [start, end), in
+// ascending key order, until the handler returns false. Nil bounds leave the interval unbounded on that side
+func (s *DB) Iterate(prefix []byte, start []byte, end []byte, handler func(key []byte, val []byte) bool) error {
+	seek := start
+	if bytes.Compare(prefix, start) > 0 {
+		seek = prefix
+	}
+
+	return s.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Seek(seek); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			key := item.KeyCopy(nil)
+			if end != nil && bytes.Compare(key, end) >= 0 {
+				break
+			}
+
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+
+			if !handler(key, val) {
+				break
+			}
+		}
+
+		return nil
+	})
+}
+
 // Init initializes the storage medium and prepares it for usage
 func (s *DB) Init() error {
 	// no special initialization needed
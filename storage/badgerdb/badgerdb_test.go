@@ -121,3 +121,57 @@ func TestDestroy(t *testing.T) {
 
 	assert.Nil(t, err, "no error expected but got %s", err)
 }
+
+func TestPutBatchAllPresent(t *testing.T) {
+	data := map[string][]byte{
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+	}
+	ldb := createBadgerDb(t)
+
+	err := ldb.PutBatch(data)
+
+	assert.Nil(t, err, "error saving batch in db")
+
+	for key, val := range data {
+		v, err := ldb.Get([]byte(key))
+
+		assert.Nil(t, err, "error not expected, but got %s", err)
+		assert.Equalf(t, v, val, "read:%s but expected: %s", v, val)
+	}
+}
+
+func TestIterateShouldWalkKeysInRangeInOrder(t *testing.T) {
+	ldb := createBadgerDb(t)
+	for _, key := range []string{"b1", "a3", "a1", "c1", "a2", "a4"} {
+		err := ldb.Put([]byte(key), []byte("value "+key))
+		assert.Nil(t, err, "error saving in db")
+	}
+
+	walked := make([]string, 0)
+	err := ldb.Iterate([]byte("a"), []byte("a2"), []byte("a4"), func(key []byte, val []byte) bool {
+		assert.Equal(t, "value "+string(key), string(val))
+		walked = append(walked, string(key))
+		return true
+	})
+
+	assert.Nil(t, err, "error not expected, but got %s", err)
+	assert.Equal(t, []string{"a2", "a3"}, walked)
+}
+
+func TestIterateShouldStopWhenHandlerReturnsFalse(t *testing.T) {
+	ldb := createBadgerDb(t)
+	for _, key := range []string{"a1", "a2", "a3"} {
+		err := ldb.Put([]byte(key), []byte("value "+key))
+		assert.Nil(t, err, "error saving in db")
+	}
+
+	walked := make([]string, 0)
+	err := ldb.Iterate(nil, nil, nil, func(key []byte, val []byte) bool {
+		walked = append(walked, string(key))
+		return len(walked) < 2
+	})
+
+	assert.Nil(t, err, "error not expected, but got %s", err)
+	assert.Equal(t, []string{"a1", "a2"}, walked)
+}
@@ -1,6 +1,7 @@
 package boltdb
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"os"
@@ -74,6 +75,23 @@ func (s *DB) Put(key, val []byte) error {
 	return err
 }
 
+// PutBatch adds all the (key, val) pairs to the storage medium in a single atomic transaction
+func (s *DB) PutBatch(data map[string][]byte) error {
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(s.parentFolder))
+		for key, val := range data {
+			err := b.Put([]byte(key), val)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	return err
+}
+
 // Get returns the value associated to the key
 func (s *DB) Get(key []byte) ([]byte, error) {
 	var val []byte
@@ -106,6 +124,40 @@ func (s *DB) Has(key []byte) error {
 	})
 }
 
+// Iterate calls the handler for every (key, val) pair whose key starts with prefix and is within [start, end), in
+// ascending key order, until the handler returns false. Nil bounds leave the interval unbounded on that side
+func (s *DB) Iterate(prefix []byte, start []byte, end []byte, handler func(key []byte, val []byte) bool) error {
+	seek := start
+	if bytes.Compare(prefix, start) > 0 {
+		seek = prefix
+	}
+
+	return s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(s.parentFolder)).Cursor()
+
+		k, v := c.First()
+		if len(seek) > 0 {
+			k, v = c.Seek(seek)
+		}
+
+		for ; k != nil; k, v = c.Next() {
+			if end != nil && bytes.Compare(k, end) >= 0 {
+				break
+			}
+			if !bytes.HasPrefix(k, prefix) {
+				break
+			}
+
+			// the cursor key and value are only valid during the transaction
+			if !handler(append([]byte{}, k...), append([]byte{}, v...)) {
+				break
+			}
+		}
+
+		return nil
+	})
+}
+
 // Init initializes the storage medium and prepares it for usage
 func (s *DB) Init() error {
 	// no special initialization needed
@@ -0,0 +1,587 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const epochDirPrefix = "Epoch_"
+
+// PersisterCreator creates the persister storing the data of an epoch in the given directory
+type PersisterCreator func(path string) (Persister, error)
+
+// epochPersister holds the persister of an epoch. An expired persister still being iterated is destroyed once the
+// last of its iterations ends
+type epochPersister struct {
+	epoch     uint32
+	path      string
+	persister Persister
+	refs      int
+	expired   bool
+}
+
+// EpochUnit is a storer keeping the data of each epoch in its own persister, found in a directory of the unit's
+// base path. It writes into the persister of the current epoch and reads through the persisters of the kept epochs.
+// When moved to a new epoch, the persisters of the epochs expired are destroyed, unless the unit is an archive
+type EpochUnit struct {
+	lock            sync.RWMutex
+	cacher          Cacher
+	basePath        string
+	numEpochsToKeep uint32
+	archive         bool
+	createPersister PersisterCreator
+	// sorted from the current epoch to the oldest kept one
+	persisters []*epochPersister
+}
+
+// NewEpochUnit creates an epoch unit over the given base path, opening the persisters of the epochs found there
+// which are still to be kept and destroying the others. The unit starts from the newest epoch found, or from epoch 0
+func NewEpochUnit(
+	c Cacher,
+	basePath string,
+	numEpochsToKeep uint32,
+	archive bool,
+	createPersister PersisterCreator,
+) (*EpochUnit, error) {
+
+	if c == nil {
+		return nil, errNilCacher
+	}
+	if createPersister == nil {
+		return nil, errNilPersisterCreator
+	}
+	if numEpochsToKeep == 0 {
+		return nil, errInvalidNumEpochsToKeep
+	}
+
+	eu := &EpochUnit{
+		cacher:          c,
+		basePath:        basePath,
+		numEpochsToKeep: numEpochsToKeep,
+		archive:         archive,
+		createPersister: createPersister,
+		persisters:      make([]*epochPersister, 0),
+	}
+
+	epochs, err := eu.storedEpochs()
+	if err != nil {
+		return nil, err
+	}
+	if len(epochs) == 0 {
+		epochs = []uint32{0}
+	}
+
+	for _, epoch := range epochs {
+		err = eu.openEpoch(epoch)
+		if err != nil {
+			_ = eu.Close()
+			return nil, err
+		}
+	}
+
+	err = eu.destroyExpiredEpochs()
+	if err != nil {
+		_ = eu.Close()
+		return nil, err
+	}
+
+	return eu, nil
+}
+
+// NewEpochUnitFromConf creates a new epoch unit from a cache config and a database config, whose file path is the
+// directory under which the databases of the epochs are created
+func NewEpochUnitFromConf(
+	cacheConf CacheConfig,
+	dbConf DBConfig,
+	numEpochsToKeep uint32,
+	archive bool,
+) (*EpochUnit, error) {
+
+	cache, err := NewCache(cacheConf.Type, cacheConf.Size, cacheConf.Shards)
+	if err != nil {
+		return nil, err
+	}
+
+	createPersister := func(path string) (Persister, error) {
+		return NewDB(dbConf.Type, path)
+	}
+
+	return NewEpochUnit(cache, dbConf.FilePath, numEpochsToKeep, archive, createPersister)
+}
+
+// storedEpochs returns, in ascending order, the epochs having a directory in the unit's base path
+func (eu *EpochUnit) storedEpochs() ([]uint32, error) {
+	infos, err := ioutil.ReadDir(eu.basePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	epochs := make([]uint32, 0)
+	for _, info := range infos {
+		if !info.IsDir() || !strings.HasPrefix(info.Name(), epochDirPrefix) {
+			continue
+		}
+
+		epoch, errParse := strconv.ParseUint(strings.TrimPrefix(info.Name(), epochDirPrefix), 10, 32)
+		if errParse != nil {
+			continue
+		}
+
+		epochs = append(epochs, uint32(epoch))
+	}
+
+	sort.Slice(epochs, func(i, j int) bool {
+		return epochs[i] < epochs[j]
+	})
+
+	return epochs, nil
+}
+
+// openEpoch opens the persister of the given epoch and makes it the current one
+func (eu *EpochUnit) openEpoch(epoch uint32) error {
+	path := filepath.Join(eu.basePath, fmt.Sprintf("%s%d", epochDirPrefix, epoch))
+	persister, err := eu.createPersister(path)
+	if err != nil {
+		return err
+	}
+
+	err = persister.Init()
+	if err != nil {
+		_ = persister.Close()
+		return err
+	}
+
+	current := &epochPersister{
+		epoch:     epoch,
+		path:      path,
+		persister: persister,
+	}
+	eu.persisters = append([]*epochPersister{current}, eu.persisters...)
+
+	return nil
+}
+
+// destroyExpiredEpochs destroys the persisters of the epochs older than the ones to be kept, unless the unit is an
+// archive. The cache is cleared, as it may hold data of the destroyed epochs
+func (eu *EpochUnit) destroyExpiredEpochs() error {
+	if eu.archive || uint32(len(eu.persisters)) <= eu.numEpochsToKeep {
+		return nil
+	}
+
+	expired := eu.persisters[eu.numEpochsToKeep:]
+	eu.persisters = eu.persisters[:eu.numEpochsToKeep]
+	eu.cacher.Clear()
+
+	for _, ep := range expired {
+		if ep.refs > 0 {
+			ep.expired = true
+			continue
+		}
+
+		err := ep.destroy()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (ep *epochPersister) destroy() error {
+	err := ep.persister.Destroy()
+	if err != nil {
+		return err
+	}
+
+	log.Info(fmt.Sprintf("destroyed the expired epoch %d storage found in %s", ep.epoch, ep.path))
+	return nil
+}
+
+// SetEpoch moves the unit to the given epoch, opening its persister, in which the next data is written, and
+// destroying the persisters of the epochs which expired. Setting an epoch not newer than the current one does nothing,
+// while an epoch skipping epochs is rejected, so a wrong epoch can not destroy the persisters of all the kept epochs
+func (eu *EpochUnit) SetEpoch(epoch uint32) error {
+	eu.lock.Lock()
+	defer eu.lock.Unlock()
+
+	if epoch <= eu.persisters[0].epoch {
+		return nil
+	}
+	if epoch > eu.persisters[0].epoch+1 {
+		return errEpochSkipped
+	}
+
+	err := eu.openEpoch(epoch)
+	if err != nil {
+		return err
+	}
+
+	return eu.destroyExpiredEpochs()
+}
+
+// Epoch returns the epoch the unit currently writes into
+func (eu *EpochUnit) Epoch() uint32 {
+	eu.lock.RLock()
+	defer eu.lock.RUnlock()
+
+	return eu.persisters[0].epoch
+}
+
+// Put adds data to both cache and the persister of the current epoch
+func (eu *EpochUnit) Put(key, data []byte) error {
+	eu.lock.Lock()
+	defer eu.lock.Unlock()
+
+	// no need to add if already present in cache
+	has := eu.cacher.Has(key)
+	if has {
+		return nil
+	}
+
+	eu.cacher.Put(key, data)
+
+	err := eu.persisters[0].persister.Put(key, data)
+	if err != nil {
+		eu.cacher.Remove(key)
+		return err
+	}
+
+	return nil
+}
+
+// PutBatch adds all the (key, data) pairs not already cached to both cache and the persister of the current epoch,
+// in a single atomic write if the persister is a Batcher. Otherwise the pairs are written one by one and a failing
+// write leaves the pairs written before it in the persister, without adding any of them to the cache
+func (eu *EpochUnit) PutBatch(data map[string][]byte) error {
+	eu.lock.Lock()
+	defer eu.lock.Unlock()
+
+	toPersist := make(map[string][]byte, len(data))
+	for key, val := range data {
+		// no need to add if already present in cache
+		if eu.cacher.Has([]byte(key)) {
+			continue
+		}
+
+		toPersist[key] = val
+	}
+
+	persister := eu.persisters[0].persister
+	batcher, ok := persister.(Batcher)
+	if ok {
+		err := batcher.PutBatch(toPersist)
+		if err != nil {
+			return err
+		}
+	} else {
+		for key, val := range toPersist {
+			err := persister.Put([]byte(key), val)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for key, val := range toPersist {
+		eu.cacher.Put([]byte(key), val)
+	}
+
+	return nil
+}
+
+// Get searches the key in the cache and then in the persisters of the kept epochs, from the current one to the
+// oldest one. In case it is found in a persister, the cache is updated with the value as well
+func (eu *EpochUnit) Get(key []byte) ([]byte, error) {
+	eu.lock.Lock()
+	defer eu.lock.Unlock()
+
+	v, ok := eu.cacher.Get(key)
+	if ok {
+		return v.([]byte), nil
+	}
+
+	var err error
+	for _, ep := range eu.persisters {
+		var data []byte
+		data, err = ep.persister.Get(key)
+		if err == nil {
+			eu.cacher.Put(key, data)
+			return data, nil
+		}
+	}
+
+	return nil, err
+}
+
+// Has checks if the key is in the cache or in the persisters of the kept epochs
+func (eu *EpochUnit) Has(key []byte) error {
+	eu.lock.RLock()
+	defer eu.lock.RUnlock()
+
+	return eu.has(key)
+}
+
+func (eu *EpochUnit) has(key []byte) error {
+	has := eu.cacher.Has(key)
+	if has {
+		return nil
+	}
+
+	var err error
+	for _, ep := range eu.persisters {
+		err = ep.persister.Has(key)
+		if err == nil {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// HasOrAdd checks if the key is present in the unit and if not adds it to the current epoch
+func (eu *EpochUnit) HasOrAdd(key []byte, value []byte) error {
+	eu.lock.Lock()
+	defer eu.lock.Unlock()
+
+	err := eu.has(key)
+	if err == nil {
+		return nil
+	}
+
+	eu.cacher.Put(key, value)
+
+	err = eu.persisters[0].persister.Put(key, value)
+	if err != nil {
+		eu.cacher.Remove(key)
+		return err
+	}
+
+	return nil
+}
+
+// Iterate calls the handler for every (key, data) pair whose key starts with prefix and is within [start, end), in
+// ascending key order, until the handler returns false. Nil bounds leave the interval unbounded on that side. The
+// pairs of all the kept epochs are merged with the cached ones while walking them, the newest data taking
+// precedence. The persisters must be Iterators
+func (eu *EpochUnit) Iterate(prefix []byte, start []byte, end []byte, handler func(key []byte, data []byte) bool) error {
+	// the sources are collected beforehand, so the handler is called without holding the lock. The persisters are
+	// referenced until the iteration ends, so an epoch expiring meanwhile is not destroyed while still iterated
+	persisters, cached, err := eu.iterationSources(prefix, start, end)
+	if err != nil {
+		return err
+	}
+	defer eu.releasePersisters(persisters)
+
+	done := make(chan struct{})
+	cursors := make([]*epochCursor, 0, len(persisters))
+	for _, ep := range persisters {
+		cursors = append(cursors, startEpochCursor(ep.persister.(Iterator), prefix, start, end, done))
+	}
+	defer stopEpochCursors(cursors, done)
+
+	for _, ec := range cursors {
+		err = ec.next()
+		if err != nil {
+			return err
+		}
+	}
+
+	idx := 0
+	for {
+		var key, data []byte
+		found := false
+		// the cursors go from the current epoch to the oldest, so on equal keys the newest epoch is kept
+		for _, ec := range cursors {
+			if ec.valid && (!found || bytes.Compare(ec.head.key, key) < 0) {
+				key, data, found = ec.head.key, ec.head.data, true
+			}
+		}
+		if idx < len(cached) && (!found || bytes.Compare(cached[idx].key, key) <= 0) {
+			key, data, found = cached[idx].key, cached[idx].data, true
+			idx++
+		}
+		if !found {
+			return nil
+		}
+
+		for _, ec := range cursors {
+			if !ec.valid || !bytes.Equal(ec.head.key, key) {
+				continue
+			}
+
+			err = ec.next()
+			if err != nil {
+				return err
+			}
+		}
+
+		if !handler(key, data) {
+			return nil
+		}
+	}
+}
+
+// iterationSources references and returns the persisters of the kept epochs, from the current one to the oldest, and
+// the cached pairs in range sorted ascending by key
+func (eu *EpochUnit) iterationSources(prefix []byte, start []byte, end []byte) ([]*epochPersister, []keyData, error) {
+	eu.lock.Lock()
+	defer eu.lock.Unlock()
+
+	for _, ep := range eu.persisters {
+		_, ok := ep.persister.(Iterator)
+		if !ok {
+			return nil, nil, errIterationNotSupported
+		}
+	}
+
+	persisters := make([]*epochPersister, len(eu.persisters))
+	copy(persisters, eu.persisters)
+	for _, ep := range persisters {
+		ep.refs++
+	}
+
+	return persisters, pairsInRange(eu.cacher, prefix, start, end), nil
+}
+
+// releasePersisters drops the references taken for an iteration, destroying the expired persisters no longer iterated
+func (eu *EpochUnit) releasePersisters(persisters []*epochPersister) {
+	eu.lock.Lock()
+	defer eu.lock.Unlock()
+
+	for _, ep := range persisters {
+		ep.refs--
+		if ep.refs > 0 || !ep.expired {
+			continue
+		}
+
+		err := ep.destroy()
+		if err != nil {
+			log.Error(err.Error())
+		}
+	}
+}
+
+// epochCursor walks the pairs of an epoch persister one at a time, the persister being iterated on its own goroutine
+type epochCursor struct {
+	pairs    chan keyData
+	errc     chan error
+	head     keyData
+	valid    bool
+	finished bool
+}
+
+func startEpochCursor(iterator Iterator, prefix []byte, start []byte, end []byte, done <-chan struct{}) *epochCursor {
+	ec := &epochCursor{
+		pairs: make(chan keyData),
+		errc:  make(chan error, 1),
+	}
+
+	go func() {
+		ec.errc <- iterator.Iterate(prefix, start, end, func(key []byte, data []byte) bool {
+			select {
+			case ec.pairs <- keyData{key: key, data: data}:
+				return true
+			case <-done:
+				return false
+			}
+		})
+		close(ec.pairs)
+	}()
+
+	return ec
+}
+
+// next moves the cursor to the following pair. Once the pairs are exhausted the cursor is no longer valid and the
+// error of the persister iteration is returned
+func (ec *epochCursor) next() error {
+	ec.head, ec.valid = <-ec.pairs
+	if ec.valid {
+		return nil
+	}
+
+	ec.finished = true
+	return <-ec.errc
+}
+
+// stopEpochCursors stops the iterations that are still running and waits for them to end
+func stopEpochCursors(cursors []*epochCursor, done chan struct{}) {
+	close(done)
+	for _, ec := range cursors {
+		if ec.finished {
+			continue
+		}
+
+		for range ec.pairs {
+		}
+		<-ec.errc
+	}
+}
+
+// Remove removes the data associated to the given key from the cache and from the persisters of all kept epochs
+func (eu *EpochUnit) Remove(key []byte) error {
+	eu.lock.Lock()
+	defer eu.lock.Unlock()
+
+	eu.cacher.Remove(key)
+
+	var err error
+	for _, ep := range eu.persisters {
+		errRemove := ep.persister.Remove(key)
+		if errRemove != nil && err == nil {
+			err = errRemove
+		}
+	}
+
+	return err
+}
+
+// ClearCache cleans up the entire cache
+func (eu *EpochUnit) ClearCache() {
+	eu.cacher.Clear()
+}
+
+// Close closes the persisters of all kept epochs, keeping their data
+func (eu *EpochUnit) Close() error {
+	eu.lock.Lock()
+	defer eu.lock.Unlock()
+
+	eu.cacher.Clear()
+
+	var err error
+	for _, ep := range eu.persisters {
+		errClose := ep.persister.Close()
+		if errClose != nil && err == nil {
+			err = errClose
+		}
+	}
+
+	return err
+}
+
+// DestroyUnit cleans up the cache and destroys the persisters of all kept epochs
+func (eu *EpochUnit) DestroyUnit() error {
+	eu.lock.Lock()
+	defer eu.lock.Unlock()
+
+	eu.cacher.Clear()
+
+	var err error
+	for _, ep := range eu.persisters {
+		errDestroy := ep.persister.Destroy()
+		if errDestroy != nil && err == nil {
+			err = errDestroy
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,274 @@
+package storage_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/numbatx/gn-numbat/storage"
+	"github.com/numbatx/gn-numbat/storage/leveldb"
+	"github.com/numbatx/gn-numbat/storage/lrucache"
+	"github.com/numbatx/gn-numbat/storage/memorydb"
+	"github.com/stretchr/testify/assert"
+)
+
+func memoryPersisterCreator(persisters map[string]storage.Persister) storage.PersisterCreator {
+	return func(path string) (storage.Persister, error) {
+		mdb, err := memorydb.New()
+		persisters[path] = mdb
+		return mdb, err
+	}
+}
+
+func initEpochUnit(t *testing.T, numEpochsToKeep uint32, archive bool) (*storage.EpochUnit, map[string]storage.Persister) {
+	cache, _ := lrucache.NewCache(10)
+	persisters := make(map[string]storage.Persister)
+
+	eu, err := storage.NewEpochUnit(cache, "unit", numEpochsToKeep, archive, memoryPersisterCreator(persisters))
+	assert.Nil(t, err, "failed to create epoch unit")
+
+	return eu, persisters
+}
+
+func TestNewEpochUnit_NilCacherShouldErr(t *testing.T) {
+	eu, err := storage.NewEpochUnit(nil, "unit", 2, false, memoryPersisterCreator(make(map[string]storage.Persister)))
+
+	assert.Nil(t, eu)
+	assert.NotNil(t, err, "expected failure")
+}
+
+func TestNewEpochUnit_NilPersisterCreatorShouldErr(t *testing.T) {
+	cache, _ := lrucache.NewCache(10)
+	eu, err := storage.NewEpochUnit(cache, "unit", 2, false, nil)
+
+	assert.Nil(t, eu)
+	assert.NotNil(t, err, "expected failure")
+}
+
+func TestNewEpochUnit_ZeroEpochsToKeepShouldErr(t *testing.T) {
+	cache, _ := lrucache.NewCache(10)
+	eu, err := storage.NewEpochUnit(cache, "unit", 0, false, memoryPersisterCreator(make(map[string]storage.Persister)))
+
+	assert.Nil(t, eu)
+	assert.NotNil(t, err, "expected failure")
+}
+
+func TestNewEpochUnit_ShouldStartFromEpochZero(t *testing.T) {
+	eu, persisters := initEpochUnit(t, 2, false)
+
+	assert.Equal(t, uint32(0), eu.Epoch())
+	assert.NotNil(t, persisters[filepath.Join("unit", "Epoch_0")])
+}
+
+func TestEpochUnit_SetEpochShouldWriteInNewEpochAndReadThroughOldOnes(t *testing.T) {
+	eu, persisters := initEpochUnit(t, 2, false)
+	key0, val0 := []byte("key0"), []byte("value0")
+	key1, val1 := []byte("key1"), []byte("value1")
+
+	_ = eu.Put(key0, val0)
+	err := eu.SetEpoch(1)
+	assert.Nil(t, err)
+	_ = eu.Put(key1, val1)
+	eu.ClearCache()
+
+	assert.Equal(t, uint32(1), eu.Epoch())
+	assert.Nil(t, persisters[filepath.Join("unit", "Epoch_1")].Has(key1))
+	assert.NotNil(t, persisters[filepath.Join("unit", "Epoch_1")].Has(key0))
+
+	v, err := eu.Get(key0)
+	assert.Nil(t, err)
+	assert.Equal(t, val0, v)
+	assert.Nil(t, eu.Has(key1))
+}
+
+func TestEpochUnit_SetOlderEpochShouldDoNothing(t *testing.T) {
+	eu, _ := initEpochUnit(t, 2, false)
+
+	_ = eu.SetEpoch(1)
+	_ = eu.SetEpoch(2)
+	err := eu.SetEpoch(1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(2), eu.Epoch())
+}
+
+func TestEpochUnit_SetEpochSkippingEpochsShouldErr(t *testing.T) {
+	eu, persisters := initEpochUnit(t, 2, false)
+	key0, val0 := []byte("key0"), []byte("value0")
+	_ = eu.Put(key0, val0)
+	_ = eu.SetEpoch(1)
+
+	err := eu.SetEpoch(1000)
+
+	assert.NotNil(t, err, "expected failure")
+	assert.Equal(t, uint32(1), eu.Epoch())
+	assert.Equal(t, 2, len(persisters))
+	v, err := eu.Get(key0)
+	assert.Nil(t, err)
+	assert.Equal(t, val0, v)
+}
+
+func TestEpochUnit_SetEpochShouldDestroyExpiredEpochs(t *testing.T) {
+	eu, _ := initEpochUnit(t, 2, false)
+	key0, val0 := []byte("key0"), []byte("value0")
+	key1, val1 := []byte("key1"), []byte("value1")
+
+	_ = eu.Put(key0, val0)
+	_ = eu.SetEpoch(1)
+	_ = eu.Put(key1, val1)
+	_ = eu.SetEpoch(2)
+
+	_, err := eu.Get(key0)
+	assert.NotNil(t, err, "expected the expired epoch data to be dropped")
+	v, err := eu.Get(key1)
+	assert.Nil(t, err)
+	assert.Equal(t, val1, v)
+}
+
+func TestEpochUnit_ArchiveShouldKeepAllEpochs(t *testing.T) {
+	eu, _ := initEpochUnit(t, 1, true)
+	key0, val0 := []byte("key0"), []byte("value0")
+
+	_ = eu.Put(key0, val0)
+	_ = eu.SetEpoch(1)
+	_ = eu.SetEpoch(2)
+	eu.ClearCache()
+
+	v, err := eu.Get(key0)
+	assert.Nil(t, err)
+	assert.Equal(t, val0, v)
+}
+
+func TestEpochUnit_RemoveShouldRemoveFromAllEpochs(t *testing.T) {
+	eu, _ := initEpochUnit(t, 2, false)
+	key, val := []byte("key"), []byte("value")
+
+	_ = eu.Put(key, val)
+	_ = eu.SetEpoch(1)
+	_ = eu.HasOrAdd(key, val)
+
+	err := eu.Remove(key)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, eu.Has(key))
+}
+
+func TestEpochUnit_IterateShouldMergeEpochsInOrder(t *testing.T) {
+	eu, _ := initEpochUnit(t, 2, false)
+
+	_ = eu.Put([]byte("key3"), []byte("value3"))
+	_ = eu.Put([]byte("key1"), []byte("value1"))
+	_ = eu.SetEpoch(1)
+	_ = eu.Put([]byte("key2"), []byte("value2"))
+	_ = eu.Put([]byte("other"), []byte("other value"))
+	eu.ClearCache()
+
+	walked := make([]string, 0)
+	err := eu.Iterate([]byte("key"), nil, nil, func(key []byte, data []byte) bool {
+		walked = append(walked, string(key))
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"key1", "key2", "key3"}, walked)
+}
+
+func TestEpochUnit_IterateShouldKeepTheNewestDataOfAKey(t *testing.T) {
+	eu, _ := initEpochUnit(t, 3, false)
+
+	_ = eu.Put([]byte("key1"), []byte("old value1"))
+	_ = eu.Put([]byte("key2"), []byte("old value2"))
+	_ = eu.SetEpoch(1)
+	eu.ClearCache()
+	_ = eu.Put([]byte("key1"), []byte("new value1"))
+	_ = eu.SetEpoch(2)
+	eu.ClearCache()
+	_ = eu.Put([]byte("key2"), []byte("cached value2"))
+
+	walked := make(map[string]string)
+	err := eu.Iterate([]byte("key"), nil, nil, func(key []byte, data []byte) bool {
+		walked[string(key)] = string(data)
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"key1": "new value1", "key2": "cached value2"}, walked)
+}
+
+func TestEpochUnit_IterateShouldStopWhenTheHandlerReturnsFalse(t *testing.T) {
+	eu, _ := initEpochUnit(t, 2, false)
+
+	_ = eu.Put([]byte("key1"), []byte("value1"))
+	_ = eu.Put([]byte("key3"), []byte("value3"))
+	_ = eu.SetEpoch(1)
+	_ = eu.Put([]byte("key2"), []byte("value2"))
+	_ = eu.Put([]byte("key4"), []byte("value4"))
+	eu.ClearCache()
+
+	walked := make([]string, 0)
+	err := eu.Iterate([]byte("key"), []byte("key2"), nil, func(key []byte, data []byte) bool {
+		walked = append(walked, string(key))
+		return len(walked) < 2
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"key2", "key3"}, walked)
+}
+
+func TestEpochUnit_IterateShouldDestroyAnEpochExpiredMeanwhileOnlyAfterTheIterationEnds(t *testing.T) {
+	eu, persisters := initEpochUnit(t, 1, false)
+
+	_ = eu.Put([]byte("key1"), []byte("value1"))
+	_ = eu.Put([]byte("key2"), []byte("value2"))
+	eu.ClearCache()
+	epoch0 := persisters[filepath.Join("unit", "Epoch_0")]
+
+	walked := make([]string, 0)
+	err := eu.Iterate([]byte("key"), nil, nil, func(key []byte, data []byte) bool {
+		if len(walked) == 0 {
+			//the iterated epoch expires, its persister has to outlive the iteration
+			assert.Nil(t, eu.SetEpoch(1))
+			assert.Nil(t, epoch0.Has([]byte("key2")))
+		}
+		walked = append(walked, string(key))
+		return true
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"key1", "key2"}, walked)
+	assert.NotNil(t, epoch0.Has([]byte("key2")))
+}
+
+func TestNewEpochUnit_ShouldResumeFromStoredEpochsAndDeleteExpiredOnes(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "epoch_unit_temp")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+	createLevelDb := func(path string) (storage.Persister, error) {
+		return leveldb.NewDB(path)
+	}
+	key1, val1 := []byte("key1"), []byte("value1")
+
+	cache, _ := lrucache.NewCache(10)
+	eu, _ := storage.NewEpochUnit(cache, dir, 3, false, createLevelDb)
+	_ = eu.SetEpoch(1)
+	_ = eu.Put(key1, val1)
+	_ = eu.SetEpoch(2)
+	_ = eu.Close()
+
+	cache, _ = lrucache.NewCache(10)
+	eu, err := storage.NewEpochUnit(cache, dir, 2, false, createLevelDb)
+	assert.Nil(t, err)
+	defer func() {
+		_ = eu.Close()
+	}()
+
+	assert.Equal(t, uint32(2), eu.Epoch())
+	v, err := eu.Get(key1)
+	assert.Nil(t, err)
+	assert.Equal(t, val1, v)
+
+	_, err = os.Stat(filepath.Join(dir, "Epoch_0"))
+	assert.True(t, os.IsNotExist(err))
+}
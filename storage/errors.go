@@ -17,3 +17,11 @@ var errMissingOrInvalidParameter = errors.New("parameter is missing or invalid")
 var errNotSupportedDBType = errors.New("nit supported db type")
 
 var errNotSupportedHashType = errors.New("hash type not supported")
+
+var errIterationNotSupported = errors.New("the persister does not support iterating its keys")
+
+var errNilPersisterCreator = errors.New("expected not nil persister creator")
+
+var errInvalidNumEpochsToKeep = errors.New("the number of epochs to keep should be greater than 0")
+
+var errEpochSkipped = errors.New("the new epoch should follow the current epoch")
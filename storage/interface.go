@@ -18,6 +18,23 @@ type Persister interface {
 	Destroy() error
 }
 
+// Batcher is a persister which can write several (key, val) pairs at once, atomically: after a crash either all of
+// them or none of them are found in the persistance medium
+type Batcher interface {
+	Persister
+	// PutBatch adds all the (key, val) pairs to the persistance medium in a single write
+	PutBatch(data map[string][]byte) error
+}
+
+// Iterator is a persister able to walk the (key, val) pairs it stores in ascending key order
+type Iterator interface {
+	Persister
+	// Iterate calls the handler for every (key, val) pair whose key starts with prefix and is within [start, end),
+	// in ascending key order, until the handler returns false. Nil bounds leave the interval unbounded on that side.
+	// The handler must not write to the persistance medium
+	Iterate(prefix []byte, start []byte, end []byte, handler func(key []byte, val []byte) bool) error
+}
+
 // Cacher provides caching services
 type Cacher interface {
 	// Clear is used to completely clear the cache.
@@ -63,10 +80,19 @@ type BloomFilter interface {
 // represented by a cache and second layer by a persitent storage (DB-like)
 type Storer interface {
 	Put(key, data []byte) error
+	PutBatch(data map[string][]byte) error
 	Get(key []byte) ([]byte, error)
 	Has(key []byte) error
 	HasOrAdd(key []byte, value []byte) error
+	Iterate(prefix []byte, start []byte, end []byte, handler func(key []byte, val []byte) bool) error
 	Remove(key []byte) error
 	ClearCache()
 	DestroyUnit() error
 }
+
+// EpochStorer is a storer keeping its data per epoch, which can be moved to a newer epoch
+type EpochStorer interface {
+	Storer
+	// SetEpoch moves the storer to the given epoch, in which the next data is written
+	SetEpoch(epoch uint32) error
+}
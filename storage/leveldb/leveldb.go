@@ -1,11 +1,13 @@
 package leveldb
 
 import (
+	"bytes"
 	"errors"
 	"os"
 
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
 const maxOpenFilesPerTable = 50
@@ -15,10 +17,13 @@ const rwxOwner = 0700
 
 var errKeyNotFound = errors.New("Key not found")
 
+var errReadOnly = errors.New("leveldb database opened as read only")
+
 // DB holds a pointer to the leveldb database and the path to where it is stored.
 type DB struct {
-	db   *leveldb.DB
-	path string
+	db       *leveldb.DB
+	path     string
+	readOnly bool
 }
 
 // NewDB is a constructor for the leveldb persister
@@ -46,11 +51,44 @@ func NewDB(path string) (s *DB, err error) {
 	return dbStore, nil
 }
 
+// NewReadOnlyDB is a constructor for a leveldb persister opened over an existing database, which it does not
+// modify. Writes and removals fail and destroying it only closes the database
+func NewReadOnlyDB(path string) (s *DB, err error) {
+	options := &opt.Options{
+		OpenFilesCacheCapacity: maxOpenFilesPerTable,
+		ErrorIfMissing:         true,
+		ReadOnly:               true,
+	}
+
+	db, err := leveldb.OpenFile(path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	dbStore := &DB{
+		db:       db,
+		path:     path,
+		readOnly: true,
+	}
+
+	return dbStore, nil
+}
+
 // Put adds the value to the (key, val) storage medium
 func (s *DB) Put(key, val []byte) error {
 	return s.db.Put(key, val, nil)
 }
 
+// PutBatch adds all the (key, val) pairs to the storage medium in a single atomic write
+func (s *DB) PutBatch(data map[string][]byte) error {
+	batch := new(leveldb.Batch)
+	for key, val := range data {
+		batch.Put([]byte(key), val)
+	}
+
+	return s.db.Write(batch, nil)
+}
+
 // Get returns the value associated to the key
 func (s *DB) Get(key []byte) ([]byte, error) {
 	has, err := s.db.Has(key, nil)
@@ -80,6 +118,33 @@ func (s *DB) Has(key []byte) error {
 	return errKeyNotFound
 }
 
+// Iterate calls the handler for every (key, val) pair whose key starts with prefix and is within [start, end), in
+// ascending key order, until the handler returns false. Nil bounds leave the interval unbounded on that side
+func (s *DB) Iterate(prefix []byte, start []byte, end []byte, handler func(key []byte, val []byte) bool) error {
+	iterRange := &util.Range{Start: start, Limit: end}
+	if bytes.Compare(prefix, start) > 0 {
+		iterRange.Start = prefix
+	}
+
+	iter := s.db.NewIterator(iterRange, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if !bytes.HasPrefix(iter.Key(), prefix) {
+			break
+		}
+
+		// the iterator reuses the key and value buffers
+		key := append([]byte{}, iter.Key()...)
+		val := append([]byte{}, iter.Value()...)
+		if !handler(key, val) {
+			break
+		}
+	}
+
+	return iter.Error()
+}
+
 // Init initializes the storage medium and prepares it for usage
 func (s *DB) Init() error {
 	// no special initialization needed
@@ -99,6 +164,10 @@ func (s *DB) Remove(key []byte) error {
 // Destroy removes the storage medium stored data
 func (s *DB) Destroy() error {
 	_ = s.db.Close()
+	if s.readOnly {
+		return errReadOnly
+	}
+
 	err := os.RemoveAll(s.path)
 	return err
 }
@@ -2,6 +2,8 @@ package leveldb_test
 
 import (
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/numbatx/gn-numbat/storage/leveldb"
@@ -121,3 +123,94 @@ func TestDestroy(t *testing.T) {
 
 	assert.Nil(t, err, "no error expected but got %s", err)
 }
+
+func TestNewReadOnlyDBMissingDatabaseShouldErr(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "leveldb_temp")
+
+	ldb, err := leveldb.NewReadOnlyDB(filepath.Join(dir, "missing"))
+
+	assert.Nil(t, ldb)
+	assert.NotNil(t, err)
+}
+
+func TestReadOnlyDBShouldReadButNotModify(t *testing.T) {
+	key, val := []byte("key"), []byte("value")
+	path, _ := ioutil.TempDir("", "leveldb_temp")
+	ldb, _ := leveldb.NewDB(path)
+	_ = ldb.Put(key, val)
+	_ = ldb.Close()
+
+	rodb, err := leveldb.NewReadOnlyDB(path)
+	assert.Nil(t, err)
+
+	v, err := rodb.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, val, v)
+	assert.NotNil(t, rodb.Put([]byte("other key"), val))
+	assert.NotNil(t, rodb.Remove(key))
+
+	err = rodb.Destroy()
+	assert.NotNil(t, err)
+	_, err = os.Stat(path)
+	assert.Nil(t, err)
+
+	ldb, _ = leveldb.NewDB(path)
+	v, err = ldb.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, val, v)
+	_ = ldb.Destroy()
+}
+
+func TestPutBatchAllPresent(t *testing.T) {
+	data := map[string][]byte{
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+	}
+	ldb := createLevelDb(t)
+
+	err := ldb.PutBatch(data)
+
+	assert.Nil(t, err, "error saving batch in db")
+
+	for key, val := range data {
+		v, err := ldb.Get([]byte(key))
+
+		assert.Nil(t, err, "error not expected, but got %s", err)
+		assert.Equalf(t, v, val, "read:%s but expected: %s", v, val)
+	}
+}
+
+func TestIterateShouldWalkKeysInRangeInOrder(t *testing.T) {
+	ldb := createLevelDb(t)
+	for _, key := range []string{"b1", "a3", "a1", "c1", "a2", "a4"} {
+		err := ldb.Put([]byte(key), []byte("value "+key))
+		assert.Nil(t, err, "error saving in db")
+	}
+
+	walked := make([]string, 0)
+	err := ldb.Iterate([]byte("a"), []byte("a2"), []byte("a4"), func(key []byte, val []byte) bool {
+		assert.Equal(t, "value "+string(key), string(val))
+		walked = append(walked, string(key))
+		return true
+	})
+
+	assert.Nil(t, err, "error not expected, but got %s", err)
+	assert.Equal(t, []string{"a2", "a3"}, walked)
+}
+
+func TestIterateShouldStopWhenHandlerReturnsFalse(t *testing.T) {
+	ldb := createLevelDb(t)
+	for _, key := range []string{"a1", "a2", "a3"} {
+		err := ldb.Put([]byte(key), []byte("value "+key))
+		assert.Nil(t, err, "error saving in db")
+	}
+
+	walked := make([]string, 0)
+	err := ldb.Iterate(nil, nil, nil, func(key []byte, val []byte) bool {
+		walked = append(walked, string(key))
+		return len(walked) < 2
+	})
+
+	assert.Nil(t, err, "error not expected, but got %s", err)
+	assert.Equal(t, []string{"a1", "a2"}, walked)
+}
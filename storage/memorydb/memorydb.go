@@ -1,9 +1,11 @@
 package memorydb
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 )
 
@@ -32,6 +34,18 @@ func (s *DB) Put(key, val []byte) error {
 	return nil
 }
 
+// PutBatch adds all the (key, val) pairs to the persistance medium at once
+func (s *DB) PutBatch(data map[string][]byte) error {
+	s.mutx.Lock()
+	defer s.mutx.Unlock()
+
+	for key, val := range data {
+		s.db[key] = val
+	}
+
+	return nil
+}
+
 // Get gets the value associated to the key, or reports an error
 func (s *DB) Get(key []byte) ([]byte, error) {
 	s.mutx.RLock()
@@ -59,6 +73,45 @@ func (s *DB) Has(key []byte) error {
 	return nil
 }
 
+// Iterate calls the handler for every (key, val) pair whose key starts with prefix and is within [start, end), in
+// ascending key order, until the handler returns false. Nil bounds leave the interval unbounded on that side
+func (s *DB) Iterate(prefix []byte, start []byte, end []byte, handler func(key []byte, val []byte) bool) error {
+	s.mutx.RLock()
+	keys := make([]string, 0)
+	for key := range s.db {
+		if isInRange([]byte(key), prefix, start, end) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	vals := make([][]byte, len(keys))
+	for i, key := range keys {
+		vals[i] = s.db[key]
+	}
+	s.mutx.RUnlock()
+
+	// the handler is called without holding the lock, as it may read from the persistance medium
+	for i, key := range keys {
+		if !handler([]byte(key), vals[i]) {
+			break
+		}
+	}
+
+	return nil
+}
+
+func isInRange(key []byte, prefix []byte, start []byte, end []byte) bool {
+	if !bytes.HasPrefix(key, prefix) {
+		return false
+	}
+	if bytes.Compare(key, start) < 0 {
+		return false
+	}
+
+	return end == nil || bytes.Compare(key, end) < 0
+}
+
 // Init initializes the storage medium and prepares it for usage
 func (s *DB) Init() error {
 	// no special initialization needed
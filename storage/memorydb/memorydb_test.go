@@ -131,3 +131,65 @@ func TestDestroy(t *testing.T) {
 
 	assert.Nil(t, err, "no error expected but got %s", err)
 }
+
+func TestPutBatchAllPresent(t *testing.T) {
+	data := map[string][]byte{
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+	}
+	mdb, err := memorydb.New()
+
+	assert.Nil(t, err, "failed to create memorydb: %s", err)
+
+	err = mdb.PutBatch(data)
+
+	assert.Nil(t, err, "error saving batch in db")
+
+	for key, val := range data {
+		v, err := mdb.Get([]byte(key))
+
+		assert.Nil(t, err, "error not expected but got %s", err)
+		assert.Equal(t, val, v, "expected %s but got %s", val, v)
+	}
+}
+
+func TestIterateShouldWalkKeysInRangeInOrder(t *testing.T) {
+	mdb, err := memorydb.New()
+
+	assert.Nil(t, err, "failed to create memorydb: %s", err)
+
+	for _, key := range []string{"b1", "a3", "a1", "c1", "a2", "a4"} {
+		err = mdb.Put([]byte(key), []byte("value "+key))
+		assert.Nil(t, err, "error saving in db")
+	}
+
+	walked := make([]string, 0)
+	err = mdb.Iterate([]byte("a"), []byte("a2"), []byte("a4"), func(key []byte, val []byte) bool {
+		assert.Equal(t, "value "+string(key), string(val))
+		walked = append(walked, string(key))
+		return true
+	})
+
+	assert.Nil(t, err, "error not expected but got %s", err)
+	assert.Equal(t, []string{"a2", "a3"}, walked)
+}
+
+func TestIterateShouldStopWhenHandlerReturnsFalse(t *testing.T) {
+	mdb, err := memorydb.New()
+
+	assert.Nil(t, err, "failed to create memorydb: %s", err)
+
+	for _, key := range []string{"a1", "a2", "a3"} {
+		err = mdb.Put([]byte(key), []byte("value "+key))
+		assert.Nil(t, err, "error saving in db")
+	}
+
+	walked := make([]string, 0)
+	err = mdb.Iterate(nil, nil, nil, func(key []byte, val []byte) bool {
+		walked = append(walked, string(key))
+		return len(walked) < 2
+	})
+
+	assert.Nil(t, err, "error not expected but got %s", err)
+	assert.Equal(t, []string{"a1", "a2"}, walked)
+}
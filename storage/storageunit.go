@@ -1,10 +1,12 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/base64"
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/numbatx/gn-numbat/core/logger"
@@ -43,6 +45,8 @@ const (
 	LvlDB    DBType = "LvlDB"
 	BadgerDB DBType = "BadgerDB"
 	BoltDB   DBType = "BoltDB"
+	// LvlDBReadOnly opens an existing leveldb database without modifying it, for tools inspecting a node's data
+	LvlDBReadOnly DBType = "LvlDBReadOnly"
 )
 
 const (
@@ -115,6 +119,54 @@ func (s *Unit) Put(key, data []byte) error {
 	return err
 }
 
+// PutBatch adds all the (key, data) pairs not already cached to both cache and persistence medium. If the persister
+// is a Batcher, the pairs are persisted in a single atomic write, otherwise they are persisted one by one and a
+// failing write leaves the pairs persisted before it in the persistence medium, without adding any of them to the cache
+func (s *Unit) PutBatch(data map[string][]byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	toPersist := make(map[string][]byte, len(data))
+	for key, val := range data {
+		// no need to add if already present in cache
+		if s.cacher.Has([]byte(key)) {
+			continue
+		}
+
+		toPersist[key] = val
+	}
+
+	err := s.persistBatch(toPersist)
+	if err != nil {
+		return err
+	}
+
+	for key, val := range toPersist {
+		s.cacher.Put([]byte(key), val)
+		if s.bloomFilter != nil {
+			s.bloomFilter.Add([]byte(key))
+		}
+	}
+
+	return nil
+}
+
+func (s *Unit) persistBatch(data map[string][]byte) error {
+	batcher, ok := s.persister.(Batcher)
+	if ok {
+		return batcher.PutBatch(data)
+	}
+
+	for key, val := range data {
+		err := s.persister.Put([]byte(key), val)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Get searches the key in the cache. In case it is not found, it searches
 // for the key in bloom filter first and if found
 // it further searches it in the associated database.
@@ -207,6 +259,94 @@ func (s *Unit) HasOrAdd(key []byte, value []byte) error {
 	return nil
 }
 
+// Iterate calls the handler for every (key, data) pair whose key starts with prefix and is within [start, end), in
+// ascending key order, until the handler returns false. Nil bounds leave the interval unbounded on that side. The
+// persisted pairs are merged with the cached ones, the cached data taking precedence. The persister must be an Iterator
+func (s *Unit) Iterate(prefix []byte, start []byte, end []byte, handler func(key []byte, data []byte) bool) error {
+	iterator, ok := s.persister.(Iterator)
+	if !ok {
+		return errIterationNotSupported
+	}
+
+	// the cached pairs are collected beforehand, so the handler is called without holding the lock
+	cached := s.cachedInRange(prefix, start, end)
+	idx := 0
+	stopped := false
+	err := iterator.Iterate(prefix, start, end, func(key []byte, data []byte) bool {
+		for ; idx < len(cached) && bytes.Compare(cached[idx].key, key) < 0; idx++ {
+			if !handler(cached[idx].key, cached[idx].data) {
+				stopped = true
+				return false
+			}
+		}
+
+		if idx < len(cached) && bytes.Equal(cached[idx].key, key) {
+			data = cached[idx].data
+			idx++
+		}
+
+		stopped = !handler(key, data)
+		return !stopped
+	})
+	if err != nil || stopped {
+		return err
+	}
+
+	for ; idx < len(cached); idx++ {
+		if !handler(cached[idx].key, cached[idx].data) {
+			break
+		}
+	}
+
+	return nil
+}
+
+type keyData struct {
+	key  []byte
+	data []byte
+}
+
+// cachedInRange returns the cached (key, data) pairs whose key starts with prefix and is within [start, end), sorted
+// ascending by key
+func (s *Unit) cachedInRange(prefix []byte, start []byte, end []byte) []keyData {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return pairsInRange(s.cacher, prefix, start, end)
+}
+
+// pairsInRange returns the (key, data) pairs of the cacher whose key starts with prefix and is within [start, end),
+// sorted ascending by key
+func pairsInRange(cacher Cacher, prefix []byte, start []byte, end []byte) []keyData {
+	cached := make([]keyData, 0)
+	for _, key := range cacher.Keys() {
+		if !bytes.HasPrefix(key, prefix) || bytes.Compare(key, start) < 0 {
+			continue
+		}
+		if end != nil && bytes.Compare(key, end) >= 0 {
+			continue
+		}
+
+		v, ok := cacher.Peek(key)
+		if !ok {
+			continue
+		}
+
+		data, ok := v.([]byte)
+		if !ok {
+			continue
+		}
+
+		cached = append(cached, keyData{key: key, data: data})
+	}
+
+	sort.Slice(cached, func(i, j int) bool {
+		return bytes.Compare(cached[i].key, cached[j].key) < 0
+	})
+
+	return cached
+}
+
 // Remove removes the data associated to the given key from both cache and persistance medium
 func (s *Unit) Remove(key []byte) error {
 	s.lock.Lock()
@@ -223,6 +363,15 @@ func (s *Unit) ClearCache() {
 	s.cacher.Clear()
 }
 
+// Close closes the db, keeping its data
+func (s *Unit) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.cacher.Clear()
+	return s.persister.Close()
+}
+
 // DestroyUnit cleans up the bloom filter, the cache, and the db
 func (s *Unit) DestroyUnit() error {
 	s.lock.Lock()
@@ -356,6 +505,8 @@ func NewDB(dbType DBType, path string) (Persister, error) {
 	switch dbType {
 	case LvlDB:
 		db, err = leveldb.NewDB(path)
+	case LvlDBReadOnly:
+		db, err = leveldb.NewReadOnlyDB(path)
 	case BadgerDB:
 		db, err = badgerdb.NewDB(path)
 	case BoltDB:
@@ -158,6 +158,26 @@ func TestPutNotPresentCacheWithNilBloomFilter(t *testing.T) {
 	assert.Nil(t, err, "expected to find key %s, but not found", key)
 }
 
+func TestPutBatchNotPresentCache(t *testing.T) {
+	data := map[string][]byte{
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+	}
+	s := initStorageUnitWithBloomFilter(t, 10)
+	err := s.PutBatch(data)
+
+	assert.Nil(t, err, "no error expected but got %s", err)
+
+	s.ClearCache()
+
+	for key, val := range data {
+		v, err := s.Get([]byte(key))
+
+		assert.Nil(t, err, "expected to find key %s, but not found", key)
+		assert.Equal(t, val, v)
+	}
+}
+
 func TestPutPresent(t *testing.T) {
 	key, val := []byte("key2"), []byte("value2")
 	s := initStorageUnitWithBloomFilter(t, 10)
@@ -514,6 +534,12 @@ func TestDestroyUnitWithNilBloomFilterNoError(t *testing.T) {
 	assert.Nil(t, err, "no error expected, but got %s", err)
 }
 
+func TestCloseUnitNoError(t *testing.T) {
+	s := initStorageUnitWithNilBloomFilter(t, 10)
+	err := s.Close()
+	assert.Nil(t, err, "no error expected, but got %s", err)
+}
+
 func TestCreateCacheFromConfWrongType(t *testing.T) {
 
 	cacher, err := storage.NewCache("NotLRU", 100, 1)
@@ -565,6 +591,27 @@ func TestCreateDBFromConfLvlDBOk(t *testing.T) {
 	assert.Nil(t, err, "no error expected destroying the persister")
 }
 
+func TestCreateDBFromConfLvlDBReadOnlyMissingDBShouldErr(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "leveldb_temp")
+	persister, err := storage.NewDB(storage.LvlDBReadOnly, filepath.Join(dir, "missing"))
+	assert.NotNil(t, err, "error expected")
+	assert.Nil(t, persister, "persister expected to be nil, but got %s", persister)
+}
+
+func TestCreateDBFromConfLvlDBReadOnlyOk(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "leveldb_temp")
+	persister, _ := storage.NewDB(storage.LvlDB, dir)
+	_ = persister.Close()
+
+	persister, err := storage.NewDB(storage.LvlDBReadOnly, dir)
+	assert.Nil(t, err, "no error expected")
+	assert.NotNil(t, persister, "valid persister expected but got nil")
+
+	err = persister.Close()
+	assert.Nil(t, err, "no error expected closing the persister")
+	_ = os.RemoveAll(dir)
+}
+
 func TestCreateDBFromConfBoltDBOk(t *testing.T) {
 	dir, err := ioutil.TempDir("", "leveldb_temp")
 	persister, err := storage.NewDB(storage.BoltDB, dir)
@@ -1050,3 +1097,40 @@ func BenchmarkStorageUnit_GetNotPresentWithBloomFilter(b *testing.B) {
 		logError(err)
 	}
 }
+
+func TestIterateShouldMergeCachedAndPersistedKeysInOrder(t *testing.T) {
+	mdb, _ := memorydb.New()
+	cache, _ := lrucache.NewCache(10)
+	s, _ := storage.NewStorageUnit(cache, mdb)
+
+	_ = s.Put([]byte("key3"), []byte("value3"))
+	_ = s.Put([]byte("key1"), []byte("value1"))
+	_ = mdb.Put([]byte("key2"), []byte("value2"))
+	_ = mdb.Put([]byte("other"), []byte("other value"))
+	// cached only, as if not yet persisted
+	cache.Put([]byte("key4"), []byte("value4"))
+
+	walked := make([]string, 0)
+	err := s.Iterate([]byte("key"), nil, nil, func(key []byte, data []byte) bool {
+		assert.Equal(t, "value"+string(key[len("key"):]), string(data))
+		walked = append(walked, string(key))
+		return true
+	})
+
+	assert.Nil(t, err, "no error expected but got %s", err)
+	assert.Equal(t, []string{"key1", "key2", "key3", "key4"}, walked)
+}
+
+func TestIterateNotIterablePersisterShouldErr(t *testing.T) {
+	mdb, _ := memorydb.New()
+	cache, _ := lrucache.NewCache(10)
+	// hides the iteration support of the wrapped persister
+	persister := struct{ storage.Persister }{mdb}
+	s, _ := storage.NewStorageUnit(cache, persister)
+
+	err := s.Iterate(nil, nil, nil, func(key []byte, data []byte) bool {
+		return true
+	})
+
+	assert.NotNil(t, err, "expected failure")
+}